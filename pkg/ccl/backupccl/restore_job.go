@@ -1113,12 +1113,15 @@ func createImportingDescriptors(
 				}
 			}
 
-			// Allocate no schedule to the row-level TTL.
+			// Allocate no schedule to the row-level TTL or rolling partitioning.
 			// This will be re-written when the descriptor is published.
 			for _, table := range mutableTables {
 				if table.HasRowLevelTTL() {
 					table.RowLevelTTL.ScheduleID = 0
 				}
+				if table.HasRollingPartitioning() {
+					table.RollingPartitioning.ScheduleID = 0
+				}
 			}
 			descsCol := txn.Descriptors()
 			// Write the new descriptors which are set in the OFFLINE state.
@@ -2269,6 +2272,20 @@ func (r *restoreResumer) publishDescriptors(
 			}
 			mutTable.RowLevelTTL.ScheduleID = j.ScheduleID()
 		}
+		// Likewise for the rolling partition schedule.
+		if mutTable.HasRollingPartitioning() {
+			j, err := sql.CreateRollingPartitionScheduledJob(
+				ctx,
+				jobsKnobs,
+				jobs.ScheduledJobTxn(txn),
+				user,
+				mutTable,
+			)
+			if err != nil {
+				return err
+			}
+			mutTable.RollingPartitioning.ScheduleID = j.ScheduleID()
+		}
 		newTables = append(newTables, mutTable.TableDesc())
 
 		// Convert any mutations that were in progress on the table descriptor
@@ -2575,6 +2592,14 @@ func (r *restoreResumer) dropDescriptors(
 				}
 			}
 		}
+		if tableToDrop.HasRollingPartitioning() {
+			scheduleID := tableToDrop.RollingPartitioning.ScheduleID
+			if scheduleID != 0 {
+				if err := scheduledJobs.DeleteByID(ctx, env, scheduleID); err != nil {
+					return err
+				}
+			}
+		}
 
 		// Arrange for fast GC of table data.
 		//
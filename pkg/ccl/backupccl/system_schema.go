@@ -646,31 +646,33 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	},
 	systemschema.ScheduledJobsTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // Desc IDs in some rows.
-		// Some rows, specifically those which are schedules for row-ttl, have IDs
+		// Some rows, specifically those which are schedules for row-ttl and
+		// rolling partitioning, have IDs
 		// baked into their values, making the restored rows invalid. Rewriting them
 		// would be tricky since the ID is in a binary proto field, but we already
 		// have code to synthesize new schedules from the table being restored that
 		// runs during descriptor creation. We can leverage these by leaving the
 		// synthesized schedule rows in the real schedule table when we otherwise
-		// clean it out, and skipping TTL rows when we copy from the restored
+		// clean it out, and skipping those rows when we copy from the restored
 		// schedule table.
 		customRestoreFunc: func(ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, _, tempTableName string) error {
 			execType := tree.ScheduledRowLevelTTLExecutor.InternalName()
+			rollingExecType := tree.ScheduledRollingPartitionExecutor.InternalName()
 
-			const deleteQuery = "DELETE FROM system.scheduled_jobs WHERE executor_type <> $1"
+			const deleteQuery = "DELETE FROM system.scheduled_jobs WHERE executor_type NOT IN ($1, $2)"
 			if _, err := txn.Exec(
-				ctx, "restore-scheduled_jobs-delete", txn.KV(), deleteQuery, execType,
+				ctx, "restore-scheduled_jobs-delete", txn.KV(), deleteQuery, execType, rollingExecType,
 			); err != nil {
 				return errors.Wrapf(err, "deleting existing scheduled_jobs")
 			}
 
 			restoreQuery := fmt.Sprintf(
-				"INSERT INTO system.scheduled_jobs (SELECT * FROM %s WHERE executor_type <> $1);",
+				"INSERT INTO system.scheduled_jobs (SELECT * FROM %s WHERE executor_type NOT IN ($1, $2));",
 				tempTableName,
 			)
 
 			if _, err := txn.Exec(
-				ctx, "restore-scheduled_jobs-insert", txn.KV(), restoreQuery, execType,
+				ctx, "restore-scheduled_jobs-insert", txn.KV(), restoreQuery, execType, rollingExecType,
 			); err != nil {
 				return err
 			}
@@ -818,6 +820,12 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	systemschema.RegionLivenessTable.GetName(): {
 		shouldIncludeInClusterBackup: optOutOfClusterBackup,
 	},
+	systemschema.ReplicationSlotsTable.GetName(): {
+		shouldIncludeInClusterBackup: optOutOfClusterBackup,
+	},
+	systemschema.NotificationsTable.GetName(): {
+		shouldIncludeInClusterBackup: optOutOfClusterBackup,
+	},
 }
 
 func rekeySystemTable(
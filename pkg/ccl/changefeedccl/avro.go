@@ -583,6 +583,69 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDIPAddrFromINetString(x.(string))
 			},
 		)
+	case types.CIDRFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DCIDR).IPAddr.CIDRString(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDCIDR(x.(string))
+			},
+		)
+	case types.MacAddrFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DMacAddr).MacAddr.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDMacAddr(x.(string))
+			},
+		)
+	case types.MacAddr8Family:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DMacAddr8).MacAddr8.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDMacAddr8(x.(string))
+			},
+		)
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DGeometric).Shape.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDGeometric(typ, x.(string))
+			},
+		)
+	case types.RangeFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtPgwireText), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseDRangeFromString(nil /* ctx */, x.(string), typ)
+				return d, err
+			},
+		)
+	case types.MultiRangeFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtPgwireText), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseDMultiRangeFromString(nil /* ctx */, x.(string), typ)
+				return d, err
+			},
+		)
 	case types.JsonFamily:
 		setNullable(
 			avroSchemaString,
@@ -593,6 +656,16 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDJSON(x.(string))
 			},
 		)
+	case types.JsonpathFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DJsonpath).Path.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDJsonpath(x.(string))
+			},
+		)
 	case types.TSQueryFamily:
 		setNullable(
 			avroSchemaString,
@@ -455,6 +455,7 @@ func TestAvroSchema(t *testing.T) {
 			`BOOL[]`:            `["null",{"type":"array","items":["null","boolean"]}]`,
 			`BOX2D`:             `["null","string"]`,
 			`BYTES`:             `["null","bytes"]`,
+			`CIDR`:              `["null","string"]`,
 			`DATE`:              `["null",{"type":"int","logicalType":"date"}]`,
 			`FLOAT8`:            `["null","double"]`,
 			`GEOGRAPHY`:         `["null","bytes"]`,
@@ -463,6 +464,8 @@ func TestAvroSchema(t *testing.T) {
 			`INT8`:              `["null","long"]`,
 			`INTERVAL`:          `["null","string"]`,
 			`JSONB`:             `["null","string"]`,
+			`MACADDR`:           `["null","string"]`,
+			`MACADDR8`:          `["null","string"]`,
 			`PG_LSN`:            `["null","string"]`,
 			`STRING`:            `["null","string"]`,
 			`STRING COLLATE fr`: `["null","string"]`,
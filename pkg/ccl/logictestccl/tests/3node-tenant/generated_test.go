@@ -121,6 +121,13 @@ func TestLogic_tmp(t *testing.T) {
 	logictest.RunLogicTests(t, serverArgs, configIdx, glob)
 }
 
+func TestTenantLogic_advisory_lock(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "advisory_lock")
+}
+
 func TestTenantLogic_aggregate(
 	t *testing.T,
 ) {
@@ -142,6 +149,13 @@ func TestTenantLogic_alter_column_type(
 	runLogicTest(t, "alter_column_type")
 }
 
+func TestTenantLogic_alter_constraint(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "alter_constraint")
+}
+
 func TestTenantLogic_alter_database_convert_to_schema(
 	t *testing.T,
 ) {
@@ -331,6 +345,13 @@ func TestTenantLogic_bit(
 	runLogicTest(t, "bit")
 }
 
+func TestTenantLogic_brin(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "brin")
+}
+
 func TestTenantLogic_builtin_function(
 	t *testing.T,
 ) {
@@ -373,6 +394,13 @@ func TestTenantLogic_check_constraints(
 	runLogicTest(t, "check_constraints")
 }
 
+func TestTenantLogic_cidr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "cidr")
+}
+
 func TestTenantLogic_cluster_settings(
 	t *testing.T,
 ) {
@@ -569,6 +597,13 @@ func TestTenantLogic_default(
 	runLogicTest(t, "default")
 }
 
+func TestTenantLogic_deferrable_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_constraints")
+}
+
 func TestTenantLogic_delete(
 	t *testing.T,
 ) {
@@ -660,6 +695,13 @@ func TestTenantLogic_distsql_tenant(
 	runLogicTest(t, "distsql_tenant")
 }
 
+func TestTenantLogic_domains(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domains")
+}
+
 func TestTenantLogic_drop_database(
 	t *testing.T,
 ) {
@@ -779,6 +821,13 @@ func TestTenantLogic_exclude_data_from_backup(
 	runLogicTest(t, "exclude_data_from_backup")
 }
 
+func TestTenantLogic_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints")
+}
+
 func TestTenantLogic_experimental_distsql_planning(
 	t *testing.T,
 ) {
@@ -828,6 +877,13 @@ func TestTenantLogic_fk(
 	runLogicTest(t, "fk")
 }
 
+func TestTenantLogic_fk_match_partial(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "fk_match_partial")
+}
+
 func TestTenantLogic_fk_read_committed(
 	t *testing.T,
 ) {
@@ -863,6 +919,13 @@ func TestTenantLogic_fuzzystrmatch(
 	runLogicTest(t, "fuzzystrmatch")
 }
 
+func TestTenantLogic_geometric(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "geometric")
+}
+
 func TestTenantLogic_geospatial(
 	t *testing.T,
 ) {
@@ -954,6 +1017,13 @@ func TestTenantLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestTenantLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestTenantLogic_hash_join(
 	t *testing.T,
 ) {
@@ -1129,6 +1199,13 @@ func TestTenantLogic_json_index(
 	runLogicTest(t, "json_index")
 }
 
+func TestTenantLogic_jsonpath(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "jsonpath")
+}
+
 func TestTenantLogic_kv_builtin_functions_tenant(
 	t *testing.T,
 ) {
@@ -1143,6 +1220,13 @@ func TestTenantLogic_limit(
 	runLogicTest(t, "limit")
 }
 
+func TestTenantLogic_listen_notify(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "listen_notify")
+}
+
 func TestTenantLogic_lock_timeout(
 	t *testing.T,
 ) {
@@ -1164,6 +1248,13 @@ func TestTenantLogic_lookup_join_spans(
 	runLogicTest(t, "lookup_join_spans")
 }
 
+func TestTenantLogic_macaddr(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "macaddr")
+}
+
 func TestTenantLogic_manual_retry(
 	t *testing.T,
 ) {
@@ -1178,6 +1269,13 @@ func TestTenantLogic_materialized_view(
 	runLogicTest(t, "materialized_view")
 }
 
+func TestTenantLogic_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "merge")
+}
+
 func TestTenantLogic_merge_join(
 	t *testing.T,
 ) {
@@ -1437,6 +1535,13 @@ func TestTenantLogic_raise(
 	runLogicTest(t, "raise")
 }
 
+func TestTenantLogic_range_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_types")
+}
+
 func TestTenantLogic_read_committed(
 	t *testing.T,
 ) {
@@ -1549,6 +1654,20 @@ func TestTenantLogic_role(
 	runLogicTest(t, "role")
 }
 
+func TestTenantLogic_rolling_partition(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "rolling_partition")
+}
+
+func TestTenantLogic_row_level_security(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "row_level_security")
+}
+
 func TestTenantLogic_row_level_ttl(
 	t *testing.T,
 ) {
@@ -1997,6 +2116,13 @@ func TestTenantLogic_table(
 	runLogicTest(t, "table")
 }
 
+func TestTenantLogic_tablesample(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "tablesample")
+}
+
 func TestTenantLogic_target_names(
 	t *testing.T,
 ) {
@@ -2060,6 +2186,13 @@ func TestTenantLogic_timetz(
 	runLogicTest(t, "timetz")
 }
 
+func TestTenantLogic_triggers(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "triggers")
+}
+
 func TestTenantLogic_trigram_builtins(
 	t *testing.T,
 ) {
@@ -2151,6 +2284,13 @@ func TestTenantLogic_udf(
 	runLogicTest(t, "udf")
 }
 
+func TestTenantLogic_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate")
+}
+
 func TestTenantLogic_udf_delete(
 	t *testing.T,
 ) {
@@ -2214,6 +2354,13 @@ func TestTenantLogic_udf_plpgsql(
 	runLogicTest(t, "udf_plpgsql")
 }
 
+func TestTenantLogic_udf_plpgsql_cursor(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_plpgsql_cursor")
+}
+
 func TestTenantLogic_udf_prepare(
 	t *testing.T,
 ) {
@@ -2249,6 +2396,13 @@ func TestTenantLogic_udf_schema_change(
 	runLogicTest(t, "udf_schema_change")
 }
 
+func TestTenantLogic_udf_security(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_security")
+}
+
 func TestTenantLogic_udf_setof(
 	t *testing.T,
 ) {
@@ -0,0 +1,128 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package partitionccl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobstest"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRollingPartitionJob verifies that the rolling partition schedule
+// maintains the window of partitions of a table, drops expired partitions
+// along with their rows, and carries zone configurations over to new
+// partitions.
+func TestRollingPartitionJob(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	env := jobstest.NewJobSchedulerTestEnv(
+		jobstest.UseSystemTables,
+		time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC),
+		tree.ScheduledRollingPartitionExecutor,
+	)
+	var executeSchedules func() error
+	var registry *jobs.Registry
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsForStuffThatShouldWorkWithSecondaryTenantsButDoesntYet(109391),
+		Knobs: base.TestingKnobs{
+			JobsTestingKnobs: &jobs.TestingKnobs{
+				JobSchedulerEnv: env,
+				TakeOverJobsScheduling: func(fn func(ctx context.Context, maxSchedules int64) error) {
+					executeSchedules = func() error {
+						defer registry.TestingNudgeAdoptionQueue()
+						return fn(ctx, 0 /* allSchedules */)
+					}
+				},
+			},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+	registry = s.ApplicationLayer().JobRegistry().(*jobs.Registry)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE t (
+  ts TIMESTAMPTZ,
+  id INT,
+  PRIMARY KEY (ts, id)
+) WITH (
+  rolling_partition_interval = '1 day',
+  rolling_partition_lookahead = 2,
+  rolling_partition_retention = '2 days'
+)`)
+
+	numSucceeded := 0
+	runJob := func(now time.Time) {
+		t.Helper()
+		env.SetTime(now)
+		require.NoError(t, executeSchedules())
+		numSucceeded++
+		testutils.SucceedsSoon(t, func() error {
+			registry.TestingNudgeAdoptionQueue()
+			var succeeded int
+			var failed []string
+			sqlDB.QueryRow(t, `SELECT count(*) FROM [SHOW JOBS] WHERE job_type = 'ROLLING PARTITION' AND status = 'succeeded'`).Scan(&succeeded)
+			for _, row := range sqlDB.QueryStr(t, `SELECT error FROM [SHOW JOBS] WHERE job_type = 'ROLLING PARTITION' AND status = 'failed'`) {
+				failed = append(failed, row[0])
+			}
+			if len(failed) > 0 {
+				t.Fatalf("rolling partition job failed: %v", failed)
+			}
+			if succeeded != numSucceeded {
+				return errors.Newf("expected %d succeeded jobs, found %d", numSucceeded, succeeded)
+			}
+			return nil
+		})
+	}
+	partitionsQuery := `SELECT partition_name FROM [SHOW PARTITIONS FROM TABLE t] ORDER BY partition_name`
+
+	runJob(time.Date(2023, 3, 14, 12, 0, 0, 0, time.UTC))
+	sqlDB.CheckQueryResults(t, partitionsQuery, [][]string{
+		{"p20230314"}, {"p20230315"}, {"p20230316"},
+	})
+
+	sqlDB.Exec(t, `ALTER PARTITION p20230316 OF INDEX t@t_pkey CONFIGURE ZONE USING gc.ttlseconds = 12345`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES
+  ('2023-03-14 01:00+00', 1), ('2023-03-14 23:00+00', 2),
+  ('2023-03-15 01:00+00', 3), ('2023-03-16 01:00+00', 4)`)
+
+	runJob(time.Date(2023, 3, 17, 12, 0, 0, 0, time.UTC))
+	sqlDB.CheckQueryResults(t, partitionsQuery, [][]string{
+		{"p20230315"}, {"p20230316"}, {"p20230317"}, {"p20230318"}, {"p20230319"},
+	})
+	sqlDB.CheckQueryResults(t, `SELECT id FROM t ORDER BY id`, [][]string{{"3"}, {"4"}})
+	sqlDB.CheckQueryResults(t, `
+SELECT partition_name FROM crdb_internal.zones
+WHERE table_name = 't' AND raw_config_sql LIKE '%gc.ttlseconds = 12345%'
+ORDER BY partition_name`, [][]string{
+		{"p20230316"}, {"p20230317"}, {"p20230318"}, {"p20230319"},
+	})
+
+	// Removing the rolling partitioning removes the schedule but keeps the
+	// partitions.
+	sqlDB.Exec(t, `ALTER TABLE t RESET (rolling_partition_interval)`)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM system.scheduled_jobs WHERE executor_type = 'scheduled-rolling-partition-executor'`,
+		[][]string{{"0"}},
+	)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM [SHOW PARTITIONS FROM TABLE t]`, [][]string{{"5"}})
+}
@@ -566,6 +566,37 @@ const (
 	// push request and leaving the push outcome to the server-side logic.
 	V23_2_RemoveLockTableWaiterTouchPush
 
+	// V23_2_ReplicationSlotsTable adds the system.replication_slots table,
+	// which stores the logical replication slots created over the replication
+	// protocol.
+	V23_2_ReplicationSlotsTable
+
+	// V23_2_NotificationsTable adds the system.notifications table, through
+	// which the notifications sent with NOTIFY are delivered to the listeners
+	// connected to other nodes.
+	V23_2_NotificationsTable
+
+	// V23_2_SharedLocks enables SELECT ... FOR SHARE and FOR KEY SHARE to
+	// acquire Shared locks on the keys they read, which are not understood by
+	// the nodes running an older binary. Until it is active, these statements
+	// perform non-locking reads.
+	V23_2_SharedLocks
+
+	// V23_2_JsonpathType enables the use of the JSONPATH type as a column type.
+	V23_2_JsonpathType
+
+	// V23_2_NetworkAddressTypes enables the use of the CIDR, MACADDR and
+	// MACADDR8 types as column types.
+	V23_2_NetworkAddressTypes
+
+	// V23_2_GeometricTypes enables the use of the POINT, LINE, LSEG, BOX, PATH,
+	// POLYGON and CIRCLE types as column types.
+	V23_2_GeometricTypes
+
+	// V23_2_RangeTypes enables the use of range and multirange types as column
+	// types.
+	V23_2_RangeTypes
+
 	// *************************************************
 	// Step (1) Add new versions here.
 	// Do not add new versions to a patch release.
@@ -987,6 +1018,34 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V23_2_RemoveLockTableWaiterTouchPush,
 		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 22},
 	},
+	{
+		Key:     V23_2_ReplicationSlotsTable,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 24},
+	},
+	{
+		Key:     V23_2_NotificationsTable,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 26},
+	},
+	{
+		Key:     V23_2_SharedLocks,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 28},
+	},
+	{
+		Key:     V23_2_JsonpathType,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 30},
+	},
+	{
+		Key:     V23_2_NetworkAddressTypes,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 32},
+	},
+	{
+		Key:     V23_2_GeometricTypes,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 34},
+	},
+	{
+		Key:     V23_2_RangeTypes,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 36},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
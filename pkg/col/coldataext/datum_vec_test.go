@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/col/typeconv"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -92,3 +93,36 @@ func TestDatumVec(t *testing.T) {
 	require.True(t, dv1.Get(1).(tree.Datum).Compare(evalCtx, tree.DNull) == 0)
 	require.True(t, dv1.Get(2).(tree.Datum).Compare(evalCtx, tree.NewDJSON(json.FromString("string2"))) == 0)
 }
+
+// TestDatumVecNetworkAddressTypes checks that the MACADDR, MACADDR8 and CIDR
+// types, which have no native physical representation, are stored in datum
+// vectors whose values survive the marshaling used when spilling to disk.
+func TestDatumVecNetworkAddressTypes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	evalCtx := &eval.Context{}
+	factory := NewExtendedColumnFactory(evalCtx)
+	for _, tc := range []struct {
+		typ *types.T
+		str string
+	}{
+		{types.MacAddr, "08:00:2b:01:02:03"},
+		{types.MacAddr8, "08:00:2b:01:02:03:04:05"},
+		{types.CIDR, "10.1.0.0/16"},
+	} {
+		t.Run(tc.typ.Name(), func(t *testing.T) {
+			require.Equal(t, typeconv.DatumVecCanonicalTypeFamily,
+				typeconv.TypeFamilyToCanonicalTypeFamily(tc.typ.Family()))
+			d, _, err := tree.ParseAndRequireString(tc.typ, tc.str, nil /* ctx */)
+			require.NoError(t, err)
+
+			dv, ok := factory.MakeColumn(tc.typ, 2 /* n */).(coldata.DatumVec)
+			require.True(t, ok)
+			dv.Set(0 /* i */, d)
+			b, err := dv.MarshalAt(nil /* appendTo */, 0 /* i */)
+			require.NoError(t, err)
+			require.NoError(t, dv.UnmarshalTo(1 /* i */, b))
+			require.True(t, dv.Get(1).(tree.Datum).Compare(evalCtx, d) == 0)
+		})
+	}
+}
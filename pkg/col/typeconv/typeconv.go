@@ -59,6 +59,10 @@ func TypeFamilyToCanonicalTypeFamily(family types.Family) types.Family {
 	default:
 		// TODO(yuzefovich): consider adding native support for
 		// types.UnknownFamily.
+		//
+		// Note that MACADDR and MACADDR8 could be represented by int64s, but
+		// not without breaking the unsigned ordering of their values, so they
+		// are handled by using tree.Datums like CIDR.
 		return DatumVecCanonicalTypeFamily
 	}
 }
@@ -25,6 +25,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:alter_database_to_schema_stmt.bnf",
     "//docs/generated/sql/bnf:alter_ddl_stmt.bnf",
     "//docs/generated/sql/bnf:alter_default_privileges_stmt.bnf",
+    "//docs/generated/sql/bnf:alter_domain_stmt.bnf",
     "//docs/generated/sql/bnf:alter_func_dep_extension_stmt.bnf",
     "//docs/generated/sql/bnf:alter_func_options_stmt.bnf",
     "//docs/generated/sql/bnf:alter_func_owner_stmt.bnf",
@@ -70,6 +71,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:backup_options.bnf",
     "//docs/generated/sql/bnf:begin_stmt.bnf",
     "//docs/generated/sql/bnf:begin_transaction.bnf",
+    "//docs/generated/sql/bnf:call_stmt.bnf",
     "//docs/generated/sql/bnf:cancel_all_jobs_stmt.bnf",
     "//docs/generated/sql/bnf:cancel_job.bnf",
     "//docs/generated/sql/bnf:cancel_query.bnf",
@@ -89,12 +91,14 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:create_changefeed_stmt.bnf",
     "//docs/generated/sql/bnf:create_database_stmt.bnf",
     "//docs/generated/sql/bnf:create_ddl_stmt.bnf",
+    "//docs/generated/sql/bnf:create_domain_stmt.bnf",
     "//docs/generated/sql/bnf:create_extension_stmt.bnf",
     "//docs/generated/sql/bnf:create_external_connection_stmt.bnf",
     "//docs/generated/sql/bnf:create_func_stmt.bnf",
     "//docs/generated/sql/bnf:create_index_stmt.bnf",
     "//docs/generated/sql/bnf:create_index_with_storage_param.bnf",
     "//docs/generated/sql/bnf:create_inverted_index_stmt.bnf",
+    "//docs/generated/sql/bnf:create_policy_stmt.bnf",
     "//docs/generated/sql/bnf:create_proc_stmt.bnf",
     "//docs/generated/sql/bnf:create_role_stmt.bnf",
     "//docs/generated/sql/bnf:create_schedule_for_backup_stmt.bnf",
@@ -107,6 +111,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:create_table_as_stmt.bnf",
     "//docs/generated/sql/bnf:create_table_stmt.bnf",
     "//docs/generated/sql/bnf:create_table_with_storage_param.bnf",
+    "//docs/generated/sql/bnf:create_trigger_stmt.bnf",
     "//docs/generated/sql/bnf:create_type.bnf",
     "//docs/generated/sql/bnf:create_view_stmt.bnf",
     "//docs/generated/sql/bnf:deallocate_stmt.bnf",
@@ -118,16 +123,20 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:drop_constraint.bnf",
     "//docs/generated/sql/bnf:drop_database.bnf",
     "//docs/generated/sql/bnf:drop_ddl_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_domain_stmt.bnf",
     "//docs/generated/sql/bnf:drop_external_connection_stmt.bnf",
     "//docs/generated/sql/bnf:drop_func_stmt.bnf",
     "//docs/generated/sql/bnf:drop_index.bnf",
     "//docs/generated/sql/bnf:drop_owned_by_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_policy_stmt.bnf",
+    "//docs/generated/sql/bnf:drop_proc_stmt.bnf",
     "//docs/generated/sql/bnf:drop_role_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schedule_stmt.bnf",
     "//docs/generated/sql/bnf:drop_schema.bnf",
     "//docs/generated/sql/bnf:drop_sequence_stmt.bnf",
     "//docs/generated/sql/bnf:drop_stmt.bnf",
     "//docs/generated/sql/bnf:drop_table.bnf",
+    "//docs/generated/sql/bnf:drop_trigger_stmt.bnf",
     "//docs/generated/sql/bnf:drop_type.bnf",
     "//docs/generated/sql/bnf:drop_view.bnf",
     "//docs/generated/sql/bnf:execute_stmt.bnf",
@@ -156,8 +165,11 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:legacy_transaction_stmt.bnf",
     "//docs/generated/sql/bnf:like_table_option_list.bnf",
     "//docs/generated/sql/bnf:limit_clause.bnf",
+    "//docs/generated/sql/bnf:listen_stmt.bnf",
+    "//docs/generated/sql/bnf:merge_stmt.bnf",
     "//docs/generated/sql/bnf:move_cursor_stmt.bnf",
     "//docs/generated/sql/bnf:not_null_column_level.bnf",
+    "//docs/generated/sql/bnf:notify_stmt.bnf",
     "//docs/generated/sql/bnf:offset_clause.bnf",
     "//docs/generated/sql/bnf:on_conflict.bnf",
     "//docs/generated/sql/bnf:opt_frame_clause.bnf",
@@ -202,6 +214,7 @@ BNF_SRCS = [
     "//docs/generated/sql/bnf:select_clause.bnf",
     "//docs/generated/sql/bnf:select_stmt.bnf",
     "//docs/generated/sql/bnf:set_cluster_setting.bnf",
+    "//docs/generated/sql/bnf:set_constraints_stmt.bnf",
     "//docs/generated/sql/bnf:set_csetting_stmt.bnf",
     "//docs/generated/sql/bnf:set_exprs_internal.bnf",
     "//docs/generated/sql/bnf:set_local_stmt.bnf",
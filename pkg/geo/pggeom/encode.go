@@ -0,0 +1,152 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pggeom
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// AppendBinary appends the binary representation of the Shape to b. The
+// representation is the same one that postgres uses for the binary wire
+// format: a sequence of big endian float8 coordinates, preceded by the number
+// of points for paths and polygons, and by a byte that is 1 for closed paths
+// and 0 for open paths.
+func AppendBinary(b []byte, s Shape) []byte {
+	var buf [8]float64
+	fs := buf[:0]
+	switch t := s.(type) {
+	case Path:
+		closed := byte(0)
+		if t.Closed {
+			closed = 1
+		}
+		b = append(b, closed)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t.Points)))
+		fs = flatCoords(t.Points)
+	case Polygon:
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t.Points)))
+		fs = t.appendFloats(fs)
+	default:
+		fs = s.appendFloats(fs)
+	}
+	for _, f := range fs {
+		b = binary.BigEndian.AppendUint64(b, math.Float64bits(f))
+	}
+	return b
+}
+
+// DecodeBinary decodes the binary representation of a Shape of the given Kind,
+// as produced by AppendBinary.
+func DecodeBinary(k Kind, b []byte) (Shape, error) {
+	d := decoder{b: b}
+	var s Shape
+	switch k {
+	case PointKind:
+		s = d.point()
+	case LSegKind:
+		s = LSeg{P: [2]Point{d.point(), d.point()}}
+	case BoxKind:
+		s = MakeBox(d.point(), d.point())
+	case PathKind:
+		closed := d.byte()
+		s = Path{Points: d.points(), Closed: closed != 0}
+	case PolygonKind:
+		pts := d.points()
+		if d.err == nil && len(pts) == 0 {
+			d.err = errors.New("invalid number of points in external \"polygon\" value")
+		}
+		if d.err == nil {
+			s = MakePolygon(pts)
+		}
+	case LineKind:
+		l := Line{A: d.float(), B: d.float(), C: d.float()}
+		if d.err == nil && fpZero(l.A) && fpZero(l.B) {
+			d.err = errors.New("invalid line specification: A and B cannot both be zero")
+		}
+		s = l
+	case CircleKind:
+		c := Circle{Center: d.point(), Radius: d.float()}
+		if d.err == nil && c.Radius < 0 {
+			d.err = errors.New("invalid radius in external \"circle\" value")
+		}
+		s = c
+	default:
+		return nil, errors.AssertionFailedf("unknown geometric kind %d", k)
+	}
+	if d.err == nil && len(d.b) != 0 {
+		d.err = errors.Newf("%d trailing bytes in %s value", len(d.b), k)
+	}
+	if d.err != nil {
+		return nil, pgerror.WithCandidateCode(d.err, pgcode.InvalidBinaryRepresentation)
+	}
+	return s, nil
+}
+
+// decoder reads the fields of a binary Shape, recording the first error.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) need(n int) bool {
+	if d.err != nil {
+		return false
+	}
+	if len(d.b) < n {
+		d.err = errors.New("insufficient bytes in geometric value")
+		return false
+	}
+	return true
+}
+
+func (d *decoder) byte() byte {
+	if !d.need(1) {
+		return 0
+	}
+	c := d.b[0]
+	d.b = d.b[1:]
+	return c
+}
+
+func (d *decoder) float() float64 {
+	if !d.need(8) {
+		return 0
+	}
+	f := math.Float64frombits(binary.BigEndian.Uint64(d.b))
+	d.b = d.b[8:]
+	return f
+}
+
+func (d *decoder) point() Point {
+	return Point{X: d.float(), Y: d.float()}
+}
+
+func (d *decoder) points() []Point {
+	if !d.need(4) {
+		return nil
+	}
+	n := int(binary.BigEndian.Uint32(d.b))
+	d.b = d.b[4:]
+	if n < 0 || n > len(d.b)/16 {
+		d.err = errors.Newf("invalid number of points %d in geometric value", n)
+		return nil
+	}
+	pts := make([]Point, n)
+	for i := range pts {
+		pts[i] = d.point()
+	}
+	return pts
+}
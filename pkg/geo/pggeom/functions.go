@@ -0,0 +1,193 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pggeom
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// Convert converts the Shape to the given Kind, the same way as the casts and
+// the conversion functions of postgres do.
+func Convert(s Shape, k Kind) (Shape, error) {
+	if s.Kind() == k {
+		return s, nil
+	}
+	switch k {
+	case PointKind:
+		switch s.(type) {
+		case LSeg, Box, Path, Polygon, Circle:
+			return Center(s)
+		}
+	case LSegKind:
+		if b, ok := s.(Box); ok {
+			// The diagonal of the box.
+			return LSeg{P: [2]Point{b.High, b.Low}}, nil
+		}
+	case BoxKind:
+		switch t := s.(type) {
+		case Point:
+			return Box{High: t, Low: t}, nil
+		case Polygon:
+			return t.BoundBox, nil
+		case Circle:
+			delta := t.Radius / math.Sqrt2
+			return Box{
+				High: Point{X: t.Center.X + delta, Y: t.Center.Y + delta},
+				Low:  Point{X: t.Center.X - delta, Y: t.Center.Y - delta},
+			}, nil
+		}
+	case PathKind:
+		if p, ok := s.(Polygon); ok {
+			return Path{Points: p.Points, Closed: true}, nil
+		}
+	case PolygonKind:
+		switch t := s.(type) {
+		case Box:
+			return MakePolygonFromBox(t), nil
+		case Path:
+			if !t.Closed {
+				return nil, pgerror.New(pgcode.InvalidParameterValue,
+					"open path cannot be converted to polygon")
+			}
+			return MakePolygon(t.Points), nil
+		case Circle:
+			return MakePolygonFromCircle(t, 12)
+		}
+	case CircleKind:
+		switch t := s.(type) {
+		case Box:
+			center, _ := Center(t)
+			return Circle{Center: center.(Point), Radius: dist(center.(Point), t.High)}, nil
+		case Polygon:
+			center := average(t.Points)
+			r := 0.0
+			for _, p := range t.Points {
+				r += dist(p, center)
+			}
+			return Circle{Center: center, Radius: r / float64(len(t.Points))}, nil
+		}
+	}
+	return nil, errors.AssertionFailedf("cannot convert %s to %s", s.Kind(), k)
+}
+
+// MakePolygonFromBox returns the polygon with the corners of the box as
+// vertices.
+func MakePolygonFromBox(b Box) Polygon {
+	return Polygon{
+		Points: []Point{
+			{X: b.Low.X, Y: b.Low.Y},
+			{X: b.Low.X, Y: b.High.Y},
+			{X: b.High.X, Y: b.High.Y},
+			{X: b.High.X, Y: b.Low.Y},
+		},
+		BoundBox: b,
+	}
+}
+
+// MakePolygonFromCircle returns the polygon with n vertices that are evenly
+// spaced on the circle.
+func MakePolygonFromCircle(c Circle, n int) (Polygon, error) {
+	if fpZero(c.Radius) {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"cannot convert circle with radius zero to polygon")
+	}
+	if n < 2 {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"must request at least 2 points")
+	}
+	pts := make([]Point, n)
+	step := 2 * math.Pi / float64(n)
+	for i := range pts {
+		angle := float64(i) * step
+		pts[i] = Point{
+			X: c.Center.X - c.Radius*math.Cos(angle),
+			Y: c.Center.Y + c.Radius*math.Sin(angle),
+		}
+	}
+	return MakePolygon(pts), nil
+}
+
+// Center returns the center of the Shape. The center of a path or polygon is
+// the average of its points.
+func Center(s Shape) (Shape, error) {
+	switch t := s.(type) {
+	case LSeg:
+		return average(t.P[:]), nil
+	case Box:
+		return average([]Point{t.High, t.Low}), nil
+	case Path:
+		return average(t.Points), nil
+	case Polygon:
+		return average(t.Points), nil
+	case Circle:
+		return t.Center, nil
+	}
+	return nil, errors.AssertionFailedf("center is not supported for %s", s.Kind())
+}
+
+// Area returns the area of the Shape, and false if it has no area, which is
+// the case for open paths.
+func Area(s Shape) (float64, bool, error) {
+	switch t := s.(type) {
+	case Box:
+		return (t.High.X - t.Low.X) * (t.High.Y - t.Low.Y), true, nil
+	case Path:
+		if !t.Closed {
+			return 0, false, nil
+		}
+		return shoelaceArea(t.Points), true, nil
+	case Polygon:
+		return shoelaceArea(t.Points), true, nil
+	case Circle:
+		return math.Pi * t.Radius * t.Radius, true, nil
+	}
+	return 0, false, errors.AssertionFailedf("area is not supported for %s", s.Kind())
+}
+
+// Length returns the length of a line segment, or the total length of the
+// segments of a path.
+func Length(s Shape) (float64, error) {
+	switch t := s.(type) {
+	case LSeg:
+		return dist(t.P[0], t.P[1]), nil
+	case Path:
+		var l float64
+		for _, seg := range segments(t.Points, t.Closed) {
+			l += dist(seg.P[0], seg.P[1])
+		}
+		return l, nil
+	}
+	return 0, errors.AssertionFailedf("length is not supported for %s", s.Kind())
+}
+
+func average(pts []Point) Point {
+	var c Point
+	for _, p := range pts {
+		c.X += p.X
+		c.Y += p.Y
+	}
+	c.X /= float64(len(pts))
+	c.Y /= float64(len(pts))
+	return c
+}
+
+func shoelaceArea(pts []Point) float64 {
+	var area float64
+	for i, p := range pts {
+		q := pts[(i+1)%len(pts)]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	return math.Abs(area) / 2
+}
@@ -0,0 +1,444 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pggeom
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geomfn"
+	"github.com/cockroachdb/errors"
+	"github.com/twpayne/go-geom"
+)
+
+// epsilon is the tolerance of the fuzzy comparisons between coordinates. It
+// matches EPSILON in postgres, which uses the same comparisons so that tiny
+// floating point errors do not change the result of the operators.
+const epsilon = 1.0e-06
+
+func fpZero(a float64) bool       { return math.Abs(a) <= epsilon }
+func fpEq(a, b float64) bool      { return a == b || math.Abs(a-b) <= epsilon }
+func fpLe(a, b float64) bool      { return a <= b+epsilon }
+func fpGe(a, b float64) bool      { return a+epsilon >= b }
+func pointEq(a, b Point) bool     { return fpEq(a.X, b.X) && fpEq(a.Y, b.Y) }
+func dist(a, b Point) float64     { return math.Hypot(a.X-b.X, a.Y-b.Y) }
+func (l Line) at(p Point) float64 { return l.A*p.X + l.B*p.Y + l.C }
+
+// Contains returns whether a contains b, which is the @> operator. b <@ a is
+// the same as a @> b. It returns an error if the operator is not defined for
+// the kinds of a and b.
+func Contains(a, b Shape) (bool, error) {
+	switch a := a.(type) {
+	case Box:
+		switch b := b.(type) {
+		case Point:
+			return a.containsPoint(b), nil
+		case LSeg:
+			return a.containsPoint(b.P[0]) && a.containsPoint(b.P[1]), nil
+		case Box:
+			return fpGe(a.High.X, b.High.X) && fpLe(a.Low.X, b.Low.X) &&
+				fpGe(a.High.Y, b.High.Y) && fpLe(a.Low.Y, b.Low.Y), nil
+		}
+	case LSeg:
+		if b, ok := b.(Point); ok {
+			return a.containsPoint(b), nil
+		}
+	case Line:
+		switch b := b.(type) {
+		case Point:
+			return fpZero(a.at(b)), nil
+		case LSeg:
+			return fpZero(a.at(b.P[0])) && fpZero(a.at(b.P[1])), nil
+		}
+	case Path:
+		if b, ok := b.(Point); ok {
+			if a.Closed {
+				return pointInside(b, a.Points), nil
+			}
+			for _, s := range segments(a.Points, false /* closed */) {
+				if s.containsPoint(b) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	case Polygon:
+		switch b := b.(type) {
+		case Point:
+			return pointInside(b, a.Points), nil
+		case Polygon:
+			return a.containsPolygon(b), nil
+		}
+	case Circle:
+		switch b := b.(type) {
+		case Point:
+			return dist(a.Center, b) <= a.Radius, nil
+		case Circle:
+			return fpLe(dist(a.Center, b.Center)+b.Radius, a.Radius), nil
+		}
+	}
+	return false, unsupportedErr("contains", a, b)
+}
+
+// Overlaps returns whether a and b overlap, which is the && operator. It
+// returns an error if the operator is not defined for the kinds of a and b.
+func Overlaps(a, b Shape) (bool, error) {
+	switch a := a.(type) {
+	case Box:
+		if b, ok := b.(Box); ok {
+			return a.overlaps(b), nil
+		}
+	case Polygon:
+		if b, ok := b.(Polygon); ok {
+			if !a.BoundBox.overlaps(b.BoundBox) {
+				return false, nil
+			}
+			for _, sa := range segments(a.Points, true /* closed */) {
+				for _, sb := range segments(b.Points, true /* closed */) {
+					if sa.intersects(sb) {
+						return true, nil
+					}
+				}
+			}
+			// If no edges intersect, the polygons overlap only if one is inside
+			// the other.
+			return pointInside(a.Points[0], b.Points) || pointInside(b.Points[0], a.Points), nil
+		}
+	case Circle:
+		if b, ok := b.(Circle); ok {
+			return fpLe(dist(a.Center, b.Center), a.Radius+b.Radius), nil
+		}
+	}
+	return false, unsupportedErr("overlaps", a, b)
+}
+
+// Intersects returns whether a and b intersect, which is the ?# operator. It
+// returns an error if the operator is not defined for the kinds of a and b.
+func Intersects(a, b Shape) (bool, error) {
+	switch a := a.(type) {
+	case LSeg:
+		switch b := b.(type) {
+		case LSeg:
+			return a.intersects(b), nil
+		case Line:
+			return a.intersectsLine(b), nil
+		case Box:
+			return b.intersectsLSeg(a), nil
+		}
+	case Line:
+		switch b := b.(type) {
+		case LSeg:
+			return b.intersectsLine(a), nil
+		case Line:
+			_, ok := a.intersection(b)
+			return ok, nil
+		case Box:
+			for _, s := range b.edges() {
+				if s.intersectsLine(a) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	case Box:
+		switch b := b.(type) {
+		case LSeg:
+			return a.intersectsLSeg(b), nil
+		case Box:
+			return a.overlaps(b), nil
+		}
+	case Path:
+		if b, ok := b.(Path); ok {
+			if !boundBox(a.Points).overlaps(boundBox(b.Points)) {
+				return false, nil
+			}
+			for _, sa := range segments(a.Points, a.Closed) {
+				for _, sb := range segments(b.Points, b.Closed) {
+					if sa.intersects(sb) {
+						return true, nil
+					}
+				}
+			}
+			return false, nil
+		}
+	}
+	return false, unsupportedErr("intersects", a, b)
+}
+
+// Distance returns the distance between a and b, which is the <-> operator. It
+// returns an error if the operator is not defined for the kinds of a and b.
+func Distance(a, b Shape) (float64, error) {
+	switch a := a.(type) {
+	case Line:
+		switch b := b.(type) {
+		case Point:
+			return a.distToPoint(b), nil
+		case LSeg:
+			return a.distToLSeg(b), nil
+		case Line:
+			if _, ok := a.intersection(b); ok {
+				return 0, nil
+			}
+			ratio := 1.0
+			if !fpZero(a.A) {
+				ratio = b.A / a.A
+			} else if !fpZero(a.B) {
+				ratio = b.B / a.B
+			}
+			return math.Abs(a.C*ratio-b.C) / math.Hypot(b.A, b.B), nil
+		}
+		return 0, unsupportedErr("distance", a, b)
+	case Circle:
+		switch b := b.(type) {
+		case Circle:
+			return math.Max(0, dist(a.Center, b.Center)-a.Radius-b.Radius), nil
+		case Point, Polygon:
+			d, err := Distance(a.Center, b)
+			if err != nil {
+				return 0, err
+			}
+			return math.Max(0, d-a.Radius), nil
+		}
+		return 0, unsupportedErr("distance", a, b)
+	}
+	switch b.(type) {
+	case Line, Circle:
+		return Distance(b, a)
+	}
+	// All other shapes are made of points and line segments, whose distance is
+	// computed by geomfn.
+	ga, err := toGeometry(a)
+	if err != nil {
+		return 0, err
+	}
+	gb, err := toGeometry(b)
+	if err != nil {
+		return 0, err
+	}
+	return geomfn.MinDistance(ga, gb)
+}
+
+// toGeometry converts a Shape that is made of points and line segments to a
+// Geometry with the same points, so that geomfn can be used on it. Closed
+// paths are converted to closed line strings, since their interior is not part
+// of the path.
+func toGeometry(s Shape) (geo.Geometry, error) {
+	var g geom.T
+	switch t := s.(type) {
+	case Point:
+		g = geom.NewPointFlat(geom.XY, []float64{t.X, t.Y})
+	case LSeg:
+		g = geom.NewLineStringFlat(geom.XY, t.appendFloats(nil))
+	case Box:
+		g = MakePolygonFromBox(t).toGeom()
+	case Path:
+		pts := t.Points
+		if t.Closed && len(pts) > 1 {
+			pts = append(pts[:len(pts):len(pts)], pts[0])
+		}
+		g = lineStringOrPoint(pts)
+	case Polygon:
+		g = t.toGeom()
+	default:
+		return geo.Geometry{}, errors.AssertionFailedf("cannot convert %s to geometry", s.Kind())
+	}
+	return geo.MakeGeometryFromGeomT(g)
+}
+
+// toGeom returns the polygon as a geom.T. Polygons with less than three points
+// have no interior, so they are converted to line strings or points.
+func (p Polygon) toGeom() geom.T {
+	if len(p.Points) < 3 {
+		return lineStringOrPoint(p.Points)
+	}
+	flat := flatCoords(p.Points)
+	flat = append(flat, p.Points[0].X, p.Points[0].Y)
+	return geom.NewPolygonFlat(geom.XY, flat, []int{len(flat)})
+}
+
+func lineStringOrPoint(pts []Point) geom.T {
+	flat := flatCoords(pts)
+	if len(pts) == 1 {
+		return geom.NewPointFlat(geom.XY, flat)
+	}
+	return geom.NewLineStringFlat(geom.XY, flat)
+}
+
+// flatCoords returns the coordinates of the points as a flat list, which is
+// the format that geom uses.
+func flatCoords(pts []Point) []float64 {
+	flat := make([]float64, 0, 2*len(pts)+2)
+	for _, p := range pts {
+		flat = append(flat, p.X, p.Y)
+	}
+	return flat
+}
+
+func unsupportedErr(op string, a, b Shape) error {
+	return errors.AssertionFailedf("%s is not supported for %s and %s", op, a.Kind(), b.Kind())
+}
+
+func (b Box) containsPoint(p Point) bool {
+	return b.High.X >= p.X && b.Low.X <= p.X && b.High.Y >= p.Y && b.Low.Y <= p.Y
+}
+
+func (b Box) overlaps(o Box) bool {
+	return fpLe(b.Low.X, o.High.X) && fpLe(o.Low.X, b.High.X) &&
+		fpLe(b.Low.Y, o.High.Y) && fpLe(o.Low.Y, b.High.Y)
+}
+
+// edges returns the four edges of the box.
+func (b Box) edges() []LSeg {
+	return segments(MakePolygonFromBox(b).Points, true /* closed */)
+}
+
+func (b Box) intersectsLSeg(l LSeg) bool {
+	if !b.overlaps(MakeBox(l.P[0], l.P[1])) {
+		return false
+	}
+	if b.containsPoint(l.P[0]) || b.containsPoint(l.P[1]) {
+		return true
+	}
+	for _, e := range b.edges() {
+		if e.intersects(l) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l LSeg) containsPoint(p Point) bool {
+	return fpEq(dist(p, l.P[0])+dist(p, l.P[1]), dist(l.P[0], l.P[1]))
+}
+
+// line returns the line through the segment. It must not be degenerate.
+func (l LSeg) line() Line {
+	line, _ := MakeLine(l.P[0], l.P[1])
+	return line
+}
+
+// intersectsLine returns whether the segment intersects the line. Like in
+// postgres, a segment that lies on the line does not intersect it.
+func (l LSeg) intersectsLine(line Line) bool {
+	if pointEq(l.P[0], l.P[1]) {
+		return fpZero(line.at(l.P[0]))
+	}
+	p, ok := l.line().intersection(line)
+	return ok && l.containsPoint(p)
+}
+
+// intersects returns whether the segments intersect. Like in postgres,
+// parallel segments never intersect.
+func (l LSeg) intersects(o LSeg) bool {
+	switch {
+	case pointEq(l.P[0], l.P[1]):
+		return o.containsPoint(l.P[0])
+	case pointEq(o.P[0], o.P[1]):
+		return l.containsPoint(o.P[0])
+	}
+	p, ok := l.line().intersection(o.line())
+	return ok && l.containsPoint(p) && o.containsPoint(p)
+}
+
+// intersection returns the intersection point of the lines, and false if they
+// are parallel.
+func (l Line) intersection(o Line) (Point, bool) {
+	det := l.A*o.B - o.A*l.B
+	if fpZero(det) {
+		return Point{}, false
+	}
+	return Point{
+		X: (l.B*o.C - o.B*l.C) / det,
+		Y: (o.A*l.C - l.A*o.C) / det,
+	}, true
+}
+
+func (l Line) distToPoint(p Point) float64 {
+	return math.Abs(l.at(p)) / math.Hypot(l.A, l.B)
+}
+
+func (l Line) distToLSeg(s LSeg) float64 {
+	if s.intersectsLine(l) {
+		return 0
+	}
+	return math.Min(l.distToPoint(s.P[0]), l.distToPoint(s.P[1]))
+}
+
+// containsPolygon returns whether the polygon contains o, which is the case if
+// all vertices and edge midpoints of o are inside p, and no edge of o crosses
+// an edge of p.
+func (p Polygon) containsPolygon(o Polygon) bool {
+	if !p.BoundBox.containsPoint(o.BoundBox.High) || !p.BoundBox.containsPoint(o.BoundBox.Low) {
+		return false
+	}
+	for _, pt := range o.Points {
+		if !pointInside(pt, p.Points) {
+			return false
+		}
+	}
+	edges := segments(p.Points, true /* closed */)
+	for _, s := range segments(o.Points, true /* closed */) {
+		mid := Point{X: (s.P[0].X + s.P[1].X) / 2, Y: (s.P[0].Y + s.P[1].Y) / 2}
+		if !pointInside(mid, p.Points) {
+			return false
+		}
+		for _, e := range edges {
+			if crosses(s, e) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// crosses returns whether the segments intersect in a single point that is
+// not an endpoint of either of them.
+func crosses(a, b LSeg) bool {
+	side := func(s LSeg, p Point) float64 {
+		return (s.P[1].X-s.P[0].X)*(p.Y-s.P[0].Y) - (s.P[1].Y-s.P[0].Y)*(p.X-s.P[0].X)
+	}
+	d1, d2 := side(a, b.P[0]), side(a, b.P[1])
+	d3, d4 := side(b, a.P[0]), side(b, a.P[1])
+	return !fpZero(d1) && !fpZero(d2) && !fpZero(d3) && !fpZero(d4) &&
+		(d1 > 0) != (d2 > 0) && (d3 > 0) != (d4 > 0)
+}
+
+// segments returns the segments between consecutive points, including the
+// segment from the last point to the first one if closed is true.
+func segments(pts []Point, closed bool) []LSeg {
+	var segs []LSeg
+	for i := 0; i+1 < len(pts); i++ {
+		segs = append(segs, LSeg{P: [2]Point{pts[i], pts[i+1]}})
+	}
+	if closed && len(pts) > 2 {
+		segs = append(segs, LSeg{P: [2]Point{pts[len(pts)-1], pts[0]}})
+	}
+	return segs
+}
+
+// pointInside returns whether p is inside or on the boundary of the polygon
+// with the given vertices.
+func pointInside(p Point, pts []Point) bool {
+	if len(pts) == 1 {
+		return pointEq(p, pts[0])
+	}
+	inside := false
+	for _, s := range segments(pts, true /* closed */) {
+		if s.containsPoint(p) {
+			return true
+		}
+		a, b := s.P[0], s.P[1]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,342 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pggeom
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// Parse parses the text representation of a Shape of the given Kind. It
+// accepts the same input formats as postgres.
+func Parse(k Kind, s string) (Shape, error) {
+	switch k {
+	case PointKind:
+		return ParsePoint(s)
+	case LSegKind:
+		return ParseLSeg(s)
+	case BoxKind:
+		return ParseBox(s)
+	case PathKind:
+		return ParsePath(s)
+	case PolygonKind:
+		return ParsePolygon(s)
+	case LineKind:
+		return ParseLine(s)
+	case CircleKind:
+		return ParseCircle(s)
+	}
+	return nil, errors.AssertionFailedf("unknown geometric kind %d", k)
+}
+
+// ParsePoint parses a point in the format (x,y) or x,y.
+func ParsePoint(s string) (Point, error) {
+	p := parser{s: s, kind: PointKind}
+	pt, ok := p.pair()
+	if !ok || !p.done() {
+		return Point{}, p.syntaxErr()
+	}
+	return pt, nil
+}
+
+// ParseLSeg parses a line segment in the format [(x1,y1),(x2,y2)],
+// ((x1,y1),(x2,y2)), (x1,y1),(x2,y2) or x1,y1,x2,y2.
+func ParseLSeg(s string) (LSeg, error) {
+	p := parser{s: s, kind: LSegKind}
+	var l LSeg
+	if _, ok := p.path(true /* allowOpen */, l.P[:]); !ok || !p.done() {
+		return LSeg{}, p.syntaxErr()
+	}
+	return l, nil
+}
+
+// ParseBox parses a box in the format ((x1,y1),(x2,y2)), (x1,y1),(x2,y2) or
+// x1,y1,x2,y2, where the two points are any opposite corners of the box.
+func ParseBox(s string) (Box, error) {
+	p := parser{s: s, kind: BoxKind}
+	var pts [2]Point
+	if _, ok := p.path(false /* allowOpen */, pts[:]); !ok || !p.done() {
+		return Box{}, p.syntaxErr()
+	}
+	return MakeBox(pts[0], pts[1]), nil
+}
+
+// ParsePath parses a path in the format [(x1,y1),...,(xn,yn)] for open paths,
+// or ((x1,y1),...,(xn,yn)), (x1,y1),...,(xn,yn) or x1,y1,...,xn,yn for closed
+// paths.
+func ParsePath(s string) (Path, error) {
+	p := parser{s: s, kind: PathKind}
+	n := pairCount(s)
+	if n <= 0 {
+		return Path{}, p.syntaxErr()
+	}
+	// A single leading parenthesis that is not followed by another one, as in
+	// (x1,y1,...,xn,yn), surrounds the whole path.
+	p.skipSpace()
+	depth := 0
+	if p.peek() == '(' && strings.LastIndexByte(s, '(') == p.pos {
+		p.pos++
+		depth++
+	}
+	pts := make([]Point, n)
+	open, ok := p.path(true /* allowOpen */, pts)
+	if !ok {
+		return Path{}, p.syntaxErr()
+	}
+	for ; depth > 0; depth-- {
+		if !p.consume(')') {
+			return Path{}, p.syntaxErr()
+		}
+	}
+	if !p.done() {
+		return Path{}, p.syntaxErr()
+	}
+	return Path{Points: pts, Closed: !open}, nil
+}
+
+// ParsePolygon parses a polygon in the format ((x1,y1),...,(xn,yn)),
+// (x1,y1),...,(xn,yn) or x1,y1,...,xn,yn.
+func ParsePolygon(s string) (Polygon, error) {
+	p := parser{s: s, kind: PolygonKind}
+	n := pairCount(s)
+	if n <= 0 {
+		return Polygon{}, p.syntaxErr()
+	}
+	pts := make([]Point, n)
+	if _, ok := p.path(false /* allowOpen */, pts); !ok || !p.done() {
+		return Polygon{}, p.syntaxErr()
+	}
+	return MakePolygon(pts), nil
+}
+
+// ParseLine parses a line in the format {A,B,C}, or as any two distinct points
+// on the line in any of the formats accepted by ParseLSeg.
+func ParseLine(s string) (Line, error) {
+	p := parser{s: s, kind: LineKind}
+	p.skipSpace()
+	if p.consume('{') {
+		var l Line
+		var ok bool
+		if l.A, ok = p.float(); !ok || !p.consume(',') {
+			return Line{}, p.syntaxErr()
+		}
+		if l.B, ok = p.float(); !ok || !p.consume(',') {
+			return Line{}, p.syntaxErr()
+		}
+		if l.C, ok = p.float(); !ok || !p.consume('}') || !p.done() {
+			return Line{}, p.syntaxErr()
+		}
+		if fpZero(l.A) && fpZero(l.B) {
+			return Line{}, pgerror.New(pgcode.InvalidParameterValue,
+				"invalid line specification: A and B cannot both be zero")
+		}
+		return l, nil
+	}
+	var pts [2]Point
+	if _, ok := p.path(true /* allowOpen */, pts[:]); !ok || !p.done() {
+		return Line{}, p.syntaxErr()
+	}
+	l, err := MakeLine(pts[0], pts[1])
+	if err != nil {
+		return Line{}, pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+	}
+	return l, nil
+}
+
+// ParseCircle parses a circle in the format <(x,y),r>, ((x,y),r), (x,y),r or
+// x,y,r.
+func ParseCircle(s string) (Circle, error) {
+	p := parser{s: s, kind: CircleKind}
+	p.skipSpace()
+	depth := 0
+	angled := false
+	if p.consume('<') {
+		depth++
+		angled = true
+	} else if p.peek() == '(' {
+		// If there are two left parentheses, the first one surrounds the whole
+		// circle.
+		save := p.pos
+		p.pos++
+		p.skipSpace()
+		if p.peek() == '(' {
+			depth++
+		} else {
+			p.pos = save
+		}
+	}
+	var c Circle
+	var ok bool
+	if c.Center, ok = p.pair(); !ok {
+		return Circle{}, p.syntaxErr()
+	}
+	p.consume(',')
+	if c.Radius, ok = p.float(); !ok || c.Radius < 0 {
+		return Circle{}, p.syntaxErr()
+	}
+	if depth > 0 {
+		closing := byte(')')
+		if angled {
+			closing = '>'
+		}
+		if !p.consume(closing) {
+			return Circle{}, p.syntaxErr()
+		}
+	}
+	if !p.done() {
+		return Circle{}, p.syntaxErr()
+	}
+	return c, nil
+}
+
+// pairCount returns the number of points in a list of coordinates, based on
+// the number of commas in s, or -1 if the number of commas is even.
+func pairCount(s string) int {
+	n := strings.Count(s, ",")
+	if n%2 == 0 {
+		return -1
+	}
+	return (n + 1) / 2
+}
+
+// parser is a cursor over the text representation of a Shape.
+type parser struct {
+	s    string
+	pos  int
+	kind Kind
+}
+
+func (p *parser) syntaxErr() error {
+	return pgerror.WithCandidateCode(
+		errors.Errorf("could not parse %q as %s", p.s, p.kind),
+		pgcode.InvalidTextRepresentation)
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && isSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+// consume skips c and any whitespace that follows it, and returns whether the
+// next character was c.
+func (p *parser) consume(c byte) bool {
+	if p.peek() != c {
+		return false
+	}
+	p.pos++
+	p.skipSpace()
+	return true
+}
+
+// done returns whether the whole input was consumed.
+func (p *parser) done() bool {
+	p.skipSpace()
+	return p.pos == len(p.s)
+}
+
+// float parses a float surrounded by optional whitespace.
+func (p *parser) float() (float64, bool) {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.s) && !isSpace(p.s[end]) && strings.IndexByte(",()[]{}<>", p.s[end]) < 0 {
+		end++
+	}
+	f, err := strconv.ParseFloat(p.s[p.pos:end], 64)
+	if err != nil {
+		// ParseFloat returns ±Inf along with a range error for values that are
+		// too large, which postgres rejects.
+		return 0, false
+	}
+	p.pos = end
+	p.skipSpace()
+	return f, true
+}
+
+// pair parses a point in the format (x,y) or x,y.
+func (p *parser) pair() (Point, bool) {
+	p.skipSpace()
+	parens := p.consume('(')
+	var pt Point
+	var ok bool
+	if pt.X, ok = p.float(); !ok || !p.consume(',') {
+		return Point{}, false
+	}
+	if pt.Y, ok = p.float(); !ok {
+		return Point{}, false
+	}
+	if parens && !p.consume(')') {
+		return Point{}, false
+	}
+	return pt, true
+}
+
+// path parses a list of len(pts) points into pts. The list is surrounded by
+// square brackets if it is an open path, which is only allowed if allowOpen is
+// true, and it is optionally surrounded by parentheses otherwise. It returns
+// whether the list was in square brackets.
+func (p *parser) path(allowOpen bool, pts []Point) (open bool, ok bool) {
+	p.skipSpace()
+	depth := 0
+	if p.peek() == '[' {
+		if !allowOpen {
+			return false, false
+		}
+		open = true
+		depth++
+		p.consume('[')
+	} else if p.peek() == '(' {
+		save := p.pos
+		p.pos++
+		p.skipSpace()
+		if p.peek() == '(' || strings.LastIndexByte(p.s, '(') == save {
+			// Either the points are surrounded by an extra pair of parentheses,
+			// as in ((x1,y1),(x2,y2)), or this is the only parenthesis, as in
+			// (x1,y1,x2,y2).
+			depth++
+		} else {
+			p.pos = save
+		}
+	}
+	for i := range pts {
+		if pts[i], ok = p.pair(); !ok {
+			return false, false
+		}
+		if i < len(pts)-1 && !p.consume(',') {
+			return false, false
+		}
+	}
+	for ; depth > 0; depth-- {
+		if !p.consume(')') && !(open && depth == 1 && p.consume(']')) {
+			return false, false
+		}
+	}
+	return open, true
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,404 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pggeom contains logic for handling the native postgres geometric
+// types: point, lseg, box, path, polygon, line and circle. Unlike the types in
+// pkg/geo, these types are purely planar and have no SRID.
+package pggeom
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Kind identifies a geometric type.
+type Kind uint8
+
+const (
+	// PointKind is the kind of a Point.
+	PointKind Kind = iota + 1
+	// LSegKind is the kind of an LSeg.
+	LSegKind
+	// BoxKind is the kind of a Box.
+	BoxKind
+	// PathKind is the kind of a Path.
+	PathKind
+	// PolygonKind is the kind of a Polygon.
+	PolygonKind
+	// LineKind is the kind of a Line.
+	LineKind
+	// CircleKind is the kind of a Circle.
+	CircleKind
+)
+
+var kindNames = [...]string{
+	PointKind:   "point",
+	LSegKind:    "lseg",
+	BoxKind:     "box",
+	PathKind:    "path",
+	PolygonKind: "polygon",
+	LineKind:    "line",
+	CircleKind:  "circle",
+}
+
+// String returns the name of the postgres type of the Kind.
+func (k Kind) String() string {
+	if k == 0 || int(k) >= len(kindNames) {
+		return "unknown"
+	}
+	return kindNames[k]
+}
+
+// Shape is implemented by all geometric types.
+type Shape interface {
+	// Kind returns the Kind of the Shape.
+	Kind() Kind
+	// String returns the text representation of the Shape, which is the same
+	// as the one postgres uses.
+	String() string
+	// Size returns the approximate size of the Shape in bytes.
+	Size() uintptr
+	// appendFloats appends all coordinates of the Shape to fs. It is used to
+	// order Shapes of the same Kind.
+	appendFloats(fs []float64) []float64
+}
+
+// Point is a point on a plane.
+type Point struct {
+	X, Y float64
+}
+
+// LSeg is a finite line segment.
+type LSeg struct {
+	P [2]Point
+}
+
+// Box is a rectangular box. High always holds the upper right corner and Low
+// the lower left corner of the box.
+type Box struct {
+	High, Low Point
+}
+
+// Path is a list of connected points. Open paths have distinct start and end
+// points, whereas in closed paths the last point connects to the first one.
+type Path struct {
+	Points []Point
+	Closed bool
+}
+
+// Polygon is a closed path that also includes its interior.
+type Polygon struct {
+	Points []Point
+	// BoundBox is the bounding box of the Points.
+	BoundBox Box
+}
+
+// Line is an infinite line, represented by the equation Ax + By + C = 0, where
+// A and B are not both zero.
+type Line struct {
+	A, B, C float64
+}
+
+// Circle is a circle.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+var _ Shape = Point{}
+var _ Shape = LSeg{}
+var _ Shape = Box{}
+var _ Shape = Path{}
+var _ Shape = Polygon{}
+var _ Shape = Line{}
+var _ Shape = Circle{}
+
+// Kind implements the Shape interface.
+func (Point) Kind() Kind { return PointKind }
+
+// Kind implements the Shape interface.
+func (LSeg) Kind() Kind { return LSegKind }
+
+// Kind implements the Shape interface.
+func (Box) Kind() Kind { return BoxKind }
+
+// Kind implements the Shape interface.
+func (Path) Kind() Kind { return PathKind }
+
+// Kind implements the Shape interface.
+func (Polygon) Kind() Kind { return PolygonKind }
+
+// Kind implements the Shape interface.
+func (Line) Kind() Kind { return LineKind }
+
+// Kind implements the Shape interface.
+func (Circle) Kind() Kind { return CircleKind }
+
+// String implements the Shape interface.
+func (p Point) String() string {
+	var b strings.Builder
+	writePoints(&b, []Point{p})
+	return b.String()
+}
+
+// String implements the Shape interface.
+func (l LSeg) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	writePoints(&b, l.P[:])
+	b.WriteByte(']')
+	return b.String()
+}
+
+// String implements the Shape interface.
+func (bx Box) String() string {
+	var b strings.Builder
+	writePoints(&b, []Point{bx.High, bx.Low})
+	return b.String()
+}
+
+// String implements the Shape interface.
+func (p Path) String() string {
+	var b strings.Builder
+	if p.Closed {
+		b.WriteByte('(')
+	} else {
+		b.WriteByte('[')
+	}
+	writePoints(&b, p.Points)
+	if p.Closed {
+		b.WriteByte(')')
+	} else {
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+// String implements the Shape interface.
+func (p Polygon) String() string {
+	var b strings.Builder
+	b.WriteByte('(')
+	writePoints(&b, p.Points)
+	b.WriteByte(')')
+	return b.String()
+}
+
+// String implements the Shape interface.
+func (l Line) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	b.WriteString(formatFloat(l.A))
+	b.WriteByte(',')
+	b.WriteString(formatFloat(l.B))
+	b.WriteByte(',')
+	b.WriteString(formatFloat(l.C))
+	b.WriteByte('}')
+	return b.String()
+}
+
+// String implements the Shape interface.
+func (c Circle) String() string {
+	var b strings.Builder
+	b.WriteByte('<')
+	writePoints(&b, []Point{c.Center})
+	b.WriteByte(',')
+	b.WriteString(formatFloat(c.Radius))
+	b.WriteByte('>')
+	return b.String()
+}
+
+// Size implements the Shape interface.
+func (p Point) Size() uintptr { return unsafe.Sizeof(p) }
+
+// Size implements the Shape interface.
+func (l LSeg) Size() uintptr { return unsafe.Sizeof(l) }
+
+// Size implements the Shape interface.
+func (b Box) Size() uintptr { return unsafe.Sizeof(b) }
+
+// Size implements the Shape interface.
+func (p Path) Size() uintptr {
+	return unsafe.Sizeof(p) + uintptr(len(p.Points))*unsafe.Sizeof(Point{})
+}
+
+// Size implements the Shape interface.
+func (p Polygon) Size() uintptr {
+	return unsafe.Sizeof(p) + uintptr(len(p.Points))*unsafe.Sizeof(Point{})
+}
+
+// Size implements the Shape interface.
+func (l Line) Size() uintptr { return unsafe.Sizeof(l) }
+
+// Size implements the Shape interface.
+func (c Circle) Size() uintptr { return unsafe.Sizeof(c) }
+
+func (p Point) appendFloats(fs []float64) []float64 {
+	return append(fs, p.X, p.Y)
+}
+
+func (l LSeg) appendFloats(fs []float64) []float64 {
+	return append(fs, l.P[0].X, l.P[0].Y, l.P[1].X, l.P[1].Y)
+}
+
+func (b Box) appendFloats(fs []float64) []float64 {
+	return append(fs, b.High.X, b.High.Y, b.Low.X, b.Low.Y)
+}
+
+func (p Path) appendFloats(fs []float64) []float64 {
+	closed := 0.0
+	if p.Closed {
+		closed = 1
+	}
+	fs = append(fs, closed)
+	return append(fs, flatCoords(p.Points)...)
+}
+
+func (p Polygon) appendFloats(fs []float64) []float64 {
+	return append(fs, flatCoords(p.Points)...)
+}
+
+func (l Line) appendFloats(fs []float64) []float64 {
+	return append(fs, l.A, l.B, l.C)
+}
+
+func (c Circle) appendFloats(fs []float64) []float64 {
+	return append(fs, c.Center.X, c.Center.Y, c.Radius)
+}
+
+// Compare returns -1, 0 or 1 depending on whether a sorts before, the same as
+// or after b. Shapes are ordered by Kind and then by their coordinates. The
+// order has no geometric meaning, it only exists so that Shapes can be sorted
+// and deduplicated.
+func Compare(a, b Shape) int {
+	if a.Kind() != b.Kind() {
+		if a.Kind() < b.Kind() {
+			return -1
+		}
+		return 1
+	}
+	var bufA, bufB [8]float64
+	fa, fb := a.appendFloats(bufA[:0]), b.appendFloats(bufB[:0])
+	for i := 0; i < len(fa) && i < len(fb); i++ {
+		if c := compareFloats(fa[i], fb[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(fa) < len(fb):
+		return -1
+	case len(fa) > len(fb):
+		return 1
+	}
+	return 0
+}
+
+// compareFloats orders floats the same way as the FLOAT type, with NaN
+// sorting before all other values.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	}
+	return 1
+}
+
+// MakeBox returns the Box with the given corners, normalized so that High
+// holds the upper right corner.
+func MakeBox(p1, p2 Point) Box {
+	b := Box{High: p1, Low: p2}
+	if b.High.X < b.Low.X {
+		b.High.X, b.Low.X = b.Low.X, b.High.X
+	}
+	if b.High.Y < b.Low.Y {
+		b.High.Y, b.Low.Y = b.Low.Y, b.High.Y
+	}
+	return b
+}
+
+// MakePolygon returns the Polygon with the given vertices.
+func MakePolygon(points []Point) Polygon {
+	return Polygon{Points: points, BoundBox: boundBox(points)}
+}
+
+// MakeLine returns the Line through the two given points, which must be
+// distinct.
+func MakeLine(p1, p2 Point) (Line, error) {
+	if pointEq(p1, p2) {
+		return Line{}, errors.New("invalid line specification: must be two distinct points")
+	}
+	switch {
+	case fpEq(p1.X, p2.X):
+		// Vertical line.
+		return Line{A: -1, B: 0, C: p1.X}, nil
+	case fpEq(p1.Y, p2.Y):
+		// Horizontal line.
+		return Line{A: 0, B: -1, C: p1.Y}, nil
+	}
+	m := (p2.Y - p1.Y) / (p2.X - p1.X)
+	return Line{A: m, B: -1, C: p1.Y - m*p1.X}, nil
+}
+
+// boundBox returns the bounding box of the points, which must not be empty.
+func boundBox(points []Point) Box {
+	b := Box{High: points[0], Low: points[0]}
+	for _, p := range points[1:] {
+		b.High.X = math.Max(b.High.X, p.X)
+		b.High.Y = math.Max(b.High.Y, p.Y)
+		b.Low.X = math.Min(b.Low.X, p.X)
+		b.Low.Y = math.Min(b.Low.Y, p.Y)
+	}
+	return b
+}
+
+// formatFloat formats f the same way as postgres formats float8 values.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	// Postgres uses the shortest representation that round-trips, in
+	// exponential notation if the exponent is less than -4 or at least 15.
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	exp, err := strconv.Atoi(s[strings.IndexByte(s, 'e')+1:])
+	if err == nil && exp >= -4 && exp < 15 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return s
+}
+
+// writePoints writes the points in the format (x1,y1),(x2,y2),...
+func writePoints(b *strings.Builder, points []Point) {
+	for i, p := range points {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('(')
+		b.WriteString(formatFloat(p.X))
+		b.WriteByte(',')
+		b.WriteString(formatFloat(p.Y))
+		b.WriteByte(')')
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pggeom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		kind Kind
+		s    string
+		exp  string
+		err  bool
+	}{
+		{kind: PointKind, s: "(1,2)", exp: "(1,2)"},
+		{kind: PointKind, s: " ( 1.5 , -2 ) ", exp: "(1.5,-2)"},
+		{kind: PointKind, s: "1,2", exp: "(1,2)"},
+		{kind: PointKind, s: "(1e20,1e-5)", exp: "(1e+20,1e-05)"},
+		{kind: PointKind, s: "(1000000,0.0001)", exp: "(1000000,0.0001)"},
+		{kind: PointKind, s: "(Infinity,-inf)", exp: "(Infinity,-Infinity)"},
+		{kind: PointKind, s: "(1,2", err: true},
+		{kind: PointKind, s: "(1,2,3)", err: true},
+		{kind: PointKind, s: "(a,b)", err: true},
+
+		{kind: LSegKind, s: "[(1,2),(3,4)]", exp: "[(1,2),(3,4)]"},
+		{kind: LSegKind, s: "((1,2),(3,4))", exp: "[(1,2),(3,4)]"},
+		{kind: LSegKind, s: "(1,2),(3,4)", exp: "[(1,2),(3,4)]"},
+		{kind: LSegKind, s: "1,2,3,4", exp: "[(1,2),(3,4)]"},
+		{kind: LSegKind, s: "[(1,2),(3,4)", err: true},
+		{kind: LSegKind, s: "(1,2),(3,4),(5,6)", err: true},
+
+		{kind: BoxKind, s: "((0,0),(1,1))", exp: "(1,1),(0,0)"},
+		{kind: BoxKind, s: "(0,1),(1,0)", exp: "(1,1),(0,0)"},
+		{kind: BoxKind, s: "3,4,1,2", exp: "(3,4),(1,2)"},
+		{kind: BoxKind, s: "[(0,0),(1,1)]", err: true},
+
+		{kind: PathKind, s: "[(0,0),(1,1),(2,0)]", exp: "[(0,0),(1,1),(2,0)]"},
+		{kind: PathKind, s: "((0,0),(1,1),(2,0))", exp: "((0,0),(1,1),(2,0))"},
+		{kind: PathKind, s: "(0,0),(1,1)", exp: "((0,0),(1,1))"},
+		{kind: PathKind, s: "(0,0,1,1)", exp: "((0,0),(1,1))"},
+		{kind: PathKind, s: "0,0,1,1", exp: "((0,0),(1,1))"},
+		{kind: PathKind, s: "[(0,0)]", exp: "[(0,0)]"},
+		{kind: PathKind, s: "", err: true},
+		{kind: PathKind, s: "[(0,0),(1,1)", err: true},
+
+		{kind: PolygonKind, s: "((0,0),(0,1),(1,0))", exp: "((0,0),(0,1),(1,0))"},
+		{kind: PolygonKind, s: "(0,0),(0,1),(1,0)", exp: "((0,0),(0,1),(1,0))"},
+		{kind: PolygonKind, s: "0,0,0,1,1,0", exp: "((0,0),(0,1),(1,0))"},
+		{kind: PolygonKind, s: "[(0,0),(0,1),(1,0)]", err: true},
+
+		{kind: LineKind, s: "{1,-1,0}", exp: "{1,-1,0}"},
+		{kind: LineKind, s: "[(0,0),(1,1)]", exp: "{1,-1,0}"},
+		{kind: LineKind, s: "(1,0),(1,5)", exp: "{-1,0,1}"},
+		{kind: LineKind, s: "(0,2),(5,2)", exp: "{0,-1,2}"},
+		{kind: LineKind, s: "{0,0,1}", err: true},
+		{kind: LineKind, s: "[(1,1),(1,1)]", err: true},
+		{kind: LineKind, s: "{1,2}", err: true},
+
+		{kind: CircleKind, s: "<(1,2),3>", exp: "<(1,2),3>"},
+		{kind: CircleKind, s: "((1,2),3)", exp: "<(1,2),3>"},
+		{kind: CircleKind, s: "(1,2),3", exp: "<(1,2),3>"},
+		{kind: CircleKind, s: "1,2,3", exp: "<(1,2),3>"},
+		{kind: CircleKind, s: "<(1,2),-3>", err: true},
+		{kind: CircleKind, s: "<(1,2),3)", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.kind.String()+"/"+tc.s, func(t *testing.T) {
+			s, err := Parse(tc.kind, tc.s)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.kind, s.Kind())
+			require.Equal(t, tc.exp, s.String())
+
+			// The output must round trip through the text and binary formats.
+			s2, err := Parse(tc.kind, s.String())
+			require.NoError(t, err)
+			require.Equal(t, 0, Compare(s, s2))
+			s3, err := DecodeBinary(tc.kind, AppendBinary(nil, s))
+			require.NoError(t, err)
+			require.Equal(t, 0, Compare(s, s3))
+		})
+	}
+}
+
+func TestDecodeBinaryErrors(t *testing.T) {
+	p := AppendBinary(nil, Point{X: 1, Y: 2})
+	_, err := DecodeBinary(PointKind, p[:15])
+	require.Error(t, err)
+	_, err = DecodeBinary(PointKind, append(p, 0))
+	require.Error(t, err)
+	_, err = DecodeBinary(PolygonKind, []byte{0, 0, 0, 0})
+	require.Error(t, err)
+	_, err = DecodeBinary(PathKind, []byte{1, 0, 0, 0, 2})
+	require.Error(t, err)
+	_, err = DecodeBinary(LineKind, AppendBinary(nil, Line{}))
+	require.Error(t, err)
+}
+
+func mustParse(t *testing.T, k Kind, s string) Shape {
+	shape, err := Parse(k, s)
+	require.NoError(t, err)
+	return shape
+}
+
+func TestPredicates(t *testing.T) {
+	testCases := []struct {
+		fn   func(a, b Shape) (bool, error)
+		a, b Shape
+		exp  bool
+	}{
+		{Contains, mustParse(t, BoxKind, "(2,2),(0,0)"), Point{X: 1, Y: 1}, true},
+		{Contains, mustParse(t, BoxKind, "(2,2),(0,0)"), Point{X: 2, Y: 2}, true},
+		{Contains, mustParse(t, BoxKind, "(2,2),(0,0)"), Point{X: 3, Y: 1}, false},
+		{Contains, mustParse(t, BoxKind, "(2,2),(0,0)"), mustParse(t, BoxKind, "(2,1),(1,0)"), true},
+		{Contains, mustParse(t, BoxKind, "(2,2),(0,0)"), mustParse(t, BoxKind, "(3,1),(1,0)"), false},
+		{Contains, mustParse(t, LSegKind, "[(0,0),(2,2)]"), Point{X: 1, Y: 1}, true},
+		{Contains, mustParse(t, LSegKind, "[(0,0),(2,2)]"), Point{X: 3, Y: 3}, false},
+		{Contains, mustParse(t, LineKind, "{1,-1,0}"), Point{X: 3, Y: 3}, true},
+		{Contains, mustParse(t, PathKind, "[(0,0),(2,0),(2,2)]"), Point{X: 2, Y: 1}, true},
+		{Contains, mustParse(t, PathKind, "[(0,0),(2,0),(2,2)]"), Point{X: 1, Y: 1}, false},
+		{Contains, mustParse(t, PathKind, "((0,0),(2,0),(2,2))"), Point{X: 1.5, Y: 0.5}, true},
+		{Contains, mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), Point{X: 1, Y: 1}, true},
+		{Contains, mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), Point{X: 4, Y: 2}, true},
+		{Contains, mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), Point{X: 5, Y: 2}, false},
+		{Contains, mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), mustParse(t, PolygonKind, "((1,1),(1,2),(2,2))"), true},
+		{Contains, mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), true},
+		{Contains, mustParse(t, PolygonKind, "((0,0),(0,4),(2,2),(4,4),(4,0))"), mustParse(t, PolygonKind, "((1,3),(3,3),(2,1))"), false},
+		{Contains, mustParse(t, CircleKind, "<(0,0),2>"), Point{X: 1, Y: 1}, true},
+		{Contains, mustParse(t, CircleKind, "<(0,0),2>"), mustParse(t, CircleKind, "<(1,0),1>"), true},
+		{Contains, mustParse(t, CircleKind, "<(0,0),2>"), mustParse(t, CircleKind, "<(1,0),1.5>"), false},
+
+		{Overlaps, mustParse(t, BoxKind, "(2,2),(0,0)"), mustParse(t, BoxKind, "(3,3),(2,2)"), true},
+		{Overlaps, mustParse(t, BoxKind, "(2,2),(0,0)"), mustParse(t, BoxKind, "(4,4),(3,3)"), false},
+		{Overlaps, mustParse(t, PolygonKind, "((0,0),(0,2),(2,2),(2,0))"), mustParse(t, PolygonKind, "((1,1),(1,3),(3,3))"), true},
+		{Overlaps, mustParse(t, PolygonKind, "((0,0),(0,4),(4,4),(4,0))"), mustParse(t, PolygonKind, "((1,1),(1,2),(2,2))"), true},
+		{Overlaps, mustParse(t, PolygonKind, "((0,0),(0,2),(2,0))"), mustParse(t, PolygonKind, "((2,2),(2,3),(3,2))"), false},
+		{Overlaps, mustParse(t, CircleKind, "<(0,0),1>"), mustParse(t, CircleKind, "<(2,0),1>"), true},
+		{Overlaps, mustParse(t, CircleKind, "<(0,0),1>"), mustParse(t, CircleKind, "<(3,0),1>"), false},
+
+		{Intersects, mustParse(t, LSegKind, "[(0,0),(2,2)]"), mustParse(t, LSegKind, "[(0,2),(2,0)]"), true},
+		{Intersects, mustParse(t, LSegKind, "[(0,0),(1,1)]"), mustParse(t, LSegKind, "[(2,0),(3,-1)]"), false},
+		{Intersects, mustParse(t, LSegKind, "[(0,0),(2,2)]"), mustParse(t, LSegKind, "[(1,1),(3,3)]"), false},
+		{Intersects, mustParse(t, LSegKind, "[(0,0),(2,2)]"), mustParse(t, LineKind, "{0,-1,1}"), true},
+		{Intersects, mustParse(t, LSegKind, "[(0,0),(2,2)]"), mustParse(t, LineKind, "{0,-1,3}"), false},
+		{Intersects, mustParse(t, LSegKind, "[(-1,-1),(5,5)]"), mustParse(t, BoxKind, "(2,2),(0,0)"), true},
+		{Intersects, mustParse(t, LSegKind, "[(3,0),(3,5)]"), mustParse(t, BoxKind, "(2,2),(0,0)"), false},
+		{Intersects, mustParse(t, LineKind, "{1,-1,0}"), mustParse(t, LineKind, "{1,1,0}"), true},
+		{Intersects, mustParse(t, LineKind, "{1,-1,0}"), mustParse(t, LineKind, "{1,-1,1}"), false},
+		{Intersects, mustParse(t, LineKind, "{1,-1,0}"), mustParse(t, BoxKind, "(2,2),(0,0)"), true},
+		{Intersects, mustParse(t, LineKind, "{1,-1,10}"), mustParse(t, BoxKind, "(2,2),(0,0)"), false},
+		{Intersects, mustParse(t, PathKind, "[(0,0),(2,2)]"), mustParse(t, PathKind, "[(0,2),(2,0)]"), true},
+		{Intersects, mustParse(t, PathKind, "[(0,0),(2,0),(2,2)]"), mustParse(t, PathKind, "[(0,1),(1,1)]"), false},
+		{Intersects, mustParse(t, PathKind, "((0,0),(2,0),(2,2))"), mustParse(t, PathKind, "[(0,1),(1,1)]"), true},
+	}
+	for _, tc := range testCases {
+		res, err := tc.fn(tc.a, tc.b)
+		require.NoError(t, err)
+		require.Equal(t, tc.exp, res, "%s %s", tc.a, tc.b)
+	}
+
+	_, err := Contains(Point{}, Point{})
+	require.Error(t, err)
+}
+
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		a, b Shape
+		exp  float64
+	}{
+		{Point{X: 0, Y: 0}, Point{X: 3, Y: 4}, 5},
+		{Point{X: 0, Y: 0}, mustParse(t, LSegKind, "[(1,-1),(1,1)]"), 1},
+		{Point{X: 1, Y: 1}, mustParse(t, BoxKind, "(2,2),(0,0)"), 0},
+		{Point{X: 4, Y: 1}, mustParse(t, BoxKind, "(2,2),(0,0)"), 2},
+		{Point{X: 1, Y: 1}, mustParse(t, PathKind, "[(0,0),(2,0),(2,2)]"), 1},
+		{Point{X: 1, Y: 1}, mustParse(t, PolygonKind, "((0,0),(0,2),(2,2),(2,0))"), 0},
+		{Point{X: 0, Y: 5}, mustParse(t, PolygonKind, "((0,0),(0,2),(2,2),(2,0))"), 3},
+		{Point{X: 0, Y: 5}, mustParse(t, LineKind, "{0,-1,1}"), 4},
+		{mustParse(t, LineKind, "{0,-1,1}"), Point{X: 0, Y: 5}, 4},
+		{Point{X: 0, Y: 5}, mustParse(t, CircleKind, "<(0,0),2>"), 3},
+		{Point{X: 0, Y: 1}, mustParse(t, CircleKind, "<(0,0),2>"), 0},
+		{mustParse(t, LSegKind, "[(0,0),(1,0)]"), mustParse(t, LSegKind, "[(0,2),(1,2)]"), 2},
+		{mustParse(t, LSegKind, "[(0,0),(1,0)]"), mustParse(t, LineKind, "{0,-1,2}"), 2},
+		{mustParse(t, LSegKind, "[(0,0),(1,0)]"), mustParse(t, BoxKind, "(2,2),(1,1)"), 1},
+		{mustParse(t, BoxKind, "(1,1),(0,0)"), mustParse(t, BoxKind, "(5,5),(4,1)"), 3},
+		{mustParse(t, LineKind, "{0,-1,1}"), mustParse(t, LineKind, "{0,-1,3}"), 2},
+		{mustParse(t, LineKind, "{0,-1,1}"), mustParse(t, LineKind, "{1,-1,3}"), 0},
+		{mustParse(t, PolygonKind, "((0,0),(0,1),(1,0))"), mustParse(t, PolygonKind, "((3,0),(3,1),(4,0))"), 2},
+		{mustParse(t, CircleKind, "<(0,0),1>"), mustParse(t, CircleKind, "<(5,0),1>"), 3},
+		{mustParse(t, CircleKind, "<(0,0),1>"), mustParse(t, PolygonKind, "((3,0),(3,1),(4,0))"), 2},
+		{mustParse(t, PathKind, "((0,0),(0,4),(4,4),(4,0))"), mustParse(t, PathKind, "[(1,1),(2,2)]"), 1},
+	}
+	for _, tc := range testCases {
+		d, err := Distance(tc.a, tc.b)
+		require.NoError(t, err)
+		require.InDelta(t, tc.exp, d, 1e-9, "%s %s", tc.a, tc.b)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		s   Shape
+		k   Kind
+		exp string
+		err bool
+	}{
+		{s: Point{X: 1, Y: 2}, k: BoxKind, exp: "(1,2),(1,2)"},
+		{s: mustParse(t, LSegKind, "[(0,0),(2,4)]"), k: PointKind, exp: "(1,2)"},
+		{s: mustParse(t, BoxKind, "(2,2),(0,0)"), k: PointKind, exp: "(1,1)"},
+		{s: mustParse(t, BoxKind, "(2,2),(0,0)"), k: LSegKind, exp: "[(2,2),(0,0)]"},
+		{s: mustParse(t, BoxKind, "(2,2),(0,0)"), k: PolygonKind, exp: "((0,0),(0,2),(2,2),(2,0))"},
+		{s: mustParse(t, BoxKind, "(2,2),(0,0)"), k: CircleKind, exp: "<(1,1),1.4142135623730951>"},
+		{s: mustParse(t, PathKind, "((0,0),(0,2),(2,2))"), k: PolygonKind, exp: "((0,0),(0,2),(2,2))"},
+		{s: mustParse(t, PathKind, "[(0,0),(0,2),(2,2)]"), k: PolygonKind, err: true},
+		{s: mustParse(t, PolygonKind, "((0,0),(0,2),(2,2),(2,0))"), k: PointKind, exp: "(1,1)"},
+		{s: mustParse(t, PolygonKind, "((0,0),(0,2),(2,2),(2,0))"), k: PathKind, exp: "((0,0),(0,2),(2,2),(2,0))"},
+		{s: mustParse(t, PolygonKind, "((0,0),(0,2),(3,2))"), k: BoxKind, exp: "(3,2),(0,0)"},
+		{s: mustParse(t, PolygonKind, "((0,0),(0,2),(2,2),(2,0))"), k: CircleKind, exp: "<(1,1),1.4142135623730951>"},
+		{s: mustParse(t, CircleKind, "<(1,1),2>"), k: PointKind, exp: "(1,1)"},
+		{s: mustParse(t, CircleKind, "<(0,0),2>"), k: BoxKind, exp: "(1.414213562373095,1.414213562373095),(-1.414213562373095,-1.414213562373095)"},
+		{s: mustParse(t, CircleKind, "<(0,0),0>"), k: PolygonKind, err: true},
+		{s: Point{}, k: CircleKind, err: true},
+	}
+	for _, tc := range testCases {
+		res, err := Convert(tc.s, tc.k)
+		if tc.err {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.exp, res.String())
+	}
+
+	p, err := MakePolygonFromCircle(Circle{Radius: 1}, 4)
+	require.NoError(t, err)
+	require.Len(t, p.Points, 4)
+	for _, pt := range p.Points {
+		require.InDelta(t, 1, math.Hypot(pt.X, pt.Y), 1e-9)
+	}
+	// The vertices go counter-clockwise starting at the leftmost point.
+	require.InDelta(t, -1, p.Points[0].X, 1e-9)
+	require.InDelta(t, 1, p.Points[1].Y, 1e-9)
+}
+
+func TestMeasures(t *testing.T) {
+	area, ok, err := Area(mustParse(t, BoxKind, "(2,3),(0,0)"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 6.0, area)
+
+	area, ok, err = Area(mustParse(t, PathKind, "((0,0),(0,2),(2,2),(2,0))"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 4.0, area)
+
+	_, ok, err = Area(mustParse(t, PathKind, "[(0,0),(0,2),(2,2),(2,0)]"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	l, err := Length(mustParse(t, PathKind, "((0,0),(0,2),(2,2),(2,0))"))
+	require.NoError(t, err)
+	require.Equal(t, 8.0, l)
+
+	l, err = Length(mustParse(t, PathKind, "[(0,0),(0,2),(2,2),(2,0)]"))
+	require.NoError(t, err)
+	require.Equal(t, 6.0, l)
+}
+
+func TestCompare(t *testing.T) {
+	require.Equal(t, -1, Compare(Point{X: 1, Y: 2}, Point{X: 1, Y: 3}))
+	require.Equal(t, 1, Compare(Point{X: 2, Y: 0}, Point{X: 1, Y: 3}))
+	require.Equal(t, 0, Compare(Point{X: 1, Y: 2}, Point{X: 1, Y: 2}))
+	require.Equal(t, -1, Compare(Point{X: math.NaN()}, Point{X: math.Inf(-1)}))
+	require.Equal(t, -1, Compare(
+		mustParse(t, PathKind, "[(0,0),(1,1)]"), mustParse(t, PathKind, "((0,0),(1,1))")))
+	require.Equal(t, -1, Compare(
+		mustParse(t, PolygonKind, "((0,0),(1,1))"), mustParse(t, PolygonKind, "((0,0),(1,1),(2,2))")))
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pggeom
+
+import "math/rand"
+
+// Random returns a random Shape of the given Kind for testing.
+func Random(rng *rand.Rand, k Kind) Shape {
+	switch k {
+	case PointKind:
+		return randPoint(rng)
+	case LSegKind:
+		return LSeg{P: [2]Point{randPoint(rng), randPoint(rng)}}
+	case BoxKind:
+		return MakeBox(randPoint(rng), randPoint(rng))
+	case PathKind:
+		return Path{Points: randPoints(rng), Closed: rng.Intn(2) == 0}
+	case PolygonKind:
+		return MakePolygon(randPoints(rng))
+	case LineKind:
+		p := randPoint(rng)
+		l, err := MakeLine(p, Point{X: p.X + 1 + rng.Float64(), Y: randCoord(rng)})
+		if err != nil {
+			panic(err)
+		}
+		return l
+	case CircleKind:
+		return Circle{Center: randPoint(rng), Radius: rng.Float64() * 100}
+	}
+	panic("unknown geometric kind")
+}
+
+// randCoord returns a random coordinate, which is an integer half of the time
+// so that the text representation is short.
+func randCoord(rng *rand.Rand) float64 {
+	if rng.Intn(2) == 0 {
+		return float64(rng.Intn(200) - 100)
+	}
+	return (rng.Float64() - 0.5) * 200
+}
+
+func randPoint(rng *rand.Rand) Point {
+	return Point{X: randCoord(rng), Y: randCoord(rng)}
+}
+
+func randPoints(rng *rand.Rand) []Point {
+	pts := make([]Point, 1+rng.Intn(5))
+	for i := range pts {
+		pts[i] = randPoint(rng)
+	}
+	return pts
+}
@@ -146,6 +146,17 @@ var (
 	// key info, such as the txn ID in the case of a transaction record.
 	LocalRangePrefix = roachpb.Key(makeKey(LocalPrefix, roachpb.RKey("k")))
 	LocalRangeMax    = LocalRangePrefix.PrefixEnd()
+	// LocalAdvisoryLockSuffix is the suffix for the keys locked by the SQL
+	// advisory locks (see pg_advisory_lock). The keys are anchored at the
+	// system database's table prefix of the tenant that owns the locks, so
+	// that they are addressable by the tenant and all sort together:
+	//
+	//   /Local/Range/<tenant prefix>/Table/1/advl/<db ID>/<class ID>/<obj ID>/<obj sub ID>
+	//
+	// where the IDs of the detail are uvarint-encoded. The db ID is that of
+	// the session's current database, and the other three IDs are those of
+	// the lock (see eval.AdvisoryLockKey).
+	LocalAdvisoryLockSuffix = roachpb.RKey("advl")
 	// LocalRangeProbeSuffix is the suffix for keys for probing.
 	LocalRangeProbeSuffix = roachpb.RKey("prbe")
 	// LocalQueueLastProcessedSuffix is the suffix for replica queue state keys.
@@ -203,10 +203,11 @@ var _ = [...]interface{}{
 	//   as a whole. They are replicated and addressable. Typical examples are
 	//   the range descriptor and transaction records. They all share
 	//   `LocalRangePrefix`.
-	RangeProbeKey,         // "prbe"
-	QueueLastProcessedKey, // "qlpt"
-	RangeDescriptorKey,    // "rdsc"
-	TransactionKey,        // "txn-"
+	SystemSQLCodec.AdvisoryLockKeyPrefix, // "advl"
+	RangeProbeKey,                        // "prbe"
+	QueueLastProcessedKey,                // "qlpt"
+	RangeDescriptorKey,                   // "rdsc"
+	TransactionKey,                       // "txn-"
 
 	//   4. Store local keys: These contain metadata about an individual store.
 	//   They are unreplicated and unaddressable. The typical example is the
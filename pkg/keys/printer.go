@@ -133,6 +133,7 @@ var (
 		{name: "Transaction", suffix: LocalTransactionSuffix, atEnd: false},
 		{name: "QueueLastProcessed", suffix: LocalQueueLastProcessedSuffix, atEnd: false},
 		{name: "RangeProbe", suffix: LocalRangeProbeSuffix, atEnd: true},
+		{name: "AdvisoryLock", suffix: LocalAdvisoryLockSuffix, atEnd: false},
 	}
 )
 
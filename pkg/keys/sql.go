@@ -228,6 +228,30 @@ func (e sqlEncoder) TenantMetadataKey(tenID roachpb.TenantID) roachpb.Key {
 	return MakeFamilyKey(k, 0)
 }
 
+// AdvisoryLockKeyPrefix returns the prefix of the range-local keys locked by
+// the tenant's advisory locks. See LocalAdvisoryLockSuffix for the layout of
+// the keys.
+func (e sqlEncoder) AdvisoryLockKeyPrefix() roachpb.Key {
+	return MakeRangeKey(e.advisoryLockAnchor(), LocalAdvisoryLockSuffix, nil)
+}
+
+// AdvisoryLockSpan returns a span that contains the range-local keys locked by
+// the tenant's advisory locks. A range request must span more than one
+// address, so the span also contains the other range-local keys that are
+// anchored at the same key and sort after the keys of the advisory locks.
+func (e sqlEncoder) AdvisoryLockSpan() roachpb.Span {
+	return roachpb.Span{
+		Key:    e.AdvisoryLockKeyPrefix(),
+		EndKey: MakeRangeKeyPrefix(e.advisoryLockAnchor().Next()),
+	}
+}
+
+// advisoryLockAnchor returns the key at which the keys locked by the tenant's
+// advisory locks are anchored.
+func (e sqlEncoder) advisoryLockAnchor() roachpb.RKey {
+	return roachpb.RKey(e.TablePrefix(SystemDatabaseID))
+}
+
 // SequenceKey returns the key used to store the value of a sequence.
 func (e sqlEncoder) SequenceKey(tableID uint32) roachpb.Key {
 	k := e.IndexPrefix(tableID, SequenceIndexID)
@@ -1856,6 +1856,7 @@ func (kl *keyLocks) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 	var txnHolder *enginepb.TxnMeta
 
 	durability := lock.Unreplicated
+	strength := lock.None
 	if kl.isLocked() {
 		// This doesn't work with multiple lock holders. See
 		// https://github.com/cockroachdb/cockroach/issues/109081.
@@ -1864,6 +1865,7 @@ func (kl *keyLocks) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		if tl.isHeldReplicated() {
 			durability = lock.Replicated
 		}
+		strength = tl.getLockMode().Strength
 	}
 
 	waiterCount := kl.waitingReaders.Len() + kl.queuedLockingRequests.Len()
@@ -1902,6 +1904,7 @@ func (kl *keyLocks) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		Durability:   durability,
 		HoldDuration: kl.lockHeldDuration(now),
 		Waiters:      lockWaiters,
+		LockStrength: strength,
 	}
 }
 
@@ -2009,9 +2012,13 @@ func (kl *keyLocks) informActiveWaiters() {
 		state := waitForState
 		if g.isSameTxn(waitForState.txn) {
 			if waitForState.held {
-				panic("request from the lock holder txn should not be waiting in a wait queue")
+				// The request is trying to promote the lock held by its transaction;
+				// it waits for the other lock holders instead.
+				state.txn, state.held = kl.claimantTxnFor(g)
+			}
+			if g.isSameTxn(state.txn) {
+				state.kind = waitSelf
 			}
-			state.kind = waitSelf
 		} else {
 			if findDistinguished {
 				kl.distinguishedWaiter = g
@@ -2069,6 +2076,28 @@ func (kl *keyLocks) claimantTxn() (_ *enginepb.TxnMeta, held bool) {
 	return qg.guard.txnMeta(), false
 }
 
+// claimantTxnFor is like claimantTxn, except it returns the transaction the
+// supplied request should push. The two only differ when the request's
+// transaction is the claimant and holds the lock, in which case the request is
+// trying to promote its transaction's lock (e.g. from Shared to Exclusive) and
+// is waiting for the other lock holders instead.
+//
+// REQUIRES: kl.mu to be locked.
+func (kl *keyLocks) claimantTxnFor(g *lockTableGuardImpl) (_ *enginepb.TxnMeta, held bool) {
+	txn, held := kl.claimantTxn()
+	if !held || !g.isSameTxn(txn) {
+		return txn, held
+	}
+	for e := kl.holders.Front(); e != nil; e = e.Next() {
+		if holderTxn := e.Value.getLockHolderTxn(); !g.isSameTxn(holderTxn) {
+			return holderTxn, true
+		}
+	}
+	// The request's transaction is the only lock holder. The request must be
+	// waiting for another request from its own transaction.
+	return txn, held
+}
+
 // releaseLockingRequestsFromTxn removes all locking requests waiting on the
 // key, referenced in the receiver, that are part of the specified transaction.
 //
@@ -2386,7 +2415,7 @@ func (kl *keyLocks) constructWaitingState(g *lockTableGuardImpl) waitingState {
 		queuedReaders:         kl.waitingReaders.Len(),
 		held:                  true,
 	}
-	txn, held := kl.claimantTxn()
+	txn, held := kl.claimantTxnFor(g)
 	waitForState.held = held
 	waitForState.txn = txn
 	if g.isSameTxn(waitForState.txn) {
@@ -2446,9 +2475,12 @@ func (kl *keyLocks) alreadyHoldsLockAndIsAllowedToProceed(
 // non-conflicting. However, the caller may be responsible for cleaning them up
 // before proceeding.
 //
+// A lock held by the request's own transaction never conflicts with the
+// request. If the request's transaction holds the lock, the request must be
+// trying to promote it (e.g. from Shared to Exclusive), which it can do once the
+// locks held by other transactions have been released.
+//
 // REQUIRES: kl.mu is locked.
-// REQUIRES: the transaction, to which the request belongs, should not be a lock
-// holder.
 func (kl *keyLocks) conflictsWithLockHolders(g *lockTableGuardImpl) bool {
 	if !kl.isLocked() {
 		return false // the lock isn't held; no conflict to speak of
@@ -2464,6 +2496,9 @@ func (kl *keyLocks) conflictsWithLockHolders(g *lockTableGuardImpl) bool {
 			!g.isSameTxn(lockHolderTxn) || g.curStrength() > tl.getLockMode().Strength,
 			"lock already held by the request's transaction with sufficient strength",
 		)
+		if g.isSameTxn(lockHolderTxn) {
+			continue // check next lock
+		}
 
 		finalizedTxn, ok := g.lt.txnStatusCache.finalizedTxns.get(lockHolderTxn.ID)
 		if ok {
@@ -2630,11 +2665,20 @@ func (kl *keyLocks) enqueueLockingRequest(g *lockTableGuardImpl) (maxQueueLength
 		active: true,
 	}
 	// The request isn't in the queue. Add it in the correct position, based on
-	// its sequence number.
+	// whether its transaction holds the lock and its sequence number. Requests
+	// from transactions that hold the lock are trying to promote it (e.g. from
+	// Shared to Exclusive); they're ordered ahead of requests from transactions
+	// that don't hold the lock. Those requests need to wait for the lock holders
+	// anyway, and ordering the promoting request behind them would deadlock.
+	promoting := kl.isPromotingRequest(g)
 	var e *list.Element[*queuedGuard]
 	for e = kl.queuedLockingRequests.Back(); e != nil; e = e.Prev() {
 		qqg := e.Value
-		if qqg.guard.seqNum < qg.guard.seqNum {
+		qqgPromoting := kl.isPromotingRequest(qqg.guard)
+		if qqgPromoting && !promoting {
+			break
+		}
+		if qqgPromoting == promoting && qqg.guard.seqNum < qg.guard.seqNum {
 			break
 		}
 	}
@@ -2650,6 +2694,15 @@ func (kl *keyLocks) enqueueLockingRequest(g *lockTableGuardImpl) (maxQueueLength
 	return false /* maxQueueLengthExceeded */
 }
 
+// isPromotingRequest returns whether the supplied request belongs to a
+// transaction that holds the lock. Such a locking request is trying to promote
+// the lock to a higher strength.
+//
+// REQUIRES: kl.mu to be locked.
+func (kl *keyLocks) isPromotingRequest(g *lockTableGuardImpl) bool {
+	return g.txn != nil && kl.isLockedBy(g.txn.ID)
+}
+
 // maybeMakeDistinguishedWaiter designates the supplied request as the
 // distinguished waiter if no distinguished waiter. If there is a distinguished
 // waiter, or the supplied request is not a candidate for becoming one[1], the
@@ -3139,6 +3192,8 @@ func (kl *keyLocks) tryUpdateLockLocked(up roachpb.LockUpdate) (heldByTxn, gc bo
 			// The lock transitioned from held to unheld as a result of this lock
 			// update.
 			gc = kl.releaseWaitersOnKeyUnlocked()
+		} else {
+			kl.releaseWaitersOnLockHolderRemoved()
 		}
 		return true, gc
 	}
@@ -3221,6 +3276,8 @@ func (kl *keyLocks) tryUpdateLockLocked(up roachpb.LockUpdate) (heldByTxn, gc bo
 		kl.clearLockHeldBy(txn.ID)
 		if !kl.isLocked() {
 			gc = kl.releaseWaitersOnKeyUnlocked()
+		} else {
+			kl.releaseWaitersOnLockHolderRemoved()
 		}
 		return true, gc
 	}
@@ -3442,6 +3499,44 @@ func (kl *keyLocks) releaseWaitersOnKeyUnlocked() (gc bool) {
 	return false
 }
 
+// releaseWaitersOnLockHolderRemoved is called when one of the transactions
+// holding the lock on the receiver's key releases it, but the key remains
+// locked by other transactions. Waiters from the remaining lock holders that
+// were trying to promote their lock may no longer conflict with any other lock
+// holder, in which case they're released. Other waiters may need to push a
+// different transaction.
+//
+// REQUIRES: kl.mu is locked.
+// REQUIRES: the (receiver) lock must be held.
+func (kl *keyLocks) releaseWaitersOnLockHolderRemoved() {
+	assert(kl.isLocked(), "releaseWaitersOnLockHolderRemoved should only be called on held locks")
+	for e := kl.queuedLockingRequests.Front(); e != nil; e = e.Next() {
+		qg := e.Value
+		g := qg.guard
+		if !kl.isPromotingRequest(g) {
+			// Promoting requests are at the front of the queue. Requests from
+			// transactions that don't hold the lock conflict with the lock holders.
+			break
+		}
+		if !qg.active {
+			continue
+		}
+		if kl.shouldRequestActivelyWait(g) {
+			continue
+		}
+		qg.active = false // claim the lock
+		if g == kl.distinguishedWaiter {
+			// A new one will be selected below in the call to informActiveWaiters.
+			kl.distinguishedWaiter = nil
+		}
+		g.mu.Lock()
+		g.doneActivelyWaitingAtLock()
+		g.mu.Unlock()
+	}
+	// Tell the remaining active waiters who they are waiting for.
+	kl.informActiveWaiters()
+}
+
 // maybeReleaseCompatibleLockingRequests goes through the list of locking
 // requests waiting in the receiver's wait queue and releases all requests from
 // the head of the queue that are compatible with each other. Releasing[1] a
@@ -0,0 +1,437 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// Advisory locks are backed by KV locks on the range-local keys reserved by
+// keys.LocalAdvisoryLockSuffix, so they are mutually exclusive across the
+// cluster and their waiters benefit from the deadlock detection of the lock
+// table. A lock is acquired by a locking read of its key with the strength of
+// the mode of the lock. Since KV only locks keys that exist, a value is first written to
+// the key if it does not exist yet. The KV locks are unreplicated, so that
+// they are tracked by the lock table even when they are uncontended, which
+// lets pg_locks display them.
+//
+// Transaction-level locks are acquired by the transaction of the session,
+// and are released by KV when it commits or rolls back. Each session-level
+// lock is acquired by a separate KV transaction that is never committed, and
+// that is rolled back to release the lock. All the locks of a session are
+// released when it ends; if the node of the session dies, its transactions
+// are eventually aborted by the transactions waiting on their locks.
+
+// advisoryLockValue is the value written to the keys of advisory locks.
+const advisoryLockValue = "advisory lock"
+
+// advisoryLockMode is the mode in which an advisory lock is held.
+type advisoryLockMode int8
+
+const (
+	advisoryLockShared advisoryLockMode = iota + 1
+	advisoryLockExclusive
+)
+
+func makeAdvisoryLockMode(shared bool) advisoryLockMode {
+	if shared {
+		return advisoryLockShared
+	}
+	return advisoryLockExclusive
+}
+
+// String returns the name of the mode, as displayed in pg_locks.
+func (m advisoryLockMode) String() string {
+	if m == advisoryLockShared {
+		return "ShareLock"
+	}
+	return "ExclusiveLock"
+}
+
+// advisoryLockID identifies an advisory lock across databases.
+type advisoryLockID struct {
+	dbID descpb.ID
+	key  eval.AdvisoryLockKey
+}
+
+// makeAdvisoryLockKey returns the KV key that is locked to acquire the given
+// advisory lock.
+func makeAdvisoryLockKey(codec keys.SQLCodec, id advisoryLockID) roachpb.Key {
+	k := codec.AdvisoryLockKeyPrefix()
+	k = encoding.EncodeUvarintAscending(k, uint64(id.dbID))
+	k = encoding.EncodeUvarintAscending(k, uint64(id.key.ClassID))
+	k = encoding.EncodeUvarintAscending(k, uint64(id.key.ObjID))
+	return encoding.EncodeUvarintAscending(k, uint64(id.key.ObjSubID))
+}
+
+// decodeAdvisoryLockKey is the inverse of makeAdvisoryLockKey.
+func decodeAdvisoryLockKey(codec keys.SQLCodec, key roachpb.Key) (advisoryLockID, error) {
+	var id advisoryLockID
+	rem, ok := bytes.CutPrefix(key, codec.AdvisoryLockKeyPrefix())
+	if !ok {
+		return id, errors.AssertionFailedf("%s is not the key of an advisory lock", key)
+	}
+	var vals [4]uint64
+	for i := range vals {
+		var err error
+		if rem, vals[i], err = encoding.DecodeUvarintAscending(rem); err != nil {
+			return id, err
+		}
+	}
+	id.dbID = descpb.ID(vals[0])
+	id.key = eval.AdvisoryLockKey{
+		ClassID:  uint32(vals[1]),
+		ObjID:    uint32(vals[2]),
+		ObjSubID: uint16(vals[3]),
+	}
+	return id, nil
+}
+
+// advisoryLocks tracks the advisory locks held by a session.
+type advisoryLocks struct {
+	// session contains the session-level locks held by the session.
+	session map[advisoryLockID]*sessionAdvisoryLock
+	// xact contains the strongest mode in which the current transaction holds
+	// each transaction-level lock. It is reset when the transaction ends.
+	xact map[advisoryLockID]advisoryLockMode
+}
+
+// sessionAdvisoryLock is a session-level advisory lock held by a session.
+// Following Postgres, a session can acquire a lock several times, in both
+// modes, and holds it until it has released it as many times.
+type sessionAdvisoryLock struct {
+	// txn is the KV transaction that holds the lock.
+	txn *kv.Txn
+	// mode is the mode in which txn holds the lock, which is the mode of the
+	// first hold. Further holds in shared mode are allowed if the lock is held
+	// in exclusive mode, and the lock remains held in exclusive mode until all
+	// the holds are released.
+	mode advisoryLockMode
+	// exclusive and shared are the number of holds of the lock in each mode.
+	exclusive, shared int
+}
+
+// resetXact forgets the transaction-level locks of the transaction that just
+// finished.
+func (l *advisoryLocks) resetXact() {
+	l.xact = nil
+}
+
+// releaseAll releases all the session-level locks of the session.
+func (l *advisoryLocks) releaseAll(ctx context.Context) {
+	for id, sl := range l.session {
+		if err := sl.txn.Rollback(ctx); err != nil {
+			log.Warningf(ctx, "unable to release advisory lock: %v", err)
+		}
+		delete(l.session, id)
+	}
+}
+
+// ownsTxn returns whether the KV transaction with the given ID holds
+// session-level locks on behalf of the session.
+func (l *advisoryLocks) ownsTxn(txnID uuid.UUID) bool {
+	for _, sl := range l.session {
+		if sl.txn.ID() == txnID {
+			return true
+		}
+	}
+	return false
+}
+
+// errAdvisoryLockScopes is returned when a session tries to acquire an
+// advisory lock that it holds at the other level in a conflicting mode. The
+// session would otherwise wait on itself, since the locks of each level are
+// held by different KV transactions.
+var errAdvisoryLockScopes = pgerror.New(pgcode.FeatureNotSupported,
+	"cannot hold an advisory lock at both the session and the transaction level "+
+		"unless both are shared")
+
+// errAdvisoryLockUpgrade is returned when a session tries to acquire in
+// exclusive mode a session-level advisory lock that it holds in shared mode.
+// The KV transaction holding the lock could promote it, but it could not
+// demote it back to shared mode when the exclusive holds are released.
+var errAdvisoryLockUpgrade = pgerror.New(pgcode.FeatureNotSupported,
+	"cannot upgrade a shared advisory lock to an exclusive lock")
+
+func (p *planner) advisoryLockID(
+	ctx context.Context, key eval.AdvisoryLockKey,
+) (advisoryLockID, error) {
+	if p.advisoryLocks == nil {
+		return advisoryLockID{}, pgerror.New(pgcode.FeatureNotSupported,
+			"advisory locks are not supported in this context")
+	}
+	id := advisoryLockID{key: key}
+	if p.CurrentDatabase() != "" {
+		db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+		if err != nil {
+			return advisoryLockID{}, err
+		}
+		id.dbID = db.GetID()
+	}
+	return id, nil
+}
+
+// AcquireAdvisoryLock is part of the eval.Planner interface.
+func (p *planner) AcquireAdvisoryLock(
+	ctx context.Context, key eval.AdvisoryLockKey, shared, xact, try bool,
+) (bool, error) {
+	id, err := p.advisoryLockID(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if xact {
+		return p.acquireXactAdvisoryLock(ctx, id, makeAdvisoryLockMode(shared), try)
+	}
+	return p.acquireSessionAdvisoryLock(ctx, id, makeAdvisoryLockMode(shared), try)
+}
+
+func (p *planner) acquireXactAdvisoryLock(
+	ctx context.Context, id advisoryLockID, mode advisoryLockMode, try bool,
+) (bool, error) {
+	l := p.advisoryLocks
+	if xactMode, ok := l.xact[id]; ok && xactMode >= mode {
+		return true, nil
+	}
+	if sl, ok := l.session[id]; ok {
+		if sl.mode >= mode {
+			// The session-level lock outlives the transaction.
+			return true, nil
+		}
+		return false, errAdvisoryLockScopes
+	}
+	// If the transaction holds the lock in shared mode, this promotes it to
+	// exclusive mode until the end of the transaction.
+	ok, err := p.acquireAdvisoryLockInTxn(ctx, p.txn, id, mode, try)
+	if err != nil || !ok {
+		return false, err
+	}
+	if l.xact == nil {
+		l.xact = make(map[advisoryLockID]advisoryLockMode)
+	}
+	l.xact[id] = mode
+	return true, nil
+}
+
+func (p *planner) acquireSessionAdvisoryLock(
+	ctx context.Context, id advisoryLockID, mode advisoryLockMode, try bool,
+) (bool, error) {
+	l := p.advisoryLocks
+	if xactMode, ok := l.xact[id]; ok && (xactMode == advisoryLockExclusive || mode == advisoryLockExclusive) {
+		return false, errAdvisoryLockScopes
+	}
+	sl, ok := l.session[id]
+	if ok {
+		if sl.mode < mode {
+			return false, errAdvisoryLockUpgrade
+		}
+	} else {
+		nodeID, _ := p.execCfg.NodeInfo.NodeID.OptionalNodeID()
+		sl = &sessionAdvisoryLock{txn: kv.NewTxn(ctx, p.execCfg.DB, nodeID), mode: mode}
+		sl.txn.SetDebugName("advisory lock")
+		acquired, err := p.acquireAdvisoryLockInTxn(ctx, sl.txn, id, mode, try)
+		if err != nil || !acquired {
+			if rollbackErr := sl.txn.Rollback(ctx); rollbackErr != nil {
+				log.Warningf(ctx, "unable to release advisory lock: %v", rollbackErr)
+			}
+			return false, err
+		}
+		if l.session == nil {
+			l.session = make(map[advisoryLockID]*sessionAdvisoryLock)
+		}
+		l.session[id] = sl
+	}
+	if mode == advisoryLockShared {
+		sl.shared++
+	} else {
+		sl.exclusive++
+	}
+	return true, nil
+}
+
+// acquireAdvisoryLockInTxn locks the key of the given advisory lock in the
+// given KV transaction. If try is true, false is returned rather than waiting
+// if the key is locked by another transaction in a conflicting mode.
+func (p *planner) acquireAdvisoryLockInTxn(
+	ctx context.Context, txn *kv.Txn, id advisoryLockID, mode advisoryLockMode, try bool,
+) (bool, error) {
+	key := makeAdvisoryLockKey(p.execCfg.Codec, id)
+	if ok, err := p.ensureAdvisoryLockKey(ctx, key, try); err != nil || !ok {
+		return false, err
+	}
+	str := lock.Exclusive
+	if mode == advisoryLockShared {
+		str = lock.Shared
+	}
+	b := txn.NewBatch()
+	b.AddRawRequest(&kvpb.GetRequest{
+		RequestHeader: kvpb.RequestHeader{Key: key},
+		KeyLocking:    str,
+	})
+	if try {
+		b.Header.WaitPolicy = lock.WaitPolicy_Error
+	}
+	if err := txn.Run(ctx, b); err != nil {
+		if try && errors.HasType(err, (*kvpb.LockConflictError)(nil)) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ensureAdvisoryLockKey writes a value to the given key of an advisory lock if
+// it does not exist yet, so that it can be locked by a locking read. The value
+// is never removed. If try is true, false is returned rather than waiting if
+// the key does not exist and is locked by another transaction.
+func (p *planner) ensureAdvisoryLockKey(
+	ctx context.Context, key roachpb.Key, try bool,
+) (bool, error) {
+	// An inconsistent read does not wait on the locks on the key, and always
+	// returns the value once it has been committed.
+	b := &kv.Batch{}
+	b.Header.ReadConsistency = kvpb.INCONSISTENT
+	b.Get(key)
+	if err := p.execCfg.DB.Run(ctx, b); err != nil {
+		return false, err
+	}
+	if b.Results[0].Rows[0].Exists() {
+		return true, nil
+	}
+	b = &kv.Batch{}
+	b.CPut(key, advisoryLockValue, nil /* expValue */)
+	if try {
+		b.Header.WaitPolicy = lock.WaitPolicy_Error
+	}
+	if err := p.execCfg.DB.Run(ctx, b); err != nil {
+		switch {
+		case errors.HasType(err, (*kvpb.ConditionFailedError)(nil)):
+			// The value was written concurrently.
+			return true, nil
+		case try && errors.HasType(err, (*kvpb.LockConflictError)(nil)):
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ReleaseAdvisoryLock is part of the eval.Planner interface.
+func (p *planner) ReleaseAdvisoryLock(
+	ctx context.Context, key eval.AdvisoryLockKey, shared bool,
+) (bool, error) {
+	id, err := p.advisoryLockID(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	l := p.advisoryLocks
+	mode := makeAdvisoryLockMode(shared)
+	sl, ok := l.session[id]
+	if ok {
+		count := &sl.exclusive
+		if mode == advisoryLockShared {
+			count = &sl.shared
+		}
+		ok = *count > 0
+		if ok {
+			*count--
+		}
+	}
+	if !ok {
+		p.BufferClientNotice(ctx, pgnotice.NewWithSeverityf(
+			"WARNING", "you don't own a lock of type %s", mode,
+		))
+		return false, nil
+	}
+	if sl.exclusive == 0 && sl.shared == 0 {
+		delete(l.session, id)
+		if err := sl.txn.Rollback(ctx); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ReleaseAllAdvisoryLocks is part of the eval.Planner interface.
+func (p *planner) ReleaseAllAdvisoryLocks(ctx context.Context) error {
+	if p.advisoryLocks == nil {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"advisory locks are not supported in this context")
+	}
+	p.advisoryLocks.releaseAll(ctx)
+	return nil
+}
+
+// forEachAdvisoryLock calls fn for each holder and waiter of the advisory
+// locks known to the lock table, across all the databases.
+func (p *planner) forEachAdvisoryLock(
+	ctx context.Context,
+	fn func(id advisoryLockID, txn *enginepb.TxnMeta, str lock.Strength, granted bool) error,
+) error {
+	prefix := p.execCfg.Codec.AdvisoryLockKeyPrefix()
+	lockSpan := p.execCfg.Codec.AdvisoryLockSpan()
+	span := &lockSpan
+	for span != nil {
+		b := p.txn.NewBatch()
+		b.AddRawRequest(&kvpb.QueryLocksRequest{
+			RequestHeader:      kvpb.RequestHeaderFromSpan(*span),
+			IncludeUncontended: true,
+		})
+		b.Header.MaxSpanRequestKeys = int64(rowinfra.ProductionKVBatchSize)
+		if err := p.txn.Run(ctx, b); err != nil {
+			return err
+		}
+		resp := b.RawResponse().Responses[0].GetQueryLocks()
+		for i := range resp.Locks {
+			l := &resp.Locks[i]
+			if !bytes.HasPrefix(l.Key, prefix) {
+				// The span also contains other range-local keys.
+				continue
+			}
+			id, err := decodeAdvisoryLockKey(p.execCfg.Codec, l.Key)
+			if err != nil {
+				return err
+			}
+			if l.LockHolder != nil {
+				if err := fn(id, l.LockHolder, l.LockStrength, true /* granted */); err != nil {
+					return err
+				}
+			}
+			for _, w := range l.Waiters {
+				if w.WaitingTxn == nil {
+					continue
+				}
+				if err := fn(id, w.WaitingTxn, w.Strength, false /* granted */); err != nil {
+					return err
+				}
+			}
+		}
+		span = resp.ResumeSpan
+	}
+	return nil
+}
@@ -81,6 +81,16 @@ func AlterColumnType(
 	if err := schemaexpr.ValidateTTLExpressionDoesNotDependOnColumn(tableDesc, tableDesc.GetRowLevelTTL(), col); err != nil {
 		return err
 	}
+	for i := range tableDesc.Policies {
+		referenced, err := policyReferencesColumn(tableDesc, &tableDesc.Policies[i], col.GetID())
+		if err != nil {
+			return err
+		}
+		if referenced {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot alter type of a column used in a policy definition")
+		}
+	}
 
 	typ, err := tree.ResolveType(ctx, t.ToType, params.p.semaCtx.GetTypeResolver())
 	if err != nil {
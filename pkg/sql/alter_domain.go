@@ -0,0 +1,235 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the type.
+	prefix, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Type, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(
+			pgcode.WrongObjectType,
+			"%q is not a domain",
+			tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations),
+		)
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	// Renaming the domain, changing its schema and changing its owner are
+	// handled in the same way as for other types.
+	if cmd, ok := n.Cmd.(tree.AlterTypeCmd); ok {
+		return &alterTypeNode{
+			n:      &tree.AlterType{Type: n.Type, Cmd: cmd},
+			prefix: prefix,
+			desc:   desc,
+		}, nil
+	}
+	return &alterDomainNode{
+		n:    n,
+		desc: desc,
+	}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	p := params.p
+	domain := n.desc.Domain
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		if t.Default == nil {
+			domain.DefaultExpr = nil
+			break
+		}
+		defaultExpr, err := p.validateDomainDefaultExpr(params.ctx, t.Default, domain.BaseType)
+		if err != nil {
+			return err
+		}
+		domain.DefaultExpr = defaultExpr
+	case *tree.AlterDomainSetNotNull:
+		if t.NotNull && !domain.NotNull {
+			if err := p.validateDomainColumns(params.ctx, n.desc, tree.Name(n.desc.Name), ""); err != nil {
+				return err
+			}
+		}
+		domain.NotNull = t.NotNull
+	case *tree.AlterDomainAddConstraint:
+		c := &t.Constraint
+		switch {
+		case c.Check != nil:
+			if err := p.addDomainCheck(params.ctx, domain, n.desc.Name, c); err != nil {
+				return err
+			}
+			check := &domain.Checks[len(domain.Checks)-1]
+			if err := p.validateDomainColumns(params.ctx, n.desc, tree.Name(check.Name), check.Expr); err != nil {
+				return err
+			}
+		case c.NotNull:
+			if !domain.NotNull {
+				if err := p.validateDomainColumns(params.ctx, n.desc, tree.Name(n.desc.Name), ""); err != nil {
+					return err
+				}
+			}
+			domain.NotNull = true
+		default:
+			return pgerror.New(pgcode.FeatureNotSupported, "NULL constraints cannot be added to a domain")
+		}
+	case *tree.AlterDomainDropConstraint:
+		i := findDomainCheck(domain, string(t.Constraint))
+		if i == -1 {
+			if t.IfExists {
+				p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"constraint %q of domain %q does not exist, skipping", t.Constraint, n.desc.Name,
+				))
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		domain.Checks = append(domain.Checks[:i], domain.Checks[i+1:]...)
+	case *tree.AlterDomainRenameConstraint:
+		i := findDomainCheck(domain, string(t.Constraint))
+		if i == -1 {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		if findDomainCheck(domain, string(t.NewName)) != -1 {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", t.NewName, n.desc.Name)
+		}
+		domain.Checks[i].Name = string(t.NewName)
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, p.Ann()),
+	); err != nil {
+		return err
+	}
+	return p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: tree.AsStringWithFQNames(n.n.Type, p.Ann()),
+		})
+}
+
+// validateDomainColumns checks that the values stored in the columns of the
+// given domain type satisfy a constraint that is being added to the domain.
+// If checkExpr is empty the constraint is NOT NULL, otherwise it is the
+// serialized expression of a CHECK constraint.
+func (p *planner) validateDomainColumns(
+	ctx context.Context, desc *typedesc.Mutable, constraintName tree.Name, checkExpr string,
+) error {
+	domainOID := catid.TypeIDToOID(desc.ID)
+	for _, id := range desc.ReferencingDescriptorIDs {
+		d, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl, ok := d.(catalog.TableDescriptor)
+		if !ok || !tbl.IsPhysicalTable() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if col.IsVirtual() || col.GetType().Oid() != domainOID {
+				continue
+			}
+			colName := tree.Name(col.GetName())
+			var pred string
+			if checkExpr == "" {
+				pred = fmt.Sprintf("%s IS NULL", tree.AsString(&colName))
+			} else {
+				expr, err := parser.ParseExpr(checkExpr)
+				if err != nil {
+					return err
+				}
+				expr, err = eval.ReplaceDomainValue(expr, &tree.ColumnItem{ColumnName: colName})
+				if err != nil {
+					return err
+				}
+				pred = fmt.Sprintf("NOT (%s)", tree.Serialize(expr))
+			}
+			query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s LIMIT 1`, tbl.GetID(), pred)
+			log.Infof(ctx, "validating domain constraint %q with query %q", constraintName, query)
+			row, err := p.InternalSQLTxn().QueryRowEx(
+				ctx,
+				"validate domain constraint",
+				p.txn,
+				sessiondata.RootUserSessionDataOverride,
+				query,
+			)
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				continue
+			}
+			if checkExpr == "" {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values", col.GetName(), tbl.GetName())
+			}
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				col.GetName(), tbl.GetName())
+		}
+	}
+	return nil
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
@@ -67,7 +67,7 @@ func (p *planner) AlterFunctionOptions(
 func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("function"))
 
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, false /* isAggregate */)
 	if err != nil {
 		return err
 	}
@@ -156,7 +156,7 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -227,7 +227,7 @@ func (p *planner) AlterFunctionSetOwner(
 
 func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("function"))
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -291,7 +291,7 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -400,12 +400,15 @@ func (n *alterFunctionDepExtensionNode) Values() tree.Datums                 { r
 func (n *alterFunctionDepExtensionNode) Close(ctx context.Context)           {}
 
 func (p *planner) mustGetMutableFunctionForAlter(
-	ctx context.Context, funcObj *tree.FuncObj,
+	ctx context.Context, funcObj *tree.FuncObj, isAggregate bool,
 ) (*funcdesc.Mutable, error) {
 	ol, err := p.matchUDF(ctx, funcObj, true /*required*/)
 	if err != nil {
 		return nil, err
 	}
+	if (ol.Class == tree.AggregateClass) != isAggregate {
+		return nil, errWrongRoutineKind(funcObj.FuncName.Object(), false /* expectProcedure */, isAggregate)
+	}
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 	mut, err := p.checkPrivilegesForDropFunction(ctx, fnID)
 	if err != nil {
@@ -416,10 +419,12 @@ func (p *planner) mustGetMutableFunctionForAlter(
 
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionSignature {
 	ret := descpb.SchemaDescriptor_FunctionSignature{
-		ID:         fnDesc.GetID(),
-		ArgTypes:   make([]*types.T, len(fnDesc.GetParams())),
-		ReturnType: fnDesc.ReturnType.Type,
-		ReturnSet:  fnDesc.ReturnType.ReturnSet,
+		ID:          fnDesc.GetID(),
+		ArgTypes:    make([]*types.T, len(fnDesc.GetParams())),
+		ReturnType:  fnDesc.ReturnType.Type,
+		ReturnSet:   fnDesc.ReturnType.ReturnSet,
+		IsProcedure: fnDesc.IsProcedure,
+		IsAggregate: fnDesc.Aggregate != nil,
 	}
 	for i := range fnDesc.Params {
 		ret.ArgTypes[i] = fnDesc.Params[i].Type
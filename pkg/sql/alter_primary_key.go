@@ -72,6 +72,15 @@ func (p *planner) AlterPrimaryKey(
 		return err
 	}
 
+	// BRIN indexes summarize blocks of values of the leading primary key
+	// column, so they can't survive a change of the primary key.
+	for _, idx := range tableDesc.NonDropIndexes() {
+		if idx.GetType() == descpb.IndexDescriptor_BRIN {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot change the primary key of a table with BRIN index %q", idx.GetName())
+		}
+	}
+
 	if alterPrimaryKeyLocalitySwap != nil {
 		if err := p.checkNoRegionChangeUnderway(
 			ctx,
@@ -142,6 +142,18 @@ func (p *planner) checkPasswordOptionConstraints(
 	return nil
 }
 
+// checkBypassRLSOptionConstraints checks that only admins can set the
+// BYPASSRLS role option, since it exempts a role from the row-level security
+// policies of every table.
+func (p *planner) checkBypassRLSOptionConstraints(
+	ctx context.Context, roleOptions roleoption.List,
+) error {
+	if roleOptions.Contains(roleoption.BYPASSRLS) || roleOptions.Contains(roleoption.NOBYPASSRLS) {
+		return p.RequireAdminRole(ctx, "change the BYPASSRLS role option")
+	}
+	return nil
+}
+
 func (n *alterRoleNode) startExec(params runParams) error {
 	var opName string
 	if n.isRole {
@@ -190,6 +202,9 @@ func (n *alterRoleNode) startExec(params runParams) error {
 			return err
 		}
 	}
+	if err := params.p.checkBypassRLSOptionConstraints(params.ctx, n.roleOptions); err != nil {
+		return err
+	}
 
 	// Check if role exists.
 	row, err := params.p.InternalSQLTxn().QueryRowEx(
@@ -332,7 +347,7 @@ func (p *planner) AlterRoleSet(ctx context.Context, n *tree.AlterRoleSet) (planN
 		dbDescID = dbDesc.GetID()
 	}
 
-	setVarKind, varName, sVar, typedValues, err := p.processSetOrResetClause(ctx, n.SetOrReset)
+	setVarKind, varName, sVar, typedValues, err := p.processSetOrResetClause(ctx, n.SetOrReset, "ALTER ROLE ... SET ")
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +366,7 @@ func (p *planner) AlterRoleSet(ctx context.Context, n *tree.AlterRoleSet) (planN
 }
 
 func (p *planner) processSetOrResetClause(
-	ctx context.Context, setOrResetClause *tree.SetVar,
+	ctx context.Context, setOrResetClause *tree.SetVar, typingContext string,
 ) (
 	setVarKind setVarBehavior,
 	varName string,
@@ -409,7 +424,7 @@ func (p *planner) processSetOrResetClause(
 		expr = paramparse.UnresolvedNameToStrVal(expr)
 
 		typedValue, err := p.analyzeExpr(
-			ctx, expr, nil, tree.IndexedVarHelper{}, types.String, false, "ALTER ROLE ... SET ",
+			ctx, expr, nil, tree.IndexedVarHelper{}, types.String, false, typingContext,
 		)
 		if err != nil {
 			return unknown, "", sessionVar{}, nil, wrapSetVarError(err, varName, expr.String())
@@ -669,20 +684,29 @@ func (n *alterRoleSetNode) getSessionVarVal(params runParams) (string, error) {
 	if n.varName == "" || n.typedValues == nil {
 		return "", nil
 	}
-	for i, v := range n.typedValues {
+	return evalSessionVarVal(params, n.varName, n.sVar, n.typedValues)
+}
+
+// evalSessionVarVal evaluates the values of a SET clause that was processed
+// by processSetOrResetClause and returns the resulting string value of the
+// session variable. The value is validated, but not applied to any session.
+func evalSessionVarVal(
+	params runParams, varName string, sVar sessionVar, typedValues []tree.TypedExpr,
+) (string, error) {
+	for i, v := range typedValues {
 		d, err := eval.Expr(params.ctx, params.EvalContext(), v)
 		if err != nil {
 			return "", err
 		}
-		n.typedValues[i] = d
+		typedValues[i] = d
 	}
 	var strVal string
 	var err error
-	if n.sVar.GetStringVal != nil {
-		strVal, err = n.sVar.GetStringVal(params.ctx, params.extendedEvalCtx, n.typedValues, params.p.Txn())
+	if sVar.GetStringVal != nil {
+		strVal, err = sVar.GetStringVal(params.ctx, params.extendedEvalCtx, typedValues, params.p.Txn())
 	} else {
 		// No string converter defined, use the default one.
-		strVal, err = getStringVal(params.ctx, params.EvalContext(), n.varName, n.typedValues)
+		strVal, err = getStringVal(params.ctx, params.EvalContext(), varName, typedValues)
 	}
 	if err != nil {
 		return "", err
@@ -690,7 +714,7 @@ func (n *alterRoleSetNode) getSessionVarVal(params runParams) (string, error) {
 
 	// Validate the new string value, but don't actually apply it to any real
 	// session.
-	if err := CheckSessionVariableValueValid(params.ctx, params.ExecCfg().Settings, n.varName, strVal); err != nil {
+	if err := CheckSessionVariableValueValid(params.ctx, params.ExecCfg().Settings, varName, strVal); err != nil {
 		return "", err
 	}
 	return strVal, nil
@@ -232,6 +232,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 					}
 					continue
 				}
+				if d.Deferrability.Deferrable {
+					return tree.NewDeferrableUniqueIndexError()
+				}
 
 				if d.PrimaryKey {
 					if t.ValidationBehavior == tree.ValidationSkip {
@@ -366,6 +369,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExclusionConstraintTableDef:
+				if err := addExclusionTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -551,53 +568,18 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 
 		case *tree.AlterTableValidateConstraint:
-			name := string(t.Constraint)
-			c := catalog.FindConstraintByName(n.tableDesc, name)
-			if c == nil {
-				return sqlerrors.NewUndefinedConstraintError(string(t.Constraint), n.tableDesc.Name)
-			}
-			switch c.GetConstraintValidity() {
-			case descpb.ConstraintValidity_Validated:
-				// Nothing to do.
-				continue
-			case descpb.ConstraintValidity_Validating:
-				return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
-					"constraint %q in the middle of being added, try again later", t.Constraint)
-			case descpb.ConstraintValidity_Dropping:
-				return sqlerrors.NewUndefinedConstraintError(string(t.Constraint), n.tableDesc.Name)
+			changed, err := n.validateConstraint(params, t.Constraint)
+			if err != nil {
+				return err
 			}
-			if ck := c.AsCheck(); ck != nil {
-				if err := validateCheckInTxn(
-					params.ctx, params.p.InternalSQLTxn(), &params.p.semaCtx,
-					params.p.SessionData(), n.tableDesc, ck.GetExpr(),
-				); err != nil {
-					return err
-				}
-				ck.CheckDesc().Validity = descpb.ConstraintValidity_Validated
-			} else if fk := c.AsForeignKey(); fk != nil {
-				if err := validateFkInTxn(
-					params.ctx, params.p.InternalSQLTxn(), n.tableDesc, name,
-				); err != nil {
-					return err
-				}
-				fk.ForeignKeyDesc().Validity = descpb.ConstraintValidity_Validated
-			} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
-				if err := validateUniqueWithoutIndexConstraintInTxn(
-					params.ctx,
-					params.p.InternalSQLTxn(),
-					n.tableDesc,
-					params.p.User(),
-					name,
-				); err != nil {
-					return err
-				}
-				uwoi.UniqueWithoutIndexDesc().Validity = descpb.ConstraintValidity_Validated
-			} else {
-				return pgerror.Newf(pgcode.WrongObjectType,
-					"constraint %q of relation %q is not a foreign key, check, or unique without index"+
-						" constraint", tree.ErrString(&t.Constraint), tree.ErrString(n.n.Table))
+			descriptorChanged = descriptorChanged || changed
+
+		case *tree.AlterTableAlterConstraint:
+			changed, err := n.alterConstraint(params, t)
+			if err != nil {
+				return err
 			}
-			descriptorChanged = true
+			descriptorChanged = descriptorChanged || changed
 
 		case tree.ColumnMutationCmd:
 			// Column mutations
@@ -703,6 +685,25 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableRowLevelSecurity:
+			if err := params.p.checkTableOwnership(params.ctx, n.tableDesc); err != nil {
+				return err
+			}
+			changed := false
+			switch t.Mode {
+			case tree.RowLevelSecurityEnable, tree.RowLevelSecurityDisable:
+				enabled := t.Mode == tree.RowLevelSecurityEnable
+				changed = n.tableDesc.RowLevelSecurity != enabled
+				n.tableDesc.RowLevelSecurity = enabled
+			case tree.RowLevelSecurityForce, tree.RowLevelSecurityNoForce:
+				forced := t.Mode == tree.RowLevelSecurityForce
+				changed = n.tableDesc.ForceRowLevelSecurity != forced
+				n.tableDesc.ForceRowLevelSecurity = forced
+			default:
+				return errors.AssertionFailedf("unknown row-level security mode %s", t.Mode)
+			}
+			descriptorChanged = descriptorChanged || changed
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -737,6 +738,15 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if err != nil {
 				return err
 			}
+			rollingPartitioningChanged, err := handleRollingPartitionStorageParamChange(
+				params,
+				setter.TableDesc,
+				setter.UpdatedRollingPartitioning,
+			)
+			if err != nil {
+				return err
+			}
+			descriptorChanged = descriptorChanged || rollingPartitioningChanged
 
 		case *tree.AlterTableResetStorageParams:
 			setter := tablestorageparam.NewSetter(n.tableDesc)
@@ -759,6 +769,15 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if err != nil {
 				return err
 			}
+			rollingPartitioningChanged, err := handleRollingPartitionStorageParamChange(
+				params,
+				setter.TableDesc,
+				setter.UpdatedRollingPartitioning,
+			)
+			if err != nil {
+				return err
+			}
+			descriptorChanged = descriptorChanged || rollingPartitioningChanged
 
 		case *tree.AlterTableRenameColumn:
 			tableDesc := n.tableDesc
@@ -1436,6 +1455,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1564,6 +1586,186 @@ func (p *planner) updateFKBackReferenceName(
 	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
 }
 
+// validateConstraint validates an unvalidated check, foreign key or unique
+// without index constraint. It returns false if the constraint was already
+// validated.
+func (n *alterTableNode) validateConstraint(
+	params runParams, constraintName tree.Name,
+) (descriptorChanged bool, _ error) {
+	name := string(constraintName)
+	c := catalog.FindConstraintByName(n.tableDesc, name)
+	if c == nil {
+		return false, sqlerrors.NewUndefinedConstraintError(name, n.tableDesc.Name)
+	}
+	switch c.GetConstraintValidity() {
+	case descpb.ConstraintValidity_Validated:
+		// Nothing to do.
+		return false, nil
+	case descpb.ConstraintValidity_Validating:
+		return false, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"constraint %q in the middle of being added, try again later", constraintName)
+	case descpb.ConstraintValidity_Dropping:
+		return false, sqlerrors.NewUndefinedConstraintError(name, n.tableDesc.Name)
+	}
+	if ck := c.AsCheck(); ck != nil {
+		if err := validateCheckInTxn(
+			params.ctx, params.p.InternalSQLTxn(), &params.p.semaCtx,
+			params.p.SessionData(), n.tableDesc, ck.GetExpr(),
+		); err != nil {
+			return false, err
+		}
+		ck.CheckDesc().Validity = descpb.ConstraintValidity_Validated
+	} else if fk := c.AsForeignKey(); fk != nil {
+		if err := validateFkInTxn(
+			params.ctx, params.p.InternalSQLTxn(), n.tableDesc, name,
+		); err != nil {
+			return false, err
+		}
+		fk.ForeignKeyDesc().Validity = descpb.ConstraintValidity_Validated
+	} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		if err := validateUniqueWithoutIndexConstraintInTxn(
+			params.ctx,
+			params.p.InternalSQLTxn(),
+			n.tableDesc,
+			params.p.User(),
+			name,
+		); err != nil {
+			return false, err
+		}
+		uwoi.UniqueWithoutIndexDesc().Validity = descpb.ConstraintValidity_Validated
+	} else {
+		return false, pgerror.Newf(pgcode.WrongObjectType,
+			"constraint %q of relation %q is not a foreign key, check, or unique without index"+
+				" constraint", tree.ErrString(&constraintName), tree.ErrString(n.n.Table))
+	}
+	return true, nil
+}
+
+// alterConstraint changes the reference actions and the validation state of
+// a constraint in place.
+func (n *alterTableNode) alterConstraint(
+	params runParams, t *tree.AlterTableAlterConstraint,
+) (descriptorChanged bool, _ error) {
+	name := string(t.Constraint)
+	c := catalog.FindConstraintByName(n.tableDesc, name)
+	if c == nil {
+		return false, sqlerrors.NewUndefinedConstraintError(name, n.tableDesc.Name)
+	}
+	switch c.GetConstraintValidity() {
+	case descpb.ConstraintValidity_Validating:
+		return false, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"constraint %q in the middle of being added, try again later", t.Constraint)
+	case descpb.ConstraintValidity_Dropping:
+		return false, sqlerrors.NewUndefinedConstraintError(name, n.tableDesc.Name)
+	}
+	if c.AsCheck() == nil && c.AsForeignKey() == nil && c.AsUniqueWithoutIndex() == nil {
+		return false, pgerror.Newf(pgcode.WrongObjectType,
+			"constraint %q of relation %q is not a foreign key, check, or unique without index"+
+				" constraint", tree.ErrString(&t.Constraint), tree.ErrString(n.n.Table))
+	}
+	if t.SetDeleteAction || t.SetUpdateAction {
+		fk := c.AsForeignKey()
+		if fk == nil {
+			return false, pgerror.Newf(pgcode.WrongObjectType,
+				"constraint %q of relation %q is not a foreign key constraint",
+				tree.ErrString(&t.Constraint), tree.ErrString(n.n.Table))
+		}
+		ref := fk.ForeignKeyDesc()
+		actions := tree.ReferenceActions{
+			Delete: tree.ForeignKeyReferenceActionType[ref.OnDelete],
+			Update: tree.ForeignKeyReferenceActionType[ref.OnUpdate],
+		}
+		if t.SetDeleteAction {
+			actions.Delete = t.Actions.Delete
+		}
+		if t.SetUpdateAction {
+			actions.Update = t.Actions.Update
+		}
+		for _, colID := range ref.OriginColumnIDs {
+			col, err := catalog.MustFindColumnByID(n.tableDesc, colID)
+			if err != nil {
+				return false, err
+			}
+			if t.SetUpdateAction && actions.Update != tree.NoAction &&
+				actions.Update != tree.Restrict && col.HasOnUpdate() {
+				return false, pgerror.Newf(
+					pgcode.InvalidTableDefinition,
+					"cannot specify a foreign key update action and an ON UPDATE"+
+						" expression on the same column",
+				)
+			}
+			if (actions.Delete == tree.SetNull || actions.Update == tree.SetNull) && !col.IsNullable() {
+				return false, pgerror.Newf(pgcode.InvalidForeignKey,
+					"cannot add a SET NULL cascading action on column %q which has a NOT NULL constraint",
+					col.GetName(),
+				)
+			}
+			if (actions.Delete == tree.SetDefault || actions.Update == tree.SetDefault) &&
+				!col.HasDefault() && !col.IsNullable() {
+				return false, pgerror.Newf(pgcode.InvalidForeignKey,
+					"cannot add a SET DEFAULT cascading action on column %q which has a "+
+						"NOT NULL constraint and a NULL default expression", col.GetName(),
+				)
+			}
+		}
+		ref.OnDelete = tree.ForeignKeyReferenceActionValue[actions.Delete]
+		ref.OnUpdate = tree.ForeignKeyReferenceActionValue[actions.Update]
+		if err := params.p.updateFKBackReferenceActions(params.ctx, n.tableDesc, ref); err != nil {
+			return false, err
+		}
+		descriptorChanged = true
+	}
+	if t.SetValidation {
+		if t.Validation != tree.ValidationSkip {
+			changed, err := n.validateConstraint(params, t.Constraint)
+			return descriptorChanged || changed, err
+		}
+		if ck := c.AsCheck(); ck != nil {
+			ck.CheckDesc().Validity = descpb.ConstraintValidity_Unvalidated
+		} else if fk := c.AsForeignKey(); fk != nil {
+			fk.ForeignKeyDesc().Validity = descpb.ConstraintValidity_Unvalidated
+		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+			uwoi.UniqueWithoutIndexDesc().Validity = descpb.ConstraintValidity_Unvalidated
+		}
+		descriptorChanged = true
+	}
+	return descriptorChanged, nil
+}
+
+// updateFKBackReferenceActions updates the ON DELETE and ON UPDATE actions of
+// a foreign key reference on the referenced table descriptor.
+func (p *planner) updateFKBackReferenceActions(
+	ctx context.Context, tableDesc *tabledesc.Mutable, ref *descpb.ForeignKeyConstraint,
+) error {
+	var referencedTableDesc *tabledesc.Mutable
+	// We don't want to lookup/edit a second copy of the same table.
+	if tableDesc.ID == ref.ReferencedTableID {
+		referencedTableDesc = tableDesc
+	} else {
+		lookup, err := p.Descriptors().MutableByID(p.txn).Table(ctx, ref.ReferencedTableID)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving referenced table ID %d", ref.ReferencedTableID)
+		}
+		referencedTableDesc = lookup
+	}
+	for i := range referencedTableDesc.InboundFKs {
+		backref := &referencedTableDesc.InboundFKs[i]
+		if backref.Name == ref.Name && backref.OriginTableID == tableDesc.ID {
+			backref.OnDelete = ref.OnDelete
+			backref.OnUpdate = ref.OnUpdate
+			if referencedTableDesc == tableDesc {
+				return nil
+			}
+			return p.writeSchemaChange(
+				ctx, referencedTableDesc, descpb.InvalidMutationID,
+				fmt.Sprintf("updating referenced FK table %s(%d) for table %s(%d)",
+					referencedTableDesc.Name, referencedTableDesc.ID, tableDesc.Name, tableDesc.ID),
+			)
+		}
+	}
+	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
+}
+
 func dropColumnImpl(
 	params runParams,
 	tn *tree.TableName,
@@ -1619,6 +1821,16 @@ func dropColumnImpl(
 		return nil, err
 	}
 
+	if err := dropPoliciesReferencingColumn(tableDesc, colToDrop, t.DropBehavior); err != nil {
+		return nil, err
+	}
+
+	if err := params.p.dropTriggersReferencingColumn(
+		params.ctx, tableDesc, colToDrop, t.DropBehavior,
+	); err != nil {
+		return nil, err
+	}
+
 	// If the dropped column uses a sequence, remove references to it from that sequence.
 	if colToDrop.NumUsesSequences() > 0 {
 		if err := params.p.removeSequenceDependencies(params.ctx, tableDesc, colToDrop); err != nil {
@@ -84,6 +84,18 @@ func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, e
 			"%q is a table's record type and cannot be modified",
 			tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations),
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domains can only be renamed, moved and have their owner changed with
+		// ALTER TYPE.
+		switch n.Cmd.(type) {
+		case *tree.AlterTypeRename, *tree.AlterTypeSetSchema, *tree.AlterTypeOwner:
+		default:
+			return nil, pgerror.Newf(
+				pgcode.WrongObjectType,
+				"%q is not an enum",
+				tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations),
+			)
+		}
 	}
 
 	return &alterTypeNode{
@@ -416,8 +416,14 @@ func isOwner(
 func (p *planner) HasOwnership(
 	ctx context.Context, privilegeObject privilege.Object,
 ) (bool, error) {
-	user := p.SessionData().User()
+	return p.UserHasOwnership(ctx, privilegeObject, p.SessionData().User())
+}
 
+// UserHasOwnership is like HasOwnership, but checks the ownership of the given
+// user.
+func (p *planner) UserHasOwnership(
+	ctx context.Context, privilegeObject privilege.Object, user username.SQLUsername,
+) (bool, error) {
 	return p.checkRolePredicate(ctx, user, func(role username.SQLUsername) (bool, error) {
 		return isOwner(ctx, p, privilegeObject, role)
 	})
@@ -765,6 +771,14 @@ func resolveMemberOfWithAdminOption(
 
 // HasRoleOption implements the AuthorizationAccessor interface.
 func (p *planner) HasRoleOption(ctx context.Context, roleOption roleoption.Option) (bool, error) {
+	return p.UserHasRoleOption(ctx, p.SessionData().User(), roleOption)
+}
+
+// UserHasRoleOption is like HasRoleOption, but checks whether the given user
+// has the role option.
+func (p *planner) UserHasRoleOption(
+	ctx context.Context, user username.SQLUsername, roleOption roleoption.Option,
+) (bool, error) {
 	// Verify that the txn is valid in any case, so that
 	// we don't get the risk to say "OK" to root requests
 	// with an invalid API usage.
@@ -772,12 +786,11 @@ func (p *planner) HasRoleOption(ctx context.Context, roleOption roleoption.Optio
 		return false, errors.AssertionFailedf("cannot use HasRoleOption without a txn")
 	}
 
-	user := p.SessionData().User()
 	if user.IsRootUser() || user.IsNodeUser() {
 		return true, nil
 	}
 
-	hasAdmin, err := p.HasAdminRole(ctx)
+	hasAdmin, err := p.UserHasAdminRole(ctx, user)
 	if err != nil {
 		return false, err
 	}
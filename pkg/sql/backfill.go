@@ -1622,10 +1622,8 @@ func ValidateConstraint(
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
-					return validateUniqueConstraint(
-						ctx, tableDesc, uwi.GetName(),
-						uwi.CollectKeyColumnIDs().Ordered(),
-						uwi.GetPredicate(),
+					return validateUniqueWithoutIndexConstraint(
+						ctx, tableDesc, uwi,
 						indexIDForValidation,
 						txn,
 						sessionData.User(),
@@ -2810,20 +2808,26 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
 		syntheticDescs = append(syntheticDescs, tableDesc)
 	}
-	var uc *descpb.UniqueWithoutIndexConstraint
-	for _, uwi := range tableDesc.UniqueConstraintsWithoutIndex() {
-		if uwi.GetName() == constraintName {
-			uc = uwi.UniqueWithoutIndexDesc()
+	var uwi catalog.UniqueWithoutIndexConstraint
+	for _, c := range tableDesc.UniqueConstraintsWithoutIndex() {
+		if c.GetName() == constraintName {
+			uwi = c
 			break
 		}
 	}
-	if uc == nil {
+	if uwi == nil {
 		return errors.AssertionFailedf("unique constraint %s does not exist", constraintName)
 	}
+	uc := uwi.UniqueWithoutIndexDesc()
 
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
+			if uc.IsExclusion() {
+				return validateExclusionConstraint(
+					ctx, tableDesc, uwi, 0 /* indexIDForValidation */, txn, user, false, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
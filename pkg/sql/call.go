@@ -0,0 +1,129 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// callNode represents a CALL statement, which executes a stored procedure. A
+// procedure with OUT or INOUT parameters returns a single row containing the
+// final values of those parameters.
+type callNode struct {
+	proc    *tree.RoutineExpr
+	columns colinfo.ResultColumns
+
+	row  tree.Datums
+	done bool
+}
+
+func newCallNode(proc *tree.RoutineExpr) *callNode {
+	var columns colinfo.ResultColumns
+	if typ := proc.ResolvedType(); typ.Family() == types.TupleFamily {
+		columns = make(colinfo.ResultColumns, len(typ.TupleContents()))
+		for i := range columns {
+			columns[i] = colinfo.ResultColumn{Name: typ.TupleLabels()[i], Typ: typ.TupleContents()[i]}
+		}
+	}
+	return &callNode{proc: proc, columns: columns}
+}
+
+func (n *callNode) startExec(params runParams) error {
+	res, err := eval.Expr(params.ctx, params.EvalContext(), n.proc)
+	if err != nil {
+		return err
+	}
+	// The result is NULL if the procedure has no OUT or INOUT parameters, or if
+	// it executed a COMMIT or ROLLBACK. In the latter case, the row is produced
+	// once the procedure is resumed.
+	if tuple, ok := res.(*tree.DTuple); ok {
+		n.row = tuple.D
+	}
+	return nil
+}
+
+func (n *callNode) Next(params runParams) (bool, error) {
+	if n.row == nil || n.done {
+		return false, nil
+	}
+	n.done = true
+	return true, nil
+}
+
+func (n *callNode) Values() tree.Datums { return n.row }
+
+func (n *callNode) Close(ctx context.Context) {}
+
+// storedProcTxnState tracks the COMMIT and ROLLBACK statements executed by a
+// stored procedure. The connExecutor ends the transaction of the CALL statement
+// once it finishes, and then executes the CALL statement again in a new
+// transaction, this time planning the remainder of the procedure.
+type storedProcTxnState struct {
+	// allowTxnControl is true if the statement being executed is a CALL
+	// statement that runs in its own implicit transaction. Transaction control
+	// is not permitted otherwise.
+	allowTxnControl bool
+
+	// txnOp is the transaction control operation executed by the procedure
+	// during the current execution of the CALL statement, if any.
+	txnOp tree.TxnControlOp
+
+	// pendingProc is the remainder of the procedure following the transaction
+	// control statement. It replaces resumeProc once the transaction ends
+	// successfully.
+	pendingProc *memo.Memo
+
+	// resumeProc, if set, is planned in place of the CALL statement in order to
+	// resume the procedure after a COMMIT or ROLLBACK. It is retained while the
+	// transaction is automatically retried.
+	resumeProc *memo.Memo
+}
+
+// clear resets the state after the CALL statement has finished.
+func (s *storedProcTxnState) clear() {
+	*s = storedProcTxnState{}
+}
+
+// EvalTxnControlExpr is part of the eval.Planner interface.
+func (p *planner) EvalTxnControlExpr(
+	ctx context.Context, expr *tree.TxnControlExpr, args tree.Datums,
+) (tree.Datum, error) {
+	if p.storedProcTxnState == nil || !p.storedProcTxnState.allowTxnControl ||
+		p.storedProcTxnState.txnOp != tree.StoredProcTxnNoOp {
+		return nil, errors.WithHint(
+			pgerror.New(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
+			"Transaction control is only permitted in a procedure called outside of an explicit transaction.",
+		)
+	}
+	cont, err := expr.Gen(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	resumeProc, ok := cont.(*memo.Memo)
+	if !ok {
+		return nil, errors.AssertionFailedf("expected a memo, found %T", cont)
+	}
+	p.storedProcTxnState.txnOp = expr.Op
+	p.storedProcTxnState.pendingProc = resumeProc
+
+	// The procedure returns immediately; the result of the CALL statement is
+	// produced once the procedure is resumed.
+	return tree.DNull, nil
+}
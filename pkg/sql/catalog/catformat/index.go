@@ -113,11 +113,16 @@ func indexForDisplay(
 
 	if f.HasFlags(tree.FmtPGCatalog) {
 		f.WriteString(" USING")
-		if index.Type == descpb.IndexDescriptor_INVERTED {
+		switch index.Type {
+		case descpb.IndexDescriptor_INVERTED:
 			f.WriteString(" gin")
-		} else {
+		case descpb.IndexDescriptor_BRIN:
+			f.WriteString(" brin")
+		default:
 			f.WriteString(" btree")
 		}
+	} else if index.Type == descpb.IndexDescriptor_BRIN {
+		f.WriteString(" USING brin")
 	}
 
 	f.WriteString(" (")
@@ -344,6 +349,17 @@ func formatStorageConfigs(
 		numCustomSettings++
 	}
 
+	if index.PagesPerRange != nil {
+		if numCustomSettings > 0 {
+			f.WriteString(", ")
+		} else {
+			f.WriteString(" WITH (")
+		}
+		f.WriteString(`pages_per_range=`)
+		f.WriteString(strconv.FormatUint(uint64(*index.PagesPerRange), 10))
+		numCustomSettings++
+	}
+
 	if numCustomSettings > 0 {
 		f.WriteString(")")
 	}
@@ -131,3 +131,30 @@ func (rowLevelTTL *RowLevelTTL) GetTTLExpr() Expression {
 	}
 	return DefaultTTLExpirationExpr
 }
+
+// DefaultRollingPartitionLookahead is the number of partitions kept ahead of
+// the partition containing the current time when rolling_partition_lookahead
+// is not specified.
+const DefaultRollingPartitionLookahead = 4
+
+// HasRetentionExpr is a utility method to determine if
+// rolling_partition_retention was set.
+func (m *RollingPartitioning) HasRetentionExpr() bool {
+	return m.RetentionExpr != ""
+}
+
+// LookaheadOrDefault returns the Lookahead or the global default.
+func (m *RollingPartitioning) LookaheadOrDefault() int64 {
+	if override := m.Lookahead; override != 0 {
+		return override
+	}
+	return DefaultRollingPartitionLookahead
+}
+
+// JobCronOrDefault returns the JobCron or the global default.
+func (m *RollingPartitioning) JobCronOrDefault() string {
+	if override := m.JobCron; override != "" {
+		return override
+	}
+	return "@hourly"
+}
@@ -75,6 +75,8 @@ var (
 		catconstants.SpanStatsSamples,
 		catconstants.SpanStatsTenantBoundaries,
 		catconstants.RegionalLiveness,
+		catconstants.ReplicationSlotsTableName,
+		catconstants.NotificationsTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -132,6 +132,12 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 			)
 		}
 
+	case types.JsonpathFamily, types.CIDRFamily, types.MacAddrFamily, types.MacAddr8Family,
+		types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily,
+		types.RangeFamily, types.MultiRangeFamily:
+		return checkColumnTypeVersion(ctx, version, t)
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -140,6 +146,36 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 	return nil
 }
 
+// columnTypeVersions maps the type families that were added after 23.1 to
+// the cluster version from which they can be used as column types.
+var columnTypeVersions = map[types.Family]clusterversion.Key{
+	types.JsonpathFamily:   clusterversion.V23_2_JsonpathType,
+	types.CIDRFamily:       clusterversion.V23_2_NetworkAddressTypes,
+	types.MacAddrFamily:    clusterversion.V23_2_NetworkAddressTypes,
+	types.MacAddr8Family:   clusterversion.V23_2_NetworkAddressTypes,
+	types.PointFamily:      clusterversion.V23_2_GeometricTypes,
+	types.LSegFamily:       clusterversion.V23_2_GeometricTypes,
+	types.BoxFamily:        clusterversion.V23_2_GeometricTypes,
+	types.PathFamily:       clusterversion.V23_2_GeometricTypes,
+	types.PolygonFamily:    clusterversion.V23_2_GeometricTypes,
+	types.LineFamily:       clusterversion.V23_2_GeometricTypes,
+	types.CircleFamily:     clusterversion.V23_2_GeometricTypes,
+	types.RangeFamily:      clusterversion.V23_2_RangeTypes,
+	types.MultiRangeFamily: clusterversion.V23_2_RangeTypes,
+}
+
+// checkColumnTypeVersion returns an error if the cluster version from which
+// the type family of t can be used as a column type is not active yet.
+func checkColumnTypeVersion(
+	ctx context.Context, version clusterversion.Handle, t *types.T,
+) error {
+	if key, ok := columnTypeVersions[t.Family()]; ok && !version.IsActive(ctx, key) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s not supported until version 23.2", t.Name())
+	}
+	return nil
+}
+
 // ColumnTypeIsIndexable returns whether the type t is valid as an indexed column.
 func ColumnTypeIsIndexable(t *types.T) bool {
 	if t.IsAmbiguous() || t.Family() == types.TupleFamily {
@@ -150,11 +186,34 @@ func ColumnTypeIsIndexable(t *types.T) bool {
 	return !MustBeValueEncoded(t) && !ColumnTypeIsOnlyInvertedIndexable(t)
 }
 
+// ColumnTypeIsBRINIndexable returns whether the type t is valid to be
+// summarized by a BRIN index. Summaries are stored as key-encoded values, so
+// the type must have a key encoding that decodes back into an equal value.
+func ColumnTypeIsBRINIndexable(t *types.T) bool {
+	switch t.Family() {
+	case types.BoolFamily, types.IntFamily, types.FloatFamily, types.DecimalFamily,
+		types.DateFamily, types.TimestampFamily, types.TimestampTZFamily, types.IntervalFamily,
+		types.TimeFamily, types.TimeTZFamily, types.StringFamily, types.BytesFamily,
+		types.UuidFamily, types.INetFamily, types.OidFamily:
+		return true
+	}
+	return false
+}
+
 // ColumnTypeIsInvertedIndexable returns whether the type t is valid to be indexed
 // using an inverted index.
 func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
-	case types.JsonFamily, types.ArrayFamily, types.StringFamily:
+	case types.JsonFamily, types.StringFamily:
+		return true
+	case types.ArrayFamily:
+		// JSONPath and geometric values have no key encoding, so they cannot be
+		// used as inverted index keys.
+		switch t.ArrayContents().Family() {
+		case types.JsonpathFamily, types.PointFamily, types.LSegFamily, types.BoxFamily,
+			types.PathFamily, types.PolygonFamily, types.LineFamily, types.CircleFamily:
+			return false
+		}
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -192,7 +251,10 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		}
 	case types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
 		return true
-	case types.TSVectorFamily, types.TSQueryFamily:
+	case types.TSVectorFamily, types.TSQueryFamily, types.JsonpathFamily:
+		return true
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
 		return true
 	}
 	return false
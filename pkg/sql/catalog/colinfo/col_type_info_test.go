@@ -0,0 +1,64 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateColumnDefTypeVersion tests that the types added after 23.1 can
+// only be used as column types once the cluster version of their feature is
+// active.
+func TestValidateColumnDefTypeVersion(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		typ *types.T
+		key clusterversion.Key
+	}{
+		{types.Jsonpath, clusterversion.V23_2_JsonpathType},
+		{types.CIDR, clusterversion.V23_2_NetworkAddressTypes},
+		{types.MacAddr, clusterversion.V23_2_NetworkAddressTypes},
+		{types.MacAddr8, clusterversion.V23_2_NetworkAddressTypes},
+		{types.Point, clusterversion.V23_2_GeometricTypes},
+		{types.LSeg, clusterversion.V23_2_GeometricTypes},
+		{types.Box, clusterversion.V23_2_GeometricTypes},
+		{types.Path, clusterversion.V23_2_GeometricTypes},
+		{types.Polygon, clusterversion.V23_2_GeometricTypes},
+		{types.Line, clusterversion.V23_2_GeometricTypes},
+		{types.Circle, clusterversion.V23_2_GeometricTypes},
+		{types.Int4Range, clusterversion.V23_2_RangeTypes},
+		{types.TSTZMultiRange, clusterversion.V23_2_RangeTypes},
+	} {
+		t.Run(tc.typ.Name(), func(t *testing.T) {
+			before := cluster.MakeTestingClusterSettingsWithVersions(
+				clusterversion.ByKey(tc.key-1),
+				clusterversion.TestingBinaryMinSupportedVersion,
+				true /* initializeVersion */)
+			err := ValidateColumnDefType(ctx, before.Version, tc.typ)
+			require.Error(t, err)
+			require.Equal(t, pgcode.FeatureNotSupported, pgerror.GetPGCode(err))
+
+			after := cluster.MakeTestingClusterSettingsWithVersions(
+				clusterversion.ByKey(tc.key),
+				clusterversion.TestingBinaryMinSupportedVersion,
+				true /* initializeVersion */)
+			require.NoError(t, ValidateColumnDefType(ctx, after.Version, tc.typ))
+		})
+	}
+}
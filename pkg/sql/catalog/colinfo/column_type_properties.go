@@ -57,6 +57,10 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
+	case types.MultiRangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.MultiRangeContents())
 	case types.TupleFamily:
 		for _, t := range typ.TupleContents() {
 			if CanHaveCompositeKeyEncoding(t) {
@@ -75,6 +79,16 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.OidFamily,
 		types.UuidFamily,
 		types.INetFamily,
+		types.CIDRFamily,
+		types.MacAddrFamily,
+		types.MacAddr8Family,
+		types.PointFamily,
+		types.LSegFamily,
+		types.BoxFamily,
+		types.PathFamily,
+		types.PolygonFamily,
+		types.LineFamily,
+		types.CircleFamily,
 		types.TimeFamily,
 		types.TimeTZFamily,
 		types.BitFamily,
@@ -83,6 +97,7 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.EnumFamily,
 		types.Box2DFamily,
 		types.PGLSNFamily,
+		types.JsonpathFamily,
 		types.VoidFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
@@ -120,6 +120,36 @@ func (desc *IndexDescriptor) InvertedColumnName() string {
 	return desc.KeyColumnNames[len(desc.KeyColumnNames)-1]
 }
 
+// DefaultBRINPagesPerRange is the number of consecutive values of the leading
+// primary key column summarized by each entry of a BRIN index when the
+// pages_per_range storage parameter is not specified. It matches the Postgres
+// default.
+const DefaultBRINPagesPerRange = 128
+
+// BRINPagesPerRange returns the number of consecutive values of the block
+// column summarized by each entry of the BRIN index. Panics if the index is
+// not a BRIN index.
+func (desc *IndexDescriptor) BRINPagesPerRange() int64 {
+	if desc.Type != IndexDescriptor_BRIN {
+		panic(errors.AssertionFailedf("index is not a BRIN index"))
+	}
+	if desc.PagesPerRange != nil {
+		return int64(*desc.PagesPerRange)
+	}
+	return DefaultBRINPagesPerRange
+}
+
+// BRINBlockColumnID returns the ColumnID of the column whose values are
+// grouped into the blocks summarized by the BRIN index. This is the first key
+// suffix column, which is the leading primary key column at the time the index
+// was created. Panics if the index is not a BRIN index.
+func (desc *IndexDescriptor) BRINBlockColumnID() ColumnID {
+	if desc.Type != IndexDescriptor_BRIN {
+		panic(errors.AssertionFailedf("index is not a BRIN index"))
+	}
+	return desc.KeySuffixColumnIDs[0]
+}
+
 // InvertedColumnKeyType returns the type of the data element that is encoded
 // as the inverted index key. This is currently always EncodedKey.
 //
@@ -713,6 +713,12 @@ type TableDescriptor interface {
 	GetRowLevelTTL() *catpb.RowLevelTTL
 	// HasRowLevelTTL returns where there is a row-level TTL config for the table.
 	HasRowLevelTTL() bool
+	// GetRollingPartitioning returns the rolling partitioning config for the
+	// table.
+	GetRollingPartitioning() *catpb.RollingPartitioning
+	// HasRollingPartitioning returns whether there is a rolling partitioning
+	// config for the table.
+	HasRollingPartitioning() bool
 	// GetExcludeDataFromBackup returns true if the table's row data is configured
 	// to be excluded during backup.
 	GetExcludeDataFromBackup() bool
@@ -759,6 +765,18 @@ type TableDescriptor interface {
 	// IsSchemaLocked returns true if we don't allow performing schema changes
 	// on this table descriptor.
 	IsSchemaLocked() bool
+	// GetPolicies returns the row-level security policies of the table.
+	GetPolicies() []descpb.PolicyDescriptor
+	// IsRowLevelSecurityEnabled returns true if the row-level security policies
+	// of the table are applied to the queries which access it.
+	IsRowLevelSecurityEnabled() bool
+	// IsRowLevelSecurityForced returns true if the row-level security policies
+	// of the table are applied to its owner as well.
+	IsRowLevelSecurityForced() bool
+	// GetTriggers returns the triggers of the table.
+	GetTriggers() []descpb.TriggerDescriptor
+	// GetNextTriggerID returns the next unused trigger ID for this table.
+	GetNextTriggerID() descpb.TriggerID
 }
 
 // MutableTableDescriptor is both a MutableDescriptor and a TableDescriptor.
@@ -815,6 +833,11 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type,
+	// nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -902,6 +925,33 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types, which
+// restrict the values of a base type.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// GetBaseType returns the type that the domain is defined over.
+	GetBaseType() *types.T
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// if any.
+	GetDefaultExpr() (string, bool)
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetCheckName(ordinal int) string
+
+	// GetCheckExpr returns the serialized expression of the CHECK constraint at
+	// the given ordinal.
+	GetCheckExpr(ordinal int) string
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -964,6 +1014,30 @@ type FunctionDescriptor interface {
 	// GetDependedOnBy returns a list of back-references of this function.
 	GetDependedOnBy() []descpb.FunctionDescriptor_Reference
 
+	// GetAggregate returns the definition of a user-defined aggregate in terms
+	// of its support functions, or nil if the function is not an aggregate.
+	GetAggregate() *descpb.FunctionDescriptor_Aggregate
+
+	// GetSecurity returns whether the function executes with the privileges of
+	// the invoking user or of its owner.
+	GetSecurity() catpb.Function_Security
+
+	// GetConfig returns the session variables that are set for the duration of
+	// the function execution, each in the form "name=value".
+	GetConfig() []string
+
+	// GetCost returns the function's estimated execution cost.
+	GetCost() float64
+
+	// GetRows returns the estimated number of rows returned by the function.
+	GetRows() float64
+
+	// GetSupport returns the name of the function's planner support function.
+	GetSupport() string
+
+	// GetParallel returns the function's parallel safety attribute.
+	GetParallel() catpb.Function_Parallel
+
 	// FuncDesc returns the function's underlying protobuf descriptor.
 	FuncDesc() *descpb.FunctionDescriptor
 
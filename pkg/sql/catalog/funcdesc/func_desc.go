@@ -12,6 +12,7 @@ package funcdesc
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -66,6 +67,7 @@ func NewMutableFunctionDescriptor(
 	params []descpb.FunctionDescriptor_Parameter,
 	returnType *types.T,
 	returnSet bool,
+	isProcedure bool,
 	privs *catpb.PrivilegeDescriptor,
 ) Mutable {
 	return Mutable{
@@ -80,6 +82,7 @@ func NewMutableFunctionDescriptor(
 					Type:      returnType,
 					ReturnSet: returnSet,
 				},
+				IsProcedure:       isProcedure,
 				Lang:              catpb.Function_SQL,
 				Volatility:        catpb.DefaultFunctionVolatility,
 				LeakProof:         catpb.DefaultFunctionLeakProof,
@@ -173,10 +176,30 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	for _, id := range desc.getAggregateSupportFuncIDs() {
+		ret.Add(id)
+	}
 
 	return ret, nil
 }
 
+// getAggregateSupportFuncIDs returns the IDs of the support functions of a
+// user-defined aggregate, or nil if the descriptor is not an aggregate.
+func (desc *immutable) getAggregateSupportFuncIDs() []descpb.ID {
+	agg := desc.Aggregate
+	if agg == nil {
+		return nil
+	}
+	ret := []descpb.ID{agg.StateFuncID}
+	if agg.FinalFuncID != descpb.InvalidID {
+		ret = append(ret, agg.FinalFuncID)
+	}
+	if agg.CombineFuncID != descpb.InvalidID {
+		ret = append(ret, agg.CombineFuncID)
+	}
+	return ret
+}
+
 // ValidateSelf implements the catalog.Descriptor interface.
 func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vea.Report(catalog.ValidateName(desc))
@@ -226,6 +249,21 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if desc.IsProcedure {
+			vea.Report(errors.AssertionFailedf("aggregate cannot be a procedure"))
+		}
+		if agg.StateFuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate state function not set"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		if len(desc.Params) == 0 {
+			vea.Report(errors.AssertionFailedf("aggregate has no parameters"))
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -255,6 +293,16 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	for _, fnID := range desc.getAggregateSupportFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid aggregate support function reference"))
+		} else if fn.Dropped() {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) is dropped",
+				fn.GetName(), fn.GetID()))
+		}
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -276,13 +324,59 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	for _, fnID := range desc.getAggregateSupportFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			continue
+		}
+		vea.Report(desc.validateOutboundFunctionRefBackReference(fn))
+	}
+
+	// The only references between functions are those from user-defined
+	// aggregates to their support functions, so all other inbound references
+	// are from tables.
 	for _, by := range desc.DependedOnBy {
+		if d, err := vdg.GetDescriptor(by.ID); err == nil && d.DescriptorType() == catalog.Function {
+			vea.Report(desc.validateInboundFunctionRef(by, vdg))
+			continue
+		}
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
 }
 
+func (desc *immutable) validateOutboundFunctionRefBackReference(
+	fn catalog.FunctionDescriptor,
+) error {
+	for _, by := range fn.GetDependedOnBy() {
+		if by.ID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		fn.GetName(), fn.GetID())
+}
+
+func (desc *immutable) validateInboundFunctionRef(
+	by descpb.FunctionDescriptor_Reference, vdg catalog.ValidationDescGetter,
+) error {
+	backRefFn, err := vdg.GetFunctionDescriptor(by.ID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depended-on-by function back reference")
+	}
+	if backRefFn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			backRefFn.GetName(), backRefFn.GetID())
+	}
+	if agg := backRefFn.GetAggregate(); agg != nil {
+		if agg.StateFuncID == desc.GetID() || agg.FinalFuncID == desc.GetID() ||
+			agg.CombineFuncID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) has no corresponding depends-on forward reference",
+		backRefFn.GetName(), by.ID)
+}
+
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
 	// Check that parent Schema contains the matching function signature.
 	if _, ok := scDesc.GetFunction(desc.GetName()); !ok {
@@ -369,6 +463,22 @@ func (desc *immutable) validateInboundTableRef(
 			cstID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
 		)
 	}
+	for _, triggerID := range by.TriggerIDs {
+		found := false
+		for _, t := range backRefTbl.GetTriggers() {
+			if t.ID == triggerID && t.FuncID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.AssertionFailedf(
+				"depended-on-by relation %q (%d) does not have a trigger with ID %d which references function %q (%d)",
+				backRefTbl.GetName(), by.ID, triggerID, desc.GetName(), desc.GetID(),
+			)
+		}
+		foundInTable = true
+	}
 	if foundInTable {
 		return nil
 	}
@@ -423,6 +533,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		if err := fn(agg.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -536,6 +651,37 @@ func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
 }
 
+// SetSecurity sets the security attribute.
+func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
+	desc.Security = v
+}
+
+// SetConfig sets the session variables that are set for the duration of the
+// function execution.
+func (desc *Mutable) SetConfig(config []string) {
+	desc.Config = config
+}
+
+// SetCost sets the estimated execution cost.
+func (desc *Mutable) SetCost(v float64) {
+	desc.Cost = v
+}
+
+// SetRows sets the estimated number of returned rows.
+func (desc *Mutable) SetRows(v float64) {
+	desc.Rows = v
+}
+
+// SetSupport sets the name of the planner support function.
+func (desc *Mutable) SetSupport(v string) {
+	desc.Support = v
+}
+
+// SetParallel sets the parallel safety attribute.
+func (desc *Mutable) SetParallel(v catpb.Function_Parallel) {
+	desc.Parallel = v
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -633,14 +779,60 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 	}
 }
 
+// AddTriggerReference adds back reference to a trigger to the function.
+func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing == triggerID {
+					return
+				}
+			}
+			ids := append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			desc.DependedOnBy[i].TriggerIDs = ids
+			return
+		}
+	}
+	desc.DependedOnBy = append(
+		desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{
+			ID:         id,
+			TriggerIDs: []descpb.TriggerID{triggerID},
+		},
+	)
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+}
+
+// RemoveTriggerReference removes back reference to a trigger from the
+// function.
+func (desc *Mutable) RemoveTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			var ids []descpb.TriggerID
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing != triggerID {
+					ids = append(ids, existing)
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = ids
+			desc.maybeRemoveTableReference(id)
+			return
+		}
+	}
+}
+
 // maybeRemoveTableReference removes a table's references from the function if
-// the column, index and constraint references are all empty. This function is
-// only used internally when removing an individual column, index or constraint
-// reference.
+// the column, index, constraint and trigger references are all empty. This
+// function is only used internally when removing an individual column, index,
+// constraint or trigger reference.
 func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
-		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 && len(ref.TriggerIDs) == 0 {
 			continue
 		}
 		ret = append(ret, ref)
@@ -648,6 +840,21 @@ func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	desc.DependedOnBy = ret
 }
 
+// AddFunctionReference adds a back reference from a user-defined aggregate to
+// one of its support functions.
+func (desc *Mutable) AddFunctionReference(id descpb.ID) {
+	for _, ref := range desc.DependedOnBy {
+		if ref.ID == id {
+			return
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: id})
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+}
+
+// RemoveReference removes all back references from the given descriptor.
 func (desc *Mutable) RemoveReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
@@ -689,21 +896,32 @@ func (desc *immutable) GetLanguage() catpb.Function_Language {
 
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret = &tree.Overload{
-		Oid:        catid.FuncIDToOID(desc.ID),
-		ReturnType: tree.FixedReturnType(desc.ReturnType.Type),
-		ReturnSet:  desc.ReturnType.ReturnSet,
-		Body:       desc.FunctionBody,
-		IsUDF:      true,
-		Version:    uint64(desc.Version),
-		Language:   desc.getCreateExprLang(),
+		Oid:         catid.FuncIDToOID(desc.ID),
+		ReturnType:  tree.FixedReturnType(desc.ReturnType.Type),
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		IsProcedure: desc.IsProcedure,
+		Body:        desc.FunctionBody,
+		IsUDF:       true,
+		Version:     uint64(desc.Version),
+		Language:    desc.getCreateExprLang(),
+
+		SessionConfig: desc.Config,
+	}
+	if desc.Security == catpb.Function_DEFINER {
+		ret.SecurityDefiner = true
+		ret.Owner = desc.GetPrivileges().Owner()
 	}
 
 	argTypes := make(tree.ParamTypes, 0, len(desc.Params))
-	for _, param := range desc.Params {
+	for i, param := range desc.Params {
 		argTypes = append(
 			argTypes,
 			tree.ParamType{Name: param.Name, Typ: param.Type},
 		)
+		if desc.IsProcedure &&
+			(param.Class == catpb.Function_Param_OUT || param.Class == catpb.Function_Param_IN_OUT) {
+			ret.OutParamOrdinals = append(ret.OutParamOrdinals, i)
+		}
 	}
 	ret.Types = argTypes
 	ret.Volatility, err = desc.getOverloadVolatility()
@@ -717,6 +935,20 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UserDefinedAggregate = &tree.UserDefinedAggregateInfo{
+			StateFunc: catid.FuncIDToOID(agg.StateFuncID),
+			StateType: agg.StateType,
+			InitCond:  agg.InitialCondition,
+		}
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.UserDefinedAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFuncID)
+		}
+		if agg.CombineFuncID != descpb.InvalidID {
+			ret.UserDefinedAggregate.CombineFunc = catid.FuncIDToOID(agg.CombineFuncID)
+		}
+	}
 
 	return ret, nil
 }
@@ -759,7 +991,8 @@ func (desc *immutable) calledOnNullInput() (bool, error) {
 // ToCreateExpr implements the FunctionDescriptor interface.
 func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 	ret = &tree.CreateRoutine{
-		Name: tree.MakeRoutineNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
+		IsProcedure: desc.IsProcedure,
+		Name:        tree.MakeRoutineNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
 		ReturnType: tree.RoutineReturnType{
 			Type:  desc.ReturnType.Type,
 			IsSet: desc.ReturnType.ReturnSet,
@@ -787,9 +1020,43 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
 	ret.Options = append(ret.Options, tree.RoutineBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
+	// The remaining attributes are only included if they differ from their
+	// defaults.
+	if desc.Security == catpb.Function_DEFINER {
+		ret.Options = append(ret.Options, tree.RoutineDefiner)
+	}
+	if desc.Parallel != catpb.Function_PARALLEL_UNSAFE {
+		ret.Options = append(ret.Options, desc.getCreateExprParallel())
+	}
+	if desc.Cost != 0 {
+		ret.Options = append(ret.Options, tree.RoutineCost(desc.Cost))
+	}
+	if desc.Rows != 0 {
+		ret.Options = append(ret.Options, tree.RoutineRows(desc.Rows))
+	}
+	if desc.Support != "" {
+		ret.Options = append(ret.Options, tree.RoutineSupport(desc.Support))
+	}
+	for _, setting := range desc.Config {
+		name, val, _ := strings.Cut(setting, "=")
+		ret.Options = append(ret.Options, tree.RoutineSetVar{SetVar: &tree.SetVar{
+			Name:   name,
+			Values: tree.Exprs{tree.NewStrVal(val)},
+		}})
+	}
 	return ret, nil
 }
 
+func (desc *immutable) getCreateExprParallel() tree.RoutineParallel {
+	switch desc.Parallel {
+	case catpb.Function_PARALLEL_RESTRICTED:
+		return tree.RoutineParallelRestricted
+	case catpb.Function_PARALLEL_SAFE:
+		return tree.RoutineParallelSafe
+	}
+	return tree.RoutineParallelUnsafe
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -57,6 +58,7 @@ func TestValidateFuncDesc(t *testing.T) {
 		tableWithGoodConstraint   = dbID + 10
 		tableWithBadColumn        = dbID + 11
 		tableWIthGoodColumn       = dbID + 12
+		tableWithTrigger          = dbID + 13
 	)
 	funcDescID := descpb.ID(bootstrap.TestingUserDescID(0))
 
@@ -147,6 +149,20 @@ func TestValidateFuncDesc(t *testing.T) {
 		},
 	}).BuildImmutable())
 
+	cb.UpsertDescriptor(tabledesc.NewBuilder(&descpb.TableDescriptor{
+		ID:            tableWithTrigger,
+		Name:          "tbl_trigger",
+		NextTriggerID: 2,
+		Triggers: []descpb.TriggerDescriptor{
+			{
+				ID:     1,
+				Name:   "trig",
+				Events: []semenumpb.TriggerEventType{semenumpb.TriggerEventType_INSERT},
+				FuncID: funcDescID,
+			},
+		},
+	}).BuildImmutable())
+
 	defaultPrivileges := catpb.NewBasePrivilegeDescriptor(username.RootUserName())
 	invalidPrivileges := catpb.NewBasePrivilegeDescriptor(username.RootUserName())
 	// Make the PrivilegeDescriptor invalid by granting SELECT to a function.
@@ -620,6 +636,40 @@ func TestValidateFuncDesc(t *testing.T) {
 				DependsOnTypes: []descpb.ID{typeWithFuncRefID},
 			},
 		},
+		{
+			`depended-on-by relation "tbl_trigger" (1013) does not have a trigger with ID 2 which references function "f" (100)`,
+			descpb.FunctionDescriptor{
+				Name:           "f",
+				ID:             funcDescID,
+				ParentID:       dbID,
+				ParentSchemaID: schemaWithFuncRefID,
+				Privileges:     defaultPrivileges,
+				ReturnType: descpb.FunctionDescriptor_ReturnType{
+					Type: types.Trigger,
+				},
+				Volatility: catpb.Function_VOLATILE,
+				DependedOnBy: []descpb.FunctionDescriptor_Reference{
+					{ID: tableWithTrigger, TriggerIDs: []descpb.TriggerID{2}},
+				},
+			},
+		},
+		{
+			``,
+			descpb.FunctionDescriptor{
+				Name:           "f",
+				ID:             funcDescID,
+				ParentID:       dbID,
+				ParentSchemaID: schemaWithFuncRefID,
+				Privileges:     defaultPrivileges,
+				ReturnType: descpb.FunctionDescriptor_ReturnType{
+					Type: types.Trigger,
+				},
+				Volatility: catpb.Function_VOLATILE,
+				DependedOnBy: []descpb.FunctionDescriptor_Reference{
+					{ID: tableWithTrigger, TriggerIDs: []descpb.TriggerID{1}},
+				},
+			},
+		},
 	}
 
 	for i, test := range testData {
@@ -11,10 +11,13 @@
 package funcinfo
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
@@ -117,6 +120,34 @@ func FunctionLangToProto(v tree.RoutineLanguage) (catpb.Function_Language, error
 	return -1, pgerror.Newf(pgcode.UndefinedObject, "language %q does not exist", v)
 }
 
+// SecurityToProto converts sql statement input security mode to protobuf
+// type.
+func SecurityToProto(v tree.RoutineSecurity) (catpb.Function_Security, error) {
+	switch v {
+	case tree.RoutineInvoker:
+		return catpb.Function_INVOKER, nil
+	case tree.RoutineDefiner:
+		return catpb.Function_DEFINER, nil
+	}
+
+	return -1, errors.AssertionFailedf("unknown function security %q", v)
+}
+
+// ParallelToProto converts sql statement input parallel mode to protobuf
+// type.
+func ParallelToProto(v tree.RoutineParallel) (catpb.Function_Parallel, error) {
+	switch v {
+	case tree.RoutineParallelUnsafe:
+		return catpb.Function_PARALLEL_UNSAFE, nil
+	case tree.RoutineParallelRestricted:
+		return catpb.Function_PARALLEL_RESTRICTED, nil
+	case tree.RoutineParallelSafe:
+		return catpb.Function_PARALLEL_SAFE, nil
+	}
+
+	return -1, errors.AssertionFailedf("unknown function parallel mode %q", v)
+}
+
 // ParamClassToProto converts sql statement input argument class to protobuf
 // type.
 func ParamClassToProto(v tree.RoutineParamClass) (catpb.Function_Param_Class, error) {
@@ -133,3 +164,27 @@ func ParamClassToProto(v tree.RoutineParamClass) (catpb.Function_Param_Class, er
 
 	return -1, errors.AssertionFailedf("unknown function parameter class %q", v)
 }
+
+// MakeProcedureReturnType returns the return type of a stored procedure with
+// the given parameters, where paramTypes contains the resolved type of each
+// parameter. A procedure without OUT or INOUT parameters returns VOID.
+// Otherwise, it returns a labeled tuple with the values of those parameters.
+func MakeProcedureReturnType(params tree.RoutineParams, paramTypes []*types.T) *types.T {
+	var outTypes []*types.T
+	var outLabels []string
+	for i := range params {
+		if params[i].Class != tree.RoutineParamOut && params[i].Class != tree.RoutineParamInOut {
+			continue
+		}
+		label := string(params[i].Name)
+		if label == "" {
+			label = fmt.Sprintf("column%d", len(outLabels)+1)
+		}
+		outTypes = append(outTypes, paramTypes[i])
+		outLabels = append(outLabels, label)
+	}
+	if len(outTypes) == 0 {
+		return types.Void
+	}
+	return types.MakeLabeledTuple(outTypes, outLabels)
+}
@@ -513,9 +513,12 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			},
 			IsUDF:                    true,
 			UDFContainsOnlySignature: true,
+			IsProcedure:              sig.IsProcedure,
 		}
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
+		} else if sig.IsAggregate {
+			overload.Class = tree.AggregateClass
 		}
 		paramTypes := make(tree.ParamTypes, 0, len(sig.ArgTypes))
 		for _, paramType := range sig.ArgTypes {
@@ -0,0 +1,65 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DequalifyTriggerWhenExpr returns a copy of the WHEN condition of the given
+// trigger with the new and old prefixes stripped from its column references,
+// so that it can be type-checked against the columns of the table. The
+// condition may only refer to columns as new.<column> and old.<column>. The
+// NEW row does not exist for DELETE triggers, and the OLD row does not exist
+// for INSERT triggers.
+func DequalifyTriggerWhenExpr(n *tree.CreateTrigger) (tree.Expr, error) {
+	var hasInsert, hasDelete bool
+	for _, event := range n.Events {
+		hasInsert = hasInsert || event.EventType == tree.TriggerEventInsert
+		hasDelete = hasDelete || event.EventType == tree.TriggerEventDelete
+	}
+	return tree.SimpleVisit(n.When, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		c, ok := v.(*tree.ColumnItem)
+		if !ok {
+			return false, v, nil
+		}
+		if c.TableName == nil {
+			return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q does not exist", c.ColumnName)
+		}
+		switch record := c.TableName.ToTableName().ObjectName; record {
+		case "new":
+			if hasDelete {
+				return false, nil, pgerror.New(pgcode.InvalidColumnReference,
+					"DELETE trigger's WHEN condition cannot reference NEW values")
+			}
+		case "old":
+			if hasInsert {
+				return false, nil, pgerror.New(pgcode.InvalidColumnReference,
+					"INSERT trigger's WHEN condition cannot reference OLD values")
+			}
+		default:
+			return false, nil, pgerror.Newf(pgcode.UndefinedTable,
+				"missing FROM-clause entry for table %q", record)
+		}
+		return false, &tree.ColumnItem{ColumnName: c.ColumnName}, nil
+	})
+}
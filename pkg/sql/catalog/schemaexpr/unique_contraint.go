@@ -15,7 +15,11 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
@@ -55,3 +59,72 @@ func ValidateUniqueWithoutIndexPredicate(
 	}
 	return expr, nil
 }
+
+// exclusionOperators are the operators that can be used in exclusion
+// constraints. They must be commutative, so that checking a new row against
+// the existing rows in one direction is sufficient.
+var exclusionOperators = map[string]treecmp.ComparisonOperatorSymbol{
+	treecmp.EQ.String():       treecmp.EQ,
+	treecmp.NE.String():       treecmp.NE,
+	treecmp.Overlaps.String(): treecmp.Overlaps,
+}
+
+// ExclusionOperator returns the comparison operator with the given name that
+// is used by an exclusion constraint.
+func ExclusionOperator(name string) (treecmp.ComparisonOperator, error) {
+	sym, ok := exclusionOperators[name]
+	if !ok {
+		return treecmp.ComparisonOperator{}, pgerror.Newf(pgcode.WrongObjectType,
+			"operator %s is not supported by exclusion constraints", name)
+	}
+	return treecmp.MakeComparisonOperator(sym), nil
+}
+
+// ValidateExclusionElems verifies that the elements of an exclusion constraint
+// refer to columns of the table, and that each operator is supported by
+// exclusion constraints and defined for the type of its column. It returns the
+// IDs of the columns and the names of the operators, in the order of the
+// elements.
+func ValidateExclusionElems(
+	desc catalog.TableDescriptor, elems tree.ExclusionElemList,
+) ([]descpb.ColumnID, []string, error) {
+	colIDs := make([]descpb.ColumnID, len(elems))
+	ops := make([]string, len(elems))
+	for i := range elems {
+		col, err := catalog.MustFindColumnByTreeName(desc, elems[i].Column)
+		if err != nil {
+			return nil, nil, err
+		}
+		if col.Dropped() {
+			return nil, nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"column %q is being dropped", col.GetName())
+		}
+		op, err := ExclusionOperator(elems[i].Operator.String())
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := CheckExclusionOperatorType(op, col.GetName(), col.GetType()); err != nil {
+			return nil, nil, err
+		}
+		colIDs[i] = col.GetID()
+		ops[i] = op.String()
+	}
+	return colIDs, ops, nil
+}
+
+// CheckExclusionOperatorType verifies that the given exclusion constraint
+// operator is defined for the type of the column it is applied to.
+func CheckExclusionOperatorType(
+	op treecmp.ComparisonOperator, colName string, typ *types.T,
+) error {
+	sym := op.Symbol
+	if sym == treecmp.NE {
+		// NE is evaluated as the negation of EQ.
+		sym = treecmp.EQ
+	}
+	if _, ok := tree.CmpOps[sym].LookupImpl(typ, typ); !ok {
+		return pgerror.Newf(pgcode.UndefinedFunction,
+			"operator %s is not defined for column %q of type %s", op, colName, typ.SQLString())
+	}
+	return nil
+}
@@ -1007,6 +1007,37 @@ CREATE TABLE system.span_stats_tenant_boundaries (
 	  FAMILY "primary" (crdb_region, unavailable_at)
 ) ;
 `
+
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// with CREATE_REPLICATION_SLOT over the replication protocol. The
+	// confirmed_flush_lsn is the position up to which the client has confirmed
+	// receiving changes, which is where a restarted stream resumes.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	slot_name           STRING NOT NULL,
+	plugin              STRING NOT NULL,
+	database_id         INT8 NOT NULL,
+	confirmed_flush_lsn INT8 NOT NULL,
+	created             TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, plugin, database_id, confirmed_flush_lsn, created)
+);`
+
+	// NotificationsTableSchema stores the notifications sent with NOTIFY, so
+	// that every node can deliver them to its listening sessions. Rows are
+	// written once the notifying transaction commits and are deleted after a
+	// short retention period.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	id                 INT8 NOT NULL DEFAULT unique_rowid(),
+	channel            STRING NOT NULL,
+	payload            STRING NOT NULL,
+	sender_pid         INT8 NOT NULL,
+	sender_instance_id INT8 NOT NULL,
+	created            TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (id),
+	FAMILY "primary" (id, channel, payload, sender_pid, sender_instance_id, created)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1237,6 +1268,8 @@ func MakeSystemTables() []SystemTable {
 		StatementActivityTable,
 		TransactionActivityTable,
 		RegionLivenessTable,
+		ReplicationSlotsTable,
+		NotificationsTable,
 	}
 }
 
@@ -4103,6 +4136,69 @@ var (
 			},
 		),
 	)
+
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "plugin", ID: 2, Type: types.String},
+				{Name: "database_id", ID: 3, Type: types.Int},
+				{Name: "confirmed_flush_lsn", ID: 4, Type: types.Int},
+				{Name: "created", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"slot_name", "plugin", "database_id", "confirmed_flush_lsn", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"slot_name"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+		),
+	)
+
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "sender_pid", ID: 4, Type: types.Int},
+				{Name: "sender_instance_id", ID: 5, Type: types.Int},
+				{Name: "created", ID: 6, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"id", "channel", "payload", "sender_pid", "sender_instance_id", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"id"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
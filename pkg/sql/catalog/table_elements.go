@@ -599,6 +599,15 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsExclusion returns true if the constraint is an exclusion constraint,
+	// in which case no two rows may have values in the key columns that
+	// satisfy every one of the operators returned by ExclusionOperators.
+	IsExclusion() bool
+
+	// ExclusionOperators returns the comparison operator for each key column
+	// of an exclusion constraint, in key column order.
+	ExclusionOperators() []string
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -151,7 +151,19 @@ func (c uniqueWithoutIndexConstraint) ParentTableID() descpb.ID {
 func (c uniqueWithoutIndexConstraint) IsValidReferencedUniqueConstraint(
 	fk catalog.ForeignKeyConstraint,
 ) bool {
-	return !c.IsPartial() && descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+	return !c.IsPartial() && !c.IsExclusion() &&
+		descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+}
+
+// IsExclusion implements the catalog.UniqueWithoutIndexConstraint interface.
+func (c uniqueWithoutIndexConstraint) IsExclusion() bool {
+	return c.desc.IsExclusion()
+}
+
+// ExclusionOperators implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) ExclusionOperators() []string {
+	return c.desc.ExclusionOperators
 }
 
 // NumKeyColumns implements the catalog.UniqueConstraint interface.
@@ -0,0 +1,63 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tabledesc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/errors"
+)
+
+// ValidatePolicies validates the row-level security policies of the table.
+func ValidatePolicies(desc catalog.TableDescriptor) error {
+	policies := desc.GetPolicies()
+	if (len(policies) > 0 || desc.IsRowLevelSecurityEnabled() || desc.IsRowLevelSecurityForced()) &&
+		!desc.IsTable() {
+		return errors.AssertionFailedf("row-level security is only supported on tables")
+	}
+	names := make(map[string]struct{}, len(policies))
+	for i := range policies {
+		p := &policies[i]
+		if p.Name == "" {
+			return errors.AssertionFailedf("empty policy name")
+		}
+		if _, ok := names[p.Name]; ok {
+			return errors.AssertionFailedf("duplicate policy name %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if _, ok := descpb.PolicyDescriptor_Type_name[int32(p.Type)]; !ok {
+			return errors.AssertionFailedf("invalid type %d for policy %q", p.Type, p.Name)
+		}
+		if _, ok := descpb.PolicyDescriptor_Command_name[int32(p.Command)]; !ok {
+			return errors.AssertionFailedf("invalid command %d for policy %q", p.Command, p.Name)
+		}
+		if len(p.RoleNames) == 0 {
+			return errors.AssertionFailedf("policy %q has no roles", p.Name)
+		}
+		for _, r := range p.RoleNames {
+			if r == "" {
+				return errors.AssertionFailedf("empty role name in policy %q", p.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// FindPolicyByName returns the index of the policy with the given name in
+// desc.Policies, or -1 if there is no such policy.
+func (desc *Mutable) FindPolicyByName(name string) int {
+	for i := range desc.Policies {
+		if desc.Policies[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
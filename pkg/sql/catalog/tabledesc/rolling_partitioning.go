@@ -0,0 +1,91 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tabledesc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// ValidateRollingPartitioning validates that the rolling partitioning options
+// are valid.
+func ValidateRollingPartitioning(rp *catpb.RollingPartitioning) error {
+	if rp == nil {
+		return nil
+	}
+	if rp.IntervalExpr == "" {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`"rolling_partition_interval" must be set`,
+		)
+	}
+	if rp.Lookahead != 0 {
+		if err := ValidateRollingPartitionLookahead("rolling_partition_lookahead", rp.Lookahead); err != nil {
+			return err
+		}
+	}
+	if rp.JobCron != "" {
+		if err := ValidateTTLCronExpr("rolling_partition_job_cron", rp.JobCron); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateRollingPartitionLookahead validates the number of partitions that
+// are created ahead of time.
+func ValidateRollingPartitionLookahead(key string, val int64) error {
+	if val <= 0 {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`"%s" must be at least 1`,
+			key,
+		)
+	}
+	return nil
+}
+
+// ValidateRollingPartitioningColumn validates that a table with rolling
+// partitioning can be partitioned by RANGE over the first column of its
+// primary key, and that the column holds a point in time.
+func ValidateRollingPartitioningColumn(desc catalog.TableDescriptor) error {
+	if !desc.HasRollingPartitioning() {
+		return nil
+	}
+	if desc.IsPartitionAllBy() {
+		return pgerror.Newf(
+			pgcode.InvalidTableDefinition,
+			"rolling partitioning is not supported on tables with PARTITION ALL BY or REGIONAL BY ROW",
+		)
+	}
+	pk := desc.GetPrimaryIndex()
+	if pk.NumKeyColumns() == 0 {
+		return nil
+	}
+	col, err := catalog.MustFindColumnByID(desc, pk.GetKeyColumnID(0))
+	if err != nil {
+		return err
+	}
+	switch col.GetType().Family() {
+	case types.TimestampFamily, types.TimestampTZFamily, types.DateFamily:
+	default:
+		return pgerror.Newf(
+			pgcode.InvalidTableDefinition,
+			"rolling partitioning requires the first column of the primary key to be a "+
+				"TIMESTAMP, TIMESTAMPTZ or DATE, but %s has type %s",
+			col.GetName(), col.GetType().SQLString(),
+		)
+	}
+	return nil
+}
@@ -314,6 +314,30 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 	}
 
+	// Process policies.
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if p.UsingExpr != "" {
+			if err := f(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			if err := f(&p.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Process triggers.
+	for i := range desc.Triggers {
+		if t := &desc.Triggers[i]; t.WhenExpr != "" {
+			if err := f(&t.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Process all non-index mutations.
 	for _, mut := range desc.Mutations {
 		if c := mut.GetColumn(); c != nil {
@@ -381,6 +405,9 @@ func (desc *wrapper) GetAllReferencedFunctionIDs() (catalog.DescriptorIDSet, err
 			ret.Add(id)
 		}
 	}
+	for i := range desc.Triggers {
+		ret.Add(desc.Triggers[i].FuncID)
+	}
 	// TODO(chengxiong): add logic to extract references from indexes when UDFs
 	// are allowed in them.
 	return ret.Union(catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)), nil
@@ -1118,7 +1145,7 @@ func (desc *Mutable) AddPrimaryIndex(idx descpb.IndexDescriptor) error {
 
 // AddSecondaryIndex adds a secondary index to a mutable table descriptor.
 func (desc *Mutable) AddSecondaryIndex(idx descpb.IndexDescriptor) error {
-	if idx.Type == descpb.IndexDescriptor_FORWARD {
+	if idx.Type != descpb.IndexDescriptor_INVERTED {
 		if err := checkColumnsValidForIndex(desc, idx.KeyColumnNames); err != nil {
 			return err
 		}
@@ -1956,7 +1983,7 @@ func (desc *Mutable) AddIndexMutation(
 
 func (desc *Mutable) checkValidIndex(idx *descpb.IndexDescriptor) error {
 	switch idx.Type {
-	case descpb.IndexDescriptor_FORWARD:
+	case descpb.IndexDescriptor_FORWARD, descpb.IndexDescriptor_BRIN:
 		if err := checkColumnsValidForIndex(desc, idx.KeyColumnNames); err != nil {
 			return err
 		}
@@ -2355,6 +2382,36 @@ func (desc *wrapper) HasRowLevelTTL() bool {
 	return desc.RowLevelTTL != nil
 }
 
+// GetRollingPartitioning implements the TableDescriptor interface.
+func (desc *wrapper) GetRollingPartitioning() *catpb.RollingPartitioning {
+	return desc.RollingPartitioning
+}
+
+// HasRollingPartitioning implements the TableDescriptor interface.
+func (desc *wrapper) HasRollingPartitioning() bool {
+	return desc.RollingPartitioning != nil
+}
+
+// GetPolicies implements the TableDescriptor interface.
+func (desc *wrapper) GetPolicies() []descpb.PolicyDescriptor {
+	return desc.Policies
+}
+
+// IsRowLevelSecurityEnabled implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityEnabled() bool {
+	return desc.RowLevelSecurity
+}
+
+// IsRowLevelSecurityForced implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityForced() bool {
+	return desc.ForceRowLevelSecurity
+}
+
+// GetTriggers implements the TableDescriptor interface.
+func (desc *wrapper) GetTriggers() []descpb.TriggerDescriptor {
+	return desc.Triggers
+}
+
 // GetExcludeDataFromBackup implements the TableDescriptor interface.
 func (desc *wrapper) GetExcludeDataFromBackup() bool {
 	return desc.ExcludeDataFromBackup
@@ -2400,6 +2457,17 @@ func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 			appendStorageParam(`ttl_label_metrics`, fmt.Sprintf(`%t`, labelMetrics))
 		}
 	}
+	if desc.HasRollingPartitioning() {
+		rp := desc.GetRollingPartitioning()
+		appendStorageParam(`rolling_partition_interval`, string(rp.IntervalExpr))
+		if lookahead := rp.Lookahead; lookahead != 0 {
+			appendStorageParam(`rolling_partition_lookahead`, fmt.Sprintf(`%d`, lookahead))
+		}
+		if rp.HasRetentionExpr() {
+			appendStorageParam(`rolling_partition_retention`, string(rp.RetentionExpr))
+		}
+		appendStorageParam(`rolling_partition_job_cron`, fmt.Sprintf(`'%s'`, rp.JobCronOrDefault()))
+	}
 	if exclude := desc.GetExcludeDataFromBackup(); exclude {
 		appendStorageParam(`exclude_data_from_backup`, `true`)
 	}
@@ -492,6 +492,30 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policies.
+	for i := range tableDesc.Policies {
+		p := &tableDesc.Policies[i]
+		if p.UsingExpr != "" {
+			if err := renameInExpr(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			if err := renameInExpr(&p.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Rename the column in the WHEN conditions of triggers.
+	for i := range tableDesc.Triggers {
+		if t := &tableDesc.Triggers[i]; t.WhenExpr != "" {
+			if err := renameInExpr(&t.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Rename the column in computed columns.
 	for i := range tableDesc.Columns {
 		if otherCol := &tableDesc.Columns[i]; otherCol.IsComputed() {
@@ -0,0 +1,107 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tabledesc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/errors"
+)
+
+// ValidateTriggers validates the triggers of the table.
+func ValidateTriggers(desc catalog.TableDescriptor) error {
+	triggers := desc.GetTriggers()
+	if len(triggers) > 0 && !desc.IsTable() {
+		return errors.AssertionFailedf("triggers are only supported on tables")
+	}
+	names := make(map[string]struct{}, len(triggers))
+	ids := make(map[descpb.TriggerID]struct{}, len(triggers))
+	for i := range triggers {
+		t := &triggers[i]
+		if t.ID == 0 {
+			return errors.AssertionFailedf("invalid trigger ID 0 for trigger %q", t.Name)
+		}
+		if t.ID >= desc.GetNextTriggerID() {
+			return errors.AssertionFailedf("trigger %q has ID %d not less than NextTriggerID value %d",
+				t.Name, t.ID, desc.GetNextTriggerID())
+		}
+		if _, ok := ids[t.ID]; ok {
+			return errors.AssertionFailedf("duplicate trigger ID %d", t.ID)
+		}
+		ids[t.ID] = struct{}{}
+		if t.Name == "" {
+			return errors.AssertionFailedf("empty trigger name")
+		}
+		if _, ok := names[t.Name]; ok {
+			return errors.AssertionFailedf("duplicate trigger name %q", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if _, ok := semenumpb.TriggerActionTime_name[int32(t.ActionTime)]; !ok {
+			return errors.AssertionFailedf("invalid action time %d for trigger %q", t.ActionTime, t.Name)
+		}
+		if len(t.Events) == 0 {
+			return errors.AssertionFailedf("trigger %q has no events", t.Name)
+		}
+		hasUpdate := false
+		for _, e := range t.Events {
+			if _, ok := semenumpb.TriggerEventType_name[int32(e)]; !ok {
+				return errors.AssertionFailedf("invalid event %d for trigger %q", e, t.Name)
+			}
+			hasUpdate = hasUpdate || e == semenumpb.TriggerEventType_UPDATE
+		}
+		if len(t.UpdateColumnIDs) > 0 && !hasUpdate {
+			return errors.AssertionFailedf("trigger %q has update columns but no UPDATE event", t.Name)
+		}
+		for _, colID := range t.UpdateColumnIDs {
+			if catalog.FindColumnByID(desc, colID) == nil {
+				return errors.AssertionFailedf("trigger %q references unknown column %d", t.Name, colID)
+			}
+		}
+		if t.FuncID == descpb.InvalidID {
+			return errors.AssertionFailedf("trigger %q has no function", t.Name)
+		}
+	}
+	return nil
+}
+
+// GetTriggerByID returns the trigger with the given ID, or nil if there is no
+// such trigger.
+func (desc *Mutable) GetTriggerByID(id descpb.TriggerID) *descpb.TriggerDescriptor {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].ID == id {
+			return &desc.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// GetTriggerByName returns the trigger with the given name, or nil if there is
+// no such trigger.
+func (desc *Mutable) GetTriggerByName(name string) *descpb.TriggerDescriptor {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].Name == name {
+			return &desc.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// RemoveTrigger removes the trigger with the given ID from the table, if it
+// exists.
+func (desc *Mutable) RemoveTrigger(id descpb.TriggerID) {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].ID == id {
+			desc.Triggers = append(desc.Triggers[:i], desc.Triggers[i+1:]...)
+			return
+		}
+	}
+}
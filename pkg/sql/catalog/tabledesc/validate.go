@@ -198,6 +198,11 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all trigger functions exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundFuncRef(desc.Triggers[i].FuncID, vdg))
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -262,6 +267,17 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in trigger functions.
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		fn, err := vdg.GetFunctionDescriptor(t.FuncID)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, t.ID))
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -375,6 +391,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -864,6 +897,12 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vea.Report(ValidateTTLExpirationExpr(desc))
 	vea.Report(ValidateTTLExpirationColumn(desc, vea.IsActive(clusterversion.V23_2TTLAllowDescPK)))
 
+	vea.Report(ValidateRollingPartitioning(desc.GetRollingPartitioning()))
+	vea.Report(ValidateRollingPartitioningColumn(desc))
+
+	vea.Report(ValidatePolicies(desc))
+	vea.Report(ValidateTriggers(desc))
+
 	// Validate that there are no column with both a foreign key ON UPDATE and an
 	// ON UPDATE expression. This check is made to ensure that we know which ON
 	// UPDATE action to perform when a FK UPDATE happens.
@@ -1247,6 +1286,13 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if ops := c.ExclusionOperators(); len(ops) > 0 && len(ops) != c.NumKeyColumns() {
+			return errors.Newf(
+				"exclusion constraint %q has %d operators for %d columns",
+				c.GetName(), len(ops), c.NumKeyColumns(),
+			)
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
@@ -1423,6 +1469,13 @@ func (desc *wrapper) validateTableIndexes(columnsByID map[descpb.ColumnID]catalo
 					idx.GetName(), idx.GetPredicate())
 			}
 		}
+		if idx.GetType() == descpb.IndexDescriptor_BRIN {
+			if err := validateBRINIndex(idx, columnsByID); err != nil {
+				return err
+			}
+		} else if idx.IndexDesc().PagesPerRange != nil {
+			return errors.Newf("index %q is not a BRIN index but has pages_per_range set", idx.GetName())
+		}
 
 		if !idx.IsMutation() {
 			if idx.IndexDesc().UseDeletePreservingEncoding {
@@ -1584,6 +1637,33 @@ func (desc *wrapper) validateTableIndexes(columnsByID map[descpb.ColumnID]catalo
 	return nil
 }
 
+// validateBRINIndex validates the structure of a BRIN index: it summarizes a
+// single column for blocks of values of an integer key suffix column.
+func validateBRINIndex(idx catalog.Index, columnsByID map[descpb.ColumnID]catalog.Column) error {
+	desc := idx.IndexDesc()
+	switch {
+	case idx.NumKeyColumns() != 1:
+		return errors.Newf("BRIN index %q must have exactly one key column", idx.GetName())
+	case idx.IsUnique():
+		return errors.Newf("BRIN index %q cannot be unique", idx.GetName())
+	case idx.NumSecondaryStoredColumns() > 0:
+		return errors.Newf("BRIN index %q cannot store columns", idx.GetName())
+	case idx.IsPartial():
+		return errors.Newf("BRIN index %q cannot be partial", idx.GetName())
+	case idx.IsSharded():
+		return errors.Newf("BRIN index %q cannot be hash sharded", idx.GetName())
+	case idx.NumKeySuffixColumns() == 0:
+		return errors.Newf("BRIN index %q must have a key suffix column", idx.GetName())
+	case desc.PagesPerRange != nil && *desc.PagesPerRange == 0:
+		return errors.Newf("BRIN index %q has invalid pages_per_range 0", idx.GetName())
+	}
+	if col := columnsByID[desc.BRINBlockColumnID()]; col.GetType().Family() != types.IntFamily {
+		return errors.Newf("BRIN index %q block column %q is not an integer column",
+			idx.GetName(), col.GetName())
+	}
+	return nil
+}
+
 // ensureShardedIndexNotComputed ensures that the sharded index is not based on a computed
 // column. This is because the sharded index is based on a hidden computed shard column
 // under the hood and we don't support transitively computed columns (computed column A
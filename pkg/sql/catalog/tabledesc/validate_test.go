@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -138,6 +139,12 @@ var validationMap = []struct {
 			"HistogramBuckets":              {status: thisFieldReferencesNoObjects},
 			"HistogramSamples":              {status: thisFieldReferencesNoObjects},
 			"SchemaLocked":                  {status: thisFieldReferencesNoObjects},
+			"RollingPartitioning":           {status: iSolemnlySwearThisFieldIsValidated},
+			"Policies":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurity":              {status: thisFieldReferencesNoObjects},
+			"ForceRowLevelSecurity":         {status: thisFieldReferencesNoObjects},
+			"Triggers":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextTriggerID":                 {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -177,6 +184,7 @@ var validationMap = []struct {
 			"UseDeletePreservingEncoding": {status: thisFieldReferencesNoObjects},
 			"ConstraintID":                {status: iSolemnlySwearThisFieldIsValidated},
 			"CreatedAtNanos":              {status: thisFieldReferencesNoObjects},
+			"PagesPerRange":               {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -227,17 +235,24 @@ var validationMap = []struct {
 			"OnUpdate":          {status: thisFieldReferencesNoObjects},
 			"Match":             {status: thisFieldReferencesNoObjects},
 			"ConstraintID":      {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrable":        {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred": {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":         {status: thisFieldReferencesNoObjects},
-			"Validity":     {status: thisFieldReferencesNoObjects},
-			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
-			"ConstraintID": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":           {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":         {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":              {status: thisFieldReferencesNoObjects},
+			"Validity":          {status: thisFieldReferencesNoObjects},
+			"Predicate":         {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID":      {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrable":        {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred": {status: thisFieldReferencesNoObjects},
+
+			"ExclusionOperators":    {status: iSolemnlySwearThisFieldIsValidated},
+			"ExclusionAccessMethod": {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -261,6 +276,7 @@ var validationMap = []struct {
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Composite":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"Domain":                        {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -327,6 +343,14 @@ var validationMap = []struct {
 			"ModificationTime":              {status: thisFieldReferencesNoObjects},
 			"Version":                       {status: thisFieldReferencesNoObjects},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
+			"IsProcedure":                   {status: thisFieldReferencesNoObjects},
+			"Aggregate":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"Security":                      {status: thisFieldReferencesNoObjects},
+			"Config":                        {status: thisFieldReferencesNoObjects},
+			"Cost":                          {status: thisFieldReferencesNoObjects},
+			"Rows":                          {status: thisFieldReferencesNoObjects},
+			"Support":                       {status: thisFieldReferencesNoObjects},
+			"Parallel":                      {status: thisFieldReferencesNoObjects},
 		},
 	},
 }
@@ -2837,6 +2861,89 @@ func TestValidateTableDesc(t *testing.T) {
 				NextIndexID:      3,
 				NextConstraintID: 2,
 			}},
+		{err: `trigger "t" has ID 2 not less than NextTriggerID value 2`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary", ColumnIDs: []descpb.ColumnID{1}, ColumnNames: []string{"bar"}},
+				},
+				PrimaryIndex: descpb.IndexDescriptor{ID: 1, Name: "bar", ConstraintID: 1,
+					KeyColumnIDs: []descpb.ColumnID{1}, KeyColumnNames: []string{"bar"},
+					KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+					EncodingType:        catenumpb.PrimaryIndexEncoding,
+					Version:             descpb.LatestIndexDescriptorVersion,
+				},
+				Triggers: []descpb.TriggerDescriptor{
+					{ID: 2, Name: "t", Events: []semenumpb.TriggerEventType{semenumpb.TriggerEventType_INSERT}, FuncID: 100},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextIndexID:      2,
+				NextConstraintID: 2,
+				NextTriggerID:    2,
+			}},
+		{err: `duplicate trigger ID 1`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary", ColumnIDs: []descpb.ColumnID{1}, ColumnNames: []string{"bar"}},
+				},
+				PrimaryIndex: descpb.IndexDescriptor{ID: 1, Name: "bar", ConstraintID: 1,
+					KeyColumnIDs: []descpb.ColumnID{1}, KeyColumnNames: []string{"bar"},
+					KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+					EncodingType:        catenumpb.PrimaryIndexEncoding,
+					Version:             descpb.LatestIndexDescriptorVersion,
+				},
+				Triggers: []descpb.TriggerDescriptor{
+					{ID: 1, Name: "t1", Events: []semenumpb.TriggerEventType{semenumpb.TriggerEventType_INSERT}, FuncID: 100},
+					{ID: 1, Name: "t2", Events: []semenumpb.TriggerEventType{semenumpb.TriggerEventType_DELETE}, FuncID: 100},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextIndexID:      2,
+				NextConstraintID: 2,
+				NextTriggerID:    2,
+			}},
+		{err: `trigger "t" has update columns but no UPDATE event`,
+			desc: descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary", ColumnIDs: []descpb.ColumnID{1}, ColumnNames: []string{"bar"}},
+				},
+				PrimaryIndex: descpb.IndexDescriptor{ID: 1, Name: "bar", ConstraintID: 1,
+					KeyColumnIDs: []descpb.ColumnID{1}, KeyColumnNames: []string{"bar"},
+					KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+					EncodingType:        catenumpb.PrimaryIndexEncoding,
+					Version:             descpb.LatestIndexDescriptorVersion,
+				},
+				Triggers: []descpb.TriggerDescriptor{
+					{ID: 1, Name: "t", Events: []semenumpb.TriggerEventType{semenumpb.TriggerEventType_INSERT},
+						UpdateColumnIDs: []descpb.ColumnID{1}, FuncID: 100},
+				},
+				NextColumnID:     2,
+				NextFamilyID:     1,
+				NextIndexID:      2,
+				NextConstraintID: 2,
+				NextTriggerID:    2,
+			}},
 	}
 	for i, d := range testData {
 		t.Run(d.err, func(t *testing.T) {
@@ -159,4 +159,20 @@ func ensureTypeMetadataIsHydrated(
 			}
 		}
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		tm.DomainData = &types.DomainMetadata{
+			BaseType: d.GetBaseType(),
+			NotNull:  d.IsNotNull(),
+			Checks:   make([]types.DomainCheck, d.NumChecks()),
+		}
+		if expr, ok := d.GetDefaultExpr(); ok {
+			tm.DomainData.DefaultExpr = &expr
+		}
+		for i := range tm.DomainData.Checks {
+			tm.DomainData.Checks[i] = types.DomainCheck{
+				Name: d.GetCheckName(i),
+				Expr: d.GetCheckExpr(i),
+			}
+		}
+	}
 }
@@ -43,6 +43,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -508,6 +509,10 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -625,6 +630,13 @@ func (desc *immutable) ValidateForwardReferences(
 			}
 		}
 	}
+
+	if d := desc.AsDomainTypeDescriptor(); d != nil && d.GetBaseType().UserDefined() {
+		// Domains over user-defined types are not supported.
+		vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from domain type %q",
+			d.GetBaseType().String(), desc.GetName(),
+		))
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -768,6 +780,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -994,6 +1012,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1020,6 +1046,39 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// GetBaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetBaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() (string, bool) {
+	if desc.Domain.DefaultExpr == nil {
+		return "", false
+	}
+	return *desc.Domain.DefaultExpr, true
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheckName implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckName(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Name
+}
+
+// GetCheckExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckExpr(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
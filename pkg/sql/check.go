@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
@@ -224,6 +225,26 @@ func nonMatchingRowQuery(
 		targetCols[i] = fmt.Sprintf("t.%s", tree.NameString(referencedColNames[i]))
 		on[i] = fmt.Sprintf("%s = %s", qualifiedSrcCols[i], targetCols[i])
 	}
+	srcWhereSep := " AND "
+	// Sufficient to check the first column to see whether there was no matching row
+	noMatchCol := targetCols[0]
+	if fk.Match == semenumpb.Match_PARTIAL {
+		// With MATCH PARTIAL, rows which are not all NULL must have a matching
+		// row for their non-NULL columns. The referenced columns of a matching
+		// row may be NULL, so use a primary key column to detect that there was
+		// no matching row.
+		srcWhereSep = " OR "
+		for i := 0; i < nCols; i++ {
+			on[i] = fmt.Sprintf("(%s IS NULL OR %s)", qualifiedSrcCols[i], on[i])
+		}
+		pkColName, err := catalog.ColumnNamesForIDs(
+			targetTbl, []descpb.ColumnID{targetTbl.GetPrimaryIndex().GetKeyColumnID(0)},
+		)
+		if err != nil {
+			return "", nil, err
+		}
+		noMatchCol = fmt.Sprintf("t.%s", tree.NameString(pkColName[0]))
+	}
 
 	limit := ""
 	if limitResults {
@@ -239,12 +260,11 @@ func nonMatchingRowQuery(
 		strings.Join(qualifiedSrcCols, ", "), // 1
 		strings.Join(srcCols, ", "),          // 2
 		srcTbl.GetID(),                       // 3
-		strings.Join(srcWhere, " AND "),      // 4
+		strings.Join(srcWhere, srcWhereSep),  // 4
 		targetTbl.GetID(),                    // 5
 		strings.Join(on, " AND "),            // 6
-		// Sufficient to check the first column to see whether there was no matching row
-		targetCols[0], // 7
-		limit,         // 8
+		noMatchCol,                           // 7
+		limit,                                // 8
 	)
 	if indexIDForValidation != 0 {
 		query = fmt.Sprintf(
@@ -258,12 +278,11 @@ func nonMatchingRowQuery(
 			strings.Join(srcCols, ", "),          // 2
 			srcTbl.GetID(),                       // 3
 			indexIDForValidation,                 // 4
-			strings.Join(srcWhere, " AND "),      // 5
+			strings.Join(srcWhere, srcWhereSep),  // 5
 			targetTbl.GetID(),                    // 6
 			strings.Join(on, " AND "),            // 7
-			// Sufficient to check the first column to see whether there was no matching row
-			targetCols[0], // 8
-			limit,         // 9
+			noMatchCol,                           // 8
+			limit,                                // 9
 		)
 	}
 	return query, originColNames, nil
@@ -503,12 +522,10 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
-			return validateUniqueConstraint(
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				p.InternalSQLTxn(),
 				p.User(),
@@ -583,12 +600,10 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
+				uc,
 				0, /* indexIDForValidation */
 				txn,
 				user,
@@ -642,6 +657,212 @@ func validateUniqueConstraint(
 		query,
 	)
 
+	values, err := runUniqueValidationQuery(ctx, txn, user, "validate unique constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add a unique index due to duplicated keys.
+		errMsg := "could not create unique constraint"
+		if preExisting {
+			errMsg = "failed to validate unique constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+				),
+				constraintName,
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+			),
+		)
+	}
+	return nil
+}
+
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given UNIQUE WITHOUT INDEX or EXCLUDE constraint. See
+// validateUniqueConstraint for a description of the arguments.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	if uc.IsExclusion() {
+		return validateExclusionConstraint(
+			ctx, srcTable, uc, indexIDForValidation, txn, user, preExisting,
+		)
+	}
+	return validateUniqueConstraint(
+		ctx,
+		srcTable,
+		uc.GetName(),
+		uc.CollectKeyColumnIDs().Ordered(),
+		uc.GetPredicate(),
+		indexIDForValidation,
+		txn,
+		user,
+		preExisting,
+	)
+}
+
+// conflictingRowFilter returns a filter on the rows of srcTbl, aliased as tbl1,
+// which is true for the rows that conflict with another row of the table
+// according to an exclusion constraint. For an exclusion constraint on columns
+// a and b with operators = and &&, the filter is of the form:
+//
+// (pred) AND EXISTS (
+//
+//	SELECT 1 FROM [tbl AS tbl2]
+//	WHERE (pred) AND tbl1.a = tbl2.a AND tbl1.b && tbl2.b
+//	AND (tbl1.pk1, tbl1.pk2) != (tbl2.pk1, tbl2.pk2)
+//
+// )
+//
+// The pred argument is a partial constraint predicate, which is empty if the
+// constraint is not partial. It refers to columns by their unqualified names,
+// which resolve to tbl1 outside of the subquery and to tbl2 inside of it.
+//
+// `indexIDForValidation`, if non-zero, will be used to force the subquery to
+// use this particular index by hinting the query.
+func conflictingRowFilter(
+	srcTbl catalog.TableDescriptor,
+	columnIDs []descpb.ColumnID,
+	ops []string,
+	pred string,
+	indexIDForValidation descpb.IndexID,
+) (filter string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, columnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// There will be an expression in the subquery for each of the columns, one
+	// for the primary key, and possibly one for pred.
+	conds := make([]string, 0, len(colNames)+2)
+	if pred != "" {
+		conds = append(conds, fmt.Sprintf("(%s)", pred))
+	}
+	for i, n := range colNames {
+		name := tree.NameString(n)
+		conds = append(conds, fmt.Sprintf("tbl1.%[1]s %[2]s tbl2.%[1]s", name, ops[i]))
+	}
+	pk1 := make([]string, len(pkColNames))
+	pk2 := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		pk1[i] = "tbl1." + tree.NameString(n)
+		pk2[i] = "tbl2." + tree.NameString(n)
+	}
+	conds = append(conds, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(pk1, ", "), strings.Join(pk2, ", "),
+	))
+
+	index := ""
+	if indexIDForValidation != 0 {
+		index = fmt.Sprintf("@[%d]", indexIDForValidation)
+	}
+	filter = fmt.Sprintf(
+		`EXISTS (SELECT 1 FROM [%d AS tbl2]%s WHERE %s)`,
+		srcTbl.GetID(), index, strings.Join(conds, " AND "),
+	)
+	if pred != "" {
+		filter = fmt.Sprintf("(%s) AND %s", pred, filter)
+	}
+	return filter, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint. See
+// validateUniqueConstraint for a description of the arguments.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	desc := uc.UniqueWithoutIndexDesc()
+	filter, colNames, err := conflictingRowFilter(
+		srcTable, desc.ColumnIDs, desc.ExclusionOperators, desc.Predicate, indexIDForValidation,
+	)
+	if err != nil {
+		return err
+	}
+	cols := make([]string, len(colNames))
+	for i, n := range colNames {
+		cols[i] = "tbl1." + tree.NameString(n)
+	}
+	index := ""
+	if indexIDForValidation != 0 {
+		index = fmt.Sprintf("@[%d]", indexIDForValidation)
+	}
+	query := fmt.Sprintf(
+		`SELECT %s FROM [%d AS tbl1]%s WHERE %s LIMIT 1`,
+		strings.Join(cols, ", "), srcTable.GetID(), index, filter,
+	)
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.GetName(),
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := runUniqueValidationQuery(ctx, txn, user, "validate exclusion constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.GetName(),
+				),
+				uc.GetName(),
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with another key.",
+				strings.Join(colNames, ", "), strings.Join(valuesStr, ", "),
+			),
+		)
+	}
+	return nil
+}
+
+// runUniqueValidationQuery runs a query that validates a unique or exclusion
+// constraint, and returns the row it produces, if any.
+func runUniqueValidationQuery(
+	ctx context.Context, txn isql.Txn, user username.SQLUsername, opName string, query string,
+) (tree.Datums, error) {
 	sessionDataOverride := sessiondata.NoSessionDataOverride
 	sessionDataOverride.User = user
 	// We are likely to have performed a lot of work before getting here (e.g.
@@ -655,13 +876,14 @@ func validateUniqueConstraint(
 	// retries in order to not waste (a lot of) work that was performed before
 	// we got here.
 	var values tree.Datums
+	var err error
 	retryOptions := retry.Options{
 		InitialBackoff: 20 * time.Millisecond,
 		Multiplier:     1.5,
 		MaxRetries:     5,
 	}
 	for r := retry.StartWithCtx(ctx, retryOptions); r.Next(); {
-		values, err = txn.QueryRowEx(ctx, "validate unique constraint", txn.KV(), sessionDataOverride, query)
+		values, err = txn.QueryRowEx(ctx, opName, txn.KV(), sessionDataOverride, query)
 		if err == nil {
 			break
 		}
@@ -672,32 +894,9 @@ func validateUniqueConstraint(
 			log.Infof(ctx, "retrying the validation query because of %v", err)
 			continue
 		}
-		return err
-	}
-	if values.Len() > 0 {
-		valuesStr := make([]string, len(values))
-		for i := range values {
-			valuesStr[i] = values[i].String()
-		}
-		// Note: this error message mirrors the message produced by Postgres
-		// when it fails to add a unique index due to duplicated keys.
-		errMsg := "could not create unique constraint"
-		if preExisting {
-			errMsg = "failed to validate unique constraint"
-		}
-		return errors.WithDetail(
-			pgerror.WithConstraintName(
-				pgerror.Newf(
-					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
-				),
-				constraintName,
-			),
-			fmt.Sprintf(
-				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
-			),
-		)
+		return nil, err
 	}
-	return nil
+	return values, nil
 }
 
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
@@ -872,6 +1071,17 @@ func checkMutationInput(
 		}
 		colIdx++
 	}
+
+	// The WITH CHECK expressions of the row-level security policies of the
+	// table are checked after the check constraints. Unlike check constraints,
+	// they are violated if they evaluate to NULL.
+	if tabDesc.IsRowLevelSecurityEnabled() && checkOrds.Contains(len(checks)) {
+		if res, err := tree.GetBool(checkVals[colIdx]); err != nil {
+			return err
+		} else if !res {
+			return sqlerrors.NewRowLevelSecurityViolationError(tabDesc.GetName())
+		}
+	}
 	return nil
 }
 
@@ -414,6 +414,9 @@ func (b *BatchEncoder) encodePK(ctx context.Context, ind catalog.Index) error {
 
 // encodeSecondaryIndex is the vector version of rowenc.EncodeSecondaryIndex.
 func (b *BatchEncoder) encodeSecondaryIndex(ctx context.Context, ind catalog.Index) error {
+	if ind.GetType() == descpb.IndexDescriptor_BRIN {
+		return errors.AssertionFailedf("BRIN index %q can't be maintained by the vectorized encoder", ind.GetName())
+	}
 	var err error
 	secondaryIndexKeyPrefix := rowenc.MakeIndexKeyPrefix(b.rh.Codec, b.rh.TableDesc.GetID(), ind.GetID())
 
@@ -858,10 +858,18 @@ func NewColOperator(
 					// would be to simply include all key columns into the set
 					// of needed for the fetch and to project them away in the
 					// ColBatchDirectScan.
-					if row.GetKeyLockingStrength(core.TableReader.LockingStrength) != lock.None ||
+					lockStrength := row.GetKeyLockingStrength(
+						ctx, flowCtx.EvalCtx.Settings, core.TableReader.LockingStrength,
+					)
+					if lockStrength != lock.None ||
 						core.TableReader.LockingWaitPolicy == descpb.ScanLockingWaitPolicy_SKIP_LOCKED {
 						return false
 					}
+					// The ColBatchDirectScan doesn't support sampling the
+					// rows.
+					if core.TableReader.Sample != nil {
+						return false
+					}
 					// At the moment, the ColBatchDirectScan cannot handle Gets
 					// (it's not clear whether it is worth to handle them via
 					// the same path as for Scans and ReverseScans (which could
@@ -2245,6 +2253,11 @@ func planCastOperator(
 	factory coldata.ColumnFactory,
 	evalCtx *eval.Context,
 ) (op colexecop.Operator, resultIdx int, typs []*types.T, err error) {
+	if toType.TypeMeta.DomainData != nil {
+		// Casts to domains have to check the constraints of the domain, which is
+		// only supported by the row-by-row engine.
+		return nil, 0, nil, errors.Errorf("unhandled cast to domain %s", toType.Name())
+	}
 	outputIdx := len(columnTypes)
 	op, err = colexecbase.GetCastOperator(colmem.NewAllocator(ctx, acc, factory), input, inputIdx, outputIdx, fromType, toType, evalCtx)
 	typs = append(columnTypes, toType)
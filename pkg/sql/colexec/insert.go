@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -222,5 +223,15 @@ func (v *vectorInserter) checkMutationInput(ctx context.Context, b coldata.Batch
 		}
 		colIdx++
 	}
+	if v.desc.IsRowLevelSecurityEnabled() && v.checkOrds.Contains(len(checks)) {
+		vec := b.ColVec(colIdx + len(v.insertCols))
+		bools := vec.Bool()
+		nulls := vec.Nulls()
+		for r := 0; r < b.Length(); r++ {
+			if !bools[r] || nulls.NullAt(r) {
+				return sqlerrors.NewRowLevelSecurityViolationError(v.desc.GetName())
+			}
+		}
+	}
 	return nil
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/colencoding"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execreleasable"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
@@ -238,6 +239,9 @@ type cFetcher struct {
 	// stableKVs indicates whether the KVs returned by nextKVer are stable (i.e.
 	// are not invalidated) across NextKV() calls.
 	stableKVs bool
+	// sampler, if set, selects the rows that are part of a BERNOULLI sample.
+	// The rows that aren't selected are decoded but not emitted.
+	sampler *execinfra.TableSampler
 	// bytesRead, kvPairsRead, and batchRequestsIssued store the total number of
 	// bytes read, key-values pairs read, and of BatchRequests issued,
 	// respectively, by this cFetcher throughout its lifetime in case when the
@@ -281,6 +285,9 @@ type cFetcher struct {
 		lastRowPrefix roachpb.Key
 		// firstKeyOfRow, if set, is the first key in the current row.
 		firstKeyOfRow roachpb.Key
+		// skipRow is set if the current row wasn't selected by the sampler, in
+		// which case it is discarded once it is finalized.
+		skipRow bool
 		// prettyValueBuf is a temp buffer used to create strings for tracing.
 		prettyValueBuf *bytes.Buffer
 
@@ -816,6 +823,9 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 				}
 				cf.machine.lastRowPrefix = cf.machine.nextKV.Key[:prefixLen+(origRemainingBytesLen-len(remainingBytes))]
 			}
+			// The sample only depends on the row key, so that the rows selected
+			// are the same as the ones selected by the row-by-row engine.
+			cf.machine.skipRow = cf.sampler != nil && !cf.sampler.SelectRow(cf.machine.lastRowPrefix)
 
 			familyID, err := cf.getCurrentColumnFamilyID()
 			if err != nil {
@@ -922,6 +932,16 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 			if err := cf.fillNulls(); err != nil {
 				return nil, err
 			}
+			if cf.machine.skipRow {
+				// The row isn't part of the sample, so the next row overwrites
+				// it. The values are simply overwritten, but the nulls have to
+				// be unset.
+				for _, nulls := range cf.machine.colvecs.Nulls {
+					nulls.UnsetNull(cf.machine.rowIdx)
+				}
+				cf.shiftState()
+				continue
+			}
 			// Note that we haven't set the tableoid value (if that system
 			// column is requested) yet, but it is ok for the purposes of the
 			// memory accounting - oids are fixed length values and, thus, have
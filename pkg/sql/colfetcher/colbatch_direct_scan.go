@@ -209,7 +209,9 @@ func NewColBatchDirectScan(
 	// should be able to modify the BatchRequest, but alas.
 	fetchSpec := spec.FetchSpec
 	fetcher := row.NewDirectKVBatchFetcher(
+		ctx,
 		flowCtx.Txn,
+		flowCtx.EvalCtx.Settings,
 		bsHeader,
 		&fetchSpec,
 		spec.Reverse,
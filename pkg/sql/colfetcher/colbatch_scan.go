@@ -220,6 +220,13 @@ func newColBatchScanBase(
 type ColBatchScan struct {
 	*colBatchScanBase
 	cf *cFetcher
+	// sampler is set if the ColBatchScan only reads a random sample of the
+	// blocks of the table (see TableReaderSpec.Sample). The individual rows of
+	// a BERNOULLI sample are selected by the cFetcher.
+	sampler *execinfra.TableSampler
+	// emptySample is set if none of the spans were selected by the sampler,
+	// in which case the scan is not started.
+	emptySample bool
 }
 
 // ScanOperator combines common interfaces between operators that perform KV
@@ -241,10 +248,21 @@ func (s *ColBatchScan) Init(ctx context.Context) {
 		s.Ctx, s.flowCtx, "colbatchscan", s.processorID,
 		&s.contentionEventsListener, &s.scanStatsListener, &s.tenantConsumptionListener,
 	)
+	spans := s.Spans
+	if s.sampler != nil {
+		var err error
+		if spans, err = s.sampler.SampleSpans(s.Ctx, s.flowCtx.Cfg.DistSender, spans); err != nil {
+			colexecerror.InternalError(err)
+		}
+		if len(spans) == 0 {
+			s.emptySample = true
+			return
+		}
+	}
 	limitBatches := !s.parallelize
 	if err := s.cf.StartScan(
 		s.Ctx,
-		s.Spans,
+		spans,
 		limitBatches,
 		s.batchBytesLimit,
 		s.limitHint,
@@ -255,6 +273,9 @@ func (s *ColBatchScan) Init(ctx context.Context) {
 
 // Next is part of the colexecop.Operator interface.
 func (s *ColBatchScan) Next() coldata.Batch {
+	if s.emptySample {
+		return coldata.ZeroBatch
+	}
 	bat, err := s.cf.NextBatch(s.Ctx)
 	if err != nil {
 		colexecerror.InternalError(err)
@@ -345,7 +366,9 @@ func NewColBatchScan(
 		return nil, nil, err
 	}
 	kvFetcher := row.NewKVFetcher(
+		ctx,
 		flowCtx.Txn,
+		flowCtx.EvalCtx.Settings,
 		bsHeader,
 		spec.Reverse,
 		spec.LockingStrength,
@@ -367,8 +390,13 @@ func NewColBatchScan(
 		fetcher.Release()
 		return nil, nil, err
 	}
+	sampler := execinfra.NewTableSampler(spec.Sample)
+	if sampler != nil && sampler.SelectsRows() {
+		fetcher.sampler = sampler
+	}
 	return &ColBatchScan{
 		colBatchScanBase: base,
 		cf:               fetcher,
+		sampler:          sampler,
 	}, tableArgs.typs, nil
 }
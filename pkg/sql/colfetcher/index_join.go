@@ -557,6 +557,7 @@ func NewColIndexJoin(
 		cFetcherMemoryLimit = int64(math.Ceil(float64(totalMemoryLimit) / 16.0))
 		streamerBudgetLimit := 14 * cFetcherMemoryLimit
 		kvFetcher = row.NewStreamingKVFetcher(
+			ctx,
 			flowCtx.Cfg.DistSender,
 			flowCtx.Stopper(),
 			txn,
@@ -575,7 +576,9 @@ func NewColIndexJoin(
 		)
 	} else {
 		kvFetcher = row.NewKVFetcher(
+			ctx,
 			txn,
+			flowCtx.EvalCtx.Settings,
 			nil,   /* bsHeader */
 			false, /* reverse */
 			spec.LockingStrength,
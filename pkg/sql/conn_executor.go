@@ -49,6 +49,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
@@ -364,6 +365,10 @@ type Server struct {
 
 	idxRecommendationsCache *idxrecommendations.IndexRecCache
 
+	// notifications routes the notifications sent with NOTIFY to the
+	// sessions on this node that are listening on their channel.
+	notifications *pgnotify.Registry
+
 	mu struct {
 		syncutil.Mutex
 		connectionCount     int64
@@ -456,6 +461,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
 		idxRecommendationsCache: idxrecommendations.NewIndexRecommendationsCache(cfg.Settings),
+		notifications:           pgnotify.NewRegistry(),
 	}
 
 	telemetryLoggingMetrics := newTelemetryLoggingmetrics(cfg.TelemetryLoggingTestingKnobs, cfg.Settings)
@@ -614,6 +620,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.insights.Start(ctx, stopper)
 
 	s.txnIDCache.Start(ctx, stopper)
+
+	s.startNotificationsWatcher(ctx, stopper)
 }
 
 // GetSQLStatsController returns the persistedsqlstats.Controller for current
@@ -1251,6 +1259,10 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType})
+	if ex.notificationListener != nil {
+		ex.notificationListener.UnlistenAll()
+	}
+	ex.advisoryLocks.releaseAll(ctx)
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1277,7 +1289,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
-		if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+		if err := ex.extraTxnState.sqlCursors.closeAll(true /* closeHeld */); err != nil {
 			log.Warningf(ctx, "error closing cursors: %v", err)
 		}
 	}
@@ -1562,6 +1574,15 @@ type connExecutor struct {
 		// createdSequences keeps track of sequences created in the current transaction.
 		// The map key is the sequence descpb.ID.
 		createdSequences map[descpb.ID]struct{}
+
+		// notifications contains the effects of the LISTEN, UNLISTEN and
+		// NOTIFY statements executed in the current transaction.
+		notifications txnNotifications
+
+		// deferredConstraints contains the checking mode of the DEFERRABLE
+		// constraints and the constraint violations postponed to the commit
+		// of the current transaction.
+		deferredConstraints txnDeferredConstraints
 	}
 
 	// sessionDataStack contains the user-configurable connection variables.
@@ -1642,6 +1663,19 @@ type connExecutor struct {
 	// any. This is printed by high-level panic recovery.
 	curStmtAST tree.Statement
 
+	// storedProcTxnState tracks the transaction control statements executed by
+	// a stored procedure. It outlives the transaction of the CALL statement,
+	// since the procedure is resumed in a new transaction.
+	storedProcTxnState storedProcTxnState
+
+	// notificationListener receives the notifications sent on the channels
+	// the session is listening on. It is nil until the first LISTEN commits.
+	notificationListener *pgnotify.Listener
+
+	// advisoryLocks tracks the advisory locks held by the session. The
+	// session-level locks are released when the session is closed.
+	advisoryLocks advisoryLocks
+
 	// queryCancelKey is a 64-bit identifier for the session used by the
 	// pgwire cancellation protocol.
 	queryCancelKey pgwirecancel.BackendKeyData
@@ -1970,6 +2004,9 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 	ex.extraTxnState.firstStmtExecuted = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.createdSequences = nil
+	ex.extraTxnState.notifications = txnNotifications{}
+	ex.extraTxnState.deferredConstraints.reset()
+	ex.advisoryLocks.resetXact()
 
 	if ex.extraTxnState.fromOuterTxn {
 		if ex.extraTxnState.shouldResetSyntheticDescriptors {
@@ -1991,8 +2028,13 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 		ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 	)
 
-	// Close all cursors.
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	// Close all cursors, except for cursors WITH HOLD that outlived their
+	// transaction. The cursors WITH HOLD that were materialized by this
+	// transaction are only kept if it committed.
+	if err := ex.extraTxnState.sqlCursors.finishHeld(ev.eventType == txnCommit); err != nil {
+		log.Warningf(ctx, "error closing cursors: %v", err)
+	}
+	if err := ex.extraTxnState.sqlCursors.closeAll(false /* closeHeld */); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
@@ -2422,6 +2464,14 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd, replRes)
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2521,6 +2571,11 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		if _, ok := cmd.(Sync); ok {
+			if err := ex.bufferNotifications(res); err != nil {
+				return err
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2676,6 +2731,8 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
@@ -3635,7 +3692,12 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.noticeSender = nil
 	p.preparedStatements = ex.getPrepStmtsAccessor()
 	p.sqlCursors = ex.getCursorAccessor()
+	p.sessionMon = ex.sessionMon
 	p.createdSequences = ex.getCreatedSequencesAccessor()
+	p.notifications = ex.getNotificationsAccessor()
+	p.deferredConstraints = &ex.extraTxnState.deferredConstraints
+	p.storedProcTxnState = &ex.storedProcTxnState
+	p.advisoryLocks = &ex.advisoryLocks
 
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
@@ -3817,6 +3879,7 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 			}
 		}
 		ex.notifyStatsRefresherOfNewTables(ex.Ctx())
+		ex.commitNotifications(ex.Ctx())
 
 		// If there is any descriptor has new version. We want to make sure there is
 		// only one version of the descriptor in all nodes. In schema changer jobs,
@@ -4141,6 +4204,12 @@ func (ex *connExecutor) getCreatedSequencesAccessor() createdSequences {
 	}
 }
 
+func (ex *connExecutor) getNotificationsAccessor() sessionNotifications {
+	return connExNotificationsAccessor{
+		ex: ex,
+	}
+}
+
 // sessionEventf logs a message to the session event log (if any).
 func (ex *connExecutor) sessionEventf(ctx context.Context, format string, args ...interface{}) {
 	if log.ExpensiveLogEnabled(ctx, 2) {
@@ -745,8 +745,22 @@ func (ex *connExecutor) execStmtInOpenState(
 		// Do the auto-commit, if necessary. In the extended protocol, the
 		// auto-commit happens when the Sync message is handled.
 		if retEv != nil || retErr != nil {
+			if ev, ok := retEv.(eventRetriableErr); ok && ev.CanAutoRetry.Get() {
+				// The CALL statement will be retried; resume the procedure from
+				// the same point.
+				ex.storedProcTxnState.txnOp = tree.StoredProcTxnNoOp
+				ex.storedProcTxnState.pendingProc = nil
+			} else {
+				ex.storedProcTxnState.clear()
+			}
+			return
+		}
+		if ex.storedProcTxnState.txnOp != tree.StoredProcTxnNoOp {
+			// A stored procedure executed COMMIT or ROLLBACK.
+			retEv, retPayload = ex.handleStoredProcTxnControl(ctx, ast)
 			return
 		}
+		ex.storedProcTxnState.clear()
 		// As portals are from extended protocol, we don't auto commit for them.
 		if canAutoCommit && !isExtendedProtocol {
 			retEv, retPayload = ex.handleAutoCommit(ctx, ast)
@@ -1008,6 +1022,12 @@ func (ex *connExecutor) execStmtInOpenState(
 	p.extendedEvalCtx.TxnIsSingleStmt = canAutoCommit && !ex.extraTxnState.firstStmtExecuted
 	ex.extraTxnState.firstStmtExecuted = true
 
+	// A stored procedure can only execute COMMIT or ROLLBACK if the CALL
+	// statement runs in its own implicit transaction.
+	_, isCall := ast.(*tree.Call)
+	ex.storedProcTxnState.allowTxnControl = isCall && ex.implicitTxn() &&
+		p.extendedEvalCtx.TxnIsSingleStmt && !isExtendedProtocol
+
 	var stmtThresholdSpan *tracing.Span
 	alreadyRecording := ex.transitionCtx.sessionTracing.Enabled()
 	// TODO(sql-sessions): fix the stmtTraceThreshold for pausable portals, so
@@ -1398,7 +1418,12 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	ctx, sp := tracing.EnsureChildSpan(ctx, ex.server.cfg.AmbientCtx.Tracer, "commit sql txn")
 	defer sp.Finish()
 
-	if err := ex.extraTxnState.sqlCursors.closeAll(true /* errorOnWithHold */); err != nil {
+	// Cursors WITH HOLD are materialized so that they remain usable after the
+	// transaction commits.
+	if err := ex.extraTxnState.sqlCursors.materializeHeld(ctx); err != nil {
+		return err
+	}
+	if err := ex.extraTxnState.sqlCursors.closeAll(false /* closeHeld */); err != nil {
 		return err
 	}
 
@@ -1416,6 +1441,12 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	if err := validateDeferredConstraintChecks(
+		ctx, ex.planner.InternalSQLTxn(), ex.extraTxnState.deferredConstraints.takeAllChecks(),
+	); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1493,7 +1524,7 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 func (ex *connExecutor) rollbackSQLTransaction(
 	ctx context.Context, stmt tree.Statement,
 ) (fsm.Event, fsm.EventPayload) {
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(false /* closeHeld */); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
 
@@ -2907,8 +2938,13 @@ func (ex *connExecutor) addActiveQuery(
 	cancelQuery context.CancelFunc,
 ) {
 	_, hidden := stmt.AST.(tree.HiddenFromShowQueries)
+	// Commands like START_REPLICATION run outside of a transaction.
+	var txnID uuid.UUID
+	if ex.state.mu.txn != nil {
+		txnID = ex.state.mu.txn.ID()
+	}
 	qm := &queryMeta{
-		txnID:         ex.state.mu.txn.ID(),
+		txnID:         txnID,
 		start:         ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived),
 		stmt:          stmt,
 		placeholders:  placeholders,
@@ -2974,6 +3010,51 @@ func (ex *connExecutor) handleAutoCommit(
 	return ev, payload
 }
 
+// handleStoredProcTxnControl commits or rolls back the implicit transaction of
+// a CALL statement after the called stored procedure executed COMMIT or
+// ROLLBACK. If the transaction ends successfully, the CALL statement is
+// executed again in a new implicit transaction in order to resume the
+// procedure.
+func (ex *connExecutor) handleStoredProcTxnControl(
+	ctx context.Context, stmt tree.Statement,
+) (fsm.Event, fsm.EventPayload) {
+	state := &ex.storedProcTxnState
+	var ev fsm.Event
+	var payload fsm.EventPayload
+	switch state.txnOp {
+	case tree.StoredProcTxnCommit:
+		ev, payload = ex.handleAutoCommit(ctx, stmt)
+	case tree.StoredProcTxnRollback:
+		ev, payload = ex.rollbackSQLTransaction(ctx, stmt)
+	default:
+		return ex.makeErrEvent(errors.AssertionFailedf(
+			"unexpected transaction control operation: %s", state.txnOp), stmt)
+	}
+	switch ev.(type) {
+	case eventTxnFinishCommitted:
+		ev = eventTxnFinishCommittedPLpgSQL{}
+	case eventTxnFinishAborted:
+		ev = eventTxnFinishAbortedPLpgSQL{}
+	default:
+		// The transaction could not be finished. If the error is retried, the
+		// procedure is resumed from the same point as before.
+		if retryEv, ok := ev.(eventRetriableErr); ok && retryEv.CanAutoRetry.Get() {
+			state.txnOp = tree.StoredProcTxnNoOp
+			state.pendingProc = nil
+		} else {
+			state.clear()
+		}
+		return ev, payload
+	}
+	state.resumeProc, state.pendingProc = state.pendingProc, nil
+	state.txnOp = tree.StoredProcTxnNoOp
+	// The CALL statement is executed again at the same position, and becomes the
+	// rewind position of the new transaction. Reset the rewind position so that
+	// it is not required to move forward.
+	ex.extraTxnState.txnRewindPos = -1
+	return ev, payload
+}
+
 // incrementStartedStmtCounter increments the appropriate started
 // statement counter for stmt's type.
 func (ex *connExecutor) incrementStartedStmtCounter(ast tree.Statement) {
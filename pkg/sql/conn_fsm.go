@@ -152,6 +152,13 @@ type eventTxnUpgradeToExplicit struct{}
 type eventTxnFinishCommitted struct{}
 type eventTxnFinishAborted struct{}
 
+// eventTxnFinishCommittedPLpgSQL and eventTxnFinishAbortedPLpgSQL are
+// generated when a stored procedure invoked by a CALL statement executes a
+// COMMIT or ROLLBACK statement. The implicit txn is finished, and the CALL
+// statement is executed again in a new implicit txn to resume the procedure.
+type eventTxnFinishCommittedPLpgSQL struct{}
+type eventTxnFinishAbortedPLpgSQL struct{}
+
 // eventSavepointRollback is generated when we want to move from Aborted to Open
 // through a ROLLBACK TO SAVEPOINT <not cockroach_restart>. Note that it is not
 // generated when such a savepoint is rolled back to from the Open state. In
@@ -220,6 +227,8 @@ type payloadWithError interface {
 func (eventTxnStart) Event()                            {}
 func (eventTxnFinishCommitted) Event()                  {}
 func (eventTxnFinishAborted) Event()                    {}
+func (eventTxnFinishCommittedPLpgSQL) Event()           {}
+func (eventTxnFinishAbortedPLpgSQL) Event()             {}
 func (eventSavepointRollback) Event()                   {}
 func (eventNonRetriableErr) Event()                     {}
 func (eventRetriableErr) Event()                        {}
@@ -315,6 +324,23 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 			Next:   stateNoTxn{},
 			Action: cleanupAndFinishOnError,
 		},
+		// Handle a COMMIT or ROLLBACK executed by a stored procedure.
+		eventTxnFinishCommittedPLpgSQL{}: {
+			Description: "PL/pgSQL COMMIT in a stored procedure called by an implicit txn",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				// Note that the KV txn has been committed by this point.
+				return args.Extended.(*txnState).finishTxnPLpgSQL(txnCommit)
+			},
+		},
+		eventTxnFinishAbortedPLpgSQL{}: {
+			Description: "PL/pgSQL ROLLBACK in a stored procedure called by an implicit txn",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				// Note that the KV txn has been rolled back by this point.
+				return args.Extended.(*txnState).finishTxnPLpgSQL(txnRollback)
+			},
+		},
 		// Handle a txn getting upgraded to an explicit txn.
 		eventTxnUpgradeToExplicit{}: {
 			Next: stateOpen{ImplicitTxn: fsm.False, WasUpgraded: fsm.True},
@@ -533,6 +559,18 @@ func (ts *txnState) finishTxn(ev txnEventType) error {
 	return nil
 }
 
+// finishTxnPLpgSQL finishes the transaction of a CALL statement when the
+// called stored procedure executes COMMIT or ROLLBACK. Unlike finishTxn, it
+// does not advance the cursor, so that the CALL statement is executed again in
+// a new transaction in order to resume the procedure.
+func (ts *txnState) finishTxnPLpgSQL(ev txnEventType) error {
+	finishedTxnID, commitTimestamp := ts.finishSQLTxn()
+	ts.setAdvanceInfo(stayInPlace, noRewind, txnEvent{
+		eventType: ev, txnID: finishedTxnID, commitTimestamp: commitTimestamp,
+	})
+	return nil
+}
+
 // cleanupAndFinishOnError rolls back the KV txn and finishes the SQL txn.
 func cleanupAndFinishOnError(args fsm.Args) error {
 	ts := args.Extended.(*txnState)
@@ -20,6 +20,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -424,6 +426,53 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// ReplicationClientMessage is a message sent by the client while changes are
+// streamed to it by a StartReplication command.
+type ReplicationClientMessage struct {
+	// Typ is one of ClientMsgCopyData, ClientMsgCopyDone or ClientMsgCopyFail.
+	Typ pgwirebase.ClientMessageType
+	// Data is the payload of the message. It is owned by the receiver.
+	Data []byte
+}
+
+// StartReplication is the command for streaming changes from a logical
+// replication slot over the replication protocol (START_REPLICATION ...
+// LOGICAL).
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// ClientMessages receives the CopyData, CopyDone and CopyFail messages
+	// that the network routine reads from the client while the changes are
+	// streamed.
+	ClientMessages <-chan ReplicationClientMessage
+	// Done is closed once execution finishes, signaling the network routine
+	// to stop forwarding messages to ClientMessages.
+	Done chan<- struct{}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (s StartReplication) String() string {
+	str := "(empty)"
+	if s.Stmt != nil {
+		str = s.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", str)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -736,6 +785,9 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
 
@@ -930,6 +982,10 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+
+	// BufferNotification buffers an asynchronous notification received by the
+	// session, to be sent to the client before the readyForQuery message.
+	BufferNotification(notification pgnotify.Notification)
 }
 
 // FlushResult represents the result of a Flush command. When this result is
@@ -976,6 +1032,24 @@ type CopyOutResult interface {
 	SendCopyDone(ctx context.Context) error
 }
 
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+
+	// SendCopyBoth sends the copy both response that starts the streaming of
+	// changes to the client.
+	SendCopyBoth(ctx context.Context) error
+
+	// SendCopyData sends a CopyData message with a replication protocol
+	// message to the client. The message is flushed to the network right
+	// away.
+	SendCopyData(ctx context.Context, copyData []byte, isHeader bool) error
+
+	// SendCopyDone sends the copy done response to the client.
+	SendCopyDone(ctx context.Context) error
+}
+
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
 // represents a lock on the delivery of results to a SQL client. While such a
 // lock is used, no more results are delivered. The lock itself can be used to
@@ -1089,6 +1163,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the SyncResult interface.
+func (r *streamingCommandResult) BufferNotification(pgnotify.Notification) {
+	// Unimplemented: the internal executor does not support notifications.
+}
+
 // SendNotice is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	// Unimplemented: the internal executor does not support notices.
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
@@ -386,7 +387,17 @@ func (c *copyMachine) canSupportVectorized(table catalog.TableDescriptor) bool {
 	// row container. I think that requires a vectorized version of lookup
 	// join. TODO(cucaroach): extend the vectorized insert code to support
 	// insertFastPath style FK checks.
-	return len(table.EnforcedOutboundForeignKeys()) == 0
+	if len(table.EnforcedOutboundForeignKeys()) > 0 {
+		return false
+	}
+	// The vectorized encoder can't read the summaries of BRIN indexes, which
+	// must be maintained as rows are written.
+	for _, idx := range table.WritableNonPrimaryIndexes() {
+		if idx.GetType() == descpb.IndexDescriptor_BRIN {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *copyMachine) initVectorizedCopy(ctx context.Context, typs []*types.T) error {
@@ -3444,6 +3444,25 @@ func writeCreateTypeDescRow(
 			tree.DNull,                           // enum_members
 		)
 	}
+	if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{d.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node, err := makeCreateDomainStmt(d, name)
+		if err != nil {
+			return false, err
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(d.GetID())),   // descriptor_id
+			tree.NewDString(d.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
 	return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 }
 
@@ -3619,6 +3638,14 @@ CREATE TABLE crdb_internal.create_function_statements (
 				}
 			}
 
+			var createStmt tree.NodeFormatter = treeNode
+			if fnDesc.GetAggregate() != nil {
+				createStmt, err = p.makeCreateAggregateStmt(ctx, fnDesc, treeNode)
+				if err != nil {
+					return err
+				}
+			}
+
 			err = addRow(
 				tree.NewDInt(tree.DInt(fnIDToDBID[fnDesc.GetID()])), // database_id
 				tree.NewDString(fnIDToDBName[fnDesc.GetID()]),       // database_name
@@ -3626,7 +3653,7 @@ CREATE TABLE crdb_internal.create_function_statements (
 				tree.NewDString(fnIDToScName[fnDesc.GetID()]),       // schema_name
 				tree.NewDInt(tree.DInt(fnDesc.GetID())),             // function_id
 				tree.NewDString(fnDesc.GetName()),                   // function_name
-				tree.NewDString(tree.AsString(treeNode)),            // create_statement
+				tree.NewDString(tree.AsString(createStmt)),          // create_statement
 			)
 			if err != nil {
 				return err
@@ -3636,6 +3663,62 @@ CREATE TABLE crdb_internal.create_function_statements (
 	},
 }
 
+// makeCreateAggregateStmt returns the CREATE AGGREGATE statement for the given
+// user-defined aggregate. The name and parameters are taken from fnExpr, which
+// is the CREATE FUNCTION statement for the aggregate's descriptor.
+func (p *planner) makeCreateAggregateStmt(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor, fnExpr *tree.CreateRoutine,
+) (*tree.CreateAggregate, error) {
+	agg := fnDesc.GetAggregate()
+	supportFuncName := func(id descpb.ID) (tree.RoutineName, error) {
+		supportFn, err := p.Descriptors().ByID(p.txn).Get().Function(ctx, id)
+		if err != nil {
+			return tree.RoutineName{}, err
+		}
+		var prefix tree.ObjectNamePrefix
+		if supportFn.GetParentSchemaID() != fnDesc.GetParentSchemaID() {
+			sc, err := p.Descriptors().ByID(p.txn).Get().Schema(ctx, supportFn.GetParentSchemaID())
+			if err != nil {
+				return tree.RoutineName{}, err
+			}
+			prefix.ExplicitSchema = true
+			prefix.SchemaName = tree.Name(sc.GetName())
+		}
+		return tree.MakeRoutineNameFromPrefix(prefix, tree.Name(supportFn.GetName())), nil
+	}
+
+	stateFuncName, err := supportFuncName(agg.StateFuncID)
+	if err != nil {
+		return nil, err
+	}
+	ret := &tree.CreateAggregate{
+		Name:   fnExpr.Name,
+		Params: fnExpr.Params,
+		Options: tree.AggregateOptions{
+			tree.AggregateStateFunc{Name: stateFuncName},
+			tree.AggregateStateType{Type: agg.StateType},
+		},
+	}
+	if agg.FinalFuncID != descpb.InvalidID {
+		name, err := supportFuncName(agg.FinalFuncID)
+		if err != nil {
+			return nil, err
+		}
+		ret.Options = append(ret.Options, tree.AggregateFinalFunc{Name: name})
+	}
+	if agg.CombineFuncID != descpb.InvalidID {
+		name, err := supportFuncName(agg.CombineFuncID)
+		if err != nil {
+			return nil, err
+		}
+		ret.Options = append(ret.Options, tree.AggregateCombineFunc{Name: name})
+	}
+	if agg.InitialCondition != nil {
+		ret.Options = append(ret.Options, tree.AggregateInitCond(*agg.InitialCondition))
+	}
+	return ret, nil
+}
+
 // Prepare the row populate function.
 var typeView = tree.NewDString("view")
 var typeTable = tree.NewDString("table")
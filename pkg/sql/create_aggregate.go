@@ -0,0 +1,547 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n *tree.CreateAggregate
+
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createAggregateNode{n: nil}
+
+// CreateAggregate creates a user-defined aggregate.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	un := n.Name.ToUnresolvedObjectName()
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, pgerror.New(pgcode.InsufficientPrivilege,
+			"cannot create an aggregate in the system database")
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+// aggregateDefinition is the resolved form of the options of a CREATE
+// AGGREGATE statement.
+type aggregateDefinition struct {
+	params     []descpb.FunctionDescriptor_Parameter
+	paramTypes []*types.T
+	stateType  *types.T
+	returnType *types.T
+	initCond   *string
+
+	stateFunc   *funcdesc.Mutable
+	finalFunc   *funcdesc.Mutable
+	combineFunc *funcdesc.Mutable
+}
+
+// supportFuncs returns the support functions of the aggregate that are set.
+func (d *aggregateDefinition) supportFuncs() []*funcdesc.Mutable {
+	ret := []*funcdesc.Mutable{d.stateFunc}
+	if d.finalFunc != nil {
+		ret = append(ret, d.finalFunc)
+	}
+	if d.combineFunc != nil {
+		ret = append(ret, d.combineFunc)
+	}
+	return ret
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	if n.scDesc.SchemaKind() == catalog.SchemaTemporary {
+		return unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(
+		params.ctx, n.dbDesc, n.scDesc.GetName(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		retErr = func() error {
+			def, err := n.resolveDefinition(params)
+			if err != nil {
+				return err
+			}
+			existing, err := n.getExistingAggregate(params)
+			if err != nil {
+				return err
+			}
+			fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.String())
+			event := eventpb.CreateFunction{
+				FunctionName: fnName.FQString(),
+				IsReplace:    existing != nil,
+			}
+			var fnDesc *funcdesc.Mutable
+			if existing == nil {
+				fnDesc, err = n.createNewAggregate(params, mutScDesc, def)
+			} else {
+				fnDesc = existing
+				err = n.replaceAggregate(params, existing, def)
+			}
+			if err != nil {
+				return err
+			}
+			return params.p.logEvent(params.ctx, fnDesc.GetID(), &event)
+		}()
+	})
+	return retErr
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// resolveDefinition resolves the argument types, state type and support
+// functions of the aggregate, and checks that they fit together.
+func (n *createAggregateNode) resolveDefinition(params runParams) (*aggregateDefinition, error) {
+	var stateFuncName, finalFuncName, combineFuncName *tree.RoutineName
+	var stateTypeRef tree.ResolvableTypeReference
+	var initCond *string
+	for _, option := range n.n.Options {
+		switch t := option.(type) {
+		case tree.AggregateStateFunc:
+			if stateFuncName != nil {
+				return nil, errConflictingAggregateOption(option)
+			}
+			stateFuncName = &t.Name
+		case tree.AggregateStateType:
+			if stateTypeRef != nil {
+				return nil, errConflictingAggregateOption(option)
+			}
+			stateTypeRef = t.Type
+		case tree.AggregateFinalFunc:
+			if finalFuncName != nil {
+				return nil, errConflictingAggregateOption(option)
+			}
+			finalFuncName = &t.Name
+		case tree.AggregateCombineFunc:
+			if combineFuncName != nil {
+				return nil, errConflictingAggregateOption(option)
+			}
+			combineFuncName = &t.Name
+		case tree.AggregateInitCond:
+			if initCond != nil {
+				return nil, errConflictingAggregateOption(option)
+			}
+			s := string(t)
+			initCond = &s
+		default:
+			return nil, errors.AssertionFailedf("unexpected aggregate option %T", t)
+		}
+	}
+	if stateFuncName == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if stateTypeRef == nil {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if len(n.n.Params) == 0 {
+		return nil, unimplemented.NewWithIssue(74775, "zero-argument aggregates")
+	}
+
+	def := &aggregateDefinition{initCond: initCond}
+	def.params = make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	def.paramTypes = make([]*types.T, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.Class != tree.RoutineParamIn {
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregates can only have input parameters")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return nil, err
+		}
+		def.params[i] = pbParam
+		def.paramTypes[i] = pbParam.Type
+	}
+
+	var err error
+	def.stateType, err = tree.ResolveType(params.ctx, stateTypeRef, params.p)
+	if err != nil {
+		return nil, err
+	}
+	if def.stateType.Family() == types.AnyFamily || types.IsWildcardTupleType(def.stateType) {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"aggregate stype cannot be %s", def.stateType.SQLString())
+	}
+
+	// The state function is called with the current state followed by the
+	// aggregate's arguments, and returns the new state.
+	def.stateFunc, err = n.resolveSupportFunc(
+		params, "sfunc", *stateFuncName, append([]*types.T{def.stateType}, def.paramTypes...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !isSameRoutineType(def.stateFunc.ReturnType.Type, def.stateType) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s",
+			def.stateFunc.GetName(), def.stateType.SQLString())
+	}
+	// A strict state function is not called while the state is NULL; the first
+	// input replaces the state instead, so it must be of the state type.
+	if initCond == nil && def.stateFunc.NullInputBehavior != catpb.Function_CALLED_ON_NULL_INPUT &&
+		(len(def.paramTypes) != 1 || !def.paramTypes[0].Equivalent(def.stateType)) {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and transition type is not compatible with input type")
+	}
+
+	// The final function computes the result of the aggregate from the final
+	// state. Without one, the aggregate returns its state.
+	def.returnType = def.stateType
+	if finalFuncName != nil {
+		def.finalFunc, err = n.resolveSupportFunc(params, "finalfunc", *finalFuncName, []*types.T{def.stateType})
+		if err != nil {
+			return nil, err
+		}
+		def.returnType = def.finalFunc.ReturnType.Type
+	}
+
+	// The combine function merges two partial states into one.
+	if combineFuncName != nil {
+		def.combineFunc, err = n.resolveSupportFunc(
+			params, "combinefunc", *combineFuncName, []*types.T{def.stateType, def.stateType},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !isSameRoutineType(def.combineFunc.ReturnType.Type, def.stateType) {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"return type of combine function %s is not %s",
+				def.combineFunc.GetName(), def.stateType.SQLString())
+		}
+	}
+
+	// Make sure the initial condition is a valid value of the state type.
+	if initCond != nil {
+		if _, _, err := tree.ParseAndRequireString(
+			def.stateType, *initCond, params.EvalContext(),
+		); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+// resolveSupportFunc resolves a support function of an aggregate by name and
+// exact argument types. Support functions must be user-defined functions that
+// the current user is allowed to execute.
+func (n *createAggregateNode) resolveSupportFunc(
+	params runParams, option string, name tree.RoutineName, argTypes []*types.T,
+) (*funcdesc.Mutable, error) {
+	fnObj := tree.FuncObj{FuncName: name}
+	fnObj.Params = make(tree.RoutineParams, len(argTypes))
+	for i, typ := range argTypes {
+		fnObj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	path := params.p.CurrentSearchPath()
+	fnDef, err := params.p.ResolveFunction(
+		params.ctx, name.ToUnresolvedObjectName().ToUnresolvedName(), &path,
+	)
+	var ol tree.QualifiedOverload
+	if err == nil {
+		ol, err = fnDef.MatchOverload(argTypes, name.Schema(), &path)
+	}
+	if err != nil {
+		if errors.Is(err, tree.ErrFunctionUndefined) {
+			return nil, pgerror.Newf(pgcode.UndefinedFunction,
+				"function %s does not exist", tree.AsString(&fnObj))
+		}
+		return nil, err
+	}
+	if !ol.IsUDF {
+		return nil, unimplemented.NewWithIssuef(74775,
+			"aggregate %s %s is not a user-defined function", option, tree.AsString(&name))
+	}
+	if ol.IsProcedure || ol.Class != tree.NormalClass {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"aggregate %s %s must be a normal function", option, tree.AsString(&name))
+	}
+	fnDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.GetParentID() != n.dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"the aggregate cannot refer to functions in other databases")
+	}
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+// getExistingAggregate returns the aggregate with the same name and argument
+// types as the one being created, or nil if there is none. It returns an error
+// if such a routine exists but may not be replaced.
+func (n *createAggregateNode) getExistingAggregate(params runParams) (*funcdesc.Mutable, error) {
+	fnObj := tree.FuncObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	existing, err := params.p.matchUDF(params.ctx, &fnObj, false /* required */)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	if !n.n.Replace {
+		return nil, pgerror.Newf(
+			pgcode.DuplicateFunction,
+			"function %q already exists with same argument types",
+			n.n.Name.Object(),
+		)
+	}
+	if existing.Class != tree.AggregateClass {
+		kind := "a function"
+		if existing.IsProcedure {
+			kind = "a procedure"
+		}
+		return nil, errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is %s.", n.n.Name.Object(), kind,
+		)
+	}
+	return params.p.checkPrivilegesForDropFunction(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+	)
+}
+
+func (n *createAggregateNode) createNewAggregate(
+	params runParams, scDesc *schemadesc.Mutable, def *aggregateDefinition,
+) (*funcdesc.Mutable, error) {
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Functions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	newDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.Name.ObjectName),
+		def.params,
+		def.returnType,
+		false, /* returnSet */
+		false, /* isProcedure */
+		privileges,
+	)
+	fnDesc := &newDesc
+	if err := n.setAggregateDefinition(params, fnDesc, def); err != nil {
+		return nil, err
+	}
+	if err := params.p.createDescriptor(
+		params.ctx, fnDesc, tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return nil, err
+	}
+	scDesc.AddFunction(
+		fnDesc.GetName(),
+		descpb.SchemaDescriptor_FunctionSignature{
+			ID:          fnDesc.GetID(),
+			ArgTypes:    def.paramTypes,
+			ReturnType:  def.returnType,
+			IsAggregate: true,
+		},
+	)
+	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate"); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+func (n *createAggregateNode) replaceAggregate(
+	params runParams, fnDesc *funcdesc.Mutable, def *aggregateDefinition,
+) error {
+	// Make sure parameter names are not changed.
+	for i := range n.n.Params {
+		if string(n.n.Params[i].Name) != fnDesc.Params[i].Name {
+			return pgerror.Newf(
+				pgcode.InvalidFunctionDefinition, "cannot change name of input parameter %q", fnDesc.Params[i].Name,
+			)
+		}
+	}
+	if !isSameRoutineType(def.returnType, fnDesc.ReturnType.Type) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+	}
+	fnDesc.ReturnType.Type = def.returnType
+
+	// Remove all existing references before adding the new ones.
+	if err := params.p.removeAggregateSupportFuncReferences(params.ctx, fnDesc); err != nil {
+		return err
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for function %d", fnDesc.DependsOnTypes, fnDesc.ID)
+	if err := params.p.removeTypeBackReferences(params.ctx, fnDesc.DependsOnTypes, fnDesc.ID, jobDesc); err != nil {
+		return err
+	}
+	if err := n.setAggregateDefinition(params, fnDesc, def); err != nil {
+		return err
+	}
+	return params.p.writeFuncSchemaChange(params.ctx, fnDesc)
+}
+
+// setAggregateDefinition stores the aggregate definition in the function
+// descriptor, and adds references to the support functions and user-defined
+// types that the aggregate depends on.
+func (n *createAggregateNode) setAggregateDefinition(
+	params runParams, fnDesc *funcdesc.Mutable, def *aggregateDefinition,
+) error {
+	fnDesc.Aggregate = &descpb.FunctionDescriptor_Aggregate{
+		StateFuncID:      def.stateFunc.GetID(),
+		StateType:        def.stateType,
+		InitialCondition: def.initCond,
+	}
+	if def.finalFunc != nil {
+		fnDesc.Aggregate.FinalFuncID = def.finalFunc.GetID()
+	}
+	if def.combineFunc != nil {
+		fnDesc.Aggregate.CombineFuncID = def.combineFunc.GetID()
+	}
+
+	// The aggregate is as volatile as the most volatile of its support
+	// functions. The support functions are always called, even for NULL input,
+	// so that they can decide how to handle it.
+	vol := catpb.Function_IMMUTABLE
+	leakProof := true
+	for _, fn := range def.supportFuncs() {
+		if volatilityRank(fn.GetVolatility()) > volatilityRank(vol) {
+			vol = fn.GetVolatility()
+		}
+		leakProof = leakProof && fn.GetLeakProof()
+	}
+	fnDesc.SetVolatility(vol)
+	fnDesc.SetLeakProof(leakProof && vol == catpb.Function_IMMUTABLE)
+	fnDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
+
+	for _, fn := range def.supportFuncs() {
+		fn.AddFunctionReference(fnDesc.GetID())
+		if err := params.p.writeFuncSchemaChange(params.ctx, fn); err != nil {
+			return err
+		}
+	}
+
+	typeDeps := make(typeDependencies)
+	for _, typ := range append([]*types.T{def.stateType, def.returnType}, def.paramTypes...) {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
+			typeDeps[id] = struct{}{}
+		})
+	}
+	return addRoutineReferences(params, fnDesc, n.n.Name.String(), nil /* planDeps */, typeDeps)
+}
+
+// removeAggregateSupportFuncReferences removes the back references from the
+// support functions of the given user-defined aggregate.
+func (p *planner) removeAggregateSupportFuncReferences(
+	ctx context.Context, fnDesc *funcdesc.Mutable,
+) error {
+	agg := fnDesc.GetAggregate()
+	if agg == nil {
+		return nil
+	}
+	for _, id := range []descpb.ID{agg.StateFuncID, agg.FinalFuncID, agg.CombineFuncID} {
+		if id == descpb.InvalidID {
+			continue
+		}
+		supportFn, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		supportFn.RemoveReference(fnDesc.GetID())
+		if err := p.writeFuncSchemaChange(ctx, supportFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func errConflictingAggregateOption(opt tree.AggregateOption) error {
+	return errors.Wrapf(tree.ErrConflictingRoutineOption, "%s", tree.AsString(opt))
+}
+
+// isSameRoutineType returns true if the given types are equal, or if they
+// refer to the same user-defined type.
+func isSameRoutineType(a, b *types.T) bool {
+	if a.Equal(b) {
+		return true
+	}
+	return types.IsOIDUserDefinedType(a.Oid()) && a.Oid() == b.Oid()
+}
+
+// volatilityRank orders function volatilities from least to most volatile.
+func volatilityRank(v catpb.Function_Volatility) int {
+	switch v {
+	case catpb.Function_IMMUTABLE:
+		return 0
+	case catpb.Function_STABLE:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createDomainNode{n: nil}
+
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the desired new type name.
+	typeName, db, err := resolveNewTypeName(p.RunParams(ctx), n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+
+	schema, err := getCreateTypeParams(params, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	// Generate a stable ID for the new type.
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := CreateDomainTypeDesc(params, id, n.n, n.dbDesc, schema, n.typeName)
+	if err != nil {
+		return err
+	}
+	return params.p.finishCreateType(params, id, n.typeName, typeDesc, n.dbDesc, schema)
+}
+
+// CreateDomainTypeDesc creates a new domain type descriptor.
+func CreateDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateDomain,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := tree.ResolveType(params.ctx, n.Type, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDomainBaseType(baseType); err != nil {
+		return nil, err
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{BaseType: baseType}
+	if n.Default != nil {
+		if domain.DefaultExpr, err = params.p.validateDomainDefaultExpr(
+			params.ctx, n.Default, baseType,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	var seenNull bool
+	for i := range n.Constraints {
+		c := &n.Constraints[i]
+		switch {
+		case c.Check != nil:
+			if err := params.p.addDomainCheck(params.ctx, domain, typeName.Type(), c); err != nil {
+				return nil, err
+			}
+		case c.NotNull:
+			if seenNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			domain.NotNull = true
+		default:
+			if domain.NotNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			seenNull = true
+		}
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// checkDomainBaseType returns an error if a domain cannot be defined over the
+// given type.
+func checkDomainBaseType(baseType *types.T) error {
+	if baseType.UserDefined() {
+		return unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+	switch baseType.Family() {
+	case types.ArrayFamily, types.TupleFamily:
+		return unimplemented.NewWithIssuef(27796,
+			"domains over %s types are not yet supported", baseType.Family().Name())
+	case types.AnyFamily, types.UnknownFamily, types.VoidFamily, types.TriggerFamily:
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid base type for a domain", baseType.SQLString())
+	}
+	return nil
+}
+
+// validateDomainDefaultExpr type checks the DEFAULT expression of a domain over
+// the given base type, and returns its serialized form. A NULL default is
+// returned as nil.
+func (p *planner) validateDomainDefaultExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T,
+) (*string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, &p.semaCtx, volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(
+		typedExpr, tree.DomainDefaultExpr, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	); err != nil {
+		return nil, err
+	}
+	seqIdents, err := seqexpr.GetUsedSequences(typedExpr)
+	if err != nil {
+		return nil, err
+	}
+	if len(seqIdents) > 0 {
+		return nil, unimplemented.NewWithIssue(27796,
+			"sequences in the DEFAULT expression of a domain are not yet supported")
+	}
+	if typedExpr == tree.DNull {
+		return nil, nil
+	}
+	s := tree.Serialize(typedExpr)
+	return &s, nil
+}
+
+// addDomainCheck validates the given CHECK constraint of the named domain and
+// adds it to the domain. The constraint is named <domain>_check if it has no
+// name.
+func (p *planner) addDomainCheck(
+	ctx context.Context,
+	domain *descpb.TypeDescriptor_Domain,
+	domainName string,
+	c *tree.DomainConstraint,
+) error {
+	// Type check the expression with VALUE replaced by a placeholder of the
+	// base type.
+	placeholder := tree.NewTypedCastExpr(tree.DNull, domain.BaseType)
+	expr, err := eval.ReplaceDomainValue(c.Check, placeholder)
+	if err != nil {
+		return err
+	}
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, expr, types.Bool, tree.DomainCheckConstraintExpr, &p.semaCtx, volatility.Volatile, false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(
+		typedExpr, tree.DomainCheckConstraintExpr, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	); err != nil {
+		return err
+	}
+
+	name := string(c.Name)
+	if name == "" {
+		name = domainName + "_check"
+		for i := 1; findDomainCheck(domain, name) != -1; i++ {
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+	} else if findDomainCheck(domain, name) != -1 {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName)
+	}
+	domain.Checks = append(domain.Checks, descpb.TypeDescriptor_Domain_DomainCheck{
+		Name: name,
+		Expr: tree.Serialize(c.Check),
+	})
+	return nil
+}
+
+// findDomainCheck returns the index of the CHECK constraint of the domain with
+// the given name, or -1 if there is no such constraint.
+func findDomainCheck(domain *descpb.TypeDescriptor_Domain, name string) int {
+	for i := range domain.Checks {
+		if domain.Checks[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// makeCreateDomainStmt returns a CREATE DOMAIN statement that creates the
+// given domain.
+func makeCreateDomainStmt(
+	d catalog.DomainTypeDescriptor, name *tree.UnresolvedObjectName,
+) (*tree.CreateDomain, error) {
+	node := &tree.CreateDomain{
+		TypeName: name,
+		Type:     d.GetBaseType(),
+	}
+	if s, ok := d.GetDefaultExpr(); ok {
+		expr, err := parser.ParseExpr(s)
+		if err != nil {
+			return nil, err
+		}
+		node.Default = expr
+	}
+	if d.IsNotNull() {
+		node.Constraints = append(node.Constraints, tree.DomainConstraint{NotNull: true})
+	}
+	for i := 0; i < d.NumChecks(); i++ {
+		expr, err := parser.ParseExpr(d.GetCheckExpr(i))
+		if err != nil {
+			return nil, err
+		}
+		node.Constraints = append(node.Constraints, tree.DomainConstraint{
+			Name:  tree.Name(d.GetCheckName(i)),
+			Check: expr,
+		})
+	}
+	return node, nil
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
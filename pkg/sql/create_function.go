@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -27,12 +28,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type createFunctionNode struct {
@@ -145,10 +148,11 @@ func (n *createFunctionNode) createNewFunction(
 	scDesc.AddFunction(
 		udfDesc.GetName(),
 		descpb.SchemaDescriptor_FunctionSignature{
-			ID:         udfDesc.GetID(),
-			ArgTypes:   paramTypes,
-			ReturnType: returnType,
-			ReturnSet:  udfDesc.ReturnType.ReturnSet,
+			ID:          udfDesc.GetID(),
+			ArgTypes:    paramTypes,
+			ReturnType:  returnType,
+			ReturnSet:   udfDesc.ReturnType.ReturnSet,
+			IsProcedure: udfDesc.IsProcedure,
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -266,6 +270,17 @@ func (n *createFunctionNode) getMutableFuncDesc(
 				n.cf.Name.Object(),
 			)
 		}
+		// Functions cannot be replaced by procedures, and vice-versa.
+		if existing.IsProcedure != n.cf.IsProcedure {
+			kind := "a function"
+			if existing.IsProcedure {
+				kind = "a procedure"
+			}
+			return nil, false, errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				"%q is %s.", n.cf.Name.Object(), kind,
+			)
+		}
 		fnID := funcdesc.UserDefinedFunctionOIDToID(existing.Oid)
 		fnDesc, err = params.p.checkPrivilegesForDropFunction(params.ctx, fnID)
 		if err != nil {
@@ -303,6 +318,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		pbParams,
 		returnType,
 		n.cf.ReturnType.IsSet,
+		n.cf.IsProcedure,
 		privileges,
 	)
 
@@ -310,14 +326,27 @@ func (n *createFunctionNode) getMutableFuncDesc(
 }
 
 func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params runParams) error {
+	return addRoutineReferences(params, udfDesc, n.cf.Name.String(), n.planDeps, n.typeDeps)
+}
+
+// addRoutineReferences adds references from the given routine to the
+// relations and types it depends on, along with the corresponding back
+// references.
+func addRoutineReferences(
+	params runParams,
+	udfDesc *funcdesc.Mutable,
+	name string,
+	planDeps planDependencies,
+	typeDeps typeDependencies,
+) error {
 	// Get all table IDs for which we need to update back references, including
 	// tables used directly in function body or as implicit types.
 	backrefTblIDs := catalog.DescriptorIDSet{}
 	implicitTypeTblIDs := catalog.DescriptorIDSet{}
-	for id := range n.planDeps {
+	for id := range planDeps {
 		backrefTblIDs.Add(id)
 	}
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		if isTable, err := params.p.descIsTable(params.ctx, id); err != nil {
 			return err
 		} else if isTable {
@@ -341,7 +370,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 		backRefMutables[id] = backRefMutable
 	}
 
-	for id, updated := range n.planDeps {
+	for id, updated := range planDeps {
 		backRefMutable := backRefMutables[id]
 		for _, dep := range updated.deps {
 			dep.ID = udfDesc.ID
@@ -354,7 +383,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 			backRefMutable,
 			descpb.InvalidMutationID,
 			fmt.Sprintf("updating udf reference %q in table %s(%d)",
-				n.cf.Name.String(), updated.desc.GetName(), updated.desc.GetID(),
+				name, updated.desc.GetName(), updated.desc.GetID(),
 			),
 		); err != nil {
 			return err
@@ -368,7 +397,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 			backRefMutable,
 			descpb.InvalidMutationID,
 			fmt.Sprintf("updating udf reference %q in table %s(%d)",
-				n.cf.Name.String(), backRefMutable.GetName(), backRefMutable.GetID(),
+				name, backRefMutable.GetName(), backRefMutable.GetID(),
 			),
 		); err != nil {
 			return err
@@ -377,7 +406,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 
 	// Add type back references. Skip table implicit types (we update table back
 	// references above).
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		if implicitTypeTblIDs.Contains(id) {
 			continue
 		}
@@ -391,7 +420,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	udfDesc.DependsOn = backrefTblIDs.Ordered()
 
 	typeDepIDs := catalog.DescriptorIDSet{}
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		typeDepIDs.Add(id)
 	}
 	udfDesc.DependsOnTypes = typeDepIDs.Difference(implicitTypeTblIDs).Ordered()
@@ -430,6 +459,46 @@ func setFuncOptions(
 			// Handle the body after the loop, since we don't yet know what language
 			// it is.
 			body = string(t)
+		case tree.RoutineSecurity:
+			v, err := funcinfo.SecurityToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetSecurity(v)
+		case tree.RoutineSetVar:
+			config, err := applyFuncConfig(params, udfDesc.Config, t.SetVar)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetConfig(config)
+		case tree.RoutineCost:
+			if t <= 0 {
+				return pgerror.New(pgcode.InvalidParameterValue, "COST must be positive")
+			}
+			udfDesc.SetCost(float64(t))
+		case tree.RoutineRows:
+			if t <= 0 {
+				return pgerror.New(pgcode.InvalidParameterValue, "ROWS must be positive")
+			}
+			if !udfDesc.ReturnType.ReturnSet {
+				return pgerror.New(pgcode.InvalidParameterValue,
+					"ROWS is not applicable when function does not return a set")
+			}
+			udfDesc.SetRows(float64(t))
+		case tree.RoutineSupport:
+			if err := params.p.RequireAdminRole(params.ctx, "specify a support function"); err != nil {
+				return err
+			}
+			if props, _ := builtinsregistry.GetBuiltinProperties(string(t)); props == nil {
+				return pgerror.Newf(pgcode.UndefinedFunction, "unknown function: %s", string(t))
+			}
+			udfDesc.SetSupport(string(t))
+		case tree.RoutineParallel:
+			v, err := funcinfo.ParallelToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetParallel(v)
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function option %q", t)
 		}
@@ -463,6 +532,43 @@ func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetSecurity(catpb.Function_INVOKER)
+	udfDesc.SetConfig(nil)
+	udfDesc.SetCost(0)
+	udfDesc.SetRows(0)
+	udfDesc.SetSupport("")
+	udfDesc.SetParallel(catpb.Function_PARALLEL_UNSAFE)
+}
+
+// applyFuncConfig applies a SET or RESET clause of a function definition to
+// the given session variable settings of the function, each of which is in
+// the form "name=value". The resulting settings are returned.
+func applyFuncConfig(params runParams, config []string, setVar *tree.SetVar) ([]string, error) {
+	setVarKind, varName, sVar, typedValues, err := params.p.processSetOrResetClause(
+		params.ctx, setVar, "FUNCTION ... SET ",
+	)
+	if err != nil {
+		return nil, err
+	}
+	if setVarKind == resetAllVars {
+		return nil, nil
+	}
+	// Remove any existing setting of the variable. The new setting, if any, is
+	// added to the end of the list.
+	newConfig := make([]string, 0, len(config)+1)
+	for _, setting := range config {
+		if name, _, _ := strings.Cut(setting, "="); name != varName {
+			newConfig = append(newConfig, setting)
+		}
+	}
+	if setVarKind == resetSingleVar {
+		return newConfig, nil
+	}
+	strVal, err := evalSessionVarVal(params, varName, sVar, typedValues)
+	if err != nil {
+		return nil, err
+	}
+	return append(newConfig, varName+"="+strVal), nil
 }
 
 func makeFunctionParam(
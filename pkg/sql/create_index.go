@@ -238,6 +238,13 @@ func makeIndexDescriptor(
 		}
 	}
 
+	if n.BRIN {
+		if err := checkBRINIndex(tableDesc, n, columns); err != nil {
+			return nil, err
+		}
+		indexDesc.Type = descpb.IndexDescriptor_BRIN
+	}
+
 	if n.Sharded != nil {
 		if n.PartitionByIndex.ContainsPartitions() {
 			return nil, pgerror.New(pgcode.FeatureNotSupported, "sharded indexes don't support explicit partitioning")
@@ -308,6 +315,9 @@ func makeIndexDescriptor(
 			telemetry.Inc(sqltelemetry.MultiColumnInvertedIndexCounter)
 		}
 	}
+	if indexDesc.Type == descpb.IndexDescriptor_BRIN {
+		telemetry.Inc(sqltelemetry.BRINIndexCounter)
+	}
 	if indexDesc.IsSharded() {
 		telemetry.Inc(sqltelemetry.HashShardedIndexCounter)
 	}
@@ -318,6 +328,60 @@ func makeIndexDescriptor(
 	return &indexDesc, nil
 }
 
+// checkBRINIndex returns an error if a BRIN index with the given definition
+// cannot be created on the table. A BRIN index summarizes a single column for
+// blocks of values of the leading primary key column, which must be an
+// integer column.
+func checkBRINIndex(
+	tableDesc *tabledesc.Mutable, n tree.CreateIndex, columns tree.IndexElemList,
+) error {
+	switch {
+	case n.Sharded != nil:
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes don't support hash sharding")
+	case len(n.Storing) > 0:
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes don't support stored columns")
+	case n.Unique:
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes can't be unique")
+	case n.Predicate != nil:
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes can't be partial")
+	case n.PartitionByIndex.ContainsPartitions():
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes don't support partitioning")
+	case len(columns) != 1:
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes must have exactly one column")
+	case columns[0].Direction == tree.Descending:
+		return pgerror.New(pgcode.FeatureNotSupported, "BRIN indexes don't support descending columns")
+	}
+	col, err := catalog.MustFindColumnByTreeName(tableDesc, columns[0].Column)
+	if err != nil {
+		return err
+	}
+	if !colinfo.ColumnTypeIsBRINIndexable(col.GetType()) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"column %s of type %s is not supported by BRIN indexes", col.GetName(), col.GetType().Name())
+	}
+	primary := tableDesc.GetPrimaryIndex()
+	leading, err := catalog.MustFindColumnByID(tableDesc, primary.GetKeyColumnID(0))
+	if err != nil {
+		return err
+	}
+	if leading.GetType().Family() != types.IntFamily {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"BRIN indexes require the leading primary key column %s to be an integer column",
+			leading.GetName())
+	}
+	if leading.GetID() == col.GetID() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"BRIN index column %s cannot be the leading primary key column", col.GetName())
+	}
+	for _, m := range tableDesc.AllMutations() {
+		if m.AsPrimaryKeySwap() != nil {
+			return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+				"cannot create a BRIN index while a primary key change is in progress")
+		}
+	}
+	return nil
+}
+
 func checkIndexColumns(
 	desc catalog.TableDescriptor,
 	columns tree.IndexElemList,
@@ -0,0 +1,198 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type createPolicyNode struct {
+	n      *tree.CreatePolicy
+	desc   *tabledesc.Mutable
+	policy descpb.PolicyDescriptor
+}
+
+// CreatePolicy adds a row-level security policy to a table.
+// Privileges: ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	tn := n.TableName.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkTableOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	name := string(n.PolicyName)
+	if tableDesc.FindPolicyByName(name) >= 0 {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"policy %q for table %q already exists", name, tableDesc.GetName())
+	}
+
+	policy := descpb.PolicyDescriptor{
+		Name:    name,
+		Type:    policyTypeToDescpb(n.Type),
+		Command: policyCommandToDescpb(n.Cmd),
+	}
+	switch policy.Command {
+	case descpb.PolicyDescriptor_SELECT, descpb.PolicyDescriptor_DELETE:
+		if n.WithCheck != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case descpb.PolicyDescriptor_INSERT:
+		if n.Using != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+
+	roles, err := decodeusername.FromRoleSpecList(
+		p.SessionData(), username.PurposeValidation, n.Roles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		roles = []username.SQLUsername{username.PublicRoleName()}
+	}
+	for _, role := range roles {
+		if !role.IsPublicRole() {
+			exists, err := p.RoleExists(ctx, role)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, sqlerrors.NewUndefinedUserError(role)
+			}
+		}
+		policy.RoleNames = append(policy.RoleNames, role.Normalized())
+	}
+
+	if n.Using != nil {
+		policy.UsingExpr, _, _, err = schemaexpr.DequalifyAndValidateExpr(
+			ctx, tableDesc, n.Using, types.Bool, tree.PolicyUsingExpr, &p.semaCtx,
+			volatility.Stable, &tn, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if n.WithCheck != nil {
+		policy.WithCheckExpr, _, _, err = schemaexpr.DequalifyAndValidateExpr(
+			ctx, tableDesc, n.WithCheck, types.Bool, tree.PolicyWithCheckExpr, &p.semaCtx,
+			volatility.Stable, &tn, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &createPolicyNode{n: n, desc: tableDesc, policy: policy}, nil
+}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("policy"))
+
+	n.desc.Policies = append(n.desc.Policies, n.policy)
+	if err := params.p.addBackRefsFromAllTypesInTable(params.ctx, n.desc); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createPolicyNode) ReadingOwnWrites() {}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
+
+// checkTableOwnership returns an error if the current user does not own the
+// given table and is not an admin. Only the owner of a table may manage its
+// policies and triggers and enable or disable row-level security on it.
+func (p *planner) checkTableOwnership(ctx context.Context, desc *tabledesc.Mutable) error {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if hasAdmin {
+		return nil
+	}
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", desc.GetName())
+	}
+	return nil
+}
+
+func policyTypeToDescpb(t tree.PolicyType) descpb.PolicyDescriptor_Type {
+	switch t {
+	case tree.PolicyTypeDefault, tree.PolicyTypePermissive:
+		return descpb.PolicyDescriptor_PERMISSIVE
+	case tree.PolicyTypeRestrictive:
+		return descpb.PolicyDescriptor_RESTRICTIVE
+	default:
+		panic(errors.AssertionFailedf("unknown policy type %d", t))
+	}
+}
+
+func policyCommandToDescpb(c tree.PolicyCommand) descpb.PolicyDescriptor_Command {
+	switch c {
+	case tree.PolicyCommandDefault, tree.PolicyCommandAll:
+		return descpb.PolicyDescriptor_ALL
+	case tree.PolicyCommandSelect:
+		return descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		return descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		return descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		return descpb.PolicyDescriptor_DELETE
+	default:
+		panic(errors.AssertionFailedf("unknown policy command %d", c))
+	}
+}
@@ -228,6 +228,34 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		)
 	}
 
+	var hasDependencies bool
+	for _, kind := range n.Kinds {
+		switch kind {
+		case "ndistinct":
+			// Multi-column statistics always include the distinct count.
+		case "dependencies":
+			hasDependencies = true
+		case "mcv":
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"statistics kind %q is not yet supported", kind,
+			)
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax, "unrecognized statistics kind %q", kind)
+		}
+	}
+	if len(n.Kinds) > 0 {
+		if len(n.ColumnNames) < 2 {
+			return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+				"statistics kinds require at least 2 columns",
+			)
+		}
+		if hasDependencies && len(n.ColumnNames) > stats.MaxDependenciesColumns {
+			return nil, pgerror.Newf(pgcode.TooManyColumns,
+				"cannot have more than %d columns in dependencies statistics", stats.MaxDependenciesColumns,
+			)
+		}
+	}
+
 	if err := n.p.CheckPrivilege(ctx, tableDesc, privilege.SELECT); err != nil {
 		return nil, err
 	}
@@ -281,6 +309,7 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 			// with a single column that doesn't use an inverted index.
 			HasHistogram:        len(columnIDs) == 1 && !isInvIndex,
 			HistogramMaxBuckets: defaultHistogramBuckets,
+			HasDependencies:     hasDependencies,
 		}}
 		// Make histograms for inverted index column types.
 		if len(columnIDs) == 1 && isInvIndex {
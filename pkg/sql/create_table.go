@@ -671,6 +671,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintDeferrability{},
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -734,7 +735,7 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -754,6 +755,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -808,6 +810,9 @@ func ResolveUniqueWithoutIndexConstraint(
 		Predicate:    predicate,
 		Validity:     validity,
 		ConstraintID: tbl.NextConstraintID,
+
+		Deferrable:        deferrability.Deferrable,
+		InitiallyDeferred: deferrability.InitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -819,6 +824,85 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// addExclusionTableDef runs various checks on the given
+// ExclusionConstraintTableDef before adding it as an EXCLUDE constraint to the
+// given table descriptor. Exclusion constraints are not backed by an index;
+// they are enforced by the optimizer the same way as UNIQUE WITHOUT INDEX
+// constraints.
+func addExclusionTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	if d.Deferrability.Deferrable {
+		return unimplemented.NewWithIssue(46657, "deferrable exclusion constraints are not supported")
+	}
+	columnIDs, ops, err := schemaexpr.ValidateExclusionElems(desc, d.Elems)
+	if err != nil {
+		return err
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Verify we are not writing a constraint over the same name.
+	constraintName := string(d.Name)
+	if constraintName == "" {
+		colNames := make([]string, len(d.Elems))
+		for i := range d.Elems {
+			colNames[i] = string(d.Elems[i].Column)
+		}
+		constraintName = tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", desc.GetName(), strings.Join(colNames, "_")),
+			func(p string) bool {
+				return catalog.FindConstraintByName(desc, p) != nil
+			},
+		)
+	} else if c := catalog.FindConstraintByName(desc, constraintName); c != nil {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", constraintName)
+	}
+
+	validity := descpb.ConstraintValidity_Validated
+	if ts != NewTable {
+		if validationBehavior == tree.ValidationSkip {
+			validity = descpb.ConstraintValidity_Unvalidated
+		} else {
+			validity = descpb.ConstraintValidity_Validating
+		}
+	}
+
+	uc := descpb.UniqueWithoutIndexConstraint{
+		Name:                  constraintName,
+		TableID:               desc.ID,
+		ColumnIDs:             columnIDs,
+		Predicate:             predicate,
+		Validity:              validity,
+		ConstraintID:          desc.NextConstraintID,
+		ExclusionOperators:    ops,
+		ExclusionAccessMethod: d.AccessMethod,
+	}
+	desc.NextConstraintID++
+	if ts == NewTable {
+		desc.UniqueWithoutIndexConstraints = append(desc.UniqueWithoutIndexConstraints, uc)
+	} else {
+		desc.AddUniqueWithoutIndexMutation(&uc, descpb.DescriptorMutation_ADD)
+	}
+	return nil
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -1052,6 +1136,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.Deferrable,
+		InitiallyDeferred:   d.Deferrability.InitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1360,6 +1446,7 @@ func NewTableDesc(
 		return nil, err
 	}
 	setter.TableDesc.RowLevelTTL = setter.UpdatedRowLevelTTL
+	setter.TableDesc.RollingPartitioning = setter.UpdatedRollingPartitioning
 
 	indexEncodingVersion := descpb.StrictIndexColumnIDGuaranteesVersion
 	isRegionalByRow := n.Locality != nil && n.Locality.LocalityLevel == tree.LocalityLevelRow
@@ -1921,6 +2008,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.Deferrability.Deferrable {
+				return nil, tree.NewDeferrableUniqueIndexError()
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -2039,7 +2129,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2207,6 +2298,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
@@ -2427,6 +2525,21 @@ func newTableDesc(
 		}
 		ttl.ScheduleID = j.ScheduleID()
 	}
+
+	// Rolling partitioned tables also require a scheduled job.
+	if ret.HasRollingPartitioning() {
+		j, err := CreateRollingPartitionScheduledJob(
+			params.ctx,
+			params.ExecCfg().JobsKnobs(),
+			jobs.ScheduledJobTxn(params.p.InternalSQLTxn()),
+			params.p.User(),
+			ret,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret.RollingPartitioning.ScheduleID = j.ScheduleID()
+	}
 	return ret, nil
 }
 
@@ -2625,6 +2738,14 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				defs = append(defs, &def)
 			}
 			for _, c := range td.UniqueWithoutIndexConstraints {
+				if c.IsExclusion() {
+					def, err := exclusionConstraintTableDef(td, &c)
+					if err != nil {
+						return nil, err
+					}
+					defs = append(defs, def)
+					continue
+				}
 				def := tree.UniqueConstraintTableDef{
 					IndexTableDef: tree.IndexTableDef{
 						Name:    tree.Name(c.Name),
@@ -2723,6 +2844,36 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 	return newDefs, nil
 }
 
+// exclusionConstraintTableDef returns the EXCLUDE table definition for the
+// given exclusion constraint of td.
+func exclusionConstraintTableDef(
+	td catalog.TableDescriptor, c *descpb.UniqueWithoutIndexConstraint,
+) (*tree.ExclusionConstraintTableDef, error) {
+	colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
+	if err != nil {
+		return nil, err
+	}
+	def := &tree.ExclusionConstraintTableDef{
+		Name:         tree.Name(c.Name),
+		AccessMethod: c.ExclusionAccessMethod,
+		Elems:        make(tree.ExclusionElemList, len(colNames)),
+	}
+	for i := range colNames {
+		op, err := schemaexpr.ExclusionOperator(c.ExclusionOperators[i])
+		if err != nil {
+			return nil, err
+		}
+		def.Elems[i] = tree.ExclusionElem{Column: tree.Name(colNames[i]), Operator: op}
+	}
+	if c.IsPartial() {
+		def.Predicate, err = parser.ParseExpr(c.Predicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
 // based on all the `colNames` and the bucket count. It delegates to one of
 // makeHashShardComputeExpr.
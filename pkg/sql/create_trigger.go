@@ -0,0 +1,246 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n       *tree.CreateTrigger
+	desc    *tabledesc.Mutable
+	trigger descpb.TriggerDescriptor
+	fn      *funcdesc.Mutable
+	// replacedFn is the function of the trigger that is replaced by CREATE OR
+	// REPLACE TRIGGER, if any.
+	replacedFn *funcdesc.Mutable
+}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: ownership of the table and EXECUTE on the trigger function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	tn := n.TableName.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkTableOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	switch n.ActionTime {
+	case tree.TriggerActionTimeBefore, tree.TriggerActionTimeAfter:
+	case tree.TriggerActionTimeInsteadOf:
+		return nil, errors.WithDetail(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a table", tableDesc.GetName()),
+			"Tables cannot have INSTEAD OF triggers.",
+		)
+	default:
+		return nil, errors.AssertionFailedf("unexpected trigger action time %v", n.ActionTime)
+	}
+	if n.ForEach != tree.TriggerForEachRow {
+		return nil, unimplemented.New("statement-level triggers",
+			"statement-level triggers are not yet supported")
+	}
+	if len(n.Transitions) > 0 {
+		return nil, unimplemented.New("trigger transition tables",
+			"REFERENCING clauses of triggers are not yet supported")
+	}
+
+	name := string(n.Name)
+	trigger := descpb.TriggerDescriptor{
+		Name:     name,
+		FuncArgs: n.FuncArgs,
+	}
+	if n.ActionTime == tree.TriggerActionTimeAfter {
+		trigger.ActionTime = semenumpb.TriggerActionTime_AFTER
+	}
+	var updateColIDs catalog.TableColSet
+	for _, event := range n.Events {
+		var e semenumpb.TriggerEventType
+		switch event.EventType {
+		case tree.TriggerEventInsert:
+			e = semenumpb.TriggerEventType_INSERT
+		case tree.TriggerEventUpdate:
+			e = semenumpb.TriggerEventType_UPDATE
+		case tree.TriggerEventDelete:
+			e = semenumpb.TriggerEventType_DELETE
+		case tree.TriggerEventTruncate:
+			return nil, unimplemented.New("TRUNCATE triggers",
+				"TRUNCATE triggers are not yet supported")
+		default:
+			return nil, errors.AssertionFailedf("unexpected trigger event %v", event.EventType)
+		}
+		hasEvent := false
+		for _, other := range trigger.Events {
+			hasEvent = hasEvent || other == e
+		}
+		if !hasEvent {
+			trigger.Events = append(trigger.Events, e)
+		}
+		for _, colName := range event.Columns {
+			col, err := catalog.MustFindColumnByTreeName(tableDesc, colName)
+			if err != nil {
+				return nil, err
+			}
+			if !updateColIDs.Contains(col.GetID()) {
+				updateColIDs.Add(col.GetID())
+				trigger.UpdateColumnIDs = append(trigger.UpdateColumnIDs, col.GetID())
+			}
+		}
+	}
+
+	if n.When != nil {
+		if trigger.WhenExpr, err = p.validateTriggerWhenExpr(ctx, tableDesc, &tn, n); err != nil {
+			return nil, err
+		}
+	}
+
+	fn, err := p.resolveTriggerFunction(ctx, n.FuncName)
+	if err != nil {
+		return nil, err
+	}
+	trigger.FuncID = fn.GetID()
+
+	node := &createTriggerNode{n: n, desc: tableDesc, trigger: trigger, fn: fn}
+	if existing := tableDesc.GetTriggerByName(name); existing != nil {
+		if !n.Replace {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", name, tableDesc.GetName())
+		}
+		if oldFuncID := existing.FuncID; oldFuncID != fn.GetID() {
+			if node.replacedFn, err = p.Descriptors().MutableByID(p.Txn()).Function(ctx, oldFuncID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return node, nil
+}
+
+// resolveTriggerFunction resolves the function with the given name that takes
+// no arguments and returns type trigger, and checks that the current user may
+// execute it.
+func (p *planner) resolveTriggerFunction(
+	ctx context.Context, funcName *tree.UnresolvedName,
+) (*funcdesc.Mutable, error) {
+	name, err := funcName.ToFunctionName()
+	if err != nil {
+		return nil, err
+	}
+	ol, err := p.matchUDF(ctx, &tree.FuncObj{
+		FuncName: name,
+		Params:   tree.RoutineParams{},
+	}, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid))
+	if err != nil {
+		return nil, err
+	}
+	if fn.IsProcedure || fn.ReturnType.Type.Family() != types.TriggerFamily {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", name.Object())
+	}
+	if err := p.CheckPrivilege(ctx, fn, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fn, nil
+}
+
+// validateTriggerWhenExpr validates the WHEN condition of the given trigger,
+// and returns its serialized form.
+func (p *planner) validateTriggerWhenExpr(
+	ctx context.Context, desc *tabledesc.Mutable, tn *tree.TableName, n *tree.CreateTrigger,
+) (string, error) {
+	expr, err := schemaexpr.DequalifyTriggerWhenExpr(n)
+	if err != nil {
+		return "", err
+	}
+	if _, _, _, err := schemaexpr.DequalifyAndValidateExpr(
+		ctx, desc, expr, types.Bool, tree.TriggerWhenExpr, &p.semaCtx,
+		volatility.Volatile, tn, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	); err != nil {
+		return "", err
+	}
+	return tree.Serialize(n.When), nil
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("trigger"))
+
+	// A replaced trigger keeps its ID, so that the back-reference from its
+	// function remains valid if the function does not change.
+	if existing := n.desc.GetTriggerByName(n.trigger.Name); existing != nil {
+		n.trigger.ID = existing.ID
+		*existing = n.trigger
+	} else {
+		if n.desc.NextTriggerID == 0 {
+			n.desc.NextTriggerID = 1
+		}
+		n.trigger.ID = n.desc.NextTriggerID
+		n.desc.NextTriggerID++
+		n.desc.Triggers = append(n.desc.Triggers, n.trigger)
+	}
+	if err := params.p.addBackRefsFromAllTypesInTable(params.ctx, n.desc); err != nil {
+		return err
+	}
+	if n.replacedFn != nil {
+		n.replacedFn.RemoveTriggerReference(n.desc.GetID(), n.trigger.ID)
+		if err := params.p.writeFuncSchemaChange(params.ctx, n.replacedFn); err != nil {
+			return err
+		}
+	}
+	n.fn.AddTriggerReference(n.desc.GetID(), n.trigger.ID)
+	if err := params.p.writeFuncSchemaChange(params.ctx, n.fn); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createTriggerNode) ReadingOwnWrites() {}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
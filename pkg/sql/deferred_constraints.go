@@ -0,0 +1,397 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// SetConstraints implements the SET CONSTRAINTS statement.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html for
+// details.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	if p.deferredConstraints == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"SET CONSTRAINTS is not supported in this context")
+	}
+	if n.Names == nil {
+		p.deferredConstraints.setAll(n.Deferred)
+	} else {
+		var keys []deferredConstraintKey
+		for i := range n.Names {
+			resolved, err := p.resolveDeferrableConstraints(ctx, &n.Names[i])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, resolved...)
+		}
+		p.deferredConstraints.set(keys, n.Deferred)
+	}
+	if !n.Deferred {
+		// Following Postgres, the checks that were postponed for the constraints
+		// that are now immediate are performed right away.
+		checks := p.deferredConstraints.takeImmediateChecks()
+		if err := validateDeferredConstraintChecks(ctx, p.InternalSQLTxn(), checks); err != nil {
+			return nil, err
+		}
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// resolveDeferrableConstraints returns the constraints with the given name in
+// the first schema of the search path containing any, or in the schema the
+// name is qualified with. All of them must be deferrable.
+func (p *planner) resolveDeferrableConstraints(
+	ctx context.Context, name *tree.TableName,
+) ([]deferredConstraintKey, error) {
+	dbName := p.CurrentDatabase()
+	if name.ExplicitCatalog {
+		dbName = name.Catalog()
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	var schemaNames []string
+	if name.ExplicitSchema {
+		schemaNames = []string{name.Schema()}
+	} else {
+		iter := p.CurrentSearchPath().Iter()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			schemaNames = append(schemaNames, scName)
+		}
+	}
+	constraintName := name.Object()
+	for _, scName := range schemaNames {
+		sc, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil || sc.SchemaKind() == catalog.SchemaVirtual {
+			continue
+		}
+		objects, err := p.Descriptors().GetAllObjectsInSchema(ctx, p.txn, db, sc)
+		if err != nil {
+			return nil, err
+		}
+		var keys []deferredConstraintKey
+		if err := objects.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			tbl, ok := desc.(catalog.TableDescriptor)
+			if !ok {
+				return nil
+			}
+			c := catalog.FindConstraintByName(tbl, constraintName)
+			if c == nil {
+				return nil
+			}
+			if !constraintDeferrable(c) {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q is not deferrable", constraintName)
+			}
+			keys = append(keys, deferredConstraintKey{tableID: tbl.GetID(), name: constraintName})
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if len(keys) > 0 {
+			return keys, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"constraint %q does not exist", constraintName)
+}
+
+// constraintDeferrable returns true if the constraint is a DEFERRABLE foreign
+// key or unique constraint.
+func constraintDeferrable(c catalog.Constraint) bool {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().Deferrable
+	}
+	if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		return uwoi.UniqueWithoutIndexDesc().Deferrable
+	}
+	return false
+}
+
+// deferredConstraintKey identifies a constraint in a transaction.
+type deferredConstraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// deferredConstraintCheck is a violation of a deferred constraint found when
+// executing a statement, which has to be revalidated when the transaction
+// commits.
+type deferredConstraintCheck struct {
+	key deferredConstraintKey
+	// keyVals are the values of the constraint columns in the violating row.
+	keyVals tree.Datums
+	// err is the error returned if the violation still exists at commit time.
+	err error
+	// initiallyDeferred is copied from the constraint.
+	initiallyDeferred bool
+}
+
+// txnDeferredConstraints holds the checking mode of the DEFERRABLE
+// constraints set with SET CONSTRAINTS in the current transaction, as well as
+// the constraint violations that were postponed to the end of the
+// transaction.
+type txnDeferredConstraints struct {
+	mu struct {
+		syncutil.Mutex
+
+		// allSet is true if SET CONSTRAINTS ALL was executed, in which case
+		// allDeferred is the mode of the constraints that were not named in a
+		// later SET CONSTRAINTS statement.
+		allSet      bool
+		allDeferred bool
+
+		// modes maps the constraints named in SET CONSTRAINTS to whether they
+		// are deferred. It is lazily allocated.
+		modes map[deferredConstraintKey]bool
+
+		// pending are the postponed violations, in execution order.
+		pending []deferredConstraintCheck
+	}
+}
+
+// reset clears the state at the end of a transaction.
+func (t *txnDeferredConstraints) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.allSet = false
+	t.mu.allDeferred = false
+	t.mu.modes = nil
+	t.mu.pending = nil
+}
+
+func (t *txnDeferredConstraints) setAll(deferred bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.allSet = true
+	t.mu.allDeferred = deferred
+	t.mu.modes = nil
+}
+
+func (t *txnDeferredConstraints) set(keys []deferredConstraintKey, deferred bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.mu.modes == nil {
+		t.mu.modes = make(map[deferredConstraintKey]bool)
+	}
+	for _, k := range keys {
+		t.mu.modes[k] = deferred
+	}
+}
+
+// isDeferredLocked returns true if the given constraint is currently
+// deferred. t.mu must be held.
+func (t *txnDeferredConstraints) isDeferredLocked(
+	key deferredConstraintKey, initiallyDeferred bool,
+) bool {
+	if deferred, ok := t.mu.modes[key]; ok {
+		return deferred
+	}
+	if t.mu.allSet {
+		return t.mu.allDeferred
+	}
+	return initiallyDeferred
+}
+
+// isDeferred returns true if violations of the constraint enforced by the
+// given check have to be postponed to the end of the transaction. It can be
+// called on a nil receiver, in which case all constraints are immediate.
+func (t *txnDeferredConstraints) isDeferred(check *exec.DeferrableCheck) bool {
+	if t == nil || check == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.isDeferredLocked(
+		deferredConstraintKey{tableID: descpb.ID(check.TableID), name: check.ConstraintName},
+		check.InitiallyDeferred,
+	)
+}
+
+// deferViolation records a violation of the constraint enforced by the given
+// check, found in the given row of the check query. Violations involving NULL
+// values (which are MATCH FULL violations) cannot be fixed by later
+// statements, so they are returned right away.
+func (t *txnDeferredConstraints) deferViolation(
+	check *exec.DeferrableCheck, row tree.Datums, mkErr exec.MkErrFn,
+) error {
+	keyVals := make(tree.Datums, len(check.KeyCols))
+	for i, ord := range check.KeyCols {
+		if row[ord] == tree.DNull {
+			return mkErr(row)
+		}
+		keyVals[i] = row[ord]
+	}
+	c := deferredConstraintCheck{
+		key:               deferredConstraintKey{tableID: descpb.ID(check.TableID), name: check.ConstraintName},
+		keyVals:           keyVals,
+		err:               mkErr(row),
+		initiallyDeferred: check.InitiallyDeferred,
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.pending = append(t.mu.pending, c)
+	return nil
+}
+
+// takeImmediateChecks removes and returns the postponed violations of the
+// constraints that are no longer deferred.
+func (t *txnDeferredConstraints) takeImmediateChecks() []deferredConstraintCheck {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ret []deferredConstraintCheck
+	pending := t.mu.pending[:0]
+	for _, c := range t.mu.pending {
+		if t.isDeferredLocked(c.key, c.initiallyDeferred) {
+			pending = append(pending, c)
+		} else {
+			ret = append(ret, c)
+		}
+	}
+	t.mu.pending = pending
+	return ret
+}
+
+// takeAllChecks removes and returns all the postponed violations.
+func (t *txnDeferredConstraints) takeAllChecks() []deferredConstraintCheck {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ret := t.mu.pending
+	t.mu.pending = nil
+	return ret
+}
+
+// validateDeferredConstraintChecks verifies that the postponed violations
+// have been fixed by the later statements of the transaction, and returns the
+// error of the first one that has not.
+func validateDeferredConstraintChecks(
+	ctx context.Context, txn descs.Txn, checks []deferredConstraintCheck,
+) error {
+	if len(checks) == 0 {
+		return nil
+	}
+	// The same violation can be recorded multiple times; only validate it once.
+	seen := make(map[string]struct{})
+	for i := range checks {
+		c := &checks[i]
+		seenKey := fmt.Sprintf("%d/%s/%s", c.key.tableID, c.key.name, c.keyVals.String())
+		if _, ok := seen[seenKey]; ok {
+			continue
+		}
+		seen[seenKey] = struct{}{}
+		query, err := makeDeferredConstraintQuery(ctx, txn, c.key)
+		if err != nil {
+			return err
+		}
+		if query == "" {
+			// The table or constraint has been dropped in the meantime.
+			continue
+		}
+		qargs := make([]interface{}, len(c.keyVals))
+		for j := range c.keyVals {
+			qargs[j] = c.keyVals[j]
+		}
+		row, err := txn.QueryRowEx(
+			ctx, "validate-deferred-constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query, qargs...,
+		)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return c.err
+		}
+	}
+	return nil
+}
+
+// makeDeferredConstraintQuery returns a query that returns a row if the
+// constraint is violated for the values of its columns given as placeholders.
+// An empty query is returned if the constraint no longer exists.
+func makeDeferredConstraintQuery(
+	ctx context.Context, txn descs.Txn, key deferredConstraintKey,
+) (string, error) {
+	tbl, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Table(ctx, key.tableID)
+	if err != nil {
+		return "", err
+	}
+	if tbl.Dropped() {
+		return "", nil
+	}
+	c := catalog.FindConstraintByName(tbl, key.name)
+	if c == nil {
+		return "", nil
+	}
+	// eqConds returns the conditions that the given columns of the given table
+	// alias are equal to the placeholders.
+	eqConds := func(alias string, desc catalog.TableDescriptor, colIDs []descpb.ColumnID) (string, error) {
+		names, err := catalog.ColumnNamesForIDs(desc, colIDs)
+		if err != nil {
+			return "", err
+		}
+		conds := make([]string, len(names))
+		for i, name := range names {
+			conds[i] = fmt.Sprintf("%s.%s = $%d", alias, tree.NameString(name), i+1)
+		}
+		return strings.Join(conds, " AND "), nil
+	}
+	if fk := c.AsForeignKey(); fk != nil {
+		ref, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Table(ctx, fk.GetReferencedTableID())
+		if err != nil {
+			return "", err
+		}
+		if ref.Dropped() {
+			return "", nil
+		}
+		originConds, err := eqConds("o", tbl, fk.ForeignKeyDesc().OriginColumnIDs)
+		if err != nil {
+			return "", err
+		}
+		refConds, err := eqConds("r", ref, fk.ForeignKeyDesc().ReferencedColumnIDs)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			`SELECT 1 FROM [%d AS o] WHERE %s AND NOT EXISTS (SELECT 1 FROM [%d AS r] WHERE %s) LIMIT 1`,
+			tbl.GetID(), originConds, ref.GetID(), refConds,
+		), nil
+	}
+	if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		conds, err := eqConds("t", tbl, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
+		if err != nil {
+			return "", err
+		}
+		if uwoi.IsPartial() {
+			conds = fmt.Sprintf("%s AND (%s)", conds, uwoi.GetPredicate())
+		}
+		return fmt.Sprintf(
+			`SELECT 1 FROM [%d AS t] WHERE %s HAVING count(*) > 1`, tbl.GetID(), conds,
+		), nil
+	}
+	return "", nil
+}
@@ -67,6 +67,16 @@ func (n *discardNode) startExec(params runParams) error {
 			return err
 		}
 
+		// UNLISTEN *
+		if err := params.p.notifications.unlisten("" /* channel */); err != nil {
+			return err
+		}
+
+		// SELECT pg_advisory_unlock_all()
+		if params.p.advisoryLocks != nil {
+			params.p.advisoryLocks.releaseAll(params.ctx)
+		}
+
 	case tree.DiscardModeSequences:
 		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
 			m.data.SequenceState = sessiondata.NewSequenceState()
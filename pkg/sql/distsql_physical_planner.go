@@ -514,6 +514,15 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// The support functions of user-defined aggregates are routines,
+				// which cannot be serialized.
+				return cannotDistribute, newQueryNotSupportedErrorf(
+					"user-defined aggregate %s cannot be executed with distsql", f.funcName,
+				)
+			}
+		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
 
@@ -1652,6 +1661,7 @@ func initTableReaderSpecTemplate(
 		TableDescriptorModificationTime: n.desc.GetModificationTime(),
 		LockingStrength:                 n.lockingStrength,
 		LockingWaitPolicy:               n.lockingWaitPolicy,
+		Sample:                          n.sample,
 	}
 	if err := rowenc.InitIndexFetchSpec(&s.FetchSpec, codec, n.desc, n.index, colIDs); err != nil {
 		return nil, execinfrapb.PostProcessSpec{}, err
@@ -2010,11 +2020,23 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			// The definition of a user-defined aggregate is passed to the
+			// aggregator as its only argument.
+			aggregations[i].Func = execinfrapb.UserDefined
+			def, err := physicalplan.MakeExpression(ctx, fholder.userDefined, planCtx, nil)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Arguments = []execinfrapb.Expression{def}
+			argumentsColumnTypes[i] = []*types.T{fholder.userDefined.ResolvedType()}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2023,6 +2045,9 @@ func (dsp *DistSQLPlanner) addAggregators(
 			col := uint32(p.PlanToStreamColMap[fholder.filterRenderIdx])
 			aggregations[i].FilterColIdx = &col
 		}
+		if fholder.userDefined != nil {
+			continue
+		}
 		aggregations[i].Arguments = make([]execinfrapb.Expression, len(fholder.arguments))
 		argumentsColumnTypes[i] = make([]*types.T, len(fholder.arguments))
 		for j, argument := range fholder.arguments {
@@ -2046,6 +2071,25 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// getDistAggregationInfo returns the blueprint for planning the local and
+// final stages of the given aggregation. ok is false if the aggregation cannot
+// be computed in multiple stages.
+func getDistAggregationInfo(
+	agg execinfrapb.AggregatorSpec_Aggregation,
+) (_ physicalplan.DistAggregationInfo, ok bool) {
+	if agg.Func == execinfrapb.UserDefined {
+		// The partial states of a user-defined aggregate can only be merged
+		// if it has a combine function.
+		uda := agg.Arguments[0].LocalExpr.(*tree.UserDefinedAggregate)
+		if uda.CombineFunc == nil {
+			return physicalplan.DistAggregationInfo{}, false
+		}
+		return physicalplan.UserDefinedDistAggregationInfo, true
+	}
+	info, ok := physicalplan.DistAggregationTable[agg.Func]
+	return info, ok
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2243,7 +2287,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 				break
 			}
 			// Check that the function supports a local stage.
-			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
+			if _, ok := getDistAggregationInfo(e); !ok {
 				multiStage = false
 				break
 			}
@@ -2283,7 +2327,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := getDistAggregationInfo(e)
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
 			if info.FinalRendering != nil {
@@ -2318,7 +2362,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// to all final aggregations.
 		finalIdx := 0
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := getDistAggregationInfo(e)
 
 			// relToAbsLocalIdx maps each local stage for the given
 			// aggregation e to its final index in localAggs.  This
@@ -2340,6 +2384,16 @@ func (dsp *DistSQLPlanner) planAggregators(
 					ColIdx:       e.ColIdx,
 					FilterColIdx: e.FilterColIdx,
 				}
+				var localArgTypes []*types.T
+				if localFunc == execinfrapb.UserDefined {
+					uda := e.Arguments[0].LocalExpr.(*tree.UserDefinedAggregate).LocalStage()
+					arg, err := physicalplan.MakeExpression(ctx, uda, planCtx, nil /* indexVarMap */)
+					if err != nil {
+						return err
+					}
+					localAgg.Arguments = []execinfrapb.Expression{arg}
+					localArgTypes = []*types.T{uda.ResolvedType()}
+				}
 
 				isNewAgg := true
 				for j, prevLocalAgg := range localAggs {
@@ -2363,10 +2417,11 @@ func (dsp *DistSQLPlanner) planAggregators(
 
 					// Keep track of the new local
 					// aggregation's output type.
-					argTypes := make([]*types.T, len(e.ColIdx))
+					argTypes := make([]*types.T, len(e.ColIdx), len(e.ColIdx)+len(localArgTypes))
 					for j, c := range e.ColIdx {
 						argTypes[j] = inputTypes[c]
 					}
+					argTypes = append(argTypes, localArgTypes...)
 					_, outputType, err := execagg.GetAggregateInfo(localFunc, argTypes...)
 					if err != nil {
 						return err
@@ -2391,6 +2446,16 @@ func (dsp *DistSQLPlanner) planAggregators(
 					Func:   finalInfo.Fn,
 					ColIdx: argIdxs,
 				}
+				var finalArgTypes []*types.T
+				if finalInfo.Fn == execinfrapb.UserDefined {
+					uda := e.Arguments[0].LocalExpr.(*tree.UserDefinedAggregate).FinalStage()
+					arg, err := physicalplan.MakeExpression(ctx, uda, planCtx, nil /* indexVarMap */)
+					if err != nil {
+						return err
+					}
+					finalAgg.Arguments = []execinfrapb.Expression{arg}
+					finalArgTypes = []*types.T{uda.ResolvedType()}
+				}
 
 				isNewAgg := true
 				for i, prevFinalAgg := range finalAggs {
@@ -2412,13 +2477,14 @@ func (dsp *DistSQLPlanner) planAggregators(
 					finalAggs = append(finalAggs, finalAgg)
 
 					if needRender {
-						argTypes := make([]*types.T, len(finalInfo.LocalIdxs))
+						argTypes := make([]*types.T, len(finalInfo.LocalIdxs), len(finalInfo.LocalIdxs)+len(finalArgTypes))
 						for i := range finalInfo.LocalIdxs {
 							// Map the corresponding local
 							// aggregation output types for
 							// the current aggregation e.
 							argTypes[i] = intermediateTypes[argIdxs[i]]
 						}
+						argTypes = append(argTypes, finalArgTypes...)
 						_, outputType, err := execagg.GetAggregateInfo(finalInfo.Fn, argTypes...)
 						if err != nil {
 							return err
@@ -2536,7 +2602,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			// to each aggregation.
 			finalIdx := 0
 			for i, e := range info.aggregations {
-				info := physicalplan.DistAggregationTable[e.Func]
+				info, _ := getDistAggregationInfo(e)
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
 					// location of the result for this
@@ -42,6 +42,7 @@ type requestedStat struct {
 	histogramMaxBuckets uint32
 	name                string
 	inverted            bool
+	dependencies        bool
 }
 
 // histogramSamples is the number of sample rows to be collected for histogram
@@ -88,7 +89,7 @@ func (dsp *DistSQLPlanner) createAndAttachSamplers(
 	// For partial statistics this loop should only iterate once
 	// since we only support one reqStat at a time.
 	for _, s := range reqStats {
-		if s.histogram {
+		if s.histogram || s.dependencies {
 			if count, ok := desc.HistogramSamplesCount(); ok {
 				sampler.SampleSize = count
 			} else {
@@ -416,11 +417,12 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 	sampledColumnIDs := make([]descpb.ColumnID, len(scan.cols))
 	for _, s := range reqStats {
 		spec := execinfrapb.SketchSpec{
-			SketchType:          execinfrapb.SketchType_HLL_PLUS_PLUS_V1,
-			GenerateHistogram:   s.histogram,
-			HistogramMaxBuckets: s.histogramMaxBuckets,
-			Columns:             make([]uint32, len(s.columns)),
-			StatName:            s.name,
+			SketchType:           execinfrapb.SketchType_HLL_PLUS_PLUS_V1,
+			GenerateHistogram:    s.histogram,
+			HistogramMaxBuckets:  s.histogramMaxBuckets,
+			Columns:              make([]uint32, len(s.columns)),
+			StatName:             s.name,
+			GenerateDependencies: s.dependencies,
 		}
 		for i, colID := range s.columns {
 			colIdx, ok := colIdxMap.Get(colID)
@@ -500,6 +502,7 @@ func (dsp *DistSQLPlanner) createPlanForCreateStats(
 			histogramMaxBuckets: histogramMaxBuckets,
 			name:                details.Name,
 			inverted:            details.ColumnStats[i].Inverted,
+			dependencies:        details.ColumnStats[i].HasDependencies,
 		}
 	}
 
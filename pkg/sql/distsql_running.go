@@ -2072,7 +2072,11 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
+		if plan.cascades[i].IsTrigger {
+			log.VEventf(ctx, 2, "executing AFTER trigger %s", plan.cascades[i].FKName)
+		} else {
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
+		}
 
 		// We place a sequence point before every cascade, so
 		// that each subsequent cascade can observe the writes
@@ -2131,7 +2135,8 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			planner,
 			evalCtx,
 			recv,
-			false, /* parallelCheck */
+			false,                      /* parallelCheck */
+			plan.cascades[i].IsTrigger, /* discardRows */
 			defaultGetSaveFlowsFunc,
 			planner.instrumentation.getAssociateNodeWithComponentsFn(),
 			recv.stats.add,
@@ -2188,6 +2193,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 				evalCtxFactory(false /* usedConcurrently */),
 				recv,
 				false, /* parallelCheck */
+				false, /* discardRows */
 				defaultGetSaveFlowsFunc,
 				planner.instrumentation.getAssociateNodeWithComponentsFn(),
 				recv.stats.add,
@@ -2229,6 +2235,9 @@ var parallelChecksConcurrencyLimit = settings.RegisterIntSetting(
 // with other check queries. If parallelCheck is true, then getSaveFlowsFunc,
 // associateNodeWithComponents, and addTopLevelQueryStats must be
 // concurrency-safe (if non-nil).
+// - discardRows indicates whether the postquery returns rows that should be
+// discarded. This is the case for AFTER triggers; other postqueries return no
+// rows.
 // - getSaveFlowsFunc will only be called if
 // planner.instrumentation.ShouldSaveFlows() returns true.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
@@ -2238,6 +2247,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	evalCtx *extendedEvalContext,
 	recv *DistSQLReceiver,
 	parallelCheck bool,
+	discardRows bool,
 	getSaveFlowsFunc func(postqueryPlanCtx *PlanningCtx) func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, bool) error,
 	associateNodeWithComponents func(exec.Node, execComponents),
 	addTopLevelQueryStats func(stats *topLevelQueryStats),
@@ -2273,9 +2283,15 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
 	defer addTopLevelQueryStats(&postqueryRecv.stats)
-	postqueryResultWriter := &errOnlyResultWriter{}
-	postqueryRecv.resultWriterMu.row = postqueryResultWriter
-	postqueryRecv.resultWriterMu.batch = postqueryResultWriter
+	if discardRows {
+		postqueryResultWriter := &droppingResultWriter{}
+		postqueryRecv.resultWriterMu.row = postqueryResultWriter
+		postqueryRecv.resultWriterMu.batch = postqueryResultWriter
+	} else {
+		postqueryResultWriter := &errOnlyResultWriter{}
+		postqueryRecv.resultWriterMu.row = postqueryResultWriter
+		postqueryRecv.resultWriterMu.batch = postqueryResultWriter
+	}
 	finishedSetupFn, cleanup := getFinishedSetupFn(planner)
 	defer cleanup()
 	dsp.Run(ctx, postqueryPlanCtx, planner.txn, postqueryPhysPlan, postqueryRecv, evalCtx, finishedSetupFn)
@@ -2368,7 +2384,8 @@ func (dsp *DistSQLPlanner) planAndRunChecksInParallel(
 			planner,
 			evalCtxFactory(true /* usedConcurrently */),
 			recv,
-			true, /* parallelCheck */
+			true,  /* parallelCheck */
+			false, /* discardRows */
 			getSaveFlowsFunc,
 			associateNodeWithComponents,
 			addTopLevelQueryStats,
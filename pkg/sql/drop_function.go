@@ -66,6 +66,9 @@ func (p *planner) DropFunction(
 		if ol == nil {
 			continue
 		}
+		if ol.IsProcedure != n.IsProcedure || (ol.Class == tree.AggregateClass) != n.IsAggregate {
+			return nil, errWrongRoutineKind(fn.FuncName.Object(), n.IsProcedure, n.IsAggregate)
+		}
 		fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 		if fnResolved.Contains(int(fnID)) {
 			continue
@@ -115,6 +118,19 @@ func (n *dropFunctionNode) Next(params runParams) (bool, error) { return false,
 func (n *dropFunctionNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *dropFunctionNode) Close(ctx context.Context)           {}
 
+// errWrongRoutineKind returns an error for a statement on one kind of routine
+// that references a routine of another kind, e.g. a DROP FUNCTION statement
+// that references a procedure or an aggregate.
+func errWrongRoutineKind(name string, expectProcedure, expectAggregate bool) error {
+	kind := "a function"
+	if expectProcedure {
+		kind = "a procedure"
+	} else if expectAggregate {
+		kind = "an aggregate function"
+	}
+	return pgerror.Newf(pgcode.WrongObjectType, "%q is not %s", name, kind)
+}
+
 // matchUDF tries to resolve a user-defined function with the given signature
 // from the current search path, only overloads with exactly the same argument
 // types are considered a match. If required is true, an error is returned if
@@ -217,6 +233,11 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		}
 	}
 
+	// Remove backreferences from the support functions of an aggregate.
+	if err := p.removeAggregateSupportFuncReferences(ctx, fnMutable); err != nil {
+		return err
+	}
+
 	// Remove backreference from types referenced by this UDF.
 	jobDesc := fmt.Sprintf(
 		"updating type backreference %v for function %s(%d)",
@@ -0,0 +1,186 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type dropPolicyNode struct {
+	n    *tree.DropPolicy
+	desc *tabledesc.Mutable
+	idx  int
+}
+
+// DropPolicy removes a row-level security policy from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	tn := n.TableName.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"relation %q does not exist, skipping", tn.ObjectName,
+		))
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.checkTableOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	name := string(n.PolicyName)
+	idx := tableDesc.FindPolicyByName(name)
+	if idx < 0 {
+		if n.IfExists {
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"policy %q for table %q does not exist, skipping", name, tableDesc.GetName(),
+			))
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", name, tableDesc.GetName())
+	}
+	return &dropPolicyNode{n: n, desc: tableDesc, idx: idx}, nil
+}
+
+func (n *dropPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("policy"))
+
+	// The policy may have been the last reference to some types, in which case
+	// their back references to the table need to be removed.
+	before, err := params.p.referencedTypeIDsInTable(params.ctx, n.desc)
+	if err != nil {
+		return err
+	}
+	n.desc.Policies = append(n.desc.Policies[:n.idx], n.desc.Policies[n.idx+1:]...)
+	after, err := params.p.referencedTypeIDsInTable(params.ctx, n.desc)
+	if err != nil {
+		return err
+	}
+	if removed := before.Difference(after); !removed.Empty() {
+		jobDesc := fmt.Sprintf("updating type back references %v for table %d", removed.Ordered(), n.desc.ID)
+		if err := params.p.removeTypeBackReferences(
+			params.ctx, removed.Ordered(), n.desc.ID, jobDesc,
+		); err != nil {
+			return err
+		}
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropPolicyNode) ReadingOwnWrites() {}
+
+func (n *dropPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPolicyNode) Close(context.Context)        {}
+
+// referencedTypeIDsInTable returns the IDs of all the types referenced by the
+// table.
+func (p *planner) referencedTypeIDsInTable(
+	ctx context.Context, desc *tabledesc.Mutable,
+) (catalog.DescriptorIDSet, error) {
+	dbDesc, err := p.Descriptors().ByID(p.txn).WithoutNonPublic().Get().Database(ctx, desc.GetParentID())
+	if err != nil {
+		return catalog.DescriptorIDSet{}, err
+	}
+	typeIDs, _, err := desc.GetAllReferencedTypeIDs(dbDesc, func(id descpb.ID) (catalog.TypeDescriptor, error) {
+		return p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Type(ctx, id)
+	})
+	if err != nil {
+		return catalog.DescriptorIDSet{}, err
+	}
+	return catalog.MakeDescriptorIDSet(typeIDs...), nil
+}
+
+// dropPoliciesReferencingColumn removes the policies of the table whose
+// expressions reference the given column if the drop behavior is CASCADE, and
+// returns an error if there are such policies otherwise.
+func dropPoliciesReferencingColumn(
+	desc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	policies := desc.Policies[:0]
+	for _, policy := range desc.Policies {
+		referenced, err := policyReferencesColumn(desc, &policy, col.GetID())
+		if err != nil {
+			return err
+		}
+		if !referenced {
+			policies = append(policies, policy)
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop column %s because policy %s on table %s depends on it",
+					col.GetName(), policy.Name, desc.GetName()),
+				"Use DROP ... CASCADE to drop the dependent objects too.",
+			)
+		}
+	}
+	desc.Policies = policies
+	return nil
+}
+
+// policyReferencesColumn returns whether the USING or WITH CHECK expression of
+// the policy references the given column.
+func policyReferencesColumn(
+	desc catalog.TableDescriptor, policy *descpb.PolicyDescriptor, colID descpb.ColumnID,
+) (bool, error) {
+	for _, exprStr := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+		if exprStr == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			return false, err
+		}
+		colIDs, err := schemaexpr.ExtractColumnIDs(desc, expr)
+		if err != nil {
+			return false, err
+		}
+		if colIDs.Contains(colID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	desc      *tabledesc.Mutable
+	triggerID descpb.TriggerID
+}
+
+// DropTrigger drops a trigger from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"relation %q does not exist, skipping", tn.ObjectName,
+		))
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.checkTableOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+
+	name := string(n.Trigger)
+	trigger := tableDesc.GetTriggerByName(name)
+	if trigger == nil {
+		if n.IfExists {
+			p.BufferClientNotice(ctx, pgnotice.Newf(
+				"trigger %q for table %q does not exist, skipping", name, tableDesc.GetName(),
+			))
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", name, tableDesc.GetName())
+	}
+	return &dropTriggerNode{n: n, desc: tableDesc, triggerID: trigger.ID}, nil
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("trigger"))
+
+	if err := params.p.dropTriggers(params.ctx, n.desc, []descpb.TriggerID{n.triggerID}); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *dropTriggerNode) ReadingOwnWrites() {}
+
+func (n *dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTriggerNode) Close(context.Context)        {}
+
+// dropTriggers removes the triggers with the given IDs from the table, along
+// with the back references to them from their functions and any types that are
+// no longer referenced. The caller is responsible for writing the table
+// descriptor.
+func (p *planner) dropTriggers(
+	ctx context.Context, desc *tabledesc.Mutable, ids []descpb.TriggerID,
+) error {
+	before, err := p.referencedTypeIDsInTable(ctx, desc)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		t := desc.GetTriggerByID(id)
+		if t == nil {
+			return errors.AssertionFailedf("trigger with ID %d does not exist", id)
+		}
+		fn, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, t.FuncID)
+		if err != nil {
+			return err
+		}
+		fn.RemoveTriggerReference(desc.GetID(), id)
+		if err := p.writeFuncSchemaChange(ctx, fn); err != nil {
+			return err
+		}
+		desc.RemoveTrigger(id)
+	}
+	after, err := p.referencedTypeIDsInTable(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if removed := before.Difference(after); !removed.Empty() {
+		jobDesc := fmt.Sprintf("updating type back references %v for table %d", removed.Ordered(), desc.ID)
+		if err := p.removeTypeBackReferences(ctx, removed.Ordered(), desc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropTriggersReferencingColumn removes the triggers of the table that fire on
+// updates of the given column or whose WHEN condition references it. An error
+// is returned if there are any such triggers and the drop behavior is not
+// CASCADE.
+func (p *planner) dropTriggersReferencingColumn(
+	ctx context.Context, desc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	var ids []descpb.TriggerID
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		referenced, err := triggerReferencesColumn(desc, t, col.GetID())
+		if err != nil {
+			return err
+		}
+		if !referenced {
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop column %s because trigger %s on table %s depends on it",
+					col.GetName(), t.Name, desc.GetName()),
+				"Use DROP ... CASCADE to drop the dependent objects too.",
+			)
+		}
+		ids = append(ids, t.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return p.dropTriggers(ctx, desc, ids)
+}
+
+// triggerReferencesColumn returns whether the UPDATE OF column list or the
+// WHEN condition of the trigger references the given column.
+func triggerReferencesColumn(
+	desc catalog.TableDescriptor, t *descpb.TriggerDescriptor, colID descpb.ColumnID,
+) (bool, error) {
+	for _, id := range t.UpdateColumnIDs {
+		if id == colID {
+			return true, nil
+		}
+	}
+	if t.WhenExpr == "" {
+		return false, nil
+	}
+	expr, err := parser.ParseExpr(t.WhenExpr)
+	if err != nil {
+		return false, err
+	}
+	colIDs, err := schemaexpr.ExtractColumnIDs(desc, expr)
+	if err != nil {
+		return false, err
+	}
+	return colIDs.Contains(colID), nil
+}
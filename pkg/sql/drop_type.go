@@ -91,29 +91,83 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 				"cannot drop type %q because table %q requires it",
 				name, name,
 			)
+		case descpb.TypeDescriptor_DOMAIN:
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is not a type", name),
+				"Use DROP DOMAIN to remove a domain.",
+			)
 		}
 
-		// Check if we can drop the type.
-		if err := p.canDropTypeDesc(ctx, typeDesc, n.DropBehavior); err != nil {
+		if err := p.addTypeToDrop(ctx, node, typeDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
+	}
+	return node, nil
+}
 
-		// Get the array type that needs to be dropped as well.
-		mutArrayDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, typeDesc.ArrayTypeID)
+// DropDomain drops the given domains. Domains are dropped in the same way as
+// other user-defined types.
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	node := &dropTypeNode{
+		toDrop: make(map[descpb.ID]*typedesc.Mutable),
+	}
+	if n.DropBehavior == tree.DropCascade {
+		return nil, unimplemented.NewWithIssue(51480, "DROP DOMAIN CASCADE is not yet supported")
+	}
+	for _, name := range n.Names {
+		_, typeDesc, err := p.ResolveMutableTypeDescriptor(ctx, name, !n.IfExists)
 		if err != nil {
 			return nil, err
 		}
-		// Ensure that we can drop the array type as well.
-		if err := p.canDropTypeDesc(ctx, mutArrayDesc, n.DropBehavior); err != nil {
+		if typeDesc == nil {
+			continue
+		}
+		if _, ok := node.toDrop[typeDesc.ID]; ok {
+			continue
+		}
+		if typeDesc.Kind != descpb.TypeDescriptor_DOMAIN {
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name)
+		}
+		if err := p.addTypeToDrop(ctx, node, typeDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
-		// Record these descriptors for deletion.
-		node.toDrop[typeDesc.ID] = typeDesc
-		node.toDrop[mutArrayDesc.ID] = mutArrayDesc
 	}
 	return node, nil
 }
 
+// addTypeToDrop records the given type and its implicit array type for
+// deletion by the dropTypeNode, after checking that they can be dropped.
+func (p *planner) addTypeToDrop(
+	ctx context.Context, node *dropTypeNode, typeDesc *typedesc.Mutable, behavior tree.DropBehavior,
+) error {
+	// Check if we can drop the type.
+	if err := p.canDropTypeDesc(ctx, typeDesc, behavior); err != nil {
+		return err
+	}
+
+	// Get the array type that needs to be dropped as well.
+	mutArrayDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, typeDesc.ArrayTypeID)
+	if err != nil {
+		return err
+	}
+	// Ensure that we can drop the array type as well.
+	if err := p.canDropTypeDesc(ctx, mutArrayDesc, behavior); err != nil {
+		return err
+	}
+	// Record these descriptors for deletion.
+	node.toDrop[typeDesc.ID] = typeDesc
+	node.toDrop[mutArrayDesc.ID] = mutArrayDesc
+	return nil
+}
+
 func (p *planner) canDropTypeDesc(
 	ctx context.Context, desc *typedesc.Mutable, behavior tree.DropBehavior,
 ) error {
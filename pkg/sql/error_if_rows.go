@@ -26,6 +26,11 @@ type errorIfRowsNode struct {
 	// produced.
 	mkErr exec.MkErrFn
 
+	// deferrable is set if the wrapped node is the check query of a DEFERRABLE
+	// constraint. If the constraint is deferred, the rows are recorded to be
+	// revalidated when the transaction commits instead of causing an error.
+	deferrable *exec.DeferrableCheck
+
 	nexted bool
 }
 
@@ -39,14 +44,21 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	}
 	n.nexted = true
 
-	ok, err := n.plan.Next(params)
-	if err != nil {
-		return false, err
-	}
-	if ok {
-		return false, n.mkErr(n.plan.Values())
+	deferred := params.p.deferredConstraints.isDeferred(n.deferrable)
+	for {
+		ok, err := n.plan.Next(params)
+		if err != nil || !ok {
+			return false, err
+		}
+		if !deferred {
+			return false, n.mkErr(n.plan.Values())
+		}
+		if err := params.p.deferredConstraints.deferViolation(
+			n.deferrable, n.plan.Values(), n.mkErr,
+		); err != nil {
+			return false, err
+		}
 	}
-	return false, nil
 }
 
 func (n *errorIfRowsNode) Values() tree.Datums {
@@ -13,14 +13,22 @@ package sql
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
@@ -316,3 +324,149 @@ func convertFastIntSetToUint32Slice(colIdxs intsets.Fast) []uint32 {
 	}
 	return cols
 }
+
+// makeTableSampleSpec returns the TableSampleSpec of a table reader that
+// performs the given sampling, or nil if the scan is not sampled.
+func makeTableSampleSpec(sample opt.TableSample) *execinfrapb.TableSampleSpec {
+	if sample.Empty() {
+		return nil
+	}
+	spec := &execinfrapb.TableSampleSpec{
+		Method:     execinfrapb.TableSampleSpec_BERNOULLI,
+		Fraction:   sample.Fraction,
+		Repeatable: sample.Repeatable,
+		Seed:       sample.Seed,
+	}
+	if sample.Method == tree.TableSampleSystem {
+		spec.Method = execinfrapb.TableSampleSpec_SYSTEM
+	}
+	return spec
+}
+
+// pruneSpansWithSummaryIndex removes from the given spans of a scan of the
+// primary index the blocks of rows which, according to the summaries stored in
+// the BRIN index params.SummaryIndex, have no rows satisfying
+// params.SummaryConstraint. See rowenc.BRINTagUnsummarized for the layout of
+// BRIN indexes.
+func pruneSpansWithSummaryIndex(
+	ctx context.Context,
+	p *planner,
+	tabDesc catalog.TableDescriptor,
+	params exec.ScanParams,
+	spans roachpb.Spans,
+) (roachpb.Spans, error) {
+	brin := params.SummaryIndex.(*optIndex).idx
+	primary := tabDesc.GetPrimaryIndex()
+	// Only the summaries of public BRIN indexes are guaranteed to cover all the
+	// rows of their blocks.
+	if !brin.Public() || p.Txn() == nil || primary.NumKeyColumns() == 0 ||
+		primary.GetKeyColumnID(0) != brin.IndexDesc().BRINBlockColumnID() {
+		return spans, nil
+	}
+	col, err := catalog.MustFindColumnByID(tabDesc, brin.GetKeyColumnID(0))
+	if err != nil {
+		return nil, err
+	}
+	codec := p.ExecCfg().Codec
+	brinPrefix := roachpb.Key(rowenc.MakeIndexKeyPrefix(codec, tabDesc.GetID(), brin.GetID()))
+	kvs, err := p.Txn().Scan(ctx, brinPrefix, brinPrefix.PrefixEnd(), 0 /* maxRows */)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the summaries of the blocks. The entries are sorted by block, and
+	// the min and max entries of each block by value.
+	type blockSummary struct {
+		block                    int64
+		summarized, unsummarized bool
+		min, max                 tree.Datum
+	}
+	var summaries []blockSummary
+	var a tree.DatumAlloc
+	for i := range kvs {
+		block, tag, val, err := rowenc.DecodeBRINEntryKey(&a, brinPrefix, col.GetType(), kvs[i].Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(summaries) == 0 || summaries[len(summaries)-1].block != block {
+			summaries = append(summaries, blockSummary{block: block})
+		}
+		s := &summaries[len(summaries)-1]
+		switch tag {
+		case rowenc.BRINTagUnsummarized:
+			s.unsummarized = true
+		case rowenc.BRINTagSummarized:
+			s.summarized = true
+		case rowenc.BRINTagMin:
+			if s.min == nil {
+				s.min = val
+			}
+		case rowenc.BRINTagMax:
+			s.max = val
+		}
+	}
+
+	// Exclude the spans of the summarized blocks whose summaries don't
+	// intersect the constraint. Consecutive blocks are excluded with a single
+	// span.
+	dir := encoding.Ascending
+	if primary.GetKeyColumnDirection(0) == catenumpb.IndexColumn_DESC {
+		dir = encoding.Descending
+	}
+	pagesPerRange := brin.IndexDesc().BRINPagesPerRange()
+	primaryPrefix := rowenc.MakeIndexKeyPrefix(codec, tabDesc.GetID(), primary.GetID())
+	var excluded roachpb.Spans
+	firstBlock, lastBlock := int64(0), int64(-1)
+	flush := func() {
+		if firstBlock <= lastBlock {
+			excluded = append(excluded, rowenc.MakeBRINBlocksSpan(
+				primaryPrefix, dir, firstBlock, lastBlock, pagesPerRange,
+			))
+		}
+	}
+	for i := range summaries {
+		s := &summaries[i]
+		if !s.summarized || s.unsummarized {
+			continue
+		}
+		if s.min != nil || s.max != nil {
+			if s.min == nil || s.max == nil {
+				continue
+			}
+			var sp constraint.Span
+			sp.Init(
+				constraint.MakeKey(s.min), constraint.IncludeBoundary,
+				constraint.MakeKey(s.max), constraint.IncludeBoundary,
+			)
+			if params.SummaryConstraint.IntersectsSpan(p.EvalContext(), &sp) {
+				continue
+			}
+		}
+		if firstBlock <= lastBlock && lastBlock == s.block-1 {
+			lastBlock = s.block
+			continue
+		}
+		flush()
+		firstBlock, lastBlock = s.block, s.block
+	}
+	flush()
+	if len(excluded) == 0 {
+		return spans, nil
+	}
+
+	// SubtractSpans only handles spans with end keys, so point lookups are
+	// handled separately.
+	var pruned, ranged roachpb.Spans
+	for _, sp := range spans {
+		if len(sp.EndKey) == 0 {
+			if !excluded.ContainsKey(sp.Key) {
+				pruned = append(pruned, sp)
+			}
+			continue
+		}
+		ranged = append(ranged, sp)
+	}
+	pruned = append(pruned, roachpb.SubtractSpans(ranged, excluded)...)
+	sort.Sort(pruned)
+	return pruned, nil
+}
@@ -1993,8 +1993,21 @@ func checkResultType(typ *types.T) error {
 	case types.JsonFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
+	case types.CIDRFamily:
+	case types.MacAddrFamily:
+	case types.MacAddr8Family:
+	case types.PointFamily:
+	case types.LSegFamily:
+	case types.BoxFamily:
+	case types.PathFamily:
+	case types.PolygonFamily:
+	case types.LineFamily:
+	case types.CircleFamily:
+	case types.RangeFamily:
+	case types.MultiRangeFamily:
 	case types.OidFamily:
 	case types.PGLSNFamily:
+	case types.JsonpathFamily:
 	case types.TupleFamily:
 	case types.EnumFamily:
 	case types.VoidFamily:
@@ -39,6 +39,15 @@ func GetAggregateInfo(
 		}
 		return builtins.NewAnyNotNullAggregate, inputTypes[0], nil
 	}
+	if fn == execinfrapb.UserDefined {
+		// The definition of a user-defined aggregate is passed as its last
+		// input, and its type is the return type of the aggregate. The
+		// constructor must be obtained via GetAggregateConstructor.
+		if len(inputTypes) == 0 {
+			return nil, nil, errors.AssertionFailedf("user-defined aggregate needs its definition as input")
+		}
+		return nil, inputTypes[len(inputTypes)-1], nil
+	}
 
 	_, builtins := builtinsregistry.GetBuiltinProperties(strings.ToLower(fn.String()))
 	for _, b := range builtins {
@@ -81,6 +90,9 @@ func GetAggregateConstructor(
 	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
 	inputTypes []*types.T,
 ) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if aggInfo.Func == execinfrapb.UserDefined {
+		return getUserDefinedAggregateConstructor(aggInfo)
+	}
 	argTypes := make([]*types.T, len(aggInfo.ColIdx)+len(aggInfo.Arguments))
 	for j, c := range aggInfo.ColIdx {
 		if c >= uint32(len(inputTypes)) {
@@ -110,6 +122,29 @@ func GetAggregateConstructor(
 	return
 }
 
+// getUserDefinedAggregateConstructor processes the specification of a
+// user-defined aggregate function. The definition of the aggregate is passed as
+// its only argument, which can only be evaluated on the gateway.
+func getUserDefinedAggregateConstructor(
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if len(aggInfo.Arguments) != 1 {
+		return nil, nil, nil, errors.AssertionFailedf(
+			"expected a single argument for user-defined aggregate, found %d", len(aggInfo.Arguments),
+		)
+	}
+	def, ok := aggInfo.Arguments[0].LocalExpr.(*tree.UserDefinedAggregate)
+	if !ok {
+		return nil, nil, nil, errors.AssertionFailedf(
+			"user-defined aggregate can only be evaluated locally",
+		)
+	}
+	constructor = func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(evalCtx, def)
+	}
+	return constructor, nil /* arguments */, def.ResolvedType(), nil
+}
+
 // GetWindowFunctionInfo returns windowFunc constructor and the return type
 // when given fn is applied to given inputTypes.
 func GetWindowFunctionInfo(
@@ -0,0 +1,143 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+)
+
+// TableSampler implements the sampling of the rows returned by a table reader
+// (see execinfrapb.TableSampleSpec).
+type TableSampler struct {
+	spec execinfrapb.TableSampleSpec
+
+	// seed determines which blocks (for SYSTEM sampling) or rows (for BERNOULLI
+	// sampling) are selected.
+	seed int64
+}
+
+// NewTableSampler returns a TableSampler for the given spec, or nil if spec is
+// nil.
+func NewTableSampler(spec *execinfrapb.TableSampleSpec) *TableSampler {
+	if spec == nil {
+		return nil
+	}
+	s := &TableSampler{spec: *spec}
+	if spec.Repeatable {
+		s.seed = spec.Seed
+	} else {
+		s.seed = randutil.NewPseudoSeed()
+	}
+	return s
+}
+
+// SampleSpans returns the spans that need to be scanned. With SYSTEM sampling,
+// the spans are split into blocks at range boundaries and each block is
+// selected with the sampling probability; the blocks that are not selected are
+// never read. The given spans are returned unchanged with BERNOULLI sampling.
+//
+// Blocks are selected based on the start key of their range and the seed, so
+// REPEATABLE sampling selects the same blocks regardless of how the spans are
+// partitioned, as long as the range boundaries don't change. If ds is nil, each
+// span is treated as a single block.
+func (s *TableSampler) SampleSpans(
+	ctx context.Context, ds *kvcoord.DistSender, spans roachpb.Spans,
+) (roachpb.Spans, error) {
+	if s.spec.Method != execinfrapb.TableSampleSpec_SYSTEM {
+		return spans, nil
+	}
+	sampled := make(roachpb.Spans, 0, len(spans))
+	var ri kvcoord.RangeIterator
+	if ds != nil {
+		ri = kvcoord.MakeRangeIterator(ds)
+	}
+	for _, sp := range spans {
+		if ds == nil || len(sp.EndKey) == 0 {
+			if s.selected(sp.Key) {
+				sampled = append(sampled, sp)
+			}
+			continue
+		}
+		rSpan, err := keys.SpanAddr(sp)
+		if err != nil {
+			return nil, err
+		}
+		for ri.Seek(ctx, rSpan.Key, kvcoord.Ascending); ri.Valid(); ri.Next(ctx) {
+			desc := ri.Desc()
+			startKey := desc.StartKey.AsRawKey()
+			block := sp
+			if startKey.Compare(block.Key) > 0 {
+				block.Key = startKey
+			}
+			if endKey := desc.EndKey.AsRawKey(); endKey.Compare(block.EndKey) < 0 {
+				block.EndKey = endKey
+			}
+			if s.selected(startKey) {
+				sampled = append(sampled, block)
+			}
+			if !ri.NeedAnother(rSpan) {
+				break
+			}
+		}
+		if err := ri.Error(); err != nil {
+			return nil, err
+		}
+	}
+	return sampled, nil
+}
+
+// SelectRow returns whether the row with the given index key is part of the
+// sample. Only BERNOULLI sampling selects individual rows; all the rows of the
+// blocks selected by SYSTEM sampling are part of the sample.
+func (s *TableSampler) SelectRow(key roachpb.Key) bool {
+	if s.spec.Method != execinfrapb.TableSampleSpec_BERNOULLI {
+		return true
+	}
+	return s.selected(key)
+}
+
+// SelectsRows returns whether the sampler selects individual rows (see
+// SelectRow).
+func (s *TableSampler) SelectsRows() bool {
+	return s.spec.Method == execinfrapb.TableSampleSpec_BERNOULLI
+}
+
+// selected returns whether the block or row with the given key is selected.
+// The selection only depends on the key and the seed.
+func (s *TableSampler) selected(key roachpb.Key) bool {
+	if s.spec.Fraction >= 1 {
+		return true
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(s.seed))
+	h := fnv.New64a()
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(key)
+	// The high bits of FNV barely depend on the last bytes written, and
+	// consecutive keys usually differ only in their last bytes, so mix the
+	// bits of the hash before using it (this is the finalizer of MurmurHash3).
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return float64(x)/math.MaxUint64 < s.spec.Fraction
+}
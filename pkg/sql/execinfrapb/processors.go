@@ -59,6 +59,13 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 			return false
 		}
 	}
+	if a.Func == AggregatorSpec_USER_DEFINED {
+		// The definition of a user-defined aggregate is its only argument.
+		if len(a.Arguments) != 1 || len(b.Arguments) != 1 ||
+			a.Arguments[0].LocalExpr != b.Arguments[0].LocalExpr {
+			return false
+		}
+	}
 	return true
 }
 
@@ -52,12 +52,18 @@ func (p *planner) fillInPlaceholders(
 				return nil, err
 			}
 		}
+		// The value of a parameter of a domain type is typed as the base type of
+		// the domain, and then cast to the domain so that the constraints of the
+		// domain are checked.
 		typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
-			ctx, e, typ, "EXECUTE parameter" /* context */, p.SemaCtx(), volatility.Volatile, true, /*allowAssignmentCast*/
+			ctx, e, typ.DomainBaseType(), "EXECUTE parameter" /* context */, p.SemaCtx(), volatility.Volatile, true, /*allowAssignmentCast*/
 		)
 		if err != nil {
 			return nil, pgerror.WithCandidateCode(err, pgcode.WrongObjectType)
 		}
+		if typ.IsDomain() {
+			typedExpr = tree.NewTypedCastExpr(typedExpr, typ)
+		}
 
 		qArgs[idx] = typedExpr
 	}
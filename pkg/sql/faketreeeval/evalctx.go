@@ -317,6 +317,30 @@ func (*DummyEvalPlanner) RepairTTLScheduledJobForTable(ctx context.Context, tabl
 	return errors.WithStack(errEvalPlanner)
 }
 
+// NotifyChannel is part of the Planner interface.
+func (*DummyEvalPlanner) NotifyChannel(ctx context.Context, channel, payload string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// AcquireAdvisoryLock is part of the Planner interface.
+func (*DummyEvalPlanner) AcquireAdvisoryLock(
+	ctx context.Context, key eval.AdvisoryLockKey, shared, xact, try bool,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// ReleaseAdvisoryLock is part of the Planner interface.
+func (*DummyEvalPlanner) ReleaseAdvisoryLock(
+	ctx context.Context, key eval.AdvisoryLockKey, shared bool,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// ReleaseAllAdvisoryLocks is part of the Planner interface.
+func (*DummyEvalPlanner) ReleaseAllAdvisoryLocks(ctx context.Context) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 // Mon is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) Mon() *mon.BytesMonitor {
 	return ep.Monitor
@@ -411,6 +435,30 @@ func (ep *DummyEvalPlanner) RoutineExprGenerator(
 	return nil
 }
 
+// EvalTxnControlExpr is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) EvalTxnControlExpr(
+	ctx context.Context, expr *tree.TxnControlExpr, args tree.Datums,
+) (tree.Datum, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// PLpgSQLCloseCursor is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) PLpgSQLCloseCursor(name tree.Name) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// PLpgSQLFetchCursor is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) PLpgSQLFetchCursor(
+	ctx context.Context, cursor *tree.CursorStmt,
+) (tree.Datums, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// GenUniqueCursorName is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) GenUniqueCursorName() tree.Name {
+	return ""
+}
+
 // ResolveTypeByOID implements the tree.TypeReferenceResolver interface.
 func (ep *DummyEvalPlanner) ResolveTypeByOID(_ context.Context, _ oid.Oid) (*types.T, error) {
 	return nil, errors.WithStack(errEvalPlanner)
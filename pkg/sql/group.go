@@ -74,6 +74,8 @@ type aggregateFuncHolder struct {
 	arguments tree.Datums
 	// isDistinct indicates whether only distinct values are aggregated.
 	isDistinct bool
+	// userDefined is set if this is a user-defined aggregate.
+	userDefined *tree.UserDefinedAggregate
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
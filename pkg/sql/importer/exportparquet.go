@@ -170,6 +170,30 @@ func NewParquetColumn(typ *types.T, name string, nullable bool) (ParquetColumn,
 		col.DecodeFn = func(x interface{}) (tree.Datum, error) {
 			return tree.ParseDIPAddrFromINetString(string(x.([]byte)))
 		}
+	case types.CIDRFamily:
+		populateLogicalStringCol(schemaEl)
+		col.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return []byte(d.(*tree.DCIDR).IPAddr.CIDRString()), nil
+		}
+		col.DecodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.ParseDCIDR(string(x.([]byte)))
+		}
+	case types.MacAddrFamily:
+		populateLogicalStringCol(schemaEl)
+		col.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return []byte(d.(*tree.DMacAddr).MacAddr.String()), nil
+		}
+		col.DecodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.ParseDMacAddr(string(x.([]byte)))
+		}
+	case types.MacAddr8Family:
+		populateLogicalStringCol(schemaEl)
+		col.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return []byte(d.(*tree.DMacAddr8).MacAddr8.String()), nil
+		}
+		col.DecodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.ParseDMacAddr8(string(x.([]byte)))
+		}
 	case types.JsonFamily:
 		schemaEl.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
 		schemaEl.LogicalType = parquet.NewLogicalType()
@@ -490,6 +490,14 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					udtSchema = tree.NewDString(typeMetaName.Schema)
 				}
 
+				// The domain_* columns are set for columns of a domain type.
+				domainCatalog, domainSchema, domainName := tree.DNull, tree.DNull, tree.DNull
+				if column.GetType().IsDomain() && typeMetaName != nil {
+					domainCatalog = dbNameStr
+					domainSchema = tree.NewDString(typeMetaName.Schema)
+					domainName = tree.NewDString(typeMetaName.Name)
+				}
+
 				// Get the sequence option if it's an identity column.
 				identityStart := tree.DNull
 				identityIncrement := tree.DNull
@@ -530,9 +538,9 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					collationCatalog,                                          // collation_catalog
 					collationSchema,                                           // collation_schema
 					collationName,                                             // collation_name
-					tree.DNull,                                                // domain_catalog
-					tree.DNull,                                                // domain_schema
-					tree.DNull,                                                // domain_name
+					domainCatalog,                                             // domain_catalog
+					domainSchema,                                              // domain_schema
+					domainName,                                                // domain_name
 					dbNameStr,                                                 // udt_catalog
 					udtSchema,                                                 // udt_schema
 					tree.NewDString(column.GetType().PGName()), // udt_name
@@ -780,7 +788,8 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 					cols = refTable.ForeignKeyReferencedColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusion() {
+					// Like in Postgres, exclusion constraints are not included.
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -826,7 +835,8 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusion() {
+					// Like in Postgres, exclusion constraints are not included.
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1413,24 +1423,34 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.IsExclusion() {
+						// Like in Postgres, exclusion constraints are not included.
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
+					var deferrable, initiallyDeferred bool
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
-					} else if c.AsForeignKey() != nil {
+					} else if fk := c.AsForeignKey(); fk != nil {
 						kind = catconstants.ConstraintTypeFK
+						deferrable = fk.ForeignKeyDesc().Deferrable
+						initiallyDeferred = fk.ForeignKeyDesc().InitiallyDeferred
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
+					} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+						deferrable = uwoi.UniqueWithoutIndexDesc().Deferrable
+						initiallyDeferred = uwoi.UniqueWithoutIndexDesc().InitiallyDeferred
 					}
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(deferrable),        // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -2042,12 +2062,52 @@ var informationSchemaUsagePrivilegesTable = virtualSchemaTable{
 }
 
 var informationSchemaDomainsTable = virtualSchemaTable{
-	comment: "domains was created for compatibility and is currently unimplemented",
-	schema:  vtable.InformationSchemaDomains,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `domains
+https://www.postgresql.org/docs/9.5/infoschema-domains.html`,
+	schema: vtable.InformationSchemaDomains,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typeDesc catalog.TypeDescriptor) error {
+			d := typeDesc.AsDomainTypeDescriptor()
+			if d == nil {
+				return nil
+			}
+			baseType := d.GetBaseType()
+			domainDefault := tree.DNull
+			if expr, ok := d.GetDefaultExpr(); ok {
+				domainDefault = tree.NewDString(expr)
+			}
+			dbNameStr := tree.NewDString(db.GetName())
+			return addRow(
+				dbNameStr,                                         // domain_catalog
+				tree.NewDString(sc.GetName()),                     // domain_schema
+				tree.NewDString(d.GetName()),                      // domain_name
+				tree.NewDString(baseType.InformationSchemaName()), // data_type
+				characterMaximumLength(baseType),                  // character_maximum_length
+				characterOctetLength(baseType),                    // character_octet_length
+				tree.DNull,                                        // character_set_catalog
+				tree.DNull,                                        // character_set_schema
+				tree.DNull,                                        // character_set_name
+				tree.DNull,                                        // collation_catalog
+				tree.DNull,                                        // collation_schema
+				tree.DNull,                                        // collation_name
+				numericPrecision(baseType),                        // numeric_precision
+				numericPrecisionRadix(baseType),                   // numeric_precision_radix
+				numericScale(baseType),                            // numeric_scale
+				datetimePrecision(baseType),                       // datetime_precision
+				tree.DNull,                                        // interval_type
+				tree.DNull,                                        // interval_precision
+				domainDefault,                                     // domain_default
+				dbNameStr,                                         // udt_catalog
+				pgCatalogNameDString,                              // udt_schema
+				tree.NewDString(baseType.PGName()),                // udt_name
+				tree.DNull,                                        // scope_catalog
+				tree.DNull,                                        // scope_schema
+				tree.DNull,                                        // scope_name
+				tree.DNull,                                        // maximum_cardinality
+				tree.DNull,                                        // dtd_identifier
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaSQLImplementationInfoTable = virtualSchemaTable{
@@ -2831,6 +2891,11 @@ func (r roleOptions) createRole() (tree.DBool, error) {
 	return tree.DBool(createRole), err
 }
 
+func (r roleOptions) bypassRLS() (tree.DBool, error) {
+	bypassRLS, err := r.Exists("BYPASSRLS")
+	return tree.DBool(bypassRLS), err
+}
+
 func forEachRoleQuery(ctx context.Context, p *planner) string {
 	return `
 SELECT
@@ -0,0 +1,31 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	channel := string(n.ChannelName)
+	if err := pgnotify.CheckChannel(channel); err != nil {
+		return nil, err
+	}
+	if err := p.notifications.listen(channel); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+)
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if err := p.notify(string(n.ChannelName), n.Payload); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// NotifyChannel is part of the eval.Planner interface.
+func (p *planner) NotifyChannel(ctx context.Context, channel, payload string) error {
+	return p.notify(channel, payload)
+}
+
+func (p *planner) notify(channel, payload string) error {
+	if err := pgnotify.CheckChannel(channel); err != nil {
+		return err
+	}
+	if err := pgnotify.CheckPayload(payload); err != nil {
+		return err
+	}
+	return p.notifications.notify(channel, payload)
+}
+
+// txnNotifications holds the effects of the LISTEN, UNLISTEN and NOTIFY
+// statements executed in the current transaction. Following Postgres, they
+// only take effect when the transaction commits, and are discarded if it
+// rolls back.
+type txnNotifications struct {
+	// listenOps are the channel subscription changes, in execution order.
+	listenOps []listenOp
+	// pending are the notifications to publish, in execution order.
+	pending []pgnotify.Notification
+	// seen is used to fold identical notifications sent in the same
+	// transaction into one. It is lazily allocated.
+	seen map[pgnotify.Notification]struct{}
+}
+
+type listenOp struct {
+	// channel is empty if the op applies to all channels, as in UNLISTEN *.
+	channel  string
+	unlisten bool
+}
+
+// sessionNotifications provides access to the LISTEN/NOTIFY state of the
+// session from the planner.
+type sessionNotifications interface {
+	// listen subscribes the session to the given channel once the current
+	// transaction commits.
+	listen(channel string) error
+	// unlisten unsubscribes the session from the given channel, or from all
+	// channels if channel is empty, once the current transaction commits.
+	unlisten(channel string) error
+	// notify queues a notification to be published once the current
+	// transaction commits.
+	notify(channel, payload string) error
+}
+
+type connExNotificationsAccessor struct {
+	ex *connExecutor
+}
+
+func (c connExNotificationsAccessor) listen(channel string) error {
+	n := &c.ex.extraTxnState.notifications
+	n.listenOps = append(n.listenOps, listenOp{channel: channel})
+	return nil
+}
+
+func (c connExNotificationsAccessor) unlisten(channel string) error {
+	n := &c.ex.extraTxnState.notifications
+	n.listenOps = append(n.listenOps, listenOp{channel: channel, unlisten: true})
+	return nil
+}
+
+func (c connExNotificationsAccessor) notify(channel, payload string) error {
+	n := &c.ex.extraTxnState.notifications
+	notification := pgnotify.Notification{
+		Channel:   channel,
+		Payload:   payload,
+		SenderPID: c.ex.queryCancelKey.GetPGBackendPID(),
+	}
+	if n.seen == nil {
+		// Lazily allocate.
+		n.seen = make(map[pgnotify.Notification]struct{})
+	}
+	if _, ok := n.seen[notification]; ok {
+		return nil
+	}
+	n.seen[notification] = struct{}{}
+	n.pending = append(n.pending, notification)
+	return nil
+}
+
+// emptySessionNotifications is the default impl used by the planner when the
+// connExecutor is not available.
+type emptySessionNotifications struct{}
+
+func (emptySessionNotifications) listen(string) error {
+	return errors.AssertionFailedf("listen not supported in emptySessionNotifications")
+}
+
+func (emptySessionNotifications) unlisten(string) error {
+	return errors.AssertionFailedf("unlisten not supported in emptySessionNotifications")
+}
+
+func (emptySessionNotifications) notify(string, string) error {
+	return errors.AssertionFailedf("notify not supported in emptySessionNotifications")
+}
+
+// commitNotifications applies the LISTEN, UNLISTEN and NOTIFY statements of
+// the transaction that just committed. The notifications are published to
+// the sessions listening on this node right away, and written to
+// system.notifications to reach the sessions listening on the other nodes.
+func (ex *connExecutor) commitNotifications(ctx context.Context) {
+	n := &ex.extraTxnState.notifications
+	for _, op := range n.listenOps {
+		if ex.notificationListener == nil {
+			if op.unlisten {
+				continue
+			}
+			ex.notificationListener = ex.server.notifications.NewListener()
+		}
+		switch {
+		case !op.unlisten:
+			ex.notificationListener.Listen(op.channel)
+		case op.channel == "":
+			ex.notificationListener.UnlistenAll()
+		default:
+			ex.notificationListener.Unlisten(op.channel)
+		}
+	}
+	if len(n.pending) == 0 {
+		return
+	}
+	ex.server.notifications.Publish(n.pending)
+	cfg := ex.server.cfg
+	if !cfg.Settings.Version.IsActive(ctx, clusterversion.V23_2_NotificationsTable) {
+		return
+	}
+	// The transaction already committed, so the error cannot be returned to
+	// the client.
+	if err := pgnotify.Insert(
+		ctx, cfg.InternalDB, cfg.NodeInfo.NodeID.SQLInstanceID(), n.pending,
+	); err != nil {
+		log.Warningf(ctx, "failed to send notifications to the other nodes: %v", err)
+	}
+}
+
+// startNotificationsWatcher starts publishing to the registry of the node the
+// notifications sent by the sessions connected to the other nodes. This
+// requires system.notifications, so it waits for the cluster to be upgraded
+// to a version in which the table exists.
+func (s *Server) startNotificationsWatcher(ctx context.Context, stopper *stop.Stopper) {
+	cfg := s.cfg
+	if cfg.RangeFeedFactory == nil {
+		// Some test servers are not able to run rangefeeds.
+		return
+	}
+	_ = stopper.RunAsyncTask(ctx, "notifications-watcher", func(taskCtx context.Context) {
+		// The rangefeed and the GC task outlive this task, so they are started
+		// with the server's context, which is not canceled when this task
+		// returns.
+		taskCtx, cancel := stopper.WithCancelOnQuiesce(taskCtx)
+		defer cancel()
+		for r := retry.StartWithCtx(taskCtx, retry.Options{MaxBackoff: 10 * time.Second}); r.Next(); {
+			if !cfg.Settings.Version.IsActive(taskCtx, clusterversion.V23_2_NotificationsTable) {
+				continue
+			}
+			tableID, err := cfg.SystemTableIDResolver.LookupSystemTableID(
+				taskCtx, string(catconstants.NotificationsTableName),
+			)
+			if err == nil {
+				err = s.notifications.Watch(
+					ctx, stopper, cfg.RangeFeedFactory, cfg.InternalDB, cfg.Codec, tableID,
+					cfg.NodeInfo.NodeID.SQLInstanceID(),
+				)
+			}
+			if err != nil {
+				log.Warningf(taskCtx, "failed to watch notifications: %v", err)
+				continue
+			}
+			return
+		}
+	})
+}
+
+// bufferNotifications buffers the notifications received by the session on
+// the given result, so that they are sent to the client before it is told
+// that the server is ready for the next query.
+func (ex *connExecutor) bufferNotifications(res ResultBase) error {
+	if ex.notificationListener == nil {
+		return nil
+	}
+	syncRes, ok := res.(SyncResult)
+	if !ok {
+		return errors.AssertionFailedf("unexpected result type %T", res)
+	}
+	for _, n := range ex.notificationListener.Drain() {
+		syncRes.BufferNotification(n)
+	}
+	return nil
+}
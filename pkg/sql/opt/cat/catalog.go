@@ -169,6 +169,12 @@ type Catalog interface {
 	// the given catalog object. If not, then CheckPrivilege returns an error.
 	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
 
+	// CheckPrivilegeForUser verifies that the given user has the given
+	// privilege on the given catalog object. If not, then it returns an error.
+	CheckPrivilegeForUser(
+		ctx context.Context, o Object, priv privilege.Kind, user username.SQLUsername,
+	) error
+
 	// CheckAnyPrivilege verifies that the current user has any privilege on
 	// the given catalog object. If not, then CheckAnyPrivilege returns an error.
 	CheckAnyPrivilege(ctx context.Context, o Object) error
@@ -190,6 +196,23 @@ type Catalog interface {
 	// NOLOGIN instead of LOGIN.
 	HasRoleOption(ctx context.Context, roleOption roleoption.Option) (bool, error)
 
+	// HasRoleOptionForUser is like HasRoleOption, but checks whether the given
+	// user has the role option.
+	HasRoleOptionForUser(
+		ctx context.Context, user username.SQLUsername, roleOption roleoption.Option,
+	) (bool, error)
+
+	// HasOwnershipForUser returns true if the given user, or one of the roles
+	// it is a member of, owns the given catalog object.
+	HasOwnershipForUser(ctx context.Context, o Object, user username.SQLUsername) (bool, error)
+
+	// IsMemberOfRoleForUser returns true if the given user is the given role or
+	// is a member (direct or indirect) of it. Every user is a member of the
+	// public role.
+	IsMemberOfRoleForUser(
+		ctx context.Context, user username.SQLUsername, role username.SQLUsername,
+	) (bool, error)
+
 	// FullyQualifiedName retrieves the fully qualified name of a data source.
 	// Note that:
 	//  - this call may involve a database operation so it shouldn't be used in
@@ -56,6 +56,14 @@ type Index interface {
 	// IsInverted returns true if this is an inverted index.
 	IsInverted() bool
 
+	// IsBRIN returns true if this is a BRIN index. A BRIN index stores a
+	// summary of the indexed column for each block of rows of the table, and
+	// cannot be scanned. Instead, its summaries are used to prune the spans of
+	// scans of the primary index. Public BRIN indexes are presented as
+	// write-only indexes, so that they are maintained by mutations but are
+	// never considered as scan targets.
+	IsBRIN() bool
+
 	// GetInvisibility returns index invisibility.
 	GetInvisibility() float64
 
@@ -13,8 +13,10 @@ package cat
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -106,6 +108,29 @@ type Table interface {
 	// Check returns the ith check constraint, where i < CheckCount.
 	Check(i int) CheckConstraint
 
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table, in which case the policies of the table restrict the rows
+	// that can be read and written.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if the policies of the table also
+	// apply to the owner of the table.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// the table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy, where i < PolicyCount.
+	Policy(i int) Policy
+
+	// TriggerCount returns the number of triggers defined on the table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount. Triggers are
+	// ordered by name, which is the order in which they fire.
+	Trigger(i int) Trigger
+
 	// FamilyCount returns the number of column families present on the table.
 	// There is always at least one primary family (always family 0) where columns
 	// go if they are not explicitly assigned to another family. The primary
@@ -188,9 +213,92 @@ type Table interface {
 // ensures that only values greater than zero can be inserted into the table:
 //
 //	CREATE TABLE a (a INT CHECK (a > 0))
+//
+// If RowLevelSecurity is true, the check constraint is a placeholder for the
+// WITH CHECK expressions of the row-level security policies of the table,
+// which depend on the user and are built by the optimizer. Constraint is empty
+// in that case.
 type CheckConstraint struct {
-	Constraint string
-	Validated  bool
+	Constraint       string
+	Validated        bool
+	RowLevelSecurity bool
+}
+
+// Policy contains the definition of a row-level security policy on a table.
+// The USING expression of a policy filters the existing rows that can be read,
+// updated or deleted, and the WITH CHECK expression must hold for the rows
+// that are inserted or updated. For example, this policy restricts the rows
+// that can be accessed to the ones of the current tenant:
+//
+//	CREATE POLICY p ON t USING (tenant_id = current_setting('app.tenant_id')::INT)
+type Policy struct {
+	Name string
+
+	// Restrictive is true if the policy is combined with the other applicable
+	// policies using AND. Permissive policies are combined using OR.
+	Restrictive bool
+
+	// Command is the command the policy applies to. It is never
+	// tree.PolicyCommandDefault.
+	Command tree.PolicyCommand
+
+	// Roles are the roles the policy applies to.
+	Roles []username.SQLUsername
+
+	// UsingExpr and WithCheckExpr are the SQL text of the USING and WITH CHECK
+	// expressions of the policy, or the empty string if they were not
+	// specified.
+	UsingExpr     string
+	WithCheckExpr string
+}
+
+// AppliesToCommand returns true if the policy applies to the given command.
+func (p *Policy) AppliesToCommand(cmd tree.PolicyCommand) bool {
+	return p.Command == tree.PolicyCommandAll || p.Command == cmd
+}
+
+// Trigger contains the definition of a row-level trigger on a table. The
+// trigger function is invoked for each row that is inserted, updated or
+// deleted by an event the trigger fires on, either before or after the row is
+// written. For example:
+//
+//	CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW EXECUTE FUNCTION f()
+type Trigger struct {
+	Name string
+
+	// ActionTime is either tree.TriggerActionTimeBefore or
+	// tree.TriggerActionTimeAfter.
+	ActionTime tree.TriggerActionTime
+
+	// Events are the events the trigger fires on.
+	Events []tree.TriggerEventType
+
+	// UpdateColumnOrdinals are the ordinals of the columns listed in an
+	// UPDATE OF clause. If it is non-empty, the trigger only fires on updates
+	// that target one of the columns.
+	UpdateColumnOrdinals []int
+
+	// WhenExpr is the SQL text of the WHEN condition of the trigger, or the
+	// empty string if the trigger is unconditional. It refers to the new and
+	// old rows as new.<column> and old.<column>.
+	WhenExpr string
+
+	// FuncID is the ID of the trigger function.
+	FuncID StableID
+
+	// FuncArgs are the arguments that are passed to the trigger function in
+	// TG_ARGV.
+	FuncArgs []string
+}
+
+// FiresOn returns true if the trigger fires on the given event.
+func (t *Trigger) FiresOn(event tree.TriggerEventType) bool {
+	for _, e := range t.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
 }
 
 // TableStatistic is an interface to a table statistic. Each statistic is
@@ -232,6 +340,11 @@ type TableStatistic interface {
 	// inverted index histograms, this will always return types.Bytes.
 	HistogramType() *types.T
 
+	// Dependencies returns the functional dependencies between the columns of
+	// the statistic. It is only used for multi-column stats created with the
+	// "dependencies" statistics kind, and is empty otherwise.
+	Dependencies() []ColumnDependency
+
 	// IsPartial returns true if this statistic was collected with a where
 	// clause. (If the where clause was something like "WHERE 1 = 1" or "WHERE
 	// true" this could technically be a full statistic rather than a partial
@@ -270,6 +383,15 @@ type HistogramBucket struct {
 	UpperBound tree.Datum
 }
 
+// ColumnDependency describes a soft functional dependency between two columns
+// of a multi-column statistic: for the fraction Degree of the rows, the value
+// of the From column determines the value of the To column. From and To are
+// indexes into the statistic's columns (see TableStatistic.ColumnOrdinal).
+type ColumnDependency struct {
+	From, To int
+	Degree   float64
+}
+
 // ForeignKeyConstraint represents a foreign key constraint. A foreign key
 // constraint has an origin (or referencing) side and a referenced side. For
 // example:
@@ -318,6 +440,14 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrable is true if checking of the constraint can be postponed until
+	// the end of the transaction with SET CONSTRAINTS.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if checking of the constraint is postponed
+	// until the end of the transaction by default.
+	InitiallyDeferred() bool
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -359,12 +489,32 @@ type UniqueConstraint interface {
 	// needs to be enforced on new mutations.
 	Validated() bool
 
+	// Deferrable is true if checking of the constraint can be postponed until
+	// the end of the transaction with SET CONSTRAINTS.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if checking of the constraint is postponed
+	// until the end of the transaction by default.
+	InitiallyDeferred() bool
+
 	// UniquenessGuaranteedByAnotherIndex returns true when WithoutIndex() returns
 	// true and the uniqueness of the constraint is guaranteed by another index.
 	// When true, the optimizer will always consider the constraint to be
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// IsExclusion is true if this is an exclusion constraint, created with
+	// EXCLUDE. An exclusion constraint generalizes a unique constraint: no two
+	// rows may have values in the constraint columns that satisfy the
+	// constraint operator of every column. The columns of an exclusion
+	// constraint do not form a key, and the constraint is always enforced
+	// without an index.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator of the ith column of an exclusion
+	// constraint.
+	ExclusionOperator(i int) treecmp.ComparisonOperator
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
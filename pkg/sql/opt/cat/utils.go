@@ -82,6 +82,9 @@ func FormatTable(cat Catalog, tab Table, tp treeprinter.Node, redactableValues b
 	}
 
 	for i := 0; i < tab.CheckCount(); i++ {
+		if tab.Check(i).RowLevelSecurity {
+			continue
+		}
 		child.Childf("CHECK (%s)", MaybeMarkRedactable(tab.Check(i).Constraint, redactableValues))
 	}
 
@@ -103,11 +106,16 @@ func FormatTable(cat Catalog, tab Table, tp treeprinter.Node, redactableValues b
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
-		c := child.Childf(
-			"UNIQUE %s%s",
-			withoutIndexStr,
-			formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
-		)
+		var c treeprinter.Node
+		if uniq.IsExclusion() {
+			c = child.Childf("EXCLUDE %s", formatExclusionCols(tab, uniq))
+		} else {
+			c = child.Childf(
+				"UNIQUE %s%s",
+				withoutIndexStr,
+				formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
+			)
+		}
 		if pred, isPartial := uniq.Predicate(); isPartial {
 			c.Childf("WHERE %s", MaybeMarkRedactable(pred, redactableValues))
 		}
@@ -127,9 +135,11 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node, redactableValue
 		idxType = "UNIQUE "
 	} else if idx.IsInverted() {
 		idxType = "INVERTED "
+	} else if idx.IsBRIN() {
+		idxType = "BRIN "
 	}
 	mutation := ""
-	if IsMutationIndex(tab, ord) {
+	if IsMutationIndex(tab, ord) && !idx.IsBRIN() {
 		mutation = " (mutation)"
 	}
 
@@ -207,6 +217,23 @@ func formatCols(tab Table, numCols int, colOrdinal func(tab Table, i int) int) s
 	return buf.String()
 }
 
+// formatExclusionCols formats the columns of an exclusion constraint together
+// with their operators.
+func formatExclusionCols(tab Table, uniq UniqueConstraint) string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	for i, n := 0, uniq.ColumnCount(); i < n; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		colName := tab.Column(uniq.ColumnOrdinal(tab, i)).ColName()
+		fmt.Fprintf(&buf, "%s WITH %s", colName.String(), uniq.ExclusionOperator(i))
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
 // formatCatalogFKRef nicely formats a catalog foreign key reference using a
 // treeprinter for debugging and testing.
 func formatCatalogFKRef(
@@ -154,8 +154,9 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 // setupCascade fills in an exec.Cascade struct for the given cascade.
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
 	return exec.Cascade{
-		FKName: cascade.FKName,
-		Buffer: cb.mutationBuffer,
+		FKName:    cascade.FKName,
+		IsTrigger: cascade.IsTrigger,
+		Buffer:    cb.mutationBuffer,
 		PlanFn: func(
 			ctx context.Context,
 			semaCtx *tree.SemaContext,
@@ -272,7 +273,18 @@ func (cb *cascadeBuilder) planCascade(
 		}
 	}
 
-	o.Memo().SetRoot(relExpr, &physical.Required{})
+	var required physical.Required
+	if cascade.IsTrigger {
+		// The trigger functions are called in projections of the query, which
+		// must be part of the presentation so that they are not pruned.
+		relExpr.Relational().OutputCols.ForEach(func(col opt.ColumnID) {
+			required.Presentation = append(required.Presentation, opt.AliasedColumn{
+				Alias: md.ColumnMeta(col).Alias,
+				ID:    col,
+			})
+		})
+	}
+	o.Memo().SetRoot(relExpr, &required)
 
 	// 3. Assign placeholders if they exist.
 	if factory.Memo().HasPlaceholders() {
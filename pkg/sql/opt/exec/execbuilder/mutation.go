@@ -127,6 +127,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -163,6 +167,12 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
+	// We cannot use the fast path if there are AFTER triggers, which need the
+	// buffered input of the insert.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
 	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
@@ -176,6 +186,15 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrable() {
+			// The fast path cannot postpone the check to the end of the
+			// transaction.
+			return execPlan{}, false, nil
+		}
+		if fk.MatchMethod() == tree.MatchPartial {
+			// MATCH PARTIAL checks cannot be performed using direct lookups.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -423,7 +442,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
 	cnt := len(ups.InsertCols) + len(ups.FetchCols) + len(ups.UpdateCols) + len(ups.CheckCols) +
-		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 1
+		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 2
 	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, ups.InsertCols)
 	colList = appendColsWhenPresent(colList, ups.FetchCols)
@@ -431,6 +450,9 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	if ups.CanaryCol != 0 {
 		colList = append(colList, ups.CanaryCol)
 	}
+	if ups.DeleteCol != 0 {
+		colList = append(colList, ups.DeleteCol)
+	}
 	colList = appendColsWhenPresent(colList, ups.CheckCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexPutCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexDelCols)
@@ -450,6 +472,13 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 			return execPlan{}, err
 		}
 	}
+	deleteCol := exec.NodeColumnOrdinal(-1)
+	if ups.DeleteCol != 0 {
+		deleteCol, err = input.getNodeColumnOrdinal(ups.DeleteCol)
+		if err != nil {
+			return execPlan{}, err
+		}
+	}
 	insertColOrds := ordinalSetFromColList(ups.InsertCols)
 	fetchColOrds := ordinalSetFromColList(ups.FetchCols)
 	updateColOrds := ordinalSetFromColList(ups.UpdateCols)
@@ -461,6 +490,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		ups.ArbiterIndexes,
 		ups.ArbiterConstraints,
 		canaryCol,
+		deleteCol,
 		insertColOrds,
 		fetchColOrds,
 		updateColOrds,
@@ -736,7 +766,17 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var deferrable *exec.DeferrableCheck
+		tab := md.Table(c.Table)
+		if uc := tab.Unique(c.CheckOrdinal); uc.Deferrable() {
+			deferrable, err = b.makeDeferrableCheck(
+				tab, uc.Name(), uc.InitiallyDeferred(), query, c.KeyCols,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -766,7 +806,16 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var deferrable *exec.DeferrableCheck
+		if fk, ok := fkCheckDeferrable(md, c); ok {
+			deferrable, err = b.makeDeferrableCheck(
+				md.Table(c.OriginTable), fk.Name(), fk.InitiallyDeferred(), query, c.KeyCols,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -775,6 +824,49 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// fkCheckDeferrable returns the foreign key enforced by the given check and
+// true if violations found by the check can be deferred to the end of the
+// transaction. Following Postgres, the checks for removed values of the
+// referenced table can only be deferred if the referential action is NO
+// ACTION; RESTRICT is always checked immediately.
+func fkCheckDeferrable(md *opt.Metadata, c *memo.FKChecksItem) (cat.ForeignKeyConstraint, bool) {
+	if c.FKOutbound {
+		fk := md.Table(c.OriginTable).OutboundForeignKey(c.FKOrdinal)
+		return fk, fk.Deferrable()
+	}
+	fk := md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+	if !fk.Deferrable() {
+		return nil, false
+	}
+	action := fk.UpdateReferenceAction()
+	if c.OpName == "delete" {
+		action = fk.DeleteReferenceAction()
+	}
+	return fk, action == tree.NoAction
+}
+
+// makeDeferrableCheck returns the description of the check query of a
+// DEFERRABLE constraint defined on the given table. The keyCols are the
+// columns of the check query holding the values of the constraint columns.
+func (b *Builder) makeDeferrableCheck(
+	tab cat.Table, name string, initiallyDeferred bool, query execPlan, keyCols opt.ColList,
+) (*exec.DeferrableCheck, error) {
+	ords := make([]exec.NodeColumnOrdinal, len(keyCols))
+	for i, col := range keyCols {
+		ord, err := query.getNodeColumnOrdinal(col)
+		if err != nil {
+			return nil, err
+		}
+		ords[i] = ord
+	}
+	return &exec.DeferrableCheck{
+		TableID:           tab.ID(),
+		ConstraintName:    name,
+		InitiallyDeferred: initiallyDeferred,
+		KeyCols:           ords,
+	}, nil
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
@@ -787,7 +879,17 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	// Generate an error of the form:
 	//   ERROR:  duplicate key value violates unique constraint "foo"
 	//   DETAIL: Key (k)=(2) already exists.
-	msg.WriteString("duplicate key value violates unique constraint ")
+	//
+	// or, for exclusion constraints:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with existing key.
+	code := pgcode.UniqueViolation
+	if uc.IsExclusion() {
+		code = pgcode.ExclusionViolation
+		msg.WriteString("conflicting key value violates exclusion constraint ")
+	} else {
+		msg.WriteString("duplicate key value violates unique constraint ")
+	}
 	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
 
 	details.WriteString("Key (")
@@ -806,11 +908,15 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 		details.WriteString(d.String())
 	}
 
-	details.WriteString(") already exists.")
+	if uc.IsExclusion() {
+		details.WriteString(") conflicts with existing key.")
+	} else {
+		details.WriteString(") already exists.")
+	}
 
 	return errors.WithDetail(
 		pgerror.WithConstraintName(
-			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
+			pgerror.Newf(code, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
@@ -266,6 +266,11 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.Max1RowExpr:
 		ep, err = b.buildMax1Row(t)
 
+	case *memo.BarrierExpr:
+		// A Barrier only restricts the optimizer and returns the rows of its
+		// input unchanged.
+		ep, err = b.buildRelational(t.Input)
+
 	case *memo.ProjectSetExpr:
 		ep, err = b.buildProjectSet(t)
 
@@ -299,6 +304,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateFunctionExpr:
 		ep, err = b.buildCreateFunction(t)
 
+	case *memo.CallExpr:
+		ep, err = b.buildCall(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -710,15 +718,27 @@ func (b *Builder) scanParams(
 		return exec.ScanParams{}, opt.ColMap{}, errors.AssertionFailedf("scan can't provide required ordering")
 	}
 
+	// Bounded staleness queries must touch at most one range, so they can't
+	// read the summaries of a BRIN index.
+	var summaryIndex cat.Index
+	var summaryConstraint *constraint.Constraint
+	if scan.SummaryConstraint != nil && !b.boundedStaleness() {
+		summaryIndex = tab.Index(scan.SummaryIndex)
+		summaryConstraint = scan.SummaryConstraint
+	}
+
 	return exec.ScanParams{
 		NeededCols:         needed,
 		IndexConstraint:    scan.Constraint,
 		InvertedConstraint: scan.InvertedConstraint,
+		SummaryIndex:       summaryIndex,
+		SummaryConstraint:  summaryConstraint,
 		HardLimit:          hardLimit,
 		SoftLimit:          softLimit,
 		Reverse:            reverse,
 		Parallelize:        parallelize,
 		Locking:            locking,
+		Sample:             scan.Sample,
 		EstimatedRowCount:  rowCount,
 		LocalityOptimized:  scan.LocalityOptimized,
 	}, outputMap, nil
@@ -1571,7 +1591,14 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
-		name, _ := memo.FindAggregateOverload(agg)
+		var name string
+		var userDefined *tree.UserDefinedAggregate
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			name = uda.Name
+			userDefined = b.buildUserDefinedAggregate(uda)
+		} else {
+			name, _ = memo.FindAggregateOverload(agg)
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
@@ -1597,12 +1624,13 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 		}
 
 		aggInfos[i] = exec.AggInfo{
-			FuncName:   name,
-			Distinct:   distinct,
-			ResultType: item.Agg.DataType(),
-			ArgCols:    argCols,
-			ConstArgs:  constArgs,
-			Filter:     filterOrd,
+			FuncName:    name,
+			Distinct:    distinct,
+			ResultType:  item.Agg.DataType(),
+			ArgCols:     argCols,
+			ConstArgs:   constArgs,
+			Filter:      filterOrd,
+			UserDefined: userDefined,
 		}
 		ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
 	}
@@ -3598,6 +3626,7 @@ var boundedStalenessAllowList = map[opt.Operator]struct{}{
 	opt.SortOp:             {},
 	opt.OrdinalityOp:       {},
 	opt.Max1RowOp:          {},
+	opt.BarrierOp:          {},
 	opt.ProjectSetOp:       {},
 	opt.WindowOp:           {},
 	opt.ExplainOp:          {},
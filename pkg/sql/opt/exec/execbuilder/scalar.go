@@ -82,7 +82,8 @@ func init() {
 		opt.SubqueryOp: (*Builder).buildSubquery,
 
 		// User-defined functions.
-		opt.UDFCallOp: (*Builder).buildUDF,
+		opt.UDFCallOp:    (*Builder).buildUDF,
+		opt.TxnControlOp: (*Builder).buildTxnControl,
 	}
 
 	for _, op := range opt.BoolOperators {
@@ -702,6 +703,10 @@ func (b *Builder) buildExistsSubquery(
 				false, /* generator */
 				false, /* tailCall */
 				nil,   /* exceptionHandler */
+				nil,   /* cursorDeclaration */
+				0,     /* resultBufferID */
+				0,     /* returnRows */
+				nil,   /* sessionOverrides */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -819,6 +824,10 @@ func (b *Builder) buildSubquery(
 			false, /* generator */
 			false, /* tailCall */
 			nil,   /* exceptionHandler */
+			nil,   /* cursorDeclaration */
+			0,     /* resultBufferID */
+			0,     /* returnRows */
+			nil,   /* sessionOverrides */
 		), nil
 	}
 
@@ -875,6 +884,10 @@ func (b *Builder) buildSubquery(
 			false, /* generator */
 			false, /* tailCall */
 			nil,   /* exceptionHandler */
+			nil,   /* cursorDeclaration */
+			0,     /* resultBufferID */
+			0,     /* returnRows */
+			nil,   /* sessionOverrides */
 		), nil
 	}
 
@@ -962,37 +975,7 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	enableStepping := udf.Def.Volatility == volatility.Volatile
 
 	// Build each routine for the exception handler, if one exists.
-	var exceptionHandler *tree.RoutineExceptionHandler
-	if udf.Def.ExceptionBlock != nil {
-		block := udf.Def.ExceptionBlock
-		exceptionHandler = &tree.RoutineExceptionHandler{
-			Codes:   block.Codes,
-			Actions: make([]*tree.RoutineExpr, len(block.Actions)),
-		}
-		for i, action := range block.Actions {
-			actionPlanGen := b.buildRoutinePlanGenerator(
-				action.Params,
-				action.Body,
-				action.BodyProps,
-				false, /* allowOuterWithRefs */
-				nil,   /* wrapRootExpr */
-			)
-			// Build a routine with no arguments for the exception handler. The actual
-			// arguments will be supplied when (if) the handler is invoked.
-			exceptionHandler.Actions[i] = tree.NewTypedRoutineExpr(
-				action.Name,
-				nil, /* args */
-				actionPlanGen,
-				action.Typ,
-				true, /* enableStepping */
-				action.CalledOnNullInput,
-				action.MultiColDataSource,
-				action.SetReturning,
-				false, /* tailCall */
-				nil,   /* exceptionHandler */
-			)
-		}
-	}
+	exceptionHandler := b.buildExceptionHandler(udf.Def.ExceptionBlock)
 
 	return tree.NewTypedRoutineExpr(
 		udf.Def.Name,
@@ -1005,9 +988,182 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.SetReturning,
 		udf.TailCall,
 		exceptionHandler,
+		udf.Def.CursorDeclaration,
+		udf.Def.ResultBufferID,
+		udf.Def.ReturnRows,
+		udf.Def.SessionOverrides,
 	), nil
 }
 
+// buildExceptionHandler builds a routine for each action of the given
+// exception block. It returns nil if the block is nil.
+func (b *Builder) buildExceptionHandler(
+	block *memo.ExceptionBlock,
+) *tree.RoutineExceptionHandler {
+	if block == nil {
+		return nil
+	}
+	exceptionHandler := &tree.RoutineExceptionHandler{
+		Codes:   block.Codes,
+		Actions: make([]*tree.RoutineExpr, len(block.Actions)),
+	}
+	for i, action := range block.Actions {
+		actionPlanGen := b.buildRoutinePlanGenerator(
+			action.Params,
+			action.Body,
+			action.BodyProps,
+			false, /* allowOuterWithRefs */
+			nil,   /* wrapRootExpr */
+		)
+		// Build a routine with no arguments for the exception handler. The actual
+		// arguments will be supplied when (if) the handler is invoked.
+		exceptionHandler.Actions[i] = tree.NewTypedRoutineExpr(
+			action.Name,
+			nil, /* args */
+			actionPlanGen,
+			action.Typ,
+			true, /* enableStepping */
+			action.CalledOnNullInput,
+			action.MultiColDataSource,
+			action.SetReturning,
+			false, /* tailCall */
+			nil,   /* exceptionHandler */
+			action.CursorDeclaration,
+			action.ResultBufferID,
+			action.ReturnRows,
+			action.SessionOverrides,
+		)
+	}
+	return exceptionHandler
+}
+
+// buildUserDefinedAggregate builds a UserDefinedAgg expression into a
+// tree.UserDefinedAggregate that can be evaluated by an aggregator.
+func (b *Builder) buildUserDefinedAggregate(
+	uda *memo.UserDefinedAggExpr,
+) *tree.UserDefinedAggregate {
+	res := &tree.UserDefinedAggregate{
+		Name:      uda.Name,
+		StateFunc: b.buildAggregateSupportRoutine(uda.Def.StateFunc),
+		StateType: uda.Def.StateType,
+		Typ:       uda.Typ,
+		InitCond:  uda.Def.InitCond,
+		NumArgs:   uda.Def.NumArgs,
+	}
+	if uda.Def.FinalFunc != nil {
+		res.FinalFunc = b.buildAggregateSupportRoutine(uda.Def.FinalFunc)
+	}
+	if uda.Def.CombineFunc != nil {
+		res.CombineFunc = b.buildAggregateSupportRoutine(uda.Def.CombineFunc)
+	}
+	return res
+}
+
+// buildAggregateSupportRoutine builds a routine with no arguments for a
+// support function of a user-defined aggregate. The actual arguments are
+// supplied by the aggregator each time the routine is invoked.
+func (b *Builder) buildAggregateSupportRoutine(def *memo.UDFDefinition) *tree.RoutineExpr {
+	for _, s := range def.Body {
+		if s.Relational().CanMutate {
+			b.ContainsMutation = true
+			break
+		}
+	}
+	planGen := b.buildRoutinePlanGenerator(
+		def.Params,
+		def.Body,
+		def.BodyProps,
+		false, /* allowOuterWithRefs */
+		nil,   /* wrapRootExpr */
+	)
+	return tree.NewTypedRoutineExpr(
+		def.Name,
+		nil, /* args */
+		planGen,
+		def.Typ,
+		def.Volatility == volatility.Volatile, /* enableStepping */
+		def.CalledOnNullInput,
+		def.MultiColDataSource,
+		def.SetReturning,
+		false, /* tailCall */
+		b.buildExceptionHandler(def.ExceptionBlock),
+		def.CursorDeclaration,
+		def.ResultBufferID,
+		def.ReturnRows,
+		def.SessionOverrides,
+	)
+}
+
+// buildTxnControl builds a TxnControl expression into a typed expression that
+// can be evaluated.
+func (b *Builder) buildTxnControl(
+	ctx *buildScalarCtx, scalar opt.ScalarExpr,
+) (tree.TypedExpr, error) {
+	txnExpr := scalar.(*memo.TxnControlExpr)
+	if txnExpr.Def == nil {
+		return nil, errors.AssertionFailedf("expected non-nil continuation definition")
+	}
+
+	// Build the argument expressions.
+	var err error
+	args := make(tree.TypedExprs, len(txnExpr.Args))
+	argTypes := make([]*types.T, len(txnExpr.Args))
+	for i := range txnExpr.Args {
+		args[i], err = b.buildScalar(ctx, txnExpr.Args[i])
+		if err != nil {
+			return nil, err
+		}
+		argTypes[i] = txnExpr.Args[i].DataType()
+	}
+
+	// The continuation is built as a CALL statement that invokes the routine
+	// modeling the remainder of the procedure, with the current values of the
+	// procedure's variables as arguments. The body of the continuation routine
+	// is not part of the new memo; like any other routine, it is copied into a
+	// separate memo each time it is invoked.
+	gen := func(ctx context.Context, args tree.Datums) (_ tree.StoredProcContinuation, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				// See the comment in buildRoutinePlanGenerator.
+				if ok, e := errorutil.ShouldCatch(r); ok {
+					err = e
+				} else {
+					panic(r)
+				}
+			}
+		}()
+		var o xform.Optimizer
+		o.Init(ctx, b.evalCtx, b.catalog)
+		f := o.Factory()
+		argExprs := make(memo.ScalarListExpr, len(args))
+		for i := range args {
+			argExprs[i] = f.ConstructConstVal(args[i], argTypes[i])
+		}
+		udf := f.ConstructUDFCall(argExprs, &memo.UDFCallPrivate{Def: txnExpr.Def})
+
+		// Synthesize an output column for each OUT and INOUT parameter.
+		var cols opt.ColList
+		var presentation physical.Presentation
+		if typ := txnExpr.Def.Typ; typ.Family() == types.TupleFamily {
+			for i, colTyp := range typ.TupleContents() {
+				col := f.Metadata().AddColumn(typ.TupleLabels()[i], colTyp)
+				cols = append(cols, col)
+				presentation = append(presentation, opt.AliasedColumn{
+					Alias: typ.TupleLabels()[i],
+					ID:    col,
+				})
+			}
+		}
+		call := f.ConstructCall(udf, &memo.CallPrivate{Columns: cols})
+		f.Memo().SetRoot(call, &physical.Required{Presentation: presentation})
+		if _, err := o.Optimize(); err != nil {
+			return nil, err
+		}
+		return f.Memo(), nil
+	}
+	return tree.NewTxnControlExpr(txnExpr.TxnOp, args, gen, txnExpr.Typ), nil
+}
+
 type wrapRootExprFn func(f *norm.Factory, e memo.RelExpr) opt.Expr
 
 // buildRoutinePlanGenerator returns a tree.RoutinePlanFn that can plan the
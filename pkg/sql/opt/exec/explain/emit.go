@@ -107,8 +107,13 @@ func Emit(plan *Plan, ob *OutputBuilder, spanFormatFn SpanFormatFn) error {
 	}
 
 	for i := range plan.Cascades {
-		ob.EnterMetaNode("fk-cascade")
-		ob.Attr("fk", plan.Cascades[i].FKName)
+		if plan.Cascades[i].IsTrigger {
+			ob.EnterMetaNode("after-trigger")
+			ob.Attr("trigger", plan.Cascades[i].FKName)
+		} else {
+			ob.EnterMetaNode("fk-cascade")
+			ob.Attr("fk", plan.Cascades[i].FKName)
+		}
 		if buffer := plan.Cascades[i].Buffer; buffer != nil {
 			ob.Attr("input", buffer.(*Node).args.(*bufferArgs).Label)
 		}
@@ -294,6 +299,7 @@ var nodeNames = [...]string{
 	alterTableUnsplitOp:    "unsplit",
 	applyJoinOp:            "", // This node does not have a fixed name.
 	bufferOp:               "buffer",
+	callOp:                 "call",
 	cancelQueriesOp:        "cancel queries",
 	cancelSessionsOp:       "cancel sessions",
 	controlJobsOp:          "control jobs",
@@ -590,6 +596,9 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if a.Table != nil && !(a.Table.IsVirtualTable() && a.Params.IndexConstraint == nil) {
 			e.emitSpans("spans", a.Table, a.Index, a.Params)
 		}
+		if a.Params.SummaryIndex != nil {
+			ob.Attr("summary index", a.Params.SummaryIndex.Name())
+		}
 
 		if a.Params.HardLimit > 0 {
 			ob.Attr("limit", a.Params.HardLimit)
@@ -597,6 +606,10 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			ob.Attr("limit", "")
 		}
 
+		if !a.Params.Sample.Empty() {
+			ob.Attr("sample", a.Params.Sample.String())
+		}
+
 		if a.Params.Parallelize {
 			ob.VAttr("parallel", "")
 		}
@@ -936,6 +949,10 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		}
 		e.emitSpans("spans", a.Table, a.Table.Index(cat.PrimaryIndex), params)
 
+	case callOp:
+		a := n.args.(*callArgs)
+		ob.Attr("procedure", a.Proc.Name)
+
 	case showCompletionsOp:
 		a := n.args.(*showCompletionsArgs)
 		if a.Command != nil {
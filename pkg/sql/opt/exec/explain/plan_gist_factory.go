@@ -36,7 +36,7 @@ import (
 )
 
 func init() {
-	if numOperators != 61 {
+	if numOperators != 62 {
 		// This error occurs when an operator has been added or removed in
 		// pkg/sql/opt/exec/explain/factory.opt. If an operator is added at the
 		// end of factory.opt, simply adjust the hardcoded value above. If an
@@ -546,6 +546,30 @@ func (u *unknownTable) Check(i int) cat.CheckConstraint {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+func (u *unknownTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+func (u *unknownTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+func (u *unknownTable) PolicyCount() int {
+	return 0
+}
+
+func (u *unknownTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 func (u *unknownTable) FamilyCount() int {
 	return 0
 }
@@ -656,6 +680,10 @@ func (u *unknownIndex) IsInverted() bool {
 	return false
 }
 
+func (u *unknownIndex) IsBRIN() bool {
+	return false
+}
+
 func (u *unknownIndex) GetInvisibility() float64 {
 	return 0.0
 }
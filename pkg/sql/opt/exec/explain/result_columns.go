@@ -200,6 +200,19 @@ func getResultColumns(
 	case showCompletionsOp:
 		return colinfo.ShowCompletionsColumns, nil
 
+	case callOp:
+		// A procedure with OUT or INOUT parameters returns a column for each of
+		// those parameters.
+		typ := args.(*callArgs).Proc.ResolvedType()
+		if typ.Family() != types.TupleFamily {
+			return nil, nil
+		}
+		cols := make(colinfo.ResultColumns, len(typ.TupleContents()))
+		for i := range cols {
+			cols[i] = colinfo.ResultColumn{Name: typ.TupleLabels()[i], Typ: typ.TupleContents()[i]}
+		}
+		return cols, nil
+
 	case alterTableSplitOp:
 		return colinfo.AlterTableSplitColumns, nil
 
@@ -47,6 +47,12 @@ type ScanParams struct {
 	IndexConstraint    *constraint.Constraint
 	InvertedConstraint inverted.Spans
 
+	// If SummaryIndex is set, the scan is a scan of the primary index that is
+	// pruned using the summaries of this BRIN index: the blocks of rows whose
+	// summaries don't intersect SummaryConstraint are not scanned.
+	SummaryIndex      cat.Index
+	SummaryConstraint *constraint.Constraint
+
 	// If non-zero, the scan returns this many rows.
 	HardLimit int64
 
@@ -66,6 +72,9 @@ type ScanParams struct {
 	// Row-level locking properties.
 	Locking opt.Locking
 
+	// If set, the scan only returns a random sample of the rows.
+	Sample opt.TableSample
+
 	EstimatedRowCount float64
 
 	// If true, we are performing a locality optimized search. In order for this
@@ -154,6 +163,10 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is the definition of the aggregate if it is a user-defined
+	// aggregate, in which case FuncName is only used for display purposes.
+	UserDefined *tree.UserDefinedAggregate
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -215,9 +228,14 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 // ConstructBuffer as an input; it should only be triggered if this buffer is
 // not empty.
 type Cascade struct {
-	// FKName is the name of the foreign key constraint.
+	// FKName is the name of the foreign key constraint, or the name of the
+	// trigger if IsTrigger is set.
 	FKName string
 
+	// IsTrigger is true if the cascade runs an AFTER row trigger rather than a
+	// foreign key action. The rows returned by its plan are discarded.
+	IsTrigger bool
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
@@ -276,6 +294,27 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableCheck describes the check query of a DEFERRABLE foreign key or
+// unique constraint. It allows a violation to be recorded and revalidated
+// when the transaction commits instead of causing an immediate error.
+type DeferrableCheck struct {
+	// TableID is the table on which the constraint is defined; for a foreign
+	// key, this is the origin (referencing) table.
+	TableID cat.StableID
+
+	// ConstraintName is the name of the constraint.
+	ConstraintName string
+
+	// InitiallyDeferred is true if the constraint is deferred unless changed
+	// with SET CONSTRAINTS.
+	InitiallyDeferred bool
+
+	// KeyCols are the ordinals of the columns in the rows returned by the
+	// check query that hold the values of the constraint columns, in the order
+	// of the constraint columns.
+	KeyCols []NodeColumnOrdinal
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
@@ -115,6 +115,11 @@ func (hi *hypotheticalIndex) IsInverted() bool {
 	return hi.inverted
 }
 
+// IsBRIN is part of the cat.Index interface.
+func (hi *hypotheticalIndex) IsBRIN() bool {
+	return false
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (hi *hypotheticalIndex) GetInvisibility() float64 {
 	// A hypotheticalIndex should not be invisible because there is no motivation
@@ -388,6 +388,8 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		invertedExpr = j.extractJSONExistsCondition(ctx, evalCtx, t.Left, t.Right, false /* all */)
 	case *memo.JsonAllExistsExpr:
 		invertedExpr = j.extractJSONExistsCondition(ctx, evalCtx, t.Left, t.Right, true /* all */)
+	case *memo.JsonPathExistsExpr:
+		invertedExpr = j.extractJSONPathExistsCondition(t.Left, t.Right)
 	case *memo.EqExpr:
 		if fetch, ok := t.Left.(*memo.FetchValExpr); ok {
 			invertedExpr = j.extractJSONFetchValEqCondition(ctx, evalCtx, fetch, t.Right)
@@ -559,6 +561,41 @@ func (j *jsonOrArrayFilterPlanner) extractJSONExistsCondition(
 	return inverted.NonInvertedColExpression{}
 }
 
+// extractJSONPathExistsCondition extracts an InvertedExpression representing
+// an inverted filter over the planner's inverted index, based on the given
+// left and right expression arguments of the @? operator. An InvertedExpression
+// can only be generated if the left argument is the index column and the right
+// argument is a constant jsonpath of the simple form $.k1.k2...kn. Otherwise,
+// an inverted.NonInvertedColExpression is returned.
+//
+// For example, consider the filter j @? '$.a.b'. The generated expression
+// covers the rows in which j has the nested key b within the key a. In lax
+// mode, it also covers the rows in which the objects along the path are
+// wrapped in arrays, like '{"a": [{"b": 1}]}'.
+func (j *jsonOrArrayFilterPlanner) extractJSONPathExistsCondition(
+	left, right opt.ScalarExpr,
+) inverted.Expression {
+	if !isIndexColumn(j.tabID, j.index, left, j.computedColumns) ||
+		!memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	d, ok := memo.ExtractConstDatum(right).(*tree.DJsonpath)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	keys, ok := d.Keys()
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	invertedExpr, err := json.EncodeKeyPathExistsInvertedIndexSpans(
+		nil /* inKey */, keys, !d.Strict(), /* unwrapArrays */
+	)
+	if err != nil {
+		panic(err)
+	}
+	return invertedExpr
+}
+
 // extractJSONEqCondition extracts an InvertedExpression representing an
 // inverted filter over the planner's inverted index, based on equality between
 // two scalar expressions. If an InvertedExpression cannot be generated from the
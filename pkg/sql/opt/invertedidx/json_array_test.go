@@ -1129,6 +1129,40 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			unique:           false,
 			remainingFilters: `j IN ('[1, 2, 3]', '{"a": "b"}', '1', '"a"')`,
 		},
+		{
+			// Simple jsonpath expressions with the @? operator are supported.
+			filters:          `j @? '$.a.b'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? '$.a.b'`,
+		},
+		{
+			filters:          `j @? 'strict $.a'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? 'strict $.a'`,
+		},
+		{
+			// Filters, accessors other than member accessors, and the root item
+			// by itself are not supported.
+			filters:  `j @? '$.a ? (@ > 1)'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j @? '$.a[*]'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j @? '$'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
 	}
 
 	for _, tc := range testCases {
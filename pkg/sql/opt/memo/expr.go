@@ -725,6 +725,25 @@ type UDFDefinition struct {
 	// ExceptionBlock contains information needed for exception-handling when the
 	// body of this routine returns an error. It can be unset.
 	ExceptionBlock *ExceptionBlock
+
+	// CursorDeclaration contains the information needed to open a SQL cursor
+	// with the result of the *first* body statement. It may be unset.
+	CursorDeclaration *tree.RoutineOpenCursor
+
+	// ResultBufferID is set for the root routine of a set-returning PL/pgSQL
+	// function. Rows added to the buffer with this ID by nested routines are
+	// returned as the result of the function. It is zero if unset.
+	ResultBufferID tree.RoutineResultBufferID
+
+	// ReturnRows identifies the result buffer to which the rows of the *first*
+	// body statement are added. It is used to implement RETURN NEXT and RETURN
+	// QUERY. It is zero if unset.
+	ReturnRows tree.RoutineResultBufferID
+
+	// SessionOverrides, if non-nil, contains changes to the session that are
+	// applied for the duration of the routine's execution. It is set for
+	// SECURITY DEFINER routines and routines with SET clauses.
+	SessionOverrides *tree.RoutineSessionOverrides
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -740,6 +759,33 @@ type ExceptionBlock struct {
 	Actions []*UDFDefinition
 }
 
+// UserDefinedAggDefinition stores details about the support functions of a
+// user-defined aggregate.
+type UserDefinedAggDefinition struct {
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+
+	// StateFunc is the function that computes the next state of the aggregate
+	// from the current state and the arguments of an input row.
+	StateFunc *UDFDefinition
+
+	// FinalFunc is the function that computes the result of the aggregate from
+	// its final state. It is nil if the final state is the result.
+	FinalFunc *UDFDefinition
+
+	// CombineFunc is the function that combines two partial states of the
+	// aggregate. It can be nil.
+	CombineFunc *UDFDefinition
+
+	// InitCond is the initial state of the aggregate. It is DNull if no initial
+	// condition was specified.
+	InitCond tree.Datum
+
+	// NumArgs is the number of arguments of the aggregate. If it is greater than
+	// one, the input of the aggregate is a tuple of the arguments.
+	NumArgs int
+}
+
 // WindowFrame denotes the definition of a window frame for an individual
 // window function, excluding the OFFSET expressions, if present.
 type WindowFrame struct {
@@ -792,13 +838,16 @@ func (f *WindowFrame) String() string {
 }
 
 // IsCanonical returns true if the ScanPrivate indicates an original unaltered
-// primary index Scan operator (i.e. unconstrained and not limited).
+// primary index Scan operator (i.e. unconstrained, not limited and not
+// sampled).
 // s.InvertedConstraint is implicitly nil because a primary index cannot
 // be inverted.
 func (s *ScanPrivate) IsCanonical() bool {
 	return s.Index == cat.PrimaryIndex &&
 		s.Constraint == nil &&
+		s.SummaryConstraint == nil &&
 		s.HardLimit == 0 &&
+		s.Sample.Empty() &&
 		!s.LocalityOptimized
 }
 
@@ -807,8 +856,10 @@ func (s *ScanPrivate) IsCanonical() bool {
 func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 	return (s.Constraint == nil || s.Constraint.IsUnconstrained()) &&
 		s.InvertedConstraint == nil &&
+		s.SummaryConstraint == nil &&
 		s.HardLimit == 0 &&
 		s.PartialIndexPredicate(md) == nil &&
+		s.Sample.Empty() &&
 		s.Locking.WaitPolicy != tree.LockWaitSkipLocked
 }
 
@@ -817,6 +868,7 @@ func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 func (s *ScanPrivate) IsFullIndexScan(md *opt.Metadata) bool {
 	return (s.Constraint == nil || s.Constraint.IsUnconstrained()) &&
 		s.InvertedConstraint == nil &&
+		s.SummaryConstraint == nil &&
 		s.HardLimit == 0
 }
 
@@ -1315,6 +1367,12 @@ type FKCascade struct {
 	// It is empty if the mutation is a deletion. Empty if the cascade does not
 	// require input.
 	NewValues opt.ColList
+
+	// IsTrigger is true if the cascading query runs an AFTER row trigger of the
+	// mutated table rather than a foreign key action. In that case FKName is
+	// the name of the trigger, and the rows returned by the query are
+	// discarded.
+	IsTrigger bool
 }
 
 // CascadeBuilder is an interface used to construct a cascading query for a
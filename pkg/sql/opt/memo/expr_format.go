@@ -480,9 +480,27 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			n := tp.Childf("inverted constraint: %s", b.String())
 			ic.Format(n, "spans", f.RedactableValues)
 		}
+		if c := private.SummaryConstraint; c != nil {
+			idx := md.Table(private.Table).Index(private.SummaryIndex)
+			n := tp.Childf("summary index: %s", idx.Name())
+			if c.Spans.Count() == 1 {
+				n.Childf(
+					"constraint: %s: %s", c.Columns.String(),
+					cat.MaybeMarkRedactable(c.Spans.Get(0).String(), f.RedactableValues),
+				)
+			} else {
+				nc := n.Childf("constraint: %s", c.Columns.String())
+				for i := 0; i < c.Spans.Count(); i++ {
+					nc.Child(cat.MaybeMarkRedactable(c.Spans.Get(i).String(), f.RedactableValues))
+				}
+			}
+		}
 		if private.HardLimit.IsSet() {
 			tp.Childf("limit: %s", private.HardLimit)
 		}
+		if !private.Sample.Empty() {
+			tp.Childf("sample: %s", private.Sample)
+		}
 
 		if private.shouldPrintFlags(md, f.HasFlags(ExprFmtHideNotVisibleIndexInfo)) {
 			var b strings.Builder
@@ -665,6 +683,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			}
 			if t.CanaryCol != 0 {
 				f.formatRelColList(e, tp, "canary column:", opt.ColList{t.CanaryCol})
+				if t.DeleteCol != 0 {
+					f.formatRelColList(e, tp, "delete column:", opt.ColList{t.DeleteCol})
+				}
 				f.formatOptionalColList(e, tp, "fetch columns:", t.FetchCols)
 				f.formatMutationCols(e, tp, "insert-mapping:", t.InsertCols, t.Table)
 				f.formatMutationCols(e, tp, "update-mapping:", t.UpdateCols, t.Table)
@@ -1277,6 +1298,10 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 	case *UDFCallExpr:
 		private = nil
 
+	case *TxnControlExpr:
+		fmt.Fprintf(f.Buffer, " %s", t.TxnOp)
+		private = nil
+
 	default:
 		private = scalar.Private()
 	}
@@ -1467,7 +1492,11 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 	if len(p.FKCascades) > 0 {
 		c := tp.Childf("cascades")
 		for i := range p.FKCascades {
-			c.Child(p.FKCascades[i].FKName)
+			if p.FKCascades[i].IsTrigger {
+				c.Childf("trigger %s", p.FKCascades[i].FKName)
+			} else {
+				c.Child(p.FKCascades[i].FKName)
+			}
 		}
 	}
 }
@@ -1705,6 +1734,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
@@ -527,6 +527,13 @@ func (h *hasher) HashScanFlags(val ScanFlags) {
 	}
 }
 
+func (h *hasher) HashTableSample(val opt.TableSample) {
+	h.HashInt(int(val.Method))
+	h.HashFloat64(val.Fraction)
+	h.HashBool(val.Repeatable)
+	h.HashInt64(val.Seed)
+}
+
 func (h *hasher) HashJoinFlags(val JoinFlags) {
 	h.HashUint64(uint64(val))
 }
@@ -557,6 +564,10 @@ func (h *hasher) HashShowTraceType(val tree.ShowTraceType) {
 	h.HashString(string(val))
 }
 
+func (h *hasher) HashTxnControlOp(val tree.TxnControlOp) {
+	h.HashUint64(uint64(val))
+}
+
 func (h *hasher) HashJobCommand(val tree.JobCommand) {
 	h.HashInt(int(val))
 }
@@ -958,6 +969,10 @@ func (h *hasher) IsScanFlagsEqual(l, r ScanFlags) bool {
 	return l == r
 }
 
+func (h *hasher) IsTableSampleEqual(l, r opt.TableSample) bool {
+	return l == r
+}
+
 func (h *hasher) IsJoinFlagsEqual(l, r JoinFlags) bool {
 	return l == r
 }
@@ -987,6 +1002,10 @@ func (h *hasher) IsShowTraceTypeEqual(l, r tree.ShowTraceType) bool {
 	return l == r
 }
 
+func (h *hasher) IsTxnControlOpEqual(l, r tree.TxnControlOp) bool {
+	return l == r
+}
+
 func (h *hasher) IsJobCommandEqual(l, r tree.JobCommand) bool {
 	return l == r
 }
@@ -1231,6 +1250,10 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 			return false
 		}
 	}
+	if l.CursorDeclaration != r.CursorDeclaration || l.ResultBufferID != r.ResultBufferID ||
+		l.ReturnRows != r.ReturnRows || l.SessionOverrides != r.SessionOverrides {
+		return false
+	}
 	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
 }
 
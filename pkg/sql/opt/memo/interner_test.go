@@ -435,6 +435,11 @@ func TestInterner(t *testing.T) {
 			{val1: tree.ShowTraceKV, val2: tree.ShowTraceRaw, equal: false},
 		}},
 
+		{hashFn: in.hasher.HashTxnControlOp, eqFn: in.hasher.IsTxnControlOpEqual, variations: []testVariation{
+			{val1: tree.StoredProcTxnCommit, val2: tree.StoredProcTxnCommit, equal: true},
+			{val1: tree.StoredProcTxnCommit, val2: tree.StoredProcTxnRollback, equal: false},
+		}},
+
 		{hashFn: in.hasher.HashIndexOrdinal, eqFn: in.hasher.IsIndexOrdinalEqual, variations: []testVariation{
 			{val1: 0, val2: 0, equal: true},
 			{val1: 0, val2: 1, equal: false},
@@ -174,8 +174,8 @@ func (b *logicalPropsBuilder) buildScanProps(scan *ScanExpr, rel *props.Relation
 		}
 		b.updateCardinalityFromTypes(rel.OutputCols, rel)
 	}
-	if scan.Locking.WaitPolicy == tree.LockWaitSkipLocked {
-		// SKIP LOCKED can act like a filter. The minimum cardinality of a scan
+	if scan.Locking.WaitPolicy == tree.LockWaitSkipLocked || !scan.Sample.Empty() {
+		// SKIP LOCKED and TABLESAMPLE can act like a filter. The minimum cardinality of a scan
 		// should never exceed zero based on the logic above, but this provides
 		// extra safety.
 		rel.Cardinality = rel.Cardinality.AsLowAs(0)
@@ -1087,6 +1087,15 @@ func (b *logicalPropsBuilder) buildShowCompletionsProps(
 	b.buildBasicProps(ctl, ctl.Columns, rel)
 }
 
+func (b *logicalPropsBuilder) buildCallProps(call *CallExpr, rel *props.Relational) {
+	b.buildBasicProps(call, call.Columns, rel)
+
+	// Cardinality
+	// -----------
+	// A procedure returns at most one row.
+	rel.Cardinality = props.AnyCardinality.Limit(1)
+}
+
 func (b *logicalPropsBuilder) buildCancelQueriesProps(
 	cancel *CancelQueriesExpr, rel *props.Relational,
 ) {
@@ -1265,6 +1274,42 @@ func (b *logicalPropsBuilder) buildMax1RowProps(max1Row *Max1RowExpr, rel *props
 	}
 }
 
+func (b *logicalPropsBuilder) buildBarrierProps(barrier *BarrierExpr, rel *props.Relational) {
+	BuildSharedProps(barrier, &rel.Shared, b.evalCtx)
+
+	inputProps := barrier.Input.Relational()
+
+	// Output Columns
+	// --------------
+	// Output columns are inherited from input.
+	rel.OutputCols = inputProps.OutputCols
+
+	// Not Null Columns
+	// ----------------
+	// Not null columns are inherited from input.
+	rel.NotNullCols = inputProps.NotNullCols
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by BuildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	// Functional dependencies are inherited from input.
+	rel.FuncDeps.CopyFrom(&inputProps.FuncDeps)
+
+	// Cardinality
+	// -----------
+	// Barrier returns all the rows of its input.
+	rel.Cardinality = inputProps.Cardinality
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildBarrier(barrier, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildOrdinalityProps(ord *OrdinalityExpr, rel *props.Relational) {
 	BuildSharedProps(ord, &rel.Shared, b.evalCtx)
 
@@ -1667,6 +1712,10 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *TxnControlExpr:
+		shared.HasUDF = true
+		shared.VolatilitySet.AddVolatile()
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -1865,6 +1914,12 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.IsExclusion() {
+			// The columns of exclusion constraints are not keys, because rows may
+			// have equal values for columns that are not compared with equality.
+			continue
+		}
+
 		// If any of the columns are nullable, add a lax key FD. Otherwise, add a
 		// strict key.
 		var keyCols opt.ColSet
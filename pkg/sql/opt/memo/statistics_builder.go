@@ -14,6 +14,7 @@ import (
 	"context"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -462,6 +463,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.Max1RowOp:
 		return sb.colStatMax1Row(colSet, e.(*Max1RowExpr))
 
+	case opt.BarrierOp:
+		return sb.colStatBarrier(colSet, e.(*BarrierExpr))
+
 	case opt.OrdinalityOp:
 		return sb.colStatOrdinality(colSet, e.(*OrdinalityExpr))
 
@@ -798,8 +802,13 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 
 	// If the constraints and pred are nil, then this scan is an unconstrained
 	// scan on a non-partial index. The stats of the scan are the same as the
-	// underlying table stats.
-	if scan.Constraint == nil && scan.InvertedConstraint == nil && pred == nil {
+	// underlying table stats, scaled down by the sampling fraction if the scan
+	// is sampled.
+	if scan.Constraint == nil && scan.InvertedConstraint == nil &&
+		scan.SummaryConstraint == nil && pred == nil {
+		if !scan.Sample.Empty() {
+			s.ApplySelectivity(props.MakeSelectivity(scan.Sample.Fraction))
+		}
 		sb.finalizeFromCardinality(relProps)
 		return
 	}
@@ -807,7 +816,7 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 	// If the constraints are nil but pred is not, then this scan is an
 	// unconstrained scan over a partial index. The selectivity of the partial
 	// index predicate expression must be applied to the underlying table stats.
-	if scan.Constraint == nil && scan.InvertedConstraint == nil {
+	if scan.Constraint == nil && scan.InvertedConstraint == nil && scan.SummaryConstraint == nil {
 		notNullCols := relProps.NotNullCols.Copy()
 		// Add any not-null columns from the predicate constraints.
 		for i := range pred {
@@ -821,8 +830,9 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 	}
 
 	// If the constraint is nil or it has a single span, apply the constraint
-	// selectivity, the inverted constraint selectivity, and the partial index
-	// predicate (if they exist) to the underlying table stats.
+	// selectivity, the inverted constraint selectivity, the summary constraint
+	// selectivity, and the partial index predicate (if they exist) to the
+	// underlying table stats.
 	if scan.Constraint == nil || scan.Constraint.Spans.Count() < 2 {
 		sb.constrainScan(scan, scan.Constraint, pred, relProps, s)
 		sb.finalizeFromCardinality(relProps)
@@ -950,6 +960,16 @@ func (sb *statisticsBuilder) constrainScan(
 		histCols.UnionWith(histColsLocal)
 	}
 
+	// Calculate distinct counts and histograms for the summary constraint
+	// --------------------------------------------------------------------
+	if scan.SummaryConstraint != nil {
+		// Estimate that the blocks pruned using the summary index are exactly
+		// those without rows satisfying the summary constraint.
+		constrainedColsLocal, histColsLocal := sb.applyIndexConstraint(scan.SummaryConstraint, scan, relProps, s)
+		constrainedCols.UnionWith(constrainedColsLocal)
+		histCols.UnionWith(histColsLocal)
+	}
+
 	// Calculate distinct counts and histograms for the partial index predicate
 	// ------------------------------------------------------------------------
 	if pred != nil {
@@ -978,7 +998,7 @@ func (sb *statisticsBuilder) constrainScan(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, scan, s)
+	corr := sb.correlationFromMultiColStats(constrainedCols, histCols, scan, s)
 	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, scan, s, corr))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(scan, notNullCols, constrainedCols))
@@ -1162,7 +1182,7 @@ func (sb *statisticsBuilder) buildInvertedFilter(
 	// -----------------------------------
 	inputStats := invFilter.Input.Relational().Statistics()
 	s.RowCount = inputStats.RowCount
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, invFilter, s)
+	corr := sb.correlationFromMultiColStats(constrainedCols, histCols, invFilter, s)
 	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, invFilter, s, corr))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(invFilter, relProps.NotNullCols, constrainedCols))
 
@@ -1856,7 +1876,7 @@ func (sb *statisticsBuilder) buildZigzagJoin(
 		// ON clause.
 		// TODO(msirek): Validate stats for inverted index zigzag join match
 		//               non-zigzag join stats.
-		corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, zigzag, s)
+		corr := sb.correlationFromMultiColStats(constrainedCols, histCols, zigzag, s)
 		s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, zigzag, s, corr))
 	} else {
 		multiColSelectivity, _ := sb.selectivityFromMultiColDistinctCounts(constrainedCols, zigzag, s)
@@ -2339,6 +2359,36 @@ func (sb *statisticsBuilder) colStatMax1Row(
 	return colStat
 }
 
+// +---------+
+// | Barrier |
+// +---------+
+
+func (sb *statisticsBuilder) buildBarrier(barrier *BarrierExpr, relProps *props.Relational) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(barrier)
+
+	inputStats := barrier.Input.Relational().Statistics()
+
+	s.RowCount = inputStats.RowCount
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatBarrier(
+	colSet opt.ColSet, barrier *BarrierExpr,
+) *props.ColumnStatistic {
+	s := barrier.Relational().Statistics()
+	colStat := sb.copyColStatFromChild(colSet, barrier, s)
+	if colSet.Intersects(barrier.Relational().NotNullCols) {
+		colStat.NullCount = 0
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +------------+
 // | Row Number |
 // +------------+
@@ -3071,7 +3121,7 @@ func (sb *statisticsBuilder) filterRelExpr(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
+	corr := sb.correlationFromMultiColStats(constrainedCols, histCols, e, s)
 	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, e, s, corr))
 	s.ApplySelectivity(sb.selectivityFromEquivalencies(equivReps, &relProps.FuncDeps, e, s))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
@@ -3336,7 +3386,7 @@ func (sb *statisticsBuilder) constrainExpr(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
+	corr := sb.correlationFromMultiColStats(constrainedCols, histCols, e, s)
 	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, e, s, corr))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(e, notNullCols, constrainedCols))
 }
@@ -4028,6 +4078,137 @@ func (sb *statisticsBuilder) correlationFromMultiColDistinctCountsForJoin(
 	return (selectivity.AsFloat() - lowerBound.AsFloat()) / (upperBound.AsFloat() - lowerBound.AsFloat())
 }
 
+// correlationFromMultiColStats returns the correlation between the given
+// constrained columns, as estimated from multi-column statistics. histCols is
+// the subset of cols which have histograms available. It combines the
+// correlation implied by multi-column distinct counts with the correlation
+// implied by functional dependencies, using the stronger of the two.
+func (sb *statisticsBuilder) correlationFromMultiColStats(
+	cols, histCols opt.ColSet, e RelExpr, s *props.Statistics,
+) float64 {
+	corr := sb.correlationFromMultiColDistinctCounts(cols, e, s)
+	return max(corr, sb.correlationFromDependencies(cols, histCols, e, s))
+}
+
+// colDependency is a functional dependency between two columns, collected by
+// CREATE STATISTICS with the "dependencies" statistics kind.
+type colDependency struct {
+	from, to opt.ColumnID
+	degree   float64
+}
+
+// correlationFromDependencies returns the correlation between the given
+// constrained columns implied by the functional dependencies between them.
+// histCols is the subset of cols which have histograms available. As in
+// correlationFromMultiColDistinctCounts, the correlation is a number between 0
+// and 1 which is used to interpolate between the selectivity assuming
+// independence and the minimum selectivity of any single column.
+//
+// The selectivity implied by the dependencies is calculated as in Postgres.
+// Given a dependency a => b with degree f, the selectivity of the predicates on
+// columns a and b is:
+//
+//	sel(a, b) = sel(a) * (f + (1-f) * sel(b))
+//
+// Dependencies are applied strongest first, and a column is implied by at most
+// one dependency.
+func (sb *statisticsBuilder) correlationFromDependencies(
+	cols, histCols opt.ColSet, e RelExpr, s *props.Statistics,
+) float64 {
+	// Respect the session setting OptimizerUseMultiColStats.
+	if !sb.evalCtx.SessionData().OptimizerUseMultiColStats || cols.Len() < 2 {
+		return 0
+	}
+	deps := sb.dependenciesFromTableStats(cols)
+	if len(deps) == 0 {
+		return 0
+	}
+
+	// Calculate the selectivity of the predicates on each column.
+	colSelectivities := make(map[opt.ColumnID]float64, cols.Len())
+	independent, upperBound := 1.0, 1.0
+	for col, ok := cols.Next(0); ok; col, ok = cols.Next(col + 1) {
+		var sel props.Selectivity
+		if histCols.Contains(col) {
+			sel, _ = sb.selectivityFromHistograms(opt.MakeColSet(col), e, s)
+		} else {
+			sel, _ = sb.selectivityFromSingleColDistinctCounts(opt.MakeColSet(col), e, s)
+		}
+		colSelectivities[col] = sel.AsFloat()
+		independent *= sel.AsFloat()
+		upperBound = min(upperBound, sel.AsFloat())
+	}
+	if upperBound == independent {
+		return 0
+	}
+
+	sort.SliceStable(deps, func(i, j int) bool {
+		return deps[i].degree > deps[j].degree
+	})
+	remaining := cols.Copy()
+	selectivity := 1.0
+	for _, dep := range deps {
+		if !remaining.Contains(dep.from) || !remaining.Contains(dep.to) {
+			continue
+		}
+		remaining.Remove(dep.to)
+		selectivity *= dep.degree + (1-dep.degree)*colSelectivities[dep.to]
+	}
+	for col, ok := remaining.Next(0); ok; col, ok = remaining.Next(col + 1) {
+		selectivity *= colSelectivities[col]
+	}
+
+	corr := (selectivity - independent) / (upperBound - independent)
+	return max(0, min(corr, 1))
+}
+
+// dependenciesFromTableStats returns the functional dependencies between pairs
+// of the given columns found in the statistics of their base tables. If
+// several statistics contain a dependency between the same columns, the most
+// recent one is used.
+func (sb *statisticsBuilder) dependenciesFromTableStats(cols opt.ColSet) []colDependency {
+	var deps []colDependency
+	var tables []opt.TableID
+	for col, ok := cols.Next(0); ok; col, ok = cols.Next(col + 1) {
+		tabID := sb.md.ColumnMeta(col).Table
+		if tabID == 0 {
+			continue
+		}
+		seen := false
+		for _, t := range tables {
+			seen = seen || t == tabID
+		}
+		if seen {
+			continue
+		}
+		tables = append(tables, tabID)
+
+		// Stats are ordered with most recent first.
+		tab := sb.md.Table(tabID)
+		for i, n := 0, tab.StatisticCount(); i < n; i++ {
+			stat := tab.Statistic(i)
+			if stat.IsPartial() {
+				continue
+			}
+			for _, d := range stat.Dependencies() {
+				from := tabID.ColumnID(stat.ColumnOrdinal(d.From))
+				to := tabID.ColumnID(stat.ColumnOrdinal(d.To))
+				if !cols.Contains(from) || !cols.Contains(to) {
+					continue
+				}
+				found := false
+				for j := range deps {
+					found = found || (deps[j].from == from && deps[j].to == to)
+				}
+				if !found {
+					deps = append(deps, colDependency{from: from, to: to, degree: d.Degree})
+				}
+			}
+		}
+	}
+	return deps
+}
+
 // selectivityFromSingleColDistinctCounts calculates the selectivity of a
 // filter by using estimated distinct counts of each constrained column before
 // and after the filter was applied. It assumes independence between columns,
@@ -192,6 +192,7 @@ func init() {
 	typingFuncMap[opt.ArrayFlattenOp] = typeArrayFlatten
 	typingFuncMap[opt.IfErrOp] = typeIfErr
 	typingFuncMap[opt.UDFCallOp] = typeUDFCall
+	typingFuncMap[opt.TxnControlOp] = typeTxnControl
 
 	// Override default typeAsAggregate behavior for aggregate functions with
 	// a large number of possible overloads or where ReturnType depends on
@@ -387,6 +388,12 @@ func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
 }
 
+// typeTxnControl returns the type of a transaction control operator, which
+// matches the return type of its continuation.
+func typeTxnControl(e opt.ScalarExpr) *types.T {
+	return e.(*TxnControlExpr).Def.Typ
+}
+
 // typeSubquery returns the type of a subquery, which is equal to the type of
 // its first (and only) column.
 func typeSubquery(e opt.ScalarExpr) *types.T {
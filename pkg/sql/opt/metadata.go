@@ -16,12 +16,14 @@ import (
 	"math/bits"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -131,12 +133,28 @@ type Metadata struct {
 	// query depends on.
 	privileges map[cat.StableID]privilegeBitmap
 
+	// userPrivileges stores the privileges that other users need to access
+	// each object that the query depends on. Objects referenced in the body of
+	// a SECURITY DEFINER routine are accessed with the privileges of the
+	// routine's owner rather than those of the current user.
+	userPrivileges map[username.SQLUsername]map[cat.StableID]privilegeBitmap
+
 	// builtinRefsByName stores the names used to reference builtin functions in
 	// the query. This is necessary to handle the case where changes to the search
 	// path cause a function call to be resolved to a UDF with the same signature
 	// as a builtin function.
 	builtinRefsByName map[tree.UnresolvedName]struct{}
 
+	// rlsChecks stores the results of the checks that determined which
+	// row-level security policies apply to the query. The query needs to be
+	// rebuilt if any of the results changes.
+	rlsChecks []RowLevelSecurityCheck
+
+	// rlsSessionUser is set if some of the checks in rlsChecks were performed
+	// for the session user, in which case the query also needs to be rebuilt
+	// if the session user changes.
+	rlsSessionUser username.SQLUsername
+
 	// NOTE! When adding fields here, update Init (if reusing allocated
 	// data structures is desired), CopyFrom and TestMetadata.
 }
@@ -202,6 +220,11 @@ func (md *Metadata) Init() {
 		delete(md.privileges, id)
 	}
 
+	userPrivileges := md.userPrivileges
+	for user := range md.userPrivileges {
+		delete(md.userPrivileges, user)
+	}
+
 	builtinRefsByName := md.builtinRefsByName
 	if builtinRefsByName == nil {
 		builtinRefsByName = make(map[tree.UnresolvedName]struct{})
@@ -222,6 +245,7 @@ func (md *Metadata) Init() {
 	md.udfDeps = udfDeps
 	md.objectRefsByName = objectRefsByName
 	md.privileges = privileges
+	md.userPrivileges = userPrivileges
 	md.builtinRefsByName = builtinRefsByName
 }
 
@@ -238,7 +262,8 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		len(md.sequences) != 0 || len(md.views) != 0 || len(md.userDefinedTypes) != 0 ||
 		len(md.userDefinedTypesSlice) != 0 || len(md.dataSourceDeps) != 0 ||
 		len(md.udfDeps) != 0 || len(md.objectRefsByName) != 0 || len(md.privileges) != 0 ||
-		len(md.builtinRefsByName) != 0 {
+		len(md.userPrivileges) != 0 || len(md.builtinRefsByName) != 0 ||
+		len(md.rlsChecks) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
 	md.schemas = append(md.schemas, from.schemas...)
@@ -304,6 +329,20 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		md.privileges[id] = privilegeSet
 	}
 
+	for user, privileges := range from.userPrivileges {
+		if md.userPrivileges == nil {
+			md.userPrivileges = make(map[username.SQLUsername]map[cat.StableID]privilegeBitmap)
+		}
+		newPrivileges := make(map[cat.StableID]privilegeBitmap, len(privileges))
+		for id, privilegeSet := range privileges {
+			newPrivileges[id] = privilegeSet
+		}
+		md.userPrivileges[user] = newPrivileges
+	}
+
+	md.rlsChecks = append(md.rlsChecks, from.rlsChecks...)
+	md.rlsSessionUser = from.rlsSessionUser
+
 	for name := range from.builtinRefsByName {
 		if md.builtinRefsByName == nil {
 			md.builtinRefsByName = make(map[tree.UnresolvedName]struct{})
@@ -357,6 +396,25 @@ func (md *Metadata) AddDependency(name MDDepName, ds cat.DataSource, priv privil
 	}
 }
 
+// AddDependencyForUser is like AddDependency, except that the privilege is
+// required by the given user rather than the current user. It is used for
+// objects that are referenced in the body of a SECURITY DEFINER routine.
+func (md *Metadata) AddDependencyForUser(
+	name MDDepName, ds cat.DataSource, priv privilege.Kind, user username.SQLUsername,
+) {
+	// Add the dependency without requiring any privilege of the current user.
+	md.AddDependency(name, ds, 0 /* priv */)
+	if md.userPrivileges == nil {
+		md.userPrivileges = make(map[username.SQLUsername]map[cat.StableID]privilegeBitmap)
+	}
+	privileges := md.userPrivileges[user]
+	if privileges == nil {
+		privileges = make(map[cat.StableID]privilegeBitmap)
+		md.userPrivileges[user] = privileges
+	}
+	privileges[ds.ID()] = privileges[ds.ID()] | (1 << priv)
+}
+
 // CheckDependencies resolves (again) each database object on which this
 // metadata depends, in order to check the following conditions:
 //  1. The object has not been modified.
@@ -442,6 +500,22 @@ func (md *Metadata) CheckDependencies(
 		}
 	}
 
+	// Check that the same row-level security policies apply to the query.
+	// Each check is performed again for the user it was performed for, which
+	// is the owner of the routine for the checks of the body of a SECURITY
+	// DEFINER routine.
+	if len(md.rlsChecks) > 0 {
+		if !md.rlsSessionUser.Undefined() && evalCtx.SessionData().User() != md.rlsSessionUser {
+			return false, nil
+		}
+		for i := range md.rlsChecks {
+			res, err := md.rlsChecks[i].Eval(ctx, optCatalog)
+			if err != nil || res != md.rlsChecks[i].Result {
+				return false, err
+			}
+		}
+	}
+
 	// Check that any references to builtin functions do not now resolve to a UDF
 	// with the same signature (e.g. after changes to the search path).
 	for name := range md.builtinRefsByName {
@@ -500,10 +574,64 @@ func (md *Metadata) checkDataSourcePrivileges(ctx context.Context, optCatalog ca
 			// Set the just-handled privilege bit to zero and look for next.
 			privs &= ^(1 << priv)
 		}
+		for user, userPrivileges := range md.userPrivileges {
+			for privs := userPrivileges[dataSource.ID()]; privs != 0; {
+				priv := privilege.Kind(bits.TrailingZeros32(uint32(privs)))
+				if priv != 0 {
+					if err := optCatalog.CheckPrivilegeForUser(ctx, dataSource, priv, user); err != nil {
+						return err
+					}
+				}
+				privs &= ^(1 << priv)
+			}
+		}
 	}
 	return nil
 }
 
+// RowLevelSecurityCheck is a check of the privileges of a user that was
+// performed to determine which row-level security policies apply to a query.
+type RowLevelSecurityCheck struct {
+	// User is the user whose privileges are checked.
+	User username.SQLUsername
+
+	// Table is set if the check is whether the user owns the table.
+	Table cat.Table
+
+	// Role is set if the check is whether the user is a member of the role. If
+	// neither Table nor Role is set, the check is whether the user bypasses
+	// row-level security.
+	Role username.SQLUsername
+
+	// Result is the result of the check.
+	Result bool
+}
+
+// Eval performs the check and returns its result.
+func (c *RowLevelSecurityCheck) Eval(ctx context.Context, catalog cat.Catalog) (bool, error) {
+	switch {
+	case c.Table != nil:
+		return catalog.HasOwnershipForUser(ctx, c.Table, c.User)
+	case !c.Role.Undefined():
+		return catalog.IsMemberOfRoleForUser(ctx, c.User, c.Role)
+	default:
+		return catalog.HasRoleOptionForUser(ctx, c.User, roleoption.BYPASSRLS)
+	}
+}
+
+// AddRowLevelSecurityCheck records the result of a check that was performed
+// for check.User to determine which row-level security policies apply to the
+// query. isSessionUser is true if check.User is the session user (rather than
+// the owner of a SECURITY DEFINER routine). The query is stale if the result
+// of the check changes, or if the check was performed for the session user
+// and the session user changes.
+func (md *Metadata) AddRowLevelSecurityCheck(check RowLevelSecurityCheck, isSessionUser bool) {
+	if isSessionUser {
+		md.rlsSessionUser = check.User
+	}
+	md.rlsChecks = append(md.rlsChecks, check)
+}
+
 // AddSchema indexes a new reference to a schema used by the query.
 func (md *Metadata) AddSchema(sch cat.Schema) SchemaID {
 	md.schemas = append(md.schemas, sch)
@@ -152,6 +152,12 @@ func (c *CustomFuncs) CanFoldOperator(v volatility.V) bool {
 	return c.f.foldingControl.canFoldOperator(v)
 }
 
+// IsNotNullDomain returns true if the given type is a domain that does not
+// allow null values.
+func (c *CustomFuncs) IsNotNullDomain(typ *types.T) bool {
+	return typ.TypeMeta.DomainData != nil && typ.TypeMeta.DomainData.NotNull
+}
+
 // FoldNullUnary replaces the unary operator with a typed null value having the
 // same type as the unary operator would have.
 func (c *CustomFuncs) FoldNullUnary(op opt.Operator, input opt.ScalarExpr) opt.ScalarExpr {
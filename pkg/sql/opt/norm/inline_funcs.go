@@ -414,6 +414,8 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 //  5. It is not a record-returning function.
 //  6. It does not recursively call itself.
 //  7. It does not have an exception-handling block.
+//  8. It is not a SECURITY DEFINER function and does not set any session
+//     variables, since the session must be modified while it executes.
 //
 // UDFs with mutations (INSERT, UPDATE, UPSERT, DELETE) cannot be inlined, but
 // we do not need an explicit check for this because immutable UDFs cannot
@@ -448,7 +450,9 @@ func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFCal
 	}
 	if udfp.Def.IsRecursive || udfp.Def.Volatility == volatility.Volatile ||
 		len(udfp.Def.Body) != 1 || udfp.Def.SetReturning || udfp.Def.MultiColDataSource ||
-		udfp.Def.ExceptionBlock != nil {
+		udfp.Def.ExceptionBlock != nil || udfp.Def.CursorDeclaration != nil ||
+		udfp.Def.ResultBufferID != 0 || udfp.Def.ReturnRows != 0 ||
+		udfp.Def.SessionOverrides != nil {
 		return false
 	}
 	if !args.IsConstantsAndPlaceholdersAndVariables() {
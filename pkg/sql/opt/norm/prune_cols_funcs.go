@@ -78,6 +78,9 @@ func (c *CustomFuncs) neededMutationCols(
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	if private.DeleteCol != 0 {
+		cols.Add(private.DeleteCol)
+	}
 
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
@@ -223,11 +226,14 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 			}
 		}
 
-	case opt.DeleteOp:
+	}
+
+	if op == opt.DeleteOp || private.DeleteCol != 0 {
 		// Add in all strict key columns from all indexes, since these are needed
 		// to compose the keys of rows to delete. Include mutation indexes, since
 		// it is necessary to delete rows even from indexes that are being added
-		// or dropped.
+		// or dropped. An Upsert with a DeleteCol (built for MERGE) can delete
+		// existing rows as well.
 		for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
 			cols.UnionWith(tabMeta.IndexKeyColumnsMapInverted(i))
 		}
@@ -242,6 +248,21 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Retain any FetchCols that are passed to AFTER triggers as the old or new
+	// values of the modified rows. The trigger queries are built after the
+	// mutation is executed, so they cannot be taken into account otherwise.
+	for i := range private.FKCascades {
+		if cascade := &private.FKCascades[i]; cascade.IsTrigger {
+			triggerCols := cascade.OldValues.ToSet()
+			triggerCols.UnionWith(cascade.NewValues.ToSet())
+			for ord, col := range private.FetchCols {
+				if col != 0 && triggerCols.Contains(col) {
+					cols.Add(tabMeta.MetaID.ColumnID(ord))
+				}
+			}
+		}
+	}
+
 	return cols
 }
 
@@ -550,6 +571,14 @@ func (c *CustomFuncs) DerivePruneCols(e memo.RelExpr, disabledRules intsets.Fast
 		usedCols := sel.Filters.OuterCols()
 		relProps.Rule.PruneCols.DifferenceWith(usedCols)
 
+	case opt.BarrierOp:
+		if disabledRules.Contains(int(opt.PruneBarrierCols)) {
+			// Avoid rule cycles.
+			break
+		}
+		// Any pruneable input columns can potentially be pruned.
+		relProps.Rule.PruneCols = c.DerivePruneCols(e.(*memo.BarrierExpr).Input, disabledRules).Copy()
+
 	case opt.ProjectOp:
 		if disabledRules.Contains(int(opt.PruneProjectCols)) {
 			// Avoid rule cycles.
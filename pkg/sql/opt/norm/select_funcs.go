@@ -417,3 +417,10 @@ func (c *CustomFuncs) addConjuncts(
 	}
 	return filters, true
 }
+
+// IsLeakproof returns true if the given filter is leakproof, meaning that it
+// cannot reveal anything about the rows it is evaluated on other than through
+// its result. See PushLeakproofFilterIntoBarrier.
+func (c *CustomFuncs) IsLeakproof(item *memo.FiltersItem) bool {
+	return item.ScalarProps().VolatilitySet.IsLeakproof()
+}
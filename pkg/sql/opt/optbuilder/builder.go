@@ -14,6 +14,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/delegate"
@@ -141,6 +142,27 @@ type Builder struct {
 	// within.
 	insideUDF bool
 
+	// insideTriggerFunc is true when the body of a trigger function is being
+	// built. References of the form new.<column> and old.<column> are then
+	// resolved to fields of the NEW and OLD records of the trigger.
+	insideTriggerFunc bool
+
+	// beforeTriggerTables contains the IDs of the tables whose BEFORE triggers
+	// are being built. It is used to detect triggers that recursively modify
+	// their own table.
+	beforeTriggerTables []cat.StableID
+
+	// checkPrivilegeUser is the user whose privileges are checked when
+	// resolving objects. It is set while building the body of a SECURITY
+	// DEFINER routine, which executes with the privileges of its owner. If
+	// unset, the privileges of the current user are checked.
+	checkPrivilegeUser username.SQLUsername
+
+	// skipRowLevelSecurity is true if the row-level security policies of
+	// tables should not be applied. It is set when building FK cascades, which
+	// are not subject to row-level security.
+	skipRowLevelSecurity bool
+
 	// insideDataSource is true when we are processing a data source.
 	insideDataSource bool
 
@@ -313,8 +335,8 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
-			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable,
+			*tree.CreateView, *tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateRoutine:
 			panic(pgerror.Newf(
@@ -354,6 +376,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -363,6 +390,9 @@ func (b *Builder) buildStmt(
 	case *tree.CreateRoutine:
 		return b.buildCreateFunction(stmt, inScope)
 
+	case *tree.Call:
+		return b.buildCall(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
@@ -38,10 +38,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		}
 	}
 
-	if cf.IsProcedure {
-		panic(unimplemented.New("CREATE PROCEDURE", "procedures not supported"))
-	}
-
 	sch, resName := b.resolveSchemaForCreateFunction(&cf.Name)
 	schID := b.factory.Metadata().AddSchema(sch)
 	cf.Name.ObjectNamePrefix = resName
@@ -144,12 +140,27 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// be resolved.
 	bodyScope := b.allocScope()
 	var paramTypes tree.ParamTypes
+	var outParamOrdinals []int
+	resolvedParamTypes := make([]*types.T, len(cf.Params))
 	for i := range cf.Params {
 		param := &cf.Params[i]
+		switch param.Class {
+		case tree.RoutineParamOut, tree.RoutineParamInOut:
+			if !cf.IsProcedure {
+				class := "OUT"
+				if param.Class == tree.RoutineParamInOut {
+					class = "INOUT"
+				}
+				panic(unimplemented.NewWithIssuef(100405,
+					"create function with '%s' argument class", class))
+			}
+			outParamOrdinals = append(outParamOrdinals, i)
+		}
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
 		if err != nil {
 			panic(err)
 		}
+		resolvedParamTypes[i] = typ
 		if types.IsRecordType(typ) {
 			if language == tree.RoutineLangSQL {
 				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
@@ -175,12 +186,18 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// Collect the parameters for PLpgSQL routines.
 		if language == tree.RoutineLangPLpgSQL {
 			paramTypes = append(paramTypes, tree.ParamType{
-				Name: param.Name.String(),
+				Name: string(param.Name),
 				Typ:  typ,
 			})
 		}
 	}
 
+	if cf.IsProcedure {
+		// The result of a procedure is determined by its OUT and INOUT
+		// parameters.
+		cf.ReturnType.Type = funcinfo.MakeProcedureReturnType(cf.Params, resolvedParamTypes)
+	}
+
 	// Collect the user defined type dependency of the return type.
 	funcReturnType, err := tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
 	if err != nil {
@@ -193,10 +210,38 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	targetVolatility := tree.GetRoutineVolatility(cf.Options)
 	fmtCtx := tree.NewFmtCtx(tree.FmtSerializable)
 
+	isTriggerFunc := funcReturnType.Family() == types.TriggerFamily
+	if isTriggerFunc {
+		if language != tree.RoutineLangPLpgSQL {
+			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"%s functions cannot return type trigger", language))
+		}
+		if len(cf.Params) > 0 {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.InvalidFunctionDefinition,
+					"trigger functions cannot have declared arguments"),
+				"The arguments of the trigger can be accessed through TG_NARGS and TG_ARGV instead.",
+			))
+		}
+		if cf.ReturnType.IsSet {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"trigger functions cannot return a set"))
+		}
+	}
+
 	// Validate each statement and collect the dependencies.
 	var stmtScope *scope
-	switch language {
-	case tree.RoutineLangSQL:
+	switch {
+	case isTriggerFunc:
+		// The body of a trigger function is only parsed, since the types of the
+		// NEW and OLD records depend on the table of the trigger. The body is
+		// built for a specific table each time the trigger fires.
+		stmt, err := plpgsql.Parse(funcBodyStr)
+		if err != nil {
+			panic(err)
+		}
+		formatFuncBodyStmt(fmtCtx, stmt.AST, false /* newLine */)
+	case language == tree.RoutineLangSQL:
 		// Parse the function body.
 		stmts, err := parser.Parse(funcBodyStr)
 		if err != nil {
@@ -225,14 +270,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			formatFuncBodyStmt(fmtCtx, stmt.AST, i > 0 /* newLine */)
 			afterBuildStmt()
 		}
-	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType.IsSet {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-
+	case language == tree.RoutineLangPLpgSQL:
 		// Parse the function body.
 		stmt, err := plpgsql.Parse(funcBodyStr)
 		if err != nil {
@@ -244,7 +282,10 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// the volatility.
 		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
 			var plBuilder plpgsqlBuilder
-			plBuilder.init(b, nil /* colRefs */, paramTypes, stmt.AST, funcReturnType)
+			plBuilder.init(
+				b, nil /* colRefs */, paramTypes, stmt.AST, funcReturnType,
+				cf.IsProcedure, cf.ReturnType.IsSet, outParamOrdinals,
+			)
 			stmtScope = plBuilder.build(stmt.AST, bodyScope)
 		})
 		checkStmtVolatility(targetVolatility, stmtScope, stmt)
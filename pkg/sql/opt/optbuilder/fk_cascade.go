@@ -103,7 +103,7 @@ func (cb *onDeleteCascadeBuilder) Build(
 		// for each public table column, making it appropriate to set it as
 		// mb.fetchScope.
 		mb.fetchScope = b.buildDeleteCascadeMutationInput(
-			cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
+			cb.mutatedTable, cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
 		)
 		mb.outScope = mb.fetchScope
 
@@ -164,6 +164,12 @@ func tryNewOnDeleteFastCascadeBuilder(
 	parentTab, childTab cat.Table,
 	mutationInputScope *scope,
 ) (_ *onDeleteFastCascadeBuilder, ok bool) {
+	if fk.MatchMethod() == tree.MatchPartial {
+		// With MATCH PARTIAL, whether a child row is deleted depends on the
+		// remaining rows in the parent table, so the filter cannot simply be
+		// transferred to the child table.
+		return nil, false
+	}
 	fkCols := make(opt.ColList, fk.ColumnCount())
 	for i := range fkCols {
 		tabOrd := fk.ReferencedColumnOrdinal(parentTab, i)
@@ -303,6 +309,7 @@ func (cb *onDeleteFastCascadeBuilder) Build(
 			noRowLocking,
 			b.allocScope(),
 			true, /* disableNotVisibleIndex */
+			nil,  /* sample */
 		)
 		mb.outScope = mb.fetchScope
 
@@ -447,7 +454,7 @@ func (cb *onDeleteSetBuilder) Build(
 		// for each public table column, making it appropriate to set it as
 		// mb.fetchScope.
 		mb.fetchScope = b.buildDeleteCascadeMutationInput(
-			cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
+			cb.mutatedTable, cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues,
 		)
 		mb.outScope = mb.fetchScope
 
@@ -506,7 +513,12 @@ func (cb *onDeleteSetBuilder) Build(
 //
 // Note that NULL values in the mutation input don't require any special
 // handling - they will be effectively ignored by the semi-join.
+//
+// For MATCH PARTIAL foreign keys, a child row is selected if its non-NULL FK
+// columns match a row in the mutation input, and they no longer match any row
+// in the parent table (see buildFKPartialOrphans).
 func (b *Builder) buildDeleteCascadeMutationInput(
+	parentTable cat.Table,
 	childTable cat.Table,
 	childTableAlias *tree.TableName,
 	fk cat.ForeignKeyConstraint,
@@ -525,6 +537,7 @@ func (b *Builder) buildDeleteCascadeMutationInput(
 		noRowLocking,
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
 	)
 
 	numFKCols := fk.ColumnCount()
@@ -552,21 +565,33 @@ func (b *Builder) buildDeleteCascadeMutationInput(
 		ID:      md.NextUniqueID(),
 	})
 
-	on := make(memo.FiltersExpr, numFKCols)
-	for i := range on {
+	fkCols := make(opt.ColList, numFKCols)
+	for i := range fkCols {
 		tabOrd := fk.OriginColumnOrdinal(childTable, i)
-		col := outScope.getColumnForTableOrdinal(tabOrd)
-		on[i] = b.factory.ConstructFiltersItem(b.factory.ConstructEq(
-			b.factory.ConstructVariable(col.id),
-			b.factory.ConstructVariable(outCols[i]),
-		))
+		fkCols[i] = outScope.getColumnForTableOrdinal(tabOrd).id
 	}
+	if fk.MatchMethod() == tree.MatchPartial {
+		outScope.expr = b.buildFKPartialOrphans(
+			parentTable, fkReferencedOrdinals(fk, parentTable), outScope.expr, fkCols,
+		)
+	}
+	on := b.buildFKMatchFilters(fk.MatchMethod(), fkCols, outCols)
 	outScope.expr = b.factory.ConstructSemiJoin(
 		outScope.expr, mutationInput, on, memo.EmptyJoinPrivate,
 	)
 	return outScope
 }
 
+// fkReferencedOrdinals returns the ordinals of the referenced columns of the
+// given FK in the parent table.
+func fkReferencedOrdinals(fk cat.ForeignKeyConstraint, parentTable cat.Table) []int {
+	ords := make([]int, fk.ColumnCount())
+	for i := range ords {
+		ords[i] = fk.ReferencedColumnOrdinal(parentTable, i)
+	}
+	return ords
+}
+
 // onUpdateCascadeBuilder is a memo.CascadeBuilder implementation for
 // ON UPDATE CASCADE / SET NULL / SET DEFAULT.
 //
@@ -658,7 +683,7 @@ func (cb *onUpdateCascadeBuilder) Build(
 
 		// Build a join of the table with the mutation input.
 		mb.outScope = b.buildUpdateCascadeMutationInput(
-			cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues, newValues,
+			cb.mutatedTable, cb.childTable, &mb.alias, fk, binding, bindingProps, oldValues, newValues,
 		)
 
 		// The scope created by b.buildUpdateCascadeMutationInput has the table
@@ -684,6 +709,18 @@ func (cb *onUpdateCascadeBuilder) Build(
 			switch cb.action {
 			case tree.Cascade:
 				updateExprs[i].Expr = &newValScopeCols[i]
+				if fk.MatchMethod() == tree.MatchPartial {
+					// With MATCH PARTIAL, only the non-NULL FK columns are updated:
+					//   CASE WHEN fk IS NULL THEN NULL ELSE fk_new_val END
+					tabOrd := fk.OriginColumnOrdinal(cb.childTable, i)
+					updateExprs[i].Expr = &tree.CaseExpr{
+						Whens: []*tree.When{{
+							Cond: &tree.IsNullExpr{Expr: mb.outScope.getColumnForTableOrdinal(tabOrd)},
+							Val:  tree.DNull,
+						}},
+						Else: &newValScopeCols[i],
+					}
+				}
 			case tree.SetNull:
 				updateExprs[i].Expr = tree.DNull
 			case tree.SetDefault:
@@ -742,7 +779,13 @@ func (cb *onUpdateCascadeBuilder) Build(
 // inserted rows the "old" values are all NULL and won't match anything in the
 // inner-join anyway. This reasoning is very similar to that of FK checks for
 // Upserts (see buildFKChecksForUpsert).
+//
+// For MATCH PARTIAL foreign keys, only child rows that no longer match any row
+// in the parent table are selected (see buildFKPartialOrphans). Such a row can
+// match multiple rows in the mutation input, so the result is de-duplicated on
+// the child's primary key.
 func (b *Builder) buildUpdateCascadeMutationInput(
+	parentTable cat.Table,
 	childTable cat.Table,
 	childTableAlias *tree.TableName,
 	fk cat.ForeignKeyConstraint,
@@ -762,6 +805,7 @@ func (b *Builder) buildUpdateCascadeMutationInput(
 		noRowLocking,
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
 	)
 
 	numFKCols := fk.ColumnCount()
@@ -851,15 +895,18 @@ func (b *Builder) buildUpdateCascadeMutationInput(
 		memo.FiltersExpr{f.ConstructFiltersItem(condition)},
 	)
 
-	on := make(memo.FiltersExpr, numFKCols)
-	for i := range on {
+	fkCols := make(opt.ColList, numFKCols)
+	for i := range fkCols {
 		tabOrd := fk.OriginColumnOrdinal(childTable, i)
-		col := outScope.getColumnForTableOrdinal(tabOrd)
-		on[i] = f.ConstructFiltersItem(f.ConstructEq(
-			f.ConstructVariable(col.id),
-			f.ConstructVariable(outColsOld[i]),
-		))
+		fkCols[i] = outScope.getColumnForTableOrdinal(tabOrd).id
+	}
+	isPartial := fk.MatchMethod() == tree.MatchPartial
+	if isPartial {
+		outScope.expr = b.buildFKPartialOrphans(
+			parentTable, fkReferencedOrdinals(fk, parentTable), outScope.expr, fkCols,
+		)
 	}
+	on := b.buildFKMatchFilters(fk.MatchMethod(), fkCols, outColsOld)
 	// This should conceptually be a semi-join, however we need to retain the "new
 	// value" columns from the right-hand side. Because the FK cols form a key in
 	// the parent table, there will be at most one match for any left row so an
@@ -884,6 +931,15 @@ func (b *Builder) buildUpdateCascadeMutationInput(
 			typ:  colMeta.Type,
 		})
 	}
+	if isPartial {
+		var pkCols opt.ColSet
+		primaryIndex := childTable.Index(cat.PrimaryIndex)
+		for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+			pkCols.Add(outScope.getColumnForTableOrdinal(primaryIndex.Column(i).Ordinal()).id)
+		}
+		outScope = b.buildDistinctOn(
+			pkCols, outScope, false /* nullsAreDistinct */, "" /* errorOnDup */)
+	}
 	return outScope
 }
 
@@ -900,6 +956,7 @@ func buildCascadeHelper(
 ) (_ memo.RelExpr, err error) {
 	factory := factoryI.(*norm.Factory)
 	b := New(ctx, semaCtx, evalCtx, catalog, factory, nil /* stmt */)
+	b.skipRowLevelSecurity = true
 
 	// Enact panic handling similar to Builder.Build().
 	defer func() {
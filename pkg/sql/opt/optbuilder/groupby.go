@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -96,6 +97,14 @@ type groupby struct {
 	// projects that expression.
 	groupStrs groupByStrSet
 
+	// groupingSets contains the grouping columns of each grouping set, if the
+	// GROUP BY clause contains ROLLUP, CUBE or GROUPING SETS. It is nil if there
+	// is only one grouping set, made up of all the grouping columns.
+	groupingSets []opt.ColSet
+
+	// groupingFns contains information about GROUPING expressions encountered.
+	groupingFns []*groupingInfo
+
 	// buildingGroupingCols is true while the grouping columns are being built.
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
@@ -279,6 +288,90 @@ func (a *aggregateInfo) ResolvedType() *types.T {
 var _ tree.Expr = &aggregateInfo{}
 var _ tree.TypedExpr = &aggregateInfo{}
 
+// groupingInfo stores information about a GROUPING expression.
+type groupingInfo struct {
+	*tree.GroupingFuncExpr
+
+	// args contains the typed arguments of the GROUPING expression.
+	args []tree.TypedExpr
+
+	// g is the groupby structure of the query level that GROUPING refers to.
+	g *groupby
+
+	// argCols contains the grouping columns that match the arguments. It is set
+	// when the expression is built.
+	argCols opt.ColList
+
+	// col is the output column of the GROUPING expression. It is set when the
+	// expression is built.
+	col *scopeColumn
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+// value returns the value of the GROUPING expression for rows produced by the
+// given grouping set: bit i, counting from the most significant bit, is set if
+// the i-th argument is not part of the grouping set.
+func (g *groupingInfo) value(set opt.ColSet) int64 {
+	var res int64
+	for _, col := range g.argCols {
+		res <<= 1
+		if !set.Contains(col) {
+			res |= 1
+		}
+	}
+	return res
+}
+
+var _ tree.Expr = &groupingInfo{}
+var _ tree.TypedExpr = &groupingInfo{}
+
+// buildGroupingFunc builds a reference to the output column of a GROUPING
+// expression. The column is synthesized the first time the expression is
+// built, after matching its arguments against the grouping columns.
+func (b *Builder) buildGroupingFunc(
+	info *groupingInfo, outScope *scope, outCol *scopeColumn, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	g := info.g
+	if info.col == nil {
+		info.argCols = make(opt.ColList, len(info.args))
+		for i, arg := range info.args {
+			col, ok := g.groupStrs[symbolicExprStr(arg)]
+			if !ok {
+				panic(pgerror.Newf(pgcode.Grouping,
+					"arguments to GROUPING must be grouping expressions of the associated query level",
+				))
+			}
+			info.argCols[i] = col.id
+		}
+		info.col = b.synthesizeColumn(
+			g.aggOutScope, scopeColName("grouping"), types.Int, info, nil, /* scalar */
+		)
+		g.groupingFns = append(g.groupingFns, info)
+	}
+	return b.finishBuildScalarRef(info.col, g.aggOutScope, outScope, outCol, colRefs)
+}
+
 func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 	// We have an aggregation if:
 	//  - we have a GROUP BY, or
@@ -322,6 +415,157 @@ func (b *Builder) constructGroupBy(
 	return b.factory.ConstructGroupBy(input, aggs, &private)
 }
 
+// constructGroupingSets constructs the aggregation for a query with several
+// grouping sets. The input is buffered by a With expression so that it is
+// only computed once, and each grouping set is aggregated over a separate scan
+// of the buffer. The results are combined with UNION ALL, using NULL for the
+// grouping columns that are not part of the grouping set of a row. The
+// results of any GROUPING expressions are computed along with each grouping
+// set.
+//
+// The output columns are the aggregate and grouping columns of aggOutScope,
+// followed by the columns of the GROUPING expressions.
+func (b *Builder) constructGroupingSets(
+	input memo.RelExpr, g *groupby, aggCols []scopeColumn,
+) memo.RelExpr {
+	md := b.factory.Metadata()
+	withID := b.factory.Memo().NextWithID()
+	md.AddWithBinding(withID, input)
+	inCols := input.Relational().OutputCols.ToList()
+
+	// Deduplicate the aggregations, as in constructGroupBy.
+	var aggColSet opt.ColSet
+	uniqueAggCols := make([]*scopeColumn, 0, len(aggCols))
+	for i := range aggCols {
+		if !aggColSet.Contains(aggCols[i].id) {
+			uniqueAggCols = append(uniqueAggCols, &aggCols[i])
+			aggColSet.Add(aggCols[i].id)
+		}
+	}
+	groupingCols := g.groupingCols()
+
+	newCol := func(id opt.ColumnID) opt.ColumnID {
+		meta := md.ColumnMeta(id)
+		return md.AddColumn(meta.Alias, meta.Type)
+	}
+
+	var outExpr memo.RelExpr
+	var outCols opt.ColList
+	for i, set := range g.groupingSets {
+		// Scan the buffered input, mapping its columns to new columns.
+		var colMap opt.ColMap
+		scanCols := make(opt.ColList, len(inCols))
+		for j, id := range inCols {
+			scanCols[j] = newCol(id)
+			colMap.Set(int(id), int(scanCols[j]))
+		}
+		var branch memo.RelExpr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    withID,
+			InCols:  inCols,
+			OutCols: scanCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		// Aggregate the grouping set.
+		cols := make(opt.ColList, 0, len(uniqueAggCols)+len(groupingCols)+len(g.groupingFns))
+		aggs := make(memo.AggregationsExpr, len(uniqueAggCols))
+		for j, col := range uniqueAggCols {
+			aggCol := newCol(col.id)
+			aggs[j] = b.factory.ConstructAggregationsItem(
+				b.factory.RemapCols(col.scalar, colMap), aggCol,
+			)
+			cols = append(cols, aggCol)
+		}
+		var private memo.GroupingPrivate
+		set.ForEach(func(col opt.ColumnID) {
+			c, _ := colMap.Get(int(col))
+			private.GroupingCols.Add(opt.ColumnID(c))
+		})
+		if private.GroupingCols.Empty() {
+			branch = b.factory.ConstructScalarGroupBy(branch, aggs, &private)
+		} else {
+			branch = b.factory.ConstructGroupBy(branch, aggs, &private)
+		}
+
+		// Project NULL for the grouping columns that are not in the grouping
+		// set, along with the results of the GROUPING expressions.
+		var projections memo.ProjectionsExpr
+		for j := range groupingCols {
+			col := &groupingCols[j]
+			if set.Contains(col.id) {
+				c, _ := colMap.Get(int(col.id))
+				cols = append(cols, opt.ColumnID(c))
+				continue
+			}
+			nullCol := newCol(col.id)
+			projections = append(projections, b.factory.ConstructProjectionsItem(
+				b.factory.ConstructNull(col.typ), nullCol,
+			))
+			cols = append(cols, nullCol)
+		}
+		for _, fn := range g.groupingFns {
+			fnCol := newCol(fn.col.id)
+			projections = append(projections, b.factory.ConstructProjectionsItem(
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(fn.value(set))), types.Int), fnCol,
+			))
+			cols = append(cols, fnCol)
+		}
+		if len(projections) > 0 {
+			branch = b.factory.ConstructProject(branch, projections, branch.Relational().OutputCols)
+		}
+
+		if i == 0 {
+			outExpr, outCols = branch, cols
+			continue
+		}
+
+		// Combine the grouping sets. The last UNION ALL produces the columns of
+		// aggOutScope.
+		unionCols := make(opt.ColList, len(cols))
+		for j := range unionCols {
+			if i < len(g.groupingSets)-1 {
+				unionCols[j] = newCol(outCols[j])
+			}
+		}
+		if i == len(g.groupingSets)-1 {
+			unionCols = unionCols[:0]
+			for _, col := range uniqueAggCols {
+				unionCols = append(unionCols, col.id)
+			}
+			for j := range groupingCols {
+				unionCols = append(unionCols, groupingCols[j].id)
+			}
+			for _, fn := range g.groupingFns {
+				unionCols = append(unionCols, fn.col.id)
+			}
+		}
+		outExpr = b.factory.ConstructUnionAll(outExpr, branch, &memo.SetPrivate{
+			LeftCols:  outCols,
+			RightCols: cols,
+			OutCols:   unionCols,
+		})
+		outCols = unionCols
+	}
+
+	return b.factory.ConstructWith(input, outExpr, &memo.WithPrivate{ID: withID})
+}
+
+// constructGroupingFns projects the results of any GROUPING expressions on top
+// of an aggregation with a single grouping set. Since all the grouping columns
+// are part of the grouping set, the results are always zero.
+func (b *Builder) constructGroupingFns(input memo.RelExpr, g *groupby) memo.RelExpr {
+	if len(g.groupingFns) == 0 {
+		return input
+	}
+	projections := make(memo.ProjectionsExpr, len(g.groupingFns))
+	for i, fn := range g.groupingFns {
+		projections[i] = b.factory.ConstructProjectionsItem(
+			b.factory.ConstructConstVal(tree.NewDInt(0), types.Int), fn.col.id,
+		)
+	}
+	return b.factory.ConstructProject(input, projections, input.Relational().OutputCols)
+}
+
 // buildGroupingColumns builds the grouping columns and adds them to the
 // groupby scopes that will be used to build the aggregation expression.
 // Returns the slice of grouping columns.
@@ -354,6 +598,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplementedWithIssueDetailf(46280, "ordered aggregate",
+				"ordering-sensitive aggregates are not supported with grouping sets"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -385,7 +633,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.def.Overload.UserDefinedAggregate != nil {
+			aggCols[i].scalar = b.constructUserDefinedAggregate(&aggInfos[i], args[0])
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -430,12 +682,17 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.constructGroupingSets(g.aggInScope.expr, g, aggCols)
+	} else {
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+		g.aggOutScope.expr = b.constructGroupingFns(g.aggOutScope.expr, g)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -509,10 +766,113 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
+	sets := []opt.ColSet{{}}
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		var itemSets []opt.ColSet
+		if gs, ok := e.(*tree.GroupingSet); ok {
+			itemSets = b.buildGroupingSet(gs, selects, projectionsScope, fromScope)
+		} else {
+			itemSets = []opt.ColSet{b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)}
+		}
+		sets = crossGroupingSets(sets, itemSets)
 	}
 	g.buildingGroupingCols = false
+
+	// A single grouping set always contains all the grouping columns, so there
+	// is no need to keep track of it.
+	if len(sets) > 1 {
+		g.groupingSets = sets
+	}
+}
+
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can expand to.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements of a CUBE. It matches the
+// limit enforced by Postgres.
+const maxCubeElements = 12
+
+// buildGroupingSet builds the grouping columns of a ROLLUP, CUBE or GROUPING
+// SETS item of a GROUP BY clause, and returns the grouping sets that it
+// expands to. For example, ROLLUP(a, b) expands to (a, b), (a) and (), while
+// CUBE(a, b) expands to (a, b), (a), (b) and ().
+func (b *Builder) buildGroupingSet(
+	gs *tree.GroupingSet, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	aggInScope := fromScope.groupby.aggInScope
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		sets := make([]opt.ColSet, len(gs.Exprs)+1)
+		for i, e := range gs.Exprs {
+			sets[i+1] = sets[i].Union(b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope))
+		}
+		// List the sets from the finest to the coarsest.
+		for i, j := 0, len(sets)-1; i < j; i, j = i+1, j-1 {
+			sets[i], sets[j] = sets[j], sets[i]
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.StatementTooComplex,
+				"CUBE is limited to %d elements", maxCubeElements,
+			))
+		}
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+		}
+		// List the subsets from the finest to the coarsest: bit i of the mask is
+		// set if the i-th element is left out of the set.
+		sets := make([]opt.ColSet, 0, 1<<len(elems))
+		for mask := 0; mask < 1<<len(elems); mask++ {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<i) == 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.ExplicitGroupingSets:
+		var sets []opt.ColSet
+		for _, e := range gs.Exprs {
+			if nested, ok := e.(*tree.GroupingSet); ok {
+				sets = append(sets, b.buildGroupingSet(nested, selects, projectionsScope, fromScope)...)
+			} else {
+				sets = append(sets, b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope))
+			}
+			if len(sets) > maxGroupingSets {
+				panic(errTooManyGroupingSets)
+			}
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %d", gs.Type))
+	}
+}
+
+var errTooManyGroupingSets = pgerror.Newf(pgcode.StatementTooComplex,
+	"too many grouping sets present (maximum %d)", maxGroupingSets,
+)
+
+// crossGroupingSets returns the grouping sets formed by the union of each
+// grouping set in left with each grouping set in right.
+func crossGroupingSets(left, right []opt.ColSet) []opt.ColSet {
+	if len(left)*len(right) > maxGroupingSets {
+		panic(errTooManyGroupingSets)
+	}
+	res := make([]opt.ColSet, 0, len(left)*len(right))
+	for _, l := range left {
+		for _, r := range right {
+			res = append(res, l.Union(r))
+		}
+	}
+	return res
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
@@ -535,9 +895,11 @@ func (b *Builder) buildGroupingList(
 // aggInScope       The scope that will contain the grouping expressions as well
 //
 //	as the aggregate function arguments.
+//
+// Returns the set of grouping columns for the expression.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -635,7 +997,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -645,7 +1008,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -698,11 +1063,29 @@ func (b *Builder) buildAggregateFunction(
 ) *aggregateInfo {
 	tempScopeColsBefore := len(tempScope.cols)
 
+	exprs := f.Exprs
+	if def.Overload.UserDefinedAggregate != nil {
+		if f.OrderBy != nil {
+			panic(unimplementedWithIssueDetailf(74775, "ordered user-defined aggregate",
+				"ORDER BY is not supported for user-defined aggregates"))
+		}
+		if len(exprs) > 1 {
+			// The arguments of a user-defined aggregate are passed to it as a
+			// single tuple, because the input of an aggregate operator must be a
+			// single column.
+			contents := make([]*types.T, len(exprs))
+			for i, e := range exprs {
+				contents[i] = e.(tree.TypedExpr).ResolvedType()
+			}
+			exprs = tree.Exprs{tree.NewTypedTuple(types.MakeTuple(contents), exprs)}
+		}
+	}
+
 	info := aggregateInfo{
 		FuncExpr: f,
 		def:      *def,
 		distinct: (f.Type == tree.DistinctFuncType),
-		args:     make(memo.ScalarListExpr, len(f.Exprs)),
+		args:     make(memo.ScalarListExpr, len(exprs)),
 	}
 
 	// Temporarily set b.subquery to nil so we don't add outer columns to the
@@ -711,7 +1094,7 @@ func (b *Builder) buildAggregateFunction(
 	b.subquery = nil
 	defer func() { b.subquery = subq }()
 
-	for i, pexpr := range f.Exprs {
+	for i, pexpr := range exprs {
 		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
 	}
 
@@ -896,6 +1279,85 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
 }
 
+// constructUserDefinedAggregate constructs a UserDefinedAgg operator that
+// computes the given user-defined aggregate over the given input.
+func (b *Builder) constructUserDefinedAggregate(
+	agg *aggregateInfo, input opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := agg.def.Overload
+	uda := o.UserDefinedAggregate
+	b.factory.Metadata().AddUserDefinedFunction(o, agg.Func.ReferenceByName)
+
+	// The state function is called with the current state followed by the
+	// arguments of the aggregate.
+	argTypes := o.Types.Types()
+	stateFuncArgTypes := make([]*types.T, 0, len(argTypes)+1)
+	stateFuncArgTypes = append(stateFuncArgTypes, uda.StateType)
+	stateFuncArgTypes = append(stateFuncArgTypes, argTypes...)
+	def := &memo.UserDefinedAggDefinition{
+		StateType: uda.StateType,
+		StateFunc: b.buildAggregateSupportFunc(uda.StateFunc, stateFuncArgTypes),
+		InitCond:  tree.DNull,
+		NumArgs:   len(argTypes),
+	}
+	if uda.FinalFunc != 0 {
+		def.FinalFunc = b.buildAggregateSupportFunc(uda.FinalFunc, []*types.T{uda.StateType})
+	}
+	if uda.CombineFunc != 0 {
+		def.CombineFunc = b.buildAggregateSupportFunc(
+			uda.CombineFunc, []*types.T{uda.StateType, uda.StateType},
+		)
+	}
+	if uda.InitCond != nil {
+		d, _, err := tree.ParseAndRequireString(uda.StateType, *uda.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		def.InitCond = d
+	}
+	return b.factory.ConstructUserDefinedAgg(input, &memo.UserDefinedAggPrivate{
+		Name: agg.def.Name,
+		Typ:  agg.ResolvedType(),
+		Def:  def,
+	})
+}
+
+// buildAggregateSupportFunc builds the definition of a support function of a
+// user-defined aggregate, given the OID of the function and the types of its
+// arguments. The arguments are supplied by the aggregator when the function is
+// evaluated.
+func (b *Builder) buildAggregateSupportFunc(
+	funcOID oid.Oid, argTypes []*types.T,
+) *memo.UDFDefinition {
+	ref := tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: funcOID}}
+	def, err := ref.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	o := def.Overloads[0].Overload
+	args := make(tree.TypedExprs, len(argTypes))
+	for i := range args {
+		args[i] = tree.DNull
+	}
+	f := tree.NewTypedFuncExpr(
+		ref,
+		0, /* aggQualifier */
+		args,
+		nil, /* filter */
+		nil, /* windowDef */
+		o.ReturnType(nil /* args */),
+		&o.FunctionProperties,
+		o,
+	)
+	// Disable normalization so that the call is not inlined or folded, since
+	// only its definition is used.
+	var udf opt.ScalarExpr
+	b.factory.DisableOptimizationsTemporarily(func() {
+		udf = b.buildUDF(f, def, b.allocScope(), nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	})
+	return udf.(*memo.UDFCallExpr).Def
+}
+
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.AggregateClass)
 }
@@ -931,6 +1393,10 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The grouping columns are not grouped on in every grouping set.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -959,6 +1425,9 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.IsExclusion() {
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
@@ -281,6 +281,10 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	// both cases, include columns undergoing mutations in the write-only state.
 	mb.addSynthesizedColsForInsert()
 
+	// Call the functions of any BEFORE INSERT triggers, which may modify or
+	// skip the rows to insert.
+	mb.buildBeforeTriggers(tree.TriggerEventInsert)
+
 	// Set insertExpr. This expression is used when building uniqueness checks.
 	// See mutationBuilder.buildCheckInputScan.
 	mb.insertExpr = mb.outScope.expr
@@ -320,6 +324,15 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 			// derived from the primary index as the join condition.
 			mb.buildInputForUpsert(inScope, nil /* onConflict */, nil /* whereClause */)
 
+			// The columns that are updated when a conflict occurs are the
+			// targets of the update, which determine the UPDATE OF triggers
+			// that fire.
+			for i := range mb.updateColIDs {
+				if mb.updateColIDs[i] != 0 {
+					mb.targetColSet.Add(mb.tabID.ColumnID(i))
+				}
+			}
+
 			// Add additional columns for computed expressions that may depend on any
 			// updated columns, as well as mutation columns with default values.
 			mb.addSynthesizedColsForUpdate()
@@ -366,6 +379,18 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 // of edge cases (that caused real correctness bugs #13437 #13962). As a result,
 // this support was removed and needs to re-enabled. See #14482.
 func (mb *mutationBuilder) needExistingRows() bool {
+	// Existing rows that are not visible according to the row-level security
+	// policies of the table must not be overwritten.
+	if mb.b.isSubjectToRowLevelSecurity(mb.tab) {
+		return true
+	}
+
+	// Triggers need the existing rows to tell the inserted rows from the
+	// updated ones, and to pass the OLD record to UPDATE triggers.
+	if mb.hasTriggers() {
+		return true
+	}
+
 	if mb.tab.DeletableIndexCount() > 1 {
 		return true
 	}
@@ -688,6 +713,8 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -879,6 +906,10 @@ func (mb *mutationBuilder) setUpsertCols(insertCols tree.NameList) {
 // buildUpsert constructs an Upsert operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
+	// Call the functions of any BEFORE UPDATE triggers for the rows that
+	// conflict with existing rows.
+	mb.buildBeforeTriggers(tree.TriggerEventUpdate)
+
 	// Merge input insert and update columns using CASE expressions.
 	mb.projectUpsertColumns()
 
@@ -911,6 +942,9 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
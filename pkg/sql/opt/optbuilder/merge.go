@@ -0,0 +1,533 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for an UpsertOp expression that implements a
+// MERGE statement. The source is left-joined to the target table using the ON
+// condition, and each joined row is assigned the WHEN clause that applies to
+// it. For example:
+//
+//	CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT)
+//	MERGE INTO abc USING xyz ON a = x
+//	WHEN MATCHED AND z = 0 THEN DELETE
+//	WHEN MATCHED THEN UPDATE SET b = y
+//	WHEN NOT MATCHED THEN INSERT VALUES (x, y, z)
+//
+// This would create an input expression similar to this SQL:
+//
+//	SELECT
+//	  x, y, z, fetch_a, fetch_b, fetch_c,
+//	  CASE WHEN action = 3 THEN x ELSE fetch_a END AS ins_a,
+//	  CASE WHEN action = 3 THEN y ELSE fetch_b END AS ins_b,
+//	  CASE WHEN action = 3 THEN z ELSE fetch_c END AS ins_c,
+//	  CASE WHEN action = 2 THEN y ELSE fetch_b END AS upd_b,
+//	  action IN (1) AS del
+//	FROM (
+//	  SELECT DISTINCT ON (fetch_a) *
+//	  FROM (
+//	    SELECT *,
+//	      CASE
+//	        WHEN fetch_a IS NOT NULL AND z = 0 THEN 1
+//	        WHEN fetch_a IS NOT NULL THEN 2
+//	        WHEN fetch_a IS NULL THEN 3
+//	      END AS action
+//	    FROM xyz LEFT JOIN abc AS fetch ON a = x
+//	  )
+//	  WHERE action IS NOT NULL
+//	)
+//
+// The DISTINCT ON (which treats NULLs as distinct) raises an error if the
+// same target row is matched by more than one source row. The Upsert operator
+// then inserts a row if the canary column (fetch_a) is null, deletes the
+// existing row if the delete column is true, and otherwise updates it. Rows
+// for which the WHEN clause is DO NOTHING, or for which no WHEN clause
+// applies, are filtered out before reaching the Upsert.
+//
+// Insert values fall back to the existing values for matched rows (and update
+// values fall back to the existing values for rows not being updated), so that
+// constraint checks and computed columns see well-formed rows whatever action
+// is taken.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, check the permissions. Select
+	// permission is always needed, since existing values must be read.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	var hasUpdate, hasDelete, hasInsert bool
+	for _, when := range merge.Whens {
+		switch when.Action {
+		case tree.MergeActionUpdate:
+			hasUpdate = true
+		case tree.MergeActionDelete:
+			hasDelete = true
+		case tree.MergeActionInsert:
+			hasInsert = true
+		}
+	}
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+		if tab.InboundForeignKeyCount() > 0 {
+			panic(unimplemented.Newf("merge delete fk",
+				"MERGE ... THEN DELETE is not supported on tables referenced by foreign keys"))
+		}
+	}
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, generalMutation)
+
+	if b.isSubjectToRowLevelSecurity(tab) {
+		panic(unimplemented.Newf("merge row-level security",
+			"MERGE is not supported on tables with row-level security policies"))
+	}
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Left-join the source to the target table, and determine the action
+	// taken for each joined row.
+	sourceScope := mb.buildInputForMerge(inScope, merge)
+	actionColID := mb.addMergeActionCol(sourceScope, merge.Whens)
+
+	// Build the values of the INSERT and UPDATE actions.
+	mb.addMergeCols(sourceScope, merge.Whens, actionColID)
+
+	// Add additional columns for computed expressions that may depend on any
+	// updated columns, as well as mutation columns with default values.
+	if hasUpdate {
+		mb.addSynthesizedColsForUpdate()
+	}
+
+	if hasInsert {
+		mb.addSynthesizedComputedColsForMerge()
+	} else {
+		// No rows are inserted, so use the existing values as insert values.
+		// The Upsert never inserts them, since the canary column is never null.
+		for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+			if kind := mb.tab.Column(i).Kind(); kind == cat.Ordinary || kind == cat.WriteOnly {
+				mb.insertColIDs[i] = mb.fetchColIDs[i]
+			}
+		}
+	}
+
+	if hasDelete {
+		mb.addMergeDeleteCol(merge.Whens, actionColID)
+	}
+
+	// Build the final upsert statement. MERGE does not support RETURNING.
+	mb.buildUpsert(nil /* returning */)
+
+	return mb.outScope
+}
+
+// buildInputForMerge left-joins the source of a MERGE statement to the target
+// table using the ON condition. It records the primary key column of the
+// target table as the canary column, which is null for source rows that do
+// not match a target row. It returns the scope of the source columns.
+func (mb *mutationBuilder) buildInputForMerge(inScope *scope, merge *tree.Merge) *scope {
+	var indexFlags *tree.IndexFlags
+	if source, ok := merge.Table.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
+		indexFlags = source.IndexFlags
+	}
+
+	// Fetch columns from a different instance of the table metadata, so that
+	// it's possible to remap columns. See buildInputForUpdate.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+		nil,   /* sample */
+	)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	sourceScope := mb.b.buildFromTables(tree.TableExprs{merge.Source}, noRowLocking, inScope)
+
+	// Check that the same table name is not used multiple times.
+	mb.b.validateJoinTableNames(mb.fetchScope, sourceScope)
+
+	// We create a new scope so that fetchScope is not modified. It will be used
+	// later to build partial index predicate expressions.
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+
+	on := mb.b.resolveAndBuildScalar(
+		merge.On,
+		types.Bool,
+		exprKindOn,
+		tree.RejectGenerators|tree.RejectWindowApplications,
+		mb.outScope,
+	)
+	mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
+		sourceScope.expr,
+		mb.fetchScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(on)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// Record a not-null "canary" column. After the left-join, this will be null
+	// if the source row does not match a target row.
+	mb.canaryColID = mb.fetchColIDs[findNotNullIndexCol(mb.tab.Index(cat.PrimaryIndex))]
+
+	return sourceScope
+}
+
+// addMergeActionCol projects an INT column containing the 1-based ordinal of
+// the first WHEN clause that applies to each row, or NULL if there is no such
+// clause or if that clause is DO NOTHING. Rows with a NULL action are then
+// filtered out, and the remaining rows are checked to ensure that no target row
+// is affected more than once. It returns the ID of the action column.
+func (mb *mutationBuilder) addMergeActionCol(
+	sourceScope *scope, whens tree.MergeWhens,
+) opt.ColumnID {
+	f := mb.b.factory
+	canary := f.ConstructVariable(mb.canaryColID)
+
+	// WHEN NOT MATCHED conditions can only refer to the source columns.
+	notMatchedScope := mb.outScope.replace()
+	notMatchedScope.appendColumnsFromScope(sourceScope)
+
+	caseWhens := make(memo.ScalarListExpr, len(whens))
+	for i, when := range whens {
+		var cond opt.ScalarExpr
+		condScope := mb.outScope
+		if when.Matched {
+			cond = f.ConstructIsNot(canary, memo.NullSingleton)
+		} else {
+			cond = f.ConstructIs(canary, memo.NullSingleton)
+			condScope = notMatchedScope
+		}
+		if when.Cond != nil {
+			whenCond := mb.b.resolveAndBuildScalar(
+				when.Cond, types.Bool, exprKindMergeWhen, tree.RejectSpecial, condScope,
+			)
+			cond = f.ConstructAnd(cond, whenCond)
+		}
+		var val opt.ScalarExpr
+		if when.Action == tree.MergeActionDoNothing {
+			val = f.ConstructNull(types.Int)
+		} else {
+			val = f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int)
+		}
+		caseWhens[i] = f.ConstructWhen(cond, val)
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	caseExpr := f.ConstructCase(memo.TrueSingleton, caseWhens, f.ConstructNull(types.Int))
+	name := scopeColName("").WithMetadataName("merge_action")
+	actionCol := mb.b.synthesizeColumn(projectionsScope, name, types.Int, nil /* expr */, caseExpr)
+	actionColID := actionCol.id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Filter out rows that are not affected by the MERGE.
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(
+			f.ConstructIsNot(f.ConstructVariable(actionColID), memo.NullSingleton),
+		)},
+	)
+
+	// Ensure that each target row is affected at most once. Unmatched rows have
+	// null primary key columns, so they are never considered duplicates.
+	var pkCols opt.ColSet
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.outScope.ordering = nil
+	mb.outScope = mb.b.buildDistinctOn(
+		pkCols, mb.outScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+	)
+
+	return actionColID
+}
+
+// addMergeCols projects the values of the INSERT and UPDATE actions of the
+// given WHEN clauses. For each target table column, a CASE expression chooses
+// the value of the action that applies to each row, or else the existing value
+// of the column. The resulting columns are recorded in insertColIDs and
+// updateColIDs.
+func (mb *mutationBuilder) addMergeCols(
+	sourceScope *scope, whens tree.MergeWhens, actionColID opt.ColumnID,
+) {
+	// SET and VALUES expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE", tree.RejectSpecial)
+
+	f := mb.b.factory
+	n := mb.tab.ColumnCount()
+	insertVals := make([]memo.ScalarListExpr, n)
+	updateVals := make([]memo.ScalarListExpr, n)
+
+	// INSERT values can only refer to the source columns.
+	insertScope := mb.outScope.replace()
+	insertScope.appendColumnsFromScope(sourceScope)
+
+	for i, when := range whens {
+		isAction := f.ConstructEq(
+			f.ConstructVariable(actionColID),
+			f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+		)
+		switch when.Action {
+		case tree.MergeActionUpdate:
+			mb.addTargetColsForMergeUpdate(when.Exprs)
+			col := 0
+			addVal := func(expr tree.Expr) {
+				ord := mb.tabID.ColumnOrdinal(mb.targetColList[col])
+				col++
+				if _, ok := expr.(tree.DefaultVal); !ok {
+					if tabCol := mb.tab.Column(ord); tabCol.IsGeneratedAlwaysAsIdentity() {
+						panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(tabCol.ColName())))
+					}
+				}
+				val := mb.buildMergeValue(expr, ord, mb.outScope)
+				updateVals[ord] = append(updateVals[ord], f.ConstructWhen(isAction, val))
+			}
+			for _, set := range when.Exprs {
+				if set.Tuple {
+					for _, expr := range set.Expr.(*tree.Tuple).Exprs {
+						addVal(expr)
+					}
+				} else {
+					addVal(set.Expr)
+				}
+			}
+
+		case tree.MergeActionInsert:
+			var vals tree.Exprs
+			if when.Values != nil {
+				if len(when.Columns) != 0 {
+					mb.addTargetNamedColsForInsert(when.Columns)
+				} else {
+					mb.addTargetTableColsForInsert(len(when.Values))
+				}
+				mb.checkNumCols(len(mb.targetColList), len(when.Values))
+				vals = when.Values
+			}
+			var explicit opt.ColSet
+			for j, colID := range mb.targetColList {
+				ord := mb.tabID.ColumnOrdinal(colID)
+				expr := vals[j]
+				if _, ok := expr.(tree.DefaultVal); !ok {
+					if tabCol := mb.tab.Column(ord); tabCol.IsGeneratedAlwaysAsIdentity() {
+						panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(tabCol.ColName())))
+					}
+				}
+				val := mb.buildMergeValue(expr, ord, insertScope)
+				insertVals[ord] = append(insertVals[ord], f.ConstructWhen(isAction, val))
+				explicit.Add(colID)
+			}
+
+			// Use default values for all other columns, including write-only
+			// mutation columns. Computed columns are added later.
+			for ord := 0; ord < n; ord++ {
+				tabCol := mb.tab.Column(ord)
+				if kind := tabCol.Kind(); kind != cat.Ordinary && kind != cat.WriteOnly {
+					continue
+				}
+				if tabCol.IsComputed() || explicit.Contains(mb.tabID.ColumnID(ord)) {
+					continue
+				}
+				val := mb.buildMergeValue(tree.DefaultVal{}, ord, insertScope)
+				insertVals[ord] = append(insertVals[ord], f.ConstructWhen(isAction, val))
+			}
+		}
+		mb.targetColList = mb.targetColList[:0]
+		mb.targetColSet = opt.ColSet{}
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for ord := 0; ord < n; ord++ {
+		tabCol := mb.tab.Column(ord)
+		if insertVals[ord] != nil {
+			caseExpr := f.ConstructCase(
+				memo.TrueSingleton, insertVals[ord], f.ConstructVariable(mb.fetchColIDs[ord]),
+			)
+			name := scopeColName("").WithMetadataName(fmt.Sprintf("%s_ins", tabCol.ColName()))
+			scopeCol := mb.b.synthesizeColumn(
+				projectionsScope, name, tabCol.DatumType(), nil /* expr */, caseExpr,
+			)
+			mb.insertColIDs[ord] = scopeCol.id
+		}
+		if updateVals[ord] != nil {
+			caseExpr := f.ConstructCase(
+				memo.TrueSingleton, updateVals[ord], f.ConstructVariable(mb.fetchColIDs[ord]),
+			)
+			name := scopeColName(tabCol.ColName()).WithMetadataName(
+				fmt.Sprintf("%s_new", tabCol.ColName()),
+			)
+			scopeCol := mb.b.synthesizeColumn(
+				projectionsScope, name, tabCol.DatumType(), nil /* expr */, caseExpr,
+			)
+			mb.updateColIDs[ord] = scopeCol.id
+		}
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// addTargetColsForMergeUpdate adds the columns named by the SET expressions of
+// a WHEN MATCHED THEN UPDATE clause to the list of target columns, and checks
+// that each SET expression provides exactly as many values as are expected by
+// the named columns.
+func (mb *mutationBuilder) addTargetColsForMergeUpdate(exprs tree.UpdateExprs) {
+	for _, expr := range exprs {
+		mb.addTargetColsByName(expr.Names)
+
+		if expr.Tuple {
+			switch t := expr.Expr.(type) {
+			case *tree.Subquery:
+				panic(unimplemented.Newf("merge update subquery",
+					"MERGE ... UPDATE SET with a subquery tuple is not supported"))
+			case *tree.Tuple:
+				mb.checkNumCols(len(expr.Names), len(t.Exprs))
+			default:
+				panic(pgerror.Newf(pgcode.Syntax,
+					"source for a multiple-column UPDATE item must be a sub-SELECT or ROW() expression; not %T",
+					expr.Expr))
+			}
+		}
+	}
+}
+
+// buildMergeValue builds the given INSERT or SET expression of a MERGE
+// statement for the table column with the given ordinal. DEFAULT is replaced
+// with the column's default value, and an assignment cast is added if the type
+// of the expression differs from the column type.
+func (mb *mutationBuilder) buildMergeValue(
+	expr tree.Expr, ord int, inScope *scope,
+) opt.ScalarExpr {
+	tabCol := mb.tab.Column(ord)
+	targetType := tabCol.DatumType()
+	if _, ok := expr.(tree.DefaultVal); ok {
+		expr = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+	}
+	texpr := inScope.resolveType(expr, targetType)
+	val := mb.b.buildScalar(texpr, inScope, nil, nil, nil)
+	if srcType := texpr.ResolvedType(); !srcType.Identical(targetType) {
+		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(tabCol.ColName())))
+		}
+		val = mb.b.factory.ConstructAssignmentCast(val, targetType)
+	}
+	return val
+}
+
+// addSynthesizedComputedColsForMerge projects the values of computed columns
+// for inserted rows. Unlike addSynthesizedComputedCols, column references in
+// the computed column expressions always refer to the insert columns, even
+// though update columns are present.
+func (mb *mutationBuilder) addSynthesizedComputedColsForMerge() {
+	resolveScope := mb.b.allocScope()
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		colID := mb.insertColIDs[i]
+		if colID == 0 {
+			colID = mb.fetchColIDs[i]
+		}
+		if colID == 0 {
+			continue
+		}
+		resolveScope.cols = append(resolveScope.cols, scopeColumn{
+			name: scopeColName(mb.tab.Column(i).ColName()),
+			typ:  mb.md.ColumnMeta(colID).Type,
+			id:   colID,
+		})
+	}
+
+	pb := makeProjectionBuilder(mb.b, mb.outScope)
+	pb.SetResolveScope(resolveScope)
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		tabCol := mb.tab.Column(i)
+		if kind := tabCol.Kind(); kind != cat.Ordinary && kind != cat.WriteOnly {
+			continue
+		}
+		if !tabCol.IsComputed() {
+			continue
+		}
+		tabColID := mb.tabID.ColumnID(i)
+		expr := mb.parseComputedExpr(tabColID)
+		colName := scopeColName(tabCol.ColName()).WithMetadataName(
+			string(tabCol.ColName()) + "_comp",
+		)
+		mb.insertColIDs[i], _ = pb.Add(colName, expr, tabCol.DatumType())
+	}
+	mb.outScope = pb.Finish()
+
+	// Add assignment casts for computed column values.
+	mb.addAssignmentCasts(mb.insertColIDs)
+}
+
+// addMergeDeleteCol projects a boolean column that is true for rows whose WHEN
+// clause is a DELETE action, and records it as the delete column.
+func (mb *mutationBuilder) addMergeDeleteCol(whens tree.MergeWhens, actionColID opt.ColumnID) {
+	f := mb.b.factory
+	var deleteActions memo.ScalarListExpr
+	var tupleTypes []*types.T
+	for i, when := range whens {
+		if when.Action == tree.MergeActionDelete {
+			deleteActions = append(deleteActions,
+				f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int))
+			tupleTypes = append(tupleTypes, types.Int)
+		}
+	}
+	isDelete := f.ConstructIn(
+		f.ConstructVariable(actionColID),
+		f.ConstructTuple(deleteActions, types.MakeTuple(tupleTypes)),
+	)
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	name := scopeColName("").WithMetadataName("merge_delete")
+	deleteCol := mb.b.synthesizeColumn(projectionsScope, name, types.Bool, nil /* expr */, isDelete)
+	mb.deleteColID = deleteCol.id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
@@ -153,3 +153,32 @@ func (b *Builder) buildCreateStatistics(n *tree.CreateStats, inScope *scope) (ou
 	})
 	return outScope
 }
+
+func (b *Builder) buildCall(c *tree.Call, inScope *scope) (outScope *scope) {
+	// We don't allow the procedure arguments to reference outer columns, so we
+	// pass a "blank" scope rather than inScope.
+	emptyScope := b.allocScope()
+	typedProc := emptyScope.resolveType(c.Proc, types.Any)
+	proc := b.buildScalar(
+		typedProc, emptyScope, nil /* outScope */, nil /* outCol */, nil, /* colRefs */
+	)
+
+	// A procedure with OUT or INOUT parameters returns a single row with a
+	// column for each of those parameters.
+	var resultCols colinfo.ResultColumns
+	if typ := typedProc.ResolvedType(); typ.Family() == types.TupleFamily {
+		resultCols = make(colinfo.ResultColumns, len(typ.TupleContents()))
+		for i := range typ.TupleContents() {
+			resultCols[i] = colinfo.ResultColumn{
+				Name: typ.TupleLabels()[i],
+				Typ:  typ.TupleContents()[i],
+			}
+		}
+	}
+	outScope = inScope.push()
+	b.synthesizeResultColumns(outScope, resultCols)
+	outScope.expr = b.factory.ConstructCall(
+		proc, &memo.CallPrivate{Columns: colsToColList(outScope.cols)},
+	)
+	return outScope
+}
@@ -138,6 +138,11 @@ type mutationBuilder struct {
 	// an insert; otherwise it's an update.
 	canaryColID opt.ColumnID
 
+	// deleteColID is the ID of the boolean column that is used by an Upsert
+	// built for a MERGE statement to decide whether an existing row should be
+	// deleted rather than updated. It is 0 for all other mutations.
+	deleteColID opt.ColumnID
+
 	// arbiters is the set of indexes and unique constraints that are used to
 	// detect conflicts for UPSERT and INSERT ON CONFLICT statements.
 	arbiters arbiterSet
@@ -297,7 +302,9 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		noRowLocking,
 		inScope,
 		false, /* disableNotVisibleIndex */
+		nil,   /* sample */
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandUpdate)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -408,7 +415,9 @@ func (mb *mutationBuilder) buildInputForDelete(
 		noRowLocking,
 		inScope,
 		false, /* disableNotVisibleIndex */
+		nil,   /* sample */
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandDelete)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -785,6 +794,11 @@ func (mb *mutationBuilder) addSynthesizedComputedCols(colIDs opt.OptionalColList
 // is true, check columns that do not reference mutation columns are not added
 // to checkColIDs, which allows pruning normalization rules to remove the
 // unnecessary projected column.
+//
+// If row-level security is enabled on the table, the row-level security
+// placeholder check is built from the WITH CHECK expressions of the policies
+// that apply to the current user. It is omitted if the user is not subject to
+// row-level security.
 func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 	if mb.tab.CheckCount() != 0 {
 		projectionsScope := mb.outScope.replace()
@@ -792,9 +806,17 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 		mutationCols := mb.mutationColumnIDs()
 
 		for i, n := 0, mb.tab.CheckCount(); i < n; i++ {
-			expr, err := parser.ParseExpr(mb.tab.Check(i).Constraint)
-			if err != nil {
-				panic(err)
+			check := mb.tab.Check(i)
+			var expr tree.Expr
+			if check.RowLevelSecurity {
+				if expr = mb.rowLevelSecurityCheckExpr(isUpdate); expr == nil {
+					continue
+				}
+			} else {
+				var err error
+				if expr, err = parser.ParseExpr(check.Constraint); err != nil {
+					panic(err)
+				}
 			}
 
 			texpr := mb.outScope.resolveAndRequireType(expr, types.Bool)
@@ -812,8 +834,9 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 			// If the mutation is not an UPDATE, track the synthesized check
 			// columns in checkColIDS. If the mutation is an UPDATE, only track
 			// the check columns if the columns referenced in the check
-			// expression are being mutated.
-			if !isUpdate || referencedCols.Intersects(mutationCols) {
+			// expression are being mutated. The row-level security check
+			// is always tracked, since the policies apply to the whole row.
+			if !isUpdate || check.RowLevelSecurity || referencedCols.Intersects(mutationCols) {
 				mb.checkColIDs[i] = scopeCol.id
 			}
 		}
@@ -995,6 +1018,7 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 		FetchCols:           checkEmptyList(mb.fetchColIDs),
 		UpdateCols:          checkEmptyList(mb.updateColIDs),
 		CanaryCol:           mb.canaryColID,
+		DeleteCol:           mb.deleteColID,
 		ArbiterIndexes:      mb.arbiters.IndexOrdinals(),
 		ArbiterConstraints:  mb.arbiters.UniqueConstraintOrdinals(),
 		CheckCols:           checkEmptyList(mb.checkColIDs),
@@ -78,6 +78,10 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 		for i, uc := 0, mb.tab.UniqueCount(); i < uc; i++ {
 			constraint := mb.tab.Unique(i)
 			if constraint.Name() == string(onConflict.Constraint) {
+				if constraint.IsExclusion() {
+					panic(pgerror.Newf(pgcode.FeatureNotSupported,
+						"ON CONFLICT is not supported with exclusion constraints"))
+				}
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
@@ -165,7 +169,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			// Exclusion constraints cannot be arbiters, because conflicts are not
+			// detected by equality of the constraint columns.
+			if mb.tab.Unique(uc).WithoutIndex() && !mb.tab.Unique(uc).IsExclusion() {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -223,6 +229,10 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			// Unique constraints with an index were handled above.
 			continue
 		}
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints cannot be arbiters.
+			continue
+		}
 
 		// Determine whether the conflict columns match the columns in the
 		// unique constraint. If not, the constraint cannot be an arbiter. We
@@ -293,6 +303,7 @@ func (mb *mutationBuilder) buildAntiJoinForDoNothingArbiter(
 		noRowLocking,
 		inScope,
 		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
 	)
 
 	// If the index is a unique partial index, then rows that are not in the
@@ -378,7 +389,11 @@ func (mb *mutationBuilder) buildLeftJoinForUpsertArbiter(
 		noRowLocking,
 		inScope,
 		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
 	)
+	// Existing rows that are not visible according to the row-level security
+	// policies of the table cannot be updated.
+	mb.b.addRowLevelSecurityFilter(mb.tab, mb.fetchScope, tree.PolicyCommandUpdate)
 	// Set fetchColIDs to reference the columns created for the fetch values.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
@@ -585,6 +600,7 @@ func (h *arbiterPredicateHelper) tableScope() *scope {
 			noRowLocking,
 			h.mb.b.allocScope(),
 			false, /* disableNotVisibleIndex */
+			nil,   /* sample */
 		)
 	}
 	return h.tableScopeLazy
@@ -664,6 +664,7 @@ func (h *fkCheckHelper) buildOtherTableScan(parent bool) (outScope *scope, tabMe
 		locking,
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
 	), otherTabMeta
 }
 
@@ -691,16 +692,21 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 		//  - MATCH FULL: only the case where *all* the columns are NULL is
 		//                allowed, and the row doesn't need to have a match in the
 		//                referenced table.
+		//  - MATCH PARTIAL: if all the columns are NULL, the row doesn't need to
+		//                   have a match in the referenced table. Otherwise, the
+		//                   non-NULL columns must match some referenced row.
 		//
 		// Note that rows that have NULLs will never have a match in the anti
-		// join and will generate errors. To handle these cases, we filter the
+		// join and will generate errors (except for MATCH PARTIAL, where the join
+		// filters ignore NULL columns). To handle these cases, we filter the
 		// mutated rows (before the anti join) to remove those which don't need a
 		// match.
 		//
 		// For SIMPLE, we filter out any rows which have a NULL. For FULL, we
 		// filter out any rows where all the columns are NULL (rows which have
 		// NULLs a subset of columns are let through and will generate FK errors
-		// because they will never have a match in the anti join).
+		// because they will never have a match in the anti join). PARTIAL is
+		// filtered like FULL.
 		switch m := h.fk.MatchMethod(); m {
 		case tree.MatchSimple:
 			// Filter out any rows which have a NULL; build filters of the form
@@ -718,7 +724,7 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 			}
 			withScanScope.expr = f.ConstructSelect(withScanScope.expr, filters)
 
-		case tree.MatchFull:
+		case tree.MatchFull, tree.MatchPartial:
 			// Filter out any rows which have NULLs on all referencing columns.
 			if !notNullWithScanCols.Empty() {
 				// We statically know that some of the referencing columns can't be
@@ -726,23 +732,9 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 				// where all the origin columns are NULL is not possible).
 				break
 			}
-			// Build a filter of the form
-			//   (a IS NOT NULL) OR (b IS NOT NULL) ...
-			var condition opt.ScalarExpr
-			for _, col := range withScanScope.cols {
-				is := f.ConstructIsNot(
-					f.ConstructVariable(col.id),
-					memo.NullSingleton,
-				)
-				if condition == nil {
-					condition = is
-				} else {
-					condition = f.ConstructOr(condition, is)
-				}
-			}
 			withScanScope.expr = f.ConstructSelect(
 				withScanScope.expr,
-				memo.FiltersExpr{f.ConstructFiltersItem(condition)},
+				h.mb.b.buildFKNotAllNullFilters(withScanScope.colList()),
 			)
 
 		default:
@@ -756,15 +748,9 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 
 	// Build the join filters:
 	//   (origin_a = referenced_a) AND (origin_b = referenced_b) AND ...
-	antiJoinFilters := make(memo.FiltersExpr, numCols)
-	for j := 0; j < numCols; j++ {
-		antiJoinFilters[j] = f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(withScanScope.cols[j].id),
-				f.ConstructVariable(scanScope.cols[j].id),
-			),
-		)
-	}
+	antiJoinFilters := h.mb.b.buildFKMatchFilters(
+		h.fk.MatchMethod(), withScanScope.colList(), scanScope.colList(),
+	)
 	var p memo.JoinPrivate
 	if h.mb.b.evalCtx.SessionData().PreferLookupJoinsForFKs {
 		p.Flags = memo.PreferLookupJoinIntoRight
@@ -798,14 +784,36 @@ func (h *fkCheckHelper) buildDeletionCheck(
 	// Build the join filters:
 	//   (origin_a = referenced_a) AND (origin_b = referenced_b) AND ...
 	f := h.mb.b.factory
-	semiJoinFilters := make(memo.FiltersExpr, len(deleteCols))
-	for j := range deleteCols {
-		semiJoinFilters[j] = f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(deleteCols[j]),
-				f.ConstructVariable(scanScope.cols[j].id),
-			),
+	var semiJoinFilters memo.FiltersExpr
+	if h.fk.MatchMethod() == tree.MatchPartial {
+		// With MATCH PARTIAL, the non-NULL columns of a child row can match
+		// several parent rows, so the child row is only orphaned if it matched a
+		// removed row and none of the remaining parent rows match it. Restrict
+		// the child rows to those which are not all NULL and have no match in
+		// the parent table:
+		//   SELECT ... FROM child
+		//   WHERE (a IS NOT NULL OR b IS NOT NULL)
+		//   AND NOT EXISTS (
+		//     SELECT * FROM parent
+		//     WHERE (child.a IS NULL OR child.a = parent.a)
+		//     AND (child.b IS NULL OR child.b = parent.b)
+		//   )
+		semiJoinFilters = h.mb.b.buildFKMatchFilters(
+			tree.MatchPartial, scanScope.colList(), deleteCols,
+		)
+		scanScope.expr = h.mb.b.buildFKPartialOrphans(
+			h.mb.tab, h.tabOrdinals, scanScope.expr, scanScope.colList(),
 		)
+	} else {
+		semiJoinFilters = make(memo.FiltersExpr, len(deleteCols))
+		for j := range deleteCols {
+			semiJoinFilters[j] = f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(deleteCols[j]),
+					f.ConstructVariable(scanScope.cols[j].id),
+				),
+			)
+		}
 	}
 	var p memo.JoinPrivate
 	if h.mb.b.evalCtx.SessionData().PreferLookupJoinsForFKs {
@@ -822,3 +830,83 @@ func (h *fkCheckHelper) buildDeletionCheck(
 		OpName:          h.mb.opName,
 	})
 }
+
+// buildFKMatchFilters builds the join filters that match the origin FK columns
+// of a child row to the referenced columns of a parent row. For MATCH SIMPLE
+// and MATCH FULL these are of the form:
+//
+//	(origin_a = referenced_a) AND (origin_b = referenced_b) AND ...
+//
+// For MATCH PARTIAL, only the non-NULL origin columns need to match:
+//
+//	(origin_a IS NULL OR origin_a = referenced_a) AND ...
+//
+// Note that for MATCH PARTIAL, rows with all NULL origin columns match every
+// referenced row, so they must be filtered out separately.
+func (b *Builder) buildFKMatchFilters(
+	match tree.CompositeKeyMatchMethod, originCols, referencedCols opt.ColList,
+) memo.FiltersExpr {
+	f := b.factory
+	filters := make(memo.FiltersExpr, len(originCols))
+	for j := range originCols {
+		var cond opt.ScalarExpr = f.ConstructEq(
+			f.ConstructVariable(originCols[j]),
+			f.ConstructVariable(referencedCols[j]),
+		)
+		if match == tree.MatchPartial {
+			cond = f.ConstructOr(
+				f.ConstructIs(f.ConstructVariable(originCols[j]), memo.NullSingleton),
+				cond,
+			)
+		}
+		filters[j] = f.ConstructFiltersItem(cond)
+	}
+	return filters
+}
+
+// buildFKNotAllNullFilters builds a filter which removes rows where all of
+// the given columns are NULL:
+//
+//	(a IS NOT NULL) OR (b IS NOT NULL) ...
+func (b *Builder) buildFKNotAllNullFilters(cols opt.ColList) memo.FiltersExpr {
+	f := b.factory
+	var condition opt.ScalarExpr
+	for _, col := range cols {
+		is := f.ConstructIsNot(
+			f.ConstructVariable(col),
+			memo.NullSingleton,
+		)
+		if condition == nil {
+			condition = is
+		} else {
+			condition = f.ConstructOr(condition, is)
+		}
+	}
+	return memo.FiltersExpr{f.ConstructFiltersItem(condition)}
+}
+
+// buildFKPartialOrphans restricts the given child rows of a MATCH PARTIAL FK
+// to those which are not all NULL in the origin columns and which no longer
+// match any row in the parent table. parentOrdinals are the ordinals of the
+// referenced columns in parentTab.
+func (b *Builder) buildFKPartialOrphans(
+	parentTab cat.Table, parentOrdinals []int, childRows memo.RelExpr, originCols opt.ColList,
+) memo.RelExpr {
+	f := b.factory
+	childRows = f.ConstructSelect(childRows, b.buildFKNotAllNullFilters(originCols))
+	parentScope := b.buildScan(
+		b.addTable(parentTab, tree.NewUnqualifiedTableName(parentTab.Name())),
+		parentOrdinals,
+		&tree.IndexFlags{IgnoreForeignKeys: true},
+		noRowLocking,
+		b.allocScope(),
+		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
+	)
+	return f.ConstructAntiJoin(
+		childRows,
+		parentScope.expr,
+		b.buildFKMatchFilters(tree.MatchPartial, originCols, parentScope.colList()),
+		memo.EmptyJoinPrivate,
+	)
+}
@@ -17,9 +17,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
 )
 
 // UniquenessChecksForGenRandomUUIDClusterMode controls the cluster setting for
@@ -33,7 +36,8 @@ var UniquenessChecksForGenRandomUUIDClusterMode = settings.RegisterBoolSetting(
 	settings.WithPublic)
 
 // buildUniqueChecksForInsert builds uniqueness check queries for an insert.
-// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints.
+// These check queries are used to enforce UNIQUE WITHOUT INDEX and EXCLUDE
+// constraints.
 func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 	// We only need to build unique checks if there is at least one unique
 	// constraint without an index.
@@ -64,7 +68,8 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 }
 
 // buildUniqueChecksForUpdate builds uniqueness check queries for an update.
-// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints.
+// These check queries are used to enforce UNIQUE WITHOUT INDEX and EXCLUDE
+// constraints.
 func (mb *mutationBuilder) buildUniqueChecksForUpdate() {
 	// We only need to build unique checks if there is at least one unique
 	// constraint without an index.
@@ -98,7 +103,8 @@ func (mb *mutationBuilder) buildUniqueChecksForUpdate() {
 }
 
 // buildUniqueChecksForUpsert builds uniqueness check queries for an upsert.
-// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints.
+// These check queries are used to enforce UNIQUE WITHOUT INDEX and EXCLUDE
+// constraints.
 func (mb *mutationBuilder) buildUniqueChecksForUpsert() {
 	// We only need to build unique checks if there is at least one unique
 	// constraint without an index.
@@ -198,7 +204,9 @@ type uniqueCheckHelper struct {
 	uniqueOrdinals intsets.Fast
 
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not included in uniqueOrdinals. For exclusion constraints, it
+	// includes all the primary key columns, since rows with equal values in
+	// the constraint columns may still differ in the primary key columns.
 	primaryKeyOrdinals intsets.Fast
 
 	// The scope and column ordinals of the scan that will serve as the right
@@ -220,9 +228,14 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds intsets.Fast
+	var uniqueOrds, eqOrds intsets.Fast
+	isExclusion := h.unique.IsExclusion()
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if !isExclusion || h.unique.ExclusionOperator(i).Symbol == treecmp.EQ {
+			eqOrds.Add(ord)
+		}
 	}
 
 	// Find the primary key columns that are not part of the unique constraint.
@@ -233,7 +246,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(eqOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -254,7 +267,11 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 
 		// If one of the columns is a UUID (or UUID casted to STRING or BYTES) set
 		// to gen_random_uuid() and we don't require uniqueness checks for
-		// gen_random_uuid(), unique check not needed.
+		// gen_random_uuid(), unique check not needed. This only applies to
+		// columns compared with equality.
+		if !eqOrds.Contains(tabOrd) {
+			continue
+		}
 		switch mb.md.ColumnMeta(colID).Type.Family() {
 		case types.UuidFamily, types.StringFamily, types.BytesFamily:
 			if columnIsGenRandomUUID(mb.outScope.expr, colID) {
@@ -293,6 +310,12 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	//
+	// This does not apply to exclusion constraints, since rows can conflict
+	// without being equal in the constraint columns.
+	if isExclusion {
+		return true
+	}
 	var uniqueCols opt.ColSet
 	h.uniqueOrdinals.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
@@ -318,6 +341,10 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// For exclusion constraints, each column is compared with the operator of
+	// the constraint instead:
+	//   (new_a op_a existing_a) AND (new_b op_b existing_b) AND ...
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -329,13 +356,26 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 		numFilters += 2
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(withScanScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+	if h.unique.IsExclusion() {
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			ord := h.unique.ColumnOrdinal(h.mb.tab, i)
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				h.constructExclusionComparison(
+					h.unique.ExclusionOperator(i),
+					withScanScope.cols[ord],
+					h.scanScope.cols[ord],
+				),
+			))
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(withScanScope.cols[i].id),
+					f.ConstructVariable(h.scanScope.cols[i].id),
+				),
+			))
+		}
 	}
 
 	// If the unique constraint is partial, we need to filter out inserted rows
@@ -359,6 +399,12 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// do this by adding another filter that uses the primary keys to check if
 	// two rows are identical:
 	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	//
+	// This is not necessary if an exclusion constraint already compares a
+	// primary key column with !=.
+	if h.pkComparedWithNe() {
+		return h.finishInsertionCheck(withScanScope, semiJoinFilters)
+	}
 	var pkFilter opt.ScalarExpr
 	for i, ok := h.primaryKeyOrdinals.Next(0); ok; i, ok = h.primaryKeyOrdinals.Next(i + 1) {
 		pkFilterLocal := f.ConstructNe(
@@ -372,14 +418,45 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 		}
 	}
 	semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(pkFilter))
+	return h.finishInsertionCheck(withScanScope, semiJoinFilters)
+}
 
+// pkComparedWithNe returns true if the constraint is an exclusion constraint
+// that compares one of the primary key columns with !=.
+func (h *uniqueCheckHelper) pkComparedWithNe() bool {
+	if !h.unique.IsExclusion() {
+		return false
+	}
+	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+		if h.unique.ExclusionOperator(i).Symbol == treecmp.NE &&
+			h.primaryKeyOrdinals.Contains(h.unique.ColumnOrdinal(h.mb.tab, i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// finishInsertionCheck builds the semi join of an insertion check with the
+// given filters, and the check itself.
+func (h *uniqueCheckHelper) finishInsertionCheck(
+	withScanScope *scope, semiJoinFilters memo.FiltersExpr,
+) memo.UniqueChecksItem {
+	f := h.mb.b.factory
 	semiJoin := f.ConstructSemiJoin(withScanScope.expr, h.scanScope.expr, semiJoinFilters, memo.EmptyJoinPrivate)
 
 	// Collect the key columns that will be shown in the error message if there
 	// is a duplicate key violation resulting from this uniqueness check.
 	keyCols := make(opt.ColList, 0, h.uniqueOrdinals.Len())
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		keyCols = append(keyCols, withScanScope.cols[i].id)
+	if h.unique.IsExclusion() {
+		// The key columns of an exclusion constraint are in the order in which
+		// they were declared.
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			keyCols = append(keyCols, withScanScope.cols[h.unique.ColumnOrdinal(h.mb.tab, i)].id)
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			keyCols = append(keyCols, withScanScope.cols[i].id)
+		}
 	}
 
 	// Create a Project that passes-through only the key columns. This allows
@@ -395,6 +472,29 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	})
 }
 
+// constructExclusionComparison constructs the comparison of a new value with an
+// existing value of a column of an exclusion constraint.
+func (h *uniqueCheckHelper) constructExclusionComparison(
+	op treecmp.ComparisonOperator, newCol, existingCol scopeColumn,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	left, right := f.ConstructVariable(newCol.id), f.ConstructVariable(existingCol.id)
+	switch op.Symbol {
+	case treecmp.EQ:
+		return f.ConstructEq(left, right)
+	case treecmp.NE:
+		return f.ConstructNe(left, right)
+	case treecmp.Overlaps:
+		if fam := newCol.typ.Family(); fam == types.GeometryFamily || fam == types.Box2DFamily {
+			// The && operator means "intersects" when used with geometry or bounding
+			// box operands.
+			return f.ConstructBBoxIntersects(left, right)
+		}
+		return f.ConstructOverlaps(left, right)
+	}
+	panic(errors.AssertionFailedf("unsupported exclusion operator: %s", redact.Safe(op)))
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
@@ -413,6 +513,7 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 		noRowLocking,
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
+		nil,  /* sample */
 	), ordinals
 }
 
@@ -302,5 +302,12 @@ func ensureColumnOrderable(e tree.TypedExpr) {
 	switch typ.Family() {
 	case types.TSQueryFamily, types.TSVectorFamily:
 		panic(unimplementedWithIssueDetailf(92165, "", "can't order by column type %s", typ.SQLString()))
+	case types.JsonpathFamily:
+		panic(unimplementedWithIssueDetailf(22513, "", "can't order by column type %s", typ.SQLString()))
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		// Like in postgres, the geometric types have no ordering.
+		panic(pgerror.Newf(pgcode.UndefinedFunction,
+			"could not identify an ordering operator for type %s", typ.SQLString()))
 	}
 }
@@ -19,8 +19,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -114,9 +117,18 @@ type plpgsqlBuilder struct {
 	// params tracks the names and types for the original function parameters.
 	params []tree.ParamType
 
-	// decls is the set of variable declarations for a PL/pgSQL function.
+	// decls is the set of variable declarations for a PL/pgSQL function. It
+	// includes hidden variables that are used to implement FOR loops.
 	decls []ast.Declaration
 
+	// cursors maps from the name of each bound cursor variable to its
+	// declaration.
+	cursors map[tree.Name]*ast.CursorDeclaration
+
+	// loopVars maps from each FOR and FOREACH loop in the function body to the
+	// hidden variables that are used to implement it.
+	loopVars map[ast.Statement]*plpgsqlLoopVars
+
 	// varTypes maps from the name of each variable to its type.
 	varTypes map[tree.Name]*types.T
 
@@ -126,6 +138,21 @@ type plpgsqlBuilder struct {
 	// returnType is the return type of the PL/pgSQL function.
 	returnType *types.T
 
+	// isProcedure is true if the PL/pgSQL routine is a stored procedure.
+	isProcedure bool
+
+	// isSetReturning is true if the PL/pgSQL function returns a set of rows.
+	isSetReturning bool
+
+	// resultBufferID identifies the buffer that collects the rows returned by
+	// RETURN NEXT and RETURN QUERY statements. It is only set if isSetReturning
+	// is true.
+	resultBufferID tree.RoutineResultBufferID
+
+	// outParams contains the ordinals of the OUT and INOUT parameters of a
+	// stored procedure.
+	outParams []int
+
 	// continuations is used to model the control flow of a PL/pgSQL function.
 	// The head of the continuations stack is used upon reaching the end of a
 	// statement block to call a function that models the statements that come
@@ -147,14 +174,55 @@ type plpgsqlBuilder struct {
 }
 
 func (b *plpgsqlBuilder) init(
-	ob *Builder, colRefs *opt.ColSet, params []tree.ParamType, block *ast.Block, returnType *types.T,
+	ob *Builder,
+	colRefs *opt.ColSet,
+	params []tree.ParamType,
+	block *ast.Block,
+	returnType *types.T,
+	isProcedure bool,
+	isSetReturning bool,
+	outParams []int,
 ) {
 	b.ob = ob
 	b.colRefs = colRefs
 	b.params = params
-	b.decls = block.Decls
 	b.returnType = returnType
+	b.isProcedure = isProcedure
+	b.isSetReturning = isSetReturning
+	if isSetReturning {
+		b.resultBufferID = tree.RoutineResultBufferID(ob.factory.Metadata().NextUniqueID())
+	}
+	b.outParams = outParams
+	b.cursors = make(map[tree.Name]*ast.CursorDeclaration)
+	for _, decl := range block.Decls {
+		switch dec := decl.(type) {
+		case *ast.Declaration:
+			d := *dec
+			if isRefCursorType(d.Typ) {
+				// Cursor variables hold the name of a cursor.
+				d.Typ = types.String
+			}
+			b.decls = append(b.decls, d)
+		case *ast.CursorDeclaration:
+			// A bound cursor variable is initialized with its own name, which is
+			// used as the name of the cursor when it is opened.
+			b.cursors[dec.Name] = dec
+			b.decls = append(b.decls, ast.Declaration{
+				Var:  dec.Name,
+				Typ:  types.String,
+				Expr: tree.NewStrVal(string(dec.Name)),
+			})
+		default:
+			panic(errors.AssertionFailedf("unexpected declaration: %T", decl))
+		}
+	}
 	b.varTypes = make(map[tree.Name]*types.T)
+	for _, ord := range b.outParams {
+		// OUT and INOUT parameters can be assigned to like variables.
+		if param := b.params[ord]; param.Name != "" {
+			b.varTypes[tree.Name(param.Name)] = param.Typ
+		}
+	}
 	for _, dec := range b.decls {
 		typ, err := tree.ResolveType(b.ob.ctx, dec.Typ, b.ob.semaCtx.TypeResolver)
 		if err != nil {
@@ -174,6 +242,124 @@ func (b *plpgsqlBuilder) init(
 			))
 		}
 	}
+	b.addLoopVars(block)
+}
+
+// isRefCursorType returns true if the given type reference names the
+// REFCURSOR type, which PL/pgSQL uses for cursor variables.
+func isRefCursorType(typ tree.ResolvableTypeReference) bool {
+	name, ok := typ.(*tree.UnresolvedObjectName)
+	return ok && name.NumParts == 1 && name.Parts[0] == "refcursor"
+}
+
+// plpgsqlLoopVars holds the names of the hidden variables that are used to
+// implement a FOR or FOREACH loop.
+type plpgsqlLoopVars struct {
+	// counter, upper, and step track the state of an integer FOR loop. If the
+	// loop variable shadows an existing variable, saved holds its value from
+	// before the loop.
+	counter, upper, step, saved tree.Name
+
+	// array and index track the state of a FOREACH loop.
+	array, index tree.Name
+
+	// cursor holds the name of the cursor opened by a query FOR loop, and
+	// iterated tracks whether the loop body executed at least once.
+	cursor, iterated tree.Name
+}
+
+// addLoopVars declares the hidden variables that are needed to implement the
+// FOR and FOREACH loops within the given block. These have to be known before
+// any continuation routines are built, since they are passed to every
+// continuation as arguments. It also declares the special FOUND variable if
+// it is set by any statement.
+func (b *plpgsqlBuilder) addLoopVars(block *ast.Block) {
+	b.loopVars = make(map[ast.Statement]*plpgsqlLoopVars)
+	var needsFound bool
+	addVar := func(name string, typ *types.T, expr ast.Expr) tree.Name {
+		v := tree.Name(b.makeIdentifier(name))
+		b.decls = append(b.decls, ast.Declaration{Var: v, Typ: typ, Expr: expr})
+		b.varTypes[v] = typ
+		return v
+	}
+	var addVars func(stmts []ast.Statement)
+	addVars = func(stmts []ast.Statement) {
+		for _, stmt := range stmts {
+			switch t := stmt.(type) {
+			case *ast.If:
+				addVars(t.ThenBody)
+				for i := range t.ElseIfList {
+					addVars(t.ElseIfList[i].Stmts)
+				}
+				addVars(t.ElseBody)
+			case *ast.Loop:
+				addVars(t.Body)
+			case *ast.While:
+				addVars(t.Body)
+			case *ast.ForInt:
+				vars := &plpgsqlLoopVars{
+					counter: addVar("_for_counter", types.Int, nil /* expr */),
+					upper:   addVar("_for_upper", types.Int, nil /* expr */),
+					step:    addVar("_for_step", types.Int, nil /* expr */),
+				}
+				if _, ok := b.varTypes[t.Var]; ok {
+					// The loop variable shadows an existing variable, which must be
+					// restored after the loop.
+					vars.saved = addVar("_for_saved", b.varTypes[t.Var], nil /* expr */)
+				} else {
+					for i := range b.params {
+						if tree.Name(b.params[i].Name) == t.Var {
+							panic(unimplemented.New(
+								"FOR loop variable shadowing a parameter",
+								"integer FOR loop variables that shadow a parameter are not yet supported",
+							))
+						}
+					}
+					// The loop variable is implicitly declared as an integer.
+					b.decls = append(b.decls, ast.Declaration{Var: t.Var, Typ: types.Int})
+					b.varTypes[t.Var] = types.Int
+				}
+				b.loopVars[t] = vars
+				addVars(t.Body)
+			case *ast.ForEachArray:
+				typ, ok := b.varTypes[t.Var]
+				if !ok {
+					panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", t.Var))
+				}
+				b.loopVars[t] = &plpgsqlLoopVars{
+					array: addVar("_foreach_array", types.MakeArray(typ), nil /* expr */),
+					index: addVar("_foreach_index", types.Int, nil /* expr */),
+				}
+				addVars(t.Body)
+			case *ast.ForSelect:
+				b.loopVars[t] = &plpgsqlLoopVars{
+					cursor:   addVar("_for_cursor", types.String, nil /* expr */),
+					iterated: addVar("_for_iterated", types.Bool, nil /* expr */),
+				}
+				needsFound = true
+				addVars(t.Body)
+			case *ast.ForCursor:
+				b.loopVars[t] = &plpgsqlLoopVars{
+					iterated: addVar("_for_iterated", types.Bool, nil /* expr */),
+				}
+				needsFound = true
+				addVars(t.Body)
+			case *ast.Fetch:
+				needsFound = true
+			}
+		}
+	}
+	addVars(block.Body)
+	for i := range block.Exceptions {
+		addVars(block.Exceptions[i].Action)
+	}
+	const foundVar = tree.Name("found")
+	if _, ok := b.varTypes[foundVar]; needsFound && !ok {
+		// FOUND is a special variable that is implicitly declared. It is set by
+		// FETCH and MOVE statements and by query FOR loops.
+		b.decls = append(b.decls, ast.Declaration{Var: foundVar, Typ: types.Bool, Expr: tree.DBoolFalse})
+		b.varTypes[foundVar] = types.Bool
+	}
 }
 
 // build constructs an expression that returns the result of executing a
@@ -225,20 +411,16 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 	for i, stmt := range stmts {
 		switch t := stmt.(type) {
 		case *ast.Return:
-			// RETURN is handled by projecting a single column with the expression
-			// that is being returned.
-			returnScalar := b.buildPLpgSQLExpr(t.Expr, b.returnType, s)
-			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
-			returnScope := s.push()
-			b.ensureScopeHasExpr(returnScope)
-			b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, returnScalar)
-			b.ob.constructProjectForScope(s, returnScope)
-			return returnScope
+			return b.buildPLpgSQLReturn(t, s)
 
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
 			// name as the variable being assigned.
-			s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+			if t.Field != "" {
+				s = b.addPLpgSQLFieldAssign(s, t.Var, t.Field, t.Value)
+			} else {
+				s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+			}
 			if b.exceptionBlock != nil {
 				// If exception handling is required, we have to start a new
 				// continuation after each variable assignment. This ensures that in the
@@ -367,6 +549,210 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			newStmts = append(newStmts, stmts[i+1:]...)
 			return b.buildPLpgSQLStatements(newStmts, s)
 
+		case *ast.ForInt:
+			// An integer FOR loop is rewritten as a WHILE loop that uses hidden
+			// variables to track the state of the loop:
+			//
+			//   FOR i IN [REVERSE] lower..upper BY step LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   counter := lower;
+			//   upper := upper;
+			//   step := step;
+			//   [check that lower, upper and step are valid];
+			//   WHILE counter <= upper LOOP   -- counter >= upper for REVERSE
+			//     i := counter;
+			//     counter := counter + step;  -- counter - step for REVERSE
+			//     [body];
+			//   END LOOP;
+			//
+			// If the loop variable shadows an existing variable, the variable is
+			// saved before the loop and restored after it.
+			vars := b.loopVars[t]
+			newStmts := make([]ast.Statement, 0, len(stmts)+8)
+			if vars.saved != "" {
+				newStmts = append(newStmts, &ast.Assignment{Var: vars.saved, Value: makeVarRef(t.Var)})
+			}
+			newStmts = append(newStmts,
+				&ast.Assignment{Var: vars.counter, Value: t.Lower},
+				&ast.Assignment{Var: vars.upper, Value: t.Upper},
+				b.makeRaiseIfNull(vars.counter, "lower bound of FOR loop cannot be null"),
+				b.makeRaiseIfNull(vars.upper, "upper bound of FOR loop cannot be null"),
+			)
+			if t.Step != nil {
+				newStmts = append(newStmts,
+					&ast.Assignment{Var: vars.step, Value: t.Step},
+					b.makeRaiseIfNull(vars.step, "BY value of FOR loop cannot be null"),
+					&ast.If{
+						Condition: &tree.ComparisonExpr{
+							Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+							Left:     makeVarRef(vars.step),
+							Right:    tree.NewDInt(0),
+						},
+						ThenBody: []ast.Statement{&ast.Raise{
+							Code:    pgcode.InvalidParameterValue.String(),
+							Message: "BY value of FOR loop must be greater than zero",
+						}},
+					},
+				)
+			} else {
+				newStmts = append(newStmts, &ast.Assignment{Var: vars.step, Value: tree.NewDInt(1)})
+			}
+			cmp, op := treecmp.LE, treebin.Plus
+			if t.Reverse {
+				cmp, op = treecmp.GE, treebin.Minus
+			}
+			body := make([]ast.Statement, 0, len(t.Body)+2)
+			body = append(body,
+				&ast.Assignment{Var: t.Var, Value: makeVarRef(vars.counter)},
+				&ast.Assignment{Var: vars.counter, Value: &tree.BinaryExpr{
+					Operator: treebin.MakeBinaryOperator(op),
+					Left:     makeVarRef(vars.counter),
+					Right:    makeVarRef(vars.step),
+				}},
+			)
+			body = append(body, t.Body...)
+			newStmts = append(newStmts, &ast.While{
+				Label: t.Label,
+				Condition: &tree.ComparisonExpr{
+					Operator: treecmp.MakeComparisonOperator(cmp),
+					Left:     makeVarRef(vars.counter),
+					Right:    makeVarRef(vars.upper),
+				},
+				Body: body,
+			})
+			if vars.saved != "" {
+				newStmts = append(newStmts, &ast.Assignment{Var: t.Var, Value: makeVarRef(vars.saved)})
+			}
+			newStmts = append(newStmts, stmts[i+1:]...)
+			return b.buildPLpgSQLStatements(newStmts, s)
+
+		case *ast.ForEachArray:
+			// A FOREACH loop is rewritten as a LOOP that uses hidden variables to
+			// iterate over the elements of the array:
+			//
+			//   FOREACH x IN ARRAY expr LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   arr := expr;
+			//   [check that arr is not NULL];
+			//   idx := 0;
+			//   LOOP
+			//     idx := idx + 1;
+			//     IF idx > cardinality(arr) THEN
+			//       EXIT;
+			//     END IF;
+			//     x := arr[idx];
+			//     [body];
+			//   END LOOP;
+			//
+			if t.Slice != 0 {
+				panic(unimplemented.New(
+					"FOREACH SLICE",
+					"FOREACH statements with SLICE are not yet supported",
+				))
+			}
+			vars := b.loopVars[t]
+			body := make([]ast.Statement, 0, len(t.Body)+3)
+			body = append(body,
+				&ast.Assignment{Var: vars.index, Value: &tree.BinaryExpr{
+					Operator: treebin.MakeBinaryOperator(treebin.Plus),
+					Left:     makeVarRef(vars.index),
+					Right:    tree.NewDInt(1),
+				}},
+				&ast.If{
+					Condition: &tree.ComparisonExpr{
+						Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+						Left:     makeVarRef(vars.index),
+						Right: &tree.FuncExpr{
+							Func:  tree.WrapFunction("cardinality"),
+							Exprs: tree.Exprs{makeVarRef(vars.array)},
+						},
+					},
+					ThenBody: []ast.Statement{&ast.Exit{}},
+				},
+				&ast.Assignment{Var: t.Var, Value: &tree.IndirectionExpr{
+					Expr:        makeVarRef(vars.array),
+					Indirection: tree.ArraySubscripts{{Begin: makeVarRef(vars.index)}},
+				}},
+			)
+			body = append(body, t.Body...)
+			newStmts := make([]ast.Statement, 0, len(stmts)+4)
+			newStmts = append(newStmts,
+				&ast.Assignment{Var: vars.array, Value: t.Expr},
+				b.makeRaiseIfNull(vars.array, "FOREACH expression must not be null"),
+				&ast.Assignment{Var: vars.index, Value: tree.NewDInt(0)},
+				&ast.Loop{Label: t.Label, Body: body},
+			)
+			newStmts = append(newStmts, stmts[i+1:]...)
+			return b.buildPLpgSQLStatements(newStmts, s)
+
+		case *ast.ForSelect, *ast.ForCursor:
+			// A query FOR loop is rewritten as a LOOP that fetches one row at a
+			// time from a cursor:
+			//
+			//   FOR x, y IN [query or bound cursor] LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   OPEN cur FOR [query];
+			//   iterated := false;
+			//   LOOP
+			//     FETCH cur INTO x, y;
+			//     IF NOT FOUND THEN
+			//       EXIT;
+			//     END IF;
+			//     iterated := true;
+			//     [body];
+			//   END LOOP;
+			//   CLOSE cur;
+			//   FOUND := iterated;
+			//
+			// A loop over a query uses a hidden cursor variable, which is reset so
+			// that a new cursor name is generated each time the loop is entered.
+			vars := b.loopVars[t]
+			var loop *ast.ForQuery
+			var open *ast.Open
+			switch t := t.(type) {
+			case *ast.ForSelect:
+				loop = &t.ForQuery
+				open = &ast.Open{CurVar: vars.cursor, Query: t.Query}
+			case *ast.ForCursor:
+				if _, ok := b.cursors[t.CurVar]; !ok {
+					panic(pgerror.New(pgcode.Syntax, "cursor FOR loop must use a bound cursor variable"))
+				}
+				loop = &t.ForQuery
+				open = &ast.Open{CurVar: t.CurVar}
+			}
+			body := make([]ast.Statement, 0, len(loop.Body)+3)
+			body = append(body,
+				&ast.Fetch{
+					Cursor: tree.CursorStmt{Name: open.CurVar, FetchType: tree.FetchNormal, Count: 1},
+					Target: loop.Target,
+				},
+				&ast.If{
+					Condition: &tree.NotExpr{Expr: makeVarRef("found")},
+					ThenBody:  []ast.Statement{&ast.Exit{}},
+				},
+				&ast.Assignment{Var: vars.iterated, Value: tree.DBoolTrue},
+			)
+			body = append(body, loop.Body...)
+			newStmts := make([]ast.Statement, 0, len(stmts)+6)
+			if open.Query != nil {
+				newStmts = append(newStmts, &ast.Assignment{Var: open.CurVar, Value: tree.DNull})
+			}
+			newStmts = append(newStmts,
+				open,
+				&ast.Assignment{Var: vars.iterated, Value: tree.DBoolFalse},
+				&ast.Loop{Label: loop.Label, Body: body},
+				&ast.Close{CurVar: open.CurVar},
+				&ast.Assignment{Var: "found", Value: makeVarRef(vars.iterated)},
+			)
+			newStmts = append(newStmts, stmts[i+1:]...)
+			return b.buildPLpgSQLStatements(newStmts, s)
+
 		case *ast.Exit:
 			if t.Label != "" {
 				panic(unimplemented.New(
@@ -490,6 +876,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 						))
 					}
 				}
+				scalar = b.checkDomainConstraints(scalar, typ)
 				b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
 			}
 			b.ob.constructProjectForScope(stmtScope, intoScope)
@@ -499,6 +886,200 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.appendBodyStmt(&execCon, intoScope)
 			return b.callContinuation(&execCon, s)
 
+		case *ast.Perform:
+			// PERFORM executes a query for its side effects and discards the
+			// result, so it is built like a SQL statement without an INTO target.
+			performCon := b.makeContinuation("_stmt_perform")
+			stmtScope := b.ob.buildStmtAtRootWithScope(t.Query, nil /* desiredTypes */, performCon.s)
+			b.appendBodyStmt(&performCon, stmtScope)
+			b.appendPlpgSQLStmts(&performCon, stmts[i+1:])
+			return b.callContinuation(&performCon, s)
+
+		case *ast.Open:
+			// OPEN statements are handled by building the cursor query into the
+			// first body statement of a continuation routine. The result of the
+			// statement is used to open a SQL cursor, and the remaining PLpgSQL
+			// statements become the last body statement.
+			//
+			// If the cursor variable is NULL, a unique name is generated for the
+			// cursor and assigned to the variable before the continuation is called.
+			b.checkCursorVariable(t.CurVar)
+			query, scroll := t.Query, t.Scroll
+			if decl, ok := b.cursors[t.CurVar]; ok {
+				if query != nil {
+					panic(pgerror.Newf(pgcode.Syntax,
+						"cursor \"%s\" is bound to a query and cannot be opened with OPEN FOR", t.CurVar,
+					))
+				}
+				query, scroll = decl.Query, decl.Scroll
+			} else if query == nil {
+				panic(pgerror.New(pgcode.Syntax, "expected \"FOR\" to open a reference cursor"))
+			}
+			if scroll == tree.Scroll {
+				panic(unimplemented.NewWithIssue(77102, "SCROLL cursor"))
+			}
+			if _, ok := query.(*tree.Select); !ok {
+				panic(pgerror.Newf(pgcode.InvalidCursorDefinition,
+					"cannot open %s query as cursor", query.StatementTag(),
+				))
+			}
+			s = b.addPLpgSQLAssign(s, t.CurVar, &tree.FuncExpr{
+				Func:  tree.WrapFunction("crdb_internal.plpgsql_gen_cursor_name"),
+				Exprs: tree.Exprs{makeVarRef(t.CurVar)},
+			})
+			openCon := b.makeContinuation("_stmt_open")
+			openCon.def.Volatility = volatility.Volatile
+			openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+				NameArgIdx: b.getVariableArgIdx(t.CurVar),
+				Scroll:     scroll,
+				CursorSQL:  tree.AsString(query),
+			}
+			openScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
+			b.appendBodyStmt(&openCon, openScope)
+			b.appendPlpgSQLStmts(&openCon, stmts[i+1:])
+			return b.callContinuation(&openCon, s)
+
+		case *ast.Fetch:
+			// FETCH and MOVE statements are handled by a call to the
+			// crdb_internal.plpgsql_fetch builtin function, which returns a tuple
+			// with the new value for FOUND followed by the fetched values. The
+			// tuple elements are assigned to the variables, which are then passed
+			// to a continuation for the remaining PLpgSQL statements.
+			b.checkCursorVariable(t.Cursor.Name)
+			fetchCon := b.makeContinuation("_stmt_fetch")
+			fetchCon.def.Volatility = volatility.Volatile
+			targets := append([]ast.Variable{"found"}, t.Target...)
+			typs := make([]*types.T, len(targets))
+			elems := make(memo.ScalarListExpr, len(targets))
+			for j := range targets {
+				typs[j] = b.resolveVariableForAssign(targets[j])
+				elems[j] = b.ob.factory.ConstructNull(typs[j])
+			}
+			resultTyp := types.MakeTuple(typs)
+			fetchScope := b.buildPLpgSQLBuiltinCall(
+				fetchCon.s, "crdb_internal.plpgsql_fetch", "stmt_fetch", resultTyp,
+				memo.ScalarListExpr{
+					b.buildPLpgSQLExpr(makeVarRef(t.Cursor.Name), types.String, fetchCon.s),
+					b.ob.factory.ConstructConstVal(tree.NewDInt(tree.DInt(t.Cursor.FetchType)), types.Int),
+					b.ob.factory.ConstructConstVal(tree.NewDInt(tree.DInt(t.Cursor.Count)), types.Int),
+					b.ob.factory.ConstructTuple(elems, resultTyp),
+				},
+			)
+			fetchCol := fetchScope.cols[len(fetchScope.cols)-1].id
+			intoScope := fetchScope.push()
+			for j := range targets {
+				for k := range intoScope.cols {
+					if intoScope.cols[k].name.MatchesReferenceName(targets[j]) {
+						panic(unimplemented.New(
+							"duplicate INTO target",
+							"assigning to a variable more than once in the same INTO statement is not supported",
+						))
+					}
+				}
+				scalar := b.ob.factory.ConstructColumnAccess(
+					b.ob.factory.ConstructVariable(fetchCol), memo.TupleOrdinal(j),
+				)
+				scalar = b.checkDomainConstraints(scalar, typs[j])
+				b.ob.synthesizeColumn(intoScope, scopeColName(targets[j]), typs[j], nil /* expr */, scalar)
+			}
+			b.ob.constructProjectForScope(fetchScope, intoScope)
+			retCon := b.makeContinuation("_stmt_fetch_ret")
+			b.appendPlpgSQLStmts(&retCon, stmts[i+1:])
+			intoScope = b.callContinuation(&retCon, intoScope)
+			b.appendBodyStmt(&fetchCon, intoScope)
+			return b.callContinuation(&fetchCon, s)
+
+		case *ast.Close:
+			// CLOSE statements are handled by a call to the
+			// crdb_internal.plpgsql_close builtin function, which is built into a
+			// body statement that is only executed for its side effects.
+			b.checkCursorVariable(t.CurVar)
+			closeCon := b.makeContinuation("_stmt_close")
+			closeCon.def.Volatility = volatility.Volatile
+			closeScope := b.buildPLpgSQLBuiltinCall(
+				closeCon.s, "crdb_internal.plpgsql_close", "stmt_close", types.Int,
+				memo.ScalarListExpr{b.buildPLpgSQLExpr(makeVarRef(t.CurVar), types.String, closeCon.s)},
+			)
+			b.appendBodyStmt(&closeCon, closeScope)
+			b.appendPlpgSQLStmts(&closeCon, stmts[i+1:])
+			return b.callContinuation(&closeCon, s)
+
+		case *ast.ReturnNext, *ast.ReturnQuery:
+			// RETURN NEXT and RETURN QUERY add rows to the result of a
+			// set-returning function without returning from it. They are handled by
+			// building the rows into the first body statement of a continuation
+			// routine, which adds the result of the statement to the result buffer
+			// of the function. The remaining PLpgSQL statements become the last
+			// body statement.
+			var stmtName string
+			var returnScope *scope
+			con := b.makeContinuation("_stmt_return_rows")
+			switch t := t.(type) {
+			case *ast.ReturnNext:
+				stmtName = "RETURN NEXT"
+				b.checkSetReturning(stmtName)
+				if t.Expr == nil {
+					panic(pgerror.New(pgcode.Syntax, "RETURN NEXT must have a parameter"))
+				}
+				returnScope = con.s.push()
+				scalar := b.buildPLpgSQLExpr(t.Expr, b.returnType, con.s)
+				b.ob.synthesizeColumn(
+					returnScope, scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_next")),
+					b.returnType, nil /* expr */, scalar,
+				)
+				b.ob.constructProjectForScope(con.s, returnScope)
+			case *ast.ReturnQuery:
+				stmtName = "RETURN QUERY"
+				b.checkSetReturning(stmtName)
+				stmtScope := b.ob.buildStmtAtRootWithScope(t.Query, nil /* desiredTypes */, con.s)
+				returnScope = b.buildReturnQueryResult(stmtScope)
+			}
+			con.def.Volatility = volatility.Volatile
+			con.def.ReturnRows = b.resultBufferID
+			b.appendBodyStmt(&con, returnScope)
+			b.appendPlpgSQLStmts(&con, stmts[i+1:])
+			return b.callContinuation(&con, s)
+
+		case *ast.Null:
+			// NULL statements do nothing.
+
+		case *ast.Commit, *ast.Rollback:
+			// COMMIT and ROLLBACK are handled by building the statements that
+			// follow into a continuation routine. Executing the transaction
+			// control statement ends the current transaction and stores a call to
+			// the continuation, which is resumed in a new transaction by the
+			// CALL statement.
+			txnOp, chain := tree.StoredProcTxnCommit, false
+			switch t := t.(type) {
+			case *ast.Commit:
+				chain = t.Chain
+			case *ast.Rollback:
+				txnOp, chain = tree.StoredProcTxnRollback, t.Chain
+			}
+			if !b.isProcedure || b.exceptionBlock != nil {
+				// Transaction control is only permitted at the top level of a
+				// procedure, and not within a block with an exception handler.
+				panic(pgerror.New(pgcode.InvalidTransactionTermination, "invalid transaction termination"))
+			}
+			if chain {
+				panic(unimplemented.New(
+					"transaction chaining",
+					"COMMIT AND CHAIN and ROLLBACK AND CHAIN are not yet supported",
+				))
+			}
+			con := b.makeContinuation("_stmt_txn")
+			con.def.Volatility = volatility.Volatile
+			b.appendPlpgSQLStmts(&con, stmts[i+1:])
+			txnControl := b.ob.factory.ConstructTxnControl(
+				b.makeContinuationArgs(s), &memo.TxnControlPrivate{TxnOp: txnOp, Def: con.def},
+			)
+			txnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_txn"))
+			txnScope := s.push()
+			b.ensureScopeHasExpr(txnScope)
+			b.ob.synthesizeColumn(txnScope, txnColName, b.returnType, nil /* expr */, txnControl)
+			b.ob.constructProjectForScope(s, txnScope)
+			return txnScope
+
 		default:
 			panic(unimplemented.New(
 				"unimplemented PL/pgSQL statement",
@@ -510,12 +1091,121 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 	return b.callContinuation(b.getContinuation(), s)
 }
 
+// buildPLpgSQLReturn builds a RETURN statement by projecting a single column
+// with the expression that is being returned. A procedure instead returns the
+// current values of its OUT and INOUT parameters.
+func (b *plpgsqlBuilder) buildPLpgSQLReturn(ret *ast.Return, s *scope) *scope {
+	var returnScalar opt.ScalarExpr
+	switch {
+	case b.isProcedure:
+		if ret.Expr != nil {
+			panic(pgerror.New(pgcode.DatatypeMismatch, "RETURN cannot have a parameter in a procedure"))
+		}
+		if b.returnType.Family() != types.TupleFamily {
+			returnScalar = b.ob.factory.ConstructNull(b.returnType)
+			break
+		}
+		elems := make(memo.ScalarListExpr, len(b.outParams))
+		for i, ord := range b.outParams {
+			param := b.params[ord]
+			elems[i] = b.ob.factory.ConstructNull(param.Typ)
+			if param.Name == "" {
+				continue
+			}
+			_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, tree.Name(param.Name))
+			if err != nil {
+				panic(err)
+			}
+			if source != nil {
+				elems[i] = b.ob.factory.ConstructVariable(source.(*scopeColumn).id)
+			}
+		}
+		returnScalar = b.ob.factory.ConstructTuple(elems, b.returnType)
+	case b.isSetReturning:
+		// A set-returning function returns the rows added by RETURN NEXT and
+		// RETURN QUERY statements, so RETURN only exits the function.
+		if ret.Expr != nil {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.DatatypeMismatch, "RETURN cannot have a parameter in function returning set"),
+				"Use RETURN NEXT or RETURN QUERY.",
+			))
+		}
+		returnScalar = b.ob.factory.ConstructNull(b.returnType)
+	case ret.Expr == nil:
+		if b.returnType.Family() != types.VoidFamily {
+			panic(pgerror.New(pgcode.Syntax, "missing expression at or near \";\""))
+		}
+		returnScalar = b.ob.factory.ConstructNull(b.returnType)
+	default:
+		returnScalar = b.buildPLpgSQLExpr(ret.Expr, b.returnType, s)
+		if returnScalar.DataType().Family() == types.UnknownFamily {
+			// An untyped NULL must take on the return type, so that it is
+			// consistent with the results of other branches of the function.
+			returnScalar = b.ob.factory.ConstructNull(b.returnType)
+		}
+	}
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
+	returnScope := s.push()
+	b.ensureScopeHasExpr(returnScope)
+	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, returnScalar)
+	b.ob.constructProjectForScope(s, returnScope)
+	return returnScope
+}
+
 // addPLpgSQLAssign adds a PL/pgSQL assignment to the current scope as a
 // new column with the variable name that projects the assigned expression.
 // If there is a column with the same name in the previous scope, it will be
 // replaced. This allows the plpgsqlBuilder to model variable mutations.
 func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, val ast.Expr) *scope {
 	typ := b.resolveVariableForAssign(ident)
+	scalar := b.buildPLpgSQLExpr(val, typ, inScope)
+	scalar = b.checkDomainConstraints(scalar, typ)
+	return b.projectPLpgSQLAssign(inScope, ident, typ, scalar)
+}
+
+// addPLpgSQLFieldAssign adds an assignment to a field of a record variable,
+// e.g. NEW.x := 1 in a trigger function. The variable is reassigned a tuple
+// in which the given field is replaced with the assigned value.
+func (b *plpgsqlBuilder) addPLpgSQLFieldAssign(
+	inScope *scope, ident ast.Variable, field tree.Name, val ast.Expr,
+) *scope {
+	typ := b.resolveVariableForAssign(ident)
+	fieldIdx := -1
+	if typ.Family() == types.TupleFamily {
+		for i, label := range typ.TupleLabels() {
+			if tree.Name(label) == field {
+				fieldIdx = i
+				break
+			}
+		}
+	}
+	if fieldIdx < 0 {
+		panic(pgerror.Newf(pgcode.UndefinedColumn,
+			"record \"%s\" has no field \"%s\"", ident, field))
+	}
+	_, source, _, err := inScope.FindSourceProvidingColumn(b.ob.ctx, ident)
+	if err != nil {
+		panic(err)
+	}
+	record := b.ob.factory.ConstructVariable(source.(*scopeColumn).id)
+	fieldTyp := typ.TupleContents()[fieldIdx]
+	elems := make(memo.ScalarListExpr, len(typ.TupleContents()))
+	for i := range elems {
+		if i == fieldIdx {
+			elems[i] = b.buildPLpgSQLExpr(val, fieldTyp, inScope)
+			elems[i] = b.checkDomainConstraints(elems[i], fieldTyp)
+			continue
+		}
+		elems[i] = b.ob.factory.ConstructColumnAccess(record, memo.TupleOrdinal(i))
+	}
+	return b.projectPLpgSQLAssign(inScope, ident, typ, b.ob.factory.ConstructTuple(elems, typ))
+}
+
+// projectPLpgSQLAssign projects the given scalar as a new column with the
+// variable name, replacing the previous value of the variable.
+func (b *plpgsqlBuilder) projectPLpgSQLAssign(
+	inScope *scope, ident ast.Variable, typ *types.T, scalar opt.ScalarExpr,
+) *scope {
 	assignScope := inScope.push()
 	b.ensureScopeHasExpr(assignScope)
 	for i := range inScope.cols {
@@ -530,7 +1220,6 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, va
 	}
 	// Project the assignment as a new column.
 	colName := scopeColName(ident)
-	scalar := b.buildPLpgSQLExpr(val, typ, inScope)
 	b.ob.synthesizeColumn(assignScope, colName, typ, nil, scalar)
 	b.ob.constructProjectForScope(inScope, assignScope)
 	return assignScope
@@ -539,25 +1228,149 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, va
 // buildPLpgSQLRaise builds a call to the crdb_internal.plpgsql_raise builtin
 // function, which implements the notice-sending behavior of RAISE statements.
 func (b *plpgsqlBuilder) buildPLpgSQLRaise(inScope *scope, args memo.ScalarListExpr) *scope {
-	const raiseFnName = "crdb_internal.plpgsql_raise"
-	props, overloads := builtinsregistry.GetBuiltinProperties(raiseFnName)
+	return b.buildPLpgSQLBuiltinCall(inScope, "crdb_internal.plpgsql_raise", "stmt_raise", types.Int, args)
+}
+
+// buildPLpgSQLBuiltinCall projects a column with the result of calling the
+// given builtin function, which must have exactly one overload. It is used to
+// build PLpgSQL statements that are implemented by builtin functions.
+func (b *plpgsqlBuilder) buildPLpgSQLBuiltinCall(
+	inScope *scope, fnName, colName string, typ *types.T, args memo.ScalarListExpr,
+) *scope {
+	props, overloads := builtinsregistry.GetBuiltinProperties(fnName)
 	if len(overloads) != 1 {
-		panic(errors.AssertionFailedf("expected one overload for %s", raiseFnName))
+		panic(errors.AssertionFailedf("expected one overload for %s", fnName))
 	}
-	raiseCall := b.ob.factory.ConstructFunction(
+	call := b.ob.factory.ConstructFunction(
 		args,
 		&memo.FunctionPrivate{
-			Name:       raiseFnName,
-			Typ:        types.Int,
+			Name:       fnName,
+			Typ:        typ,
 			Properties: props,
 			Overload:   &overloads[0],
 		},
 	)
-	raiseColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_raise"))
-	raiseScope := inScope.push()
-	b.ob.synthesizeColumn(raiseScope, raiseColName, types.Int, nil /* expr */, raiseCall)
-	b.ob.constructProjectForScope(inScope, raiseScope)
-	return raiseScope
+	callColName := scopeColName("").WithMetadataName(b.makeIdentifier(colName))
+	callScope := inScope.push()
+	b.ob.synthesizeColumn(callScope, callColName, typ, nil /* expr */, call)
+	b.ob.constructProjectForScope(inScope, callScope)
+	return callScope
+}
+
+// buildReturnQueryResult projects the result of the query for a RETURN QUERY
+// statement as a single column with the return type of the function. The
+// ordering of the query is preserved.
+func (b *plpgsqlBuilder) buildReturnQueryResult(stmtScope *scope) *scope {
+	cols := stmtScope.makePresentation()
+	md := b.ob.factory.Metadata()
+	castCol := func(col opt.ColumnID, typ *types.T) opt.ScalarExpr {
+		colTyp := md.ColumnMeta(col).Type
+		scalar := b.ob.factory.ConstructVariable(col)
+		if colTyp.Identical(typ) {
+			return scalar
+		}
+		if !cast.ValidCast(colTyp, typ, cast.ContextAssignment) {
+			panic(errors.WithDetailf(
+				pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+				"Returned type %s does not match expected type %s.", colTyp.SQLString(), typ.SQLString(),
+			))
+		}
+		return b.ob.factory.ConstructAssignmentCast(scalar, typ)
+	}
+	var scalar opt.ScalarExpr
+	isSingleTuple := len(cols) == 1 && md.ColumnMeta(cols[0].ID).Type.Family() == types.TupleFamily
+	if b.returnType.Family() == types.TupleFamily && !isSingleTuple {
+		// The columns of the query make up the elements of the composite type
+		// returned by the function.
+		contents := b.returnType.TupleContents()
+		elems := make(memo.ScalarListExpr, len(cols))
+		if types.IsRecordType(b.returnType) {
+			for i := range cols {
+				elems[i] = b.ob.factory.ConstructVariable(cols[i].ID)
+			}
+		} else {
+			if len(cols) != len(contents) {
+				panic(errors.WithDetail(
+					pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+					"Number of returned columns does not match expected column count.",
+				))
+			}
+			for i := range cols {
+				elems[i] = castCol(cols[i].ID, contents[i])
+			}
+		}
+		scalar = b.ob.factory.ConstructTuple(elems, b.returnType)
+	} else {
+		if len(cols) != 1 {
+			panic(errors.WithDetail(
+				pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+				"Number of returned columns does not match expected column count.",
+			))
+		}
+		scalar = castCol(cols[0].ID, b.returnType)
+	}
+	returnScope := stmtScope.push()
+	colName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_query"))
+	b.ob.synthesizeColumn(returnScope, colName, b.returnType, nil /* expr */, scalar)
+	returnScope.copyOrdering(stmtScope)
+	b.ob.constructProjectForScope(stmtScope, returnScope)
+	return returnScope
+}
+
+// checkSetReturning returns an error if the function is not set-returning,
+// since the given statement can only be used in a set-returning function.
+func (b *plpgsqlBuilder) checkSetReturning(stmtName string) {
+	if !b.isSetReturning {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch, "cannot use %s in a non-SETOF function", stmtName))
+	}
+}
+
+// checkCursorVariable returns an error if the given variable cannot hold the
+// name of a cursor.
+func (b *plpgsqlBuilder) checkCursorVariable(name ast.Variable) {
+	typ, ok := b.varTypes[name]
+	if !ok {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
+	}
+	if typ.Family() != types.StringFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"variable \"%s\" must be of type cursor or refcursor", name,
+		))
+	}
+}
+
+// getVariableArgIdx returns the index of the argument that supplies the value
+// of the given variable when a continuation routine is called. See
+// makeContinuation.
+func (b *plpgsqlBuilder) getVariableArgIdx(name ast.Variable) int {
+	for i := range b.decls {
+		if b.decls[i].Var == name {
+			return i
+		}
+	}
+	for i := range b.params {
+		if tree.Name(b.params[i].Name) == name {
+			return len(b.decls) + i
+		}
+	}
+	panic(errors.AssertionFailedf("failed to find variable %s", name))
+}
+
+// makeRaiseIfNull returns a statement that raises an error with the given
+// message if the given variable is NULL.
+func (b *plpgsqlBuilder) makeRaiseIfNull(name ast.Variable, message string) ast.Statement {
+	return &ast.If{
+		Condition: &tree.IsNullExpr{Expr: makeVarRef(name)},
+		ThenBody: []ast.Statement{&ast.Raise{
+			Code:    pgcode.NullValueNotAllowed.String(),
+			Message: message,
+		}},
+	}
+}
+
+// makeVarRef returns a reference to the variable with the given name.
+func makeVarRef(name ast.Variable) *tree.UnresolvedName {
+	return &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{string(name)}}
 }
 
 // getRaiseArgs validates the options attached to the given PLpgSQL RAISE
@@ -904,12 +1717,40 @@ func (b *plpgsqlBuilder) appendPlpgSQLStmts(con *continuation, stmts []ast.State
 // given continuation function.
 func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 	if con == nil {
+		if b.isProcedure || b.isSetReturning || b.returnType.Family() == types.VoidFamily {
+			// A procedure, a set-returning function, or a function returning VOID
+			// implicitly returns when control reaches the end of the routine.
+			return b.buildPLpgSQLReturn(&ast.Return{}, s)
+		}
 		// There is no continuation. If the control flow reaches this point, we need
 		// to throw a runtime error.
 		return b.buildEndOfFunctionRaise(s)
 	}
+	// PLpgSQL continuation routines are always in tail-call position.
+	call := b.ob.factory.ConstructUDFCall(
+		b.makeContinuationArgs(s), &memo.UDFCallPrivate{Def: con.def, TailCall: true},
+	)
+
+	returnColName := scopeColName("").WithMetadataName(con.def.Name)
+	returnScope := s.push()
+	b.ensureScopeHasExpr(returnScope)
+	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, call)
+	b.ob.constructProjectForScope(s, returnScope)
+	return returnScope
+}
+
+// makeContinuationArgs builds the arguments for a call to a continuation
+// routine from the current values of the variables and parameters in the
+// given scope.
+func (b *plpgsqlBuilder) makeContinuationArgs(s *scope) memo.ScalarListExpr {
 	args := make(memo.ScalarListExpr, 0, len(b.decls)+len(b.params))
 	addArg := func(name tree.Name, typ *types.T) {
+		if name == "" {
+			// An unnamed parameter cannot be referenced, so its value does not need
+			// to be passed to the continuation.
+			args = append(args, b.ob.factory.ConstructNull(typ))
+			return
+		}
 		_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, name)
 		if err != nil {
 			panic(err)
@@ -926,15 +1767,7 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 	for _, param := range b.params {
 		addArg(tree.Name(param.Name), param.Typ)
 	}
-	// PLpgSQL continuation routines are always in tail-call position.
-	call := b.ob.factory.ConstructUDFCall(args, &memo.UDFCallPrivate{Def: con.def, TailCall: true})
-
-	returnColName := scopeColName("").WithMetadataName(con.def.Name)
-	returnScope := s.push()
-	b.ensureScopeHasExpr(returnScope)
-	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, call)
-	b.ob.constructProjectForScope(s, returnScope)
-	return returnScope
+	return args
 }
 
 // buildPLpgSQLExpr parses and builds the given SQL expression into a ScalarExpr
@@ -948,6 +1781,16 @@ func (b *plpgsqlBuilder) buildPLpgSQLExpr(expr ast.Expr, typ *types.T, s *scope)
 	return b.ob.buildScalar(typedExpr, s, nil, nil, b.colRefs)
 }
 
+// checkDomainConstraints wraps the given scalar, which is assigned to a
+// variable of type typ, in an assignment cast if typ is a domain. This ensures
+// that the assigned value satisfies the constraints of the domain.
+func (b *plpgsqlBuilder) checkDomainConstraints(scalar opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	if !typ.IsDomain() {
+		return scalar
+	}
+	return b.ob.factory.ConstructAssignmentCast(scalar, typ)
+}
+
 // resolveVariableForAssign attempts to retrieve the type of the variable with
 // the given name, throwing an error if no such variable exists.
 func (b *plpgsqlBuilder) resolveVariableForAssign(name tree.Name) *types.T {
@@ -0,0 +1,210 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// rowLevelSecurityUser returns the user whose row-level security policies
+// apply to the statement being built. This is the owner of the routine if
+// the body of a SECURITY DEFINER routine is being built, and the session user
+// otherwise.
+func (b *Builder) rowLevelSecurityUser() username.SQLUsername {
+	if !b.checkPrivilegeUser.Undefined() {
+		return b.checkPrivilegeUser
+	}
+	return b.evalCtx.SessionData().User()
+}
+
+// evalRowLevelSecurityCheck performs the given check for check.User and
+// records its result in the metadata, so that a cached memo is invalidated if
+// the result changes.
+func (b *Builder) evalRowLevelSecurityCheck(check opt.RowLevelSecurityCheck) bool {
+	res, err := check.Eval(b.ctx, b.catalog)
+	if err != nil {
+		panic(err)
+	}
+	check.Result = res
+	isSessionUser := b.checkPrivilegeUser.Undefined()
+	b.factory.Metadata().AddRowLevelSecurityCheck(check, isSessionUser)
+	return res
+}
+
+// isSubjectToRowLevelSecurity returns true if the policies of the given table
+// restrict the rows that the current user can access. This is the case if
+// row-level security is enabled on the table, and the user neither has the
+// BYPASSRLS role option nor owns the table (unless row-level security is
+// forced for the owner). Row-level security is never applied while building
+// view or function definitions, nor while building FK cascades.
+func (b *Builder) isSubjectToRowLevelSecurity(tab cat.Table) bool {
+	if !tab.IsRowLevelSecurityEnabled() ||
+		b.insideViewDef || b.insideFuncDef || b.skipRowLevelSecurity {
+		return false
+	}
+	user := b.rowLevelSecurityUser()
+	if b.evalRowLevelSecurityCheck(opt.RowLevelSecurityCheck{User: user}) {
+		return false
+	}
+	if !tab.IsRowLevelSecurityForced() &&
+		b.evalRowLevelSecurityCheck(opt.RowLevelSecurityCheck{User: user, Table: tab}) {
+		return false
+	}
+	return true
+}
+
+// rowLevelSecurityPolicies returns the policies of the given table that apply
+// to the given command for the current user. ok is false if the current user
+// is not subject to row-level security on the table, in which case the
+// policies must not be applied at all. Note that if ok is true and no
+// policies are returned, no rows may be accessed.
+func (b *Builder) rowLevelSecurityPolicies(
+	tab cat.Table, cmd tree.PolicyCommand,
+) (policies []cat.Policy, ok bool) {
+	if !b.isSubjectToRowLevelSecurity(tab) {
+		return nil, false
+	}
+	user := b.rowLevelSecurityUser()
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		policy := tab.Policy(i)
+		if !policy.AppliesToCommand(cmd) {
+			continue
+		}
+		for _, role := range policy.Roles {
+			if b.evalRowLevelSecurityCheck(opt.RowLevelSecurityCheck{User: user, Role: role}) {
+				policies = append(policies, policy)
+				break
+			}
+		}
+	}
+	return policies, true
+}
+
+// combinePolicyExprs combines the expressions of the given policies into a
+// single boolean expression: the permissive expressions are combined with OR,
+// and the result is combined with each of the restrictive expressions using
+// AND. If there are no permissive expressions, the result is false. getExpr
+// returns the expression of a policy, or the empty string if the policy does
+// not have the expression, in which case the policy is ignored.
+func combinePolicyExprs(policies []cat.Policy, getExpr func(p *cat.Policy) string) tree.Expr {
+	var permissive, restrictive tree.Expr
+	for i := range policies {
+		p := &policies[i]
+		exprStr := getExpr(p)
+		if exprStr == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		switch {
+		case p.Restrictive && restrictive == nil:
+			restrictive = expr
+		case p.Restrictive:
+			restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+		case permissive == nil:
+			permissive = expr
+		default:
+			permissive = &tree.OrExpr{Left: permissive, Right: expr}
+		}
+	}
+	if permissive == nil {
+		permissive = tree.DBoolFalse
+	}
+	if restrictive == nil {
+		return permissive
+	}
+	return &tree.AndExpr{Left: &tree.ParenExpr{Expr: permissive}, Right: restrictive}
+}
+
+// policyUsingExpr returns the USING expression of the policy.
+func policyUsingExpr(p *cat.Policy) string {
+	return p.UsingExpr
+}
+
+// policyWithCheckExpr returns the WITH CHECK expression of the policy. If it
+// was not specified, the USING expression is used instead.
+func policyWithCheckExpr(p *cat.Policy) string {
+	if p.WithCheckExpr != "" {
+		return p.WithCheckExpr
+	}
+	return p.UsingExpr
+}
+
+// addRowLevelSecurityFilter wraps the expression of the given scope, which
+// must be a scan of the given table, in a Select that filters out the rows
+// that are not visible to the current user according to the USING
+// expressions of the policies of the table that apply to the given command.
+// If the current user is not subject to row-level security on the table, the
+// scope is not modified.
+//
+// The Select is wrapped in a Barrier, so that the filters of the query are
+// evaluated on the visible rows only. Otherwise, the optimizer could evaluate
+// a filter of the query before the filter of the policies, and the errors or
+// side effects of the filter (such as a function that raises an error for
+// some values) could reveal the rows that are not visible. Leakproof filters
+// can still be pushed into the Barrier, so that they can constrain the scan.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, scope *scope, cmd tree.PolicyCommand,
+) {
+	policies, ok := b.rowLevelSecurityPolicies(tab, cmd)
+	if !ok {
+		return
+	}
+	expr := combinePolicyExprs(policies, policyUsingExpr)
+	texpr := scope.resolveAndRequireType(expr, types.Bool)
+	filter := b.buildScalar(texpr, scope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	scope.expr = b.factory.ConstructBarrier(b.factory.ConstructSelect(
+		scope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	))
+}
+
+// rowLevelSecurityCheckExpr returns the expression that the rows written by
+// the mutation must satisfy according to the WITH CHECK expressions of the
+// policies of the target table, or nil if the current user is not subject to
+// row-level security on the table. The rows written by an UPSERT must satisfy
+// the policies of both INSERT and UPDATE commands.
+func (mb *mutationBuilder) rowLevelSecurityCheckExpr(isUpdate bool) tree.Expr {
+	var cmds []tree.PolicyCommand
+	switch {
+	case isUpdate:
+		cmds = []tree.PolicyCommand{tree.PolicyCommandUpdate}
+	case mb.canaryColID != 0:
+		// Only UPSERT mutations have a canary column. MERGE is not supported
+		// on tables with row-level security.
+		cmds = []tree.PolicyCommand{tree.PolicyCommandInsert, tree.PolicyCommandUpdate}
+	default:
+		cmds = []tree.PolicyCommand{tree.PolicyCommandInsert}
+	}
+	var res tree.Expr
+	for _, cmd := range cmds {
+		policies, ok := mb.b.rowLevelSecurityPolicies(mb.tab, cmd)
+		if !ok {
+			return nil
+		}
+		expr := combinePolicyExprs(policies, policyWithCheckExpr)
+		if res == nil {
+			res = expr
+		} else {
+			res = &tree.AndExpr{Left: &tree.ParenExpr{Expr: res}, Right: &tree.ParenExpr{Expr: expr}}
+		}
+	}
+	return res
+}
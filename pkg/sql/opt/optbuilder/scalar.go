@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
@@ -34,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -125,6 +127,9 @@ func (b *Builder) buildScalar(
 	case *windowInfo:
 		return b.finishBuildScalarRef(t.col, inScope, outScope, outCol, colRefs)
 
+	case *groupingInfo:
+		return b.buildGroupingFunc(t, outScope, outCol, colRefs)
+
 	case *tree.AndExpr:
 		left := b.buildScalar(reType(t.TypedLeft(), types.Bool), inScope, nil, nil, colRefs)
 		right := b.buildScalar(reType(t.TypedRight(), types.Bool), inScope, nil, nil, colRefs)
@@ -624,6 +629,10 @@ func (b *Builder) buildUDF(
 	colRefs *opt.ColSet,
 ) (out opt.ScalarExpr) {
 	o := f.ResolvedOverload()
+	if f.ResolvedType().Family() == types.TriggerFamily {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"trigger functions can only be called as triggers"))
+	}
 	b.factory.Metadata().AddUserDefinedFunction(o, f.Func.ReferenceByName)
 
 	// Validate that the return types match the original return types defined in
@@ -708,6 +717,13 @@ func (b *Builder) buildUDF(
 			col := b.synthesizeColumn(bodyScope, argColName, paramType.Typ, nil /* expr */, nil /* scalar */)
 			col.setParamOrd(i)
 			params[i] = col.id
+			// The argument of a parameter with a domain type must satisfy the
+			// constraints of the domain. The argument may already have the domain
+			// type without having been checked, e.g., if it is a constant, so an
+			// assignment cast is always added.
+			if paramType.Typ.IsDomain() && i < len(args) {
+				args[i] = b.factory.ConstructAssignmentCast(args[i], paramType.Typ)
+			}
 		}
 	}
 
@@ -716,8 +732,23 @@ func (b *Builder) buildUDF(
 	// We'll need to track the depth of the UDFs we are building expressions
 	// within.
 	b.insideUDF = true
+	// The body of a SECURITY DEFINER routine is built with the privileges of
+	// the routine's owner.
+	if o.SecurityDefiner {
+		defer func(user username.SQLUsername) { b.checkPrivilegeUser = user }(b.checkPrivilegeUser)
+		b.checkPrivilegeUser = o.Owner
+	}
+	// Stable expressions in the body of a routine that modifies the session
+	// cannot be folded here, since their results may depend on the user and
+	// settings that are only in effect while the routine executes.
+	if o.SecurityDefiner || len(o.SessionConfig) > 0 {
+		fc := b.factory.FoldingControl()
+		defer func(save norm.FoldingControl) { *fc = save }(*fc)
+		fc.DisallowStableFolds()
+	}
 	isSetReturning := o.Class == tree.GeneratorClass
 	isMultiColDataSource := false
+	var resultBufferID tree.RoutineResultBufferID
 
 	// Build an expression for each statement in the function body.
 	var body []memo.RelExpr
@@ -769,9 +800,20 @@ func (b *Builder) buildUDF(
 		// TODO(#108298): Figure out how to handle PLpgSQL functions with VOID
 		// return types.
 		var plBuilder plpgsqlBuilder
-		plBuilder.init(b, colRefs, o.Types.(tree.ParamTypes), stmt.AST, rtyp)
+		plBuilder.init(
+			b, colRefs, o.Types.(tree.ParamTypes), stmt.AST, rtyp, o.IsProcedure, isSetReturning,
+			o.OutParamOrdinals,
+		)
 		stmtScope := plBuilder.build(stmt.AST, bodyScope)
-		b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+		if isSetReturning {
+			// The rows returned by a set-returning PL/pgSQL function are collected
+			// in a result buffer rather than returned by the last statement, so
+			// only the types of the result need to be determined here.
+			_, _, isMultiColDataSource = b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+			resultBufferID = plBuilder.resultBufferID
+		} else {
+			b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+		}
 		body = []memo.RelExpr{stmtScope.expr}
 		bodyProps = []*physical.Required{stmtScope.makePhysicalProps()}
 	default:
@@ -780,19 +822,27 @@ func (b *Builder) buildUDF(
 
 	b.insideUDF = false
 
+	// Procedures are always treated as volatile, since they are invoked only
+	// for their side effects and must never be inlined or folded.
+	vol := o.Volatility
+	if o.IsProcedure {
+		vol = volatility.Volatile
+	}
 	out = b.factory.ConstructUDFCall(
 		args,
 		&memo.UDFCallPrivate{
 			Def: &memo.UDFDefinition{
 				Name:               def.Name,
 				Typ:                f.ResolvedType(),
-				Volatility:         o.Volatility,
+				Volatility:         vol,
 				SetReturning:       isSetReturning,
 				CalledOnNullInput:  o.CalledOnNullInput,
 				MultiColDataSource: isMultiColDataSource,
 				Body:               body,
 				BodyProps:          bodyProps,
 				Params:             params,
+				ResultBufferID:     resultBufferID,
+				SessionOverrides:   makeRoutineSessionOverrides(o),
 			},
 		},
 	)
@@ -812,6 +862,19 @@ func (b *Builder) buildUDF(
 	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
 }
 
+// makeRoutineSessionOverrides returns the changes to the session that are
+// applied while executing the given routine, or nil if there are none.
+func makeRoutineSessionOverrides(o *tree.Overload) *tree.RoutineSessionOverrides {
+	if !o.SecurityDefiner && len(o.SessionConfig) == 0 {
+		return nil
+	}
+	overrides := &tree.RoutineSessionOverrides{Settings: o.SessionConfig}
+	if o.SecurityDefiner {
+		overrides.User = o.Owner
+	}
+	return overrides
+}
+
 // finishBuildLastStmt manages the columns returned by the last statement of a
 // UDF. Depending on the context and return type of the UDF, this may mean
 // expanding a tuple into multiple columns, or combining multiple columns into
@@ -1080,7 +1143,14 @@ func (b *Builder) constructComparison(
 		}
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
+		if cmp.Op.LeftType.Family() == types.JsonFamily {
+			// The @@ operator means "jsonpath match" when used with jsonb and
+			// jsonpath operands.
+			return b.factory.ConstructJsonPathMatch(left, right)
+		}
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.JSONPathExists:
+		return b.factory.ConstructJsonPathExists(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
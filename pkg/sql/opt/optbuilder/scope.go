@@ -129,6 +129,7 @@ const (
 	exprKindHaving
 	exprKindLateralJoin
 	exprKindLimit
+	exprKindMergeWhen
 	exprKindOffset
 	exprKindOn
 	exprKindOrderBy
@@ -137,6 +138,7 @@ const (
 	exprKindReturning
 	exprKindSelect
 	exprKindStoreID
+	exprKindTableSample
 	exprKindValues
 	exprKindWhere
 	exprKindWindowFrameStart
@@ -152,6 +154,7 @@ var exprKindName = [...]string{
 	exprKindHaving:            "HAVING",
 	exprKindLateralJoin:       "LATERAL JOIN",
 	exprKindLimit:             "LIMIT",
+	exprKindMergeWhen:         "MERGE WHEN",
 	exprKindOffset:            "OFFSET",
 	exprKindOn:                "ON",
 	exprKindOrderBy:           "ORDER BY",
@@ -160,6 +163,7 @@ var exprKindName = [...]string{
 	exprKindReturning:         "RETURNING",
 	exprKindSelect:            "SELECT",
 	exprKindStoreID:           "RELOCATE STORE ID",
+	exprKindTableSample:       "TABLESAMPLE",
 	exprKindValues:            "VALUES",
 	exprKindWhere:             "WHERE",
 	exprKindWindowFrameStart:  "WINDOW FRAME START",
@@ -986,6 +990,31 @@ func (s *scope) Resolve(
 	return nil, colinfo.NewUndefinedColumnError(tree.ErrString(tree.NewColumnItem(prefix, colName)))
 }
 
+// resolveRecordField resolves a column item of the form <record>.<field> to a
+// field of a record variable of a trigger function, or returns nil if the
+// column item does not refer to such a variable.
+func (s *scope) resolveRecordField(t *tree.ColumnItem) tree.Expr {
+	if !s.builder.insideTriggerFunc || t.TableName == nil || t.TableName.NumParts != 1 {
+		return nil
+	}
+	recordName := tree.Name(t.TableName.Parts[0])
+	_, source, _, err := s.FindSourceProvidingColumn(s.builder.ctx, recordName)
+	if err != nil || source == nil {
+		return nil
+	}
+	col := source.(*scopeColumn)
+	if col.typ.Family() != types.TupleFamily {
+		return nil
+	}
+	for _, label := range col.typ.TupleLabels() {
+		if tree.Name(label) == t.ColumnName {
+			return &tree.ColumnAccessExpr{Expr: col, ColName: t.ColumnName}
+		}
+	}
+	panic(pgerror.Newf(pgcode.UndefinedColumn,
+		"record \"%s\" has no field \"%s\"", recordName, t.ColumnName))
+}
+
 func makeUntypedTuple(labels []string, texprs []tree.TypedExpr) *tree.Tuple {
 	exprs := make(tree.Exprs, len(texprs))
 	for i, e := range texprs {
@@ -1029,6 +1058,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.ColumnItem:
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
+			// It may be a reference to a field of the NEW or OLD record in the
+			// body of a trigger function, e.g. SELECT new.a.
+			if field := s.resolveRecordField(t); field != nil {
+				return false, field
+			}
 			// It may be a reference to a table, e.g. SELECT tbl FROM tbl.
 			// Attempt to resolve as a TupleStar.
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -1084,6 +1118,9 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			break
 		}
 
+	case *tree.GroupingFuncExpr:
+		expr = s.replaceGrouping(t)
+
 	case *tree.ArrayFlatten:
 		if sub, ok := t.Subquery.(*tree.Subquery); ok {
 			// Copy the ArrayFlatten expression so that the tree isn't mutated.
@@ -1292,6 +1329,71 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	return s.builder.buildAggregateFunction(f, &private, tempScope, s)
 }
 
+// replaceGrouping returns a groupingInfo struct that can be used to replace a
+// raw GROUPING expression. The arguments of GROUPING must be grouping
+// expressions of the current query level; they are matched against the
+// grouping columns when the groupingInfo is built.
+func (s *scope) replaceGrouping(f *tree.GroupingFuncExpr) tree.Expr {
+	semaCtx := s.builder.semaCtx
+	if semaCtx.Properties.IsSet(tree.RejectAggregates) {
+		panic(tree.NewInvalidGroupingOperationError(semaCtx.TypeCheckContext()))
+	}
+	if semaCtx.Properties.IsSet(tree.RejectNestedAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping,
+			"aggregate function calls cannot contain grouping operations",
+		))
+	}
+	s.verifyGroupingContext()
+	if len(f.Exprs) > 31 {
+		panic(pgerror.Newf(pgcode.TooManyArguments, "GROUPING must have fewer than 32 arguments"))
+	}
+
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called within a subquery
+	// context.
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	semaCtx.Properties.Require("GROUPING", tree.RejectSpecial)
+
+	expr := f.Walk(s).(*tree.GroupingFuncExpr)
+	info := &groupingInfo{
+		GroupingFuncExpr: &tree.GroupingFuncExpr{Exprs: make(tree.Exprs, len(expr.Exprs))},
+		args:             make([]tree.TypedExpr, len(expr.Exprs)),
+	}
+	for i, e := range expr.Exprs {
+		typedArg, err := tree.TypeCheck(s.builder.ctx, e, semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		info.Exprs[i] = typedArg
+		info.args[i] = typedArg
+	}
+
+	if s.groupby == nil {
+		s.initGrouping()
+	}
+	info.g = s.groupby
+	return info
+}
+
+// verifyGroupingContext checks that the current scope is allowed to contain
+// GROUPING expressions.
+func (s *scope) verifyGroupingContext() {
+	switch s.context {
+	case exprKindLateralJoin:
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in FROM clause of their own query level",
+		))
+
+	case exprKindOn:
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in JOIN conditions",
+		))
+
+	case exprKindWhere:
+		panic(tree.NewInvalidGroupingOperationError(s.context.String()))
+	}
+}
+
 func (s *scope) lookupWindowDef(name tree.Name) *tree.WindowDef {
 	for i := range s.windowDefs {
 		if s.windowDefs[i].Name == name {
@@ -1354,6 +1456,10 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	if f.ResolvedOverload().UserDefinedAggregate != nil {
+		panic(unimplementedWithIssueDetailf(74775, "user-defined aggregate window function",
+			"user-defined aggregates cannot be used as window functions"))
+	}
 
 	// We will be performing type checking on expressions from PARTITION BY and
 	// ORDER BY clauses below, and we need the semantic context to know that we
@@ -91,7 +91,11 @@ func (b *Builder) buildDataSource(
 			locking = locking.filter(source.As.Alias)
 		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
+		if source.TableSample != nil {
+			outScope = b.buildSampledTable(source.Expr, source.TableSample, indexFlags, locking, inScope)
+		} else {
+			outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
+		}
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality(outScope)
@@ -146,7 +150,7 @@ func (b *Builder) buildDataSource(
 		switch t := ds.(type) {
 		case cat.Table:
 			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -155,7 +159,10 @@ func (b *Builder) buildDataSource(
 				}),
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
+				nil,   /* sample */
 			)
+			b.addRowLevelSecurityFilter(t, outScope, tree.PolicyCommandSelect)
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -459,7 +466,122 @@ func (b *Builder) buildScanFromTableRef(
 	tn := tree.MakeUnqualifiedTableName(tab.Name())
 	tabMeta := b.addTable(tab, &tn)
 
-	return b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	outScope = b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */, nil /* sample */)
+	b.addRowLevelSecurityFilter(tab, outScope, tree.PolicyCommandSelect)
+	return outScope
+}
+
+// buildSampledTable builds a scan of the table named by texpr that only
+// returns a random sample of its rows, as specified by the TABLESAMPLE clause.
+// Only tables and materialized views can be sampled.
+func (b *Builder) buildSampledTable(
+	texpr tree.TableExpr,
+	sample *tree.TableSample,
+	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
+	inScope *scope,
+) (outScope *scope) {
+	tn, ok := texpr.(*tree.TableName)
+	if !ok || inScope.resolveCTE(tn) != nil {
+		panic(errTableSampleNotTable())
+	}
+
+	ds, depName, resName := b.resolveDataSource(tn, privilege.SELECT)
+	locking = locking.filter(tn.ObjectName)
+	if locking.isSet() {
+		// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
+		b.checkPrivilege(depName, ds, privilege.UPDATE)
+	}
+	tab, ok := ds.(cat.Table)
+	if !ok || tab.IsVirtualTable() {
+		panic(errTableSampleNotTable())
+	}
+
+	private := b.buildTableSample(sample)
+	tabMeta := b.addTable(tab, &resName)
+	outScope = b.buildScan(
+		tabMeta,
+		tableOrdinals(tab, columnKinds{
+			includeMutations: false,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags, locking, inScope,
+		false, /* disableNotVisibleIndex */
+		&private,
+	)
+	b.addRowLevelSecurityFilter(tab, outScope, tree.PolicyCommandSelect)
+	return outScope
+}
+
+func errTableSampleNotTable() error {
+	return pgerror.New(pgcode.WrongObjectType,
+		"TABLESAMPLE clause can only be applied to tables and materialized views")
+}
+
+// buildTableSample evaluates the arguments of a TABLESAMPLE clause. The
+// arguments are evaluated once, when the statement is built. If they contain
+// placeholders that have not been assigned yet, the memo is marked as not
+// reusable, so that the statement is built again once the placeholder values
+// are known.
+func (b *Builder) buildTableSample(sample *tree.TableSample) opt.TableSample {
+	private := opt.TableSample{Method: sample.Method, Fraction: 1}
+	if d, ok := b.evalTableSampleArg(sample.Percent, types.Float); ok {
+		if d == tree.DNull {
+			panic(pgerror.New(pgcode.InvalidTablesampleArgument,
+				"TABLESAMPLE percentage cannot be null"))
+		}
+		percent := float64(*d.(*tree.DFloat))
+		if !(percent >= 0 && percent <= 100) {
+			panic(pgerror.New(pgcode.InvalidTablesampleArgument,
+				"sample percentage must be between 0 and 100"))
+		}
+		private.Fraction = percent / 100
+	}
+	if sample.Seed != nil {
+		private.Repeatable = true
+		if d, ok := b.evalTableSampleArg(sample.Seed, types.Int); ok {
+			if d == tree.DNull {
+				panic(pgerror.New(pgcode.InvalidTablesampleRepeat,
+					"TABLESAMPLE REPEATABLE parameter cannot be null"))
+			}
+			private.Seed = int64(*d.(*tree.DInt))
+		}
+	}
+	return private
+}
+
+// evalTableSampleArg type checks and evaluates an argument of a TABLESAMPLE
+// clause. It returns ok=false if the argument contains placeholders that cannot
+// be replaced yet.
+func (b *Builder) evalTableSampleArg(expr tree.Expr, typ *types.T) (_ tree.Datum, ok bool) {
+	defer b.semaCtx.Properties.Restore(b.semaCtx.Properties)
+	b.semaCtx.Properties.Require(exprKindTableSample.String(), tree.RejectSpecial|tree.RejectSubqueries)
+
+	// The arguments cannot refer to any columns or contain subqueries, so they
+	// are type checked without resolving names. As a result, the only variables
+	// they can contain are placeholders.
+	texpr, err := tree.TypeCheckAndRequire(b.ctx, expr, b.semaCtx, typ, exprKindTableSample.String())
+	if err != nil {
+		panic(err)
+	}
+	if tree.ContainsVars(texpr) {
+		if b.KeepPlaceholders || !b.evalCtx.HasPlaceholders() {
+			b.DisableMemoReuse = true
+			return nil, false
+		}
+		b.HadPlaceholders = true
+	}
+	if _, isConst := texpr.(tree.Datum); !isConst {
+		// The argument could evaluate differently the next time the statement is
+		// executed (e.g. if it calls a volatile function).
+		b.DisableMemoReuse = true
+	}
+	d, err := eval.Expr(b.ctx, b.evalCtx, texpr)
+	if err != nil {
+		panic(err)
+	}
+	return d, true
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -522,6 +644,7 @@ func (b *Builder) buildScan(
 	locking lockingSpec,
 	inScope *scope,
 	disableNotVisibleIndex bool,
+	sample *opt.TableSample,
 ) (outScope *scope) {
 	if ordinals == nil {
 		panic(errors.AssertionFailedf("no ordinals"))
@@ -728,6 +851,14 @@ func (b *Builder) buildScan(
 		private.Flags.NoZigzagJoin = true
 	}
 	private.Flags.DisableNotVisibleIndex = disableNotVisibleIndex
+	if sample != nil {
+		if (private.Flags.ForceIndex && private.Flags.Index != cat.PrimaryIndex) ||
+			private.Flags.ForceZigzag {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"TABLESAMPLE can only be used with the primary index"))
+		}
+		private.Sample = *sample
+	}
 
 	b.addCheckConstraintsForTable(tabMeta)
 	b.addComputedColsForTable(tabMeta, virtualMutationColOrds)
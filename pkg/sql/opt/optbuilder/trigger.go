@@ -0,0 +1,641 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// triggerRecordOrdinals returns the ordinals of the columns of the given table
+// that make up the NEW and OLD records passed to its trigger functions. These
+// are the columns that are visible to queries.
+func triggerRecordOrdinals(tab cat.Table) []int {
+	ords := make([]int, 0, tab.ColumnCount())
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// triggerRecordType returns the type of the NEW and OLD records of the triggers
+// of the given table, which is a tuple with a labeled field for each of the
+// given columns.
+func triggerRecordType(tab cat.Table, ords []int) *types.T {
+	contents := make([]*types.T, len(ords))
+	labels := make([]string, len(ords))
+	for i, ord := range ords {
+		col := tab.Column(ord)
+		contents[i] = col.DatumType()
+		labels[i] = string(col.ColName())
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// triggersForEvent returns the ordinals of the triggers of the target table
+// that fire at the given time for the given event, in the order in which they
+// fire. Triggers are not fired when a view or function definition is being
+// built.
+func (mb *mutationBuilder) triggersForEvent(
+	actionTime tree.TriggerActionTime, event tree.TriggerEventType,
+) []int {
+	if !mb.hasTriggers() {
+		return nil
+	}
+	var triggers []int
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trigger := mb.tab.Trigger(i)
+		if trigger.ActionTime != actionTime || !trigger.FiresOn(event) {
+			continue
+		}
+		if event == tree.TriggerEventUpdate && len(trigger.UpdateColumnOrdinals) > 0 {
+			// An UPDATE OF trigger only fires if one of its columns is updated.
+			updated := false
+			for _, ord := range trigger.UpdateColumnOrdinals {
+				if mb.targetColSet.Contains(mb.tabID.ColumnID(ord)) {
+					updated = true
+					break
+				}
+			}
+			if !updated {
+				continue
+			}
+		}
+		triggers = append(triggers, i)
+	}
+	return triggers
+}
+
+// hasTriggers returns whether any triggers of the target table may fire.
+func (mb *mutationBuilder) hasTriggers() bool {
+	return mb.tab.TriggerCount() > 0 && !mb.b.insideViewDef && !mb.b.insideFuncDef
+}
+
+// triggerNewAndOldCols returns the columns of the mutation input that hold the
+// fields of the NEW and OLD records of the triggers for the given event. Either
+// list is nil if the corresponding record is NULL for the event.
+func (mb *mutationBuilder) triggerNewAndOldCols(
+	event tree.TriggerEventType, ords []int,
+) (newCols, oldCols opt.ColList) {
+	switch event {
+	case tree.TriggerEventInsert:
+		newCols = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			newCols[i] = mb.insertColIDs[ord]
+		}
+	case tree.TriggerEventUpdate:
+		newCols = make(opt.ColList, len(ords))
+		oldCols = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			oldCols[i] = mb.fetchColIDs[ord]
+			newCols[i] = mb.updateColIDs[ord]
+			if newCols[i] == 0 {
+				newCols[i] = oldCols[i]
+			}
+		}
+	case tree.TriggerEventDelete:
+		oldCols = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			oldCols[i] = mb.fetchColIDs[ord]
+		}
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger event %v", event))
+	}
+	return newCols, oldCols
+}
+
+// buildBeforeTriggers wraps the mutation input in expressions that call the
+// functions of the BEFORE row triggers that fire for the given event. For
+// example, for a table t with columns a and b, and a trigger tr that fires
+// before inserts:
+//
+//	project
+//	 ├── columns: a_tr:9 b_tr:10 ...
+//	 ├── select
+//	 │    ├── project
+//	 │    │    ├── columns: tr:8 ...
+//	 │    │    ├── project
+//	 │    │    │    ├── columns: new:6 old:7 ...
+//	 │    │    │    ├── <mutation input>
+//	 │    │    │    └── projections
+//	 │    │    │         ├── (a, b) [as=new:6]
+//	 │    │    │         └── NULL [as=old:7]
+//	 │    │    └── projections
+//	 │    │         └── tr_func(new:6, old:7, ...) [as=tr:8]
+//	 │    └── filters
+//	 │         └── tr:8 IS DISTINCT FROM NULL
+//	 └── projections
+//	      ├── (tr:8).a [as=a_tr:9]
+//	      └── (tr:8).b [as=b_tr:10]
+//
+// A row is skipped if a trigger function returns NULL. Otherwise, the record
+// returned by the function of an INSERT or UPDATE trigger becomes the new row,
+// and computed columns are recomputed from it. The triggers fire in order,
+// each receiving the row returned by the previous one.
+//
+// For an UPSERT or INSERT .. ON CONFLICT DO UPDATE, the UPDATE triggers only
+// fire for the rows that conflict with an existing row, which are those with a
+// non-NULL canary column.
+func (mb *mutationBuilder) buildBeforeTriggers(event tree.TriggerEventType) {
+	triggers := mb.triggersForEvent(tree.TriggerActionTimeBefore, event)
+	if len(triggers) == 0 {
+		return
+	}
+	tabID := mb.tab.ID()
+	for _, id := range mb.b.beforeTriggerTables {
+		if id == tabID {
+			panic(unimplemented.New("recursive triggers",
+				"BEFORE triggers that recursively modify their own table are not yet supported"))
+		}
+	}
+	mb.b.beforeTriggerTables = append(mb.b.beforeTriggerTables, tabID)
+	defer func() {
+		mb.b.beforeTriggerTables = mb.b.beforeTriggerTables[:len(mb.b.beforeTriggerTables)-1]
+	}()
+
+	f := mb.b.factory
+	var guard opt.ScalarExpr
+	if event == tree.TriggerEventUpdate && mb.canaryColID != 0 {
+		guard = f.ConstructIsNot(f.ConstructVariable(mb.canaryColID), memo.NullSingleton)
+	}
+	ords := triggerRecordOrdinals(mb.tab)
+	for _, i := range triggers {
+		trigger := mb.tab.Trigger(i)
+		newCols, oldCols := mb.triggerNewAndOldCols(event, ords)
+		var resultCol opt.ColumnID
+		mb.outScope, resultCol = mb.b.buildRowTrigger(
+			mb.outScope, mb.tab, &trigger, event, ords, newCols, oldCols, guard,
+		)
+
+		// Skip the row if the trigger function returned NULL.
+		mb.outScope.expr = f.ConstructSelect(
+			mb.outScope.expr,
+			memo.FiltersExpr{f.ConstructFiltersItem(
+				f.ConstructIsNot(f.ConstructVariable(resultCol), memo.NullSingleton),
+			)},
+		)
+		if event == tree.TriggerEventDelete {
+			continue
+		}
+
+		// Project the fields of the returned record as the new row.
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		colIDs := mb.insertColIDs
+		if event == tree.TriggerEventUpdate {
+			colIDs = mb.updateColIDs
+		}
+		for j, ord := range ords {
+			col := mb.tab.Column(ord)
+			if col.IsComputed() {
+				continue
+			}
+			colName := scopeColName(col.ColName()).WithMetadataName(
+				fmt.Sprintf("%s_%s", col.ColName(), trigger.Name),
+			)
+			field := f.ConstructColumnAccess(f.ConstructVariable(resultCol), memo.TupleOrdinal(j))
+			scopeCol := mb.b.synthesizeColumn(projectionsScope, colName, col.DatumType(), nil /* expr */, field)
+			colIDs[ord] = scopeCol.id
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+	}
+	if event == tree.TriggerEventDelete {
+		return
+	}
+
+	// Recompute the computed columns from the rows returned by the triggers.
+	colIDs := mb.insertColIDs
+	if event == tree.TriggerEventUpdate {
+		colIDs = mb.updateColIDs
+	}
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		if mb.tab.Column(i).IsComputed() {
+			colIDs[i] = 0
+		}
+	}
+	mb.disambiguateColumns()
+	mb.addSynthesizedComputedCols(colIDs, false /* restrict */)
+	mb.addAssignmentCasts(colIDs)
+}
+
+// buildAfterTriggers adds a cascade for each AFTER row trigger that fires for
+// the given event. The trigger functions are called by the cascading queries
+// once the mutation has been executed, with the old and new values of the
+// modified rows; see afterTriggerBuilder. For an UPSERT or INSERT .. ON
+// CONFLICT DO UPDATE, the canary column tells the inserted rows from the
+// updated ones.
+func (mb *mutationBuilder) buildAfterTriggers(event tree.TriggerEventType) {
+	triggers := mb.triggersForEvent(tree.TriggerActionTimeAfter, event)
+	if len(triggers) == 0 {
+		return
+	}
+	mb.ensureWithID()
+	ords := triggerRecordOrdinals(mb.tab)
+	newCols, oldCols := mb.triggerNewAndOldCols(event, ords)
+	hasCanary := mb.canaryColID != 0
+	if hasCanary {
+		// The canary column is passed to the cascade after the new values.
+		newCols = append(newCols, mb.canaryColID)
+	}
+	for _, i := range triggers {
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName:    mb.tab.Trigger(i).Name,
+			Builder:   newAfterTriggerBuilder(mb.tab, i, event, hasCanary),
+			WithID:    mb.withID,
+			OldValues: oldCols,
+			NewValues: newCols,
+			IsTrigger: true,
+		})
+	}
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder implementation for AFTER row
+// triggers. It builds a query that calls the trigger function for each row
+// modified by the original mutation:
+//
+//	project
+//	 ├── columns: tr:9
+//	 ├── project
+//	 │    ├── columns: new:7 old:8
+//	 │    ├── with-scan &1
+//	 │    │    ├── columns: a:5 b:6
+//	 │    │    └── mapping:
+//	 │    │         ├──  t.a:1 => a:5
+//	 │    │         └──  t.b:2 => b:6
+//	 │    └── projections
+//	 │         ├── (a:5, b:6) [as=new:7]
+//	 │         └── NULL [as=old:8]
+//	 └── projections
+//	      └── tr_func(new:7, old:8, ...) [as=tr:9]
+//
+// The rows returned by the query are discarded.
+type afterTriggerBuilder struct {
+	mutatedTable cat.Table
+	// triggerOrdinal is the ordinal of the trigger on the mutated table (can be
+	// passed to mutatedTable.Trigger).
+	triggerOrdinal int
+	event          tree.TriggerEventType
+	// hasCanary is true if the mutation is an UPSERT, in which case the last
+	// of the new values is its canary column. The trigger only fires for the
+	// rows inserted by the UPSERT if the event is INSERT, and only for the rows
+	// it updated if the event is UPDATE.
+	hasCanary bool
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+func newAfterTriggerBuilder(
+	mutatedTable cat.Table, triggerOrdinal int, event tree.TriggerEventType, hasCanary bool,
+) *afterTriggerBuilder {
+	return &afterTriggerBuilder{
+		mutatedTable:   mutatedTable,
+		triggerOrdinal: triggerOrdinal,
+		event:          event,
+		hasCanary:      hasCanary,
+	}
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		opt.MaybeInjectOptimizerTestingPanic(ctx, evalCtx)
+
+		// The trigger function is executed on behalf of the user that modified
+		// the table, so its queries are subject to row-level security.
+		b.skipRowLevelSecurity = false
+
+		md := b.factory.Metadata()
+		md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+
+		// Scan the old and new values of the modified rows, followed by the
+		// canary column, if any.
+		inScope := b.allocScope()
+		inCols := append(oldValues[:len(oldValues):len(oldValues)], newValues...)
+		if tb.hasCanary {
+			newValues = newValues[:len(newValues)-1]
+		}
+		outCols := make(opt.ColList, len(inCols))
+		for i, col := range inCols {
+			colMeta := md.ColumnMeta(col)
+			outCols[i] = b.synthesizeColumn(
+				inScope, scopeColName("").WithMetadataName(colMeta.Alias), colMeta.Type,
+				nil /* expr */, nil, /* scalar */
+			).id
+		}
+		inScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		var oldCols, newCols opt.ColList
+		if len(oldValues) > 0 {
+			oldCols = outCols[:len(oldValues)]
+		}
+		if len(newValues) > 0 {
+			newCols = outCols[len(oldValues) : len(oldValues)+len(newValues)]
+		}
+		var guard opt.ScalarExpr
+		if tb.hasCanary {
+			canary := b.factory.ConstructVariable(outCols[len(outCols)-1])
+			if tb.event == tree.TriggerEventInsert {
+				guard = b.factory.ConstructIs(canary, memo.NullSingleton)
+			} else {
+				guard = b.factory.ConstructIsNot(canary, memo.NullSingleton)
+			}
+		}
+		trigger := tb.mutatedTable.Trigger(tb.triggerOrdinal)
+		outScope, _ := b.buildRowTrigger(
+			inScope, tb.mutatedTable, &trigger, tb.event,
+			triggerRecordOrdinals(tb.mutatedTable), newCols, oldCols, guard,
+		)
+		return outScope.expr
+	})
+}
+
+// buildRowTrigger projects a column with the result of calling the function of
+// the given row trigger for each row of inScope. newCols and oldCols are the
+// columns of inScope that hold the fields of the NEW and OLD records, which
+// correspond to the table columns with the given ordinals. Either list is nil
+// if the corresponding record is NULL.
+//
+// If the trigger has a WHEN condition, the function is only called for rows
+// that satisfy it. Rows that do not are filtered out for an AFTER trigger, and
+// pass through a BEFORE trigger unchanged. The same applies to rows that do
+// not satisfy guard, if it is non-nil.
+func (b *Builder) buildRowTrigger(
+	inScope *scope,
+	tab cat.Table,
+	trigger *cat.Trigger,
+	event tree.TriggerEventType,
+	ords []int,
+	newCols, oldCols opt.ColList,
+	guard opt.ScalarExpr,
+) (outScope *scope, resultCol opt.ColumnID) {
+	f := b.factory
+	recordTyp := triggerRecordType(tab, ords)
+	makeRecord := func(cols opt.ColList) opt.ScalarExpr {
+		if cols == nil {
+			return f.ConstructNull(recordTyp)
+		}
+		elems := make(memo.ScalarListExpr, len(cols))
+		for i, col := range cols {
+			elems[i] = f.ConstructVariable(col)
+		}
+		return f.ConstructTuple(elems, recordTyp)
+	}
+
+	// Project the NEW and OLD records.
+	recordScope := inScope.replace()
+	recordScope.appendColumnsFromScope(inScope)
+	newCol := b.synthesizeColumn(
+		recordScope, scopeColName("").WithMetadataName("new"), recordTyp, nil /* expr */, makeRecord(newCols),
+	)
+	oldCol := b.synthesizeColumn(
+		recordScope, scopeColName("").WithMetadataName("old"), recordTyp, nil /* expr */, makeRecord(oldCols),
+	)
+	b.constructProjectForScope(inScope, recordScope)
+	newVar, oldVar := f.ConstructVariable(newCol.id), f.ConstructVariable(oldCol.id)
+
+	when := guard
+	if trigger.WhenExpr != "" {
+		whenExpr := b.buildTriggerWhen(trigger.WhenExpr, recordScope, newCol, oldCol)
+		if when != nil {
+			whenExpr = f.ConstructAnd(when, whenExpr)
+		}
+		when = whenExpr
+	}
+	if when != nil && trigger.ActionTime == tree.TriggerActionTimeAfter {
+		recordScope.expr = f.ConstructSelect(
+			recordScope.expr, memo.FiltersExpr{f.ConstructFiltersItem(when)},
+		)
+		when = nil
+	}
+
+	call := b.buildTriggerFunctionCall(tab, trigger, event, recordTyp, newVar, oldVar)
+	if when != nil {
+		// A BEFORE trigger leaves rows that do not satisfy the WHEN condition
+		// unchanged.
+		passthrough := newVar
+		if event == tree.TriggerEventDelete {
+			passthrough = oldVar
+		}
+		call = f.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{f.ConstructWhen(when, call)},
+			passthrough,
+		)
+	}
+
+	outScope = recordScope.replace()
+	outScope.appendColumnsFromScope(recordScope)
+	col := b.synthesizeColumn(
+		outScope, scopeColName("").WithMetadataName(trigger.Name), recordTyp, nil /* expr */, call,
+	)
+	b.constructProjectForScope(recordScope, outScope)
+	return outScope, col.id
+}
+
+// buildTriggerWhen builds the WHEN condition of a trigger. References to
+// new.<column> and old.<column> in the condition are resolved to the fields of
+// the given NEW and OLD record columns.
+func (b *Builder) buildTriggerWhen(
+	whenExpr string, inScope *scope, newCol, oldCol *scopeColumn,
+) opt.ScalarExpr {
+	expr, err := parser.ParseExpr(whenExpr)
+	if err != nil {
+		panic(err)
+	}
+	whenScope := inScope.replace()
+	whenScope.cols = []scopeColumn{
+		{name: scopeColName("new"), typ: newCol.typ, id: newCol.id},
+		{name: scopeColName("old"), typ: oldCol.typ, id: oldCol.id},
+	}
+	defer func(save bool) { b.insideTriggerFunc = save }(b.insideTriggerFunc)
+	b.insideTriggerFunc = true
+	texpr := whenScope.resolveAndRequireType(expr, types.Bool)
+	return b.buildScalar(texpr, whenScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// triggerFuncParams are the parameters of the routine that is built from the
+// body of a trigger function. The NEW and OLD records come first, followed by
+// the special variables that describe the trigger. Note that unlike in
+// Postgres, TG_ARGV is indexed starting from 1.
+var triggerFuncParams = []struct {
+	name string
+	typ  *types.T
+}{
+	{name: "new"},
+	{name: "old"},
+	{name: "tg_name", typ: types.Name},
+	{name: "tg_when", typ: types.String},
+	{name: "tg_level", typ: types.String},
+	{name: "tg_op", typ: types.String},
+	{name: "tg_relid", typ: types.Oid},
+	{name: "tg_relname", typ: types.Name},
+	{name: "tg_table_name", typ: types.Name},
+	{name: "tg_table_schema", typ: types.Name},
+	{name: "tg_nargs", typ: types.Int},
+	{name: "tg_argv", typ: types.StringArray},
+}
+
+// buildTriggerFunctionCall builds a call to the function of the given trigger,
+// passing the given NEW and OLD records. The call returns the record returned
+// by the trigger function.
+func (b *Builder) buildTriggerFunctionCall(
+	tab cat.Table,
+	trigger *cat.Trigger,
+	event tree.TriggerEventType,
+	recordTyp *types.T,
+	newRecord, oldRecord opt.ScalarExpr,
+) opt.ScalarExpr {
+	f := b.factory
+	funcName, o, err := b.catalog.ResolveFunctionByOID(
+		b.ctx, catid.FuncIDToOID(catid.DescID(trigger.FuncID)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if o.Language != tree.RoutineLangPLpgSQL {
+		panic(errors.AssertionFailedf("expected trigger function to be written in PL/pgSQL"))
+	}
+	f.Metadata().AddUserDefinedFunction(o, nil /* name */)
+	tn, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build the arguments of the call.
+	argv := tree.NewDArray(types.String)
+	for _, arg := range trigger.FuncArgs {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+	args := memo.ScalarListExpr{
+		newRecord,
+		oldRecord,
+		f.ConstructConstVal(tree.NewDName(trigger.Name), types.Name),
+		f.ConstructConstVal(tree.NewDString(trigger.ActionTime.String()), types.String),
+		f.ConstructConstVal(tree.NewDString("ROW"), types.String),
+		f.ConstructConstVal(tree.NewDString(event.String()), types.String),
+		f.ConstructConstVal(tree.NewDOid(oid.Oid(tab.ID())), types.Oid),
+		f.ConstructConstVal(tree.NewDName(string(tab.Name())), types.Name),
+		f.ConstructConstVal(tree.NewDName(string(tab.Name())), types.Name),
+		f.ConstructConstVal(tree.NewDName(tn.Schema()), types.Name),
+		f.ConstructConstVal(tree.NewDInt(tree.DInt(len(trigger.FuncArgs))), types.Int),
+		f.ConstructConstVal(argv, types.StringArray),
+	}
+
+	// Add the parameters to the scope of the body.
+	bodyScope := b.allocScope()
+	paramTypes := make(tree.ParamTypes, len(triggerFuncParams))
+	params := make(opt.ColList, len(triggerFuncParams))
+	for i := range triggerFuncParams {
+		typ := triggerFuncParams[i].typ
+		if typ == nil {
+			typ = recordTyp
+		}
+		paramTypes[i] = tree.ParamType{Name: triggerFuncParams[i].name, Typ: typ}
+		col := b.synthesizeColumn(
+			bodyScope, scopeColName(tree.Name(paramTypes[i].Name)), typ, nil /* expr */, nil, /* scalar */
+		)
+		col.setParamOrd(i)
+		params[i] = col.id
+	}
+
+	stmt, err := plpgsql.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	defer func(insideUDF, insideTriggerFunc bool) {
+		b.insideUDF, b.insideTriggerFunc = insideUDF, insideTriggerFunc
+	}(b.insideUDF, b.insideTriggerFunc)
+	b.insideUDF, b.insideTriggerFunc = true, true
+	// The body of a SECURITY DEFINER trigger function is built with the
+	// privileges of the function's owner.
+	if o.SecurityDefiner {
+		defer func(user username.SQLUsername) { b.checkPrivilegeUser = user }(b.checkPrivilegeUser)
+		b.checkPrivilegeUser = o.Owner
+	}
+	if o.SecurityDefiner || len(o.SessionConfig) > 0 {
+		fc := f.FoldingControl()
+		defer func(save norm.FoldingControl) { *fc = save }(*fc)
+		fc.DisallowStableFolds()
+	}
+
+	// The NEW and OLD records can be assigned to like variables. The function
+	// returns a record of the same type, or NULL.
+	var plBuilder plpgsqlBuilder
+	plBuilder.init(
+		b, nil /* colRefs */, paramTypes, stmt.AST, recordTyp,
+		false /* isProcedure */, false /* isSetReturning */, []int{0, 1}, /* outParams */
+	)
+	stmtScope := plBuilder.build(stmt.AST, bodyScope)
+	if len(stmtScope.cols) != 1 {
+		panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"trigger function %s must return a single record", funcName.Object()))
+	}
+
+	// Trigger functions are always treated as volatile, since they are
+	// invoked for each row for their side effects.
+	return f.ConstructUDFCall(
+		args,
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:              funcName.Object(),
+				Typ:               recordTyp,
+				Volatility:        volatility.Volatile,
+				CalledOnNullInput: true,
+				Body:              []memo.RelExpr{stmtScope.expr},
+				BodyProps:         []*physical.Required{stmtScope.makePhysicalProps()},
+				Params:            params,
+				SessionOverrides:  makeRoutineSessionOverrides(o),
+			},
+		},
+	)
+}
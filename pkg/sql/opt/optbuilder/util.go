@@ -667,9 +667,17 @@ func (b *Builder) resolveDataSourceRef(
 // error. It also adds the object and it's original unresolved name as a
 // dependency to the metadata, so that the privileges can be re-checked on reuse
 // of the memo.
+//
+// Within the body of a SECURITY DEFINER routine, the privileges of the owner
+// of the routine are checked instead of those of the current user.
 func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv privilege.Kind) {
 	if !(priv == privilege.SELECT && b.skipSelectPrivilegeChecks) {
-		err := b.catalog.CheckPrivilege(b.ctx, ds, priv)
+		var err error
+		if b.checkPrivilegeUser.Undefined() {
+			err = b.catalog.CheckPrivilege(b.ctx, ds, priv)
+		} else {
+			err = b.catalog.CheckPrivilegeForUser(b.ctx, ds, priv, b.checkPrivilegeUser)
+		}
 		if err != nil {
 			panic(err)
 		}
@@ -680,7 +688,11 @@ func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv pri
 
 	// Add dependency on this object to the metadata, so that the metadata can be
 	// cached and later checked for freshness.
-	b.factory.Metadata().AddDependency(name, ds, priv)
+	if b.checkPrivilegeUser.Undefined() {
+		b.factory.Metadata().AddDependency(name, ds, priv)
+	} else {
+		b.factory.Metadata().AddDependencyForUser(name, ds, priv, b.checkPrivilegeUser)
+	}
 }
 
 // resolveNumericColumnRefs converts a list of tree.ColumnIDs from a
@@ -318,6 +318,7 @@ func (b *Builder) buildAggregationAsWindow(
 	// instead of each group. To rectify this, we must 'squash' the values down by
 	// wrapping it with a GroupBy or ScalarGroupBy.
 	g.aggOutScope.expr = b.constructWindowGroup(aggregateExpr, groupingColSet, g.aggs, g.aggOutScope)
+	g.aggOutScope.expr = b.constructGroupingFns(g.aggOutScope.expr, g)
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -196,12 +196,14 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"UniqueID":             {fullName: "opt.UniqueID", passByVal: true},
 		"WithID":               {fullName: "opt.WithID", passByVal: true},
 		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true},
+		"UserDefinedAggDef":    {fullName: "memo.UserDefinedAggDefinition", isPointer: true, usePointerIntern: true},
 		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
 		"OrderingChoice":       {fullName: "props.OrderingChoice", passByVal: true},
 		"GroupingOrder":        {fullName: "memo.GroupingOrder", passByVal: true},
 		"TupleOrdinal":         {fullName: "memo.TupleOrdinal", passByVal: true},
 		"ScanLimit":            {fullName: "memo.ScanLimit", passByVal: true},
 		"ScanFlags":            {fullName: "memo.ScanFlags", passByVal: true},
+		"TableSample":          {fullName: "opt.TableSample", passByVal: true},
 		"JoinFlags":            {fullName: "memo.JoinFlags", passByVal: true},
 		"WindowFrame":          {fullName: "memo.WindowFrame", passByVal: true},
 		"FKCascades":           {fullName: "memo.FKCascades", passByVal: true},
@@ -209,6 +211,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"StatementReturnType":  {fullName: "tree.StatementReturnType", passByVal: true},
 		"StatementType":        {fullName: "tree.StatementType", passByVal: true},
 		"ShowTraceType":        {fullName: "tree.ShowTraceType", passByVal: true},
+		"TxnControlOp":         {fullName: "tree.TxnControlOp", passByVal: true},
 		"ShowCompletions":      {fullName: "tree.ShowCompletions", isPointer: true, usePointerIntern: true},
 		"bool":                 {fullName: "bool", passByVal: true},
 		"int":                  {fullName: "int", passByVal: true},
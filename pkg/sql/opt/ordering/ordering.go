@@ -164,6 +164,11 @@ func init() {
 		buildChildReqOrdering: setOpBuildChildReqOrdering,
 		buildProvidedOrdering: setOpBuildProvided,
 	}
+	funcMap[opt.BarrierOp] = funcs{
+		canProvideOrdering:    barrierCanProvideOrdering,
+		buildChildReqOrdering: barrierBuildChildReqOrdering,
+		buildProvidedOrdering: barrierBuildProvided,
+	}
 	funcMap[opt.IndexJoinOp] = funcs{
 		canProvideOrdering:    indexJoinCanProvideOrdering,
 		buildChildReqOrdering: lookupOrIndexJoinBuildChildReqOrdering,
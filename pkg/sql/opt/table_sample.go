@@ -0,0 +1,54 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// TableSample stores the TABLESAMPLE clause of a Scan, which causes the scan to
+// return only a random sample of the rows of the table. The zero value
+// indicates that the scan is not sampled.
+type TableSample struct {
+	// Method is the sampling method. BERNOULLI samples each row independently,
+	// SYSTEM samples blocks of rows and skips reading the blocks that are not
+	// selected.
+	Method tree.TableSampleMethod
+
+	// Fraction is the probability with which each row (or block of rows) is
+	// selected, between 0 and 1.
+	Fraction float64
+
+	// Repeatable is true if Seed should be used to seed the random number
+	// generator, so that the same rows are selected each time the scan is
+	// executed (as long as the table is not modified).
+	Repeatable bool
+	Seed       int64
+}
+
+// Empty returns true if the scan is not sampled.
+func (ts *TableSample) Empty() bool {
+	return ts.Method == 0
+}
+
+func (ts TableSample) String() string {
+	if ts.Empty() {
+		return ""
+	}
+	method := strings.ToLower(ts.Method.String())
+	if ts.Repeatable {
+		return fmt.Sprintf("%s(%g) repeatable(%d)", method, ts.Fraction*100, ts.Seed)
+	}
+	return fmt.Sprintf("%s(%g)", method, ts.Fraction*100)
+}
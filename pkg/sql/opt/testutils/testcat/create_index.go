@@ -12,6 +12,7 @@ package testcat
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
 )
@@ -53,7 +54,15 @@ func (tc *Catalog) CreateIndex(stmt *tree.CreateIndex, version descpb.IndexDescr
 
 	}
 	if tab != nil {
-		tab.addIndexWithVersion(indexTableDef, idxType, version)
+		idx := tab.addIndexWithVersion(indexTableDef, idxType, version)
+		if stmt.BRIN {
+			// BRIN indexes can't be scanned, so they are presented as write-only
+			// indexes.
+			idx.BRIN = true
+			if !cat.IsMutationIndex(tab, idx.Ordinal()) {
+				tab.writeOnlyIdxCount++
+			}
+		}
 	} else if view != nil {
 		view.addIndex(indexTableDef)
 	}
@@ -361,7 +361,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
@@ -369,6 +371,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
+		case *tree.ExclusionConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.FamilyTableDef:
 			tab.addFamily(def)
 
@@ -380,6 +385,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						tree.ConstraintDeferrability{},
 					)
 				} else {
 					tab.addIndex(
@@ -681,13 +687,19 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrable:               d.Deferrability.Deferrable,
+		initiallyDeferred:        d.Deferrability.InitiallyDeferred,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -708,6 +720,9 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+
+		deferrable:        deferrability.Deferrable,
+		initiallyDeferred: deferrability.InitiallyDeferred,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -848,7 +863,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintDeferrability{},
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
@@ -1145,6 +1162,39 @@ func (tt *Table) makeIndexName(defName tree.Name, cols tree.IndexElemList, typ i
 	return name
 }
 
+// addExclusionConstraint adds an exclusion constraint, which is represented
+// as a unique constraint without an index with an operator for each column.
+func (tt *Table) addExclusionConstraint(def *tree.ExclusionConstraintTableDef) {
+	name := string(def.Name)
+	if name == "" {
+		var buf bytes.Buffer
+		buf.WriteString("exclude")
+		for i := range def.Elems {
+			buf.WriteRune('_')
+			buf.WriteString(string(def.Elems[i].Column))
+		}
+		name = buf.String()
+	}
+	u := UniqueConstraint{
+		name:              name,
+		tabID:             tt.TabID,
+		columnOrdinals:    make([]int, len(def.Elems)),
+		exclusionOps:      make([]treecmp.ComparisonOperator, len(def.Elems)),
+		withoutIndex:      true,
+		validated:         true,
+		deferrable:        def.Deferrability.Deferrable,
+		initiallyDeferred: def.Deferrability.InitiallyDeferred,
+	}
+	for i := range def.Elems {
+		u.columnOrdinals[i] = tt.FindOrdinal(string(def.Elems[i].Column))
+		u.exclusionOps[i] = def.Elems[i].Operator
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) makeUniqueConstraintName(defName tree.Name, columns tree.IndexElemList) string {
 	name := string(defName)
 	if name == "" {
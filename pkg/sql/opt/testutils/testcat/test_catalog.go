@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -264,6 +265,13 @@ func (tc *Catalog) CheckPrivilege(ctx context.Context, o cat.Object, priv privil
 	return tc.CheckAnyPrivilege(ctx, o)
 }
 
+// CheckPrivilegeForUser is part of the cat.Catalog interface.
+func (tc *Catalog) CheckPrivilegeForUser(
+	ctx context.Context, o cat.Object, priv privilege.Kind, user username.SQLUsername,
+) error {
+	return tc.CheckAnyPrivilege(ctx, o)
+}
+
 // CheckAnyPrivilege is part of the cat.Catalog interface.
 func (tc *Catalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error {
 	switch t := o.(type) {
@@ -304,6 +312,27 @@ func (tc *Catalog) HasRoleOption(ctx context.Context, roleOption roleoption.Opti
 	return true, nil
 }
 
+// HasRoleOptionForUser is part of the cat.Catalog interface.
+func (tc *Catalog) HasRoleOptionForUser(
+	ctx context.Context, user username.SQLUsername, roleOption roleoption.Option,
+) (bool, error) {
+	return true, nil
+}
+
+// HasOwnershipForUser is part of the cat.Catalog interface.
+func (tc *Catalog) HasOwnershipForUser(
+	ctx context.Context, o cat.Object, user username.SQLUsername,
+) (bool, error) {
+	return true, nil
+}
+
+// IsMemberOfRoleForUser is part of the cat.Catalog interface.
+func (tc *Catalog) IsMemberOfRoleForUser(
+	ctx context.Context, user username.SQLUsername, role username.SQLUsername,
+) (bool, error) {
+	return true, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (tc *Catalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -844,6 +873,36 @@ func (tt *Table) Check(i int) cat.CheckConstraint {
 	return tt.Checks[i]
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (tt *Table) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (tt *Table) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (tt *Table) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (tt *Table) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
 // FamilyCount is part of the cat.Table interface.
 func (tt *Table) FamilyCount() int {
 	return len(tt.Families)
@@ -1021,6 +1080,9 @@ type Index struct {
 	// Inverted is true when this index is an inverted index.
 	Inverted bool
 
+	// BRIN is true when this index is a BRIN index.
+	BRIN bool
+
 	// Invisibility specifies the invisibility of an index and can be any float64
 	// between [0.0, 1.0]. An index with invisibility 0.0 means that the index is
 	// visible. An index with invisibility 1.0 means that the index is fully not
@@ -1092,6 +1154,11 @@ func (ti *Index) IsInverted() bool {
 	return ti.Inverted
 }
 
+// IsBRIN is part of the cat.Index interface.
+func (ti *Index) IsBRIN() bool {
+	return ti.BRIN
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (ti *Index) GetInvisibility() float64 {
 	return ti.Invisibility
@@ -1340,6 +1407,30 @@ func (ts *TableStat) HistogramType() *types.T {
 	return ts.histogramType
 }
 
+// Dependencies is part of the cat.TableStatistic interface.
+func (ts *TableStat) Dependencies() []cat.ColumnDependency {
+	if len(ts.js.Dependencies) == 0 {
+		return nil
+	}
+	deps := make([]cat.ColumnDependency, len(ts.js.Dependencies))
+	for i, d := range ts.js.Dependencies {
+		deps[i] = cat.ColumnDependency{
+			From: ts.statColumn(d.From), To: ts.statColumn(d.To), Degree: d.Degree,
+		}
+	}
+	return deps
+}
+
+// statColumn returns the index of the named column in the statistic's columns.
+func (ts *TableStat) statColumn(name string) int {
+	for i, c := range ts.js.Columns {
+		if c == name {
+			return i
+		}
+	}
+	panic(errors.AssertionFailedf("column %q is not part of the statistic", name))
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (ts *TableStat) IsPartial() bool {
 	return ts.js.IsPartial()
@@ -1391,6 +1482,9 @@ type ForeignKeyConstraint struct {
 	matchMethod  tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1458,6 +1552,16 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1467,6 +1571,11 @@ type UniqueConstraint struct {
 	predicate      string
 	withoutIndex   bool
 	validated      bool
+
+	deferrable        bool
+	initiallyDeferred bool
+
+	exclusionOps []treecmp.ComparisonOperator
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1512,12 +1621,32 @@ func (u *UniqueConstraint) Validated() bool {
 	return u.validated
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
 // interface.
 func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperator {
+	return u.exclusionOps[i]
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
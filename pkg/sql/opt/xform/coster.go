@@ -783,6 +783,12 @@ func (c *coster) computeScanCost(scan *memo.ScanExpr, required *physical.Require
 	}
 	baseCost := memo.Cost(numSpans * randIOCostFactor)
 
+	// If the scan is pruned using a BRIN index, add the cost of reading the
+	// summaries.
+	if scan.SummaryConstraint != nil {
+		baseCost += randIOCostFactor
+	}
+
 	// If this is a virtual scan, add the cost of fetching table descriptors.
 	if c.mem.Metadata().Table(scan.Table).IsVirtualTable() {
 		baseCost += virtualScanTableDescriptorFetchCost
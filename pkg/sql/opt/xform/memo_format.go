@@ -300,6 +300,9 @@ func (mf *memoFormatter) formatPrivate(e opt.Expr, physProps *physical.Required)
 		if t.InvertedConstraint != nil {
 			fmt.Fprintf(mf.buf, ",constrained inverted")
 		}
+		if t.SummaryConstraint != nil {
+			fmt.Fprintf(mf.buf, ",summary=%s", tab.Index(t.SummaryIndex).Name())
+		}
 		if t.HardLimit.IsSet() {
 			fmt.Fprintf(mf.buf, ",lim=%s", t.HardLimit)
 		}
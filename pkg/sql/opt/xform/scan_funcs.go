@@ -104,6 +104,109 @@ func (c *CustomFuncs) GenerateIndexScans(
 	})
 }
 
+// CanPruneScanWithSummary returns true if the given scan is a scan of the
+// primary index of a table with a BRIN index, and the scan can be pruned using
+// the summaries of the BRIN index. The scan may be constrained, but it must not
+// be limited or already pruned.
+func (c *CustomFuncs) CanPruneScanWithSummary(scanPrivate *memo.ScanPrivate) bool {
+	if scanPrivate.Index != cat.PrimaryIndex || scanPrivate.SummaryConstraint != nil ||
+		scanPrivate.HardLimit != 0 || scanPrivate.LocalityOptimized || !scanPrivate.Sample.Empty() {
+		return false
+	}
+	tab := c.e.mem.Metadata().Table(scanPrivate.Table)
+	// BRIN indexes are presented as write-only indexes.
+	for i, n := tab.IndexCount(), tab.WritableIndexCount(); i < n; i++ {
+		if tab.Index(i).IsBRIN() {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateSummaryPrunedScans generates a Select over a pruned scan of the
+// primary index for each BRIN index whose column is constrained by the filters.
+// At execution time, the pruned scan skips the blocks of rows whose summaries
+// in the BRIN index don't intersect the constraint. For example, given a BRIN
+// index on column b of a table with primary key a:
+//
+//	SELECT * FROM t WHERE b > 10
+//
+// generates:
+//
+//	select
+//	 ├── scan t
+//	 │    └── summary index: t_b_idx
+//	 │         └── constraint: /2: [/11 - ]
+//	 └── filters
+//	      └── b > 10
+//
+// The filters are always kept, since the pruned scan may still return rows that
+// don't satisfy them.
+func (c *CustomFuncs) GenerateSummaryPrunedScans(
+	grp memo.RelExpr,
+	required *physical.Required,
+	scanPrivate *memo.ScanPrivate,
+	filters memo.FiltersExpr,
+) {
+	tab := c.e.mem.Metadata().Table(scanPrivate.Table)
+	for ord, n := tab.IndexCount(), tab.WritableIndexCount(); ord < n; ord++ {
+		index := tab.Index(ord)
+		if !index.IsBRIN() {
+			continue
+		}
+		col := scanPrivate.Table.ColumnID(index.Column(0).Ordinal())
+		summaryConstraint := c.summaryConstraint(col, filters)
+		if summaryConstraint == nil {
+			continue
+		}
+
+		newScanPrivate := *scanPrivate
+		newScanPrivate.SummaryIndex = ord
+		newScanPrivate.SummaryConstraint = summaryConstraint
+
+		var sb indexScanBuilder
+		sb.Init(c, scanPrivate.Table)
+		sb.SetScan(&newScanPrivate)
+		sb.AddSelect(filters)
+		sb.Build(grp)
+	}
+}
+
+// summaryConstraint returns the constraint on the given column that is implied
+// by the filters, or nil if there is none. NULLs are never part of a summary,
+// so nil is also returned if the constraint allows NULL values.
+func (c *CustomFuncs) summaryConstraint(
+	col opt.ColumnID, filters memo.FiltersExpr,
+) *constraint.Constraint {
+	var result *constraint.Constraint
+	for i := range filters {
+		cs := filters[i].ScalarProps().Constraints
+		if cs == nil {
+			continue
+		}
+		for j, n := 0, cs.Length(); j < n; j++ {
+			cons := cs.Constraint(j)
+			if cons.Columns.Count() != 1 || cons.Columns.Get(0).ID() != col ||
+				cons.Columns.Get(0).Descending() {
+				continue
+			}
+			if result == nil {
+				result = &constraint.Constraint{}
+				*result = *cons
+			} else {
+				result.IntersectWith(c.e.evalCtx, cons)
+			}
+		}
+	}
+	if result == nil || result.IsUnconstrained() || result.IsContradiction() {
+		return nil
+	}
+	if start := result.Spans.Get(0).StartKey(); start.IsEmpty() || start.IsNull() {
+		return nil
+	}
+	return result
+}
+
 // CanMaybeGenerateLocalityOptimizedScan returns true if it may be possible to
 // generate a locality optimized scan from the given scan private.
 // CanMaybeGenerateLocalityOptimizedScan performs simple checks that are
@@ -121,6 +224,11 @@ func (c *CustomFuncs) CanMaybeGenerateLocalityOptimizedScan(scanPrivate *memo.Sc
 		return false
 	}
 
+	if scanPrivate.SummaryConstraint != nil {
+		// Scans pruned using a BRIN index are not locality optimized.
+		return false
+	}
+
 	if scanPrivate.Constraint == nil {
 		// Since we have no constraint, we must have a limit to use this
 		// optimization. We also require the limit to be less than the kv batch
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
@@ -26,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
@@ -38,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -413,6 +416,20 @@ func (oc *optCatalog) CheckPrivilege(ctx context.Context, o cat.Object, priv pri
 	return oc.planner.CheckPrivilege(ctx, desc, priv)
 }
 
+// CheckPrivilegeForUser is part of the cat.Catalog interface.
+func (oc *optCatalog) CheckPrivilegeForUser(
+	ctx context.Context, o cat.Object, priv privilege.Kind, user username.SQLUsername,
+) error {
+	if o.ID() == 0 {
+		return oc.planner.CheckPrivilegeForUser(ctx, syntheticprivilege.GlobalPrivilegeObject, priv, user)
+	}
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return err
+	}
+	return oc.planner.CheckPrivilegeForUser(ctx, desc, priv, user)
+}
+
 // CheckAnyPrivilege is part of the cat.Catalog interface.
 func (oc *optCatalog) CheckAnyPrivilege(ctx context.Context, o cat.Object) error {
 	desc, err := getDescFromCatalogObjectForPermissions(o)
@@ -439,6 +456,39 @@ func (oc *optCatalog) HasRoleOption(
 	return oc.planner.HasRoleOption(ctx, roleOption)
 }
 
+// HasRoleOptionForUser is part of the cat.Catalog interface.
+func (oc *optCatalog) HasRoleOptionForUser(
+	ctx context.Context, user username.SQLUsername, roleOption roleoption.Option,
+) (bool, error) {
+	return oc.planner.UserHasRoleOption(ctx, user, roleOption)
+}
+
+// HasOwnershipForUser is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnershipForUser(
+	ctx context.Context, o cat.Object, user username.SQLUsername,
+) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.UserHasOwnership(ctx, desc, user)
+}
+
+// IsMemberOfRoleForUser is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRoleForUser(
+	ctx context.Context, user username.SQLUsername, role username.SQLUsername,
+) (bool, error) {
+	if user == role || role.IsPublicRole() {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (oc *optCatalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -730,6 +780,10 @@ type optTable struct {
 	// indexes.
 	indexes []optIndex
 
+	// indexCount is the number of public indexes, excluding public BRIN
+	// indexes. See IndexCount.
+	indexCount int
+
 	// codec is capable of encoding sql table keys.
 	codec keys.SQLCodec
 
@@ -763,6 +817,13 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// policies are the row-level security policies of the table.
+	policies []cat.Policy
+
+	// triggers are the triggers of the table. They are ordered by name, like
+	// the triggers in the descriptor.
+	triggers []cat.Trigger
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -789,8 +850,32 @@ func newOptTable(
 	// Determine how many columns we will potentially need.
 	cols := ot.desc.DeletableColumns()
 	numCols := len(ot.desc.AllColumns())
-	// Add one for each inverted index column.
+	// BRIN indexes can't be scanned, so public BRIN indexes are presented as
+	// write-only indexes by ordering them after the other public indexes.
 	secondaryIndexes := ot.desc.DeletableNonPrimaryIndexes()
+	ot.indexCount = len(ot.desc.ActiveIndexes())
+	publicIndexes := ot.desc.PublicNonPrimaryIndexes()
+	for _, index := range publicIndexes {
+		if index.GetType() == descpb.IndexDescriptor_BRIN {
+			ot.indexCount--
+		}
+	}
+	if ot.indexCount < len(ot.desc.ActiveIndexes()) {
+		reordered := make([]catalog.Index, 0, len(secondaryIndexes))
+		for _, index := range publicIndexes {
+			if index.GetType() != descpb.IndexDescriptor_BRIN {
+				reordered = append(reordered, index)
+			}
+		}
+		for _, index := range publicIndexes {
+			if index.GetType() == descpb.IndexDescriptor_BRIN {
+				reordered = append(reordered, index)
+			}
+		}
+		secondaryIndexes = append(reordered, secondaryIndexes[len(publicIndexes):]...)
+	}
+
+	// Add one for each inverted index column.
 	for _, index := range secondaryIndexes {
 		if index.GetType() == descpb.IndexDescriptor_INVERTED {
 			numCols++
@@ -821,15 +906,24 @@ func newOptTable(
 		// written to the primary index and all secondary indexes.
 		if !col.IsVirtual() || pkCols.Contains(col.GetID()) {
 			cd := col.ColumnDesc()
+			defaultExpr, nullable := cd.DefaultExpr, col.IsNullable()
+			if dd := col.GetType().TypeMeta.DomainData; dd != nil {
+				// Columns of a domain type inherit the default and the NOT NULL
+				// constraint of the domain.
+				if defaultExpr == nil {
+					defaultExpr = dd.DefaultExpr
+				}
+				nullable = nullable && !dd.NotNull
+			}
 			ot.columns[col.Ordinal()].Init(
 				col.Ordinal(),
 				cat.StableID(col.GetID()),
 				col.ColName(),
 				kind,
 				col.GetType(),
-				col.IsNullable(),
+				nullable,
 				visibility,
-				cd.DefaultExpr,
+				defaultExpr,
 				cd.ComputeExpr,
 				cd.OnUpdateExpr,
 				mapGeneratedAsIdentityType(col.GetGeneratedAsIdentityType()),
@@ -902,6 +996,23 @@ func newOptTable(
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
+
+			deferrable:        u.UniqueWithoutIndexDesc().Deferrable,
+			initiallyDeferred: u.UniqueWithoutIndexDesc().InitiallyDeferred,
+		}
+		if u.IsExclusion() {
+			// The operators of an exclusion constraint are matched with the
+			// columns in the order they were declared.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = u.UniqueWithoutIndexDesc().ColumnIDs
+			uc.exclusionOps = make([]treecmp.ComparisonOperator, len(u.ExclusionOperators()))
+			for j, name := range u.ExclusionOperators() {
+				op, err := schemaexpr.ExclusionOperator(name)
+				if err != nil {
+					return nil, err
+				}
+				uc.exclusionOps[j] = op
+			}
 		}
 	}
 
@@ -1006,6 +1117,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1019,6 +1132,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 
@@ -1053,17 +1168,41 @@ func newOptTable(
 			}
 		}
 	}
-	// Move all existing and synthesized checks into the opt table.
+	// Move all existing and synthesized checks into the opt table. The
+	// placeholder for the row-level security policies immediately follows the
+	// existing checks, so that its ordinal is known during execution (see
+	// checkMutationInput).
 	activeChecks := desc.EnforcedCheckConstraints()
-	ot.checkConstraints = make([]cat.CheckConstraint, 0, len(activeChecks)+len(synthesizedChecks))
+	ot.checkConstraints = make([]cat.CheckConstraint, 0, len(activeChecks)+len(synthesizedChecks)+1)
 	for i := range activeChecks {
 		ot.checkConstraints = append(ot.checkConstraints, cat.CheckConstraint{
 			Constraint: activeChecks[i].GetExpr(),
 			Validated:  activeChecks[i].GetConstraintValidity() == descpb.ConstraintValidity_Validated,
 		})
 	}
+	if desc.IsRowLevelSecurityEnabled() {
+		ot.checkConstraints = append(ot.checkConstraints, cat.CheckConstraint{RowLevelSecurity: true})
+	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	if policies := desc.GetPolicies(); len(policies) > 0 {
+		ot.policies = make([]cat.Policy, len(policies))
+		for i := range policies {
+			ot.policies[i] = makeOptPolicy(&policies[i])
+		}
+	}
+
+	if triggers := desc.GetTriggers(); len(triggers) > 0 {
+		ot.triggers = make([]cat.Trigger, len(triggers))
+		for i := range triggers {
+			ot.triggers[i] = makeOptTrigger(&triggers[i], &ot.colMap)
+		}
+		// Triggers fire in alphabetical order of their names.
+		sort.Slice(ot.triggers, func(i, j int) bool {
+			return ot.triggers[i].Name < ot.triggers[j].Name
+		})
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1206,7 +1345,7 @@ func (ot *optTable) getCol(i int) catalog.Column {
 // IndexCount is part of the cat.Table interface.
 func (ot *optTable) IndexCount() int {
 	// Primary index is always present, so count is always >= 1.
-	return len(ot.desc.ActiveIndexes())
+	return ot.indexCount
 }
 
 // WritableIndexCount is part of the cat.Table interface.
@@ -1246,6 +1385,36 @@ func (ot *optTable) Check(i int) cat.CheckConstraint {
 	return ot.checkConstraints[i]
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.IsRowLevelSecurityForced()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	return ot.policies[i]
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return ot.triggers[i]
+}
+
 // FamilyCount is part of the cat.Table interface.
 func (ot *optTable) FamilyCount() int {
 	return 1 + len(ot.families)
@@ -1585,6 +1754,11 @@ func (oi *optIndex) IsInverted() bool {
 	return oi.idx.GetType() == descpb.IndexDescriptor_INVERTED
 }
 
+// IsBRIN is part of the cat.Index interface.
+func (oi *optIndex) IsBRIN() bool {
+	return oi.idx.GetType() == descpb.IndexDescriptor_BRIN
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (oi *optIndex) GetInvisibility() float64 {
 	return oi.idx.GetInvisibility()
@@ -1725,9 +1899,71 @@ func (op *optPartition) PartitionByListPrefixes() []tree.Datums {
 	return op.datums
 }
 
+// makeOptTrigger returns the cat.Trigger corresponding to the given trigger
+// descriptor. colMap maps the column IDs of the table to column ordinals.
+func makeOptTrigger(t *descpb.TriggerDescriptor, colMap *catalog.TableColMap) cat.Trigger {
+	trigger := cat.Trigger{
+		Name:                 t.Name,
+		ActionTime:           tree.TriggerActionTimeBefore,
+		Events:               make([]tree.TriggerEventType, len(t.Events)),
+		UpdateColumnOrdinals: make([]int, 0, len(t.UpdateColumnIDs)),
+		WhenExpr:             t.WhenExpr,
+		FuncID:               cat.StableID(t.FuncID),
+		FuncArgs:             t.FuncArgs,
+	}
+	if t.ActionTime == semenumpb.TriggerActionTime_AFTER {
+		trigger.ActionTime = tree.TriggerActionTimeAfter
+	}
+	for i, e := range t.Events {
+		switch e {
+		case semenumpb.TriggerEventType_INSERT:
+			trigger.Events[i] = tree.TriggerEventInsert
+		case semenumpb.TriggerEventType_UPDATE:
+			trigger.Events[i] = tree.TriggerEventUpdate
+		default:
+			trigger.Events[i] = tree.TriggerEventDelete
+		}
+	}
+	for _, colID := range t.UpdateColumnIDs {
+		if ord, ok := colMap.Get(colID); ok {
+			trigger.UpdateColumnOrdinals = append(trigger.UpdateColumnOrdinals, ord)
+		}
+	}
+	return trigger
+}
+
+// makeOptPolicy returns the cat.Policy corresponding to the given policy
+// descriptor.
+func makeOptPolicy(p *descpb.PolicyDescriptor) cat.Policy {
+	policy := cat.Policy{
+		Name:          p.Name,
+		Restrictive:   p.Type == descpb.PolicyDescriptor_RESTRICTIVE,
+		Roles:         make([]username.SQLUsername, len(p.RoleNames)),
+		UsingExpr:     p.UsingExpr,
+		WithCheckExpr: p.WithCheckExpr,
+	}
+	switch p.Command {
+	case descpb.PolicyDescriptor_SELECT:
+		policy.Command = tree.PolicyCommandSelect
+	case descpb.PolicyDescriptor_INSERT:
+		policy.Command = tree.PolicyCommandInsert
+	case descpb.PolicyDescriptor_UPDATE:
+		policy.Command = tree.PolicyCommandUpdate
+	case descpb.PolicyDescriptor_DELETE:
+		policy.Command = tree.PolicyCommandDelete
+	default:
+		policy.Command = tree.PolicyCommandAll
+	}
+	for i, role := range p.RoleNames {
+		policy.Roles[i] = username.MakeSQLUsernameFromPreNormalizedString(role)
+	}
+	return policy
+}
+
 type optTableStat struct {
 	stat           *stats.TableStatistic
 	columnOrdinals []int
+	dependencies   []cat.ColumnDependency
 }
 
 var _ cat.TableStatistic = &optTableStat{}
@@ -1745,6 +1981,15 @@ func (os *optTableStat) init(tab *optTable, stat *stats.TableStatistic) (ok bool
 		}
 	}
 
+	if stat.HistogramData != nil && len(stat.HistogramData.Dependencies) > 0 {
+		os.dependencies = make([]cat.ColumnDependency, len(stat.HistogramData.Dependencies))
+		for i, d := range stat.HistogramData.Dependencies {
+			os.dependencies[i] = cat.ColumnDependency{
+				From: int(d.From), To: int(d.To), Degree: d.Degree,
+			}
+		}
+	}
+
 	return true, nil
 }
 
@@ -1807,6 +2052,11 @@ func (os *optTableStat) HistogramType() *types.T {
 	return os.stat.HistogramData.ColumnType
 }
 
+// Dependencies is part of the cat.TableStatistic interface.
+func (os *optTableStat) Dependencies() []cat.ColumnDependency {
+	return os.dependencies
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (os *optTableStat) IsPartial() bool {
 	return os.stat.IsPartial()
@@ -1881,7 +2131,14 @@ type optUniqueConstraint struct {
 	withoutIndex bool
 	validity     descpb.ConstraintValidity
 
+	deferrable        bool
+	initiallyDeferred bool
+
 	uniquenessGuaranteedByAnotherIndex bool
+
+	// exclusionOps is non-nil for exclusion constraints, and contains the
+	// operator of each column.
+	exclusionOps []treecmp.ComparisonOperator
 }
 
 var _ cat.UniqueConstraint = &optUniqueConstraint{}
@@ -1929,6 +2186,16 @@ func (u *optUniqueConstraint) Validated() bool {
 	return u.validity == descpb.ConstraintValidity_Validated
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
 // interface. It is a hack to make unique hash sharded index work before issue
 // #75070 is resolved. Be sure to remove `ignoreUniquenessCheck` field from
@@ -1937,6 +2204,16 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperator {
+	return u.exclusionOps[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1954,6 +2231,9 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2025,6 +2305,16 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2269,6 +2559,36 @@ func (ot *optVirtualTable) Statistic(i int) cat.TableStatistic {
 	panic(errors.AssertionFailedf("no stats"))
 }
 
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
 // CheckCount is part of the cat.Table interface.
 func (ot *optVirtualTable) CheckCount() int {
 	return len(ot.desc.EnforcedCheckConstraints())
@@ -2425,6 +2745,11 @@ func (oi *optVirtualIndex) IsInverted() bool {
 	return false
 }
 
+// IsBRIN is part of the cat.Index interface.
+func (oi *optVirtualIndex) IsBRIN() bool {
+	return false
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (oi *optVirtualIndex) GetInvisibility() float64 {
 	return 0.0
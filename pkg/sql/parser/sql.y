@@ -306,6 +306,12 @@ func (u *sqlSymUnion) enumValueList() tree.EnumValueList {
 func (u *sqlSymUnion) compositeTypeList() []tree.CompositeTypeElem {
     return u.val.([]tree.CompositeTypeElem)
 }
+func (u *sqlSymUnion) domainConstraint() tree.DomainConstraint {
+    return u.val.(tree.DomainConstraint)
+}
+func (u *sqlSymUnion) domainConstraints() []tree.DomainConstraint {
+    return u.val.([]tree.DomainConstraint)
+}
 func (u *sqlSymUnion) unresolvedName() *tree.UnresolvedName {
     return u.val.(*tree.UnresolvedName)
 }
@@ -324,6 +330,9 @@ func (u *sqlSymUnion) tableNames() tree.TableNames {
 func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
     return u.val.(*tree.IndexFlags)
 }
+func (u *sqlSymUnion) tableSample() *tree.TableSample {
+    return u.val.(*tree.TableSample)
+}
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
@@ -555,6 +564,12 @@ func (u *sqlSymUnion) privilegeList() privilege.List {
 func (u *sqlSymUnion) onConflict() *tree.OnConflict {
     return u.val.(*tree.OnConflict)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) orderBy() tree.OrderBy {
     return u.val.(tree.OrderBy)
 }
@@ -639,9 +654,18 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionElem {
+    return u.val.(tree.ExclusionElem)
+}
+func (u *sqlSymUnion) exclusionElems() tree.ExclusionElemList {
+    return u.val.(tree.ExclusionElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
+func (u *sqlSymUnion) indexAccessMethod() tree.IndexAccessMethod {
+    return u.val.(tree.IndexAccessMethod)
+}
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
@@ -738,6 +762,9 @@ func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
 func (u *sqlSymUnion) referenceActions() tree.ReferenceActions {
     return u.val.(tree.ReferenceActions)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) createStatsOptions() *tree.CreateStatsOptions {
     return u.val.(*tree.CreateStatsOptions)
 }
@@ -841,6 +868,12 @@ func (u *sqlSymUnion) routineOptions() tree.RoutineOptions {
 func (u *sqlSymUnion) functionOption() tree.RoutineOption {
     return u.val.(tree.RoutineOption)
 }
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
 func (u *sqlSymUnion) routineParams() tree.RoutineParams {
     return u.val.(tree.RoutineParams)
 }
@@ -886,6 +919,30 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
     return u.val.(*tree.BeginTransaction)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() *tree.TriggerEvent {
+    return u.val.(*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
+func (u *sqlSymUnion) triggerTransition() *tree.TriggerTransition {
+    return u.val.(*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerTransitions() tree.TriggerTransitions {
+    return u.val.(tree.TriggerTransitions)
+}
+func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
+    return u.val.(tree.TriggerForEach)
+}
+func (u *sqlSymUnion) policyType() tree.PolicyType {
+    return u.val.(tree.PolicyType)
+}
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -906,17 +963,17 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT AT_QUESTION
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
-%token <str> BOOLEAN BOTH BOX2D BUNDLE BY
+%token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
 %token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
-%token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
+%token <str> COMBINEFUNC COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
 %token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
@@ -925,21 +982,21 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISABLE DISCARD DISTINCT DO DOMAIN DOUBLE DROP EACH
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTERNAL EXTRACT EXTRACT_DURATION EXTREMES
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX
 %token <str> FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
 %token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
 
-%token <str> GENERATED GEOGRAPHY GEOMETRY GEOMETRYM GEOMETRYZ GEOMETRYZM
+%token <str> GENERATED GEOGRAPHY GEOMETRIC_DISTANCE GEOMETRIC_INTERSECTS GEOMETRY GEOMETRYM GEOMETRYZ GEOMETRYZM
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
@@ -949,9 +1006,9 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITCOND INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -961,46 +1018,46 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOBYPASSRLS NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PERMISSIVE PHYSICAL PLACEMENT PLACING
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
+%token <str> RANGE RANGES RANGE_ADJACENT READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
 %token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
-%token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
+%token <str> SFUNC SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STORAGE STORE STORED STORING STYPE SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
+%token <str> TABLE TABLES TABLESAMPLE TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TRANSFORM TREAT TRIGGER TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
@@ -1066,9 +1123,10 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
+%type <tree.Statement> alter_aggregate_stmt
 
 // ALTER RANGE
 %type <tree.Statement> alter_zone_range_stmt
@@ -1196,7 +1254,10 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_policy_stmt
+%type <tree.Statement> create_trigger_stmt
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
@@ -1206,6 +1267,7 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1218,9 +1280,14 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_policy_stmt
+%type <tree.Statement> drop_trigger_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1236,6 +1303,7 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.Statement> deallocate_stmt
 %type <tree.Statement> grant_stmt
 %type <tree.Statement> insert_stmt
+%type <tree.Statement> merge_stmt
 %type <tree.Statement> import_stmt
 %type <tree.Statement> pause_stmt pause_jobs_stmt pause_schedules_stmt pause_all_jobs_stmt
 %type <*tree.Select>   for_schedules_clause
@@ -1260,6 +1328,8 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
+%type <bool> constraints_set_mode
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1321,6 +1391,8 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
@@ -1379,6 +1451,7 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.AlterBackupCmd> alter_backup_cmds
 
 %type <tree.AlterTableCmd> alter_table_cmd
+%type <tree.AlterTableCmd> alter_constraint_option_list alter_constraint_option
 %type <tree.AlterTableCmds> alter_table_cmds
 %type <tree.AlterIndexCmd> alter_index_cmd
 %type <tree.AlterIndexCmds> alter_index_cmds
@@ -1448,7 +1521,7 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <empty> opt_privileges_clause
 %type <bool> distinct_clause opt_with_data
 %type <tree.DistinctOn> distinct_on_clause
-%type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
+%type <tree.NameList> opt_column_list insert_column_list opt_stats_kinds opt_stats_columns query_stats_cols
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params create_as_params
@@ -1501,7 +1574,7 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <*tree.TenantSpec> virtual_cluster_spec virtual_cluster_spec_opt_all
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
-%type <bool> opt_index_access_method
+%type <tree.IndexAccessMethod> opt_index_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1512,6 +1585,8 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.ColumnDefList> opt_col_def_list col_def_list opt_col_def_list_no_types col_def_list_no_types
 %type <tree.ColumnDef> col_def
 %type <*tree.OnConflict> on_conflict
+%type <tree.MergeWhens> merge_when_list
+%type <*tree.MergeWhen> merge_when_clause merge_insert
 
 %type <tree.Statement> begin_transaction
 %type <tree.TransactionModes> transaction_mode_list transaction_mode
@@ -1525,6 +1600,8 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
+%type <*tree.TableSample> opt_tablesample_clause
+%type <tree.Expr> opt_repeatable_clause
 %type <*tree.IndexFlags> index_flags_param
 %type <*tree.IndexFlags> index_flags_param_list
 %type <tree.Expr> a_expr b_expr c_expr d_expr typed_literal
@@ -1561,6 +1638,9 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <str> explain_option_name
 %type <[]string> explain_option_list opt_enum_val_list enum_val_list
 %type <[]tree.CompositeTypeElem> composite_type_list opt_composite_type_list
+%type <tree.DomainConstraint> domain_constraint domain_constraint_elem
+%type <[]tree.DomainConstraint> opt_domain_constraint_list
+%type <tree.Expr> opt_domain_default
 
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
 %type <*types.T> const_typename
@@ -1610,6 +1690,10 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
 %type <tree.ReferenceActions> reference_actions
+%type <tree.ConstraintDeferrability> opt_deferrable
+%type <tree.ExclusionElem> exclusion_elem
+%type <tree.ExclusionElemList> exclusion_elem_list
+%type <str> opt_exclusion_access_method
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
 %type <tree.Expr> func_application func_expr_common_subexpr special_function
@@ -1678,6 +1762,8 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
 %type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
+%type <tree.AggregateOptions> aggregate_opt_list
+%type <tree.AggregateOption> aggregate_opt_item
 %type <tree.RoutineParamClass> routine_param_class
 %type <*tree.UnresolvedObjectName> routine_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
@@ -1687,6 +1773,23 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 %type <tree.FuncObjs> function_with_paramtypes_list
 %type <empty> opt_link_sym
 
+// Trigger relevant components.
+%type <tree.TriggerActionTime> trigger_action_time
+%type <*tree.TriggerEvent> trigger_event
+%type <tree.TriggerEvents> trigger_event_list
+%type <*tree.TriggerTransition> trigger_transition
+%type <tree.TriggerTransitions> opt_trigger_transition_list trigger_transition_list
+%type <bool> trigger_transition_type
+%type <tree.TriggerForEach> trigger_for_each trigger_for_type
+%type <tree.PolicyType> opt_policy_type
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.Expr> opt_policy_using opt_policy_with_check
+%type <tree.Expr> trigger_when
+%type <[]string> trigger_func_args
+%type <str> trigger_func_arg
+%type <empty> trigger_for_opt_each function_or_procedure opt_as
+
 %type <*tree.LabelSpec> label_spec
 
 %type <*tree.ShowRangesOptions> opt_show_ranges_options show_ranges_options
@@ -1731,11 +1834,11 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT  // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT AT_QUESTION  // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT RANGE_ADJACENT GEOMETRIC_DISTANCE GEOMETRIC_INTERSECTS
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1779,7 +1882,7 @@ stmt:
 stmt_without_legacy_transaction:
   preparable_stmt            // help texts in sub-rule
 | analyze_stmt               // EXTEND WITH HELP: ANALYZE
-| call_stmt
+| call_stmt                  // EXTEND WITH HELP: CALL
 | copy_stmt
 | comment_stmt
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
@@ -1800,6 +1903,8 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
@@ -1810,7 +1915,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1823,10 +1927,12 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -1848,6 +1954,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... RENAME TO <newname>
 //   ALTER TABLE ... RENAME [COLUMN] <colname> TO <newname>
 //   ALTER TABLE ... VALIDATE CONSTRAINT <constraintname>
+//   ALTER TABLE ... ALTER CONSTRAINT <constraintname> [ON DELETE <action>] [ON UPDATE <action>] [NOT VALID | VALIDATE]
 //   ALTER TABLE ... SET (storage_param = value, ...)
 //   ALTER TABLE ... SPLIT AT <selectclause> [WITH EXPIRATION <expr>]
 //   ALTER TABLE ... UNSPLIT AT <selectclause>
@@ -1861,6 +1968,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -2012,6 +2120,14 @@ alter_database_stmt:
 //    CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT
 //    IMMUTABLE | STABLE | VOLATILE
 //    [ NOT ] LEAKPROOF
+//    [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    PARALLEL { UNSAFE | RESTRICTED | SAFE }
+//    COST execution_cost
+//    ROWS result_rows
+//    SUPPORT support_function
+//    SET configuration_parameter { TO | = } value
+//    RESET configuration_parameter
+//    RESET ALL
 // %SeeAlso: WEBDOCS/alter-function.html
 alter_func_stmt:
   alter_func_options_stmt
@@ -2787,7 +2903,12 @@ alter_table_cmd:
     }
   }
   // ALTER TABLE <name> ALTER CONSTRAINT ...
-| ALTER CONSTRAINT constraint_name error { return unimplementedWithIssueDetail(sqllex, 31632, "alter constraint") }
+| ALTER CONSTRAINT constraint_name alter_constraint_option_list
+  {
+    cmd := $4.alterTableCmd().(*tree.AlterTableAlterConstraint)
+    cmd.Constraint = tree.Name($3)
+    $$.val = cmd
+  }
   // ALTER TABLE <name> INHERITS ....
 | INHERITS error
   {
@@ -2839,6 +2960,26 @@ alter_table_cmd:
   {
     $$.val = &tree.AlterTableSetAudit{Mode: $3.auditMode()}
   }
+  // ALTER TABLE <name> ENABLE ROW LEVEL SECURITY
+| ENABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityEnable}
+  }
+  // ALTER TABLE <name> DISABLE ROW LEVEL SECURITY
+| DISABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityDisable}
+  }
+  // ALTER TABLE <name> FORCE ROW LEVEL SECURITY
+| FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityForce}
+  }
+  // ALTER TABLE <name> NO FORCE ROW LEVEL SECURITY
+| NO FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityNoForce}
+  }
   // ALTER TABLE <name> PARTITION BY ...
 | partition_by_table
   {
@@ -2944,6 +3085,41 @@ opt_drop_behavior:
     $$.val = tree.DropDefault
   }
 
+alter_constraint_option_list:
+  alter_constraint_option
+| alter_constraint_option_list alter_constraint_option
+  {
+    cmd := $1.alterTableCmd().(*tree.AlterTableAlterConstraint)
+    if err := cmd.CombineWith($2.alterTableCmd().(*tree.AlterTableAlterConstraint)); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = cmd
+  }
+
+alter_constraint_option:
+  reference_on_delete
+  {
+    $$.val = &tree.AlterTableAlterConstraint{
+      Actions: tree.ReferenceActions{Delete: $1.referenceAction()},
+      SetDeleteAction: true,
+    }
+  }
+| reference_on_update
+  {
+    $$.val = &tree.AlterTableAlterConstraint{
+      Actions: tree.ReferenceActions{Update: $1.referenceAction()},
+      SetUpdateAction: true,
+    }
+  }
+| NOT VALID
+  {
+    $$.val = &tree.AlterTableAlterConstraint{Validation: tree.ValidationSkip, SetValidation: true}
+  }
+| VALIDATE
+  {
+    $$.val = &tree.AlterTableAlterConstraint{Validation: tree.ValidationDefault, SetValidation: true}
+  }
+
 opt_validate_behavior:
   NOT VALID
   {
@@ -3052,6 +3228,117 @@ alter_type_stmt:
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
+// %Help: ALTER DOMAIN - change the definition of a domain.
+// %Category: DDL
+// %Text: ALTER DOMAIN <typename> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name> [ CASCADE | RESTRICT ]
+//   ALTER DOMAIN ... RENAME CONSTRAINT <oldname> TO <newname>
+//   ALTER DOMAIN ... RENAME TO <newname>
+//   ALTER DOMAIN ... SET SCHEMA <newschemaname>
+//   ALTER DOMAIN ... OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+//
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+    }
+  }
+| ALTER DOMAIN type_name ADD domain_constraint
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{Constraint: $5.domainConstraint()},
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME CONSTRAINT constraint_name TO constraint_name
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainRenameConstraint{
+        Constraint: tree.Name($6),
+        NewName: tree.Name($8),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeRename{
+        NewName: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeSetSchema{
+        Schema: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterDomain{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeOwner{
+        Owner: $6.roleSpec(),
+      },
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 opt_add_val_placement:
   BEFORE SCONST
   {
@@ -3851,16 +4138,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -4009,9 +4286,21 @@ opt_with_options:
     $$.val = nil
   }
 
-// CALL invokes a stored procedure. It is not currently supported in CRDB.
+// %Help: CALL - invoke a procedure
+// %Category: Misc
+// %Text: CALL <name> ( [ <expr> [, ...] ] )
+// %SeeAlso: CREATE PROCEDURE
 call_stmt:
-  CALL error { return unimplementedWithIssueDetail(sqllex, 17511, "call procedure") }
+  CALL func_application
+  {
+    p := $2.expr().(*tree.FuncExpr)
+    if p.Type != 0 || p.OrderBy != nil {
+      return setErr(sqllex, errors.New("CALL must reference a procedure with only arguments"))
+    }
+    p.InCall = true
+    $$.val = &tree.Call{Proc: p}
+  }
+| CALL error // SHOW HELP: CALL
 
 // The COPY grammar in postgres has 3 different versions, all of which are supported by postgres:
 // 1) The "really old" syntax from v7.2 and prior
@@ -4566,6 +4855,62 @@ create_extension_stmt:
   }
 | CREATE EXTENSION error // SHOW HELP: CREATE EXTENSION
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ argname ] argtype [, ...] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+//  )
+// %SeeAlso: WEBDOCS/create-aggregate.html
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name func_params '(' aggregate_opt_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToFunctionName(),
+      Params: $5.routineParams(),
+      Options: $7.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_opt_list:
+  aggregate_opt_item
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_opt_list ',' aggregate_opt_item
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_opt_item:
+  SFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateStateFunc{Name: $3.unresolvedObjectName().ToFunctionName()}
+  }
+| STYPE '=' typename
+  {
+    $$.val = tree.AggregateStateType{Type: $3.typeReference()}
+  }
+| FINALFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateFinalFunc{Name: $3.unresolvedObjectName().ToFunctionName()}
+  }
+| COMBINEFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateCombineFunc{Name: $3.unresolvedObjectName().ToFunctionName()}
+  }
+| INITCOND '=' SCONST
+  {
+    $$.val = tree.AggregateInitCond($3)
+  }
+
 // %Help: CREATE FUNCTION - define a new function
 // %Category: DDL
 // %Text:
@@ -4576,6 +4921,12 @@ create_extension_stmt:
 //    | { IMMUTABLE | STABLE | VOLATILE }
 //    | [ NOT ] LEAKPROOF
 //    | { CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT }
+//    | [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    | PARALLEL { UNSAFE | RESTRICTED | SAFE }
+//    | COST execution_cost
+//    | ROWS result_rows
+//    | SUPPORT support_function
+//    | SET configuration_parameter { TO | = } value
 //    | AS 'definition'
 //  } ...
 // %SeeAlso: WEBDOCS/create-function.html
@@ -4606,6 +4957,8 @@ create_func_stmt:
 // CREATE [ OR REPLACE ] PROCEDURE
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
 //  { LANGUAGE lang_name
+//    | [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    | SET configuration_parameter { TO | = } value
 //    | AS 'definition'
 //  } ...
 // %SeeAlso: WEBDOCS/create-procedure.html
@@ -4625,6 +4978,288 @@ create_proc_stmt:
   }
 | CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
 
+// %Help: CREATE POLICY - define a new row-level security policy for a table
+// %Category: DDL
+// %Text:
+// CREATE POLICY name ON table_name
+//    [ AS { PERMISSIVE | RESTRICTIVE } ]
+//    [ FOR { ALL | SELECT | INSERT | UPDATE | DELETE } ]
+//    [ TO { role_name | PUBLIC | CURRENT_USER | SESSION_USER } [, ...] ]
+//    [ USING ( using_expression ) ]
+//    [ WITH CHECK ( check_expression ) ]
+// %SeeAlso: DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_type opt_policy_command opt_policy_roles
+  opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.CreatePolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      Type: $6.policyType(),
+      Cmd: $7.policyCommand(),
+      Roles: $8.roleSpecList(),
+      Using: $9.expr(),
+      WithCheck: $10.expr(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_type:
+  AS PERMISSIVE
+  {
+    $$.val = tree.PolicyTypePermissive
+  }
+| AS RESTRICTIVE
+  {
+    $$.val = tree.PolicyTypeRestrictive
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyTypeDefault
+  }
+
+opt_policy_command:
+  FOR ALL
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+| FOR SELECT
+  {
+    $$.val = tree.PolicyCommandSelect
+  }
+| FOR INSERT
+  {
+    $$.val = tree.PolicyCommandInsert
+  }
+| FOR UPDATE
+  {
+    $$.val = tree.PolicyCommandUpdate
+  }
+| FOR DELETE
+  {
+    $$.val = tree.PolicyCommandDelete
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyCommandDefault
+  }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_using:
+  USING '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+opt_policy_with_check:
+  WITH CHECK '(' a_expr ')'
+  {
+    $$.val = $4.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER name { BEFORE | AFTER | INSTEAD OF } { event [ OR ... ] }
+//    ON table_name
+//    [ REFERENCING { { OLD | NEW } TABLE [ AS ] transition_relation_name } [ ... ] ]
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    [ WHEN ( condition ) ]
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( [ arguments ] )
+//
+// where event can be one of:
+//    INSERT
+//    UPDATE [ OF column_name [, ... ] ]
+//    DELETE
+//    TRUNCATE
+// %SeeAlso: CREATE FUNCTION, DROP TRIGGER
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list
+  ON table_name opt_trigger_transition_list trigger_for_each trigger_when
+  EXECUTE function_or_procedure func_name '(' trigger_func_args ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      TableName: $8.unresolvedObjectName(),
+      Transitions: $9.triggerTransitions(),
+      ForEach: $10.triggerForEach(),
+      When: $11.expr(),
+      FuncName: $14.unresolvedName(),
+      FuncArgs: $16.strs(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+| INSTEAD OF
+  {
+    $$.val = tree.TriggerActionTimeInsteadOf
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventInsert}
+  }
+| UPDATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate}
+  }
+| UPDATE OF name_list
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate, Columns: $3.nameList()}
+  }
+| DELETE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventDelete}
+  }
+| TRUNCATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventTruncate}
+  }
+
+opt_trigger_transition_list:
+  REFERENCING trigger_transition_list
+  {
+    $$.val = $2.triggerTransitions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerTransitions(nil)
+  }
+
+trigger_transition_list:
+  trigger_transition
+  {
+    $$.val = tree.TriggerTransitions{$1.triggerTransition()}
+  }
+| trigger_transition_list trigger_transition
+  {
+    $$.val = append($1.triggerTransitions(), $2.triggerTransition())
+  }
+
+trigger_transition:
+  trigger_transition_type TABLE opt_as table_alias_name
+  {
+    $$.val = &tree.TriggerTransition{Name: tree.Name($4), IsNew: $1.bool()}
+  }
+
+trigger_transition_type:
+  NEW
+  {
+    $$.val = true
+  }
+| OLD
+  {
+    $$.val = false
+  }
+
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
+trigger_for_each:
+  FOR trigger_for_opt_each trigger_for_type
+  {
+    $$.val = $3.triggerForEach()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+trigger_for_opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+trigger_for_type:
+  ROW
+  {
+    $$.val = tree.TriggerForEachRow
+  }
+| STATEMENT
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+trigger_func_args:
+  trigger_func_arg
+  {
+    $$.val = []string{$1}
+  }
+| trigger_func_args ',' trigger_func_arg
+  {
+    $$.val = append($1.strs(), $3)
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+trigger_func_arg:
+  ICONST
+  {
+    $$ = $1.numVal().String()
+  }
+| FCONST
+  {
+    $$ = $1.numVal().String()
+  }
+| SCONST
+| unrestricted_name
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
@@ -4708,9 +5343,9 @@ routine_param:
 
 routine_param_class:
   IN { $$.val = tree.RoutineParamIn }
-| OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'OUT' argument class") }
-| INOUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'INOUT' argument class") }
-| IN OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'IN OUT' argument class") }
+| OUT { $$.val = tree.RoutineParamOut }
+| INOUT { $$.val = tree.RoutineParamInOut }
+| IN OUT { $$.val = tree.RoutineParamInOut }
 | VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
 
 routine_param_type:
@@ -4777,19 +5412,19 @@ common_routine_opt_item:
   }
 | EXTERNAL SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | EXTERNAL SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | LEAKPROOF
   {
@@ -4801,22 +5436,44 @@ common_routine_opt_item:
   }
 | COST numeric_only
   {
-    return unimplemented(sqllex, "create function/procedure ... cost")
+    cost, err := tree.AsRoutineEstimate($2.numVal())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = tree.RoutineCost(cost)
   }
 | ROWS numeric_only
   {
-    return unimplemented(sqllex, "create function/procedure ... rows")
+    rows, err := tree.AsRoutineEstimate($2.numVal())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = tree.RoutineRows(rows)
   }
 | SUPPORT name
   {
-    return unimplemented(sqllex, "create function/procedure ... support")
+    $$.val = tree.RoutineSupport($2)
+  }
+| SET generic_set
+  {
+    $$.val = tree.RoutineSetVar{SetVar: $2.setVar()}
+  }
+| RESET session_var
+  {
+    $$.val = tree.RoutineSetVar{SetVar: &tree.SetVar{Name: $2, Values: tree.Exprs{tree.DefaultVal{}}, Reset: true}}
+  }
+| RESET_ALL ALL
+  {
+    $$.val = tree.RoutineSetVar{SetVar: &tree.SetVar{ResetAll: true, Reset: true}}
+  }
+| PARALLEL name
+  {
+    parallel, err := tree.AsRoutineParallel($2)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = parallel
   }
-
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function/procedure ... set") }
-| PARALLEL { return unimplemented(sqllex, "create function/procedure ... parallel") }
 
 routine_as:
   SCONST
@@ -4869,29 +5526,129 @@ opt_link_sym:
   {
   }
 
-// %Help: DROP FUNCTION - remove a function
+// %Help: DROP POLICY - remove a row-level security policy from a table
+// %Category: DDL
+// %Text: DROP POLICY [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      PolicyName: tree.Name($3),
+      TableName: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      IfExists: true,
+      PolicyName: tree.Name($5),
+      TableName: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Trigger: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      IfExists: true,
+      Trigger: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ argname ] argtype [, ...] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-aggregate.html
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: DROP FUNCTION - remove a function
+// %Category: DDL
+// %Text:
+// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-function.html
+drop_func_stmt:
+  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP PROCEDURE - remove a procedure
 // %Category: DDL
 // %Text:
-// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
 //    [ CASCADE | RESTRICT ]
-// %SeeAlso: WEBDOCS/drop-function.html
-drop_func_stmt:
-  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+// %SeeAlso: WEBDOCS/drop-procedure.html
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
   {
     $$.val = &tree.DropFunction{
+      IsProcedure: true,
       Functions: $3.functionObjs(),
       DropBehavior: $4.dropBehavior(),
     }
   }
-| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
   {
     $$.val = &tree.DropFunction{
+      IsProcedure: true,
       IfExists: true,
       Functions: $5.functionObjs(),
       DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
 
 function_with_paramtypes_list:
   function_with_paramtypes
@@ -4988,6 +5745,41 @@ alter_func_owner_stmt:
     }
   }
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] ) RENAME TO new_name
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] ) SET SCHEMA new_schema
+// %SeeAlso: WEBDOCS/alter-aggregate.html
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterFunctionRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      IsAggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterFunctionSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      IsAggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterFunctionSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      IsAggregate: true,
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 alter_func_dep_extension_stmt:
   ALTER FUNCTION function_with_paramtypes opt_no DEPENDS ON EXTENSION name
   {
@@ -5010,7 +5802,6 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
@@ -5025,7 +5816,6 @@ create_unsupported:
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -5037,11 +5827,9 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
 | DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
@@ -5053,7 +5841,6 @@ drop_unsupported:
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -5064,29 +5851,47 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
 // %Text:
-// CREATE STATISTICS <statisticname>
+// CREATE STATISTICS <statisticname> [( <kind> [, ...] )]
 //   [ON <colname> [, ...]]
 //   FROM <tablename> [AS OF SYSTEM TIME <expr>]
+//
+// Kinds:
+//   ndistinct, dependencies
 create_stats_stmt:
-  CREATE STATISTICS statistics_name opt_stats_columns FROM create_stats_target opt_create_stats_options
+  CREATE STATISTICS statistics_name opt_stats_kinds opt_stats_columns FROM create_stats_target opt_create_stats_options
   {
     $$.val = &tree.CreateStats{
       Name: tree.Name($3),
-      ColumnNames: $4.nameList(),
-      Table: $6.tblExpr(),
-      Options: *$7.createStatsOptions(),
+      Kinds: $4.nameList(),
+      ColumnNames: $5.nameList(),
+      Table: $7.tblExpr(),
+      Options: *$8.createStatsOptions(),
     }
   }
 | CREATE STATISTICS error // SHOW HELP: CREATE STATISTICS
 
+opt_stats_kinds:
+  '(' name_list ')'
+  {
+    $$.val = $2.nameList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.NameList(nil)
+  }
+
 opt_stats_columns:
   ON name_list
   {
@@ -5452,7 +6257,12 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_proc_stmt     // EXTEND WITH HELP: DROP PROCEDURE
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5587,6 +6397,29 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <type_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -5807,6 +6640,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6485,7 +7319,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -6939,6 +7773,33 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// %SeeAlso: SET TRANSACTION, WEBDOCS/sql-set-constraints.html
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS db_object_name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.tableNames(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -9822,14 +10683,26 @@ col_qualification_elem:
   {
     $$.val = tree.HiddenConstraint{}
   }
-| UNIQUE opt_without_index
+| UNIQUE opt_without_index opt_deferrable
   {
+    if $3.constraintDeferrability().Deferrable {
+      if !$2.bool() {
+        return setErr(sqllex, tree.NewDeferrableUniqueIndexError())
+      }
+      return setErr(sqllex, errors.WithHint(
+        pgerror.New(pgcode.FeatureNotSupported, "DEFERRABLE column-level unique constraints are not supported"),
+        "Declare the UNIQUE WITHOUT INDEX constraint as a table constraint instead.",
+      ))
+    }
     $$.val = tree.UniqueConstraint{
       WithoutIndex: $2.bool(),
     }
   }
-| PRIMARY KEY opt_with_storage_parameter_list
+| PRIMARY KEY opt_with_storage_parameter_list opt_deferrable
   {
+    if $4.constraintDeferrability().Deferrable {
+      return setErr(sqllex, tree.NewDeferrableUniqueIndexError())
+    }
     $$.val = tree.PrimaryKeyConstraint{
       StorageParams: $3.storageParams(),
     }
@@ -9854,7 +10727,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -9862,6 +10735,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -10012,6 +10886,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability().Deferrable {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported, "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -10027,9 +10904,10 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
-| PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
+| PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list opt_deferrable
   {
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef{
@@ -10038,6 +10916,7 @@ constraint_elem:
         StorageParams: $7.storageParams(),
       },
       PrimaryKey: true,
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | FOREIGN KEY '(' name_list ')' REFERENCES table_name
@@ -10050,11 +10929,56 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_exclusion_access_method '(' exclusion_elem_list ')' opt_where_clause opt_deferrable
+  {
+    $$.val = &tree.ExclusionConstraintTableDef{
+      AccessMethod: $2,
+      Elems: $4.exclusionElems(),
+      Predicate: $6.expr(),
+      Deferrability: $7.constraintDeferrability(),
+    }
+  }
+
+opt_exclusion_access_method:
+  USING name
+  {
+    switch $2 {
+      case "btree", "hash", "gist", "gin":
+        $$ = $2
+      case "spgist":
+        return unimplemented(sqllex, "exclude using " + $2)
+      default:
+        sqllex.Error("unrecognized access method: " + $2)
+        return 1
+    }
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclusion_elem_list:
+  exclusion_elem
+  {
+    $$.val = tree.ExclusionElemList{$1.exclusionElem()}
+  }
+| exclusion_elem_list ',' exclusion_elem
+  {
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
+  }
+
+exclusion_elem:
+  name WITH all_op
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExclusionElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -10163,12 +11087,30 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
 
 storing:
   COVERING
@@ -10240,6 +11182,10 @@ opt_column_list:
 // table. MATCH PARTIAL is not yet implemented. (Of course, NOT NULL
 // constraints can be applied to the referencing column(s) to prevent
 // these cases from arising.)"
+//
+// Unlike Postgres, we implement MATCH PARTIAL: if not all of the foreign key
+// columns are null, the non-null columns must match the corresponding columns
+// of at least one row in the referenced table.
 key_match:
   MATCH SIMPLE
   {
@@ -10251,7 +11197,7 @@ key_match:
   }
 | MATCH PARTIAL
   {
-    return unimplementedWithIssueDetail(sqllex, 20305, "match partial")
+    $$.val = tree.MatchPartial
   }
 | /* EMPTY */
   {
@@ -10722,6 +11668,14 @@ role_option:
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
   }
+| BYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOBYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
 | password_clause
 | valid_until_clause
 | REPLICATION
@@ -10815,8 +11769,70 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <type_name> [AS] <type> [DEFAULT <expr>] [<constraint> ...]
+//
+// Constraints:
+//   [CONSTRAINT <name>] { NOT NULL | NULL | CHECK (<expr>) }
+//
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename opt_domain_default opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateDomain{
+      TypeName: $3.unresolvedObjectName(),
+      Type: $5.typeReference(),
+      Default: $6.expr(),
+      Constraints: $7.domainConstraints(),
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+opt_domain_default:
+  DEFAULT b_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_domain_constraint_list:
+  opt_domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraints(), $2.domainConstraint())
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.DomainConstraint(nil)
+  }
+
+domain_constraint:
+  CONSTRAINT constraint_name domain_constraint_elem
+  {
+    c := $3.domainConstraint()
+    c.Name = tree.Name($2)
+    $$.val = c
+  }
+| domain_constraint_elem
+
+domain_constraint_elem:
+  NOT NULL
+  {
+    $$.val = tree.DomainConstraint{NotNull: true}
+  }
+| NULL
+  {
+    $$.val = tree.DomainConstraint{}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr()}
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -10872,7 +11888,7 @@ composite_type_list:
 // %Category: DDL
 // %Text:
 // CREATE [UNIQUE | INVERTED] INDEX [CONCURRENTLY] [IF NOT EXISTS] [<idxname>]
-//        ON <tablename> ( <colname> [ASC | DESC] [, ...] )
+//        ON <tablename> [USING <method>] ( <colname> [ASC | DESC] [, ...] )
 //        [USING HASH] [STORING ( <colnames...> )]
 //        [PARTITION BY <partition params>]
 //        [WITH <storage_parameter_list] [WHERE <where_conds...>]
@@ -10883,17 +11899,23 @@ create_index_stmt:
   CREATE opt_unique INDEX opt_concurrently opt_index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $7.unresolvedObjectName().ToTableName()
+    accessMethod := $8.indexAccessMethod()
+    sharded := $12.shardedIndexDef()
+    if sharded == nil && accessMethod == tree.IndexAccessMethodHash {
+      sharded = &tree.ShardedIndexDef{ShardBuckets: tree.DefaultVal{}}
+    }
     $$.val = &tree.CreateIndex{
       Name:             tree.Name($5),
       Table:            table,
       Unique:           $2.bool(),
       Columns:          $10.idxElems(),
-      Sharded:          $12.shardedIndexDef(),
+      Sharded:          sharded,
       Storing:          $13.nameList(),
       PartitionByIndex: $14.partitionByIndex(),
       StorageParams:    $15.storageParams(),
       Predicate:        $16.expr(),
-      Inverted:         $8.bool(),
+      Inverted:         accessMethod == tree.IndexAccessMethodInverted,
+      BRIN:             accessMethod == tree.IndexAccessMethodBRIN,
       Concurrently:     $4.bool(),
       Invisibility:     $17.indexInvisibility(),
     }
@@ -10901,16 +11923,22 @@ create_index_stmt:
 | CREATE opt_unique INDEX opt_concurrently IF NOT EXISTS index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $10.unresolvedObjectName().ToTableName()
+    accessMethod := $11.indexAccessMethod()
+    sharded := $15.shardedIndexDef()
+    if sharded == nil && accessMethod == tree.IndexAccessMethodHash {
+      sharded = &tree.ShardedIndexDef{ShardBuckets: tree.DefaultVal{}}
+    }
     $$.val = &tree.CreateIndex{
       Name:             tree.Name($8),
       Table:            table,
       Unique:           $2.bool(),
       IfNotExists:      true,
       Columns:          $13.idxElems(),
-      Sharded:          $15.shardedIndexDef(),
+      Sharded:          sharded,
       Storing:          $16.nameList(),
       PartitionByIndex: $17.partitionByIndex(),
-      Inverted:         $11.bool(),
+      Inverted:         accessMethod == tree.IndexAccessMethodInverted,
+      BRIN:             accessMethod == tree.IndexAccessMethodBRIN,
       StorageParams:    $18.storageParams(),
       Predicate:        $19.expr(),
       Concurrently:     $4.bool(),
@@ -10960,10 +11988,14 @@ opt_index_access_method:
     /* FORCE DOC */
     switch $2 {
       case "gin", "gist":
-        $$.val = true
+        $$.val = tree.IndexAccessMethodInverted
       case "btree":
-        $$.val = false
-      case "hash", "spgist", "brin":
+        $$.val = tree.IndexAccessMethodBTree
+      case "hash":
+        $$.val = tree.IndexAccessMethodHash
+      case "brin":
+        $$.val = tree.IndexAccessMethodBRIN
+      case "spgist":
         return unimplemented(sqllex, "index using " + $2)
       default:
         sqllex.Error("unrecognized access method: " + $2)
@@ -10972,7 +12004,7 @@ opt_index_access_method:
   }
 | /* EMPTY */
   {
-    $$.val = false
+    $$.val = tree.IndexAccessMethodBTree
   }
 
 opt_concurrently:
@@ -12223,6 +13255,97 @@ returning_clause:
     $$.val = tree.AbsentReturningClause
   }
 
+// %Help: MERGE - insert, update or delete rows of a table based on a join
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//       USING <source> ON <expr>
+//       WHEN MATCHED [AND <expr>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//       WHEN NOT MATCHED [AND <expr>] THEN
+//         { INSERT [( <colnames...> )] VALUES ( <exprs...> ) | INSERT DEFAULT VALUES | DO NOTHING }
+//       [...]
+// %SeeAlso: INSERT, UPDATE, DELETE, UPSERT
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED THEN UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Action: tree.MergeActionUpdate, Exprs: $6.updateExprs()}
+  }
+| WHEN MATCHED AND a_expr THEN UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Cond: $4.expr(), Action: tree.MergeActionUpdate, Exprs: $8.updateExprs()}
+  }
+| WHEN MATCHED THEN DELETE
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Action: tree.MergeActionDelete}
+  }
+| WHEN MATCHED AND a_expr THEN DELETE
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Cond: $4.expr(), Action: tree.MergeActionDelete}
+  }
+| WHEN MATCHED THEN DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Action: tree.MergeActionDoNothing}
+  }
+| WHEN MATCHED AND a_expr THEN DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Matched: true, Cond: $4.expr(), Action: tree.MergeActionDoNothing}
+  }
+| WHEN NOT MATCHED THEN merge_insert
+  {
+    $$.val = $5.mergeWhen()
+  }
+| WHEN NOT MATCHED AND a_expr THEN merge_insert
+  {
+    when := $7.mergeWhen()
+    when.Cond = $5.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED THEN DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDoNothing}
+  }
+| WHEN NOT MATCHED AND a_expr THEN DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Cond: $5.expr(), Action: tree.MergeActionDoNothing}
+  }
+
+merge_insert:
+  INSERT VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Values: $4.exprs()}
+  }
+| INSERT '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Columns: $3.nameList(), Values: $7.exprs()}
+  }
+| INSERT DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert}
+  }
+
 // %Help: UPDATE - update rows of a table
 // %Category: DML
 // %Text:
@@ -12941,9 +14064,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.ExplicitGroupingSets, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13147,14 +14279,15 @@ table_ref:
         As:         $4.aliasClause(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| relation_expr opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
   {
     name := $1.unresolvedObjectName().ToTableName()
     $$.val = &tree.AliasedTableExpr{
-      Expr:       &name,
-      IndexFlags: $2.indexFlags(),
-      Ordinality: $3.bool(),
-      As:         $4.aliasClause(),
+      Expr:        &name,
+      IndexFlags:  $2.indexFlags(),
+      Ordinality:  $3.bool(),
+      As:          $4.aliasClause(),
+      TableSample: $5.tableSample(),
     }
   }
 | select_with_parens opt_ordinality opt_alias_clause
@@ -13381,6 +14514,31 @@ alias_clause:
     $$.val = tree.AliasClause{Alias: tree.Name($1), Cols: $2.colDefList()}
   }
 
+// TABLESAMPLE method '(' percent ')' [ REPEATABLE '(' seed ')' ]
+opt_tablesample_clause:
+  TABLESAMPLE name '(' a_expr ')' opt_repeatable_clause
+  {
+    method, ok := tree.TableSampleMethodFromName($2)
+    if !ok {
+      return setErr(sqllex, pgerror.Newf(pgcode.UndefinedObject, "tablesample method %s does not exist", $2))
+    }
+    $$.val = &tree.TableSample{Method: method, Percent: $4.expr(), Seed: $6.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TableSample)(nil)
+  }
+
+opt_repeatable_clause:
+  REPEATABLE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 opt_alias_clause:
   alias_clause
 | /* EMPTY */
@@ -13518,6 +14676,32 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
 // UNLISTEN
 unlisten_stmt:
    UNLISTEN type_name
@@ -13743,8 +14927,6 @@ simple_typename:
   }
 | const_typename
 | interval_type
-| POINT error { return unimplementedWithIssueDetail(sqllex, 21286, "point") } // needed or else it generates a syntax error.
-| POLYGON error { return unimplementedWithIssueDetail(sqllex, 21286, "polygon") } // needed or else it generates a syntax error.
 
 geo_shape_type:
   POINT { $$.val = geopb.ShapeType_Point }
@@ -13784,6 +14966,8 @@ const_geo:
   GEOGRAPHY { $$.val = types.Geography }
 | GEOMETRY  { $$.val = types.Geometry }
 | BOX2D     { $$.val = types.Box2D }
+| POINT     { $$.val = types.Point }
+| POLYGON   { $$.val = types.Polygon }
 | GEOMETRY '(' geo_shape_type ')'
   {
     $$.val = types.MakeGeometry($3.geoShapeType(), 0)
@@ -14411,10 +15595,26 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_QUESTION a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONPathExists), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
   }
+| a_expr GEOMETRIC_DISTANCE a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("geometric_distance"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr GEOMETRIC_INTERSECTS a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("geometric_intersects"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr RANGE_ADJACENT a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("range_adjacent"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
 | a_expr LESS_EQUALS a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.LE), Left: $1.expr(), Right: $3.expr()}
@@ -14965,7 +16165,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingFuncExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -15308,6 +16511,16 @@ special_function:
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
   }
 | LEAST '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POINT '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POINT '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POLYGON '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POLYGON '(' error { return helpWithFunctionByName(sqllex, $1) }
 
 
 // Aggregate decoration clauses
@@ -15623,6 +16836,7 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| AT_QUESTION { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONPathExists) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -16575,6 +17789,7 @@ unreserved_keyword:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -16589,6 +17804,7 @@ unreserved_keyword:
 | CLUSTER
 | CLUSTERS
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -16636,11 +17852,14 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
+| ENABLE
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
 | ENCRYPTION_INFO_DIR
@@ -16665,6 +17884,7 @@ unreserved_keyword:
 | FAILURE
 | FILES
 | FILTER
+| FINALFUNC
 | FIRST
 | FOLLOWING
 | FORMAT
@@ -16711,9 +17931,11 @@ unreserved_keyword:
 | INDEX
 | INDEXES
 | INHERITS
+| INITCOND
 | INJECT
 | INPUT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | INVISIBLE
@@ -16741,6 +17963,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
@@ -16748,6 +17971,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -16773,12 +17997,15 @@ unreserved_keyword:
 | NAMES
 | NAN
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
+| NOBYPASSRLS
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -16803,6 +18030,7 @@ unreserved_keyword:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | OPERATOR
 | OPT
@@ -16821,6 +18049,7 @@ unreserved_keyword:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLAN
@@ -16828,6 +18057,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -16852,6 +18082,7 @@ unreserved_keyword:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -16869,6 +18100,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -16910,6 +18142,7 @@ unreserved_keyword:
 | SESSIONS
 | SET
 | SETS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -16929,6 +18162,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -16940,6 +18174,7 @@ unreserved_keyword:
 | STORING
 | STREAM
 | STRICT
+| STYPE
 | SUBSCRIPTION
 | SUPER
 | SUPPORT
@@ -17053,6 +18288,7 @@ bare_label_keywords:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -17074,6 +18310,7 @@ bare_label_keywords:
 | COLLATION
 | COLUMN
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17135,14 +18372,17 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DISABLE
 | DISCARD
 | DISTINCT
 | DO
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ELSE
 | ENCODING
+| ENABLE
 | ENCRYPTED
 | ENCRYPTION_INFO_DIR
 | ENCRYPTION_PASSPHRASE
@@ -17172,6 +18412,7 @@ bare_label_keywords:
 | FALSE
 | FAMILY
 | FILES
+| FINALFUNC
 | FIRST
 | FLOAT
 | FOLLOWING
@@ -17233,6 +18474,7 @@ bare_label_keywords:
 | INDEX_BEFORE_NAME_THEN_PAREN
 | INDEX_BEFORE_PAREN
 | INHERITS
+| INITCOND
 | INITIALLY
 | INJECT
 | INNER
@@ -17240,6 +18482,7 @@ bare_label_keywords:
 | INPUT
 | INSENSITIVE
 | INSERT
+| INSTEAD
 | INT
 | INTEGER
 | INTERVAL
@@ -17278,6 +18521,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17287,6 +18531,7 @@ bare_label_keywords:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17311,10 +18556,12 @@ bare_label_keywords:
 | NAN
 | NATURAL
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
+| NOBYPASSRLS
 | NOCANCELQUERY
 | NOCONTROLCHANGEFEED
 | NOCONTROLJOB
@@ -17331,6 +18578,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -17345,6 +18593,7 @@ bare_label_keywords:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | ONLY
 | OPERATOR
@@ -17367,6 +18616,7 @@ bare_label_keywords:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLACING
@@ -17376,6 +18626,7 @@ bare_label_keywords:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGON
 | POLYGONM
 | POLYGONZ
@@ -17405,6 +18656,7 @@ bare_label_keywords:
 | REDACT
 | REF
 | REFERENCES
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17422,6 +18674,7 @@ bare_label_keywords:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -17466,6 +18719,7 @@ bare_label_keywords:
 | SETS
 | SETTING
 | SETTINGS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -17488,6 +18742,7 @@ bare_label_keywords:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STATUS
@@ -17501,6 +18756,7 @@ bare_label_keywords:
 | STREAM
 | STRICT
 | STRING
+| STYPE
 | SUBSCRIPTION
 | SUBSTRING
 | SUPER
@@ -17512,6 +18768,7 @@ bare_label_keywords:
 | SYSTEM
 | TABLE
 | TABLES
+| TABLESAMPLE
 | TABLESPACE
 | TEMP
 | TEMPLATE
@@ -17692,6 +18949,7 @@ type_func_name_no_crdb_extra_keyword:
 | OVERLAPS
 | RIGHT
 | SIMILAR
+| TABLESAMPLE
 
 // CockroachDB-specific keywords that can be used in type/function
 // identifiers.
@@ -51,11 +51,12 @@ type Statement[T any] struct {
 	NumAnnotations tree.AnnotationIdx
 }
 
-// IsANSIDML returns true if the AST is one of the 4 DML statements,
-// SELECT, UPDATE, INSERT, DELETE, or an EXPLAIN of one of these statements.
+// IsANSIDML returns true if the AST is one of the 5 DML statements,
+// SELECT, UPDATE, INSERT, DELETE, MERGE, or an EXPLAIN of one of these
+// statements.
 func IsANSIDML(stmt tree.Statement) bool {
 	switch t := stmt.(type) {
-	case *tree.Select, *tree.ParenSelect, *tree.Delete, *tree.Insert, *tree.Update:
+	case *tree.Select, *tree.ParenSelect, *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge:
 		return true
 	case *tree.Explain:
 		return IsANSIDML(t.Statement)
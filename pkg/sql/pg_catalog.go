@@ -22,6 +22,7 @@ import (
 	"unicode"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
@@ -53,6 +54,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/vtable"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
@@ -578,6 +580,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
@@ -593,7 +599,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				tree.MakeDBool(isRoot || createDB),   // rolcreatedb
 				tree.MakeDBool(roleCanLogin),         // rolcanlogin.
 				tree.DBoolFalse,                      // rolreplication
-				tree.DBoolFalse,                      // rolbypassrls
+				tree.MakeDBool(isRoot || bypassRLS),  // rolbypassrls
 				negOneVal,                            // rolconnlimit
 				passwdStarString,                     // rolpassword
 				rolValidUntil,                        // rolvaliduntil
@@ -734,13 +740,13 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.DNull,      // relacl
 			relOptions,      // reloptions
 			// These columns were automatically created by pg_catalog_test's missing column generator.
-			tree.DNull,                 // relforcerowsecurity
+			tree.MakeDBool(tree.DBool(table.IsRowLevelSecurityForced())), // relforcerowsecurity
 			tree.DNull,                 // relispartition
 			tree.DNull,                 // relispopulated
 			tree.NewDString(replIdent), // relreplident
 			tree.DNull,                 // relrewrite
-			tree.DNull,                 // relrowsecurity
-			tree.DNull,                 // relpartbound
+			tree.MakeDBool(tree.DBool(table.IsRowLevelSecurityEnabled())), // relrowsecurity
+			tree.DNull, // relpartbound
 			// These columns were automatically created by pg_catalog_test's missing column generator.
 			tree.DNull, // relminmxid
 		); err != nil {
@@ -852,7 +858,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -904,6 +909,8 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		condeferrable := tree.DBoolFalse
+		condeferred := tree.DBoolFalse
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -981,6 +988,8 @@ func populateTableConstraints(
 			if confkey, err = colIDArrayToDatum(fk.ForeignKeyDesc().ReferencedColumnIDs); err != nil {
 				return err
 			}
+			condeferrable = tree.MakeDBool(tree.DBool(fk.ForeignKeyDesc().Deferrable))
+			condeferred = tree.MakeDBool(tree.DBool(fk.ForeignKeyDesc().InitiallyDeferred))
 			var buf bytes.Buffer
 			if err := showForeignKeyConstraint(
 				&buf, db.GetName(),
@@ -997,13 +1006,41 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
 			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
 			if err != nil {
 				return err
 			}
-			f.WriteString(strings.Join(colNames, ", "))
+			if uwoi.IsExclusion() {
+				contype = conTypeExclusion
+				// Postgres always shows the access method of exclusion
+				// constraints, which defaults to btree.
+				accessMethod := uwoi.UniqueWithoutIndexDesc().ExclusionAccessMethod
+				if accessMethod == "" {
+					accessMethod = "btree"
+				}
+				f.WriteString("EXCLUDE USING ")
+				f.WriteString(accessMethod)
+				f.WriteString(" (")
+				ops := uwoi.ExclusionOperators()
+				for i := range colNames {
+					if i > 0 {
+						f.WriteString(", ")
+					}
+					f.WriteString(colNames[i])
+					f.WriteString(" WITH ")
+					f.WriteString(ops[i])
+				}
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				f.WriteString(strings.Join(colNames, ", "))
+			}
 			f.WriteByte(')')
+			f.FormatNode(&tree.ConstraintDeferrability{
+				Deferrable:        uwoi.UniqueWithoutIndexDesc().Deferrable,
+				InitiallyDeferred: uwoi.UniqueWithoutIndexDesc().InitiallyDeferred,
+			})
+			condeferrable = tree.MakeDBool(tree.DBool(uwoi.UniqueWithoutIndexDesc().Deferrable))
+			condeferred = tree.MakeDBool(tree.DBool(uwoi.UniqueWithoutIndexDesc().InitiallyDeferred))
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1039,8 +1076,8 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -2039,13 +2076,43 @@ https://www.postgresql.org/docs/9.5/catalog-pg-language.html`,
 }
 
 var pgCatalogLocksTable = virtualSchemaTable{
-	comment: `locks held by active processes (empty - feature does not exist)
+	comment: `locks held by active processes (only advisory locks)
 https://www.postgresql.org/docs/9.6/view-pg-locks.html`,
 	schema: vtable.PGCatalogLocks,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		advisory := tree.NewDString("advisory")
+		return p.forEachAdvisoryLock(ctx, func(
+			id advisoryLockID, txn *enginepb.TxnMeta, str lock.Strength, granted bool,
+		) error {
+			// The backend of other sessions cannot be determined from the KV
+			// transactions that hold their locks.
+			pid := tree.DNull
+			if txn.ID == p.txn.ID() || (p.advisoryLocks != nil && p.advisoryLocks.ownsTxn(txn.ID)) {
+				pid = tree.NewDInt(tree.DInt(p.extendedEvalCtx.QueryCancelKey.GetPGBackendPID()))
+			}
+			mode := advisoryLockExclusive
+			if str == lock.Shared {
+				mode = advisoryLockShared
+			}
+			return addRow(
+				advisory,                                 // locktype
+				dbOid(id.dbID),                           // database
+				tree.DNull,                               // relation
+				tree.DNull,                               // page
+				tree.DNull,                               // tuple
+				tree.DNull,                               // virtualxid
+				tree.DNull,                               // transactionid
+				tree.NewDOid(oid.Oid(id.key.ClassID)),    // classid
+				tree.NewDOid(oid.Oid(id.key.ObjID)),      // objid
+				tree.NewDInt(tree.DInt(id.key.ObjSubID)), // objsubid
+				tree.NewDString(txn.ID.String()),         // virtualtransaction
+				pid,                                      // pid
+				tree.NewDString(mode.String()),           // mode
+				tree.MakeDBool(tree.DBool(granted)),      // granted
+				tree.DBoolFalse,                          // fastpath
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogMatViewsTable = virtualSchemaTable{
@@ -2525,46 +2592,76 @@ func addPgProcUDFRow(
 		argNames = argNamesArray
 	}
 
+	isAgg := fnDesc.GetAggregate() != nil
+	kind := "f"
+	if isAgg {
+		kind = "a"
+	}
+
 	lang := languageInternalOid
 	if fnDesc.GetLanguage() == catpb.Function_PLPGSQL {
 		lang = languagePlpgsqlOid
 	} else if fnDesc.GetLanguage() == catpb.Function_SQL {
 		lang = languageSqlOid
 	}
+
+	// Use the same default estimates as Postgres if none were specified.
+	cost := fnDesc.GetCost()
+	if cost == 0 {
+		cost = 100
+	}
+	rows := fnDesc.GetRows()
+	if rows == 0 && fnDesc.GetReturnType().ReturnSet {
+		rows = 1000
+	}
+	support := tree.DNull
+	if name := fnDesc.GetSupport(); name != "" {
+		support = h.RegProc(name)
+	}
+	config := tree.DNull
+	if settings := fnDesc.GetConfig(); len(settings) > 0 {
+		configArr := tree.NewDArray(types.String)
+		for _, setting := range settings {
+			if err := configArr.Append(tree.NewDString(setting)); err != nil {
+				return err
+			}
+		}
+		config = configArr
+	}
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
 		tree.NewDName(fnDesc.GetName()),                 // proname
 		schemaOid(scDesc.GetID()),                       // pronamespace
 		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
-		lang,            // prolang
-		tree.DNull,      // procost
-		tree.DNull,      // prorows
-		oidZero,         // provariadic
-		tree.DNull,      // protransform
-		tree.DBoolFalse, // proisagg
-		tree.DBoolFalse, // proiswindow
-		tree.DBoolFalse, // prosecdef
-		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
-		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
-		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
-		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),      // provolatile
-		tree.DNull, // proparallel
-		tree.NewDInt(tree.DInt(len(fnDesc.GetParams()))), // pronargs
-		tree.NewDInt(tree.DInt(0)),                       // pronargdefaults
-		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()),  // prorettype
-		tree.NewDOidVectorFromDArray(argTypes),           // proargtypes
-		tree.DNull,                                       // proallargtypes
-		argModes,                                         // proargmodes
-		argNames,                                         // proargnames
-		tree.DNull,                                       // proargdefaults
-		tree.DNull,                                       // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),        // prosrc
-		tree.DNull,                                       // probin
-		tree.DNull,                                       // proconfig
-		tree.DNull,                                       // proacl
-		tree.NewDString("f"),                             // prokind
+		lang,                              // prolang
+		tree.NewDFloat(tree.DFloat(cost)), // procost
+		tree.NewDFloat(tree.DFloat(rows)), // prorows
+		oidZero,                           // provariadic
+		tree.DNull,                        // protransform
+		tree.MakeDBool(tree.DBool(isAgg)), // proisagg
+		tree.DBoolFalse,                   // proiswindow
+		tree.MakeDBool(tree.DBool(fnDesc.GetSecurity() == catpb.Function_DEFINER)), // prosecdef
+		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),                          // proleakproof
+		tree.MakeDBool(tree.DBool(isStrict)),                                       // proisstrict
+		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)),               // proretset
+		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),                    // provolatile
+		tree.NewDString(funcParallel(fnDesc.GetParallel())),                        // proparallel
+		tree.NewDInt(tree.DInt(len(fnDesc.GetParams()))),                           // pronargs
+		tree.NewDInt(tree.DInt(0)),                                                 // pronargdefaults
+		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()),                            // prorettype
+		tree.NewDOidVectorFromDArray(argTypes),                                     // proargtypes
+		tree.DNull,                                                                 // proallargtypes
+		argModes,                                                                   // proargmodes
+		argNames,                                                                   // proargnames
+		tree.DNull,                                                                 // proargdefaults
+		tree.DNull,                                                                 // protrftypes
+		tree.NewDString(fnDesc.GetFunctionBody()),                                  // prosrc
+		tree.DNull,            // probin
+		config,                // proconfig
+		tree.DNull,            // proacl
+		tree.NewDString(kind), // prokind
 		// These columns were automatically created by pg_catalog_test's missing column generator.
-		tree.DNull, // prosupport
+		support, // prosupport
 	)
 }
 
@@ -2670,16 +2767,26 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: vtable.PGCatalogRange,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		// Only the built-in range types exist; user-defined range types are
+		// not supported.
+		for _, typ := range types.Ranges {
+			if err := addRow(
+				tree.NewDOid(typ.Oid()),                 // rngtypid
+				tree.NewDOid(typ.RangeContents().Oid()), // rngsubtype
+				oidZero,                                 // rngcollation
+				oidZero,                                 // rngsubopc
+				oidZero,                                 // rngcanonical
+				oidZero,                                 // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogRewriteTable = virtualSchemaTable{
@@ -2751,6 +2858,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -2769,7 +2880,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					negOneVal,                             // rolconnlimit
 					passwdStarString,                      // rolpassword
 					rolValidUntil,                         // rolvaliduntil
-					tree.DBoolFalse,                       // rolbypassrls
+					tree.MakeDBool(isSuper || bypassRLS),  // rolbypassrls
 					settings,                              // rolconfig
 				)
 			})
@@ -3072,17 +3183,16 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultiRange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3103,8 +3213,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
-	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3200,14 +3308,29 @@ func addPGTypeRow(
 		builtinPrefix = "record_"
 		typType = typTypeComposite
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
-	case types.VoidFamily:
-		// void does not have an array type.
+	case types.VoidFamily, types.TriggerFamily:
+		// void and trigger do not have array types.
+	case types.RangeFamily:
+		typType = typTypeRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.MultiRangeFamily:
+		typType = typTypeMultiRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull, typBaseType, typDefault := tree.DBoolFalse, oidZero, tree.DNull
+	if dd := typ.TypeMeta.DomainData; dd != nil {
+		typType = typTypeDomain
+		typNotNull = tree.MakeDBool(tree.DBool(dd.NotNull))
+		typBaseType = tree.NewDOid(dd.BaseType.Oid())
+		if dd.DefaultExpr != nil {
+			typDefault = tree.NewDString(*dd.DefaultExpr)
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3237,13 +3360,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3976,12 +4099,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(string(name)), /* name */
-				tree.NewDString(c.statement),  /* statement */
-				tree.DBoolFalse,               /* is_holdable */
-				tree.DBoolFalse,               /* is_binary */
-				tree.DBoolFalse,               /* is_scrollable */
-				tz,                            /* creation_date */
+				tree.NewDString(string(name)),          /* name */
+				tree.NewDString(c.statement),           /* statement */
+				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
+				tree.DBoolFalse,                        /* is_binary */
+				tree.MakeDBool(tree.DBool(c.scroll)),   /* is_scrollable */
+				tz,                                     /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4472,10 +4595,24 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
 	types.PGLSNFamily:       typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
+	types.CIDRFamily:        typCategoryNetworkAddr,
+	types.MacAddrFamily:     typCategoryUserDefined,
+	types.MacAddr8Family:    typCategoryUserDefined,
+	types.PointFamily:       typCategoryGeometric,
+	types.LSegFamily:        typCategoryGeometric,
+	types.BoxFamily:         typCategoryGeometric,
+	types.PathFamily:        typCategoryGeometric,
+	types.PolygonFamily:     typCategoryGeometric,
+	types.LineFamily:        typCategoryGeometric,
+	types.CircleFamily:      typCategoryGeometric,
+	types.RangeFamily:       typCategoryRange,
+	types.MultiRangeFamily:  typCategoryRange,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4596,11 +4733,86 @@ https://www.postgresql.org/docs/9.6/catalog-pg-aggregate.html`,
 						}
 					}
 				}
-				return nil
+				return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(scDesc catalog.SchemaDescriptor) error {
+					return scDesc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+						if !sig.IsAggregate {
+							return nil
+						}
+						fnDesc, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, sig.ID)
+						if err != nil {
+							return err
+						}
+						return addPgAggregateUDFRow(ctx, p, fnDesc, addRow)
+					})
+				})
 			})
 	},
 }
 
+// addPgAggregateUDFRow adds the pg_aggregate row for the given user-defined
+// aggregate.
+func addPgAggregateUDFRow(
+	ctx context.Context,
+	p *planner,
+	fnDesc catalog.FunctionDescriptor,
+	addRow func(...tree.Datum) error,
+) error {
+	agg := fnDesc.GetAggregate()
+	noFn := tree.NewDOidWithName(0, types.RegProc, "-")
+	supportFuncRegProc := func(id descpb.ID) (tree.Datum, error) {
+		if id == descpb.InvalidID {
+			return noFn, nil
+		}
+		supportFn, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDOid(catid.FuncIDToOID(id)).AsRegProc(supportFn.GetName()), nil
+	}
+	transFn, err := supportFuncRegProc(agg.StateFuncID)
+	if err != nil {
+		return err
+	}
+	finalFn, err := supportFuncRegProc(agg.FinalFuncID)
+	if err != nil {
+		return err
+	}
+	combineFn, err := supportFuncRegProc(agg.CombineFuncID)
+	if err != nil {
+		return err
+	}
+	aggFn := tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())).AsRegProc(fnDesc.GetName())
+	initVal := tree.DNull
+	if agg.InitialCondition != nil {
+		initVal = tree.NewDString(*agg.InitialCondition)
+	}
+	return addRow(
+		aggFn,                             // aggfnoid
+		tree.NewDString("n"),              // aggkind
+		zeroVal,                           // aggnumdirectargs
+		transFn,                           // aggtransfn
+		finalFn,                           // aggfinalfn
+		combineFn,                         // aggcombinefn
+		noFn,                              // aggserialfn
+		noFn,                              // aggdeserialfn
+		noFn,                              // aggmtransfn
+		noFn,                              // aggminvtransfn
+		noFn,                              // aggmfinalfn
+		tree.DBoolFalse,                   // aggfinalextra
+		tree.DBoolFalse,                   // aggmfinalextra
+		oidZero,                           // aggsortop
+		tree.NewDOid(agg.StateType.Oid()), // aggtranstype
+		tree.DNull,                        // aggtransspace
+		tree.DNull,                        // aggmtranstype
+		tree.DNull,                        // aggmtransspace
+		initVal,                           // agginitval
+		tree.DNull,                        // aggminitval
+		// These columns were automatically created by pg_catalog_test's missing column generator.
+		tree.DNull, // aggfinalmodify
+		tree.DNull, // aggmfinalmodify
+	)
+}
+
 // oidHasher provides a consistent hashing mechanism for object identifiers in
 // pg_catalog tables, allowing for reliable joins across tables.
 //
@@ -4888,6 +5100,17 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+func funcParallel(v catpb.Function_Parallel) string {
+	switch v {
+	case catpb.Function_PARALLEL_SAFE:
+		return "s"
+	case catpb.Function_PARALLEL_RESTRICTED:
+		return "r"
+	default:
+		return "u"
+	}
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
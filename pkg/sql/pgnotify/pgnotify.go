@@ -0,0 +1,182 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgnotify implements the routing of asynchronous notifications sent
+// with NOTIFY or pg_notify() to the sessions that are listening on their
+// channel with LISTEN.
+package pgnotify
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// maxChannelLen is the maximum length of a channel name, matching the
+// identifier length limit of Postgres (NAMEDATALEN - 1).
+const maxChannelLen = 63
+
+// maxPayloadLen is the maximum length of a notification payload, matching
+// NOTIFY_PAYLOAD_MAX_LENGTH - 1 in Postgres.
+const maxPayloadLen = 7999
+
+// maxPendingPerListener bounds the number of notifications queued for a
+// single listener that has not consumed them yet. Notifications beyond this
+// limit are dropped.
+const maxPendingPerListener = 1 << 16
+
+// Notification is an asynchronous notification sent on a channel.
+type Notification struct {
+	Channel string
+	Payload string
+	// SenderPID is the backend PID of the session that sent the notification,
+	// as reported by pg_backend_pid().
+	SenderPID uint32
+}
+
+// CheckChannel returns an error if the given channel name is not valid.
+func CheckChannel(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxChannelLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// CheckPayload returns an error if the given payload is too long.
+func CheckPayload(payload string) error {
+	if len(payload) > maxPayloadLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	return nil
+}
+
+// Registry keeps track of the listeners on a node and routes published
+// notifications to them.
+type Registry struct {
+	mu struct {
+		syncutil.Mutex
+		// channels maps each channel to the set of listeners on it.
+		channels map[string]map[*Listener]struct{}
+	}
+}
+
+// NewRegistry creates a new Registry.
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.mu.channels = make(map[string]map[*Listener]struct{})
+	return r
+}
+
+// NewListener creates a new Listener that is not listening on any channel.
+func (r *Registry) NewListener() *Listener {
+	l := &Listener{registry: r}
+	l.channels = make(map[string]struct{})
+	return l
+}
+
+// Publish delivers the given notifications, in order, to every listener on
+// their channel.
+func (r *Registry) Publish(notifications []Notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range notifications {
+		for l := range r.mu.channels[n.Channel] {
+			l.enqueue(n)
+		}
+	}
+}
+
+// Listener receives the notifications sent on the channels it listens on.
+// The channel set of a Listener is only modified by its owning session, but
+// notifications can be enqueued concurrently by any session publishing on
+// the node.
+type Listener struct {
+	registry *Registry
+
+	// channels is the set of channels being listened on. It is protected by
+	// registry.mu.
+	channels map[string]struct{}
+
+	mu struct {
+		syncutil.Mutex
+		pending []Notification
+	}
+}
+
+// Listen starts listening on the given channel. It is a no-op if the
+// listener is already listening on it.
+func (l *Listener) Listen(channel string) {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := l.channels[channel]; ok {
+		return
+	}
+	l.channels[channel] = struct{}{}
+	listeners, ok := r.mu.channels[channel]
+	if !ok {
+		listeners = make(map[*Listener]struct{})
+		r.mu.channels[channel] = listeners
+	}
+	listeners[l] = struct{}{}
+}
+
+// Unlisten stops listening on the given channel. It is a no-op if the
+// listener is not listening on it.
+func (l *Listener) Unlisten(channel string) {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l.unlistenLocked(channel)
+}
+
+// UnlistenAll stops listening on all channels.
+func (l *Listener) UnlistenAll() {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for channel := range l.channels {
+		l.unlistenLocked(channel)
+	}
+}
+
+func (l *Listener) unlistenLocked(channel string) {
+	r := l.registry
+	if _, ok := l.channels[channel]; !ok {
+		return
+	}
+	delete(l.channels, channel)
+	listeners := r.mu.channels[channel]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.channels, channel)
+	}
+}
+
+// Drain returns the notifications received since the last call to Drain.
+func (l *Listener) Drain() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.mu.pending
+	l.mu.pending = nil
+	return pending
+}
+
+func (l *Listener) enqueue(n Notification) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.mu.pending) >= maxPendingPerListener {
+		return
+	}
+	l.mu.pending = append(l.mu.pending, n)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry()
+	a := r.NewListener()
+	b := r.NewListener()
+
+	a.Listen("jobs")
+	a.Listen("jobs")
+	a.Listen("events")
+	b.Listen("events")
+
+	n1 := Notification{Channel: "jobs", Payload: "1", SenderPID: 7}
+	n2 := Notification{Channel: "events", Payload: "2", SenderPID: 7}
+	n3 := Notification{Channel: "other", Payload: "3", SenderPID: 7}
+	r.Publish([]Notification{n1, n2, n3})
+
+	require.Equal(t, []Notification{n1, n2}, a.Drain())
+	require.Equal(t, []Notification{n2}, b.Drain())
+	require.Empty(t, a.Drain())
+
+	a.Unlisten("jobs")
+	b.UnlistenAll()
+	r.Publish([]Notification{n1, n2})
+	require.Equal(t, []Notification{n2}, a.Drain())
+	require.Empty(t, b.Drain())
+
+	a.UnlistenAll()
+	require.Empty(t, r.mu.channels)
+}
+
+func TestCheck(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	require.NoError(t, CheckChannel("jobs"))
+	require.NoError(t, CheckChannel(strings.Repeat("a", maxChannelLen)))
+	require.EqualError(t, CheckChannel(""), "channel name cannot be empty")
+	require.EqualError(t, CheckChannel(strings.Repeat("a", maxChannelLen+1)), "channel name too long")
+
+	require.NoError(t, CheckPayload(""))
+	require.NoError(t, CheckPayload(strings.Repeat("a", maxPayloadLen)))
+	require.EqualError(t, CheckPayload(strings.Repeat("a", maxPayloadLen+1)), "payload string too long")
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// retention is how long the rows of system.notifications are kept. The
+// listeners receive the notifications through a rangefeed as soon as they are
+// written, so the rows only need to outlive the restarts of the rangefeeds.
+const retention = time.Minute
+
+// gcInterval is the interval at which the expired rows of
+// system.notifications are deleted.
+const gcInterval = time.Minute
+
+// gcBatchSize is the maximum number of rows deleted by one statement.
+const gcBatchSize = 1000
+
+// Insert writes the given notifications to system.notifications, from which
+// the other SQL instances of the cluster publish them to their registry. It
+// is called once the transaction that sent the notifications committed, after
+// they were published to the registry of the local instance.
+func Insert(
+	ctx context.Context,
+	db isql.DB,
+	instanceID base.SQLInstanceID,
+	notifications []Notification,
+) error {
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		for _, n := range notifications {
+			if _, err := txn.ExecEx(
+				ctx, "insert-notification", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+				`INSERT INTO system.notifications (channel, payload, sender_pid, sender_instance_id)
+VALUES ($1, $2, $3, $4)`,
+				n.Channel, n.Payload, int64(n.SenderPID), int64(instanceID),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Watch starts a rangefeed over system.notifications, whose descriptor ID is
+// tableID, which publishes to the registry the notifications sent by the
+// other SQL instances of the cluster. The notifications sent by the given
+// instance are skipped, as they were published when their transaction
+// committed. A notification can be published more than once if the rangefeed
+// restarts.
+//
+// Watch also starts a task which periodically deletes the rows of the table
+// older than retention.
+func (r *Registry) Watch(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	f *rangefeed.Factory,
+	db isql.DB,
+	codec keys.SQLCodec,
+	tableID descpb.ID,
+	instanceID base.SQLInstanceID,
+) error {
+	decoder := makeRowDecoder()
+	var alloc tree.DatumAlloc
+	handleEvent := func(ctx context.Context, value *kvpb.RangeFeedValue) {
+		if !value.Value.IsPresent() {
+			// The row was garbage collected.
+			return
+		}
+		n, sender, err := decoder.decodeRow(value.Value, &alloc)
+		if err != nil {
+			log.Warningf(ctx, "failed to decode notification %v: %v", value.Key, err)
+			return
+		}
+		if sender == instanceID {
+			return
+		}
+		r.Publish([]Notification{n})
+	}
+
+	// The rangefeed stops on server shutdown, so it does not need to be
+	// closed.
+	tablePrefix := codec.TablePrefix(uint32(tableID))
+	if _, err := f.RangeFeed(
+		ctx,
+		"notifications",
+		[]roachpb.Span{{Key: tablePrefix, EndKey: tablePrefix.PrefixEnd()}},
+		db.KV().Clock().Now(),
+		handleEvent,
+		rangefeed.WithSystemTablePriority(),
+	); err != nil {
+		return err
+	}
+
+	return stopper.RunAsyncTask(ctx, "notifications-gc", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(gcInterval)
+			select {
+			case <-timer.C:
+				timer.Read = true
+				if err := deleteExpired(ctx, db); err != nil {
+					log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// deleteExpired deletes the rows of system.notifications older than
+// retention.
+func deleteExpired(ctx context.Context, db isql.DB) error {
+	for {
+		n, err := db.Executor().ExecEx(
+			ctx, "delete-expired-notifications", nil /* txn */, sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE created < $1 LIMIT $2`,
+			timeutil.Now().Add(-retention), gcBatchSize,
+		)
+		if err != nil || n < gcBatchSize {
+			return err
+		}
+	}
+}
+
+// rowDecoder decodes the rows of system.notifications.
+type rowDecoder struct {
+	decoder valueside.Decoder
+}
+
+func makeRowDecoder() rowDecoder {
+	return rowDecoder{
+		decoder: valueside.MakeDecoder(systemschema.NotificationsTable.PublicColumns()),
+	}
+}
+
+// decodeRow decodes the notification stored in the given row value, along
+// with the ID of the SQL instance that sent it. All the columns the
+// notification is made of are stored in the value.
+func (d *rowDecoder) decodeRow(
+	value roachpb.Value, alloc *tree.DatumAlloc,
+) (_ Notification, sender base.SQLInstanceID, _ error) {
+	bytes, err := value.GetTuple()
+	if err != nil {
+		return Notification{}, 0, err
+	}
+	datums, err := d.decoder.Decode(alloc, bytes)
+	if err != nil {
+		return Notification{}, 0, err
+	}
+	// The columns are id, channel, payload, sender_pid, sender_instance_id and
+	// created; see systemschema.NotificationsTableSchema.
+	for _, d := range datums[1:5] {
+		if d == tree.DNull {
+			return Notification{}, 0, errors.AssertionFailedf("unexpected NULL column")
+		}
+	}
+	return Notification{
+		Channel:   string(tree.MustBeDString(datums[1])),
+		Payload:   string(tree.MustBeDString(datums[2])),
+		SenderPID: uint32(tree.MustBeDInt(datums[3])),
+	}, base.SQLInstanceID(tree.MustBeDInt(datums[4])), nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotifyAcrossNodes checks that the notifications sent by a session are
+// delivered to the sessions listening on the other nodes of the cluster.
+func TestNotifyAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const numNodes = 3
+	tc := testcluster.StartTestCluster(t, numNodes, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	// Notifications are handled synchronously while a listener executes a
+	// statement, so there is no need to synchronize access to received.
+	received := make([][]string, numNodes)
+	conns := make([]*pgx.Conn, numNodes)
+	for i := range conns {
+		i := i
+		pgURL, cleanup := sqlutils.PGUrl(
+			t, tc.Server(i).ApplicationLayer().AdvSQLAddr(), "TestNotifyAcrossNodes", url.User(username.RootUser),
+		)
+		defer cleanup()
+		cfg, err := pgx.ParseConfig(pgURL.String())
+		require.NoError(t, err)
+		cfg.OnNotification = func(_ *pgconn.PgConn, n *pgconn.Notification) {
+			received[i] = append(received[i], fmt.Sprintf("%s:%s", n.Channel, n.Payload))
+		}
+		conns[i], err = pgx.ConnectConfig(ctx, cfg)
+		require.NoError(t, err)
+		defer func(conn *pgx.Conn) { _ = conn.Close(ctx) }(conns[i])
+	}
+
+	_, err := conns[0].Exec(ctx, "LISTEN jobs")
+	require.NoError(t, err)
+	_, err = conns[1].Exec(ctx, "LISTEN jobs")
+	require.NoError(t, err)
+
+	// Notify from the last node, whose session is not listening. The
+	// notifications sent before the watchers of the other nodes started are
+	// not delivered, so keep notifying until they are.
+	testutils.SucceedsSoon(t, func() error {
+		_, err := conns[2].Exec(ctx, "NOTIFY jobs, 'ping'")
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			_, err := conns[i].Exec(ctx, "SELECT 1")
+			require.NoError(t, err)
+			if len(received[i]) == 0 {
+				return errors.Newf("node %d did not receive the notification", i+1)
+			}
+		}
+		return nil
+	})
+
+	// Now that the watchers are running, every notification is delivered to
+	// all the listeners. The notifications sent by a rolled back transaction
+	// and on the other channels are not.
+	_, err = conns[0].Exec(ctx, "NOTIFY jobs, 'a'")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "SELECT pg_notify('jobs', 'b'), pg_notify('other', 'c')")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "BEGIN; NOTIFY jobs, 'd'; ROLLBACK")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "NOTIFY jobs, 'e'")
+	require.NoError(t, err)
+	expected := []string{"jobs:a", "jobs:b", "jobs:e"}
+	for i := 0; i < 2; i++ {
+		i := i
+		var got []string
+		testutils.SucceedsSoon(t, func() error {
+			_, err := conns[i].Exec(ctx, "SELECT 1")
+			require.NoError(t, err)
+			for _, n := range received[i] {
+				// The pings sent above may still be delivered.
+				if n != "jobs:ping" {
+					got = append(got, n)
+				}
+			}
+			received[i] = nil
+			if len(got) < len(expected) {
+				return errors.Newf("node %d only received %v", i+1, got)
+			}
+			return nil
+		})
+		// Notifications sent by different nodes are not ordered.
+		sort.Strings(got)
+		require.Equal(t, expected, got, "node %d", i+1)
+	}
+	require.Empty(t, received[2])
+}
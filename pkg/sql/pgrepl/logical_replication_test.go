@@ -0,0 +1,200 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogicalReplication creates a replication slot, streams the changes
+// made to a table from it and checks that the position confirmed by the
+// client is saved in the slot.
+func TestLogicalReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	systemDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t, ""))
+	systemDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	systemDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+	systemDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.side_transport_interval = '50ms'`)
+	systemDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.closed_timestamp_refresh_interval = '50ms'`)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, b STRING)`)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.AdvSQLAddr(), "pgrepl_logical_replication_test", url.User(username.RootUser))
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	ctx := context.Background()
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	results, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Rows, 1)
+	require.Equal(t, "s", string(results[0].Rows[0][0]))
+	require.Equal(t, "pgoutput", string(results[0].Rows[0][3]))
+
+	_, err = conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, pgcode.DuplicateObject.String(), pgErr.Code)
+
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `UPDATE t SET b = 'b' WHERE a = 1`)
+	sqlDB.Exec(t, `DELETE FROM t WHERE a = 1`)
+
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'p')`,
+	})
+	require.NoError(t, fe.Flush())
+	require.NoError(t, conn.Conn().SetReadDeadline(timeutil.Now().Add(time.Minute)))
+	msg, err := fe.Receive()
+	require.NoError(t, err)
+	require.IsType(t, &pgproto3.CopyBothResponse{}, msg)
+
+	// Read the changes until the three transactions were received.
+	var changes []string
+	var commits int
+	var lastCommit lsn.LSN
+	for commits < 3 {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		data, ok := msg.(*pgproto3.CopyData)
+		require.True(t, ok, "unexpected message %#v", msg)
+		if data.Data[0] != 'w' {
+			// Keepalive.
+			continue
+		}
+		// Skip the XLogData header: the type, the start and end positions and
+		// the send time.
+		body := data.Data[1+8+8+8:]
+		switch body[0] {
+		case 'B':
+		case 'C':
+			commits++
+			lastCommit = lsn.LSN(binary.BigEndian.Uint64(body[2:10]))
+		case 'R':
+			name := body[5:]
+			// Skip the namespace.
+			name = name[strings.IndexByte(string(name), 0)+1:]
+			changes = append(changes, fmt.Sprintf("R %s", name[:strings.IndexByte(string(name), 0)]))
+		case 'I', 'U', 'D':
+			changes = append(changes, fmt.Sprintf("%c %s", body[0], decodeTuples(t, body[5:])))
+		default:
+			t.Fatalf("unexpected message type %c", body[0])
+		}
+	}
+	require.Equal(t, []string{
+		"R t",
+		"I N(1, a)",
+		"U N(1, b)",
+		"D K(1, NULL)",
+	}, changes)
+
+	// Confirm the changes and end the stream.
+	status := []byte{'r'}
+	for i := 0; i < 3; i++ {
+		status = binary.BigEndian.AppendUint64(status, uint64(lastCommit))
+	}
+	status = binary.BigEndian.AppendUint64(status, 0)
+	status = append(status, 0)
+	fe.Send(&pgproto3.CopyData{Data: status})
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	var gotCopyDone, gotCommandComplete bool
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+		case *pgproto3.CopyDone:
+			gotCopyDone = true
+		case *pgproto3.CommandComplete:
+			require.Equal(t, "START_REPLICATION", string(msg.CommandTag))
+			gotCommandComplete = true
+		case *pgproto3.ReadyForQuery:
+			done = true
+		default:
+			t.Fatalf("unexpected message %#v", msg)
+		}
+	}
+	require.True(t, gotCopyDone)
+	require.True(t, gotCommandComplete)
+
+	var confirmedFlushLSN int64
+	sqlDB.QueryRow(t,
+		`SELECT confirmed_flush_lsn FROM system.replication_slots WHERE slot_name = 's'`,
+	).Scan(&confirmedFlushLSN)
+	require.Equal(t, lastCommit, lsn.LSN(confirmedFlushLSN))
+
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT s`).ReadAll()
+	require.NoError(t, err)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM system.replication_slots`, [][]string{{"0"}})
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT s`).ReadAll()
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, pgcode.UndefinedObject.String(), pgErr.Code)
+}
+
+// decodeTuples decodes the tuples of an Insert, Update or Delete message
+// following the relation OID.
+func decodeTuples(t *testing.T, data []byte) string {
+	var tuples []string
+	for len(data) > 0 {
+		kind := data[0]
+		n := int(binary.BigEndian.Uint16(data[1:3]))
+		data = data[3:]
+		vals := make([]string, n)
+		for i := range vals {
+			switch data[0] {
+			case 'n':
+				vals[i] = "NULL"
+				data = data[1:]
+			case 't':
+				l := binary.BigEndian.Uint32(data[1:5])
+				vals[i] = string(data[5 : 5+l])
+				data = data[5+l:]
+			default:
+				t.Fatalf("unexpected tuple data kind %c", data[0])
+			}
+		}
+		tuples = append(tuples, fmt.Sprintf("%c(%s)", kind, strings.Join(vals, ", ")))
+	}
+	return strings.Join(tuples, " ")
+}
@@ -11,15 +11,45 @@
 package lsnutil
 
 import (
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
+// lsnLogicalBits is the number of low bits of an LSN used to store the
+// logical component of a HLC timestamp. The remaining high bits store the
+// wall time in microseconds, which is sufficient until the year 2540.
+const lsnLogicalBits = 10
+
+const maxLSNLogical = 1<<lsnLogicalBits - 1
+
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
+//
+// The conversion preserves ordering: if a <= b, then
+// HLCToLSN(a) <= HLCToLSN(b). It is lossy, since the wall time is truncated
+// to microseconds and the logical component is capped, so distinct
+// timestamps may map to the same LSN.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	logical := h.Logical
+	if logical > maxLSNLogical {
+		logical = maxLSNLogical
+	}
+	return lsn.LSN(h.WallTime/int64(time.Microsecond))<<lsnLogicalBits | lsn.LSN(logical)
+}
+
+// LSNToHLC converts a LSN produced by HLCToLSN back into a HLC. For any
+// timestamp h, LSNToHLC(HLCToLSN(h)) <= h, so resuming a stream of changes
+// from the returned timestamp never skips changes at or after h.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	wallTime := l >> lsnLogicalBits
+	if wallTime > math.MaxInt64/lsn.LSN(time.Microsecond) {
+		return hlc.MaxTimestamp
+	}
+	return hlc.Timestamp{
+		WallTime: int64(wallTime) * int64(time.Microsecond),
+		Logical:  int32(l & maxLSNLogical),
+	}
 }
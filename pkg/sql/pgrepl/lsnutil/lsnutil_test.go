@@ -0,0 +1,78 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lsnutil
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHLCToLSN(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		ts        hlc.Timestamp
+		expected  lsn.LSN
+		roundTrip hlc.Timestamp
+	}{
+		{
+			ts:        hlc.Timestamp{},
+			expected:  0,
+			roundTrip: hlc.Timestamp{},
+		},
+		{
+			ts:        hlc.Timestamp{WallTime: 1000, Logical: 1},
+			expected:  1<<lsnLogicalBits | 1,
+			roundTrip: hlc.Timestamp{WallTime: 1000, Logical: 1},
+		},
+		{
+			// The wall time is truncated to microseconds.
+			ts:        hlc.Timestamp{WallTime: 1999, Logical: 2},
+			expected:  1<<lsnLogicalBits | 2,
+			roundTrip: hlc.Timestamp{WallTime: 1000, Logical: 2},
+		},
+		{
+			// The logical component is capped.
+			ts:        hlc.Timestamp{WallTime: 2000, Logical: 5000},
+			expected:  2<<lsnLogicalBits | maxLSNLogical,
+			roundTrip: hlc.Timestamp{WallTime: 2000, Logical: maxLSNLogical},
+		},
+	} {
+		t.Run(tc.ts.String(), func(t *testing.T) {
+			l := HLCToLSN(tc.ts)
+			require.Equal(t, tc.expected, l)
+			rt := LSNToHLC(l)
+			require.Equal(t, tc.roundTrip, rt)
+			require.True(t, rt.LessEq(tc.ts))
+		})
+	}
+}
+
+func TestHLCToLSNOrdering(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	timestamps := []hlc.Timestamp{
+		{WallTime: 1},
+		{WallTime: 1, Logical: 1},
+		{WallTime: 999, Logical: 2000},
+		{WallTime: 1000},
+		{WallTime: 1000, Logical: 1},
+		{WallTime: 1698796800000000000},
+		{WallTime: 1698796800000000000, Logical: 3},
+	}
+	for i := 1; i < len(timestamps); i++ {
+		require.LessOrEqual(t, HLCToLSN(timestamps[i-1]), HLCToLSN(timestamps[i]))
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput encodes logical replication messages in the format
+// produced by the Postgres "pgoutput" output plugin (protocol version 1),
+// along with the streaming replication messages that carry them over the
+// COPY BOTH sub-protocol.
+//
+// See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/lib/pq/oid"
+)
+
+// ProtocolVersion is the version of the pgoutput protocol implemented by this
+// package.
+const ProtocolVersion = 1
+
+// MessageType is the first byte of a pgoutput message.
+type MessageType byte
+
+// Logical replication message types.
+const (
+	MessageBegin    MessageType = 'B'
+	MessageCommit   MessageType = 'C'
+	MessageRelation MessageType = 'R'
+	MessageInsert   MessageType = 'I'
+	MessageUpdate   MessageType = 'U'
+	MessageDelete   MessageType = 'D'
+)
+
+// Streaming replication message types, sent inside CopyData messages.
+const (
+	// MessageXLogData wraps a pgoutput message.
+	MessageXLogData byte = 'w'
+	// MessagePrimaryKeepalive is sent periodically by the server.
+	MessagePrimaryKeepalive byte = 'k'
+	// MessageStandbyStatusUpdate is sent by the client to report progress.
+	MessageStandbyStatusUpdate byte = 'r'
+)
+
+// Tuple data kinds.
+const (
+	tupleNew  byte = 'N'
+	tupleKey  byte = 'K'
+	tupleOld  byte = 'O'
+	tupleNull byte = 'n'
+	tupleText byte = 't'
+)
+
+// ReplicaIdentity describes which columns of the old row are sent for
+// UPDATE and DELETE messages.
+type ReplicaIdentity byte
+
+// Replica identity settings, mirroring pg_class.relreplident.
+const (
+	// ReplicaIdentityDefault sends the primary key columns of the old row.
+	ReplicaIdentityDefault ReplicaIdentity = 'd'
+	// ReplicaIdentityNothing sends no old row.
+	ReplicaIdentityNothing ReplicaIdentity = 'n'
+	// ReplicaIdentityFull sends all columns of the old row.
+	ReplicaIdentityFull ReplicaIdentity = 'f'
+)
+
+// columnFlagKey marks a column that is part of the replica identity key.
+const columnFlagKey byte = 1
+
+// RelationColumn describes a column of a relation.
+type RelationColumn struct {
+	Name    string
+	TypeOID oid.Oid
+	// TypeMod is the type modifier of the column, or -1 if there is none.
+	TypeMod int32
+	// IsKey is true if the column is part of the replica identity.
+	IsKey bool
+}
+
+// Relation describes a table whose changes are replicated. A Relation
+// message must be sent before the first change to the table in a stream.
+type Relation struct {
+	OID             oid.Oid
+	Namespace       string
+	Name            string
+	ReplicaIdentity ReplicaIdentity
+	Columns         []RelationColumn
+}
+
+// AppendBegin appends a Begin message to buf. finalLSN is the LSN of the
+// commit record of the transaction.
+func AppendBegin(buf []byte, finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	buf = append(buf, byte(MessageBegin))
+	buf = appendUint64(buf, uint64(finalLSN))
+	buf = appendTime(buf, commitTime)
+	return appendUint32(buf, xid)
+}
+
+// AppendCommit appends a Commit message to buf. commitLSN is the LSN of the
+// commit record and endLSN is the LSN just past it.
+func AppendCommit(buf []byte, commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	buf = append(buf, byte(MessageCommit))
+	// Flags are currently unused.
+	buf = append(buf, 0)
+	buf = appendUint64(buf, uint64(commitLSN))
+	buf = appendUint64(buf, uint64(endLSN))
+	return appendTime(buf, commitTime)
+}
+
+// AppendRelation appends a Relation message to buf.
+func AppendRelation(buf []byte, rel *Relation) []byte {
+	buf = append(buf, byte(MessageRelation))
+	buf = appendUint32(buf, uint32(rel.OID))
+	buf = appendString(buf, rel.Namespace)
+	buf = appendString(buf, rel.Name)
+	buf = append(buf, byte(rel.ReplicaIdentity))
+	buf = appendUint16(buf, uint16(len(rel.Columns)))
+	for i := range rel.Columns {
+		col := &rel.Columns[i]
+		var flags byte
+		if col.IsKey {
+			flags |= columnFlagKey
+		}
+		buf = append(buf, flags)
+		buf = appendString(buf, col.Name)
+		buf = appendUint32(buf, uint32(col.TypeOID))
+		buf = appendUint32(buf, uint32(col.TypeMod))
+	}
+	return buf
+}
+
+// AppendInsert appends an Insert message for the given new row to buf.
+func AppendInsert(buf []byte, relOID oid.Oid, newRow tree.Datums) []byte {
+	buf = append(buf, byte(MessageInsert))
+	buf = appendUint32(buf, uint32(relOID))
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, newRow)
+}
+
+// AppendUpdate appends an Update message to buf. oldRow may be nil if the
+// old row is not sent. If keyOnly is true, oldRow only contains the replica
+// identity key columns, with NULLs in place of the others.
+func AppendUpdate(
+	buf []byte, relOID oid.Oid, oldRow tree.Datums, keyOnly bool, newRow tree.Datums,
+) []byte {
+	buf = append(buf, byte(MessageUpdate))
+	buf = appendUint32(buf, uint32(relOID))
+	if oldRow != nil {
+		buf = appendOldTuple(buf, oldRow, keyOnly)
+	}
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, newRow)
+}
+
+// AppendDelete appends a Delete message for the given old row to buf. If
+// keyOnly is true, oldRow only contains the replica identity key columns,
+// with NULLs in place of the others.
+func AppendDelete(buf []byte, relOID oid.Oid, oldRow tree.Datums, keyOnly bool) []byte {
+	buf = append(buf, byte(MessageDelete))
+	buf = appendUint32(buf, uint32(relOID))
+	return appendOldTuple(buf, oldRow, keyOnly)
+}
+
+// AppendXLogData appends a streaming replication XLogData message wrapping
+// the given pgoutput message to buf. The result is meant to be sent as the
+// payload of a CopyData message.
+func AppendXLogData(buf []byte, start, end lsn.LSN, sendTime time.Time, msg []byte) []byte {
+	buf = append(buf, MessageXLogData)
+	buf = appendUint64(buf, uint64(start))
+	buf = appendUint64(buf, uint64(end))
+	buf = appendTime(buf, sendTime)
+	return append(buf, msg...)
+}
+
+// AppendPrimaryKeepalive appends a streaming replication keepalive message
+// to buf. end is the current end of the replication stream. If
+// replyRequested is set, the client should reply with a standby status
+// update as soon as possible.
+func AppendPrimaryKeepalive(
+	buf []byte, end lsn.LSN, sendTime time.Time, replyRequested bool,
+) []byte {
+	buf = append(buf, MessagePrimaryKeepalive)
+	buf = appendUint64(buf, uint64(end))
+	buf = appendTime(buf, sendTime)
+	if replyRequested {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// StandbyStatusUpdate is the progress report periodically sent by a client
+// consuming a replication stream.
+type StandbyStatusUpdate struct {
+	// WritePosition is the LSN of the last byte received by the client.
+	WritePosition lsn.LSN
+	// FlushPosition is the LSN of the last byte durably flushed by the client.
+	FlushPosition lsn.LSN
+	// ApplyPosition is the LSN of the last byte applied by the client.
+	ApplyPosition lsn.LSN
+	// ClientTime is the time at which the client sent the update.
+	ClientTime time.Time
+	// ReplyRequested is set if the client wants an immediate keepalive.
+	ReplyRequested bool
+}
+
+// standbyStatusUpdateLen is the length of a standby status update message,
+// including its type byte.
+const standbyStatusUpdateLen = 1 + 8 + 8 + 8 + 8 + 1
+
+// DecodeStandbyStatusUpdate decodes a standby status update sent by the
+// client as the payload of a CopyData message.
+func DecodeStandbyStatusUpdate(data []byte) (StandbyStatusUpdate, error) {
+	if len(data) != standbyStatusUpdateLen || data[0] != MessageStandbyStatusUpdate {
+		return StandbyStatusUpdate{}, pgwirebase.NewProtocolViolationErrorf(
+			"invalid standby status update message",
+		)
+	}
+	data = data[1:]
+	clientMicros := int64(binary.BigEndian.Uint64(data[24:32]))
+	return StandbyStatusUpdate{
+		WritePosition:  lsn.LSN(binary.BigEndian.Uint64(data[0:8])),
+		FlushPosition:  lsn.LSN(binary.BigEndian.Uint64(data[8:16])),
+		ApplyPosition:  lsn.LSN(binary.BigEndian.Uint64(data[16:24])),
+		ClientTime:     duration.AddMicros(pgwirebase.PGEpochJDate, clientMicros),
+		ReplyRequested: data[32] != 0,
+	}, nil
+}
+
+func appendOldTuple(buf []byte, oldRow tree.Datums, keyOnly bool) []byte {
+	if keyOnly {
+		buf = append(buf, tupleKey)
+	} else {
+		buf = append(buf, tupleOld)
+	}
+	return appendTuple(buf, oldRow)
+}
+
+// appendTuple appends the TupleData for the given row to buf. Values are
+// sent in the text format.
+func appendTuple(buf []byte, row tree.Datums) []byte {
+	buf = appendUint16(buf, uint16(len(row)))
+	fmtCtx := tree.NewFmtCtx(tree.FmtPgwireText)
+	defer fmtCtx.Close()
+	for _, d := range row {
+		if d == tree.DNull {
+			buf = append(buf, tupleNull)
+			continue
+		}
+		fmtCtx.Buffer.Reset()
+		fmtCtx.FormatNode(d)
+		buf = append(buf, tupleText)
+		buf = appendUint32(buf, uint32(fmtCtx.Buffer.Len()))
+		buf = append(buf, fmtCtx.Buffer.Bytes()...)
+	}
+	return buf
+}
+
+func appendUint16(buf []byte, v uint16) []byte {
+	return binary.BigEndian.AppendUint16(buf, v)
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	return binary.BigEndian.AppendUint32(buf, v)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	return binary.BigEndian.AppendUint64(buf, v)
+}
+
+// appendString appends a null-terminated string to buf.
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, s...)
+	return append(buf, 0)
+}
+
+// appendTime appends t as the number of microseconds since the Postgres
+// epoch.
+func appendTime(buf []byte, t time.Time) []byte {
+	return appendUint64(buf, uint64(duration.DiffMicros(t, pgwirebase.PGEpochJDate)))
+}
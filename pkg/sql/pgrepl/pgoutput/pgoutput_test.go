@@ -0,0 +1,192 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+// pgEpochPlus returns the Postgres epoch plus the given number of
+// microseconds.
+func pgEpochPlus(micros int64) time.Time {
+	return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(micros) * time.Microsecond)
+}
+
+func TestEncode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		name     string
+		encoded  []byte
+		expected []byte
+	}{
+		{
+			name:    "begin",
+			encoded: AppendBegin(nil, 0x0102, pgEpochPlus(0x0304), 7),
+			expected: []byte{
+				'B',
+				0, 0, 0, 0, 0, 0, 1, 2,
+				0, 0, 0, 0, 0, 0, 3, 4,
+				0, 0, 0, 7,
+			},
+		},
+		{
+			name:    "commit",
+			encoded: AppendCommit(nil, 0x10, 0x11, pgEpochPlus(1)),
+			expected: []byte{
+				'C',
+				0,
+				0, 0, 0, 0, 0, 0, 0, 0x10,
+				0, 0, 0, 0, 0, 0, 0, 0x11,
+				0, 0, 0, 0, 0, 0, 0, 1,
+			},
+		},
+		{
+			name: "relation",
+			encoded: AppendRelation(nil, &Relation{
+				OID:             104,
+				Namespace:       "public",
+				Name:            "t",
+				ReplicaIdentity: ReplicaIdentityDefault,
+				Columns: []RelationColumn{
+					{Name: "k", TypeOID: oid.T_int8, TypeMod: -1, IsKey: true},
+					{Name: "v", TypeOID: oid.T_text, TypeMod: -1},
+				},
+			}),
+			expected: []byte{
+				'R',
+				0, 0, 0, 104,
+				'p', 'u', 'b', 'l', 'i', 'c', 0,
+				't', 0,
+				'd',
+				0, 2,
+				1, 'k', 0, 0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff,
+				0, 'v', 0, 0, 0, 0, 25, 0xff, 0xff, 0xff, 0xff,
+			},
+		},
+		{
+			name:    "insert",
+			encoded: AppendInsert(nil, 104, tree.Datums{tree.NewDInt(12), tree.NewDString("ab")}),
+			expected: []byte{
+				'I',
+				0, 0, 0, 104,
+				'N',
+				0, 2,
+				't', 0, 0, 0, 2, '1', '2',
+				't', 0, 0, 0, 2, 'a', 'b',
+			},
+		},
+		{
+			name: "update with key",
+			encoded: AppendUpdate(
+				nil, 104,
+				tree.Datums{tree.NewDInt(1), tree.DNull}, true, /* keyOnly */
+				tree.Datums{tree.NewDInt(2), tree.DNull},
+			),
+			expected: []byte{
+				'U',
+				0, 0, 0, 104,
+				'K',
+				0, 2,
+				't', 0, 0, 0, 1, '1',
+				'n',
+				'N',
+				0, 2,
+				't', 0, 0, 0, 1, '2',
+				'n',
+			},
+		},
+		{
+			name:    "update without old row",
+			encoded: AppendUpdate(nil, 104, nil, false /* keyOnly */, tree.Datums{tree.DBoolTrue}),
+			expected: []byte{
+				'U',
+				0, 0, 0, 104,
+				'N',
+				0, 1,
+				't', 0, 0, 0, 1, 't',
+			},
+		},
+		{
+			name: "delete",
+			encoded: AppendDelete(
+				nil, 104, tree.Datums{tree.NewDInt(3), tree.NewDString("x")}, false, /* keyOnly */
+			),
+			expected: []byte{
+				'D',
+				0, 0, 0, 104,
+				'O',
+				0, 2,
+				't', 0, 0, 0, 1, '3',
+				't', 0, 0, 0, 1, 'x',
+			},
+		},
+		{
+			name:    "xlogdata",
+			encoded: AppendXLogData(nil, 1, 2, pgEpochPlus(3), []byte{'C'}),
+			expected: []byte{
+				'w',
+				0, 0, 0, 0, 0, 0, 0, 1,
+				0, 0, 0, 0, 0, 0, 0, 2,
+				0, 0, 0, 0, 0, 0, 0, 3,
+				'C',
+			},
+		},
+		{
+			name:    "keepalive",
+			encoded: AppendPrimaryKeepalive(nil, 9, pgEpochPlus(4), true /* replyRequested */),
+			expected: []byte{
+				'k',
+				0, 0, 0, 0, 0, 0, 0, 9,
+				0, 0, 0, 0, 0, 0, 0, 4,
+				1,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.encoded)
+		})
+	}
+}
+
+func TestDecodeStandbyStatusUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	msg := []byte{
+		'r',
+		0, 0, 0, 0, 0, 0, 0, 3,
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 5,
+		1,
+	}
+	update, err := DecodeStandbyStatusUpdate(msg)
+	require.NoError(t, err)
+	require.Equal(t, StandbyStatusUpdate{
+		WritePosition:  lsn.LSN(3),
+		FlushPosition:  lsn.LSN(2),
+		ApplyPosition:  lsn.LSN(1),
+		ClientTime:     pgEpochPlus(5),
+		ReplyRequested: true,
+	}, update)
+
+	_, err = DecodeStandbyStatusUpdate(msg[:len(msg)-1])
+	require.Error(t, err)
+	_, err = DecodeStandbyStatusUpdate(append([]byte{'x'}, msg[1:]...))
+	require.Error(t, err)
+}
@@ -53,11 +53,11 @@ func (crs *CreateReplicationSlot) Format(ctx *tree.FmtCtx) {
 }
 
 func (crs *CreateReplicationSlot) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Rows
 }
 
 func (crs *CreateReplicationSlot) StatementType() tree.StatementType {
-	return tree.TypeDDL
+	return tree.TypeDML
 }
 
 func (crs *CreateReplicationSlot) StatementTag() string {
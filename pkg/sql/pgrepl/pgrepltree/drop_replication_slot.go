@@ -32,11 +32,11 @@ func (drs *DropReplicationSlot) Format(ctx *tree.FmtCtx) {
 }
 
 func (drs *DropReplicationSlot) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Ack
 }
 
 func (drs *DropReplicationSlot) StatementType() tree.StatementType {
-	return tree.TypeDDL
+	return tree.TypeDML
 }
 
 func (drs *DropReplicationSlot) StatementTag() string {
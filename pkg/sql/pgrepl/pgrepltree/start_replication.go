@@ -59,7 +59,7 @@ func (srs *StartReplication) Format(ctx *tree.FmtCtx) {
 }
 
 func (srs *StartReplication) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Ack
 }
 
 func (srs *StartReplication) StatementType() tree.StatementType {
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -59,6 +60,7 @@ type commandResult struct {
 	// buffer contains items that are sent before the connection is closed.
 	buffer struct {
 		notices            []pgnotice.Notice
+		notifications      []pgnotify.Notification
 		paramStatusUpdates []paramStatusUpdate
 	}
 
@@ -162,6 +164,9 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 	case closeComplete:
 		r.conn.bufferCloseComplete()
 	case readyForQuery:
+		for _, notification := range r.buffer.notifications {
+			r.conn.bufferNotification(notification)
+		}
 		r.conn.bufferReadyForQuery(byte(t))
 		// The error is saved on conn.err.
 		_ /* err */ = r.conn.Flush(r.pos)
@@ -309,6 +314,11 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.SyncResult interface.
+func (r *commandResult) BufferNotification(notification pgnotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, notification)
+}
+
 // SendNotice is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	if err := r.conn.bufferNotice(ctx, notice); err != nil {
@@ -326,7 +336,7 @@ func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColum
 	}
 	r.types = make([]*types.T, len(cols))
 	for i, col := range cols {
-		r.types[i] = col.Typ
+		r.types[i] = col.Typ.DomainBaseType()
 	}
 }
 
@@ -383,6 +393,16 @@ func (r *commandResult) SendCopyData(ctx context.Context, copyData []byte, isHea
 	return nil
 }
 
+// SendCopyBoth is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBoth(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	if err := r.conn.bufferCopyBoth(); err != nil {
+		return err
+	}
+	return r.conn.Flush(r.pos)
+}
+
 // SendCopyDone is part of the pgwirebase.Conn interface.
 func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	r.assertNotReleased()
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -111,6 +112,36 @@ type conn struct {
 
 	// alwaysLogAuthActivity is used force-enables logging of authn events.
 	alwaysLogAuthActivity bool
+
+	// replicationStream is set once a START_REPLICATION command was received.
+	// It is only accessed by the network routine.
+	replicationStream *replicationStreamState
+}
+
+// replicationStreamState is the state of the START_REPLICATION command that
+// streams changes to the client.
+type replicationStreamState struct {
+	// msgs receives the copy messages sent by the client during the stream.
+	msgs chan<- sql.ReplicationClientMessage
+	// done is closed when the stream ends.
+	done <-chan struct{}
+}
+
+// forwardReplicationMessage forwards a copy message read from the client to
+// the replication stream. Messages arriving after the stream ended are
+// ignored, like copy messages arriving after a failed COPY.
+func (c *conn) forwardReplicationMessage(
+	ctx context.Context, typ pgwirebase.ClientMessageType, data []byte,
+) error {
+	msg := sql.ReplicationClientMessage{Typ: typ, Data: append([]byte(nil), data...)}
+	select {
+	case c.replicationStream.msgs <- msg:
+	case <-c.replicationStream.done:
+		c.replicationStream = nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
 }
 
 func (c *conn) setErr(err error) {
@@ -354,8 +385,33 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch ast := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem, *pgrepltree.CreateReplicationSlot, *pgrepltree.DropReplicationSlot:
+		case *pgrepltree.StartReplication:
+			if ast.Kind != pgrepltree.LogicalReplication {
+				return c.stmtBuf.Push(ctx, sql.SendError{
+					Err: unimplemented.NewWithIssueDetail(0, "physical", "physical replication is not supported"),
+				})
+			}
+			// While the changes are streamed, the client sends standby status
+			// updates in CopyData messages. The network routine keeps reading
+			// messages and forwards these ones to the stream; see serveImpl.
+			endParse := timeutil.Now()
+			msgs := make(chan sql.ReplicationClientMessage)
+			done := make(chan struct{})
+			c.replicationStream = &replicationStreamState{msgs: msgs, done: done}
+			return c.stmtBuf.Push(
+				ctx,
+				sql.StartReplication{
+					ParsedStmt:     stmt,
+					Stmt:           ast,
+					ClientMessages: msgs,
+					Done:           done,
+					TimeReceived:   timeReceived,
+					ParseStart:     startParse,
+					ParseEnd:       endParse,
+				},
+			)
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -857,7 +913,7 @@ func cookTag(
 		tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 
 	case tree.Rows:
-		if tagStr != "SHOW" && tagStr != "EXPLAIN" {
+		if tagStr != "SHOW" && tagStr != "EXPLAIN" && tagStr != "CALL" {
 			tag = append(tag, ' ')
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
@@ -1025,6 +1081,16 @@ func (c *tenantEgressCounter) GetBatchNetworkEgress(
 	return egress
 }
 
+func (c *conn) bufferNotification(notification pgnotify.Notification) {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(int32(notification.SenderPID))
+	c.msgBuilder.writeTerminatedString(notification.Channel)
+	c.msgBuilder.writeTerminatedString(notification.Payload)
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
 func (c *conn) bufferReadyForQuery(txnStatus byte) {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgReady)
 	c.msgBuilder.writeByte(txnStatus)
@@ -1190,6 +1256,14 @@ func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	return nil
 }
 
+func (c *conn) bufferCopyBoth() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	// The replication stream uses the text format and has no columns.
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferCopyDone() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
@@ -1418,6 +1492,17 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	res.bufferingDisabled = true
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
 	pgproto3 "github.com/jackc/pgproto3/v2"
 	pgx "github.com/jackc/pgx/v4"
 	"github.com/lib/pq"
@@ -1942,3 +1943,94 @@ func TestFailPrepareFailsTxn(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestListenNotify checks that notifications sent by a session are delivered
+// to the other sessions listening on their channel once the sending
+// transaction commits.
+func TestListenNotify(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv := serverutils.StartServerOnly(t, base.TestServerArgs{Insecure: true})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	host, ports, _ := net.SplitHostPort(s.AdvSQLAddr())
+	port, _ := strconv.Atoi(ports)
+	connCfg, err := pgx.ParseConfig(
+		fmt.Sprintf("postgresql://%s@%s:%d/defaultdb?sslmode=disable", username.RootUser, host, port),
+	)
+	require.NoError(t, err)
+	connCfg.TLSConfig = nil
+	connCfg.Logger = pgxTestLogger{}
+
+	// Notifications are handled synchronously while the listener executes a
+	// statement, so there is no need to synchronize access to received.
+	var received []pgconn.Notification
+	listenerCfg := connCfg.Copy()
+	listenerCfg.OnNotification = func(_ *pgconn.PgConn, n *pgconn.Notification) {
+		received = append(received, *n)
+	}
+	listener, err := pgx.ConnectConfig(ctx, listenerCfg)
+	require.NoError(t, err)
+	defer func() { _ = listener.Close(ctx) }()
+	notifier, err := pgx.ConnectConfig(ctx, connCfg)
+	require.NoError(t, err)
+	defer func() { _ = notifier.Close(ctx) }()
+
+	// poll makes the listener interact with the server, which delivers the
+	// pending notifications, and returns them.
+	poll := func() []pgconn.Notification {
+		received = nil
+		_, err := listener.Exec(ctx, "SELECT 1")
+		require.NoError(t, err)
+		return received
+	}
+
+	var pid uint32
+	require.NoError(t, notifier.QueryRow(ctx, "SELECT pg_backend_pid()").Scan(&pid))
+
+	_, err = listener.Exec(ctx, "LISTEN jobs")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY jobs, 'a'")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "SELECT pg_notify('jobs', 'b')")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY other, 'c'")
+	require.NoError(t, err)
+	require.Equal(t, []pgconn.Notification{
+		{PID: pid, Channel: "jobs", Payload: "a"},
+		{PID: pid, Channel: "jobs", Payload: "b"},
+	}, poll())
+
+	// Notifications are only delivered when the transaction commits.
+	tx, err := notifier.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY jobs, 'd'")
+	require.NoError(t, err)
+	require.Empty(t, poll())
+	require.NoError(t, tx.Commit(ctx))
+	require.Equal(t, []pgconn.Notification{{PID: pid, Channel: "jobs", Payload: "d"}}, poll())
+
+	tx, err = notifier.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "NOTIFY jobs, 'e'")
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback(ctx))
+	require.Empty(t, poll())
+
+	// LISTEN only takes effect when the transaction commits.
+	tx, err = listener.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "UNLISTEN jobs")
+	require.NoError(t, err)
+	_, err = notifier.Exec(ctx, "NOTIFY jobs, 'f'")
+	require.NoError(t, err)
+	received = nil
+	require.NoError(t, tx.Commit(ctx))
+	require.Equal(t, []pgconn.Notification{{PID: pid, Channel: "jobs", Payload: "f"}}, received)
+	_, err = notifier.Exec(ctx, "NOTIFY jobs, 'g'")
+	require.NoError(t, err)
+	require.Empty(t, poll())
+}
@@ -24,6 +24,7 @@ import (
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
@@ -38,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
@@ -319,6 +321,15 @@ func validateArrayDimensions(nDimensions int, nElements int) error {
 func DecodeDatum(
 	ctx context.Context, evalCtx *eval.Context, typ *types.T, code FormatCode, b []byte,
 ) (tree.Datum, error) {
+	if typ.TypeMeta.DomainData != nil {
+		// Decode values of a domain as values of its base type, and then check
+		// them against the constraints of the domain.
+		d, err := DecodeDatum(ctx, evalCtx, typ.DomainBaseType(), code, b)
+		if err != nil {
+			return nil, err
+		}
+		return eval.PerformCast(ctx, evalCtx, d, typ)
+	}
 	id := typ.Oid()
 	// Use a direct string pointing to b where we are sure we aren't retaining this string.
 	bs := encoding.UnsafeConvertBytesToString(b)
@@ -451,11 +462,39 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return d, nil
+		case oid.T_cidr:
+			d, err := tree.ParseDCIDR(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
+		case oid.T_macaddr:
+			d, err := tree.ParseDMacAddr(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
+		case oidext.T_macaddr8:
+			d, err := tree.ParseDMacAddr8(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
+		case oid.T_point, oid.T_lseg, oid.T_box, oid.T_path, oid.T_polygon, oid.T_line, oid.T_circle:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDGeometric(typ, bs)
 		case oid.T_jsonb, oid.T_json:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
 			}
 			return tree.ParseDJSON(bs)
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(bs)
 		case oid.T_tsquery:
 			ret, err := tsearch.ParseTSQuery(bs)
 			if err != nil {
@@ -477,6 +516,20 @@ func DecodeDatum(
 			}
 			return tree.NewDString(bs), nil
 		}
+		switch typ.Family() {
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			return d, err
+		case types.MultiRangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDMultiRangeFromString(evalCtx, bs, typ)
+			return d, err
+		}
 	case FormatBinary:
 		switch id {
 		case oid.T_record:
@@ -703,6 +756,45 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
+		case oid.T_cidr:
+			ipAddr, err := pgBinaryToIPAddr(b)
+			if err != nil {
+				return nil, err
+			}
+			if ipAddr.Network() != ipAddr {
+				return nil, NewInvalidBinaryRepresentationErrorf(
+					"invalid external \"cidr\" value: bits set to right of mask")
+			}
+			return tree.NewDCIDR(tree.DCIDR{IPAddr: ipAddr}), nil
+		case oid.T_macaddr:
+			if len(b) != 6 {
+				return nil, pgerror.Newf(pgcode.Syntax, "macaddr requires 6 bytes for binary format")
+			}
+			var m macaddr.MacAddr
+			for _, c := range b {
+				m = m<<8 | macaddr.MacAddr(c)
+			}
+			return tree.NewDMacAddr(m), nil
+		case oidext.T_macaddr8:
+			switch len(b) {
+			case 6:
+				var m macaddr.MacAddr
+				for _, c := range b {
+					m = m<<8 | macaddr.MacAddr(c)
+				}
+				return tree.NewDMacAddr8(m.ToMacAddr8()), nil
+			case 8:
+				return tree.NewDMacAddr8(macaddr.MacAddr8(binary.BigEndian.Uint64(b))), nil
+			default:
+				return nil, pgerror.Newf(pgcode.Syntax, "macaddr8 requires 6 or 8 bytes for binary format")
+			}
+		case oid.T_point, oid.T_lseg, oid.T_box, oid.T_path, oid.T_polygon, oid.T_line, oid.T_circle:
+			k, _ := tree.GeometricKind(typ)
+			s, err := pggeom.DecodeBinary(k, b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(s), nil
 		case oid.T_json:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
@@ -756,6 +848,19 @@ func DecodeDatum(
 			}
 			ba, err := bitarray.FromEncodingParts(words, lastBitsUsed)
 			return &tree.DBitArray{BitArray: ba}, err
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(encoding.UnsafeConvertBytesToString(b))
 		case oid.T_tsquery:
 			ret, err := tsearch.DecodeTSQueryPGBinary(b)
 			if err != nil {
@@ -787,6 +892,12 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b)
+			}
+			if typ.Family() == types.MultiRangeFamily {
+				return decodeBinaryMultiRange(ctx, evalCtx, typ, b)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1013,6 +1124,94 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// decodeBinaryRange decodes the Postgres binary format of a range, which is a
+// flags byte followed by the length-prefixed binary encodings of the finite
+// bounds.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, NewInvalidBinaryRepresentationErrorf("range requires a flags byte for binary format")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&PGBinaryRangeEmpty != 0 {
+		if len(b) != 0 {
+			return nil, NewInvalidBinaryRepresentationErrorf("unexpected data after range")
+		}
+		return tree.NewDEmptyRange(t), nil
+	}
+	var lower, upper tree.RangeBound
+	for _, bound := range []struct {
+		b         *tree.RangeBound
+		infinite  byte
+		inclusive byte
+	}{
+		{&lower, PGBinaryRangeLowerInfinite, PGBinaryRangeLowerInclusive},
+		{&upper, PGBinaryRangeUpperInfinite, PGBinaryRangeUpperInclusive},
+	} {
+		if flags&bound.infinite != 0 {
+			continue
+		}
+		if len(b) < 4 {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for range bound")
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if n < 0 || n > len(b) {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range bound length: %d", n)
+		}
+		val, err := DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, b[:n])
+		if err != nil {
+			return nil, err
+		}
+		b = b[n:]
+		bound.b.Val = val
+		bound.b.Inclusive = flags&bound.inclusive != 0
+	}
+	if len(b) != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("unexpected data after range")
+	}
+	return tree.NewDRange(t, lower, upper)
+}
+
+// decodeBinaryMultiRange decodes the Postgres binary format of a multirange,
+// which is the number of ranges followed by the length-prefixed binary
+// encodings of the ranges.
+func decodeBinaryMultiRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DMultiRange, error) {
+	if len(b) < 4 {
+		return nil, NewInvalidBinaryRepresentationErrorf("multirange requires a 4 byte header for binary format")
+	}
+	n := int(int32(binary.BigEndian.Uint32(b)))
+	b = b[4:]
+	if n < 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("invalid number of ranges: %d", n)
+	}
+	var ranges []*tree.DRange
+	for i := 0; i < n; i++ {
+		if len(b) < 4 {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data for multirange")
+		}
+		l := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if l < 0 || l > len(b) {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range length: %d", l)
+		}
+		r, err := decodeBinaryRange(ctx, evalCtx, t.MultiRangeContents(), b[:l])
+		if err != nil {
+			return nil, err
+		}
+		b = b[l:]
+		ranges = append(ranges, r)
+	}
+	if len(b) != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("unexpected data after multirange")
+	}
+	return tree.NewDMultiRange(t, ranges)
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tree.Datum, error) {
@@ -1117,3 +1316,17 @@ const (
 	// AF_NET + 1.
 	PGBinaryIPv6family byte = 3
 )
+
+// The flags of the Postgres binary format of ranges.
+const (
+	// PGBinaryRangeEmpty is set if the range is empty.
+	PGBinaryRangeEmpty byte = 0x01
+	// PGBinaryRangeLowerInclusive is set if the lower bound is inclusive.
+	PGBinaryRangeLowerInclusive byte = 0x02
+	// PGBinaryRangeUpperInclusive is set if the upper bound is inclusive.
+	PGBinaryRangeUpperInclusive byte = 0x04
+	// PGBinaryRangeLowerInfinite is set if the lower bound is infinite.
+	PGBinaryRangeLowerInfinite byte = 0x08
+	// PGBinaryRangeUpperInfinite is set if the upper bound is infinite.
+	PGBinaryRangeUpperInfinite byte = 0x10
+)
@@ -45,6 +45,7 @@ const (
 	ServerMsgCloseComplete        ServerMessageType = '3'
 	ServerMsgCopyInResponse       ServerMessageType = 'G'
 	ServerMsgCopyOutResponse      ServerMessageType = 'H'
+	ServerMsgCopyBothResponse     ServerMessageType = 'W'
 	ServerMsgCopyDataCommand      ServerMessageType = 'd'
 	ServerMsgCopyDoneCommand      ServerMessageType = 'c'
 	ServerMsgDataRow              ServerMessageType = 'D'
@@ -52,6 +53,7 @@ const (
 	ServerMsgErrorResponse        ServerMessageType = 'E'
 	ServerMsgNoticeResponse       ServerMessageType = 'N'
 	ServerMsgNoData               ServerMessageType = 'n'
+	ServerMsgNotificationResponse ServerMessageType = 'A'
 	ServerMsgParameterDescription ServerMessageType = 't'
 	ServerMsgParameterStatus      ServerMessageType = 'S'
 	ServerMsgParseComplete        ServerMessageType = '1'
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
@@ -58,6 +59,8 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like Postgres, describe the values of a domain using its base type.
+	t = t.DomainBaseType()
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -188,6 +191,18 @@ func writeTextDatumNotNull(
 	case *tree.DIPAddr:
 		b.writeLengthPrefixedString(v.IPAddr.String())
 
+	case *tree.DCIDR:
+		b.writeLengthPrefixedString(v.IPAddr.CIDRString())
+
+	case *tree.DMacAddr:
+		b.writeLengthPrefixedString(v.MacAddr.String())
+
+	case *tree.DMacAddr8:
+		b.writeLengthPrefixedString(v.MacAddr8.String())
+
+	case *tree.DGeometric:
+		b.writeLengthPrefixedString(v.Shape.String())
+
 	case *tree.DString:
 		writeTextString(b, string(*v), t)
 
@@ -246,6 +261,9 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Path.String())
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -263,6 +281,10 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(d)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange, *tree.DMultiRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DOid:
 		b.writeLengthPrefixedDatum(v)
 
@@ -638,40 +660,24 @@ func writeBinaryDatumNotNull(
 		writeBinaryBytes(b, v.GetBytes())
 
 	case *tree.DIPAddr:
-		// We calculate the Postgres binary format for an IPAddr. For the spec see,
-		// https://github.com/postgres/postgres/blob/81c5e46c490e2426db243eada186995da5bb0ba7/src/backend/utils/adt/network.c#L144
-		// The pgBinary encoding is as follows:
-		//  The int32 length of the following bytes.
-		//  The family byte.
-		//  The mask size byte.
-		//  A 0 byte for is_cidr. It's ignored on the postgres frontend.
-		//  The length of our IP bytes.
-		//  The IP bytes.
-		const pgIPAddrBinaryHeaderSize = 4
-		if v.Family == ipaddr.IPv4family {
-			b.putInt32(net.IPv4len + pgIPAddrBinaryHeaderSize)
-			b.writeByte(pgwirebase.PGBinaryIPv4family)
-			b.writeByte(v.Mask)
-			b.writeByte(0)
-			b.writeByte(byte(net.IPv4len))
-			err := v.Addr.WriteIPv4Bytes(b)
-			if err != nil {
-				b.setError(err)
-			}
-		} else if v.Family == ipaddr.IPv6family {
-			b.putInt32(net.IPv6len + pgIPAddrBinaryHeaderSize)
-			b.writeByte(pgwirebase.PGBinaryIPv6family)
-			b.writeByte(v.Mask)
-			b.writeByte(0)
-			b.writeByte(byte(net.IPv6len))
-			err := v.Addr.WriteIPv6Bytes(b)
-			if err != nil {
-				b.setError(err)
-			}
-		} else {
-			b.setError(errors.Errorf("error encoding inet to pgBinary: %v", v.IPAddr))
+		writeBinaryIPAddr(b, v.IPAddr, false /* isCIDR */)
+
+	case *tree.DCIDR:
+		writeBinaryIPAddr(b, v.IPAddr, true /* isCIDR */)
+
+	case *tree.DMacAddr:
+		b.putInt32(6)
+		for i := 5; i >= 0; i-- {
+			b.writeByte(byte(v.MacAddr >> (8 * i)))
 		}
 
+	case *tree.DMacAddr8:
+		b.putInt32(8)
+		b.putInt64(int64(v.MacAddr8))
+
+	case *tree.DGeometric:
+		writeBinaryBytes(b, pggeom.AppendBinary(nil, v.Shape))
+
 	case *tree.DEnum:
 		b.writeLengthPrefixedString(v.LogicalRep)
 
@@ -747,6 +753,13 @@ func writeBinaryDatumNotNull(
 		b.putInt32(int32(len(v.EWKB())))
 		b.write(v.EWKB())
 
+	case *tree.DJsonpath:
+		s := v.Path.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
 	case *tree.DTSQuery:
 		initialLen := b.Len()
 		// Reserve bytes for writing length later.
@@ -806,6 +819,32 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.writeBinaryRange(ctx, v, sessionLoc)
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
+	case *tree.DMultiRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			rangeLen := b.Len()
+			b.putInt32(int32(0))
+			b.writeBinaryRange(ctx, r, sessionLoc)
+			b.putInt32AtIndex(rangeLen, int32(b.Len()-(rangeLen+4)))
+		}
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON, t)
 
@@ -817,6 +856,77 @@ func writeBinaryDatumNotNull(
 	}
 }
 
+// writeBinaryRange writes the Postgres binary format for a range, without its
+// length prefix: a flags byte followed by the length-prefixed binary encodings
+// of the finite bounds.
+func (b *writeBuffer) writeBinaryRange(ctx context.Context, r *tree.DRange, sessionLoc *time.Location) {
+	if r.Empty {
+		b.writeByte(pgwirebase.PGBinaryRangeEmpty)
+		return
+	}
+	var flags byte
+	if r.Lower.Inclusive {
+		flags |= pgwirebase.PGBinaryRangeLowerInclusive
+	}
+	if r.Upper.Inclusive {
+		flags |= pgwirebase.PGBinaryRangeUpperInclusive
+	}
+	if r.Lower.Infinite() {
+		flags |= pgwirebase.PGBinaryRangeLowerInfinite
+	}
+	if r.Upper.Infinite() {
+		flags |= pgwirebase.PGBinaryRangeUpperInfinite
+	}
+	b.writeByte(flags)
+	for _, bound := range [2]tree.RangeBound{r.Lower, r.Upper} {
+		if !bound.Infinite() {
+			b.writeBinaryDatum(ctx, bound.Val, sessionLoc, r.Typ.RangeContents())
+		}
+	}
+}
+
+// writeBinaryIPAddr writes the Postgres binary format for an IPAddr. For the
+// spec see,
+// https://github.com/postgres/postgres/blob/81c5e46c490e2426db243eada186995da5bb0ba7/src/backend/utils/adt/network.c#L144
+// The pgBinary encoding is as follows:
+//
+//	The int32 length of the following bytes.
+//	The family byte.
+//	The mask size byte.
+//	The is_cidr byte. It's ignored on the postgres frontend.
+//	The length of our IP bytes.
+//	The IP bytes.
+func writeBinaryIPAddr(b *writeBuffer, ipAddr ipaddr.IPAddr, isCIDR bool) {
+	const pgIPAddrBinaryHeaderSize = 4
+	var cidrByte byte
+	if isCIDR {
+		cidrByte = 1
+	}
+	if ipAddr.Family == ipaddr.IPv4family {
+		b.putInt32(net.IPv4len + pgIPAddrBinaryHeaderSize)
+		b.writeByte(pgwirebase.PGBinaryIPv4family)
+		b.writeByte(ipAddr.Mask)
+		b.writeByte(cidrByte)
+		b.writeByte(byte(net.IPv4len))
+		err := ipAddr.Addr.WriteIPv4Bytes(b)
+		if err != nil {
+			b.setError(err)
+		}
+	} else if ipAddr.Family == ipaddr.IPv6family {
+		b.putInt32(net.IPv6len + pgIPAddrBinaryHeaderSize)
+		b.writeByte(pgwirebase.PGBinaryIPv6family)
+		b.writeByte(ipAddr.Mask)
+		b.writeByte(cidrByte)
+		b.writeByte(byte(net.IPv6len))
+		err := ipAddr.Addr.WriteIPv6Bytes(b)
+		if err != nil {
+			b.setError(err)
+		}
+	} else {
+		b.setError(errors.Errorf("error encoding inet to pgBinary: %v", ipAddr))
+	}
+}
+
 // writeBinaryColumnarElement is the same as writeBinaryDatum where the datum is
 // represented in a columnar element (at position rowIdx in the vector at
 // position vecIdx in vecs).
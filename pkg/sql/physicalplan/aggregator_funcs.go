@@ -83,6 +83,22 @@ type DistAggregationInfo struct {
 // index corresponding to the local stage.
 var passThroughLocalIdxs = []uint32{0}
 
+// UserDefinedDistAggregationInfo is the blueprint for planning user-defined
+// aggregates that have a combine function: the local stage computes partial
+// states, and the final stage combines them and computes the result. It is not
+// part of DistAggregationTable since it only applies to aggregates with a
+// combine function, and each stage is given the definition it evaluates as an
+// argument (see tree.UserDefinedAggregate).
+var UserDefinedDistAggregationInfo = DistAggregationInfo{
+	LocalStage: []execinfrapb.AggregatorSpec_Func{execinfrapb.UserDefined},
+	FinalStage: []FinalStageInfo{
+		{
+			Fn:        execinfrapb.UserDefined,
+			LocalIdxs: passThroughLocalIdxs,
+		},
+	},
+}
+
 // DistAggregationTable is DistAggregationInfo look-up table. Functions that
 // don't have an entry in the table are not optimized with a local stage.
 var DistAggregationTable = map[execinfrapb.AggregatorSpec_Func]DistAggregationInfo{
@@ -68,6 +68,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *showTraceNode:
 		return n.columns
+	case *callNode:
+		return n.columns
 	case *zeroNode:
 		return n.columns
 	case *deleteNode:
@@ -176,6 +178,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
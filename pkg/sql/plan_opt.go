@@ -354,13 +354,13 @@ func (opc *optPlanningCtx) reset(ctx context.Context) {
 	opc.optimizer.Init(ctx, p.EvalContext(), opc.catalog)
 	opc.flags = 0
 
-	// We only allow memo caching for SELECT/INSERT/UPDATE/DELETE. We could
+	// We only allow memo caching for SELECT/INSERT/UPDATE/DELETE/MERGE. We could
 	// support it for all statements in principle, but it would increase the
 	// surface of potential issues (conditions we need to detect to invalidate a
 	// cached memo).
 	switch p.stmt.AST.(type) {
 	case *tree.ParenSelect, *tree.Select, *tree.SelectClause, *tree.UnionClause, *tree.ValuesClause,
-		*tree.Insert, *tree.Update, *tree.Delete, *tree.CannedOptPlan:
+		*tree.Insert, *tree.Update, *tree.Delete, *tree.Merge, *tree.CannedOptPlan:
 		// If the current transaction has uncommitted DDL statements, we cannot rely
 		// on descriptor versions for detecting a "stale" memo. This is because
 		// descriptor versions are bumped at most once per transaction, even if there
@@ -518,6 +518,13 @@ func (opc *optPlanningCtx) reuseMemo(
 func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ error) {
 	prepared := opc.p.stmt.Prepared
 	p := opc.p
+	if _, isCall := p.stmt.AST.(*tree.Call); isCall &&
+		p.storedProcTxnState != nil && p.storedProcTxnState.resumeProc != nil {
+		// The CALL statement is resuming a stored procedure that executed COMMIT
+		// or ROLLBACK. The remainder of the procedure has already been planned.
+		opc.log(ctx, "resuming stored procedure")
+		return p.storedProcTxnState.resumeProc, nil
+	}
 	if opc.allowMemoReuse && prepared != nil && prepared.Memo != nil {
 		// We are executing a previously prepared statement and a reusable memo is
 		// available.
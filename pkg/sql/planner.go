@@ -200,6 +200,12 @@ type planner struct {
 
 	monitor *mon.BytesMonitor
 
+	// sessionMon is the session-level memory monitor, if the planner is
+	// associated with a connExecutor. It tracks memory for objects that may
+	// outlive the monitor of the transaction, like the rows of a cursor opened
+	// by a PL/pgSQL routine.
+	sessionMon *mon.BytesMonitor
+
 	// Corresponding Statement for this query.
 	stmt Statement
 
@@ -229,6 +235,28 @@ type planner struct {
 
 	createdSequences createdSequences
 
+	notifications sessionNotifications
+
+	// deferredConstraints holds the state of the DEFERRABLE constraints in the
+	// current transaction. It is nil when the planner is not associated with a
+	// connExecutor, in which case all constraints are checked immediately.
+	deferredConstraints *txnDeferredConstraints
+
+	// storedProcTxnState tracks the COMMIT and ROLLBACK statements executed by
+	// a stored procedure. It is nil when the planner is not associated with a
+	// connExecutor, in which case transaction control is not permitted.
+	storedProcTxnState *storedProcTxnState
+
+	// advisoryLocks tracks the advisory locks held by the session. It is nil
+	// when the planner is not associated with a connExecutor, in which case
+	// advisory locks cannot be acquired.
+	advisoryLocks *advisoryLocks
+
+	// routineResultBuffers maps from the ID of each set-returning PL/pgSQL
+	// routine that is currently executing to the buffer that collects the rows
+	// it returns with RETURN NEXT and RETURN QUERY.
+	routineResultBuffers map[tree.RoutineResultBufferID]*routineResultBuffer
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
@@ -456,6 +484,7 @@ func newInternalPlanner(
 	p.sqlCursors = emptySqlCursors{}
 	p.preparedStatements = emptyPreparedStatements{}
 	p.createdSequences = emptyCreatedSequences{}
+	p.notifications = emptySessionNotifications{}
 
 	p.schemaResolver.descCollection = p.Descriptors()
 	p.schemaResolver.sessionDataStack = sds
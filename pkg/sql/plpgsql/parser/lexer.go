@@ -246,54 +246,167 @@ func (l *lexer) MakeDynamicExecuteStmt() *plpgsqltree.DynamicExecute {
 	return ret
 }
 
-func (l *lexer) ProcessForOpenCursor(nullCursorExplicitExpr bool) *plpgsqltree.Open {
-	openStmt := &plpgsqltree.Open{}
-	openStmt.CursorOptions = plpgsqltree.CursorOptionFastPlan.Mask()
-
-	if nullCursorExplicitExpr {
-		if l.Peek().id == NO {
-			l.lastPos++
-			if l.Peek().id == SCROLL {
-				openStmt.CursorOptions |= plpgsqltree.CursorOptionNoScroll.Mask()
-				l.lastPos++
+// MakeForControl makes the statement for a FOR loop, given the loop target
+// variables. The FOR keyword, the target variables and the IN keyword have
+// already been consumed. The label and body of the loop are filled in by the
+// caller. MakeForControl returns a nil statement if the loop is over a
+// dynamic query, which is not yet supported.
+func (l *lexer) MakeForControl(target []plpgsqltree.Variable) (plpgsqltree.Statement, error) {
+	startPos, endPos, _ := l.readSQLConstruct(LOOP)
+	if endPos <= startPos || startPos <= 0 {
+		return nil, errors.New("missing expression in FOR loop")
+	}
+	// Look for the ".." that indicates an integer FOR loop.
+	reverse := l.tokens[startPos].id == REVERSE
+	dotDotPos, byPos := -1, -1
+	parenLevel := 0
+	for pos := startPos; pos < endPos; pos++ {
+		switch l.tokens[pos].id {
+		case '(', '[':
+			parenLevel++
+		case ')', ']':
+			parenLevel--
+		case DOT_DOT:
+			if parenLevel == 0 && dotDotPos == -1 {
+				dotDotPos = pos
+			}
+		case BY:
+			if parenLevel == 0 && dotDotPos != -1 && byPos == -1 {
+				byPos = pos
 			}
-		} else if l.Peek().id == SCROLL {
-			openStmt.CursorOptions |= plpgsqltree.CursorOptionScroll.Mask()
-			l.lastPos++
 		}
-
-		if l.Peek().id != FOR {
-			l.setErr(pgerror.New(pgcode.Syntax, "syntax error, expected \"FOR\""))
-			return nil
+	}
+	if dotDotPos != -1 {
+		if len(target) != 1 {
+			return nil, errors.New("integer FOR loop must have only one target variable")
+		}
+		if reverse {
+			startPos++
+		}
+		upperEndPos := endPos
+		if byPos != -1 {
+			upperEndPos = byPos
+		}
+		lower, err := l.ParseExpr(l.getStr(startPos, dotDotPos))
+		if err != nil {
+			return nil, err
+		}
+		upper, err := l.ParseExpr(l.getStr(dotDotPos+1, upperEndPos))
+		if err != nil {
+			return nil, err
+		}
+		var step plpgsqltree.Expr
+		if byPos != -1 {
+			if step, err = l.ParseExpr(l.getStr(byPos+1, endPos)); err != nil {
+				return nil, err
+			}
 		}
+		return &plpgsqltree.ForInt{
+			Var:     target[0],
+			Lower:   lower,
+			Upper:   upper,
+			Step:    step,
+			Reverse: reverse,
+		}, nil
+	}
+	if reverse {
+		return nil, errors.New("cannot specify REVERSE in query FOR loop")
+	}
+	switch l.tokens[startPos].id {
+	case EXECUTE:
+		return nil, nil
+	case IDENT:
+		if endPos == startPos+1 {
+			// A single identifier names a bound cursor.
+			return &plpgsqltree.ForCursor{
+				ForQuery: plpgsqltree.ForQuery{Target: target},
+				CurVar:   plpgsqltree.Variable(l.tokens[startPos].str),
+			}, nil
+		}
+		if l.tokens[startPos+1].id == '(' {
+			return nil, unimp.New("cursor arguments", "cursor arguments are not yet supported")
+		}
+	}
+	stmt, err := l.ParseSqlStmt(l.getStr(startPos, endPos))
+	if err != nil {
+		return nil, err
+	}
+	return &plpgsqltree.ForSelect{
+		ForQuery: plpgsqltree.ForQuery{Target: target},
+		Query:    stmt,
+	}, nil
+}
 
-		l.lastPos++
-		if l.Peek().id == EXECUTE {
+// MakeFetchOrMoveStmt makes a Fetch node for a FETCH or MOVE statement. The
+// FETCH or MOVE keyword has already been consumed. The cursor direction is
+// parsed by the SQL parser, and the INTO targets are read for FETCH.
+func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error) {
+	startPos, endPos, terminator := l.readSQLConstruct(INTO, ';')
+	if endPos <= startPos || startPos <= 0 {
+		return nil, errors.New("missing cursor name")
+	}
+	var cursor tree.CursorStmt
+	if isMove {
+		stmt, err := l.ParseSqlStmt("MOVE " + l.getStr(startPos, endPos))
+		if err != nil {
+			return nil, err
+		}
+		cursor = stmt.(*tree.MoveCursor).CursorStmt
+	} else {
+		stmt, err := l.ParseSqlStmt("FETCH " + l.getStr(startPos, endPos))
+		if err != nil {
+			return nil, err
+		}
+		cursor = stmt.(*tree.FetchCursor).CursorStmt
+		switch cursor.FetchType {
+		case tree.FetchAll, tree.FetchBackwardAll:
+			return nil, pgerror.New(pgcode.Syntax, "FETCH statement cannot return multiple rows")
+		case tree.FetchNormal:
+			if cursor.Count > 1 || cursor.Count < -1 {
+				return nil, pgerror.New(pgcode.Syntax, "FETCH statement cannot return multiple rows")
+			}
+		}
+	}
+	// Move to the terminator.
+	l.lastPos++
+	var target []plpgsqltree.Variable
+	if terminator == INTO {
+		if isMove {
+			return nil, pgerror.New(pgcode.Syntax, "MOVE statement cannot have an INTO target")
+		}
+		// Read in one or more comma-separated variables as the INTO target.
+		for {
 			l.lastPos++
-			dynamicQuery, endToken := l.ReadSqlExpressionStr2(USING, ';')
-			openStmt.DynamicQuery = dynamicQuery
+			tok := l.lastToken()
+			if tok.id != IDENT {
+				return nil, errors.Newf("\"%s\" is not a scalar variable", tok.str)
+			}
+			target = append(target, plpgsqltree.Variable(tok.str))
 			l.lastPos++
-			if endToken == USING {
-				// Continue reading for params for the sql expression till the ending
-				// token is not a comma.
-				openStmt.Params = make([]string, 0)
-				for {
-					param, endToken := l.ReadSqlExpressionStr2(',', ';')
-					openStmt.Params = append(openStmt.Params, param)
-					if endToken != ',' {
-						break
-					}
-					l.lastPos++
-				}
+			if tok = l.lastToken(); tok.id == ';' {
+				break
+			} else if tok.id != ',' {
+				return nil, pgerror.New(pgcode.Syntax, "syntax error, expected \";\"")
 			}
-		} else {
-			openStmt.Query = l.ReadSqlExpressionStr(';')
 		}
-	} else {
-		// read_cursor_args()
-		openStmt.ArgQuery = "hello"
+	} else if !isMove {
+		return nil, pgerror.New(pgcode.Syntax, "syntax error, expected \"INTO\"")
 	}
-	return openStmt
+	return &plpgsqltree.Fetch{
+		Cursor: cursor,
+		Target: target,
+		IsMove: isMove,
+	}, nil
+}
+
+// ReadSqlStatement reads the SQL statement that ends with the given terminator
+// and parses it. The terminator is not consumed.
+func (l *lexer) ReadSqlStatement(terminator int) (tree.Statement, error) {
+	sqlStr := l.ReadSqlExpressionStr(terminator)
+	if sqlStr == "" {
+		return nil, errors.New("expected SQL statement")
+	}
+	return l.ParseSqlStmt(sqlStr)
 }
 
 // ReadSqlExpressionStr returns the string from the l.lastPos till it sees
@@ -306,6 +419,20 @@ func (l *lexer) ReadSqlExpressionStr(terminator int) (sqlStr string) {
 	return sqlStr
 }
 
+// ReadReturnExpressionStr returns the SQL string for the expression of a
+// RETURN statement, or the empty string if the statement has no expression.
+func (l *lexer) ReadReturnExpressionStr() string {
+	next := l.Peek()
+	if l.parser.Lookahead() != -1 && l.lastPos < len(l.tokens) {
+		// The parser has already consumed the next token.
+		next = l.tokens[l.lastPos]
+	}
+	if next.id == ';' {
+		return ""
+	}
+	return l.ReadSqlExpressionStr(';')
+}
+
 func (l *lexer) ReadSqlExpressionStr2(
 	terminator1 int, terminator2 int,
 ) (sqlStr string, terminatorMet int) {
@@ -380,26 +507,6 @@ func (l *lexer) getStr(startPos, endPos int) string {
 	return l.in[start:end]
 }
 
-func (l *lexer) ProcessQueryForCursorWithoutExplicitExpr(openStmt *plpgsqltree.Open) {
-	l.lastPos++
-	if int(l.Peek().id) == EXECUTE {
-		dynamicQuery, endToken := l.ReadSqlExpressionStr2(USING, ';')
-		openStmt.DynamicQuery = dynamicQuery
-		if endToken == USING {
-			var expr string
-			for {
-				expr, endToken = l.ReadSqlExpressionStr2(',', ';')
-				openStmt.Params = append(openStmt.Params, expr)
-				if endToken != ',' {
-					break
-				}
-			}
-		}
-	} else {
-		openStmt.Query = l.ReadSqlExpressionStr(';')
-	}
-}
-
 // Peek peeks
 func (l *lexer) Peek() plpgsqlSymType {
 	if l.lastPos+1 < len(l.tokens) {
@@ -479,3 +586,12 @@ func (l *lexer) GetTypeFromValidSQLSyntax(sqlStr string) (tree.ResolvableTypeRef
 func (l *lexer) ParseExpr(sqlStr string) (plpgsqltree.Expr, error) {
 	return parser.ParseExpr(sqlStr)
 }
+
+// ParseSqlStmt parses the given string as a single SQL statement.
+func (l *lexer) ParseSqlStmt(sqlStr string) (tree.Statement, error) {
+	stmt, err := parser.ParseOne(sqlStr)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.AST, nil
+}
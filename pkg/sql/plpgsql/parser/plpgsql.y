@@ -118,8 +118,16 @@ func (u *plpgsqlSymUnion) elseIf() []plpgsqltree.ElseIf {
     return u.val.([]plpgsqltree.ElseIf)
 }
 
-func (u *plpgsqlSymUnion) open() *plpgsqltree.Open {
-    return u.val.(*plpgsqltree.Open)
+func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
+    return u.val.(tree.Statement)
+}
+
+func (u *plpgsqlSymUnion) cursorScrollOption() tree.CursorScrollOption {
+    return u.val.(tree.CursorScrollOption)
+}
+
+func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
+    return u.val.([]plpgsqltree.Variable)
 }
 
 func (u *plpgsqlSymUnion) expr() plpgsqltree.Expr {
@@ -133,12 +141,15 @@ func (u *plpgsqlSymUnion) exprs() []plpgsqltree.Expr {
     return u.val.([]plpgsqltree.Expr)
 }
 
-func (u *plpgsqlSymUnion) declaration() *plpgsqltree.Declaration {
-    return u.val.(*plpgsqltree.Declaration)
+func (u *plpgsqlSymUnion) declaration() plpgsqltree.Statement {
+    if u.val == nil {
+      return nil
+    }
+    return u.val.(plpgsqltree.Statement)
 }
 
-func (u *plpgsqlSymUnion) declarations() []plpgsqltree.Declaration {
-    return u.val.([]plpgsqltree.Declaration)
+func (u *plpgsqlSymUnion) declarations() []plpgsqltree.Statement {
+    return u.val.([]plpgsqltree.Statement)
 }
 
 func (u *plpgsqlSymUnion) raiseOption() *plpgsqltree.RaiseOption {
@@ -311,21 +322,21 @@ func (u *plpgsqlSymUnion) conditions() []plpgsqltree.Condition {
 
 %type <str> decl_varname decl_defkey
 %type <bool>	decl_const decl_notnull
-%type <plpgsqltree.Expr>	decl_defval decl_cursor_query
+%type <plpgsqltree.Expr>	decl_defval
 %type <tree.ResolvableTypeReference>	decl_datatype
 %type <str>		decl_collate
 
-%type <*plpgsqltree.Open> open_stmt_processor
+%type <tree.Statement> stmt_until_semi
 %type <str>	expr_until_semi expr_until_paren
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.Expr>	opt_exitcond
 
-%type <forvariable>	for_variable
+%type <[]plpgsqltree.Variable>	for_variable
 %type <plpgsqltree.Expr>	return_variable
-%type <*tree.NumVal>	foreach_slice
+%type <int32>	foreach_slice
 %type <plpgsqltree.Statement>	for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label cursor_variable
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
@@ -340,8 +351,8 @@ func (u *plpgsqlSymUnion) conditions() []plpgsqltree.Condition {
 %type <plpgsqltree.Statement>	stmt_commit stmt_rollback
 %type <plpgsqltree.Statement>	stmt_case stmt_foreach_a
 
-%type <*plpgsqltree.Declaration> decl_stmt decl_statement
-%type <[]plpgsqltree.Declaration> decl_sect opt_decl_stmts decl_stmts
+%type <plpgsqltree.Statement> decl_stmt decl_statement
+%type <[]plpgsqltree.Statement> decl_sect opt_decl_stmts decl_stmts
 
 %type <[]plpgsqltree.Exception> exception_sect proc_exceptions
 %type <*plpgsqltree.Exception>	proc_exception
@@ -362,11 +373,9 @@ func (u *plpgsqlSymUnion) conditions() []plpgsqltree.Condition {
 %type <plpgsqltree.Expr> format_expr
 %type <[]plpgsqltree.Expr> opt_format_exprs format_exprs
 
-%type <uint32>	opt_scrollable
-
-%type <*plpgsqltree.Fetch>	opt_fetch_direction
+%type <tree.CursorScrollOption>	opt_scrollable
 
-%type <*tree.NumVal>	opt_transaction_chain
+%type <bool>	opt_transaction_chain
 
 %type <str>	unreserved_keyword
 %%
@@ -399,7 +408,7 @@ decl_sect: DECLARE opt_decl_stmts
 | /* EMPTY */
   {
     // Use a nil slice to indicate DECLARE was not used.
-    $$.val = []plpgsqltree.Declaration(nil)
+    $$.val = []plpgsqltree.Statement(nil)
   }
 ;
 
@@ -409,7 +418,7 @@ opt_decl_stmts: decl_stmts
   }
 | /* EMPTY */
   {
-    $$.val = []plpgsqltree.Declaration{}
+    $$.val = []plpgsqltree.Statement{}
   }
 ;
 
@@ -420,16 +429,16 @@ decl_stmts: decl_stmts decl_stmt
     if dec == nil {
       $$.val = decs
     } else {
-      $$.val = append(decs, *dec)
+      $$.val = append(decs, dec)
     }
   }
 | decl_stmt
   {
     dec := $1.declaration()
     if dec == nil {
-      $$.val = []plpgsqltree.Declaration{}
+      $$.val = []plpgsqltree.Statement{}
     } else {
-      $$.val = []plpgsqltree.Declaration{*dec}
+      $$.val = []plpgsqltree.Statement{dec}
     }
 	}
 ;
@@ -441,7 +450,7 @@ decl_stmt	: decl_statement
 | DECLARE
   {
     // This is to allow useless extra "DECLARE" keywords in the declare section.
-    $$.val = (*plpgsqltree.Declaration)(nil)
+    $$.val = (plpgsqltree.Statement)(nil)
   }
 // TODO(chengxiong): turn this block on and throw useful error if user
 // tries to put the block label just before BEGIN instead of before
@@ -466,50 +475,36 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
   {
     return unimplemented(plpgsqllex, "alias for")
   }
-| decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for decl_cursor_query ';'
+| decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = &plpgsqltree.CursorDeclaration{
+      Name: plpgsqltree.Variable($1),
+      Scroll: $2.cursorScrollOption(),
+      Query: $6.sqlStatement(),
+    }
   }
 ;
 
 opt_scrollable:
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = tree.UnspecifiedScroll
   }
 | NO_SCROLL SCROLL
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = tree.NoScroll
   }
 | SCROLL
   {
-    return unimplemented(plpgsqllex, "cursor")
-  }
-;
-
-decl_cursor_query:
-  {
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
+    $$.val = tree.Scroll
   }
 ;
 
 decl_cursor_args:
   {
   }
-| '(' decl_cursor_arglist ')'
-  {
-  }
-;
-
-decl_cursor_arglist: decl_cursor_arg
-  {
-  }
-| decl_cursor_arglist ',' decl_cursor_arg
-  {
-  }
-;
-
-decl_cursor_arg: decl_varname decl_datatype
+| '('
   {
+    return unimplemented(plpgsqllex, "cursor arguments")
   }
 ;
 
@@ -645,11 +640,17 @@ proc_stmt:pl_block ';'
     $$.val = $1.statement()
   }
 | stmt_while
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_for
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_foreach_a
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_exit
   {
     $$.val = $1.statement()
@@ -679,34 +680,55 @@ proc_stmt:pl_block ';'
     $$.val = $1.statement()
   }
 | stmt_perform
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_call
   {
     $$.val = $1.statement()
   }
 | stmt_getdiag
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_open
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_fetch
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_move
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_close
   {
     $$.val = $1.statement()
   }
 | stmt_null
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_commit
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_rollback
-  { }
+  {
+    $$.val = $1.statement()
+  }
 ;
 
 stmt_perform: PERFORM expr_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    // PERFORM is equivalent to a SELECT statement whose result is discarded.
+    stmt, err := plpgsqllex.(*lexer).ParseSqlStmt("SELECT " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Perform{Query: stmt}
   }
 ;
 
@@ -737,6 +759,18 @@ stmt_assign: IDENT assign_operator expr_until_semi ';'
       Value: expr,
     }
   }
+| IDENT '.' IDENT assign_operator expr_until_semi ';'
+  {
+    expr, err := plpgsqllex.(*lexer).ParseExpr($5)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Assignment{
+      Var: plpgsqltree.Variable($1),
+      Field: tree.Name($3),
+      Value: expr,
+    }
+  }
 ;
 
 stmt_getdiag: GET getdiag_area_opt DIAGNOSTICS getdiag_list ';'
@@ -952,54 +986,79 @@ stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    body := $5.statements()
+    switch t := $3.statement().(type) {
+    case *plpgsqltree.ForInt:
+      t.Label, t.Body = $1, body
+    case *plpgsqltree.ForSelect:
+      t.Label, t.Body = $1, body
+    case *plpgsqltree.ForCursor:
+      t.Label, t.Body = $1, body
+    }
+    $$.val = $3.statement()
   }
 ;
 
 for_control: for_variable IN
-  // TODO need to parse the sql expression here.
   {
-    return unimplemented(plpgsqllex, "for loop")
+    stmt, err := plpgsqllex.(*lexer).MakeForControl($1.variables())
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if stmt == nil {
+      // FOR loops over dynamic queries are not yet supported.
+      return unimplemented(plpgsqllex, "for loop over dynamic query")
+    }
+    $$.val = stmt
   }
 ;
 
 /*
- * Processing the for_variable is tricky because we don't yet know if the
- * FOR is an integer FOR loop or a loop over query results.  In the former
- * case, the variable is just a name that we must instantiate as a loop
- * local variable, regardless of any other definition it might have.
- * Therefore, we always save the actual identifier into $$.name where it
- * can be used for that case.  We also save the outer-variable definition,
- * if any, because that's what we need for the loop-over-query case.  Note
- * that we must NOT apply check_assignable() or any other semantic check
- * until we know what's what.
- *
- * However, if we see a comma-separated list of names, we know that it
- * can't be an integer FOR loop and so it's OK to check the variables
- * immediately.  In particular, for T_WORD followed by comma, we should
- * complain that the name is not known rather than say it's a syntax error.
- * Note that the non-error result of this case sets *both* $$.scalar and
- * $$.row; see the for_control production.
+ * Unlike Postgres, we do not know at parse time which identifiers refer to
+ * variables, so the loop variables are collected as a list of names here.
+ * Whether the FOR loop is an integer loop or a loop over query results is
+ * determined by MakeForControl, and the loop variables are checked during
+ * function creation.
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.Variable{plpgsqltree.Variable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.variables(), plpgsqltree.Variable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH any_identifier foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: $1,
+      Var: plpgsqltree.Variable($3),
+      Slice: int($4.int32()),
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = int32(0)
   }
 | SLICE ICONST
   {
+    slice, err := $2.numVal().AsInt32()
+    if err != nil || slice < 0 {
+      return setErr(plpgsqllex, errors.New("SLICE must be a non-negative integer"))
+    }
+    $$.val = slice
   }
 ;
 
@@ -1035,33 +1094,36 @@ stmt_return: RETURN return_variable ';'
   }
 | RETURN_NEXT NEXT return_variable ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    $$.val = &plpgsqltree.ReturnNext{
+      Expr: $3.expr(),
+    }
   }
-| RETURN_QUERY QUERY query_options ';'
- {
-   return unimplemented (plpgsqllex, "return query")
- }
-;
-
-
-query_options:
+| RETURN_QUERY QUERY EXECUTE
   {
-    _, terminator := plpgsqllex.(*lexer).ReadSqlExpressionStr2(EXECUTE, ';')
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
+    return unimplemented(plpgsqllex, "return dynamic sql query")
+  }
+| RETURN_QUERY QUERY stmt_until_semi ';'
+  {
+    $$.val = &plpgsqltree.ReturnQuery{
+      Query: $3.sqlStatement(),
     }
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
   }
 ;
 
 
-return_variable: expr_until_semi
+return_variable:
   {
-    expr, err := plpgsqllex.(*lexer).ParseExpr($1)
-    if err != nil {
-      return setErr(plpgsqllex, err)
+    sqlStr := plpgsqllex.(*lexer).ReadReturnExpressionStr()
+    if sqlStr == "" {
+      // A RETURN statement without an expression is allowed in procedures.
+      $$.val = (plpgsqltree.Expr)(nil)
+    } else {
+      expr, err := plpgsqllex.(*lexer).ParseExpr(sqlStr)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+      $$.val = expr
     }
-    $$.val = expr
   }
 ;
 
@@ -1247,35 +1309,51 @@ stmt_dynexecute: EXECUTE
   }
 ;
 
-// TODO: change expr_until_semi to process_cursor_before_semi
-stmt_open: OPEN IDENT open_stmt_processor ';'
+stmt_open: OPEN cursor_variable ';'
   {
-    openCursorStmt := $3.open()
-    openCursorStmt.CursorName = $2
-    $$.val = openCursorStmt
+    $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
   }
-;
-
-stmt_fetch: FETCH opt_fetch_direction IDENT INTO
+| OPEN cursor_variable '('
+  {
+    return unimplemented(plpgsqllex, "cursor arguments")
+  }
+| OPEN cursor_variable opt_scrollable FOR EXECUTE
   {
-    return unimplemented(plpgsqllex, "fetch")
+    return unimplemented(plpgsqllex, "cursor for a dynamic query")
+  }
+| OPEN cursor_variable opt_scrollable FOR stmt_until_semi ';'
+  {
+    $$.val = &plpgsqltree.Open{
+      CurVar: plpgsqltree.Variable($2),
+      Scroll: $3.cursorScrollOption(),
+      Query: $5.sqlStatement(),
+    }
   }
 ;
 
-stmt_move: MOVE opt_fetch_direction IDENT ';'
+stmt_fetch: FETCH
   {
-    return unimplemented(plpgsqllex, "move")
+    stmt, err := plpgsqllex.(*lexer).MakeFetchOrMoveStmt(false /* isMove */)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
-opt_fetch_direction:
+stmt_move: MOVE
   {
-      return unimplemented(plpgsqllex, "fetch direction")
+    stmt, err := plpgsqllex.(*lexer).MakeFetchOrMoveStmt(true /* isMove */)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
+;
 
 stmt_close: CLOSE cursor_variable ';'
   {
-    $$.val = &plpgsqltree.Close{}
+    $$.val = &plpgsqltree.Close{CurVar: plpgsqltree.Variable($2)}
   }
 ;
 
@@ -1287,28 +1365,31 @@ stmt_null: NULL ';'
 
 stmt_commit: COMMIT opt_transaction_chain ';'
   {
-    return unimplemented(plpgsqllex, "commit")
+    $$.val = &plpgsqltree.Commit{Chain: $2.bool()}
   }
 ;
 
 stmt_rollback: ROLLBACK opt_transaction_chain ';'
   {
-    return unimplemented(plpgsqllex, "rollback")
+    $$.val = &plpgsqltree.Rollback{Chain: $2.bool()}
   }
 ;
 
 opt_transaction_chain:
 AND CHAIN
-  { }
+  {
+    $$.val = true
+  }
 | AND NO CHAIN
-  { }
+  {
+    $$.val = false
+  }
 | /* EMPTY */
-  { }
-
-cursor_variable: IDENT
   {
-    unimplemented(plpgsqllex, "cursor variable")
+    $$.val = false
   }
+
+cursor_variable: IDENT
 ;
 
 exception_sect: /* EMPTY */
@@ -1364,10 +1445,15 @@ proc_condition: any_identifier
   }
 ;
 
-open_stmt_processor:
+stmt_until_semi:
   {
-	  $$.val = plpgsqllex.(*lexer).ProcessForOpenCursor(true)
+    stmt, err := plpgsqllex.(*lexer).ReadSqlStatement(';')
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
+;
 
 expr_until_semi:
   {
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geogen"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -29,6 +30,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -49,6 +52,30 @@ func RandDatum(rng *rand.Rand, typ *types.T, nullOk bool) tree.Datum {
 		false /* favorCommonData */, false /* targetColumnIsUnique */)
 }
 
+// randRange generates a random range of the given range type.
+func randRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewDEmptyRange(typ)
+	}
+	var bounds [2]tree.RangeBound
+	for i := range bounds {
+		if rng.Intn(5) != 0 {
+			bounds[i].Val = RandDatum(rng, typ.RangeContents(), false /* nullOk */)
+			bounds[i].Inclusive = rng.Intn(2) == 0
+		}
+	}
+	if r, err := tree.NewDRange(typ, bounds[0], bounds[1]); err == nil {
+		return r
+	}
+	// The lower bound may be greater than the upper bound.
+	bounds[0].Val, bounds[1].Val = bounds[1].Val, bounds[0].Val
+	if r, err := tree.NewDRange(typ, bounds[0], bounds[1]); err == nil {
+		return r
+	}
+	// The bounds of discrete ranges may overflow when they are canonicalized.
+	return tree.NewDEmptyRange(typ)
+}
+
 // NullChance returns `n` representing a 1 out of `n` probability of generating
 // nulls, depending on whether `nullOk` is true.
 func NullChance(nullOk bool) (nullChance int) {
@@ -183,6 +210,13 @@ func RandDatumWithNullChance(
 	case types.INetFamily:
 		ipAddr := ipaddr.RandIPAddr(rng)
 		return tree.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr})
+	case types.CIDRFamily:
+		ipAddr := ipaddr.RandIPAddr(rng)
+		return tree.NewDCIDR(tree.DCIDR{IPAddr: ipAddr.Network()})
+	case types.MacAddrFamily:
+		return tree.NewDMacAddr(macaddr.MacAddr(rng.Uint64()) & macaddr.MaxMacAddr)
+	case types.MacAddr8Family:
+		return tree.NewDMacAddr8(macaddr.MacAddr8(rng.Uint64()))
 	case types.JsonFamily:
 		j, err := json.Random(20, rng)
 		if err != nil {
@@ -287,6 +321,24 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JsonpathFamily:
+		return tree.NewDJsonpath(jsonpath.Random(rng))
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		k, _ := tree.GeometricKind(typ)
+		return tree.NewDGeometric(pggeom.Random(rng, k))
+	case types.RangeFamily:
+		return randRange(rng, typ)
+	case types.MultiRangeFamily:
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = randRange(rng, typ.MultiRangeContents())
+		}
+		m, err := tree.NewDMultiRange(typ, ranges)
+		if err != nil {
+			panic(err)
+		}
+		return m
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
@@ -713,6 +765,19 @@ var (
 			tree.DMinIPAddr,
 			tree.DMaxIPAddr,
 		},
+		types.CIDRFamily: {
+			tree.DMinCIDR,
+			tree.DMaxCIDR,
+		},
+		types.MacAddrFamily: {
+			tree.NewDMacAddr(0),
+			tree.NewDMacAddr(macaddr.MaxMacAddr),
+		},
+		types.MacAddr8Family: {
+			tree.NewDMacAddr8(0),
+			tree.NewDMacAddr8(math.MaxInt64 + 1),
+			tree.NewDMacAddr8(macaddr.MaxMacAddr8),
+		},
 		types.PGLSNFamily: {
 			tree.NewDPGLSN(0),
 			tree.NewDPGLSN(math.MaxInt64),
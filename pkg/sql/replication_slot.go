@@ -0,0 +1,239 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// pgoutputPluginName is the name of the only logical decoding output plugin
+// supported by replication slots.
+const pgoutputPluginName = "pgoutput"
+
+// maxReplicationSlotNameLen is the maximum length of a replication slot name,
+// matching NAMEDATALEN-1 in Postgres.
+const maxReplicationSlotNameLen = 63
+
+// replicationSlot is a row of system.replication_slots.
+type replicationSlot struct {
+	name       string
+	plugin     string
+	databaseID descpb.ID
+	// confirmedFlushLSN is the position up to which the client has confirmed
+	// receiving changes. Streams of the slot resume after it.
+	confirmedFlushLSN lsn.LSN
+}
+
+// validateReplicationSlotName checks that the given slot name only contains
+// the characters Postgres allows.
+func validateReplicationSlotName(name string) error {
+	if name == "" {
+		return pgerror.Newf(pgcode.InvalidName, "replication slot name %q is too short", name)
+	}
+	if len(name) > maxReplicationSlotNameLen {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName,
+					"replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// getReplicationSlot reads the replication slot with the given name. It
+// returns an error with pgcode.UndefinedObject if the slot does not exist.
+func getReplicationSlot(
+	ctx context.Context, txn isql.Txn, kvTxn *kv.Txn, name string,
+) (replicationSlot, error) {
+	row, err := txn.QueryRowEx(
+		ctx, "get-replication-slot", kvTxn, sessiondata.NodeUserSessionDataOverride,
+		`SELECT plugin, database_id, confirmed_flush_lsn FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil {
+		return replicationSlot{}, err
+	}
+	if row == nil {
+		return replicationSlot{}, pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", name)
+	}
+	return replicationSlot{
+		name:              name,
+		plugin:            string(tree.MustBeDString(row[0])),
+		databaseID:        descpb.ID(tree.MustBeDInt(row[1])),
+		confirmedFlushLSN: lsn.LSN(tree.MustBeDInt(row[2])),
+	}, nil
+}
+
+// advanceReplicationSlot records that the client of the given slot has
+// confirmed receiving the changes up to the given position. The position
+// never moves backwards.
+func advanceReplicationSlot(
+	ctx context.Context, txn isql.Txn, name string, confirmedFlushLSN lsn.LSN,
+) error {
+	row, err := txn.QueryRowEx(
+		ctx, "advance-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`UPDATE system.replication_slots
+    SET confirmed_flush_lsn = greatest(confirmed_flush_lsn, $2)
+  WHERE slot_name = $1
+RETURNING slot_name`,
+		name, int64(confirmedFlushLSN),
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", name)
+	}
+	return nil
+}
+
+// checkLogicalReplicationConnection checks that the session was started in
+// the replication mode needed to use logical replication slots.
+func checkLogicalReplicationConnection(sd *sessiondata.SessionData) error {
+	if sd.ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE ||
+		sd.Database == "" {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	return nil
+}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	n *pgrepltree.CreateReplicationSlot
+
+	run struct {
+		consistentPoint lsn.LSN
+		done            bool
+	}
+}
+
+// CreateReplicationSlot implements the CREATE_REPLICATION_SLOT replication
+// protocol command. Only logical slots using the pgoutput plugin are
+// supported. Snapshots are not exported, so the snapshot options are ignored
+// and the snapshot_name column is always NULL.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := validateReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	if n.Temporary {
+		return nil, unimplemented.NewWithIssue(105130, "temporary replication slots are not supported")
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.NewWithIssue(105130, "physical replication slots are not supported")
+	}
+	if n.Plugin != pgoutputPluginName {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"output plugin %q is not supported; only %q is supported", n.Plugin, pgoutputPluginName)
+	}
+	if err := checkLogicalReplicationConnection(p.SessionData()); err != nil {
+		return nil, err
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	// Changes committed after the slot is created are streamed from it.
+	n.run.consistentPoint = lsnutil.HLCToLSN(p.Txn().ReadTimestamp())
+	if _, err := p.InternalSQLTxn().ExecEx(
+		params.ctx, "create-replication-slot", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots (slot_name, plugin, database_id, confirmed_flush_lsn)
+VALUES ($1, $2, $3, $4)`,
+		string(n.n.Slot), string(n.n.Plugin), int64(db.GetID()), int64(n.run.consistentPoint),
+	); err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UniqueViolation {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"replication slot %q already exists", n.n.Slot)
+		}
+		return err
+	}
+	return nil
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.run.done {
+		return false, nil
+	}
+	n.run.done = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(string(n.n.Slot)),
+		tree.NewDString(n.run.consistentPoint.String()),
+		tree.DNull, // snapshot_name
+		tree.NewDString(string(n.n.Plugin)),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+type dropReplicationSlotNode struct {
+	n *pgrepltree.DropReplicationSlot
+}
+
+// DropReplicationSlot implements the DROP_REPLICATION_SLOT replication
+// protocol command.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	rows, err := p.InternalSQLTxn().ExecEx(
+		params.ctx, "drop-replication-slot", p.Txn(), sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1`,
+		string(n.n.Slot),
+	)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", n.n.Slot)
+	}
+	return nil
+}
+
+func (n *dropReplicationSlotNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums                 { return nil }
+func (n *dropReplicationSlotNode) Close(ctx context.Context)           {}
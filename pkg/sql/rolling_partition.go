@@ -0,0 +1,152 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+// BuildRollingPartitionScheduleLabel returns the label used for the schedule
+// which maintains the partitions of a table with rolling partitioning.
+func BuildRollingPartitionScheduleLabel(tbl *tabledesc.Mutable) string {
+	return fmt.Sprintf("rolling partitions for table [%d]", tbl.GetID())
+}
+
+// newRollingPartitionScheduledJob returns a *jobs.ScheduledJob which maintains
+// the rolling partitions of the given table. It assumes that
+// tblDesc.RollingPartitioning is not nil.
+func newRollingPartitionScheduledJob(
+	env scheduledjobs.JobSchedulerEnv, owner username.SQLUsername, tblDesc *tabledesc.Mutable,
+) (*jobs.ScheduledJob, error) {
+	sj := jobs.NewScheduledJob(env)
+	sj.SetScheduleLabel(BuildRollingPartitionScheduleLabel(tblDesc))
+	sj.SetOwner(owner)
+	sj.SetScheduleDetails(jobspb.ScheduleDetails{
+		Wait: jobspb.ScheduleDetails_WAIT,
+		// If a job fails, try again at the allocated cron time.
+		OnError: jobspb.ScheduleDetails_RETRY_SCHED,
+	})
+
+	if err := sj.SetSchedule(tblDesc.RollingPartitioning.JobCronOrDefault()); err != nil {
+		return nil, err
+	}
+	args := &catpb.ScheduledRollingPartitionArgs{
+		TableID: tblDesc.GetID(),
+	}
+	any, err := pbtypes.MarshalAny(args)
+	if err != nil {
+		return nil, err
+	}
+	sj.SetExecutionDetails(
+		tree.ScheduledRollingPartitionExecutor.InternalName(),
+		jobspb.ExecutionArguments{Args: any},
+	)
+	return sj, nil
+}
+
+// CreateRollingPartitionScheduledJob creates a new rolling partition schedule.
+func CreateRollingPartitionScheduledJob(
+	ctx context.Context,
+	knobs *jobs.TestingKnobs,
+	s jobs.ScheduledJobStorage,
+	owner username.SQLUsername,
+	tblDesc *tabledesc.Mutable,
+) (*jobs.ScheduledJob, error) {
+	if !tblDesc.HasRollingPartitioning() {
+		return nil, errors.AssertionFailedf(
+			"CreateRollingPartitionScheduledJob called with no .RollingPartitioning: %#v", tblDesc,
+		)
+	}
+
+	env := JobSchedulerEnv(knobs)
+	j, err := newRollingPartitionScheduledJob(env, owner, tblDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Create(ctx, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// handleRollingPartitionStorageParamChange reconciles the rolling partition
+// schedule of the table with the updated configuration, and installs the
+// updated configuration on the descriptor.
+func handleRollingPartitionStorageParamChange(
+	params runParams, tableDesc *tabledesc.Mutable, after *catpb.RollingPartitioning,
+) (descriptorChanged bool, err error) {
+	before := tableDesc.GetRollingPartitioning()
+	if before == nil && after == nil {
+		return false, nil
+	}
+	if before != nil && after != nil && *before == *after {
+		return false, nil
+	}
+
+	switch {
+	case before == nil:
+		if err := tabledesc.ValidateRollingPartitioningColumn(tableDesc); err != nil {
+			return false, err
+		}
+		tableDesc.RollingPartitioning = after
+		j, err := CreateRollingPartitionScheduledJob(
+			params.ctx,
+			params.ExecCfg().JobsKnobs(),
+			jobs.ScheduledJobTxn(params.p.InternalSQLTxn()),
+			params.p.User(),
+			tableDesc,
+		)
+		if err != nil {
+			return false, err
+		}
+		after.ScheduleID = j.ScheduleID()
+
+	case after == nil:
+		if before.ScheduleID != 0 {
+			if err := DeleteSchedule(
+				params.ctx, params.ExecCfg(), params.p.InternalSQLTxn(), before.ScheduleID,
+			); err != nil {
+				return false, err
+			}
+		}
+		tableDesc.RollingPartitioning = nil
+
+	default:
+		after.ScheduleID = before.ScheduleID
+		if before.JobCronOrDefault() != after.JobCronOrDefault() {
+			env := JobSchedulerEnv(params.ExecCfg().JobsKnobs())
+			schedules := jobs.ScheduledJobTxn(params.p.InternalSQLTxn())
+			s, err := schedules.Load(params.ctx, env, after.ScheduleID)
+			if err != nil {
+				return false, err
+			}
+			if err := s.SetSchedule(after.JobCronOrDefault()); err != nil {
+				return false, err
+			}
+			if err := schedules.Update(params.ctx, s); err != nil {
+				return false, err
+			}
+		}
+		tableDesc.RollingPartitioning = after
+	}
+	return true, nil
+}
@@ -0,0 +1,404 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rollingpartition
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// deleteBatchSize is the number of rows removed by each DELETE statement when
+// clearing out the rows of dropped partitions.
+const deleteBatchSize = 1000
+
+type rollingPartitionResumer struct {
+	job *jobs.Job
+	st  *cluster.Settings
+}
+
+var _ jobs.Resumer = (*rollingPartitionResumer)(nil)
+
+// tableInfo is the state of the table read at the start of the job.
+type tableInfo struct {
+	tableName  *tree.TableName
+	indexName  string
+	column     string
+	columnType *types.T
+	config     catpb.RollingPartitioning
+	existing   []rangePartition
+}
+
+// Resume implements the jobs.Resumer interface.
+func (r rollingPartitionResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	jobExecCtx := execCtx.(sql.JobExecContext)
+	execCfg := jobExecCtx.ExecCfg()
+	evalCtx := &jobExecCtx.ExtendedEvalContext().Context
+	details := r.job.Details().(jobspb.RollingPartitionDetails)
+
+	var info tableInfo
+	if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		desc, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, details.TableID)
+		if err != nil {
+			return err
+		}
+		info, err = loadTableInfo(ctx, execCfg.Codec, txn, desc)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	interval, err := evalInterval(ctx, evalCtx, info.config.IntervalExpr)
+	if err != nil {
+		return err
+	}
+	if info.columnType.Family() == types.DateFamily && interval.Nanos() != 0 {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"rolling_partition_interval must be a whole number of days for DATE column %s",
+			tree.Name(info.column),
+		)
+	}
+	var retention *duration.Duration
+	if info.config.HasRetentionExpr() {
+		d, err := evalInterval(ctx, evalCtx, info.config.RetentionExpr)
+		if err != nil {
+			return err
+		}
+		retention = &d
+	}
+
+	plan, err := planWindow(
+		info.existing, details.Cutoff, interval, info.config.LookaheadOrDefault(), retention,
+	)
+	if err != nil {
+		return err
+	}
+	if !plan.changed() {
+		return nil
+	}
+
+	ie := execCfg.InternalDB.Executor()
+
+	// Remove the rows of the dropped partitions before the partitions
+	// themselves, so that a failure part way through is picked up by the next
+	// run of the job while the bounds of the dropped partitions are still known.
+	var rowsDeleted int64
+	for _, p := range plan.dropped {
+		n, err := deletePartitionRows(ctx, ie, info, p)
+		rowsDeleted += n
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt, err := buildPartitionByStmt(info, plan.partitions)
+	if err != nil {
+		return err
+	}
+	log.Infof(ctx, "rolling partitions of %s: adding %d, dropping %d",
+		info.tableName.FQString(), len(plan.added), len(plan.dropped))
+	if _, err := ie.ExecEx(
+		ctx, "rolling-partition-repartition", nil, /* txn */
+		sessiondata.RootUserSessionDataOverride, stmt,
+	); err != nil {
+		return err
+	}
+
+	if err := copyZoneConfigs(ctx, ie, info, plan); err != nil {
+		return err
+	}
+
+	return r.job.NoTxn().Update(ctx, func(
+		_ isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		progress := md.Progress
+		rp := progress.GetRollingPartition()
+		rp.PartitionsAdded = int64(len(plan.added))
+		rp.PartitionsDropped = int64(len(plan.dropped))
+		rp.RowsDeleted = rowsDeleted
+		ju.UpdateProgress(progress)
+		return nil
+	})
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r rollingPartitionResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	return nil
+}
+
+// loadTableInfo reads the rolling partitioning configuration and the existing
+// range partitions of the primary index of the table.
+func loadTableInfo(
+	ctx context.Context, codec keys.SQLCodec, txn descs.Txn, desc catalog.TableDescriptor,
+) (tableInfo, error) {
+	if !desc.HasRollingPartitioning() {
+		return tableInfo{}, errors.Newf("unable to find rolling partitioning on table %s", desc.GetName())
+	}
+	name, err := descs.GetObjectName(ctx, txn.KV(), txn.Descriptors(), desc)
+	if err != nil {
+		return tableInfo{}, errors.Wrapf(err, "error fetching table relation name for rolling partitioning")
+	}
+	idx := desc.GetPrimaryIndex()
+	col, err := catalog.MustFindColumnByID(desc, idx.GetKeyColumnID(0))
+	if err != nil {
+		return tableInfo{}, err
+	}
+	info := tableInfo{
+		tableName:  name.(*tree.TableName),
+		indexName:  idx.GetName(),
+		column:     col.GetName(),
+		columnType: col.GetType(),
+		config:     *desc.GetRollingPartitioning(),
+	}
+
+	part := idx.GetPartitioning()
+	if part.NumColumns() == 0 {
+		return info, nil
+	}
+	if part.NumColumns() != 1 || part.NumLists() > 0 {
+		return tableInfo{}, pgerror.Newf(
+			pgcode.ObjectNotInPrerequisiteState,
+			"table %s must be partitioned by RANGE over %s only to use rolling partitioning",
+			tree.Name(desc.GetName()), tree.Name(col.GetName()),
+		)
+	}
+	var a tree.DatumAlloc
+	decodeBound := func(name string, b []byte) (time.Time, error) {
+		tuple, _, err := rowenc.DecodePartitionTuple(&a, codec, desc, idx, part, b, nil /* prefixDatums */)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if tuple.SpecialCount > 0 || len(tuple.Datums) != 1 {
+			return time.Time{}, pgerror.Newf(
+				pgcode.ObjectNotInPrerequisiteState,
+				"partition %s of table %s cannot use MINVALUE or MAXVALUE with rolling partitioning",
+				tree.Name(name), tree.Name(desc.GetName()),
+			)
+		}
+		return datumToTime(tuple.Datums[0])
+	}
+	if err := part.ForEachRange(func(name string, from, to []byte) error {
+		fromTime, err := decodeBound(name, from)
+		if err != nil {
+			return err
+		}
+		toTime, err := decodeBound(name, to)
+		if err != nil {
+			return err
+		}
+		info.existing = append(info.existing, rangePartition{name: name, from: fromTime, to: toTime})
+		return nil
+	}); err != nil {
+		return tableInfo{}, err
+	}
+	return info, nil
+}
+
+// evalInterval evaluates a serialized INTERVAL stored in the descriptor.
+func evalInterval(
+	ctx context.Context, evalCtx *eval.Context, expr catpb.Expression,
+) (duration.Duration, error) {
+	parsed, err := parser.ParseExpr(string(expr))
+	if err != nil {
+		return duration.Duration{}, errors.Wrapf(err, "unexpected expression for rolling partition interval")
+	}
+	typedExpr, err := tree.TypeCheckAndRequire(ctx, parsed, nil /* semaCtx */, types.Interval, "rolling partition interval")
+	if err != nil {
+		return duration.Duration{}, err
+	}
+	d, err := eval.Expr(ctx, evalCtx, typedExpr)
+	if err != nil {
+		return duration.Duration{}, err
+	}
+	return tree.MustBeDInterval(d).Duration, nil
+}
+
+// datumToTime returns the point in time held by a partition bound.
+func datumToTime(d tree.Datum) (time.Time, error) {
+	switch t := d.(type) {
+	case *tree.DTimestamp:
+		return t.Time, nil
+	case *tree.DTimestampTZ:
+		return t.Time.UTC(), nil
+	case *tree.DDate:
+		return t.ToTime()
+	}
+	return time.Time{}, errors.AssertionFailedf("unexpected partition bound %s of type %s", d, d.ResolvedType())
+}
+
+// timeToDatum returns a partition bound of the given type for a point in time.
+func timeToDatum(t time.Time, typ *types.T) (tree.Datum, error) {
+	switch typ.Family() {
+	case types.TimestampFamily:
+		return tree.MakeDTimestamp(t, time.Microsecond)
+	case types.TimestampTZFamily:
+		return tree.MakeDTimestampTZ(t, time.Microsecond)
+	case types.DateFamily:
+		return tree.NewDDateFromTime(t)
+	}
+	return nil, errors.AssertionFailedf("unexpected partition column type %s", typ.SQLString())
+}
+
+// buildPartitionByStmt returns the statement which partitions the primary
+// index of the table into the given partitions.
+func buildPartitionByStmt(info tableInfo, partitions []rangePartition) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "ALTER TABLE %s PARTITION BY ", info.tableName.FQString())
+	if len(partitions) == 0 {
+		buf.WriteString("NOTHING")
+		return buf.String(), nil
+	}
+	fmt.Fprintf(&buf, "RANGE (%s) (", tree.NameString(info.column))
+	for i, p := range partitions {
+		from, err := timeToDatum(p.from, info.columnType)
+		if err != nil {
+			return "", err
+		}
+		to, err := timeToDatum(p.to, info.columnType)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "PARTITION %s VALUES FROM (%s) TO (%s)",
+			tree.NameString(p.name),
+			tree.AsStringWithFlags(from, tree.FmtParsable),
+			tree.AsStringWithFlags(to, tree.FmtParsable),
+		)
+	}
+	buf.WriteString(")")
+	return buf.String(), nil
+}
+
+// deletePartitionRows removes the rows of a dropped partition in batches and
+// returns the number of rows removed.
+func deletePartitionRows(
+	ctx context.Context, ie isql.Executor, info tableInfo, p rangePartition,
+) (int64, error) {
+	from, err := timeToDatum(p.from, info.columnType)
+	if err != nil {
+		return 0, err
+	}
+	to, err := timeToDatum(p.to, info.columnType)
+	if err != nil {
+		return 0, err
+	}
+	col := tree.NameString(info.column)
+	stmt := fmt.Sprintf(
+		"DELETE FROM %s WHERE %s >= $1 AND %s < $2 LIMIT %d",
+		info.tableName.FQString(), col, col, deleteBatchSize,
+	)
+	var total int64
+	for {
+		n, err := ie.ExecEx(
+			ctx, "rolling-partition-delete", nil, /* txn */
+			sessiondata.RootUserSessionDataOverride, stmt, from, to,
+		)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+		if n < deleteBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// copyZoneConfigs applies the zone configuration of the newest partition that
+// was kept to each of the added partitions, so that partitions created by the
+// job are placed like the ones before them.
+func copyZoneConfigs(
+	ctx context.Context, ie isql.Executor, info tableInfo, plan windowPlan,
+) error {
+	if len(plan.added) == 0 {
+		return nil
+	}
+	var source string
+	for _, p := range plan.partitions {
+		if !p.from.Before(plan.added[0].from) {
+			break
+		}
+		source = p.name
+	}
+	if source == "" {
+		return nil
+	}
+	row, err := ie.QueryRowEx(
+		ctx, "rolling-partition-zone-config", nil, /* txn */
+		sessiondata.RootUserSessionDataOverride,
+		fmt.Sprintf(`SELECT raw_config_sql FROM %s.crdb_internal.zones
+WHERE schema_name = $1 AND table_name = $2 AND index_name = $3 AND partition_name = $4`,
+			tree.NameString(info.tableName.Catalog())),
+		info.tableName.Schema(), info.tableName.Table(), info.indexName, source,
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil || row[0] == tree.DNull {
+		return nil
+	}
+	const using = "CONFIGURE ZONE USING"
+	rawConfigSQL := string(tree.MustBeDString(row[0]))
+	pos := strings.Index(rawConfigSQL, using)
+	if pos < 0 {
+		return errors.AssertionFailedf("unexpected zone configuration %q", rawConfigSQL)
+	}
+	options := rawConfigSQL[pos+len(using):]
+	for _, p := range plan.added {
+		stmt := fmt.Sprintf("ALTER PARTITION %s OF INDEX %s@%s %s%s",
+			tree.NameString(p.name), info.tableName.FQString(), tree.NameString(info.indexName),
+			using, options,
+		)
+		if _, err := ie.ExecEx(
+			ctx, "rolling-partition-configure-zone", nil, /* txn */
+			sessiondata.RootUserSessionDataOverride, stmt,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeRollingPartition, func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+		return &rollingPartitionResumer{
+			job: job,
+			st:  settings,
+		}
+	}, jobs.UsesTenantCostControl)
+}
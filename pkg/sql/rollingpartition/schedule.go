@@ -0,0 +1,266 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rollingpartition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+type rollingPartitionExecutor struct {
+	metrics rollingPartitionMetrics
+}
+
+var _ jobs.ScheduledJobController = (*rollingPartitionExecutor)(nil)
+
+type rollingPartitionMetrics struct {
+	*jobs.ExecutorMetrics
+}
+
+var _ metric.Struct = &rollingPartitionMetrics{}
+
+// MetricStruct implements metric.Struct interface.
+func (m *rollingPartitionMetrics) MetricStruct() {}
+
+// OnDrop implements the jobs.ScheduledJobController interface.
+func (s rollingPartitionExecutor) OnDrop(
+	ctx context.Context,
+	scheduleControllerEnv scheduledjobs.ScheduleControllerEnv,
+	env scheduledjobs.JobSchedulerEnv,
+	schedule *jobs.ScheduledJob,
+	txn isql.Txn,
+	descsCol *descs.Collection,
+) (int, error) {
+	var args catpb.ScheduledRollingPartitionArgs
+	if err := pbtypes.UnmarshalAny(schedule.ExecutionArgs().Args, &args); err != nil {
+		return 0, err
+	}
+
+	isValid, err := isValidSchedule(ctx, txn.KV(), descsCol, schedule, args)
+	if err != nil {
+		return 0, err
+	}
+
+	if isValid {
+		tbl, err := descsCol.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, args.TableID)
+		if err != nil {
+			return 0, err
+		}
+		tn, err := descs.GetObjectName(ctx, txn.KV(), descsCol, tbl)
+		if err != nil {
+			return 0, err
+		}
+		return 0, errors.WithHintf(
+			pgerror.Newf(
+				pgcode.InvalidTableDefinition,
+				"cannot drop a rolling partition schedule",
+			),
+			`use ALTER TABLE %s RESET (rolling_partition_interval) instead`,
+			tn.FQString(),
+		)
+	}
+	return 0, nil
+}
+
+// isValidSchedule determines whether the given schedule still maintains the
+// partitions of its table. Schedules are left behind if the table is dropped
+// by the declarative schema changer, and such schedules may be dropped.
+func isValidSchedule(
+	ctx context.Context,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	schedule *jobs.ScheduledJob,
+	args catpb.ScheduledRollingPartitionArgs,
+) (bool, error) {
+	desc, err := descsCol.ByIDWithLeased(txn).WithoutNonPublic().Get().Table(ctx, args.TableID)
+	if err != nil {
+		// If the descriptor does not exist the schedule is not valid.
+		if sqlerrors.IsUndefinedRelationError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if desc == nil || !desc.HasRollingPartitioning() {
+		return false, nil
+	}
+	return desc.GetRollingPartitioning().ScheduleID == schedule.ScheduleID(), nil
+}
+
+// ExecuteJob implements the jobs.ScheduledJobController interface.
+func (s rollingPartitionExecutor) ExecuteJob(
+	ctx context.Context,
+	txn isql.Txn,
+	cfg *scheduledjobs.JobExecutionConfig,
+	env scheduledjobs.JobSchedulerEnv,
+	sj *jobs.ScheduledJob,
+) error {
+	args := &catpb.ScheduledRollingPartitionArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return err
+	}
+
+	descsCol := descs.FromTxn(txn)
+	isValid, err := isValidSchedule(ctx, txn.KV(), descsCol, sj, *args)
+	if err != nil {
+		return err
+	}
+	if !isValid {
+		// The table no longer uses this schedule, so remove it instead of
+		// running a job which would fail.
+		log.Infof(ctx, "dropping stale rolling partition schedule %d", sj.ScheduleID())
+		return jobs.ScheduledJobTxn(txn).Delete(ctx, sj)
+	}
+
+	p, cleanup := cfg.PlanHookMaker(
+		ctx,
+		fmt.Sprintf("invoke-rolling-partition-%d", args.TableID),
+		txn.KV(),
+		username.NodeUserName(),
+	)
+	defer cleanup()
+
+	if _, err := createRollingPartitionJob(
+		ctx,
+		&jobs.CreatedByInfo{
+			ID:   sj.ScheduleID(),
+			Name: jobs.CreatedByScheduledJobs,
+		},
+		txn,
+		p.(sql.PlanHookState).ExecCfg().JobRegistry,
+		env,
+		*args,
+	); err != nil {
+		s.metrics.NumFailed.Inc(1)
+		return err
+	}
+	s.metrics.NumStarted.Inc(1)
+	return nil
+}
+
+// NotifyJobTermination implements the jobs.ScheduledJobController interface.
+func (s rollingPartitionExecutor) NotifyJobTermination(
+	ctx context.Context,
+	txn isql.Txn,
+	jobID jobspb.JobID,
+	jobStatus jobs.Status,
+	details jobspb.Details,
+	env scheduledjobs.JobSchedulerEnv,
+	sj *jobs.ScheduledJob,
+) error {
+	if jobStatus == jobs.StatusFailed {
+		jobs.DefaultHandleFailedRun(
+			sj,
+			"rolling partitions for table [%d] job failed",
+			details.(jobspb.RollingPartitionDetails).TableID,
+		)
+		s.metrics.NumFailed.Inc(1)
+		return nil
+	}
+
+	if jobStatus == jobs.StatusSucceeded {
+		s.metrics.NumSucceeded.Inc(1)
+	}
+
+	sj.SetScheduleStatus(string(jobStatus))
+	return nil
+}
+
+// Metrics implements the jobs.ScheduledJobController interface.
+func (s rollingPartitionExecutor) Metrics() metric.Struct {
+	return &s.metrics
+}
+
+// GetCreateScheduleStatement implements the jobs.ScheduledJobController interface.
+func (s rollingPartitionExecutor) GetCreateScheduleStatement(
+	ctx context.Context, txn isql.Txn, env scheduledjobs.JobSchedulerEnv, sj *jobs.ScheduledJob,
+) (string, error) {
+	descsCol := descs.FromTxn(txn)
+	args := &catpb.ScheduledRollingPartitionArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return "", err
+	}
+	tbl, err := descsCol.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, args.TableID)
+	if err != nil {
+		return "", err
+	}
+	tn, err := descs.GetObjectName(ctx, txn.KV(), descsCol, tbl)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`ALTER TABLE %s SET (rolling_partition_interval = ..., ...)`, tn.FQString()), nil
+}
+
+func createRollingPartitionJob(
+	ctx context.Context,
+	createdByInfo *jobs.CreatedByInfo,
+	txn isql.Txn,
+	jobRegistry *jobs.Registry,
+	env scheduledjobs.JobSchedulerEnv,
+	args catpb.ScheduledRollingPartitionArgs,
+) (jobspb.JobID, error) {
+	descsCol := descs.FromTxn(txn)
+	tableDesc, err := descsCol.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, args.TableID)
+	if err != nil {
+		return 0, err
+	}
+	tn, err := descs.GetObjectName(ctx, txn.KV(), descsCol, tableDesc)
+	if err != nil {
+		return 0, err
+	}
+	record := jobs.Record{
+		Description: fmt.Sprintf("rolling partitions for %s", tn.FQString()),
+		Username:    username.NodeUserName(),
+		Details: jobspb.RollingPartitionDetails{
+			TableID: args.TableID,
+			Cutoff:  env.Now(),
+		},
+		Progress:  jobspb.RollingPartitionProgress{},
+		CreatedBy: createdByInfo,
+	}
+
+	jobID := jobRegistry.MakeJobID()
+	if _, err := jobRegistry.CreateAdoptableJobWithTxn(ctx, record, jobID, txn); err != nil {
+		return jobspb.InvalidJobID, err
+	}
+	return jobID, nil
+}
+
+func init() {
+	jobs.RegisterScheduledJobExecutorFactory(
+		tree.ScheduledRollingPartitionExecutor.InternalName(),
+		func() (jobs.ScheduledJobExecutor, error) {
+			m := jobs.MakeExecutorMetrics(tree.ScheduledRollingPartitionExecutor.InternalName())
+			return &rollingPartitionExecutor{
+				metrics: rollingPartitionMetrics{
+					ExecutorMetrics: &m,
+				},
+			}, nil
+		},
+	)
+}
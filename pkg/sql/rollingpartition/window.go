@@ -0,0 +1,143 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rollingpartition
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/errors"
+)
+
+// maxPartitionsPerRun bounds the number of partitions a single run of the job
+// may add, which guards against an interval that is tiny compared to the
+// lookahead window.
+const maxPartitionsPerRun = 1000
+
+// rangePartition is a single partition of a table with rolling partitioning,
+// covering the times in [from, to).
+type rangePartition struct {
+	name     string
+	from, to time.Time
+}
+
+// windowPlan describes the partitions of a table after a run of the job.
+type windowPlan struct {
+	// partitions are all the partitions the table should have, ordered by
+	// their lower bound.
+	partitions []rangePartition
+	// added are the partitions which do not exist yet.
+	added []rangePartition
+	// dropped are the existing partitions whose retention has passed.
+	dropped []rangePartition
+}
+
+// changed returns whether the partitioning of the table needs to be updated.
+func (p windowPlan) changed() bool {
+	return len(p.added) > 0 || len(p.dropped) > 0
+}
+
+// planWindow computes the partitions a table should have at the given time,
+// such that the partition containing now and lookahead partitions after it
+// exist, and the partitions whose upper bound is older than the retention are
+// dropped. Existing partitions are kept as they are, and new partitions start
+// at the upper bound of the last existing partition, or at now truncated to
+// the interval if that is later.
+func planWindow(
+	existing []rangePartition,
+	now time.Time,
+	interval duration.Duration,
+	lookahead int64,
+	retention *duration.Duration,
+) (windowPlan, error) {
+	if interval.Compare(duration.Duration{}) <= 0 {
+		return windowPlan{}, errors.AssertionFailedf("invalid partition interval %s", interval)
+	}
+	now = now.UTC()
+	sorted := append([]rangePartition(nil), existing...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].from.Before(sorted[j].from)
+	})
+
+	var plan windowPlan
+	names := make(map[string]struct{}, len(sorted))
+	var expiry time.Time
+	if retention != nil {
+		expiry = duration.Add(now, retention.Mul(-1))
+	}
+	start := truncateToInterval(now, interval)
+	for _, p := range sorted {
+		if p.to.After(start) {
+			start = p.to
+		}
+		if retention != nil && !p.to.After(expiry) {
+			plan.dropped = append(plan.dropped, p)
+			continue
+		}
+		plan.partitions = append(plan.partitions, p)
+		names[p.name] = struct{}{}
+	}
+
+	horizon := duration.Add(now, interval.Mul(lookahead))
+	for from := start; !from.After(horizon); {
+		to := duration.Add(from, interval)
+		if !to.After(from) {
+			return windowPlan{}, errors.AssertionFailedf(
+				"partition interval %s does not advance from %s", interval, from,
+			)
+		}
+		if len(plan.added) >= maxPartitionsPerRun {
+			return windowPlan{}, errors.Newf(
+				"rolling partitioning would add more than %d partitions; "+
+					"use a larger rolling_partition_interval or a smaller rolling_partition_lookahead",
+				maxPartitionsPerRun,
+			)
+		}
+		p := rangePartition{name: partitionName(from), from: from, to: to}
+		if _, ok := names[p.name]; ok {
+			return windowPlan{}, errors.Newf(
+				"cannot add partition %s as a partition with that name already exists", p.name,
+			)
+		}
+		names[p.name] = struct{}{}
+		plan.added = append(plan.added, p)
+		plan.partitions = append(plan.partitions, p)
+		from = to
+	}
+	return plan, nil
+}
+
+// truncateToInterval truncates t to the start of the month if the interval
+// spans months, to the start of the day if the interval spans days, and
+// otherwise to a multiple of the interval.
+func truncateToInterval(t time.Time, interval duration.Duration) time.Time {
+	switch {
+	case interval.Months > 0:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case interval.Days > 0:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	default:
+		return t.Truncate(time.Duration(interval.Nanos()))
+	}
+}
+
+// partitionName returns the name of the partition starting at the given time.
+func partitionName(from time.Time) string {
+	switch {
+	case from.Second() != 0 || from.Nanosecond() != 0:
+		return from.Format("p20060102_150405")
+	case from.Hour() != 0 || from.Minute() != 0:
+		return from.Format("p20060102_1504")
+	default:
+		return from.Format("p20060102")
+	}
+}
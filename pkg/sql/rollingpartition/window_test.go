@@ -0,0 +1,172 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rollingpartition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanWindow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	day := duration.MakeDuration(0, 1, 0)
+	hour := duration.MakeDuration(int64(time.Hour), 0, 0)
+	month := duration.MakeDuration(0, 0, 1)
+	week := duration.MakeDuration(0, 7, 0)
+	ts := func(s string) time.Time {
+		t.Helper()
+		r, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return r
+	}
+	dayPartition := func(s string) rangePartition {
+		from := ts(s + " 00:00")
+		return rangePartition{name: partitionName(from), from: from, to: from.AddDate(0, 0, 1)}
+	}
+	names := func(ps []rangePartition) []string {
+		var ret []string
+		for _, p := range ps {
+			ret = append(ret, p.name)
+		}
+		return ret
+	}
+
+	testCases := []struct {
+		desc      string
+		existing  []rangePartition
+		now       time.Time
+		interval  duration.Duration
+		lookahead int64
+		retention *duration.Duration
+
+		expectedPartitions []string
+		expectedAdded      []string
+		expectedDropped    []string
+		expectedErr        string
+	}{
+		{
+			desc:               "no existing partitions",
+			now:                ts("2023-03-14 15:09"),
+			interval:           day,
+			lookahead:          2,
+			expectedPartitions: []string{"p20230314", "p20230315", "p20230316"},
+			expectedAdded:      []string{"p20230314", "p20230315", "p20230316"},
+		},
+		{
+			desc: "window already covered",
+			existing: []rangePartition{
+				dayPartition("2023-03-14"), dayPartition("2023-03-15"), dayPartition("2023-03-16"),
+			},
+			now:                ts("2023-03-14 15:09"),
+			interval:           day,
+			lookahead:          2,
+			expectedPartitions: []string{"p20230314", "p20230315", "p20230316"},
+		},
+		{
+			desc: "window advances and drops expired partitions",
+			existing: []rangePartition{
+				dayPartition("2023-03-12"), dayPartition("2023-03-13"), dayPartition("2023-03-14"),
+			},
+			now:                ts("2023-03-14 15:09"),
+			interval:           day,
+			lookahead:          1,
+			retention:          &day,
+			expectedPartitions: []string{"p20230313", "p20230314", "p20230315"},
+			expectedAdded:      []string{"p20230315"},
+			expectedDropped:    []string{"p20230312"},
+		},
+		{
+			desc:               "stale partitions are not back filled",
+			existing:           []rangePartition{dayPartition("2023-01-01")},
+			now:                ts("2023-03-14 15:09"),
+			interval:           day,
+			lookahead:          1,
+			expectedPartitions: []string{"p20230101", "p20230314", "p20230315"},
+			expectedAdded:      []string{"p20230314", "p20230315"},
+		},
+		{
+			desc:               "hourly partitions",
+			now:                ts("2023-03-14 23:09"),
+			interval:           hour,
+			lookahead:          1,
+			expectedPartitions: []string{"p20230314_2300", "p20230315"},
+			expectedAdded:      []string{"p20230314_2300", "p20230315"},
+		},
+		{
+			desc:               "monthly partitions",
+			now:                ts("2023-12-14 23:09"),
+			interval:           month,
+			lookahead:          1,
+			expectedPartitions: []string{"p20231201", "p20240101"},
+			expectedAdded:      []string{"p20231201", "p20240101"},
+		},
+		{
+			desc:               "weekly partitions start on the current day",
+			now:                ts("2023-03-14 23:09"),
+			interval:           week,
+			lookahead:          1,
+			expectedPartitions: []string{"p20230314", "p20230321"},
+			expectedAdded:      []string{"p20230314", "p20230321"},
+		},
+		{
+			desc:               "all partitions expired",
+			existing:           []rangePartition{dayPartition("2023-01-01")},
+			now:                ts("2023-03-14 15:09"),
+			interval:           month,
+			lookahead:          0,
+			retention:          &day,
+			expectedPartitions: []string{"p20230301"},
+			expectedAdded:      []string{"p20230301"},
+			expectedDropped:    []string{"p20230101"},
+		},
+		{
+			desc: "name collision",
+			existing: []rangePartition{
+				{name: "p20230314", from: ts("2023-01-01 00:00"), to: ts("2023-01-02 00:00")},
+			},
+			now:         ts("2023-03-14 15:09"),
+			interval:    day,
+			lookahead:   1,
+			expectedErr: "a partition with that name already exists",
+		},
+		{
+			desc:        "too many partitions",
+			now:         ts("2023-03-14 15:09"),
+			interval:    duration.MakeDuration(int64(time.Second), 0, 0),
+			lookahead:   maxPartitionsPerRun,
+			expectedErr: "would add more than",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			plan, err := planWindow(tc.existing, tc.now, tc.interval, tc.lookahead, tc.retention)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPartitions, names(plan.partitions))
+			require.Equal(t, tc.expectedAdded, names(plan.added))
+			require.Equal(t, tc.expectedDropped, names(plan.dropped))
+			for i := 1; i < len(plan.partitions); i++ {
+				require.False(t, plan.partitions[i].from.Before(plan.partitions[i-1].to))
+			}
+		})
+	}
+}
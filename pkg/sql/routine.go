@@ -13,8 +13,12 @@ package sql
 import (
 	"context"
 	"strconv"
+	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql"
@@ -22,6 +26,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -48,7 +54,10 @@ func (p *planner) EvalRoutineExpr(
 		return expr.CachedResult, nil
 	}
 
-	if expr.TailCall && !expr.Generator && p.EvalContext().RoutineSender != nil {
+	// A routine that modifies the session cannot be deferred, since the
+	// modifications would not be applied when the parent routine evaluates it.
+	if expr.TailCall && !expr.Generator && expr.SessionOverrides == nil &&
+		p.EvalContext().RoutineSender != nil {
 		// This is a nested routine in tail-call position.
 		if !p.curPlan.flags.IsDistributed() && tailCallOptimizationEnabled {
 			// Tail-call optimizations are enabled. Send the information needed to
@@ -150,17 +159,113 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
+	var buf *routineResultBuffer
+	if id := g.expr.ResultBufferID; id != 0 {
+		// This is a set-returning PL/pgSQL routine. Its result is made up of the
+		// rows added to the result buffer by RETURN NEXT and RETURN QUERY
+		// statements, rather than the result of the last body statement.
+		retTypes := g.returnTypes()
+		buf = &routineResultBuffer{multiColOutput: g.expr.MultiColOutput, numCols: len(retTypes)}
+		buf.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine-result" /* opName */)
+		if g.p.routineResultBuffers == nil {
+			g.p.routineResultBuffers = make(map[tree.RoutineResultBufferID]*routineResultBuffer)
+		}
+		// The routine may be called recursively, so restore the buffer for the
+		// outer invocation once this one finishes.
+		prevBuf := g.p.routineResultBuffers[id]
+		g.p.routineResultBuffers[id] = buf
+		defer func() {
+			if prevBuf != nil {
+				g.p.routineResultBuffers[id] = prevBuf
+			} else {
+				delete(g.p.routineResultBuffers, id)
+			}
+		}()
+	}
+	if g.expr.SessionOverrides != nil {
+		// Any nested routines deferred below execute with the same session
+		// modifications, just as they would if they had not been deferred.
+		restore, err := g.applySessionOverrides(ctx)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
 	for {
 		err = g.startInternal(ctx, txn)
 		if err != nil || g.deferredRoutine.expr == nil {
 			// No tail-call optimization.
-			return err
+			break
 		}
 		// A nested routine in tail-call position deferred its execution until now.
 		// Since it's in tail-call position, evaluating it will give the result of
 		// this routine as well.
 		g.reset(ctx, g.p, g.deferredRoutine.expr, g.deferredRoutine.args)
 	}
+	if buf != nil {
+		if err != nil {
+			buf.rch.Close(ctx)
+			return err
+		}
+		// Replace the result of the last body statement with the buffered rows.
+		if g.rci != nil {
+			g.rci.Close()
+		}
+		g.rch.Close(ctx)
+		g.rch = buf.rch
+		g.rci = newRowContainerIterator(ctx, g.rch)
+	}
+	return err
+}
+
+// applySessionOverrides pushes a copy of the current session data onto the
+// session data stack and applies the session overrides of the routine to it,
+// so that they are in effect while the routine executes. The returned function
+// pops the copy to restore the original session data.
+func (g *routineGenerator) applySessionOverrides(ctx context.Context) (restore func(), _ error) {
+	sds := g.p.EvalContext().SessionDataStack
+	sds.PushTopClone()
+	restore = func() {
+		if err := sds.Pop(); err != nil {
+			log.Warningf(ctx, "unable to restore session data after routine: %v", err)
+		}
+	}
+	sd := sds.Top()
+	overrides := g.expr.SessionOverrides
+	if !overrides.User.Undefined() {
+		// A SECURITY DEFINER routine executes as its owner. Similar to SET ROLE,
+		// the session user is unchanged.
+		if sd.SessionUserProto == "" {
+			sd.SessionUserProto = sd.UserProto
+		}
+		sd.UserProto = overrides.User.EncodeProto()
+	}
+	m := g.p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sd)
+	for _, setting := range overrides.Settings {
+		name, val, _ := strings.Cut(setting, "=")
+		_, v, err := getSessionVar(name, false /* missingOk */)
+		if err == nil && v.Set == nil {
+			err = newCannotChangeParameterError(name)
+		}
+		if err == nil {
+			err = v.Set(ctx, m, val)
+		}
+		if err != nil {
+			restore()
+			return nil, err
+		}
+	}
+	return restore, nil
+}
+
+// returnTypes returns the types of the columns in each row returned by the
+// routine.
+func (g *routineGenerator) returnTypes() []*types.T {
+	if g.expr.MultiColOutput {
+		// A routine with multiple output column should have its types in a tuple.
+		return g.expr.ResolvedType().TupleContents()
+	}
+	return []*types.T{g.expr.ResolvedType()}
 }
 
 // startInternal implements logic for a single execution of a routine.
@@ -169,18 +274,10 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 // is cache-able (i.e., there are no arguments to the routine and stepping is
 // disabled).
 func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err error) {
-	rt := g.expr.ResolvedType()
-	var retTypes []*types.T
-	if g.expr.MultiColOutput {
-		// A routine with multiple output column should have its types in a tuple.
-		if rt.Family() != types.TupleFamily {
-			return errors.AssertionFailedf("routine expected to return multiple columns")
-		}
-		retTypes = rt.TupleContents()
-	} else {
-		retTypes = []*types.T{g.expr.ResolvedType()}
+	if g.expr.MultiColOutput && g.expr.ResolvedType().Family() != types.TupleFamily {
+		return errors.AssertionFailedf("routine expected to return multiple columns")
 	}
-	g.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine" /* opName */)
+	g.rch.Init(ctx, g.returnTypes(), g.p.ExtendedEvalContext(), "routine" /* opName */)
 
 	// Configure stepping for volatile routines so that mutations made by the
 	// invoking statement are visible to the routine.
@@ -208,12 +305,30 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 		ctx, sp := tracing.ChildSpan(ctx, opName)
 		defer sp.Finish()
 
-		// If this is the last statement, use the rowResultWriter created above.
-		// Otherwise, use a rowResultWriter that drops all rows added to it.
+		// If this is the last statement, use the rowResultWriter created above,
+		// unless this is a set-returning PL/pgSQL routine, which returns the
+		// contents of its result buffer instead (see Start). The result of the first statement of a PL/pgSQL routine may be used to
+		// open a cursor or may be added to the result of a set-returning
+		// function. Otherwise, use a rowResultWriter that drops all rows added to
+		// it.
 		var w rowResultWriter
-		if isFinalPlan {
+		var cursorHelper *plpgsqlCursorHelper
+		switch {
+		case isFinalPlan && g.expr.ResultBufferID == 0:
 			w = rrw
-		} else {
+		case stmtIdx == 1 && g.expr.CursorDeclaration != nil:
+			cursorHelper, err = g.newCursorHelper(ctx, plan.(*planComponents))
+			if err != nil {
+				return err
+			}
+			w = cursorHelper
+		case stmtIdx == 1 && g.expr.ReturnRows != 0:
+			buf, ok := g.p.routineResultBuffers[g.expr.ReturnRows]
+			if !ok {
+				return errors.AssertionFailedf("expected result buffer for routine %s", g.expr.Name)
+			}
+			w = buf
+		default:
 			w = &droppingResultWriter{}
 		}
 
@@ -228,8 +343,21 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 		// Run the plan.
 		err = runPlanInsidePlan(ctx, g.p.RunParams(ctx), plan.(*planComponents), w, g)
 		if err != nil {
+			if cursorHelper != nil {
+				_ = cursorHelper.Close()
+			}
 			return err
 		}
+		if cursorHelper != nil {
+			// The cursor is registered only once its query has been executed.
+			return g.p.sqlCursors.addCursor(cursorHelper.cursorName, &sqlCursor{
+				Rows:       cursorHelper,
+				readSeqNum: txn.GetReadSeqNum(),
+				txn:        txn,
+				statement:  g.expr.CursorDeclaration.CursorSQL,
+				created:    timeutil.Now(),
+			})
+		}
 		return nil
 	})
 	if err != nil {
@@ -307,17 +435,201 @@ func (g *routineGenerator) SendDeferredRoutine(routine *tree.RoutineExpr, args t
 	g.deferredRoutine.args = args
 }
 
+// newCursorHelper returns a plpgsqlCursorHelper for the cursor opened by this
+// routine, which will collect the rows of the given plan.
+func (g *routineGenerator) newCursorHelper(
+	ctx context.Context, plan *planComponents,
+) (*plpgsqlCursorHelper, error) {
+	open := g.expr.CursorDeclaration
+	if open.NameArgIdx < 0 || open.NameArgIdx >= len(g.args) {
+		return nil, errors.AssertionFailedf("unexpected cursor name argument index: %d", open.NameArgIdx)
+	}
+	if g.args[open.NameArgIdx] == tree.DNull {
+		return nil, errors.AssertionFailedf("expected non-null cursor name")
+	}
+	cursorName := tree.Name(tree.MustBeDString(g.args[open.NameArgIdx]))
+	if cursor := g.p.sqlCursors.getCursor(cursorName); cursor != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateCursor, "cursor %q already in use", cursorName)
+	}
+	if g.p.extendedEvalCtx.PreparedStatementState.HasPortal(string(cursorName)) {
+		return nil, pgerror.Newf(pgcode.DuplicateCursor, "cursor %q already exists as portal", cursorName)
+	}
+	resultCols := plan.main.planColumns()
+	h := &plpgsqlCursorHelper{
+		// The cursor outlives the routine, so it cannot use the routine's
+		// context when rows are fetched or when it is closed.
+		ctx:        context.Background(),
+		cursorName: cursorName,
+		resultCols: make(colinfo.ResultColumns, len(resultCols)),
+	}
+	copy(h.resultCols, resultCols)
+	colTypes := make([]*types.T, len(resultCols))
+	for i := range resultCols {
+		colTypes[i] = resultCols[i].Typ
+	}
+	// The cursor may outlive the transaction's memory monitor, since open
+	// cursors are closed after the transaction finishes. Use the session's
+	// monitor if possible.
+	parentMon := g.p.Mon()
+	if g.p.sessionMon != nil {
+		parentMon = g.p.sessionMon
+	}
+	h.container.InitWithParentMon(
+		ctx, colTypes, parentMon, g.p.ExtendedEvalContext(), "routine-cursor", /* opName */
+	)
+	return h, nil
+}
+
+// routineResultBuffer collects the rows returned by a set-returning PL/pgSQL
+// routine with RETURN NEXT and RETURN QUERY statements.
+type routineResultBuffer struct {
+	rch rowContainerHelper
+	// multiColOutput is true if the routine returns multiple columns, in which
+	// case each (tuple-typed) row added to the buffer is expanded into columns.
+	multiColOutput bool
+	numCols        int
+	err            error
+}
+
+var _ rowResultWriter = &routineResultBuffer{}
+
+// AddRow is part of the rowResultWriter interface.
+func (b *routineResultBuffer) AddRow(ctx context.Context, row tree.Datums) error {
+	if b.multiColOutput {
+		if len(row) != 1 {
+			return errors.AssertionFailedf("expected a single tuple column, found %d columns", len(row))
+		}
+		if row[0] == tree.DNull {
+			row = make(tree.Datums, b.numCols)
+			for i := range row {
+				row[i] = tree.DNull
+			}
+		} else {
+			row = tree.MustBeDTuple(row[0]).D
+		}
+	}
+	return b.rch.AddRow(ctx, row)
+}
+
+// SetRowsAffected is part of the rowResultWriter interface.
+func (b *routineResultBuffer) SetRowsAffected(ctx context.Context, n int) {}
+
+// SetError is part of the rowResultWriter interface.
+func (b *routineResultBuffer) SetError(err error) {
+	b.err = err
+}
+
+// Err is part of the rowResultWriter interface.
+func (b *routineResultBuffer) Err() error {
+	return b.err
+}
+
+// plpgsqlCursorHelper materializes the result of the query for a cursor that
+// is opened by a PL/pgSQL OPEN statement. It is a rowResultWriter that collects
+// the rows of the query, and an isql.Rows that allows them to be fetched
+// through the SQL cursor.
+type plpgsqlCursorHelper struct {
+	ctx        context.Context
+	cursorName tree.Name
+	resultCols colinfo.ResultColumns
+
+	container    rowContainerHelper
+	iter         *rowContainerIterator
+	lastRow      tree.Datums
+	rowsAffected int
+	err          error
+}
+
+var _ isql.Rows = &plpgsqlCursorHelper{}
+var _ rowResultWriter = &plpgsqlCursorHelper{}
+
+// Next is part of the isql.Rows interface.
+func (h *plpgsqlCursorHelper) Next(_ context.Context) (bool, error) {
+	if h.iter == nil {
+		h.iter = newRowContainerIterator(h.ctx, h.container)
+	}
+	row, err := h.iter.Next()
+	if err != nil {
+		return false, err
+	}
+	if row == nil {
+		h.lastRow = nil
+		return false, nil
+	}
+	h.lastRow = make(tree.Datums, len(row))
+	copy(h.lastRow, row)
+	return true, nil
+}
+
+// Cur is part of the isql.Rows interface.
+func (h *plpgsqlCursorHelper) Cur() tree.Datums {
+	return h.lastRow
+}
+
+// RowsAffected is part of the isql.Rows interface.
+func (h *plpgsqlCursorHelper) RowsAffected() int {
+	return h.rowsAffected
+}
+
+// Close is part of the isql.Rows interface.
+func (h *plpgsqlCursorHelper) Close() error {
+	if h.iter != nil {
+		h.iter.Close()
+		h.iter = nil
+	}
+	h.container.Close(h.ctx)
+	return nil
+}
+
+// Types is part of the isql.Rows interface.
+func (h *plpgsqlCursorHelper) Types() colinfo.ResultColumns {
+	return h.resultCols
+}
+
+// HasResults is part of the isql.Rows interface.
+func (h *plpgsqlCursorHelper) HasResults() bool {
+	return h.lastRow != nil
+}
+
+// AddRow is part of the rowResultWriter interface.
+func (h *plpgsqlCursorHelper) AddRow(ctx context.Context, row tree.Datums) error {
+	return h.container.AddRow(ctx, row)
+}
+
+// SetRowsAffected is part of the rowResultWriter interface.
+func (h *plpgsqlCursorHelper) SetRowsAffected(ctx context.Context, n int) {
+	h.rowsAffected = n
+}
+
+// SetError is part of the rowResultWriter interface.
+func (h *plpgsqlCursorHelper) SetError(err error) {
+	h.err = err
+}
+
+// Err is part of the rowResultWriter interface.
+func (h *plpgsqlCursorHelper) Err() error {
+	return h.err
+}
+
 // droppingResultWriter drops all rows that are added to it. It only tracks
 // errors with the SetError and Err functions.
 type droppingResultWriter struct {
 	err error
 }
 
+var _ rowResultWriter = &droppingResultWriter{}
+var _ batchResultWriter = &droppingResultWriter{}
+
 // AddRow is part of the rowResultWriter interface.
 func (d *droppingResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
 	return nil
 }
 
+// AddBatch is part of the batchResultWriter interface.
+func (d *droppingResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	return nil
+}
+
 // SetRowsAffected is part of the rowResultWriter interface.
 func (d *droppingResultWriter) SetRowsAffected(ctx context.Context, n int) {}
 
@@ -0,0 +1,262 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package row
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// brinWriter maintains the summaries of the BRIN indexes of a table as rows
+// are inserted or updated. See rowenc.BRINTagUnsummarized for the layout of
+// BRIN indexes.
+//
+// The summary of a block is only created by a writer that observes that the
+// block has no rows yet, so that the summary covers every row of the block.
+// Afterwards, writers only ever widen summaries; deleting rows leaves the
+// summary of their block unchanged.
+type brinWriter struct {
+	txn     *kv.Txn
+	codec   keys.SQLCodec
+	table   catalog.TableDescriptor
+	indexes []brinIndex
+	alloc   *tree.DatumAlloc
+}
+
+type brinIndex struct {
+	index         catalog.Index
+	prefix        roachpb.Key
+	colID         descpb.ColumnID
+	colType       *types.T
+	blockColID    descpb.ColumnID
+	pagesPerRange int64
+	// canSummarize is true if the writer may create summaries for blocks
+	// without rows. This requires the index to be public, because writers that
+	// don't know about the index could otherwise add rows to the block without
+	// widening its summary.
+	canSummarize bool
+	// blocks caches the state of the blocks written to so far.
+	blocks map[int64]*brinBlock
+}
+
+type brinBlock struct {
+	prefix     roachpb.Key
+	summarized bool
+	// minKey and maxKey are the keys of the entries encoding the bounds of the
+	// summary of a summarized block. They are nil if no non-NULL value has been
+	// written to the block.
+	minKey, maxKey roachpb.Key
+}
+
+// makeBRINWriter returns a brinWriter for the BRIN indexes in the given list
+// of indexes. If txn is nil, the writer marks every block it writes to as
+// unsummarized.
+func makeBRINWriter(
+	txn *kv.Txn, codec keys.SQLCodec, table catalog.TableDescriptor, indexes []catalog.Index,
+) (brinWriter, error) {
+	w := brinWriter{txn: txn, codec: codec, table: table}
+	primary := table.GetPrimaryIndex()
+	for _, idx := range indexes {
+		if idx.GetType() != descpb.IndexDescriptor_BRIN || idx.DeleteOnly() ||
+			idx.UseDeletePreservingEncoding() {
+			continue
+		}
+		col, err := catalog.MustFindColumnByID(table, idx.GetKeyColumnID(0))
+		if err != nil {
+			return brinWriter{}, err
+		}
+		desc := idx.IndexDesc()
+		blockColID := desc.BRINBlockColumnID()
+		w.indexes = append(w.indexes, brinIndex{
+			index:         idx,
+			prefix:        rowenc.MakeIndexKeyPrefix(codec, table.GetID(), idx.GetID()),
+			colID:         col.GetID(),
+			colType:       col.GetType(),
+			blockColID:    blockColID,
+			pagesPerRange: desc.BRINPagesPerRange(),
+			canSummarize: idx.Public() && primary.NumKeyColumns() > 0 &&
+				primary.GetKeyColumnID(0) == blockColID,
+			blocks: make(map[int64]*brinBlock),
+		})
+	}
+	if len(w.indexes) > 0 {
+		w.alloc = &tree.DatumAlloc{}
+	}
+	return w, nil
+}
+
+// writeRow adds to the batch the kv operations necessary to keep the BRIN
+// summaries up to date with the given new values of a row.
+func (w *brinWriter) writeRow(
+	ctx context.Context, b Putter, colMap catalog.TableColMap, values []tree.Datum, traceKV bool,
+) error {
+	for i := range w.indexes {
+		bi := &w.indexes[i]
+		blockOrd, ok := colMap.Get(bi.blockColID)
+		if !ok {
+			return errors.AssertionFailedf("missing BRIN block column %d", bi.blockColID)
+		}
+		block, ok, err := rowenc.BRINBlock(values[blockOrd], bi.pagesPerRange)
+		if err != nil || !ok {
+			return err
+		}
+		blk, err := w.getBlock(ctx, b, bi, block, traceKV)
+		if err != nil {
+			return err
+		}
+		if !blk.summarized {
+			continue
+		}
+		valOrd, ok := colMap.Get(bi.colID)
+		if !ok {
+			return errors.AssertionFailedf("missing BRIN column %d", bi.colID)
+		}
+		if values[valOrd] == tree.DNull {
+			continue
+		}
+		if err := w.widen(ctx, b, blk, values[valOrd], traceKV); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// widen widens the summary of the given block to include val.
+func (w *brinWriter) widen(
+	ctx context.Context, b Putter, blk *brinBlock, val tree.Datum, traceKV bool,
+) error {
+	// The values are encoded in ascending order, so comparing the keys compares
+	// the values.
+	minKey, err := rowenc.MakeBRINEntryKey(blk.prefix, rowenc.BRINTagMin, val)
+	if err != nil {
+		return err
+	}
+	if blk.minKey == nil || bytes.Compare(minKey, blk.minKey) < 0 {
+		insertPutFn(ctx, b, &minKey, brinEntryValue(), traceKV)
+		if blk.minKey != nil {
+			insertDelFn(ctx, b, &blk.minKey, traceKV)
+		}
+		blk.minKey = minKey
+	}
+	maxKey, err := rowenc.MakeBRINEntryKey(blk.prefix, rowenc.BRINTagMax, val)
+	if err != nil {
+		return err
+	}
+	if blk.maxKey == nil || bytes.Compare(maxKey, blk.maxKey) > 0 {
+		insertPutFn(ctx, b, &maxKey, brinEntryValue(), traceKV)
+		if blk.maxKey != nil {
+			insertDelFn(ctx, b, &blk.maxKey, traceKV)
+		}
+		blk.maxKey = maxKey
+	}
+	return nil
+}
+
+// getBlock returns the state of the given block, reading it if it is not
+// cached yet. If the block has no summary, no rows and the index allows it, a
+// new empty summary is created.
+func (w *brinWriter) getBlock(
+	ctx context.Context, b Putter, bi *brinIndex, block int64, traceKV bool,
+) (*brinBlock, error) {
+	if blk, ok := bi.blocks[block]; ok {
+		return blk, nil
+	}
+	blk := &brinBlock{prefix: rowenc.MakeBRINBlockPrefix(bi.prefix, block)}
+	bi.blocks[block] = blk
+
+	if w.txn == nil {
+		// Without a transaction we can't read the summary, so mark the block as
+		// unsummarized.
+		key, err := rowenc.MakeBRINEntryKey(blk.prefix, rowenc.BRINTagUnsummarized, nil /* val */)
+		if err != nil {
+			return nil, err
+		}
+		insertPutFn(ctx, b, &key, brinEntryValue(), traceKV)
+		return blk, nil
+	}
+
+	kvs, err := w.txn.Scan(ctx, blk.prefix, blk.prefix.PrefixEnd(), 0 /* maxRows */)
+	if err != nil {
+		return nil, err
+	}
+	if len(kvs) > 0 {
+		for i := range kvs {
+			_, tag, _, err := rowenc.DecodeBRINEntryKey(w.alloc, bi.prefix, bi.colType, kvs[i].Key)
+			if err != nil {
+				return nil, err
+			}
+			switch tag {
+			case rowenc.BRINTagUnsummarized:
+				return blk, nil
+			case rowenc.BRINTagSummarized:
+				blk.summarized = true
+			case rowenc.BRINTagMin:
+				if blk.minKey == nil {
+					blk.minKey = kvs[i].Key
+				}
+			case rowenc.BRINTagMax:
+				blk.maxKey = kvs[i].Key
+			}
+		}
+		return blk, nil
+	}
+
+	if !bi.canSummarize {
+		return blk, nil
+	}
+	empty, err := w.blockIsEmpty(ctx, bi, block)
+	if err != nil || !empty {
+		return blk, err
+	}
+	key, err := rowenc.MakeBRINEntryKey(blk.prefix, rowenc.BRINTagSummarized, nil /* val */)
+	if err != nil {
+		return nil, err
+	}
+	insertPutFn(ctx, b, &key, brinEntryValue(), traceKV)
+	blk.summarized = true
+	return blk, nil
+}
+
+// brinEntryValue returns the value of a BRIN index entry. A new value is needed
+// for each entry, since the checksum of a value depends on its key.
+func brinEntryValue() *roachpb.Value {
+	var value roachpb.Value
+	value.SetBytes([]byte{})
+	return &value
+}
+
+// blockIsEmpty returns true if the primary index has no rows in the given
+// block.
+func (w *brinWriter) blockIsEmpty(ctx context.Context, bi *brinIndex, block int64) (bool, error) {
+	dir := encoding.Ascending
+	if w.table.GetPrimaryIndex().GetKeyColumnDirection(0) == catenumpb.IndexColumn_DESC {
+		dir = encoding.Descending
+	}
+	prefix := rowenc.MakeIndexKeyPrefix(w.codec, w.table.GetID(), w.table.GetPrimaryIndexID())
+	span := rowenc.MakeBRINBlocksSpan(prefix, dir, block, block, bi.pagesPerRange)
+	kvs, err := w.txn.Scan(ctx, span.Key, span.EndKey, 1 /* maxRows */)
+	if err != nil {
+		return false, err
+	}
+	return len(kvs) == 0, nil
+}
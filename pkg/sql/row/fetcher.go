@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -233,7 +234,9 @@ type Fetcher struct {
 	kvFetcher *KVFetcher
 	// indexKey stores the index key of the current row, up to (and not including)
 	// any family ID.
-	indexKey       []byte
+	indexKey []byte
+	// rowKey stores the index key of the row last returned by NextRow.
+	rowKey         []byte
 	prettyValueBuf *bytes.Buffer
 
 	valueColsFound int // how many needed cols we've found so far in the value
@@ -296,6 +299,10 @@ type FetcherInitArgs struct {
 	// LockStrength represents the row-level locking mode to use when fetching
 	// rows.
 	LockStrength descpb.ScanLockingStrength
+	// Settings are the cluster settings, which determine the per-key locking
+	// strength used for LockStrength. They may be nil if LockStrength is
+	// FOR_NONE.
+	Settings *cluster.Settings
 	// LockWaitPolicy represents the policy to be used for handling conflicting
 	// locks held by other active transactions.
 	LockWaitPolicy descpb.ScanLockingWaitPolicy
@@ -438,7 +445,7 @@ func (rf *Fetcher) Init(ctx context.Context, args FetcherInitArgs) error {
 		var batchRequestsIssued int64
 		fetcherArgs := newTxnKVFetcherArgs{
 			reverse:                    args.Reverse,
-			lockStrength:               args.LockStrength,
+			lockStrength:               GetKeyLockingStrength(ctx, args.Settings, args.LockStrength),
 			lockWaitPolicy:             args.LockWaitPolicy,
 			lockTimeout:                args.LockTimeout,
 			acc:                        rf.kvFetcherMemAcc,
@@ -704,6 +711,7 @@ func (rf *Fetcher) nextKey(ctx context.Context) (newRow bool, spanID int, _ erro
 	if !ok {
 		// No more keys in the scan.
 		rf.kvEnd = true
+		rf.rowKey = rf.indexKey
 		return true, 0, nil
 	}
 
@@ -770,6 +778,7 @@ func (rf *Fetcher) nextKey(ctx context.Context) (newRow bool, spanID int, _ erro
 		rf.keyRemainingBytes = rf.kv.Key[prefixLen:]
 	}
 
+	rf.rowKey = rf.indexKey
 	rf.indexKey = nil
 	return true, spanID, nil
 }
@@ -1230,6 +1239,12 @@ func (rf *Fetcher) RowLastModified() hlc.Timestamp {
 	return rf.table.rowLastModified
 }
 
+// RowKey may only be called after NextRow has returned a non-nil row and
+// returns the index key of that row, up to (and not including) any family ID.
+func (rf *Fetcher) RowKey() roachpb.Key {
+	return rf.rowKey
+}
+
 // RowIsDeleted may only be called after NextRow has returned a non-nil row and
 // returns true if that row was most recently deleted. This method is only
 // meaningful when the configured KVBatchFetcher returns deletion tombstones, which
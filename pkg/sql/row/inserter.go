@@ -31,6 +31,8 @@ type Inserter struct {
 	InsertCols            []catalog.Column
 	InsertColIDtoRowIndex catalog.TableColMap
 
+	brin brinWriter
+
 	// For allocation avoidance.
 	key      roachpb.Key
 	valueBuf []byte
@@ -65,6 +67,11 @@ func MakeInserter(
 		return Inserter{}, err
 	}
 
+	var err error
+	if ri.brin, err = makeBRINWriter(txn, codec, tableDesc, ri.Helper.Indexes); err != nil {
+		return Inserter{}, err
+	}
+
 	return ri, nil
 }
 
@@ -186,5 +193,5 @@ func (ri *Inserter) InsertRow(
 		}
 	}
 
-	return nil
+	return ri.brin.writeRow(ctx, b, ri.InsertColIDtoRowIndex, values, traceKV)
 }
@@ -285,7 +285,7 @@ func makeTxnKVFetcherDefaultSendFunc(txn *kv.Txn, batchRequestsIssued *int64) se
 type newTxnKVFetcherArgs struct {
 	sendFn                     sendFunc
 	reverse                    bool
-	lockStrength               descpb.ScanLockingStrength
+	lockStrength               lock.Strength
 	lockWaitPolicy             descpb.ScanLockingWaitPolicy
 	lockTimeout                time.Duration
 	acc                        *mon.BoundAccount
@@ -312,7 +312,7 @@ func newTxnKVFetcherInternal(args newTxnKVFetcherArgs) *txnKVFetcher {
 		// Default to BATCH_RESPONSE. The caller will override if needed.
 		scanFormat:                 kvpb.BATCH_RESPONSE,
 		reverse:                    args.reverse,
-		lockStrength:               GetKeyLockingStrength(args.lockStrength),
+		lockStrength:               args.lockStrength,
 		lockWaitPolicy:             GetWaitPolicy(args.lockWaitPolicy),
 		lockTimeout:                args.lockTimeout,
 		acc:                        args.acc,
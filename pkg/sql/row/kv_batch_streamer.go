@@ -17,7 +17,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -52,14 +51,14 @@ var _ KVBatchFetcher = &txnKVStreamer{}
 // newTxnKVStreamer creates a new txnKVStreamer.
 func newTxnKVStreamer(
 	streamer *kvstreamer.Streamer,
-	lockStrength descpb.ScanLockingStrength,
+	lockStrength lock.Strength,
 	acc *mon.BoundAccount,
 	kvPairsRead *int64,
 	batchRequestsIssued *int64,
 ) KVBatchFetcher {
 	f := &txnKVStreamer{
 		streamer:   streamer,
-		keyLocking: GetKeyLockingStrength(lockStrength),
+		keyLocking: lockStrength,
 		acc:        acc,
 	}
 	f.kvBatchFetcherHelper.init(f.nextBatch, kvPairsRead, batchRequestsIssued)
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvstreamer"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -54,7 +55,7 @@ func newTxnKVFetcher(
 	txn *kv.Txn,
 	bsHeader *kvpb.BoundedStalenessHeader,
 	reverse bool,
-	lockStrength descpb.ScanLockingStrength,
+	lockStrength lock.Strength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
 	lockTimeout time.Duration,
 	acc *mon.BoundAccount,
@@ -116,7 +117,9 @@ func newTxnKVFetcher(
 // the memory account can be shared by the caller with other components (as long
 // as there is no concurrency).
 func NewDirectKVBatchFetcher(
+	ctx context.Context,
 	txn *kv.Txn,
+	st *cluster.Settings,
 	bsHeader *kvpb.BoundedStalenessHeader,
 	spec *fetchpb.IndexFetchSpec,
 	reverse bool,
@@ -127,7 +130,7 @@ func NewDirectKVBatchFetcher(
 	forceProductionKVBatchSize bool,
 ) KVBatchFetcher {
 	f := newTxnKVFetcher(
-		txn, bsHeader, reverse, lockStrength, lockWaitPolicy,
+		txn, bsHeader, reverse, GetKeyLockingStrength(ctx, st, lockStrength), lockWaitPolicy,
 		lockTimeout, acc, forceProductionKVBatchSize,
 	)
 	f.scanFormat = kvpb.COL_BATCH_RESPONSE
@@ -142,7 +145,9 @@ func NewDirectKVBatchFetcher(
 // the memory account can be shared by the caller with other components (as long
 // as there is no concurrency).
 func NewKVFetcher(
+	ctx context.Context,
 	txn *kv.Txn,
+	st *cluster.Settings,
 	bsHeader *kvpb.BoundedStalenessHeader,
 	reverse bool,
 	lockStrength descpb.ScanLockingStrength,
@@ -152,7 +157,7 @@ func NewKVFetcher(
 	forceProductionKVBatchSize bool,
 ) *KVFetcher {
 	return newKVFetcher(newTxnKVFetcher(
-		txn, bsHeader, reverse, lockStrength, lockWaitPolicy,
+		txn, bsHeader, reverse, GetKeyLockingStrength(ctx, st, lockStrength), lockWaitPolicy,
 		lockTimeout, acc, forceProductionKVBatchSize,
 	))
 }
@@ -162,6 +167,7 @@ func NewKVFetcher(
 //
 // If maintainOrdering is true, then diskBuffer must be non-nil.
 func NewStreamingKVFetcher(
+	ctx context.Context,
 	distSender *kvcoord.DistSender,
 	stopper *stop.Stopper,
 	txn *kv.Txn,
@@ -178,6 +184,7 @@ func NewStreamingKVFetcher(
 ) *KVFetcher {
 	var kvPairsRead int64
 	var batchRequestsIssued int64
+	keyLocking := GetKeyLockingStrength(ctx, st, lockStrength)
 	streamer := kvstreamer.NewStreamer(
 		distSender,
 		stopper,
@@ -188,7 +195,7 @@ func NewStreamingKVFetcher(
 		streamerBudgetAcc,
 		&kvPairsRead,
 		&batchRequestsIssued,
-		GetKeyLockingStrength(lockStrength),
+		keyLocking,
 	)
 	mode := kvstreamer.OutOfOrder
 	if maintainOrdering {
@@ -203,7 +210,7 @@ func NewStreamingKVFetcher(
 		maxKeysPerRow,
 		diskBuffer,
 	)
-	return newKVFetcher(newTxnKVStreamer(streamer, lockStrength, kvFetcherMemAcc, &kvPairsRead, &batchRequestsIssued))
+	return newKVFetcher(newTxnKVStreamer(streamer, keyLocking, kvFetcherMemAcc, &kvPairsRead, &batchRequestsIssued))
 }
 
 func newKVFetcher(batchFetcher KVBatchFetcher) *KVFetcher {
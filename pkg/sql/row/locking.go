@@ -11,14 +11,21 @@
 package row
 
 import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/errors"
 )
 
 // GetKeyLockingStrength returns the configured per-key locking strength to use
-// for key-value scans.
-func GetKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strength {
+// for key-value scans. The settings are only used for the FOR_SHARE and
+// FOR_KEY_SHARE strengths, and may be nil otherwise.
+func GetKeyLockingStrength(
+	ctx context.Context, st *cluster.Settings, lockStrength descpb.ScanLockingStrength,
+) lock.Strength {
 	switch lockStrength {
 	case descpb.ScanLockingStrength_FOR_NONE:
 		return lock.None
@@ -27,9 +34,14 @@ func GetKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 		// Promote to FOR_SHARE.
 		fallthrough
 	case descpb.ScanLockingStrength_FOR_SHARE:
-		// We currently perform no per-key locking when FOR_SHARE is used
-		// because Shared locks have not yet been implemented.
-		return lock.None
+		// Shared locks conflict only with Exclusive locks and intents, so
+		// multiple transactions can hold them on the same key at once. Nodes
+		// running an older binary don't support them, in which case we
+		// perform no per-key locking.
+		if !st.Version.IsActive(ctx, clusterversion.V23_2_SharedLocks) {
+			return lock.None
+		}
+		return lock.Shared
 
 	case descpb.ScanLockingStrength_FOR_NO_KEY_UPDATE:
 		// Promote to FOR_UPDATE.
@@ -46,6 +46,8 @@ type Updater struct {
 	rd Deleter
 	ri Inserter
 
+	brin brinWriter
+
 	// For allocation avoidance.
 	newValues       []tree.Datum
 	key             roachpb.Key
@@ -177,9 +179,13 @@ func MakeUpdater(
 		newIndexEntries:       make([][]rowenc.IndexEntry, len(includeIndexes)),
 	}
 
+	var err error
+	if ru.brin, err = makeBRINWriter(txn, codec, tableDesc, includeIndexes); err != nil {
+		return Updater{}, err
+	}
+
 	if primaryKeyColChange {
 		// These fields are only used when the primary key is changing.
-		var err error
 		ru.rd = MakeDeleter(codec, tableDesc, requestedCols, sv, internal, metrics)
 		if ru.ri, err = MakeInserter(
 			ctx, txn, codec, tableDesc, requestedCols, alloc, sv, internal, metrics,
@@ -523,6 +529,10 @@ func (ru *Updater) UpdateRow(
 		}
 	}
 
+	if err := ru.brin.writeRow(ctx, putter, ru.FetchColIDtoRowIndex, ru.newValues, traceKV); err != nil {
+		return nil, err
+	}
+
 	// We're deleting indexes in a delete only state. We're bounding this by the number of indexes because inverted
 	// indexed will be handled separately.
 	if ru.DeleteHelper != nil {
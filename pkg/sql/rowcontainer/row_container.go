@@ -742,6 +742,12 @@ func (f *DiskBackedIndexedRowContainer) AddRow(ctx context.Context, row rowenc.E
 		tree.NewDInt(tree.DInt(f.idx)),
 	)
 	f.idx++
+	if f.diskRowIter != nil {
+		// The disk iterator doesn't observe rows that are added after it was
+		// created, so it has to be recreated by the next call to GetRow.
+		f.resetCache(ctx)
+		f.resetIterator()
+	}
 	return f.DiskBackedRowContainer.AddRow(ctx, f.scratchEncRow)
 }
 
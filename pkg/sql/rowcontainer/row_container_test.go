@@ -645,6 +645,51 @@ func TestDiskBackedIndexedRowContainer(t *testing.T) {
 		}
 	})
 
+	// AddRowAfterGetRow spills DiskBackedIndexedRowContainer to disk and then
+	// alternates between adding rows and reading them, verifying that the
+	// rows added after a read are visible to later reads.
+	t.Run("AddRowAfterGetRow", func(t *testing.T) {
+		for i := 0; i < numTestRuns; i++ {
+			rows := make([]rowenc.EncDatumRow, numRows)
+			types := randgen.RandSortingTypes(rng, numCols)
+			for i := 0; i < numRows; i++ {
+				rows[i] = randgen.RandEncDatumRowOfTypes(rng, types)
+			}
+
+			func() {
+				rc := NewDiskBackedIndexedRowContainer(colinfo.NoOrdering, types, &evalCtx, tempEngine, memoryMonitor, diskMonitor)
+				defer rc.Close(ctx)
+				if err := rc.SpillToDisk(ctx); err != nil {
+					t.Fatal(err)
+				}
+				for i := 0; i < numRows; i++ {
+					if err := rc.AddRow(ctx, rows[i]); err != nil {
+						t.Fatal(err)
+					}
+					// Read the row that was just added, followed by the first one.
+					for _, pos := range []int{i, 0} {
+						readRow, err := rc.GetRow(ctx, pos)
+						if err != nil {
+							t.Fatalf("unexpected error: %v", err)
+						}
+						if readRow.GetIdx() != pos {
+							t.Fatalf("read row at index %d, expected %d", readRow.GetIdx(), pos)
+						}
+						for col := range rows[pos] {
+							datum, err := readRow.GetDatum(col)
+							if err != nil {
+								t.Fatalf("unexpected error: %v", err)
+							}
+							if cmp := datum.Compare(&evalCtx, rows[pos][col].Datum); cmp != 0 {
+								t.Fatalf("read row is not equal to written one")
+							}
+						}
+					}
+				}
+			}()
+		}
+	})
+
 	// TestGetRow adds all rows into DiskBackedIndexedRowContainer, sorts them,
 	// and checks that both the index and the row are what we expect by GetRow()
 	// to be returned. Then, it spills to disk and does the same check again.
@@ -0,0 +1,162 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// A BRIN index does not have an entry per row. Instead, the rows of the table
+// are grouped into blocks of consecutive values of the leading primary key
+// column (the block column), and the index stores a summary of the indexed
+// column for each block. The entries of a block are encoded as:
+//
+//	/<index prefix>/<block>/<tag>[/<value>]/0
+//
+// where <tag> is one of the BRINTag constants below, and <value> is the
+// ascending key encoding of a value of the indexed column for the min and max
+// tags. All entries have empty values.
+//
+// A block is summarized if it has a BRINTagSummarized entry and no
+// BRINTagUnsummarized entry. The summary of a summarized block is the range
+// between the smallest BRINTagMin value and the largest BRINTagMax value of the
+// block; NULLs are never part of a summary. Every non-NULL value of the indexed
+// column in the rows of a summarized block must lie within its summary, but
+// the summary may be wider than necessary. This lets concurrent writers widen
+// a summary by adding entries without reading each other's writes. Blocks
+// without a summary must always be scanned.
+const (
+	// BRINTagUnsummarized marks a block that must not be pruned, for example
+	// because its rows were written by a bulk operation that does not maintain
+	// summaries.
+	BRINTagUnsummarized uint64 = iota
+	// BRINTagSummarized marks a block as summarized.
+	BRINTagSummarized
+	// BRINTagMin is the tag of entries encoding a lower bound of the summary.
+	BRINTagMin
+	// BRINTagMax is the tag of entries encoding an upper bound of the summary.
+	BRINTagMax
+)
+
+// BRINBlock returns the block containing the given value of the block column
+// of a BRIN index with the given number of values per block. ok is false if the
+// value is NULL.
+func BRINBlock(blockVal tree.Datum, pagesPerRange int64) (block int64, ok bool, _ error) {
+	if blockVal == tree.DNull {
+		return 0, false, nil
+	}
+	d, isInt := tree.UnwrapDOidWrapper(blockVal).(*tree.DInt)
+	if !isInt {
+		return 0, false, errors.AssertionFailedf(
+			"unexpected BRIN block column value of type %s", blockVal.ResolvedType(),
+		)
+	}
+	v := int64(*d)
+	block = v / pagesPerRange
+	if v%pagesPerRange < 0 {
+		// Round towards negative infinity so that each block covers exactly
+		// pagesPerRange values.
+		block--
+	}
+	return block, true, nil
+}
+
+// BRINBlockBounds returns the smallest and largest values of the block column
+// contained in the given block.
+func BRINBlockBounds(block, pagesPerRange int64) (lo, hi int64) {
+	if block < math.MinInt64/pagesPerRange {
+		lo = math.MinInt64
+	} else {
+		lo = block * pagesPerRange
+	}
+	if block > (math.MaxInt64-pagesPerRange+1)/pagesPerRange {
+		hi = math.MaxInt64
+	} else {
+		hi = block*pagesPerRange + pagesPerRange - 1
+	}
+	return lo, hi
+}
+
+// MakeBRINBlocksSpan returns the span of an index containing the rows in the
+// blocks from firstBlock to lastBlock (inclusive), given that the block column
+// is the leading key column of the index and is encoded with the given
+// direction. indexPrefix is the prefix of the index containing the rows,
+// usually the primary index.
+func MakeBRINBlocksSpan(
+	indexPrefix roachpb.Key, dir encoding.Direction, firstBlock, lastBlock, pagesPerRange int64,
+) roachpb.Span {
+	lo, _ := BRINBlockBounds(firstBlock, pagesPerRange)
+	_, hi := BRINBlockBounds(lastBlock, pagesPerRange)
+	prefix := indexPrefix[:len(indexPrefix):len(indexPrefix)]
+	if dir == encoding.Descending {
+		return roachpb.Span{
+			Key:    encoding.EncodeVarintDescending(prefix, hi),
+			EndKey: roachpb.Key(encoding.EncodeVarintDescending(prefix, lo)).PrefixEnd(),
+		}
+	}
+	return roachpb.Span{
+		Key:    encoding.EncodeVarintAscending(prefix, lo),
+		EndKey: roachpb.Key(encoding.EncodeVarintAscending(prefix, hi)).PrefixEnd(),
+	}
+}
+
+// MakeBRINBlockPrefix returns the prefix of all entries of the given block of
+// the BRIN index with the given prefix.
+func MakeBRINBlockPrefix(indexPrefix roachpb.Key, block int64) roachpb.Key {
+	return encoding.EncodeVarintAscending(indexPrefix[:len(indexPrefix):len(indexPrefix)], block)
+}
+
+// MakeBRINEntryKey returns the key of a BRIN index entry of the block with the
+// given prefix. val must be non-nil if and only if tag is BRINTagMin or
+// BRINTagMax.
+func MakeBRINEntryKey(blockPrefix roachpb.Key, tag uint64, val tree.Datum) (roachpb.Key, error) {
+	key := encoding.EncodeUvarintAscending(blockPrefix[:len(blockPrefix):len(blockPrefix)], tag)
+	if val != nil {
+		var err error
+		if key, err = keyside.Encode(key, val, encoding.Ascending); err != nil {
+			return nil, err
+		}
+	}
+	return keys.MakeFamilyKey(key, 0), nil
+}
+
+// DecodeBRINEntryKey decodes the block, tag and value of a BRIN index entry.
+// indexPrefix is the prefix of the index, and typ is the type of the indexed
+// column. val is nil unless tag is BRINTagMin or BRINTagMax.
+func DecodeBRINEntryKey(
+	a *tree.DatumAlloc, indexPrefix roachpb.Key, typ *types.T, key roachpb.Key,
+) (block int64, tag uint64, val tree.Datum, err error) {
+	if !bytes.HasPrefix(key, indexPrefix) {
+		return 0, 0, nil, errors.AssertionFailedf("key %s is not a BRIN index entry", key)
+	}
+	rest := key[len(indexPrefix):]
+	if rest, block, err = encoding.DecodeVarintAscending(rest); err != nil {
+		return 0, 0, nil, err
+	}
+	if rest, tag, err = encoding.DecodeUvarintAscending(rest); err != nil {
+		return 0, 0, nil, err
+	}
+	if tag == BRINTagMin || tag == BRINTagMax {
+		if val, _, err = keyside.Decode(a, typ, rest, encoding.Ascending); err != nil {
+			return 0, 0, nil, err
+		}
+	}
+	return block, tag, val, nil
+}
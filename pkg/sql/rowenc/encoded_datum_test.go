@@ -215,7 +215,9 @@ func TestEncDatumCompare(t *testing.T) {
 	for _, typ := range types.OidToType {
 		switch typ.Family() {
 		case types.AnyFamily, types.UnknownFamily, types.ArrayFamily, types.JsonFamily, types.TupleFamily, types.VoidFamily,
-			types.TSQueryFamily, types.TSVectorFamily:
+			types.TSQueryFamily, types.TSVectorFamily, types.JsonpathFamily, types.PointFamily,
+			types.LSegFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily, types.LineFamily,
+			types.CircleFamily:
 			continue
 		case types.CollatedStringFamily:
 			typ = types.MakeCollatedString(types.String, *randgen.RandCollationLocale(rng))
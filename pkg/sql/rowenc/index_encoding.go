@@ -1225,6 +1225,12 @@ func EncodeSecondaryIndex(
 	values []tree.Datum,
 	includeEmpty bool,
 ) ([]IndexEntry, error) {
+	// BRIN indexes don't have an entry per row. Their summaries are maintained
+	// by the row writers instead.
+	if secondaryIndex.GetType() == descpb.IndexDescriptor_BRIN {
+		return []IndexEntry{}, nil
+	}
+
 	secondaryIndexKeyPrefix := MakeIndexKeyPrefix(codec, tableDesc.GetID(), secondaryIndex.GetID())
 
 	// Use the primary key encoding for covering indexes.
@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math/rand"
 	"sort"
 	"testing"
 
@@ -567,7 +568,7 @@ func TestEncodeContainingArrayInvertedIndexSpans(t *testing.T) {
 	// Run a set of randomly generated test cases.
 	rng, _ := randutil.NewTestRand()
 	for i := 0; i < 100; i++ {
-		typ := randgen.RandArrayType(rng)
+		typ := randInvertedIndexableArrayType(rng)
 
 		// Generate two random arrays and evaluate the result of `left @> right`.
 		left := randgen.RandArray(rng, typ, 0 /* nullChance */)
@@ -586,6 +587,17 @@ func TestEncodeContainingArrayInvertedIndexSpans(t *testing.T) {
 	}
 }
 
+// randInvertedIndexableArrayType returns a random array type that can be used
+// in an inverted index.
+func randInvertedIndexableArrayType(rng *rand.Rand) *types.T {
+	for {
+		typ := randgen.RandArrayType(rng)
+		if colinfo.ColumnTypeIsInvertedIndexable(typ) {
+			return typ
+		}
+	}
+}
+
 func TestEncodeContainedArrayInvertedIndexSpans(t *testing.T) {
 	testCases := []struct {
 		indexedValue string
@@ -708,7 +720,7 @@ func TestEncodeContainedArrayInvertedIndexSpans(t *testing.T) {
 	// Run a set of randomly generated test cases.
 	rng, _ := randutil.NewTestRand()
 	for i := 0; i < 100; i++ {
-		typ := randgen.RandArrayType(rng)
+		typ := randInvertedIndexableArrayType(rng)
 
 		// Generate two random arrays and evaluate the result of `left <@ right`.
 		left := randgen.RandArray(rng, typ, 0 /* nullChance */)
@@ -947,7 +959,7 @@ func TestEncodeOverlapsArrayInvertedIndexSpans(t *testing.T) {
 	// Run a set of randomly generated test cases.
 	rng, _ := randutil.NewTestRand()
 	for i := 0; i < 100; i++ {
-		typ := randgen.RandArrayType(rng)
+		typ := randInvertedIndexableArrayType(rng)
 
 		// Generate two random arrays and evaluate the result of `left && right`.
 		// Using 1/9th as the Null Chance to generate arrays with a small
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -82,6 +83,22 @@ func Decode(
 			rkey, i, err = encoding.DecodeUvarintDescending(key)
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(i)}), rkey, err
+	case types.MacAddrFamily:
+		var i uint64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeUvarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeUvarintDescending(key)
+		}
+		return tree.NewDMacAddr(macaddr.MacAddr(i)), rkey, err
+	case types.MacAddr8Family:
+		var i uint64
+		if dir == encoding.Ascending {
+			rkey, i, err = encoding.DecodeUvarintAscending(key)
+		} else {
+			rkey, i, err = encoding.DecodeUvarintDescending(key)
+		}
+		return tree.NewDMacAddr8(macaddr.MacAddr8(i)), rkey, err
 	case types.FloatFamily:
 		var f float64
 		if dir == encoding.Ascending {
@@ -134,6 +151,10 @@ func Decode(
 		}
 		d := a.NewDJSON(tree.DJSON{JSON: json})
 		return d, rkey, err
+	case types.RangeFamily:
+		return decodeRangeKey(a, valType, key, dir)
+	case types.MultiRangeFamily:
+		return decodeMultiRangeKey(a, valType, key, dir)
 	case types.BytesFamily:
 		var r []byte
 		if dir == encoding.Ascending {
@@ -254,6 +275,19 @@ func Decode(
 		var ipAddr ipaddr.IPAddr
 		_, err := ipAddr.FromBuffer(r)
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), rkey, err
+	case types.CIDRFamily:
+		var r []byte
+		if dir == encoding.Ascending {
+			rkey, r, err = encoding.DecodeBytesAscending(key, nil)
+		} else {
+			rkey, r, err = encoding.DecodeBytesDescending(key, nil)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		var ipAddr ipaddr.IPAddr
+		_, err := ipAddr.FromBuffer(r)
+		return tree.NewDCIDR(tree.DCIDR{IPAddr: ipAddr}), rkey, err
 	case types.OidFamily:
 		// TODO: This possibly should use DecodeUint32 (with corresponding changes
 		// to encoding) to ensure that the value fits in a DOid without any loss of
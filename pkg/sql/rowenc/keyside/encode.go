@@ -81,6 +81,16 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 			return encoding.EncodeUvarintAscending(b, uint64(t.LSN)), nil
 		}
 		return encoding.EncodeUvarintDescending(b, uint64(t.LSN)), nil
+	case *tree.DMacAddr:
+		if dir == encoding.Ascending {
+			return encoding.EncodeUvarintAscending(b, uint64(t.MacAddr)), nil
+		}
+		return encoding.EncodeUvarintDescending(b, uint64(t.MacAddr)), nil
+	case *tree.DMacAddr8:
+		if dir == encoding.Ascending {
+			return encoding.EncodeUvarintAscending(b, uint64(t.MacAddr8)), nil
+		}
+		return encoding.EncodeUvarintDescending(b, uint64(t.MacAddr8)), nil
 	case *tree.DBox2D:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBox2DAscending(b, t.CartesianBoundingBox.BoundingBox)
@@ -143,6 +153,12 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 			return encoding.EncodeBytesAscending(b, data), nil
 		}
 		return encoding.EncodeBytesDescending(b, data), nil
+	case *tree.DCIDR:
+		data := t.ToBuffer(nil)
+		if dir == encoding.Ascending {
+			return encoding.EncodeBytesAscending(b, data), nil
+		}
+		return encoding.EncodeBytesDescending(b, data), nil
 	case *tree.DTuple:
 		for _, datum := range t.D {
 			var err error
@@ -179,6 +195,10 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return append(b, []byte(*t)...), nil
 	case *tree.DJSON:
 		return encodeJSONKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
+	case *tree.DMultiRange:
+		return encodeMultiRangeKey(b, t, dir)
 	}
 	return nil, errors.Errorf("unable to encode table key: %T", val)
 }
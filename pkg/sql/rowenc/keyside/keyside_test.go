@@ -240,10 +240,16 @@ func hasKeyEncoding(typ *types.T) bool {
 	// Only some types are round-trip key encodable.
 	switch typ.Family() {
 	case types.CollatedStringFamily, types.TupleFamily, types.DecimalFamily,
-		types.GeographyFamily, types.GeometryFamily, types.TSVectorFamily, types.TSQueryFamily:
+		types.GeographyFamily, types.GeometryFamily, types.TSVectorFamily, types.TSQueryFamily,
+		types.JsonpathFamily, types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
 		return false
 	case types.ArrayFamily:
 		return hasKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily:
+		return hasKeyEncoding(typ.RangeContents())
+	case types.MultiRangeFamily:
+		return hasKeyEncoding(typ.MultiRangeContents())
 	}
 	return true
 }
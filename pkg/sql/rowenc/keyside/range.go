@@ -0,0 +1,224 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The bytes used in the ordered key encoding of ranges. They are chosen so
+// that the encoding sorts in the same order as tree.DRange.Compare: empty
+// ranges sort first, then ranges are ordered by their lower bound and then by
+// their upper bound. An infinite lower bound sorts before all finite lower
+// bounds and an infinite upper bound sorts after all finite upper bounds. For
+// bounds with equal values, an inclusive lower bound sorts before an exclusive
+// one, and an exclusive upper bound sorts before an inclusive one.
+const (
+	rangeKeyEmpty    = 0x00
+	rangeKeyNonEmpty = 0x01
+
+	rangeKeyLowerInfinite = 0x00
+	rangeKeyLowerFinite   = 0x01
+	rangeKeyUpperFinite   = 0x01
+	rangeKeyUpperInfinite = 0x02
+
+	rangeKeyLowerInclusive = 0x00
+	rangeKeyLowerExclusive = 0x01
+	rangeKeyUpperExclusive = 0x00
+	rangeKeyUpperInclusive = 0x01
+
+	multiRangeKeyTerminator = 0x00
+	multiRangeKeyRange      = 0x01
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The bounds of
+// the range are encoded in ascending order, and the result is wrapped in a
+// byte string in the given direction:
+// [emptyFlag, lowerMarker, enc(lower), lowerInclusivity, upperMarker,
+// enc(upper), upperInclusivity]. Infinite bounds omit the encoded value and
+// the inclusivity, and an empty range consists of the empty flag only.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	inner, err := appendRangeKeyContents(nil, r)
+	if err != nil {
+		return nil, err
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// encodeMultiRangeKey generates an ordered key encoding of a multirange. Each
+// range is encoded as in encodeRangeKey, preceded by a marker, and the list of
+// ranges is followed by a terminator that sorts before the marker, so that a
+// multirange sorts before the multiranges it is a prefix of:
+// [marker, range1, marker, range2, terminator].
+func encodeMultiRangeKey(
+	b []byte, m *tree.DMultiRange, dir encoding.Direction,
+) ([]byte, error) {
+	var inner []byte
+	for _, r := range m.Ranges {
+		inner = append(inner, multiRangeKeyRange)
+		var err error
+		inner, err = appendRangeKeyContents(inner, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	inner = append(inner, multiRangeKeyTerminator)
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+func appendRangeKeyContents(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return append(b, rangeKeyEmpty), nil
+	}
+	b = append(b, rangeKeyNonEmpty)
+	var err error
+	if r.Lower.Infinite() {
+		b = append(b, rangeKeyLowerInfinite)
+	} else {
+		b = append(b, rangeKeyLowerFinite)
+		if b, err = Encode(b, r.Lower.Val, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.Lower.Inclusive {
+			b = append(b, rangeKeyLowerInclusive)
+		} else {
+			b = append(b, rangeKeyLowerExclusive)
+		}
+	}
+	if r.Upper.Infinite() {
+		b = append(b, rangeKeyUpperInfinite)
+	} else {
+		b = append(b, rangeKeyUpperFinite)
+		if b, err = Encode(b, r.Upper.Val, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.Upper.Inclusive {
+			b = append(b, rangeKeyUpperInclusive)
+		} else {
+			b = append(b, rangeKeyUpperExclusive)
+		}
+	}
+	return b, nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	inner, rkey, err := decodeRangeKeyBytes(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, inner, err := decodeRangeKeyContents(a, t, inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (trailing bytes)")
+	}
+	return r, rkey, nil
+}
+
+// decodeMultiRangeKey decodes a multirange key generated by
+// encodeMultiRangeKey.
+func decodeMultiRangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	inner, rkey, err := decodeRangeKeyBytes(buf, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &tree.DMultiRange{Typ: t}
+	for {
+		if len(inner) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		marker := inner[0]
+		inner = inner[1:]
+		if marker == multiRangeKeyTerminator {
+			break
+		}
+		var r *tree.DRange
+		r, inner, err = decodeRangeKeyContents(a, t.MultiRangeContents(), inner)
+		if err != nil {
+			return nil, nil, err
+		}
+		m.Ranges = append(m.Ranges, r)
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid multirange encoding (trailing bytes)")
+	}
+	return m, rkey, nil
+}
+
+func decodeRangeKeyBytes(buf []byte, dir encoding.Direction) (inner, rkey []byte, err error) {
+	if dir == encoding.Ascending {
+		rkey, inner, err = encoding.DecodeBytesAscending(buf, nil)
+	} else {
+		rkey, inner, err = encoding.DecodeBytesDescending(buf, nil)
+	}
+	return inner, rkey, err
+}
+
+func decodeRangeKeyContents(
+	a *tree.DatumAlloc, t *types.T, buf []byte,
+) (*tree.DRange, []byte, error) {
+	if len(buf) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (missing empty flag)")
+	}
+	r := &tree.DRange{Typ: t}
+	if buf[0] == rangeKeyEmpty {
+		r.Empty = true
+		return r, buf[1:], nil
+	}
+	buf = buf[1:]
+	var err error
+	if len(buf) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (missing lower bound)")
+	}
+	lowerMarker := buf[0]
+	buf = buf[1:]
+	if lowerMarker == rangeKeyLowerFinite {
+		if r.Lower.Val, buf, err = Decode(a, t.RangeContents(), buf, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		if len(buf) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid range encoding (missing lower inclusivity)")
+		}
+		r.Lower.Inclusive = buf[0] == rangeKeyLowerInclusive
+		buf = buf[1:]
+	}
+	if len(buf) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (missing upper bound)")
+	}
+	upperMarker := buf[0]
+	buf = buf[1:]
+	if upperMarker == rangeKeyUpperFinite {
+		if r.Upper.Val, buf, err = Decode(a, t.RangeContents(), buf, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		if len(buf) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid range encoding (missing upper inclusivity)")
+		}
+		r.Upper.Inclusive = buf[0] == rangeKeyUpperInclusive
+		buf = buf[1:]
+	}
+	return r, buf, nil
+}
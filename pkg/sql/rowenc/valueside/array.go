@@ -11,6 +11,7 @@
 package valueside
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -231,10 +232,19 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.Int, nil
 	case types.UuidFamily:
 		return encoding.UUID, nil
-	case types.INetFamily:
+	case types.INetFamily, types.CIDRFamily:
 		return encoding.IPAddr, nil
+	case types.MacAddrFamily, types.MacAddr8Family:
+		return encoding.Int, nil
 	case types.JsonFamily:
 		return encoding.JSON, nil
+	case types.JsonpathFamily:
+		return encoding.Bytes, nil
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		return encoding.Bytes, nil
+	case types.RangeFamily, types.MultiRangeFamily:
+		return encoding.Bytes, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
 	default:
@@ -303,6 +313,12 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedUUIDValue(b, t.UUID), nil
 	case *tree.DIPAddr:
 		return encoding.EncodeUntaggedIPAddrValue(b, t.IPAddr), nil
+	case *tree.DCIDR:
+		return encoding.EncodeUntaggedIPAddrValue(b, t.IPAddr), nil
+	case *tree.DMacAddr:
+		return encoding.EncodeUntaggedIntValue(b, int64(t.MacAddr)), nil
+	case *tree.DMacAddr8:
+		return encoding.EncodeUntaggedIntValue(b, int64(t.MacAddr8)), nil
 	case *tree.DOid:
 		return encoding.EncodeUntaggedIntValue(b, int64(t.Oid)), nil
 	case *tree.DCollatedString:
@@ -319,6 +335,22 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTuple:
 		return encodeUntaggedTuple(t, b, encoding.NoColumnID, nil)
+	case *tree.DJsonpath:
+		return encoding.EncodeUntaggedBytesValue(b, []byte(t.Path.String())), nil
+	case *tree.DGeometric:
+		return encoding.EncodeUntaggedBytesValue(b, pggeom.AppendBinary(nil, t.Shape)), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(nil, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DMultiRange:
+		encoded, err := encodeMultiRange(nil, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTSQuery:
 		encoded := tsearch.EncodeTSQueryPGBinary(nil, t.TSQuery)
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
@@ -12,6 +12,7 @@ package valueside
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -19,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
@@ -181,6 +183,21 @@ func DecodeUntaggedDatum(
 	case types.INetFamily:
 		b, data, err := encoding.DecodeUntaggedIPAddrValue(buf)
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: data}), b, err
+	case types.CIDRFamily:
+		b, data, err := encoding.DecodeUntaggedIPAddrValue(buf)
+		return tree.NewDCIDR(tree.DCIDR{IPAddr: data}), b, err
+	case types.MacAddrFamily:
+		b, data, err := encoding.DecodeUntaggedIntValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDMacAddr(macaddr.MacAddr(data)), b, nil
+	case types.MacAddr8Family:
+		b, data, err := encoding.DecodeUntaggedIntValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDMacAddr8(macaddr.MacAddr8(data)), b, nil
 	case types.JsonFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -196,6 +213,51 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.JsonpathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		v, err := tree.ParseDJsonpath(string(data))
+		return v, b, err
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		k, _ := tree.GeometricKind(t)
+		s, err := pggeom.DecodeBinary(k, data)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDGeometric(s), b, nil
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, data, err := decodeRange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		if len(data) != 0 {
+			return nil, b, errors.AssertionFailedf("invalid range encoding (trailing bytes)")
+		}
+		return r, b, nil
+	case types.MultiRangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		m, data, err := decodeMultiRange(a, t, data)
+		if err != nil {
+			return nil, b, err
+		}
+		if len(data) != 0 {
+			return nil, b, errors.AssertionFailedf("invalid multirange encoding (trailing bytes)")
+		}
+		return m, b, nil
 	case types.TSQueryFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -11,6 +11,7 @@
 package valueside
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -77,12 +78,34 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 		return encoding.EncodeUUIDValue(appendTo, uint32(colID), t.UUID), nil
 	case *tree.DIPAddr:
 		return encoding.EncodeIPAddrValue(appendTo, uint32(colID), t.IPAddr), nil
+	case *tree.DCIDR:
+		return encoding.EncodeIPAddrValue(appendTo, uint32(colID), t.IPAddr), nil
+	case *tree.DMacAddr:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.MacAddr)), nil
+	case *tree.DMacAddr8:
+		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.MacAddr8)), nil
 	case *tree.DJSON:
 		encoded, err := json.EncodeJSON(scratch, t.JSON)
 		if err != nil {
 			return nil, err
 		}
 		return encoding.EncodeJSONValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DJsonpath:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Path.String())), nil
+	case *tree.DGeometric:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), pggeom.AppendBinary(scratch, t.Shape)), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(scratch, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DMultiRange:
+		encoded, err := encodeMultiRange(scratch, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
 	case *tree.DTSQuery:
 		encoded, err := tsearch.EncodeTSQuery(scratch, t.TSQuery)
 		if err != nil {
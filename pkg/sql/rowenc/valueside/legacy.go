@@ -12,6 +12,7 @@ package valueside
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -19,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -93,6 +95,16 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetInt(int64(v.LSN))
 			return r, nil
 		}
+	case types.MacAddrFamily:
+		if v, ok := val.(*tree.DMacAddr); ok {
+			r.SetInt(int64(v.MacAddr))
+			return r, nil
+		}
+	case types.MacAddr8Family:
+		if v, ok := val.(*tree.DMacAddr8); ok {
+			r.SetInt(int64(v.MacAddr8))
+			return r, nil
+		}
 	case types.GeographyFamily:
 		if v, ok := val.(*tree.DGeography); ok {
 			err := r.SetGeo(v.SpatialObject())
@@ -139,6 +151,12 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.CIDRFamily:
+		if v, ok := val.(*tree.DCIDR); ok {
+			data := v.ToBuffer(nil)
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.JsonFamily:
 		if v, ok := val.(*tree.DJSON); ok {
 			data, err := json.EncodeJSON(nil, v.JSON)
@@ -148,6 +166,35 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetString(v.Path.String())
+			return r, nil
+		}
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		if v, ok := val.(*tree.DGeometric); ok {
+			r.SetBytes(pggeom.AppendBinary(nil, v.Shape))
+			return r, nil
+		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			data, err := encodeRange(nil, v)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.MultiRangeFamily:
+		if v, ok := val.(*tree.DMultiRange); ok {
+			data, err := encodeMultiRange(nil, v)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.TSQueryFamily:
 		if v, ok := val.(*tree.DTSQuery); ok {
 			data := tsearch.EncodeTSQueryPGBinary(nil, v.TSQuery)
@@ -249,6 +296,18 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDPGLSN(tree.DPGLSN{LSN: lsn.LSN(v)}), nil
+	case types.MacAddrFamily:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDMacAddr(macaddr.MacAddr(v)), nil
+	case types.MacAddr8Family:
+		v, err := value.GetInt()
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDMacAddr8(macaddr.MacAddr8(v)), nil
 	case types.FloatFamily:
 		v, err := value.GetFloat()
 		if err != nil {
@@ -360,6 +419,17 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr}), nil
+	case types.CIDRFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		var ipAddr ipaddr.IPAddr
+		_, err = ipAddr.FromBuffer(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDCIDR(tree.DCIDR{IPAddr: ipAddr}), nil
 	case types.OidFamily:
 		v, err := value.GetInt()
 		if err != nil {
@@ -391,6 +461,38 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDJSON(jsonDatum), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.ParseDJsonpath(string(v))
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		k, _ := tree.GeometricKind(typ)
+		s, err := pggeom.DecodeBinary(k, v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDGeometric(s), nil
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		r, _, err := decodeRange(a, typ, v)
+		return r, err
+	case types.MultiRangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		m, _, err := decodeMultiRange(a, typ, v)
+		return m, err
 	case types.TSQueryFamily:
 		v, err := value.GetBytes()
 		if err != nil {
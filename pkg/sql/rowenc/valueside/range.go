@@ -0,0 +1,118 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags describing the shape of an encoded range. They match the flags
+// used by Postgres in the binary format of ranges.
+const (
+	rangeFlagEmpty         = 0x01
+	rangeFlagLowerInc      = 0x02
+	rangeFlagUpperInc      = 0x04
+	rangeFlagLowerInfinite = 0x08
+	rangeFlagUpperInfinite = 0x10
+)
+
+// encodeRange encodes a range as a flags byte followed by the tagged value
+// encodings of its finite bounds.
+func encodeRange(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return append(b, rangeFlagEmpty), nil
+	}
+	var flags byte
+	if r.Lower.Inclusive {
+		flags |= rangeFlagLowerInc
+	}
+	if r.Upper.Inclusive {
+		flags |= rangeFlagUpperInc
+	}
+	if r.Lower.Infinite() {
+		flags |= rangeFlagLowerInfinite
+	}
+	if r.Upper.Infinite() {
+		flags |= rangeFlagUpperInfinite
+	}
+	b = append(b, flags)
+	var err error
+	for _, bound := range [2]tree.RangeBound{r.Lower, r.Upper} {
+		if bound.Infinite() {
+			continue
+		}
+		if b, err = Encode(b, NoColumnID, bound.Val, nil /* scratch */); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// encodeMultiRange encodes a multirange as the number of its ranges followed
+// by the encoding of each range.
+func encodeMultiRange(b []byte, m *tree.DMultiRange) ([]byte, error) {
+	b = encoding.EncodeNonsortingUvarint(b, uint64(len(m.Ranges)))
+	for _, r := range m.Ranges {
+		var err error
+		if b, err = encodeRange(b, r); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range encoded by encodeRange.
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (missing flags)")
+	}
+	flags := b[0]
+	b = b[1:]
+	r := &tree.DRange{Typ: t}
+	if flags&rangeFlagEmpty != 0 {
+		r.Empty = true
+		return r, b, nil
+	}
+	var err error
+	if flags&rangeFlagLowerInfinite == 0 {
+		if r.Lower.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+		r.Lower.Inclusive = flags&rangeFlagLowerInc != 0
+	}
+	if flags&rangeFlagUpperInfinite == 0 {
+		if r.Upper.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+		r.Upper.Inclusive = flags&rangeFlagUpperInc != 0
+	}
+	return r, b, nil
+}
+
+// decodeMultiRange decodes a multirange encoded by encodeMultiRange.
+func decodeMultiRange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultiRange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &tree.DMultiRange{Typ: t, Ranges: make([]*tree.DRange, n)}
+	for i := range m.Ranges {
+		if m.Ranges[i], b, err = decodeRange(a, t.MultiRangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return m, b, nil
+}
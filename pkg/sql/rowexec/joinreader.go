@@ -530,6 +530,7 @@ func newJoinReader(
 		}
 		singleRowLookup := readerType == indexJoinReaderType || spec.LookupColumnsAreKey
 		streamingKVFetcher = row.NewStreamingKVFetcher(
+			ctx,
 			flowCtx.Cfg.DistSender,
 			flowCtx.Stopper(),
 			jr.txn,
@@ -561,6 +562,7 @@ func newJoinReader(
 			StreamingKVFetcher:         streamingKVFetcher,
 			Txn:                        jr.txn,
 			LockStrength:               spec.LockingStrength,
+			Settings:                   flowCtx.EvalCtx.Settings,
 			LockWaitPolicy:             spec.LockingWaitPolicy,
 			LockTimeout:                flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:                      &jr.alloc,
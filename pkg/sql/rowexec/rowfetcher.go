@@ -45,6 +45,8 @@ type rowFetcher interface {
 	NextRowInto(
 		ctx context.Context, destination rowenc.EncDatumRow, colIdxMap catalog.TableColMap,
 	) (ok bool, err error)
+	// RowKey returns the index key of the row last returned by NextRow.
+	RowKey() roachpb.Key
 
 	Reset()
 	GetBytesRead() int64
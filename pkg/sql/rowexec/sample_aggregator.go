@@ -107,6 +107,9 @@ func newSampleAggregator(
 		if s.GenerateHistogram && len(s.Columns) != 1 {
 			return nil, errors.Errorf("histograms require one column")
 		}
+		if s.GenerateDependencies && len(s.Columns) < 2 {
+			return nil, errors.Errorf("dependencies require at least two columns")
+		}
 	}
 
 	// Limit the memory use by creating a child monitor with a hard limit.
@@ -155,6 +158,11 @@ func newSampleAggregator(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateDependencies {
+			for _, c := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(c))
+			}
+		}
 	}
 
 	s.sr.Init(
@@ -514,6 +522,18 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 					return err
 				}
 				histogram = &h
+			} else if si.spec.GenerateDependencies && len(s.sr.Get()) != 0 {
+				colIdxs := make([]int, len(si.spec.Columns))
+				for i, c := range si.spec.Columns {
+					colIdxs[i] = int(c)
+				}
+				deps, err := stats.FunctionalDependencies(s.EvalCtx, s.sr.Get(), colIdxs)
+				if err != nil {
+					return err
+				}
+				if len(deps) > 0 {
+					histogram = &stats.HistogramData{Dependencies: deps}
+				}
 			}
 
 			columnIDs := make([]descpb.ColumnID, len(si.spec.Columns))
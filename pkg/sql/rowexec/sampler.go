@@ -146,6 +146,11 @@ func newSamplerProcessor(
 		if spec.Sketches[i].GenerateHistogram {
 			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
 		}
+		if spec.Sketches[i].GenerateDependencies {
+			for _, c := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(c))
+			}
+		}
 	}
 	for i := range spec.InvertedSketches {
 		var sr stats.SampleReservoir
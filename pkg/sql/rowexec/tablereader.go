@@ -50,6 +50,13 @@ type tableReader struct {
 
 	ignoreMisplannedRanges bool
 
+	// sampler is set if the tableReader only returns a random sample of the
+	// rows (see TableReaderSpec.Sample).
+	sampler *execinfra.TableSampler
+	// emptySample is set if none of the spans were selected by the sampler,
+	// in which case the scan is not started.
+	emptySample bool
+
 	// fetcher wraps a row.Fetcher, allowing the tableReader to add a stat
 	// collection layer.
 	fetcher rowFetcher
@@ -107,6 +114,7 @@ func newTableReader(
 	tr.parallelize = spec.Parallelize
 	tr.batchBytesLimit = batchBytesLimit
 	tr.maxTimestampAge = time.Duration(spec.MaxTimestampAgeNanos)
+	tr.sampler = execinfra.NewTableSampler(spec.Sample)
 
 	// Make sure the key column types are hydrated. The fetched column types
 	// will be hydrated in ProcessorBase.Init below.
@@ -152,6 +160,7 @@ func newTableReader(
 			Txn:                        flowCtx.Txn,
 			Reverse:                    spec.Reverse,
 			LockStrength:               spec.LockingStrength,
+			Settings:                   flowCtx.EvalCtx.Settings,
 			LockWaitPolicy:             spec.LockingWaitPolicy,
 			LockTimeout:                flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:                      &tr.alloc,
@@ -213,15 +222,26 @@ func (tr *tableReader) startScan(ctx context.Context) error {
 		bytesLimit = tr.batchBytesLimit
 	}
 	log.VEventf(ctx, 1, "starting scan with limitBatches %t", limitBatches)
+	spans := tr.Spans
 	var err error
+	if tr.sampler != nil {
+		if spans, err = tr.sampler.SampleSpans(ctx, tr.FlowCtx.Cfg.DistSender, spans); err != nil {
+			return err
+		}
+		if len(spans) == 0 {
+			tr.emptySample = true
+			tr.scanStarted = true
+			return nil
+		}
+	}
 	if tr.maxTimestampAge == 0 {
 		err = tr.fetcher.StartScan(
-			ctx, tr.Spans, nil /* spanIDs */, bytesLimit, tr.limitHint,
+			ctx, spans, nil /* spanIDs */, bytesLimit, tr.limitHint,
 		)
 	} else {
 		initialTS := tr.FlowCtx.Txn.ReadTimestamp()
 		err = tr.fetcher.StartInconsistentScan(
-			ctx, tr.FlowCtx.Cfg.DB.KV(), initialTS, tr.maxTimestampAge, tr.Spans,
+			ctx, tr.FlowCtx.Cfg.DB.KV(), initialTS, tr.maxTimestampAge, spans,
 			bytesLimit, tr.limitHint, tr.EvalCtx.QualityOfService(),
 		)
 	}
@@ -264,6 +284,10 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 				break
 			}
 		}
+		if tr.emptySample {
+			tr.MoveToDraining(nil /* err */)
+			break
+		}
 		// Check if it is time to emit a progress update.
 		if tr.rowsRead >= tableReaderProgressFrequency {
 			meta := execinfrapb.GetProducerMeta()
@@ -284,6 +308,9 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 		// case can avoid tracking of the stall time which gives a noticeable
 		// performance hit.
 		tr.rowsRead++
+		if tr.sampler != nil && !tr.sampler.SelectRow(tr.fetcher.RowKey()) {
+			continue
+		}
 		if outRow := tr.ProcessRowHelper(row); outRow != nil {
 			return outRow, nil
 		}
@@ -469,6 +469,7 @@ func (z *zigzagJoiner) setupInfo(
 		row.FetcherInitArgs{
 			Txn:                        flowCtx.Txn,
 			LockStrength:               spec.LockingStrength,
+			Settings:                   flowCtx.EvalCtx.Settings,
 			LockWaitPolicy:             spec.LockingWaitPolicy,
 			LockTimeout:                flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:                      &info.alloc,
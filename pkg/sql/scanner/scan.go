@@ -294,6 +294,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.JSON_ALL_EXISTS)
 			return
+		case '#': // ?#
+			s.pos++
+			lval.SetID(lexbase.GEOMETRIC_INTERSECTS)
+			return
 		}
 		return
 
@@ -321,6 +325,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.CONTAINED_BY)
 			return
+		case '-': // <-
+			if s.peekN(1) == '>' {
+				// <->
+				s.pos += 2
+				lval.SetID(lexbase.GEOMETRIC_DISTANCE)
+				return
+			}
 		}
 		return
 
@@ -405,6 +416,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.AT_AT)
 			return
+		case '?': // @?
+			s.pos++
+			lval.SetID(lexbase.AT_QUESTION)
+			return
 		}
 		return
 
@@ -419,6 +434,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 
 	case '-':
 		switch s.peek() {
+		case '|': // -|
+			if s.peekN(1) == '-' {
+				// -|-
+				s.pos += 2
+				lval.SetID(lexbase.RANGE_ADJACENT)
+				return
+			}
 		case '>': // ->
 			if s.peekN(1) == '>' {
 				// ->>
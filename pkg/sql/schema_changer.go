@@ -468,6 +468,24 @@ func (sc *SchemaChanger) maybeUpdateScheduledJobsForRowLevelTTL(
 	return nil
 }
 
+// maybeDropScheduledJobForRollingPartitioning drops the schedule which
+// maintains the rolling partitions of the table if the table is being dropped.
+func (sc *SchemaChanger) maybeDropScheduledJobForRollingPartitioning(
+	ctx context.Context, tableDesc catalog.TableDescriptor,
+) error {
+	if !tableDesc.Dropped() || !tableDesc.HasRollingPartitioning() {
+		return nil
+	}
+	return sc.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		scheduleID := tableDesc.GetRollingPartitioning().ScheduleID
+		if scheduleID > 0 {
+			log.Infof(ctx, "dropping rolling partition schedule %d", scheduleID)
+			return DeleteSchedule(ctx, sc.execCfg, txn, scheduleID)
+		}
+		return nil
+	})
+}
+
 func (sc *SchemaChanger) maybeBackfillMaterializedView(
 	ctx context.Context, table catalog.TableDescriptor,
 ) error {
@@ -857,6 +875,10 @@ func (sc *SchemaChanger) exec(ctx context.Context) error {
 		return err
 	}
 
+	if err := sc.maybeDropScheduledJobForRollingPartitioning(ctx, tableDesc); err != nil {
+		return err
+	}
+
 	if sc.mutationID == descpb.InvalidMutationID {
 		// Nothing more to do.
 		isCreateTableAs := tableDesc.Adding() && tableDesc.IsAs()
@@ -435,6 +435,32 @@ func (b *builderState) NextTableConstraintID(tableID catid.DescID) (ret catid.Co
 	return ret
 }
 
+// NextTableTriggerID implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) NextTableTriggerID(tableID catid.DescID) (ret catid.TriggerID) {
+	{
+		b.ensureDescriptor(tableID)
+		desc := b.descCache[tableID].desc
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			panic(errors.AssertionFailedf("Expected table descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = tbl.GetNextTriggerID()
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	// Consult all present trigger elements in case their ID is larger.
+	scpb.ForEachTrigger(b.QueryByID(tableID), func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.Trigger,
+	) {
+		if e.TriggerID >= ret {
+			ret = e.TriggerID + 1
+		}
+	})
+	return ret
+}
+
 // NextTableTentativeIndexID implements the scbuildstmt.TableHelpers interface.
 func (b *builderState) NextTableTentativeIndexID(tableID catid.DescID) (ret catid.IndexID) {
 	ret = catid.IndexID(scbuildstmt.TableTentativeIdsStart)
@@ -481,6 +507,13 @@ func (b *builderState) IsTableEmpty(table *scpb.Table) bool {
 	return b.tr.IsTableEmpty(b.ctx, table.TableID, index.IndexID)
 }
 
+// TableHasPolicies implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) TableHasPolicies(tableID catid.DescID) bool {
+	b.ensureDescriptor(tableID)
+	tbl, ok := b.descCache[tableID].desc.(catalog.TableDescriptor)
+	return ok && len(tbl.GetPolicies()) > 0
+}
+
 func (b *builderState) nextIndexID(id catid.DescID) (ret catid.IndexID) {
 	{
 		b.ensureDescriptor(id)
@@ -956,6 +989,10 @@ func (b *builderState) ResolveUserDefinedTypeType(
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
 			"cannot modify table record type %q", typ.GetName()))
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domains are only supported by the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"domain type %q is not supported by the declarative schema changer", typ.GetName()))
 	default:
 		panic(errors.AssertionFailedf("unknown type kind %s", typ.GetKind()))
 	}
@@ -1252,7 +1289,11 @@ func (b *builderState) ResolveUDF(
 	}
 
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
-	b.mustOwn(fnID)
+	if p.RequiredPrivilege != 0 && !p.RequireOwnership {
+		b.checkPrivilege(fnID, p.RequiredPrivilege)
+	} else {
+		b.mustOwn(fnID)
+	}
 	b.ensureDescriptor(fnID)
 	return b.QueryByID(fnID)
 }
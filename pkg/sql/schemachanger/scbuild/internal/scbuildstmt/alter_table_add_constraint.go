@@ -41,9 +41,15 @@ func alterTableAddConstraint(
 ) {
 	switch d := t.ConstraintDef.(type) {
 	case *tree.UniqueConstraintTableDef:
+		if d.Deferrability.Deferrable && !d.WithoutIndex {
+			panic(tree.NewDeferrableUniqueIndexError())
+		}
 		if d.PrimaryKey {
 			alterTableAddPrimaryKey(b, tn, tbl, t)
 		} else if d.WithoutIndex {
+			if d.Deferrability.Deferrable {
+				panic(scerrors.NotImplementedErrorf(t, "DEFERRABLE unique constraint"))
+			}
 			alterTableAddUniqueWithoutIndex(b, tn, tbl, t)
 		} else {
 			if t.ValidationBehavior == tree.ValidationSkip {
@@ -61,7 +67,15 @@ func alterTableAddConstraint(
 	case *tree.CheckConstraintTableDef:
 		alterTableAddCheck(b, tn, tbl, t)
 	case *tree.ForeignKeyConstraintTableDef:
+		if d.Deferrability.Deferrable {
+			panic(scerrors.NotImplementedErrorf(t, "DEFERRABLE foreign key constraint"))
+		}
 		alterTableAddForeignKey(b, tn, tbl, t)
+	case *tree.ExclusionConstraintTableDef:
+		if d.Deferrability.Deferrable {
+			panic(scerrors.NotImplementedErrorf(t, "DEFERRABLE exclusion constraint"))
+		}
+		alterTableAddExclusion(b, tn, tbl, t)
 	}
 }
 
@@ -536,6 +550,110 @@ func alterTableAddUniqueWithoutIndex(
 	})
 }
 
+// alterTableAddExclusion contains logic for building
+// `ALTER TABLE ... ADD EXCLUDE ... [NOT VALID]`.
+// It assumes `t` is such a command.
+func alterTableAddExclusion(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableAddConstraint,
+) {
+	d := t.ConstraintDef.(*tree.ExclusionConstraintTableDef)
+
+	// 1. Check that each operator is supported and defined for the type of its
+	// column.
+	colIDs := make([]catid.ColumnID, len(d.Elems))
+	ops := make([]string, len(d.Elems))
+	colNames := make([]string, len(d.Elems))
+	for i, elem := range d.Elems {
+		colID := getColumnIDFromColumnName(b, tbl.TableID, elem.Column, true /* required */)
+		typ := mustRetrieveColumnTypeElem(b, tbl.TableID, colID).Type
+		op, err := schemaexpr.ExclusionOperator(elem.Operator.String())
+		if err != nil {
+			panic(err)
+		}
+		if err := schemaexpr.CheckExclusionOperatorType(op, string(elem.Column), typ); err != nil {
+			panic(err)
+		}
+		colIDs[i] = colID
+		ops[i] = op.String()
+		colNames[i] = string(elem.Column)
+	}
+
+	// 2. If a name is provided, check that this name is not used; Otherwise,
+	// generate a unique name for it, following the Postgres naming scheme.
+	if skip, err := validateConstraintNameIsNotUsed(b, tn, tbl, t); err != nil {
+		panic(err)
+	} else if skip {
+		return
+	}
+	if d.Name == "" {
+		d.Name = tree.Name(tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", tn.Object(), strings.Join(colNames, "_")),
+			func(name string) bool {
+				return constraintNameInUse(b, tbl.TableID, name)
+			},
+		))
+	}
+
+	// 3. If there is a predicate, validate it.
+	if d.Predicate != nil {
+		predicate, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, d.Predicate, types.Bool,
+			tree.UniqueWithoutIndexPredicateExpr, b.SemaCtx(), volatility.Immutable, tn, b.ClusterSettings().Version.ActiveVersion(b),
+			func() colinfo.ResultColumns {
+				return getNonDropResultColumns(b, tbl.TableID)
+			},
+			func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+				return columnLookupFn(b, tbl.TableID, columnName)
+			},
+		)
+		if err != nil {
+			panic(err)
+		}
+		typedPredicate, err := parser.ParseExpr(predicate)
+		if err != nil {
+			panic(err)
+		}
+		d.Predicate = typedPredicate
+	}
+
+	// 4. Add a UniqueWithoutIndex, ConstraintName element to builder state.
+	// Exclusion constraints are unique without index constraints with an
+	// operator for each column.
+	constraintID := b.NextTableConstraintID(tbl.TableID)
+	if t.ValidationBehavior == tree.ValidationDefault {
+		uwi := &scpb.UniqueWithoutIndexConstraint{
+			TableID:               tbl.TableID,
+			ConstraintID:          constraintID,
+			ColumnIDs:             colIDs,
+			IndexIDForValidation:  getIndexIDForValidationForConstraint(b, tbl.TableID),
+			ExclusionOperators:    ops,
+			ExclusionAccessMethod: d.AccessMethod,
+		}
+		if d.Predicate != nil {
+			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
+		}
+		b.Add(uwi)
+		b.LogEventForExistingTarget(uwi)
+	} else {
+		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
+			TableID:               tbl.TableID,
+			ConstraintID:          constraintID,
+			ColumnIDs:             colIDs,
+			ExclusionOperators:    ops,
+			ExclusionAccessMethod: d.AccessMethod,
+		}
+		if d.Predicate != nil {
+			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
+		}
+		b.Add(uwi)
+		b.LogEventForExistingTarget(uwi)
+	}
+	b.Add(&scpb.ConstraintWithoutIndexName{
+		TableID:      tbl.TableID,
+		ConstraintID: constraintID,
+		Name:         string(d.Name),
+	})
+}
+
 // getFullyResolvedColNames returns fully resolved column names for `colNames`.
 // For each column name in `colNames`, its fully resolved name will be "db.sc.tbl.col".
 // The order of column names in the return is in syc with that in the input `colNames`.
@@ -567,7 +685,8 @@ func areColsUniqueInTable(b BuildCtx, tableID catid.DescID, columnIDs []catid.Co
 		case *scpb.SecondaryIndex:
 			ret = isIndexUniqueAndCanServeFK(b, &te.Index, columnIDs)
 		case *scpb.UniqueWithoutIndexConstraint:
-			if te.Predicate == nil && descpb.ColumnIDs(te.ColumnIDs).PermutationOf(columnIDs) {
+			if te.Predicate == nil && len(te.ExclusionOperators) == 0 &&
+				descpb.ColumnIDs(te.ColumnIDs).PermutationOf(columnIDs) {
 				ret = true
 			}
 		}
@@ -598,6 +717,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		ifNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		ifNotExists = d.IfNotExists
 	default:
 		return false, errors.AssertionFailedf(
 			"unsupported constraint: %T", t.ConstraintDef)
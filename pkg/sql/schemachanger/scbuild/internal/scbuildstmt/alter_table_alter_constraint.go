@@ -0,0 +1,179 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+)
+
+func alterTableAlterConstraint(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableAlterConstraint,
+) {
+	constraintElems := b.ResolveConstraint(tbl.TableID, t.Constraint, ResolveParams{
+		IsExistenceOptional: false,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+
+	// 1. We can only alter non-index-backed constraints. Panic if not.
+	_, _, constraintNameElem := scpb.FindConstraintWithoutIndexName(constraintElems)
+	if constraintNameElem == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"constraint %q of relation %q is not a foreign key, check, or unique without index"+
+				" constraint", tree.ErrString(&t.Constraint), tree.ErrString(tn)))
+	}
+
+	// 2. Un-validating a constraint is not supported yet.
+	if t.SetValidation && t.Validation == tree.ValidationSkip {
+		panic(scerrors.NotImplementedErrorf(t, "ALTER CONSTRAINT ... NOT VALID"))
+	}
+
+	// 3. Compute the new reference actions, if any.
+	constraintID := constraintNameElem.ConstraintID
+	var fkActions *scpb.ForeignKeyConstraintReferenceActions
+	if t.SetDeleteAction || t.SetUpdateAction {
+		fkActions = alterForeignKeyReferenceActions(b, tn, tbl, t, constraintElems)
+	}
+
+	// 4. Validate the constraint, carrying over the new reference actions to
+	//    the validated sibling if the constraint is a foreign key.
+	if t.SetValidation {
+		if skip, err := shouldSkipValidatingConstraint(b, tbl.TableID, constraintID); err != nil {
+			panic(err)
+		} else if !skip {
+			validateConstraint(b, tbl.TableID, validateConstraintSpec{
+				constraintNameElem: constraintNameElem,
+				ckNotValidElem:     retrieveCheckConstraintUnvalidatedElem(b, tbl.TableID, constraintID),
+				uwiNotValidElem:    retrieveUniqueWithoutIndexConstraintUnvalidatedElem(b, tbl.TableID, constraintID),
+				fkNotValidElem:     retrieveForeignKeyConstraintUnvalidatedElem(b, tbl.TableID, constraintID),
+				fkActions:          fkActions,
+			})
+			return
+		}
+	}
+
+	// 5. Otherwise, change the reference actions in place.
+	if fkActions != nil {
+		b.Add(fkActions)
+	}
+}
+
+// alterForeignKeyReferenceActions returns an element which changes the
+// reference actions of the foreign key constraint in constraintElems as
+// specified by t.
+func alterForeignKeyReferenceActions(
+	b BuildCtx,
+	tn *tree.TableName,
+	tbl *scpb.Table,
+	t *tree.AlterTableAlterConstraint,
+	constraintElems ElementResultSet,
+) *scpb.ForeignKeyConstraintReferenceActions {
+	var ret *scpb.ForeignKeyConstraintReferenceActions
+	var columnIDs []catid.ColumnID
+	var isPublic, isAltered bool
+	constraintElems.ForEach(func(
+		current scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) {
+		init := func(
+			constraintID catid.ConstraintID,
+			referencedTableID catid.DescID,
+			onUpdate, onDelete semenumpb.ForeignKeyAction,
+		) {
+			ret = &scpb.ForeignKeyConstraintReferenceActions{
+				TableID:                tbl.TableID,
+				ConstraintID:           constraintID,
+				ReferencedTableID:      referencedTableID,
+				OnUpdateAction:         onUpdate,
+				OnDeleteAction:         onDelete,
+				PreviousOnUpdateAction: onUpdate,
+				PreviousOnDeleteAction: onDelete,
+			}
+			isPublic = current == scpb.Status_PUBLIC && target == scpb.ToPublic
+		}
+		switch e := e.(type) {
+		case *scpb.ForeignKeyConstraint:
+			init(e.ConstraintID, e.ReferencedTableID, e.OnUpdateAction, e.OnDeleteAction)
+			columnIDs = e.ColumnIDs
+		case *scpb.ForeignKeyConstraintUnvalidated:
+			init(e.ConstraintID, e.ReferencedTableID, e.OnUpdateAction, e.OnDeleteAction)
+			columnIDs = e.ColumnIDs
+		case *scpb.ForeignKeyConstraintReferenceActions:
+			isAltered = target == scpb.ToPublic
+		}
+	})
+	if ret == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"constraint %q of relation %q is not a foreign key constraint",
+			tree.ErrString(&t.Constraint), tree.ErrString(tn)))
+	}
+	if !isPublic {
+		_, _, tableNameElem := scpb.FindNamespace(b.QueryByID(tbl.TableID))
+		panic(sqlerrors.NewUndefinedConstraintError(string(t.Constraint), tableNameElem.Name))
+	}
+	if isAltered {
+		panic(scerrors.NotImplementedErrorf(t,
+			"altering the reference actions of a foreign key constraint more than once in a transaction"))
+	}
+
+	actions := tree.ReferenceActions{
+		Delete: tree.ForeignKeyReferenceActionType[ret.OnDeleteAction],
+		Update: tree.ForeignKeyReferenceActionType[ret.OnUpdateAction],
+	}
+	if t.SetDeleteAction {
+		actions.Delete = t.Actions.Delete
+	}
+	if t.SetUpdateAction {
+		actions.Update = t.Actions.Update
+	}
+	for _, colID := range columnIDs {
+		colName := mustRetrieveColumnNameElem(b, tbl.TableID, colID).Name
+
+		// 1. If the `ON UPDATE behavior` is changed to something other than NO
+		// ACTION or RESTRICT, and the column has an `ON UPDATE expr`, panic.
+		if t.SetUpdateAction && actions.Update != tree.NoAction && actions.Update != tree.Restrict &&
+			retrieveColumnOnUpdateExpressionElem(b, tbl.TableID, colID) != nil {
+			panic(pgerror.Newf(
+				pgcode.InvalidTableDefinition,
+				"cannot specify a foreign key update action and an ON UPDATE"+
+					" expression on the same column",
+			))
+		}
+
+		// 2. SET NULL actions cannot be used with NOT NULL columns.
+		colIsNotNull := isColNotNull(b, tbl.TableID, colID)
+		if (actions.Delete == tree.SetNull || actions.Update == tree.SetNull) && colIsNotNull {
+			panic(pgerror.Newf(pgcode.InvalidForeignKey,
+				"cannot add a SET NULL cascading action on column %q which has a NOT NULL constraint",
+				colName,
+			))
+		}
+
+		// 3. SET DEFAULT actions cannot be used with NOT NULL columns which
+		// default to NULL.
+		if (actions.Delete == tree.SetDefault || actions.Update == tree.SetDefault) && colIsNotNull &&
+			retrieveColumnDefaultExpressionElem(b, tbl.TableID, colID) == nil {
+			panic(pgerror.Newf(pgcode.InvalidForeignKey,
+				"cannot add a SET DEFAULT cascading action on column %q which has a "+
+					"NOT NULL constraint and a NULL default expression", colName,
+			))
+		}
+	}
+	ret.OnUpdateAction = tree.ForeignKeyReferenceActionValue[actions.Update]
+	ret.OnDeleteAction = tree.ForeignKeyReferenceActionValue[actions.Delete]
+	return ret
+}
@@ -70,6 +70,7 @@ func alterPrimaryKey(b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t alterPri
 	// TODO (xiang): This section contains all fall-back cases and need to
 	// be removed to fully support `ALTER PRIMARY KEY`.
 	fallBackIfShardedIndexExists(b, t, tbl.TableID)
+	fallBackIfBRINIndexExists(b, t, tbl.TableID)
 	fallBackIfPartitionedIndexExists(b, t, tbl.TableID)
 	fallBackIfRegionalByRowTable(b, t.n, tbl.TableID)
 	fallBackIfDescColInRowLevelTTLTables(b, tbl.TableID, t)
@@ -428,6 +429,18 @@ func fallBackIfShardedIndexExists(b BuildCtx, t alterPrimaryKeySpec, tableID cat
 	})
 }
 
+// fallBackIfBRINIndexExists panics with an unimplemented error if there exist
+// BRIN indexes on the table.
+func fallBackIfBRINIndexExists(b BuildCtx, t alterPrimaryKeySpec, tableID catid.DescID) {
+	tableElts := b.QueryByID(tableID).Filter(notFilter(absentTargetFilter))
+	scpb.ForEachSecondaryIndex(tableElts, func(_ scpb.Status, _ scpb.TargetStatus, idx *scpb.SecondaryIndex) {
+		if idx.IsBrin {
+			panic(scerrors.NotImplementedErrorf(t.n, "ALTER PRIMARY KEY on a table with BRIN "+
+				"indexes is not yet supported."))
+		}
+	})
+}
+
 // fallBackIfRegionalByRowTable panics with an unimplemented
 // error if it's a REGIONAL BY ROW table because we need to
 // include the implicit REGION column when constructing the
@@ -33,6 +33,7 @@ func alterTableDropColumn(
 ) {
 	fallBackIfSubZoneConfigExists(b, n, tbl.TableID)
 	fallBackIfRegionalByRowTable(b, n, tbl.TableID)
+	fallBackIfTableHasPolicies(b, n, tbl.TableID)
 	checkSafeUpdatesForDropColumn(b)
 	checkRegionalByRowColumnConflict(b, tbl, n)
 	// Version gates functionally that is implemented after the statement is
@@ -271,6 +272,10 @@ func dropColumn(
 				)
 			}
 			dropCascadeDescriptor(b, e.FunctionID)
+		case *scpb.TriggerEvents:
+			dropTriggerReferencingColumn(b, tbl, cn, e.TriggerID, behavior)
+		case *scpb.TriggerWhen:
+			dropTriggerReferencingColumn(b, tbl, cn, e.TriggerID, behavior)
 		case *scpb.UniqueWithoutIndexConstraint:
 			// Until the appropriate version gate is hit, we still do not allow
 			// dropping unique without index constraints.
@@ -325,6 +330,33 @@ func dropColumn(
 	assertAllColumnElementsAreDropped(colElts)
 }
 
+// dropTriggerReferencingColumn drops the trigger that fires on updates of the
+// dropped column or whose WHEN condition references it, or panics if the drop
+// behavior is not CASCADE.
+func dropTriggerReferencingColumn(
+	b BuildCtx,
+	tbl *scpb.Table,
+	cn *scpb.ColumnName,
+	triggerID catid.TriggerID,
+	behavior tree.DropBehavior,
+) {
+	triggerElts := triggerElements(b, tbl.TableID, triggerID)
+	_, target, triggerName := scpb.FindTriggerName(triggerElts)
+	if target != scpb.ToPublic {
+		return
+	}
+	if behavior != tree.DropCascade {
+		_, _, ns := scpb.FindNamespace(b.QueryByID(tbl.TableID))
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop column %s because trigger %s on table %s depends on it",
+				cn.Name, triggerName.Name, ns.Name),
+			"Use DROP ... CASCADE to drop the dependent objects too.",
+		))
+	}
+	dropTrigger(b, tbl.TableID, triggerID)
+}
+
 func walkDropColumnDependencies(b BuildCtx, col *scpb.Column, fn func(e scpb.Element)) {
 	var sequencesToDrop catalog.DescriptorIDSet
 	var indexesToDrop catid.IndexSet
@@ -341,7 +373,8 @@ func walkDropColumnDependencies(b BuildCtx, col *scpb.Column, fn func(e scpb.Ele
 			case *scpb.Column, *scpb.ColumnName, *scpb.ColumnComment, *scpb.ColumnNotNull,
 				*scpb.ColumnDefaultExpression, *scpb.ColumnOnUpdateExpression,
 				*scpb.UniqueWithoutIndexConstraint, *scpb.CheckConstraint,
-				*scpb.UniqueWithoutIndexConstraintUnvalidated, *scpb.CheckConstraintUnvalidated:
+				*scpb.UniqueWithoutIndexConstraintUnvalidated, *scpb.CheckConstraintUnvalidated,
+				*scpb.TriggerEvents, *scpb.TriggerWhen:
 				fn(e)
 			case *scpb.ColumnType:
 				if elt.ColumnID == col.ColumnID {
@@ -111,6 +111,9 @@ type validateConstraintSpec struct {
 	ckNotValidElem     *scpb.CheckConstraintUnvalidated
 	uwiNotValidElem    *scpb.UniqueWithoutIndexConstraintUnvalidated
 	fkNotValidElem     *scpb.ForeignKeyConstraintUnvalidated
+	// fkActions, if set, overrides the reference actions of the validated
+	// foreign key constraint.
+	fkActions *scpb.ForeignKeyConstraintReferenceActions
 }
 
 func validateConstraint(b BuildCtx, tableID catid.DescID, spec validateConstraintSpec) {
@@ -129,13 +132,21 @@ func validateConstraint(b BuildCtx, tableID catid.DescID, spec validateConstrain
 	if spec.uwiNotValidElem != nil {
 		b.Drop(spec.uwiNotValidElem)
 		b.Add(&scpb.UniqueWithoutIndexConstraint{
-			TableID:      tableID,
-			ConstraintID: nextConstraintID,
-			ColumnIDs:    spec.uwiNotValidElem.ColumnIDs,
-			Predicate:    spec.uwiNotValidElem.Predicate,
+			TableID:               tableID,
+			ConstraintID:          nextConstraintID,
+			ColumnIDs:             spec.uwiNotValidElem.ColumnIDs,
+			Predicate:             spec.uwiNotValidElem.Predicate,
+			ExclusionOperators:    spec.uwiNotValidElem.ExclusionOperators,
+			ExclusionAccessMethod: spec.uwiNotValidElem.ExclusionAccessMethod,
 		})
 	}
 	if spec.fkNotValidElem != nil {
+		onUpdateAction := spec.fkNotValidElem.OnUpdateAction
+		onDeleteAction := spec.fkNotValidElem.OnDeleteAction
+		if spec.fkActions != nil {
+			onUpdateAction = spec.fkActions.OnUpdateAction
+			onDeleteAction = spec.fkActions.OnDeleteAction
+		}
 		b.Drop(spec.fkNotValidElem)
 		b.Add(&scpb.ForeignKeyConstraint{
 			TableID:                 tableID,
@@ -143,8 +154,8 @@ func validateConstraint(b BuildCtx, tableID catid.DescID, spec validateConstrain
 			ColumnIDs:               spec.fkNotValidElem.ColumnIDs,
 			ReferencedTableID:       spec.fkNotValidElem.ReferencedTableID,
 			ReferencedColumnIDs:     spec.fkNotValidElem.ReferencedColumnIDs,
-			OnUpdateAction:          spec.fkNotValidElem.OnUpdateAction,
-			OnDeleteAction:          spec.fkNotValidElem.OnDeleteAction,
+			OnUpdateAction:          onUpdateAction,
+			OnDeleteAction:          onDeleteAction,
 			CompositeKeyMatchMethod: spec.fkNotValidElem.CompositeKeyMatchMethod,
 			IndexIDForValidation:    getIndexIDForValidationForConstraint(b, tableID),
 		})
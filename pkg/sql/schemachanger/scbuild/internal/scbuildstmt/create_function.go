@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
@@ -28,6 +29,13 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	for _, option := range n.Options {
+		switch option.(type) {
+		case tree.RoutineSecurity, tree.RoutineSetVar, tree.RoutineCost,
+			tree.RoutineRows, tree.RoutineSupport, tree.RoutineParallel:
+			panic(scerrors.NotImplementedErrorf(n, "function option %s", tree.AsString(option)))
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	dbElts, scElts := b.ResolveTargetObject(n.Name.ToUnresolvedObjectName(), privilege.CREATE)
@@ -61,9 +69,9 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 
 	fnID := b.GenerateUniqueDescID()
 	fn := scpb.Function{
-		FunctionID: fnID,
-		ReturnSet:  n.ReturnType.IsSet,
-		ReturnType: b.ResolveTypeRef(n.ReturnType.Type),
+		FunctionID:  fnID,
+		ReturnSet:   n.ReturnType.IsSet,
+		IsProcedure: n.IsProcedure,
 	}
 	fn.Params = make([]scpb.Function_Parameter, len(n.Params))
 	for i, param := range n.Params {
@@ -82,6 +90,16 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 			Type:  b.ResolveTypeRef(param.Type),
 		}
 	}
+	if n.IsProcedure {
+		// The result of a procedure is determined by its OUT and INOUT
+		// parameters.
+		paramTypes := make([]*types.T, len(fn.Params))
+		for i := range fn.Params {
+			paramTypes[i] = fn.Params[i].Type.Type
+		}
+		n.ReturnType.Type = funcinfo.MakeProcedureReturnType(n.Params, paramTypes)
+	}
+	fn.ReturnType = b.ResolveTypeRef(n.ReturnType.Type)
 
 	// Add function element.
 	b.Add(&fn)
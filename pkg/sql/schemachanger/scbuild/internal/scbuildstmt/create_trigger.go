@@ -0,0 +1,209 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// CreateTrigger implements CREATE TRIGGER.
+func CreateTrigger(b BuildCtx, n *tree.CreateTrigger) {
+	b.IncrementSchemaChangeCreateCounter("trigger")
+
+	elts := b.ResolveTable(n.TableName, ResolveParams{
+		RequiredPrivilege: privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(elts)
+	_, _, ns := scpb.FindNamespace(elts)
+	if !b.HasOwnership(tbl) {
+		panic(pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(ns.Name)))
+	}
+	panicIfSchemaIsLocked(elts)
+	tn := tree.MakeTableNameFromPrefix(b.NamePrefix(tbl), tree.Name(ns.Name))
+
+	switch n.ActionTime {
+	case tree.TriggerActionTimeBefore, tree.TriggerActionTimeAfter:
+	case tree.TriggerActionTimeInsteadOf:
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a table", ns.Name),
+			"Tables cannot have INSTEAD OF triggers.",
+		))
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger action time %v", n.ActionTime))
+	}
+	if n.ForEach != tree.TriggerForEachRow {
+		panic(unimplemented.New("statement-level triggers",
+			"statement-level triggers are not yet supported"))
+	}
+	if len(n.Transitions) > 0 {
+		panic(unimplemented.New("trigger transition tables",
+			"REFERENCING clauses of triggers are not yet supported"))
+	}
+
+	triggerID := b.NextTableTriggerID(tbl.TableID)
+	timing := &scpb.TriggerTiming{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+	}
+	if n.ActionTime == tree.TriggerActionTimeAfter {
+		timing.ActionTime = semenumpb.TriggerActionTime_AFTER
+	}
+	events := &scpb.TriggerEvents{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+	}
+	var updateColIDs catalog.TableColSet
+	for _, event := range n.Events {
+		var e semenumpb.TriggerEventType
+		switch event.EventType {
+		case tree.TriggerEventInsert:
+			e = semenumpb.TriggerEventType_INSERT
+		case tree.TriggerEventUpdate:
+			e = semenumpb.TriggerEventType_UPDATE
+		case tree.TriggerEventDelete:
+			e = semenumpb.TriggerEventType_DELETE
+		case tree.TriggerEventTruncate:
+			panic(unimplemented.New("TRUNCATE triggers",
+				"TRUNCATE triggers are not yet supported"))
+		default:
+			panic(errors.AssertionFailedf("unexpected trigger event %v", event.EventType))
+		}
+		hasEvent := false
+		for _, other := range events.Events {
+			hasEvent = hasEvent || other == e
+		}
+		if !hasEvent {
+			events.Events = append(events.Events, e)
+		}
+		for _, colName := range event.Columns {
+			colID := getColumnIDFromColumnName(b, tbl.TableID, colName, true /* required */)
+			if !updateColIDs.Contains(colID) {
+				updateColIDs.Add(colID)
+				events.UpdateColumnIDs = append(events.UpdateColumnIDs, colID)
+			}
+		}
+	}
+
+	var when *scpb.TriggerWhen
+	if n.When != nil {
+		validateTriggerWhenExpr(b, &tn, tbl, n)
+		when = &scpb.TriggerWhen{
+			TableID:    tbl.TableID,
+			TriggerID:  triggerID,
+			Expression: *b.WrapExpression(tbl.TableID, n.When),
+		}
+	}
+
+	fn := resolveTriggerFunction(b, n.FuncName)
+
+	if existing := triggerIDByName(b, tbl.TableID, string(n.Name)); existing != 0 {
+		if !n.Replace {
+			panic(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", n.Name, ns.Name))
+		}
+		// The replaced trigger is dropped and the new one is added with a new ID.
+		dropTrigger(b, tbl.TableID, existing)
+	}
+
+	trigger := &scpb.Trigger{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+	}
+	b.Add(trigger)
+	b.Add(&scpb.TriggerName{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Name:      string(n.Name),
+	})
+	b.Add(timing)
+	b.Add(events)
+	if when != nil {
+		b.Add(when)
+	}
+	b.Add(&scpb.TriggerFunctionCall{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		FuncID:    fn.FunctionID,
+		FuncArgs:  n.FuncArgs,
+	})
+	b.LogEventForExistingTarget(trigger)
+}
+
+// resolveTriggerFunction resolves the function with the given name that takes
+// no arguments and returns type trigger, and checks that the current user may
+// execute it.
+func resolveTriggerFunction(b BuildCtx, funcName *tree.UnresolvedName) *scpb.Function {
+	name, err := funcName.ToFunctionName()
+	if err != nil {
+		panic(err)
+	}
+	elts := b.ResolveUDF(&tree.FuncObj{
+		FuncName: name,
+		Params:   tree.RoutineParams{},
+	}, ResolveParams{
+		RequiredPrivilege: privilege.EXECUTE,
+	})
+	_, _, fn := scpb.FindFunction(elts)
+	if fn.IsProcedure || fn.ReturnType.Type.Family() != types.TriggerFamily {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", name.Object()))
+	}
+	return fn
+}
+
+// validateTriggerWhenExpr validates the WHEN condition of the given trigger.
+// See schemaexpr.DequalifyTriggerWhenExpr for the columns it may reference.
+func validateTriggerWhenExpr(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, n *tree.CreateTrigger,
+) {
+	expr, err := schemaexpr.DequalifyTriggerWhenExpr(n)
+	if err != nil {
+		panic(err)
+	}
+	if _, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, expr, types.Bool,
+		tree.TriggerWhenExpr, b.SemaCtx(), volatility.Volatile, tn, b.ClusterSettings().Version.ActiveVersion(b),
+		func() colinfo.ResultColumns {
+			return getNonDropResultColumns(b, tbl.TableID)
+		},
+		func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+			return columnLookupFn(b, tbl.TableID, columnName)
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// triggerIDByName returns the ID of the trigger of the table with the given
+// name, or zero if there is none.
+func triggerIDByName(b BuildCtx, tableID catid.DescID, name string) (id catid.TriggerID) {
+	scpb.ForEachTriggerName(b.QueryByID(tableID).Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.TriggerName,
+	) {
+		if e.Name == name {
+			id = e.TriggerID
+		}
+	})
+	return id
+}
@@ -243,6 +243,10 @@ type TableHelpers interface {
 	// added to this table.
 	NextTableConstraintID(tableID catid.DescID) catid.ConstraintID
 
+	// NextTableTriggerID returns the ID that should be used for any new trigger
+	// added to this table.
+	NextTableTriggerID(tableID catid.DescID) catid.TriggerID
+
 	// NextTableTentativeIndexID returns the tentative ID, starting from
 	// scbuild.TABLE_TENTATIVE_IDS_START, that should be used for any new index added to
 	// this table.
@@ -278,6 +282,10 @@ type TableHelpers interface {
 
 	// IsTableEmpty returns if the table is empty or not.
 	IsTableEmpty(tbl *scpb.Table) bool
+
+	// TableHasPolicies returns whether the table has row-level security
+	// policies.
+	TableHasPolicies(tableID catid.DescID) bool
 }
 
 type FunctionHelpers interface {
@@ -26,6 +26,9 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		// TODO(chengxiong): remove this when we allow UDF usage.
 		panic(scerrors.NotImplementedErrorf(n, "cascade dropping functions"))
 	}
+	if n.IsAggregate {
+		panic(scerrors.NotImplementedErrorf(n, "dropping user-defined aggregates"))
+	}
 
 	var toCheckBackRefs []catid.DescID
 	var toCheckBackRefsNames []*scpb.FunctionName
@@ -37,6 +40,13 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		if fn == nil {
 			continue
 		}
+		if fn.IsProcedure != n.IsProcedure {
+			kind := "function"
+			if n.IsProcedure {
+				kind = "procedure"
+			}
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a %s", f.FuncName.Object(), kind))
+		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
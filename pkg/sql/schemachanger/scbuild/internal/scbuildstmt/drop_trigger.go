@@ -0,0 +1,82 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/screl"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropTrigger implements DROP TRIGGER.
+func DropTrigger(b BuildCtx, n *tree.DropTrigger) {
+	elts := b.ResolveTable(n.Table, ResolveParams{
+		IsExistenceOptional: n.IfExists,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(elts)
+	if tbl == nil {
+		b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+			"relation %q does not exist, skipping", n.Table.Object(),
+		))
+		return
+	}
+	_, _, ns := scpb.FindNamespace(elts)
+	if !b.HasOwnership(tbl) {
+		panic(pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(ns.Name)))
+	}
+	panicIfSchemaIsLocked(elts)
+
+	triggerID := triggerIDByName(b, tbl.TableID, string(n.Trigger))
+	if triggerID == 0 {
+		if n.IfExists {
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+				"trigger %q for table %q does not exist, skipping", n.Trigger, ns.Name,
+			))
+			return
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.Trigger, ns.Name))
+	}
+	_, _, trigger := scpb.FindTrigger(triggerElements(b, tbl.TableID, triggerID))
+	dropTrigger(b, tbl.TableID, triggerID)
+	b.LogEventForExistingTarget(trigger)
+	b.IncrementSchemaChangeDropCounter("trigger")
+}
+
+// dropTrigger drops all the elements of the trigger with the given ID.
+func dropTrigger(b BuildCtx, tableID catid.DescID, triggerID catid.TriggerID) {
+	triggerElements(b, tableID, triggerID).ForEach(func(
+		_ scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) {
+		if target == scpb.ToPublic {
+			b.Drop(e)
+		}
+	})
+}
+
+// triggerElements returns the elements of the trigger with the given ID.
+func triggerElements(
+	b BuildCtx, tableID catid.DescID, triggerID catid.TriggerID,
+) ElementResultSet {
+	return b.QueryByID(tableID).Filter(func(
+		_ scpb.Status, _ scpb.TargetStatus, e scpb.Element,
+	) bool {
+		idI, _ := screl.Schema.GetAttribute(screl.TriggerID, e)
+		return idI != nil && idI.(catid.TriggerID) == triggerID
+	})
+}
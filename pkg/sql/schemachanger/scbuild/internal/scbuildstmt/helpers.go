@@ -224,6 +224,10 @@ func dropCascadeDescriptor(b BuildCtx, id catid.DescID) {
 			dropCascadeDescriptor(next, t.TypeID)
 		case *scpb.FunctionBody:
 			dropCascadeDescriptor(next, t.FunctionID)
+		case *scpb.TriggerFunctionCall:
+			dropTrigger(next, t.TableID, t.TriggerID)
+		case *scpb.TriggerWhen:
+			dropTrigger(next, t.TableID, t.TriggerID)
 		case *scpb.Column, *scpb.ColumnType, *scpb.SecondaryIndexPartial:
 			// These only have type references.
 			break
@@ -867,6 +871,16 @@ func fallBackIfSubZoneConfigExists(b BuildCtx, n tree.NodeFormatter, id catid.De
 	}
 }
 
+// fallBackIfTableHasPolicies throws an unimplemented error if the table has
+// row-level security policies, which are not supported by the declarative
+// schema changer.
+func fallBackIfTableHasPolicies(b BuildCtx, n tree.NodeFormatter, id catid.DescID) {
+	if b.TableHasPolicies(id) {
+		panic(scerrors.NotImplementedErrorf(n,
+			"row-level security policies are not supported"))
+	}
+}
+
 // fallBackIfVirtualColumnWithNotNullConstraint throws an unimplemented error
 // if the to-be-added column `d` is a virtual column with not null constraint.
 // This is a quick, temporary fix for the following troubled stmt in the
@@ -66,6 +66,8 @@ var supportedStatements = map[reflect.Type]supportedStatement{
 	reflect.TypeOf((*tree.CreateRoutine)(nil)):       {fn: CreateFunction, statementTag: tree.CreateRoutineTag, on: true, checks: isV231Active},
 	reflect.TypeOf((*tree.CreateSchema)(nil)):        {fn: CreateSchema, statementTag: tree.CreateSchemaTag, on: false, checks: isV232Active},
 	reflect.TypeOf((*tree.CreateSequence)(nil)):      {fn: CreateSequence, statementTag: tree.CreateSequenceTag, on: false, checks: isV232Active},
+	reflect.TypeOf((*tree.CreateTrigger)(nil)):       {fn: CreateTrigger, statementTag: tree.CreateTriggerTag, on: true, checks: isV232Active},
+	reflect.TypeOf((*tree.DropTrigger)(nil)):         {fn: DropTrigger, statementTag: tree.DropTriggerTag, on: true, checks: isV232Active},
 }
 
 // supportedStatementTags tracks statement tags which are implemented
@@ -217,6 +217,9 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if typ.AsDomainTypeDescriptor() != nil {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"domain type %q is not supported by the declarative schema changer", typ.GetName()))
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -395,6 +398,9 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	for _, c := range tbl.OutboundForeignKeys() {
 		w.walkForeignKeyConstraint(tbl, c)
 	}
+	for i := range tbl.GetTriggers() {
+		w.walkTrigger(tbl, &tbl.GetTriggers()[i])
+	}
 
 	_ = tbl.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 		w.backRefs.Add(dep.ID)
@@ -582,6 +588,7 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 			IndexID:             idx.GetID(),
 			IsUnique:            idx.IsUnique(),
 			IsInverted:          idx.GetType() == descpb.IndexDescriptor_INVERTED,
+			IsBrin:              idx.GetType() == descpb.IndexDescriptor_BRIN,
 			IsCreatedExplicitly: idx.IsCreatedExplicitly(),
 			ConstraintID:        idx.GetConstraintID(),
 			IsNotVisible:        idx.GetInvisibility() != 0.0,
@@ -700,20 +707,30 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 				c.GetName(), tbl.GetName(), tbl.GetID()))
 		}
 	}
+	colIDs := c.CollectKeyColumnIDs().Ordered()
+	if c.IsExclusion() {
+		// The operators of an exclusion constraint are in the order in which the
+		// columns were declared.
+		colIDs = c.UniqueWithoutIndexDesc().ColumnIDs
+	}
 	if c.IsConstraintUnvalidated() && w.clusterVersion.IsActive(clusterversion.V23_1) {
 		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:               tbl.GetID(),
+			ConstraintID:          c.GetConstraintID(),
+			ColumnIDs:             colIDs,
+			Predicate:             expr,
+			ExclusionOperators:    c.ExclusionOperators(),
+			ExclusionAccessMethod: c.UniqueWithoutIndexDesc().ExclusionAccessMethod,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	} else {
 		uwi := &scpb.UniqueWithoutIndexConstraint{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:               tbl.GetID(),
+			ConstraintID:          c.GetConstraintID(),
+			ColumnIDs:             colIDs,
+			Predicate:             expr,
+			ExclusionOperators:    c.ExclusionOperators(),
+			ExclusionAccessMethod: c.UniqueWithoutIndexDesc().ExclusionAccessMethod,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	}
@@ -807,13 +824,67 @@ func (w *walkCtx) walkForeignKeyConstraint(
 	}
 }
 
+func (w *walkCtx) walkTrigger(tbl catalog.TableDescriptor, t *descpb.TriggerDescriptor) {
+	w.ev(scpb.Status_PUBLIC, &scpb.Trigger{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerName{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Name:      t.Name,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerTiming{
+		TableID:    tbl.GetID(),
+		TriggerID:  t.ID,
+		ActionTime: t.ActionTime,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerEvents{
+		TableID:         tbl.GetID(),
+		TriggerID:       t.ID,
+		Events:          t.Events,
+		UpdateColumnIDs: t.UpdateColumnIDs,
+	})
+	if t.WhenExpr != "" {
+		expr, err := w.newExpression(t.WhenExpr)
+		if err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "trigger %q in table %q (%d)",
+				t.Name, tbl.GetName(), tbl.GetID()))
+		}
+		w.ev(scpb.Status_PUBLIC, &scpb.TriggerWhen{
+			TableID:    tbl.GetID(),
+			TriggerID:  t.ID,
+			Expression: *expr,
+		})
+	}
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerFunctionCall{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		FuncID:    t.FuncID,
+		FuncArgs:  t.FuncArgs,
+	})
+}
+
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
+	// User-defined aggregates and the support functions they reference are
+	// only handled by the legacy schema changer.
+	if fnDesc.GetAggregate() != nil {
+		panic(scerrors.NotImplementedErrorf(nil, "user-defined aggregate %q", fnDesc.GetName()))
+	}
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		if w.lookupFn(ref.ID).DescriptorType() == catalog.Function {
+			panic(scerrors.NotImplementedErrorf(
+				nil, "function %q is referenced by a user-defined aggregate", fnDesc.GetName(),
+			))
+		}
+	}
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
-		FunctionID: fnDesc.GetID(),
-		ReturnSet:  fnDesc.GetReturnType().ReturnSet,
-		ReturnType: *typeT,
-		Params:     make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		FunctionID:  fnDesc.GetID(),
+		ReturnSet:   fnDesc.GetReturnType().ReturnSet,
+		ReturnType:  *typeT,
+		Params:      make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		IsProcedure: fnDesc.FuncDesc().IsProcedure,
 	}
 	for i, param := range fnDesc.GetParams() {
 		typeT := newTypeT(param.Type)
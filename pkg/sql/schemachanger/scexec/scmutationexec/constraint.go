@@ -507,6 +507,44 @@ func (i *immediateVisitor) MakePublicForeignKeyConstraintValidated(
 	return errors.AssertionFailedf("failed to find FK constraint %d in descriptor %v", op.ConstraintID, out)
 }
 
+func (i *immediateVisitor) SetForeignKeyConstraintReferenceActions(
+	ctx context.Context, op scop.SetForeignKeyConstraintReferenceActions,
+) error {
+	out, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil || out.Dropped() {
+		return err
+	}
+	var fk *descpb.ForeignKeyConstraint
+	for idx := range out.OutboundFKs {
+		if out.OutboundFKs[idx].ConstraintID == op.ConstraintID {
+			fk = &out.OutboundFKs[idx]
+			break
+		}
+	}
+	if fk == nil {
+		return errors.AssertionFailedf("failed to find FK constraint %d in table %q (%d)",
+			op.ConstraintID, out.GetName(), out.GetID())
+	}
+	fk.OnUpdate = op.OnUpdateAction
+	fk.OnDelete = op.OnDeleteAction
+
+	// Update the back-reference in the referenced table. As with other inbound
+	// FK updates, the constraint is identified by its name.
+	in, err := i.checkOutTable(ctx, op.ReferencedTableID)
+	if err != nil || in.Dropped() {
+		return err
+	}
+	for idx, inboundFK := range in.InboundFKs {
+		if inboundFK.OriginTableID == op.TableID && inboundFK.Name == fk.Name {
+			in.InboundFKs[idx].OnUpdate = op.OnUpdateAction
+			in.InboundFKs[idx].OnDelete = op.OnDeleteAction
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("failed to find accompanying inbound FK (%v) in"+
+		" referenced table %v (%v)", op.ConstraintID, in.Name, in.ID)
+}
+
 func (i *immediateVisitor) AddUniqueWithoutIndexConstraint(
 	ctx context.Context, op scop.AddUniqueWithoutIndexConstraint,
 ) error {
@@ -525,6 +563,9 @@ func (i *immediateVisitor) AddUniqueWithoutIndexConstraint(
 		Validity:     op.Validity,
 		ConstraintID: op.ConstraintID,
 		Predicate:    string(op.PartialExpr),
+
+		ExclusionOperators:    op.ExclusionOperators,
+		ExclusionAccessMethod: op.ExclusionAccessMethod,
 	}
 	if op.Validity == descpb.ConstraintValidity_Unvalidated {
 		// Unvalidated constraint doesn't need to transition through an intermediate
@@ -0,0 +1,132 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/errors"
+)
+
+func (i *immediateVisitor) AddTrigger(ctx context.Context, op scop.AddTrigger) error {
+	tbl, err := i.checkOutTable(ctx, op.Trigger.TableID)
+	if err != nil {
+		return err
+	}
+	if tbl.GetTriggerByID(op.Trigger.TriggerID) != nil {
+		return errors.AssertionFailedf("trigger with ID %d already exists in table %d",
+			op.Trigger.TriggerID, op.Trigger.TableID)
+	}
+	tbl.Triggers = append(tbl.Triggers, descpb.TriggerDescriptor{ID: op.Trigger.TriggerID})
+	if op.Trigger.TriggerID >= tbl.NextTriggerID {
+		tbl.NextTriggerID = op.Trigger.TriggerID + 1
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTrigger(ctx context.Context, op scop.RemoveTrigger) error {
+	tbl, err := i.checkOutTable(ctx, op.Trigger.TableID)
+	if err != nil {
+		return err
+	}
+	tbl.RemoveTrigger(op.Trigger.TriggerID)
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerName(ctx context.Context, op scop.SetTriggerName) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Name.TableID, op.Name.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.Name = op.Name.Name
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerTiming(ctx context.Context, op scop.SetTriggerTiming) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Timing.TableID, op.Timing.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.ActionTime = op.Timing.ActionTime
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerEvents(ctx context.Context, op scop.SetTriggerEvents) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Events.TableID, op.Events.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.Events = op.Events.Events
+	trigger.UpdateColumnIDs = op.Events.UpdateColumnIDs
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerWhen(ctx context.Context, op scop.SetTriggerWhen) error {
+	trigger, err := i.checkOutTrigger(ctx, op.When.TableID, op.When.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.WhenExpr = string(op.When.Expr)
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerFunctionCall(
+	ctx context.Context, op scop.SetTriggerFunctionCall,
+) error {
+	trigger, err := i.checkOutTrigger(ctx, op.FunctionCall.TableID, op.FunctionCall.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.FuncID = op.FunctionCall.FuncID
+	trigger.FuncArgs = op.FunctionCall.FuncArgs
+	return nil
+}
+
+func (i *immediateVisitor) AddTriggerBackReferenceInFunction(
+	ctx context.Context, op scop.AddTriggerBackReferenceInFunction,
+) error {
+	fn, err := i.checkOutFunction(ctx, op.FunctionID)
+	if err != nil {
+		return err
+	}
+	fn.AddTriggerReference(op.BackReferencedTableID, op.BackReferencedTriggerID)
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTriggerBackReferenceInFunction(
+	ctx context.Context, op scop.RemoveTriggerBackReferenceInFunction,
+) error {
+	fn, err := i.checkOutFunction(ctx, op.FunctionID)
+	if err != nil {
+		return err
+	}
+	fn.RemoveTriggerReference(op.BackReferencedTableID, op.BackReferencedTriggerID)
+	return nil
+}
+
+// checkOutTrigger returns the trigger with the given ID in the checked out
+// table descriptor.
+func (i *immediateVisitor) checkOutTrigger(
+	ctx context.Context, tableID descpb.ID, triggerID descpb.TriggerID,
+) (*descpb.TriggerDescriptor, error) {
+	tbl, err := i.checkOutTable(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	trigger := tbl.GetTriggerByID(triggerID)
+	if trigger == nil {
+		return nil, errors.AssertionFailedf("trigger with ID %d not found in table %d",
+			triggerID, tableID)
+	}
+	return trigger, nil
+}
@@ -407,6 +407,18 @@ type RemoveForeignKeyBackReference struct {
 	OriginConstraintID descpb.ConstraintID
 }
 
+// SetForeignKeyConstraintReferenceActions sets the ON UPDATE and ON DELETE
+// actions of an existing foreign key constraint, in both the origin table and
+// the back-reference in the referenced table.
+type SetForeignKeyConstraintReferenceActions struct {
+	immediateMutationOp
+	TableID           descpb.ID
+	ConstraintID      descpb.ConstraintID
+	ReferencedTableID descpb.ID
+	OnUpdateAction    semenumpb.ForeignKeyAction
+	OnDeleteAction    semenumpb.ForeignKeyAction
+}
+
 // AddUniqueWithoutIndexConstraint adds a non-existent
 // unique_without_index constraint to the table.
 type AddUniqueWithoutIndexConstraint struct {
@@ -416,6 +428,10 @@ type AddUniqueWithoutIndexConstraint struct {
 	ColumnIDs    []descpb.ColumnID
 	PartialExpr  catpb.Expression
 	Validity     descpb.ConstraintValidity
+	// ExclusionOperators and ExclusionAccessMethod are set if the constraint
+	// is an exclusion constraint.
+	ExclusionOperators    []string
+	ExclusionAccessMethod string
 }
 
 // MakeValidatedUniqueWithoutIndexConstraintPublic moves a new, validated unique_without_index
@@ -842,3 +858,59 @@ type InitSequence struct {
 	RestartWith    int64
 	UseRestartWith bool
 }
+
+// AddTrigger adds a trigger to a table. Its properties are set by the
+// operations of its dependent elements.
+type AddTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+// RemoveTrigger removes a trigger from a table.
+type RemoveTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+type SetTriggerName struct {
+	immediateMutationOp
+	Name scpb.TriggerName
+}
+
+type SetTriggerTiming struct {
+	immediateMutationOp
+	Timing scpb.TriggerTiming
+}
+
+type SetTriggerEvents struct {
+	immediateMutationOp
+	Events scpb.TriggerEvents
+}
+
+type SetTriggerWhen struct {
+	immediateMutationOp
+	When scpb.TriggerWhen
+}
+
+type SetTriggerFunctionCall struct {
+	immediateMutationOp
+	FunctionCall scpb.TriggerFunctionCall
+}
+
+// AddTriggerBackReferenceInFunction adds a back-reference to a trigger in the
+// trigger function.
+type AddTriggerBackReferenceInFunction struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID descpb.TriggerID
+	FunctionID              descpb.ID
+}
+
+// RemoveTriggerBackReferenceInFunction removes the back-reference to a trigger
+// from the trigger function.
+type RemoveTriggerBackReferenceInFunction struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID descpb.TriggerID
+	FunctionID              descpb.ID
+}
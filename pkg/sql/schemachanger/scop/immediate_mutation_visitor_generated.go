@@ -69,6 +69,7 @@ type ImmediateMutationVisitor interface {
 	MakePublicForeignKeyConstraintValidated(context.Context, MakePublicForeignKeyConstraintValidated) error
 	RemoveForeignKeyConstraint(context.Context, RemoveForeignKeyConstraint) error
 	RemoveForeignKeyBackReference(context.Context, RemoveForeignKeyBackReference) error
+	SetForeignKeyConstraintReferenceActions(context.Context, SetForeignKeyConstraintReferenceActions) error
 	AddUniqueWithoutIndexConstraint(context.Context, AddUniqueWithoutIndexConstraint) error
 	MakeValidatedUniqueWithoutIndexConstraintPublic(context.Context, MakeValidatedUniqueWithoutIndexConstraintPublic) error
 	MakePublicUniqueWithoutIndexConstraintValidated(context.Context, MakePublicUniqueWithoutIndexConstraintValidated) error
@@ -128,6 +129,15 @@ type ImmediateMutationVisitor interface {
 	CreateSequenceDescriptor(context.Context, CreateSequenceDescriptor) error
 	SetSequenceOptions(context.Context, SetSequenceOptions) error
 	InitSequence(context.Context, InitSequence) error
+	AddTrigger(context.Context, AddTrigger) error
+	RemoveTrigger(context.Context, RemoveTrigger) error
+	SetTriggerName(context.Context, SetTriggerName) error
+	SetTriggerTiming(context.Context, SetTriggerTiming) error
+	SetTriggerEvents(context.Context, SetTriggerEvents) error
+	SetTriggerWhen(context.Context, SetTriggerWhen) error
+	SetTriggerFunctionCall(context.Context, SetTriggerFunctionCall) error
+	AddTriggerBackReferenceInFunction(context.Context, AddTriggerBackReferenceInFunction) error
+	RemoveTriggerBackReferenceInFunction(context.Context, RemoveTriggerBackReferenceInFunction) error
 }
 
 // Visit is part of the ImmediateMutationOp interface.
@@ -365,6 +375,11 @@ func (op RemoveForeignKeyBackReference) Visit(ctx context.Context, v ImmediateMu
 	return v.RemoveForeignKeyBackReference(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetForeignKeyConstraintReferenceActions) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetForeignKeyConstraintReferenceActions(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op AddUniqueWithoutIndexConstraint) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.AddUniqueWithoutIndexConstraint(ctx, op)
@@ -659,3 +674,48 @@ func (op SetSequenceOptions) Visit(ctx context.Context, v ImmediateMutationVisit
 func (op InitSequence) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.InitSequence(ctx, op)
 }
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerName) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerName(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerTiming) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerTiming(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerEvents) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerEvents(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerWhen) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerWhen(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerFunctionCall) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerFunctionCall(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTriggerBackReferenceInFunction) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTriggerBackReferenceInFunction(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTriggerBackReferenceInFunction) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTriggerBackReferenceInFunction(ctx, op)
+}
@@ -195,3 +195,27 @@ func checkIfDescriptorIsWithoutData(id descpb.ID, md *opGenContext) bool {
 	}
 	return !doesDescriptorHaveData
 }
+
+// checkIfConstraintWillBeRemoved returns true if the foreign key constraint
+// identified by the table and constraint IDs is targeting ABSENT, in which case
+// any changes applied to it do not need to be reverted.
+func checkIfConstraintWillBeRemoved(
+	tableID descpb.ID, constraintID descpb.ConstraintID, md *opGenContext,
+) bool {
+	for _, t := range md.Targets {
+		if t.TargetStatus != scpb.Status_ABSENT {
+			continue
+		}
+		switch e := t.Element().(type) {
+		case *scpb.ForeignKeyConstraint:
+			if e.TableID == tableID && e.ConstraintID == constraintID {
+				return true
+			}
+		case *scpb.ForeignKeyConstraintUnvalidated:
+			if e.TableID == tableID && e.ConstraintID == constraintID {
+				return true
+			}
+		}
+	}
+	return false
+}
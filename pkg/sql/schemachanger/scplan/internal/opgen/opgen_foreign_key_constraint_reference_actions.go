@@ -0,0 +1,56 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.ForeignKeyConstraintReferenceActions)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.ForeignKeyConstraintReferenceActions) *scop.SetForeignKeyConstraintReferenceActions {
+					return &scop.SetForeignKeyConstraintReferenceActions{
+						TableID:           this.TableID,
+						ConstraintID:      this.ConstraintID,
+						ReferencedTableID: this.ReferencedTableID,
+						OnUpdateAction:    this.OnUpdateAction,
+						OnDeleteAction:    this.OnDeleteAction,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(
+					this *scpb.ForeignKeyConstraintReferenceActions, md *opGenContext,
+				) *scop.SetForeignKeyConstraintReferenceActions {
+					// There is nothing to restore if the constraint itself is being
+					// removed.
+					if checkIfConstraintWillBeRemoved(this.TableID, this.ConstraintID, md) {
+						return nil
+					}
+					return &scop.SetForeignKeyConstraintReferenceActions{
+						TableID:           this.TableID,
+						ConstraintID:      this.ConstraintID,
+						ReferencedTableID: this.ReferencedTableID,
+						OnUpdateAction:    this.PreviousOnUpdateAction,
+						OnDeleteAction:    this.PreviousOnDeleteAction,
+					}
+				}),
+			),
+		),
+	)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+func init() {
+	opRegistry.register((*scpb.TriggerWhen)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.TriggerWhen) *scop.SetTriggerWhen {
+					return &scop.SetTriggerWhen{When: *protoutil.Clone(this).(*scpb.TriggerWhen)}
+				}),
+				emit(func(this *scpb.TriggerWhen) *scop.UpdateTableBackReferencesInTypes {
+					if len(this.UsesTypeIDs) == 0 {
+						return nil
+					}
+					return &scop.UpdateTableBackReferencesInTypes{
+						TypeIDs:               this.UsesTypeIDs,
+						BackReferencedTableID: this.TableID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				// The trigger has already been removed from the table at this
+				// point, see the dep rules for trigger dependents.
+				emit(func(this *scpb.TriggerWhen) *scop.UpdateTableBackReferencesInTypes {
+					if len(this.UsesTypeIDs) == 0 {
+						return nil
+					}
+					return &scop.UpdateTableBackReferencesInTypes{
+						TypeIDs:               this.UsesTypeIDs,
+						BackReferencedTableID: this.TableID,
+					}
+				}),
+			),
+		),
+	)
+}
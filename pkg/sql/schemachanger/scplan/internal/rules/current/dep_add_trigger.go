@@ -0,0 +1,37 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package current
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/rel"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	. "github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan/internal/rules"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan/internal/scgraph"
+)
+
+// This rule ensures that the trigger is added to the table in the same stage
+// as, and before, its properties are set. The table descriptor only validates
+// if the trigger is fully defined.
+func init() {
+	registerDepRule(
+		"trigger existence precedes trigger dependents",
+		scgraph.SameStagePrecedence,
+		"trigger", "dependent",
+		func(from, to NodeVars) rel.Clauses {
+			return rel.Clauses{
+				from.Type((*scpb.Trigger)(nil)),
+				to.TypeFilter(rulesVersionKey, isTriggerDependent),
+				JoinOnTriggerID(from, to, "table-id", "trigger-id"),
+				StatusesToPublicOrTransient(from, scpb.Status_PUBLIC, to, scpb.Status_PUBLIC),
+			}
+		},
+	)
+}
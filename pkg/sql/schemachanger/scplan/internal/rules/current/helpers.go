@@ -164,6 +164,11 @@ func getExpression(element scpb.Element) (*scpb.Expression, error) {
 			return nil, nil
 		}
 		return &e.Expression, nil
+	case *scpb.TriggerWhen:
+		if e == nil {
+			return nil, nil
+		}
+		return &e.Expression, nil
 	}
 	return nil, errors.AssertionFailedf("element %T does not have an embedded scpb.Expression", element)
 }
@@ -258,7 +263,7 @@ func isConstraintDependent(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.ConstraintWithoutIndexName:
 		return true
-	case *scpb.ConstraintComment:
+	case *scpb.ConstraintComment, *scpb.ForeignKeyConstraintReferenceActions:
 		return true
 	}
 	return false
@@ -272,6 +277,15 @@ func isConstraintWithIndexName(e scpb.Element) bool {
 	return false
 }
 
+func isTriggerDependent(e scpb.Element) bool {
+	switch e.(type) {
+	case *scpb.TriggerName, *scpb.TriggerTiming, *scpb.TriggerEvents, *scpb.TriggerWhen,
+		*scpb.TriggerFunctionCall:
+		return true
+	}
+	return false
+}
+
 func isData(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.DatabaseData:
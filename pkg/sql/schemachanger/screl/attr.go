@@ -68,6 +68,8 @@ const (
 	// SourceIndexID is the index ID of the source index for a newly created
 	// index.
 	SourceIndexID
+	// TriggerID is the ID of a trigger.
+	TriggerID
 
 	// TargetStatus is the target status of an element.
 	TargetStatus
@@ -345,6 +347,11 @@ var elementSchemaOptions = []rel.SchemaOption{
 		rel.EntityAttr(ConstraintID, "ConstraintID"),
 		rel.EntityAttr(Comment, "Comment"),
 	),
+	rel.EntityMapping(t((*scpb.ForeignKeyConstraintReferenceActions)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(ReferencedDescID, "ReferencedTableID"),
+		rel.EntityAttr(ConstraintID, "ConstraintID"),
+	),
 	rel.EntityMapping(t((*scpb.IndexColumn)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 		rel.EntityAttr(IndexID, "IndexID"),
@@ -395,6 +402,34 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.FunctionParamDefaultExpression)(nil)),
 		rel.EntityAttr(DescID, "FunctionID"),
 	),
+	// Trigger elements.
+	rel.EntityMapping(t((*scpb.Trigger)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerName)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(Name, "Name"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerTiming)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerEvents)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerWhen)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(ReferencedTypeIDs, "UsesTypeIDs"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerFunctionCall)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(ReferencedDescID, "FuncID"),
+	),
 }
 
 // Schema is the schema exported by this package covering the elements of scpb.
@@ -133,7 +133,9 @@ func MinElementVersion(el scpb.Element) clusterversion.Key {
 		*scpb.UniqueWithoutIndexConstraintUnvalidated, *scpb.ForeignKeyConstraintUnvalidated,
 		*scpb.IndexZoneConfig, *scpb.TableSchemaLocked:
 		return clusterversion.V23_1
-	case *scpb.SequenceOption:
+	case *scpb.SequenceOption, *scpb.ForeignKeyConstraintReferenceActions,
+		*scpb.Trigger, *scpb.TriggerName, *scpb.TriggerTiming, *scpb.TriggerEvents,
+		*scpb.TriggerWhen, *scpb.TriggerFunctionCall:
 		return clusterversion.V23_2
 	default:
 		panic(errors.AssertionFailedf("unknown element %T", el))
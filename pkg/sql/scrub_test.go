@@ -721,3 +721,49 @@ INSERT INTO db.t VALUES (1, 2), (2,3);
 	time.Sleep(1 * time.Millisecond)
 	scrubtestutils.RunScrub(t, db, `EXPERIMENTAL SCRUB TABLE db.t AS OF SYSTEM TIME '-1ms' WITH OPTIONS CONSTRAINT ALL`, exp)
 }
+
+// TestScrubExclusionConstraint tests SCRUB on a table that violates an
+// exclusion constraint.
+func TestScrubExclusionConstraint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	// Create the table and row entries. The constraint is added without
+	// validation so that the existing rows can violate it.
+	if _, err := db.Exec(`
+CREATE DATABASE db;
+CREATE TABLE db.t (
+	id INT PRIMARY KEY,
+	room INT,
+	slots INT[]
+);
+
+INSERT INTO db.t VALUES (1, 1, ARRAY[1, 2]), (2, 1, ARRAY[2, 3]), (3, 1, ARRAY[4]), (4, 2, ARRAY[1]);
+ALTER TABLE db.t ADD CONSTRAINT no_overlap EXCLUDE (room WITH =, slots WITH &&) NOT VALID;
+`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Run SCRUB
+	exp := []scrubtestutils.ExpectedScrubResult{
+		{
+			ErrorType:    scrub.ExclusionConstraintViolation,
+			Database:     "db",
+			Table:        "t",
+			PrimaryKey:   "(1)",
+			DetailsRegex: `{"constraint_name": "no_overlap", "row_data": {"id": "1", "room": "1", "slots": "ARRAY\[1,2\]"}`,
+		},
+		{
+			ErrorType:    scrub.ExclusionConstraintViolation,
+			Database:     "db",
+			Table:        "t",
+			PrimaryKey:   "(2)",
+			DetailsRegex: `{"constraint_name": "no_overlap", "row_data": {"id": "2", "room": "1", "slots": "ARRAY\[2,3\]"}`,
+		},
+	}
+	scrubtestutils.RunScrub(t, db, `EXPERIMENTAL SCRUB TABLE db.t WITH OPTIONS CONSTRAINT ALL`, exp)
+	time.Sleep(1 * time.Millisecond)
+	scrubtestutils.RunScrub(t, db, `EXPERIMENTAL SCRUB TABLE db.t AS OF SYSTEM TIME '-1ms' WITH OPTIONS CONSTRAINT ALL`, exp)
+}
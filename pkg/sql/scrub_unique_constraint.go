@@ -24,7 +24,7 @@ import (
 )
 
 // sqlUniqueConstraintCheckOperation is a check which validates a
-// UNIQUE constraint on a table.
+// UNIQUE or EXCLUDE constraint on a table.
 type sqlUniqueConstraintCheckOperation struct {
 	tableName  *tree.TableName
 	tableDesc  catalog.TableDescriptor
@@ -33,6 +33,9 @@ type sqlUniqueConstraintCheckOperation struct {
 	name       string
 	asOf       hlc.Timestamp
 	predicate  string
+	// exclusionOps is set if the constraint is an exclusion constraint, in
+	// which case it contains the operator for each of the columns in cols.
+	exclusionOps []string
 
 	// columns is a list of the columns returned in the query result
 	// tree.Datums.
@@ -82,6 +85,9 @@ func newSQLUniqueWithoutIndexConstraintCheckOperation(
 		name:       constraint.GetName(),
 		predicate:  constraint.GetPredicate(),
 	}
+	if constraint.IsExclusion() {
+		op.exclusionOps = constraint.ExclusionOperators()
+	}
 	return &op
 }
 
@@ -90,6 +96,9 @@ func newSQLUniqueWithoutIndexConstraintCheckOperation(
 // then runs in the distSQL execution engine.
 func (o *sqlUniqueConstraintCheckOperation) Start(params runParams) error {
 	ctx := params.ctx
+	if o.exclusionOps != nil {
+		return o.startExclusion(params)
+	}
 	// Create a query of the form:
 	// SELECT a,b,c FROM db.t AS tbl1 JOIN
 	//   (SELECT b, c FROM db.t GROUP BY b, c
@@ -156,6 +165,54 @@ ON %[4]s
 	return err
 }
 
+// startExclusion is the implementation of Start for exclusion constraints.
+func (o *sqlUniqueConstraintCheckOperation) startExclusion(params runParams) error {
+	ctx := params.ctx
+	// Create a query of the form:
+	// SELECT a,b,c FROM db.t AS tbl1
+	//   WHERE [(partial predicate) AND] EXISTS (
+	//     SELECT 1 FROM db.t AS tbl2
+	//     WHERE [(partial predicate) AND] tbl1.b = tbl2.b AND tbl1.c && tbl2.c
+	//     AND (tbl1.a) != (tbl2.a)
+	//   );
+	// Where a, b, and c are all the public columns in table db.t, a is the
+	// primary key, and b and c are the columns of the exclusion constraint.
+	o.columns = o.tableDesc.PublicColumns()
+	pCols := make([]string, len(o.columns))
+	for i := 0; i < len(o.columns); i++ {
+		pCols[i] = fmt.Sprintf("tbl1.%[1]s", tree.NameString(o.columns[i].GetName()))
+	}
+	asOf := ""
+	if o.asOf != hlc.MaxTimestamp {
+		asOf = fmt.Sprintf("AS OF SYSTEM TIME '%s'", o.asOf.AsOfSystemTime())
+	}
+	tableName := fmt.Sprintf("%s.%s", o.tableName.Catalog(), o.tableName.Table())
+	filter, _, err := conflictingRowFilter(o.tableDesc, o.cols, o.exclusionOps, o.predicate,
+		0 /* indexIDForValidation */)
+	if err != nil {
+		return err
+	}
+
+	sel := fmt.Sprintf(`SELECT %[1]s FROM %[2]s AS tbl1 %[3]s WHERE %[4]s`,
+		strings.Join(pCols, ","), // 1
+		tableName,                // 2
+		asOf,                     // 3
+		filter,                   // 4
+	)
+
+	rows, err := params.p.InternalSQLTxn().QueryBuffered(
+		ctx, "scrub-exclusion", params.p.txn, sel,
+	)
+	if err != nil {
+		return err
+	}
+
+	o.run.started = true
+	o.run.rows = rows
+	o.primaryColIdxs, err = getPrimaryColIdxs(o.tableDesc, o.columns)
+	return err
+}
+
 // Next implements the checkOperation interface.
 func (o *sqlUniqueConstraintCheckOperation) Next(params runParams) (tree.Datums, error) {
 	row := o.run.rows[o.run.rowIndex]
@@ -185,9 +242,13 @@ func (o *sqlUniqueConstraintCheckOperation) Next(params runParams) (tree.Datums,
 		return nil, err
 	}
 
+	errorType := scrub.UniqueConstraintViolation
+	if o.exclusionOps != nil {
+		errorType = scrub.ExclusionConstraintViolation
+	}
 	return tree.Datums{
 		tree.DNull, /* job_uuid */
-		tree.NewDString(scrub.UniqueConstraintViolation),
+		tree.NewDString(errorType),
 		tree.NewDString(o.tableName.Catalog()),
 		tree.NewDString(o.tableName.Table()),
 		tree.NewDString(primaryKeyDatums.String()),
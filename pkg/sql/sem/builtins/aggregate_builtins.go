@@ -1340,6 +1340,7 @@ const sizeOfSTMakeLineAggregate = int64(unsafe.Sizeof(stMakeLineAgg{}))
 const sizeOfSTUnionAggregate = int64(unsafe.Sizeof(stUnionAgg{}))
 const sizeOfSTCollectAggregate = int64(unsafe.Sizeof(stCollectAgg{}))
 const sizeOfSTExtentAggregate = int64(unsafe.Sizeof(stExtentAgg{}))
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
 
 // aggregateWithIntermediateResult is a common interface for aggregate functions
 // which can return a result without loss of precision. This is useful when an
@@ -1531,6 +1532,102 @@ func (a *anyNotNullAggregate) Size() int64 {
 	return sizeOfAnyNotNullAggregate
 }
 
+// See NewUserDefinedAggregate.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	evalCtx *eval.Context
+	def     *tree.UserDefinedAggregate
+	state   tree.Datum
+
+	// args is reused to pass the current state and the input arguments to the
+	// state function.
+	args tree.Datums
+}
+
+// NewUserDefinedAggregate returns an aggregate function that computes the given
+// user-defined aggregate by evaluating its state function for each input row,
+// starting from the initial condition, and then evaluating its final function,
+// if any, on the final state.
+//
+// If the aggregate has more than one argument, the arguments must be passed to
+// Add as a single tuple.
+func NewUserDefinedAggregate(
+	evalCtx *eval.Context, def *tree.UserDefinedAggregate,
+) eval.AggregateFunc {
+	return &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		evalCtx:                  evalCtx,
+		def:                      def,
+		state:                    def.InitCond,
+	}
+}
+
+// Add evaluates the state function with the current state and the given
+// arguments, and replaces the state with the result.
+func (a *userDefinedAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	a.args = append(a.args[:0], a.state)
+	if a.def.NumArgs > 1 {
+		a.args = append(a.args, tree.MustBeDTuple(datum).D...)
+	} else {
+		a.args = append(a.args, datum)
+	}
+	if !a.def.StateFunc.CalledOnNullInput {
+		// As in Postgres, rows with NULL arguments are skipped when the state
+		// function is strict. If the state is NULL, the first row replaces the
+		// state instead of being passed to the state function.
+		for _, arg := range a.args[1:] {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.state == tree.DNull {
+			if a.def.NumArgs == 1 && a.args[1].ResolvedType().Equivalent(a.def.StateType) {
+				return a.setState(ctx, a.args[1])
+			}
+			return nil
+		}
+	}
+	res, err := a.evalCtx.Planner.EvalRoutineExpr(ctx, a.def.StateFunc, a.args)
+	if err != nil {
+		return err
+	}
+	return a.setState(ctx, res)
+}
+
+func (a *userDefinedAggregate) setState(ctx context.Context, state tree.Datum) error {
+	a.state = state
+	return a.updateMemoryUsage(ctx, int64(state.Size()))
+}
+
+// Result evaluates the final function on the current state, or returns the
+// current state if there is no final function.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.def.FinalFunc == nil {
+		return a.state, nil
+	}
+	// Result is not passed a context, so the final function is evaluated with
+	// a background context.
+	ctx := context.Background()
+	return a.evalCtx.Planner.EvalRoutineExpr(ctx, a.def.FinalFunc, tree.Datums{a.state})
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = a.def.InitCond
+	a.reset(ctx)
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
 type arrayAggregate struct {
 	arr *tree.DArray
 	// Note that we do not embed singleDatumAggregateBase struct to help with
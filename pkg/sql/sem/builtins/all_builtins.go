@@ -58,7 +58,9 @@ func init() {
 			signature := name + fn.Signature(true)
 			overloads[i].Oid = signatureMustHaveHardcodedOID(signature)
 			tree.OidToBuiltinName[overloads[i].Oid] = name
-			if _, ok := CastBuiltinNames[name]; ok {
+			// Only the single argument overloads of cast builtins are casts, the
+			// others are constructors like point(x, y).
+			if _, ok := CastBuiltinNames[name]; ok && fn.Types.Length() == 1 {
 				retOid := fn.ReturnType(nil).Oid()
 				if _, ok := CastBuiltinOIDs[retOid]; !ok {
 					CastBuiltinOIDs[retOid] = make(map[types.Family]oid.Oid, len(overloads))
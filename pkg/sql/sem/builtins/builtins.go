@@ -269,14 +269,16 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToLower(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their lower-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToLower(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their lower-case equivalents.",
+				volatility.Immutable,
+			),
+		}, rangeLowerOverloads...)...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -300,14 +302,16 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToUpper(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their to their upper-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToUpper(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their to their upper-case equivalents.",
+				volatility.Immutable,
+			),
+		}, rangeUpperOverloads...)...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -853,6 +857,7 @@ var regularBuiltins = map[string]builtinDefinition{
 	// - set_masklen
 	// - text(inet)
 	// - inet_same_family
+	// - network
 
 	"abbrev": makeBuiltin(defProps(),
 		tree.Overload{
@@ -882,6 +887,17 @@ var regularBuiltins = map[string]builtinDefinition{
 				"\n\nFor example, `broadcast('192.168.1.2/24')` returns `'192.168.1.255/24'`",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.CIDR}},
+			ReturnType: tree.FixedReturnType(types.INet),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				return &tree.DIPAddr{IPAddr: dCIDR.IPAddr.Broadcast()}, nil
+			},
+			Info: "Gets the broadcast address for the network address represented by the value." +
+				"\n\nFor example, `broadcast('192.168.1.0/24'::cidr)` returns `'192.168.1.255/24'`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"family": makeBuiltin(defProps(),
@@ -899,6 +915,20 @@ var regularBuiltins = map[string]builtinDefinition{
 				"\n\nFor example, `family('::1')` returns `6`",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.CIDR}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				if dCIDR.Family == ipaddr.IPv4family {
+					return tree.NewDInt(tree.DInt(4)), nil
+				}
+				return tree.NewDInt(tree.DInt(6)), nil
+			},
+			Info: "Extracts the IP family of the value; 4 for IPv4, 6 for IPv6." +
+				"\n\nFor example, `family('::/64'::cidr)` returns `6`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"host": makeBuiltin(defProps(),
@@ -917,6 +947,18 @@ var regularBuiltins = map[string]builtinDefinition{
 				"\n\nFor example, `host('192.168.1.2/16')` returns `'192.168.1.2'`",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.CIDR}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				s := dCIDR.IPAddr.CIDRString()
+				return tree.NewDString(s[:strings.IndexByte(s, '/')]), nil
+			},
+			Info: "Extracts the address part of the network address as text." +
+				"\n\nFor example, `host('192.168.0.0/16'::cidr)` returns `'192.168.0.0'`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"hostmask": makeBuiltin(defProps(),
@@ -932,6 +974,17 @@ var regularBuiltins = map[string]builtinDefinition{
 				"\n\nFor example, `hostmask('192.168.1.2/16')` returns `'0.0.255.255'`",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.CIDR}},
+			ReturnType: tree.FixedReturnType(types.INet),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				return &tree.DIPAddr{IPAddr: dCIDR.IPAddr.Hostmask()}, nil
+			},
+			Info: "Creates an IP host mask corresponding to the prefix length in the value." +
+				"\n\nFor example, `hostmask('192.168.0.0/16'::cidr)` returns `'0.0.255.255'`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"masklen": makeBuiltin(defProps(),
@@ -946,6 +999,17 @@ var regularBuiltins = map[string]builtinDefinition{
 				"\n\nFor example, `masklen('192.168.1.2/16')` returns `16`",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.CIDR}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				return tree.NewDInt(tree.DInt(dCIDR.Mask)), nil
+			},
+			Info: "Retrieves the prefix length stored in the value." +
+				"\n\nFor example, `masklen('192.168.0.0/16'::cidr)` returns `16`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"netmask": makeBuiltin(defProps(),
@@ -961,6 +1025,17 @@ var regularBuiltins = map[string]builtinDefinition{
 				"\n\nFor example, `netmask('192.168.1.2/16')` returns `'255.255.0.0'`",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.CIDR}},
+			ReturnType: tree.FixedReturnType(types.INet),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				return &tree.DIPAddr{IPAddr: dCIDR.IPAddr.Netmask()}, nil
+			},
+			Info: "Creates an IP network mask corresponding to the prefix length in the value." +
+				"\n\nFor example, `netmask('192.168.0.0/16'::cidr)` returns `'255.255.0.0'`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"set_masklen": makeBuiltin(defProps(),
@@ -984,6 +1059,28 @@ var regularBuiltins = map[string]builtinDefinition{
 				"For example, `set_masklen('192.168.1.2', 16)` returns `'192.168.1.2/16'`.",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.CIDR},
+				{Name: "prefixlen", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.CIDR),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dCIDR := tree.MustBeDCIDR(args[0])
+				mask := int(tree.MustBeDInt(args[1]))
+
+				if !(dCIDR.Family == ipaddr.IPv4family && mask >= 0 && mask <= 32) && !(dCIDR.Family == ipaddr.IPv6family && mask >= 0 && mask <= 128) {
+					return nil, pgerror.Newf(
+						pgcode.InvalidParameterValue, "invalid mask length: %d", mask)
+				}
+				ipAddr := ipaddr.IPAddr{Family: dCIDR.Family, Addr: dCIDR.Addr, Mask: byte(mask)}
+				return tree.NewDCIDR(tree.DCIDR{IPAddr: ipAddr.Network()}), nil
+			},
+			Info: "Sets the prefix length of `val` to `prefixlen`. Bits to the right of the new " +
+				"prefix length are set to zero.\n\n" +
+				"For example, `set_masklen('192.168.1.0/24'::cidr, 16)` returns `'192.168.0.0/16'`.",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"inet_same_family": makeBuiltin(defProps(),
@@ -1019,6 +1116,20 @@ var regularBuiltins = map[string]builtinDefinition{
 				"The host part of the addresses is ignored.",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.CIDR},
+				{Name: "container", Typ: types.CIDR},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ipAddr := tree.MustBeDCIDR(args[0]).IPAddr
+				other := tree.MustBeDCIDR(args[1]).IPAddr
+				return tree.MakeDBool(tree.DBool(ipAddr.ContainedByOrEquals(&other))), nil
+			},
+			Info:       "Test for subnet inclusion or equality.",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"inet_contains_or_equals": makeBuiltin(defProps(),
@@ -1037,6 +1148,49 @@ var regularBuiltins = map[string]builtinDefinition{
 				"The host part of the addresses is ignored.",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "container", Typ: types.CIDR},
+				{Name: "val", Typ: types.CIDR},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ipAddr := tree.MustBeDCIDR(args[0]).IPAddr
+				other := tree.MustBeDCIDR(args[1]).IPAddr
+				return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrEquals(&other))), nil
+			},
+			Info:       "Test for subnet inclusion or equality.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"network": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.INet}},
+			ReturnType: tree.FixedReturnType(types.CIDR),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dIPAddr := tree.MustBeDIPAddr(args[0])
+				return tree.NewDCIDR(tree.DCIDR{IPAddr: dIPAddr.IPAddr.Network()}), nil
+			},
+			Info: "Extracts the network part of the address, zeroing out the bits to the right " +
+				"of the netmask." +
+				"\n\nFor example, `network('192.168.1.5/24')` returns `'192.168.1.0/24'`",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"macaddr8_set7bit": makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MacAddr8}},
+			ReturnType: tree.FixedReturnType(types.MacAddr8),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMacAddr8(tree.MustBeDMacAddr8(args[0]).Set7Bit()), nil
+			},
+			Info: "Sets the 7th bit of the address to one, creating what is known as a modified " +
+				"EUI-64 for inclusion in an IPv6 address." +
+				"\n\nFor example, `macaddr8_set7bit('00:34:56:ab:cd:ef')` returns `'02:34:56:ff:fe:ab:cd:ef'`",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"from_ip": makeBuiltin(defProps(),
@@ -3924,14 +4078,6 @@ value if you rely on the HLC for accuracy.`,
 	// The behavior of both the JSON and JSONB data types in CockroachDB is
 	// similar to the behavior of the JSONB data type in Postgres.
 
-	"jsonb_path_exists":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_exists_opr":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match_opr":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-
 	"json_remove_path": makeBuiltin(jsonProps(),
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "val", Typ: types.Jsonb}, {Name: "path", Typ: types.StringArray}},
@@ -8235,6 +8381,99 @@ specified store on the node it's run from. One of 'mvccGC', 'merge', 'split',
 			CalledOnNullInput: true,
 		},
 	),
+	"crdb_internal.plpgsql_close": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "name", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "cursor variable in CLOSE is null")
+				}
+				name := tree.Name(tree.MustBeDString(args[0]))
+				if err := evalCtx.Planner.PLpgSQLCloseCursor(name); err != nil {
+					return nil, err
+				}
+				return tree.DNull, nil
+			},
+			Info:              "This function is used internally to implement the PLpgSQL CLOSE statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_fetch": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "name", Typ: types.String},
+				{Name: "direction", Typ: types.Int},
+				{Name: "count", Typ: types.Int},
+				{Name: "result_types", Typ: types.AnyTuple},
+			},
+			ReturnType: tree.IdentityReturnType(3),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "cursor variable in FETCH is null")
+				}
+				if args[1] == tree.DNull || args[2] == tree.DNull {
+					return nil, errors.AssertionFailedf("expected non-null FETCH direction and count")
+				}
+				cursor := &tree.CursorStmt{
+					Name:      tree.Name(tree.MustBeDString(args[0])),
+					FetchType: tree.FetchType(tree.MustBeDInt(args[1])),
+					Count:     int64(tree.MustBeDInt(args[2])),
+				}
+				row, err := evalCtx.Planner.PLpgSQLFetchCursor(ctx, cursor)
+				if err != nil {
+					return nil, err
+				}
+				// The first element of the result indicates whether a row was
+				// found. The remaining elements are the fetched values, which are
+				// cast to the types of the FETCH targets. Like SELECT INTO, missing
+				// values are NULL and extra values are ignored.
+				resTyp := args[3].ResolvedType()
+				res := make(tree.Datums, len(resTyp.TupleContents()))
+				res[0] = tree.MakeDBool(row != nil)
+				for i := 1; i < len(res); i++ {
+					res[i] = tree.DNull
+					if i-1 >= len(row) {
+						continue
+					}
+					res[i], err = eval.PerformAssignmentCast(ctx, evalCtx, row[i-1], resTyp.TupleContents()[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				return tree.NewDTuple(resTyp, res...), nil
+			},
+			Info:              "This function is used internally to implement the PLpgSQL FETCH and MOVE statements.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_gen_cursor_name": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "name", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] != tree.DNull {
+					// The cursor variable already has a name.
+					return args[0], nil
+				}
+				return tree.NewDString(string(evalCtx.Planner.GenUniqueCursorName())), nil
+			},
+			Info:              "This function is used internally to generate names for PLpgSQL cursors.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
 	"bitmask_or": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
 		stringOverload2(
 			"a",
@@ -8461,6 +8700,8 @@ var lengthImpls = func(incBitOverload bool) builtinDefinition {
 				volatility.Immutable,
 			),
 		)
+		// Like in postgres, only length is defined for the geometric types.
+		overloads = append(overloads, geometricLengthOverloads...)
 	}
 	return makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString}, overloads...)
 }
@@ -1403,7 +1403,7 @@ var builtinOidsArray = []string{
 	1424: `obj_description(object_oid: oid, catalog_name: string) -> string`,
 	1425: `oid(int: int) -> oid`,
 	1426: `shobj_description(object_oid: oid, catalog_name: string) -> string`,
-	1427: `pg_try_advisory_lock(int: int) -> bool`,
+	1427: `pg_try_advisory_lock(key: int) -> bool`,
 	1428: `pg_advisory_unlock(key: int) -> bool`,
 	1429: `pg_client_encoding() -> string`,
 	1430: `pg_function_is_visible(oid: oid) -> bool`,
@@ -2463,6 +2463,489 @@ var builtinOidsArray = []string{
 	2492: `make_timestamptz(year: int, month: int, day: int, hour: int, min: int, sec: float, timezone: string) -> timestamptz`,
 	2493: `date_trunc(element: string, input: timestamptz, timezone: string) -> timestamptz`,
 	2494: `make_date(year: int, month: int, day: int) -> date`,
+	2495: `pg_notify(channel: string, payload: string) -> void`,
+	2496: `crdb_internal.plpgsql_close(name: string) -> int`,
+	2497: `crdb_internal.plpgsql_fetch(name: string, direction: int, count: int, result_types: tuple) -> anyelement`,
+	2498: `crdb_internal.plpgsql_gen_cursor_name(name: string) -> string`,
+	2499: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2500: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2501: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2502: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2503: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2504: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2505: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2506: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2507: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2508: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2509: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2510: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2511: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2512: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2513: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2514: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2515: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2516: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2517: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2518: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2519: `jsonpathin(input: anyelement) -> jsonpath`,
+	2520: `jsonpath(string: string) -> jsonpath`,
+	2521: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2522: `char(jsonpath: jsonpath) -> "char"`,
+	2523: `name(jsonpath: jsonpath) -> name`,
+	2524: `text(jsonpath: jsonpath) -> string`,
+	2525: `varchar(jsonpath: jsonpath) -> varchar`,
+	2526: `bpchar(jsonpath: jsonpath) -> char`,
+	2527: `cidrrecv(input: anyelement) -> cidr`,
+	2528: `cidrout(cidr: cidr) -> bytes`,
+	2529: `cidrin(input: anyelement) -> cidr`,
+	2530: `cidr(string: string) -> cidr`,
+	2531: `cidr(cidr: cidr) -> cidr`,
+	2532: `varchar(cidr: cidr) -> varchar`,
+	2533: `text(cidr: cidr) -> string`,
+	2534: `bpchar(cidr: cidr) -> char`,
+	2535: `name(cidr: cidr) -> name`,
+	2536: `char(cidr: cidr) -> "char"`,
+	2537: `max(arg1: cidr) -> anyelement`,
+	2538: `percentile_disc_impl(arg1: float, arg2: cidr) -> cidr`,
+	2539: `percentile_disc_impl(arg1: float[], arg2: cidr) -> cidr[]`,
+	2540: `min(arg1: cidr) -> anyelement`,
+	2541: `array_cat_agg(arg1: cidr[]) -> cidr[]`,
+	2542: `array_agg(arg1: cidr) -> cidr[]`,
+	2543: `array_prepend(elem: cidr, array: cidr[]) -> cidr[]`,
+	2544: `array_remove(array: cidr[], elem: cidr) -> anyelement`,
+	2545: `array_positions(array: cidr[], elem: cidr) -> int[]`,
+	2546: `array_cat(left: cidr[], right: cidr[]) -> cidr[]`,
+	2547: `array_position(array: cidr[], elem: cidr) -> int`,
+	2548: `array_replace(array: cidr[], toreplace: cidr, replacewith: cidr) -> anyelement`,
+	2549: `array_append(array: cidr[], elem: cidr) -> cidr[]`,
+	2550: `first_value(val: cidr) -> cidr`,
+	2551: `nth_value(val: cidr, n: int) -> cidr`,
+	2552: `lag(val: cidr) -> cidr`,
+	2553: `lag(val: cidr, n: int) -> cidr`,
+	2554: `lag(val: cidr, n: int, default: cidr) -> cidr`,
+	2555: `lead(val: cidr) -> cidr`,
+	2556: `lead(val: cidr, n: int) -> cidr`,
+	2557: `lead(val: cidr, n: int, default: cidr) -> cidr`,
+	2558: `last_value(val: cidr) -> cidr`,
+	2559: `cidrsend(cidr: cidr) -> bytes`,
+	2560: `cidr(inet: inet) -> cidr`,
+	2561: `inet(cidr: cidr) -> inet`,
+	2562: `macaddrrecv(input: anyelement) -> macaddr`,
+	2563: `macaddrout(macaddr: macaddr) -> bytes`,
+	2564: `macaddrin(input: anyelement) -> macaddr`,
+	2565: `macaddr(string: string) -> macaddr`,
+	2566: `macaddr(macaddr: macaddr) -> macaddr`,
+	2567: `varchar(macaddr: macaddr) -> varchar`,
+	2568: `text(macaddr: macaddr) -> string`,
+	2569: `bpchar(macaddr: macaddr) -> char`,
+	2570: `name(macaddr: macaddr) -> name`,
+	2571: `char(macaddr: macaddr) -> "char"`,
+	2572: `max(arg1: macaddr) -> anyelement`,
+	2573: `percentile_disc_impl(arg1: float, arg2: macaddr) -> macaddr`,
+	2574: `percentile_disc_impl(arg1: float[], arg2: macaddr) -> macaddr[]`,
+	2575: `min(arg1: macaddr) -> anyelement`,
+	2576: `array_cat_agg(arg1: macaddr[]) -> macaddr[]`,
+	2577: `array_agg(arg1: macaddr) -> macaddr[]`,
+	2578: `array_prepend(elem: macaddr, array: macaddr[]) -> macaddr[]`,
+	2579: `array_remove(array: macaddr[], elem: macaddr) -> anyelement`,
+	2580: `array_positions(array: macaddr[], elem: macaddr) -> int[]`,
+	2581: `array_cat(left: macaddr[], right: macaddr[]) -> macaddr[]`,
+	2582: `array_position(array: macaddr[], elem: macaddr) -> int`,
+	2583: `array_replace(array: macaddr[], toreplace: macaddr, replacewith: macaddr) -> anyelement`,
+	2584: `array_append(array: macaddr[], elem: macaddr) -> macaddr[]`,
+	2585: `first_value(val: macaddr) -> macaddr`,
+	2586: `nth_value(val: macaddr, n: int) -> macaddr`,
+	2587: `lag(val: macaddr) -> macaddr`,
+	2588: `lag(val: macaddr, n: int) -> macaddr`,
+	2589: `lag(val: macaddr, n: int, default: macaddr) -> macaddr`,
+	2590: `lead(val: macaddr) -> macaddr`,
+	2591: `lead(val: macaddr, n: int) -> macaddr`,
+	2592: `lead(val: macaddr, n: int, default: macaddr) -> macaddr`,
+	2593: `last_value(val: macaddr) -> macaddr`,
+	2594: `macaddrsend(macaddr: macaddr) -> bytes`,
+	2595: `macaddr(macaddr8: macaddr8) -> macaddr`,
+	2596: `macaddr8recv(input: anyelement) -> macaddr8`,
+	2597: `macaddr8out(macaddr8: macaddr8) -> bytes`,
+	2598: `macaddr8in(input: anyelement) -> macaddr8`,
+	2599: `macaddr8(string: string) -> macaddr8`,
+	2600: `macaddr8(macaddr8: macaddr8) -> macaddr8`,
+	2601: `varchar(macaddr8: macaddr8) -> varchar`,
+	2602: `text(macaddr8: macaddr8) -> string`,
+	2603: `bpchar(macaddr8: macaddr8) -> char`,
+	2604: `name(macaddr8: macaddr8) -> name`,
+	2605: `char(macaddr8: macaddr8) -> "char"`,
+	2606: `max(arg1: macaddr8) -> anyelement`,
+	2607: `percentile_disc_impl(arg1: float, arg2: macaddr8) -> macaddr8`,
+	2608: `percentile_disc_impl(arg1: float[], arg2: macaddr8) -> macaddr8[]`,
+	2609: `min(arg1: macaddr8) -> anyelement`,
+	2610: `array_cat_agg(arg1: macaddr8[]) -> macaddr8[]`,
+	2611: `array_agg(arg1: macaddr8) -> macaddr8[]`,
+	2612: `array_prepend(elem: macaddr8, array: macaddr8[]) -> macaddr8[]`,
+	2613: `array_remove(array: macaddr8[], elem: macaddr8) -> anyelement`,
+	2614: `array_positions(array: macaddr8[], elem: macaddr8) -> int[]`,
+	2615: `array_cat(left: macaddr8[], right: macaddr8[]) -> macaddr8[]`,
+	2616: `array_position(array: macaddr8[], elem: macaddr8) -> int`,
+	2617: `array_replace(array: macaddr8[], toreplace: macaddr8, replacewith: macaddr8) -> anyelement`,
+	2618: `array_append(array: macaddr8[], elem: macaddr8) -> macaddr8[]`,
+	2619: `first_value(val: macaddr8) -> macaddr8`,
+	2620: `nth_value(val: macaddr8, n: int) -> macaddr8`,
+	2621: `lag(val: macaddr8) -> macaddr8`,
+	2622: `lag(val: macaddr8, n: int) -> macaddr8`,
+	2623: `lag(val: macaddr8, n: int, default: macaddr8) -> macaddr8`,
+	2624: `lead(val: macaddr8) -> macaddr8`,
+	2625: `lead(val: macaddr8, n: int) -> macaddr8`,
+	2626: `lead(val: macaddr8, n: int, default: macaddr8) -> macaddr8`,
+	2627: `last_value(val: macaddr8) -> macaddr8`,
+	2628: `macaddr8send(macaddr8: macaddr8) -> bytes`,
+	2629: `macaddr8(macaddr: macaddr) -> macaddr8`,
+	2630: `broadcast(val: cidr) -> inet`,
+	2631: `family(val: cidr) -> int`,
+	2632: `host(val: cidr) -> string`,
+	2633: `hostmask(val: cidr) -> inet`,
+	2634: `masklen(val: cidr) -> int`,
+	2635: `netmask(val: cidr) -> inet`,
+	2636: `set_masklen(val: cidr, prefixlen: int) -> cidr`,
+	2637: `inet_contained_by_or_equals(val: cidr, container: cidr) -> bool`,
+	2638: `inet_contains_or_equals(container: cidr, val: cidr) -> bool`,
+	2639: `network(val: inet) -> cidr`,
+	2640: `macaddr8_set7bit(val: macaddr8) -> macaddr8`,
+	2641: `trunc(val: macaddr) -> macaddr`,
+	2642: `trunc(val: macaddr8) -> macaddr8`,
+	2643: `pointrecv(input: anyelement) -> point`,
+	2644: `pointout(point: point) -> bytes`,
+	2645: `pointin(input: anyelement) -> point`,
+	2646: `pointsend(point: point) -> bytes`,
+	2647: `point(box: box) -> point`,
+	2648: `point(circle: circle) -> point`,
+	2649: `point(lseg: lseg) -> point`,
+	2650: `point(path: path) -> point`,
+	2651: `point(point: point) -> point`,
+	2652: `point(polygon: polygon) -> point`,
+	2653: `point(string: string) -> point`,
+	2654: `point(x: float, y: float) -> point`,
+	2655: `varchar(point: point) -> varchar`,
+	2656: `text(point: point) -> string`,
+	2657: `bpchar(point: point) -> char`,
+	2658: `name(point: point) -> name`,
+	2659: `char(point: point) -> "char"`,
+	2660: `lsegrecv(input: anyelement) -> lseg`,
+	2661: `lsegout(lseg: lseg) -> bytes`,
+	2662: `lsegin(input: anyelement) -> lseg`,
+	2663: `lsegsend(lseg: lseg) -> bytes`,
+	2664: `lseg(box: box) -> lseg`,
+	2665: `lseg(lseg: lseg) -> lseg`,
+	2666: `lseg(p1: point, p2: point) -> lseg`,
+	2667: `lseg(string: string) -> lseg`,
+	2668: `varchar(lseg: lseg) -> varchar`,
+	2669: `text(lseg: lseg) -> string`,
+	2670: `bpchar(lseg: lseg) -> char`,
+	2671: `name(lseg: lseg) -> name`,
+	2672: `char(lseg: lseg) -> "char"`,
+	2673: `pathrecv(input: anyelement) -> path`,
+	2674: `pathout(path: path) -> bytes`,
+	2675: `pathin(input: anyelement) -> path`,
+	2676: `pathsend(path: path) -> bytes`,
+	2677: `path(path: path) -> path`,
+	2678: `path(polygon: polygon) -> path`,
+	2679: `path(string: string) -> path`,
+	2680: `varchar(path: path) -> varchar`,
+	2681: `text(path: path) -> string`,
+	2682: `bpchar(path: path) -> char`,
+	2683: `name(path: path) -> name`,
+	2684: `char(path: path) -> "char"`,
+	2685: `boxrecv(input: anyelement) -> box`,
+	2686: `boxout(box: box) -> bytes`,
+	2687: `boxin(input: anyelement) -> box`,
+	2688: `boxsend(box: box) -> bytes`,
+	2689: `box(box: box) -> box`,
+	2690: `box(circle: circle) -> box`,
+	2691: `box(p1: point, p2: point) -> box`,
+	2692: `box(point: point) -> box`,
+	2693: `box(polygon: polygon) -> box`,
+	2694: `box(string: string) -> box`,
+	2695: `varchar(box: box) -> varchar`,
+	2696: `text(box: box) -> string`,
+	2697: `bpchar(box: box) -> char`,
+	2698: `name(box: box) -> name`,
+	2699: `char(box: box) -> "char"`,
+	2700: `polygonrecv(input: anyelement) -> polygon`,
+	2701: `polygonout(polygon: polygon) -> bytes`,
+	2702: `polygonin(input: anyelement) -> polygon`,
+	2703: `polygonsend(polygon: polygon) -> bytes`,
+	2704: `polygon(box: box) -> polygon`,
+	2705: `polygon(circle: circle) -> polygon`,
+	2706: `polygon(npts: int, circle: circle) -> polygon`,
+	2707: `polygon(path: path) -> polygon`,
+	2708: `polygon(polygon: polygon) -> polygon`,
+	2709: `polygon(string: string) -> polygon`,
+	2710: `varchar(polygon: polygon) -> varchar`,
+	2711: `text(polygon: polygon) -> string`,
+	2712: `bpchar(polygon: polygon) -> char`,
+	2713: `name(polygon: polygon) -> name`,
+	2714: `char(polygon: polygon) -> "char"`,
+	2715: `linerecv(input: anyelement) -> line`,
+	2716: `lineout(line: line) -> bytes`,
+	2717: `linein(input: anyelement) -> line`,
+	2718: `linesend(line: line) -> bytes`,
+	2719: `line(line: line) -> line`,
+	2720: `line(p1: point, p2: point) -> line`,
+	2721: `line(string: string) -> line`,
+	2722: `varchar(line: line) -> varchar`,
+	2723: `text(line: line) -> string`,
+	2724: `bpchar(line: line) -> char`,
+	2725: `name(line: line) -> name`,
+	2726: `char(line: line) -> "char"`,
+	2727: `circlerecv(input: anyelement) -> circle`,
+	2728: `circleout(circle: circle) -> bytes`,
+	2729: `circlein(input: anyelement) -> circle`,
+	2730: `circlesend(circle: circle) -> bytes`,
+	2731: `circle(box: box) -> circle`,
+	2732: `circle(center: point, radius: float) -> circle`,
+	2733: `circle(circle: circle) -> circle`,
+	2734: `circle(polygon: polygon) -> circle`,
+	2735: `circle(string: string) -> circle`,
+	2736: `varchar(circle: circle) -> varchar`,
+	2737: `text(circle: circle) -> string`,
+	2738: `bpchar(circle: circle) -> char`,
+	2739: `name(circle: circle) -> name`,
+	2740: `char(circle: circle) -> "char"`,
+	2741: `area(val: box) -> float`,
+	2742: `area(val: circle) -> float`,
+	2743: `area(val: path) -> float`,
+	2744: `area(val: polygon) -> float`,
+	2745: `center(val: box) -> point`,
+	2746: `center(val: circle) -> point`,
+	2747: `diameter(val: circle) -> float`,
+	2748: `geometric_distance(a: box, b: box) -> float`,
+	2749: `geometric_distance(a: box, b: lseg) -> float`,
+	2750: `geometric_distance(a: box, b: point) -> float`,
+	2751: `geometric_distance(a: circle, b: circle) -> float`,
+	2752: `geometric_distance(a: circle, b: point) -> float`,
+	2753: `geometric_distance(a: circle, b: polygon) -> float`,
+	2754: `geometric_distance(a: line, b: line) -> float`,
+	2755: `geometric_distance(a: line, b: lseg) -> float`,
+	2756: `geometric_distance(a: line, b: point) -> float`,
+	2757: `geometric_distance(a: lseg, b: box) -> float`,
+	2758: `geometric_distance(a: lseg, b: line) -> float`,
+	2759: `geometric_distance(a: lseg, b: lseg) -> float`,
+	2760: `geometric_distance(a: lseg, b: point) -> float`,
+	2761: `geometric_distance(a: path, b: path) -> float`,
+	2762: `geometric_distance(a: path, b: point) -> float`,
+	2763: `geometric_distance(a: point, b: box) -> float`,
+	2764: `geometric_distance(a: point, b: circle) -> float`,
+	2765: `geometric_distance(a: point, b: line) -> float`,
+	2766: `geometric_distance(a: point, b: lseg) -> float`,
+	2767: `geometric_distance(a: point, b: path) -> float`,
+	2768: `geometric_distance(a: point, b: point) -> float`,
+	2769: `geometric_distance(a: point, b: polygon) -> float`,
+	2770: `geometric_distance(a: polygon, b: circle) -> float`,
+	2771: `geometric_distance(a: polygon, b: point) -> float`,
+	2772: `geometric_distance(a: polygon, b: polygon) -> float`,
+	2773: `geometric_intersects(a: box, b: box) -> bool`,
+	2774: `geometric_intersects(a: line, b: box) -> bool`,
+	2775: `geometric_intersects(a: line, b: line) -> bool`,
+	2776: `geometric_intersects(a: lseg, b: box) -> bool`,
+	2777: `geometric_intersects(a: lseg, b: line) -> bool`,
+	2778: `geometric_intersects(a: lseg, b: lseg) -> bool`,
+	2779: `geometric_intersects(a: path, b: path) -> bool`,
+	2780: `height(val: box) -> float`,
+	2781: `isclosed(val: path) -> bool`,
+	2782: `isopen(val: path) -> bool`,
+	2783: `length(val: lseg) -> float`,
+	2784: `length(val: path) -> float`,
+	2785: `npoints(val: path) -> int`,
+	2786: `npoints(val: polygon) -> int`,
+	2787: `pclose(val: path) -> path`,
+	2788: `popen(val: path) -> path`,
+	2789: `radius(val: circle) -> float`,
+	2790: `width(val: box) -> float`,
+	2791: `datemultirange(daterange...) -> datemultirange`,
+	2792: `datemultirangein(input: anyelement) -> datemultirange`,
+	2793: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2794: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2795: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2796: `daterange(lower: date, upper: date) -> daterange`,
+	2797: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2798: `daterangein(input: anyelement) -> daterange`,
+	2799: `daterangeout(daterange: daterange) -> bytes`,
+	2800: `daterangerecv(input: anyelement) -> daterange`,
+	2801: `daterangesend(daterange: daterange) -> bytes`,
+	2802: `int4multirange(int4range...) -> int4multirange`,
+	2803: `int4multirangein(input: anyelement) -> int4multirange`,
+	2804: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2805: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2806: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2807: `int4range(lower: int4, upper: int4) -> int4range`,
+	2808: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2809: `int4rangein(input: anyelement) -> int4range`,
+	2810: `int4rangeout(int4range: int4range) -> bytes`,
+	2811: `int4rangerecv(input: anyelement) -> int4range`,
+	2812: `int4rangesend(int4range: int4range) -> bytes`,
+	2813: `int8multirange(int8range...) -> int8multirange`,
+	2814: `int8multirangein(input: anyelement) -> int8multirange`,
+	2815: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2816: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2817: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2818: `int8range(lower: int, upper: int) -> int8range`,
+	2819: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2820: `int8rangein(input: anyelement) -> int8range`,
+	2821: `int8rangeout(int8range: int8range) -> bytes`,
+	2822: `int8rangerecv(input: anyelement) -> int8range`,
+	2823: `int8rangesend(int8range: int8range) -> bytes`,
+	2824: `isempty(val: datemultirange) -> bool`,
+	2825: `isempty(val: daterange) -> bool`,
+	2826: `isempty(val: int4multirange) -> bool`,
+	2827: `isempty(val: int4range) -> bool`,
+	2828: `isempty(val: int8multirange) -> bool`,
+	2829: `isempty(val: int8range) -> bool`,
+	2830: `isempty(val: nummultirange) -> bool`,
+	2831: `isempty(val: numrange) -> bool`,
+	2832: `isempty(val: tsmultirange) -> bool`,
+	2833: `isempty(val: tsrange) -> bool`,
+	2834: `isempty(val: tstzmultirange) -> bool`,
+	2835: `isempty(val: tstzrange) -> bool`,
+	2836: `lower(val: datemultirange) -> date`,
+	2837: `lower(val: daterange) -> date`,
+	2838: `lower(val: int4multirange) -> int4`,
+	2839: `lower(val: int4range) -> int4`,
+	2840: `lower(val: int8multirange) -> int`,
+	2841: `lower(val: int8range) -> int`,
+	2842: `lower(val: nummultirange) -> decimal`,
+	2843: `lower(val: numrange) -> decimal`,
+	2844: `lower(val: tsmultirange) -> timestamp`,
+	2845: `lower(val: tsrange) -> timestamp`,
+	2846: `lower(val: tstzmultirange) -> timestamptz`,
+	2847: `lower(val: tstzrange) -> timestamptz`,
+	2848: `lower_inc(val: datemultirange) -> bool`,
+	2849: `lower_inc(val: daterange) -> bool`,
+	2850: `lower_inc(val: int4multirange) -> bool`,
+	2851: `lower_inc(val: int4range) -> bool`,
+	2852: `lower_inc(val: int8multirange) -> bool`,
+	2853: `lower_inc(val: int8range) -> bool`,
+	2854: `lower_inc(val: nummultirange) -> bool`,
+	2855: `lower_inc(val: numrange) -> bool`,
+	2856: `lower_inc(val: tsmultirange) -> bool`,
+	2857: `lower_inc(val: tsrange) -> bool`,
+	2858: `lower_inc(val: tstzmultirange) -> bool`,
+	2859: `lower_inc(val: tstzrange) -> bool`,
+	2860: `lower_inf(val: datemultirange) -> bool`,
+	2861: `lower_inf(val: daterange) -> bool`,
+	2862: `lower_inf(val: int4multirange) -> bool`,
+	2863: `lower_inf(val: int4range) -> bool`,
+	2864: `lower_inf(val: int8multirange) -> bool`,
+	2865: `lower_inf(val: int8range) -> bool`,
+	2866: `lower_inf(val: nummultirange) -> bool`,
+	2867: `lower_inf(val: numrange) -> bool`,
+	2868: `lower_inf(val: tsmultirange) -> bool`,
+	2869: `lower_inf(val: tsrange) -> bool`,
+	2870: `lower_inf(val: tstzmultirange) -> bool`,
+	2871: `lower_inf(val: tstzrange) -> bool`,
+	2872: `nummultirange(numrange...) -> nummultirange`,
+	2873: `nummultirangein(input: anyelement) -> nummultirange`,
+	2874: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2875: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2876: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2877: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2878: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2879: `numrangein(input: anyelement) -> numrange`,
+	2880: `numrangeout(numrange: numrange) -> bytes`,
+	2881: `numrangerecv(input: anyelement) -> numrange`,
+	2882: `numrangesend(numrange: numrange) -> bytes`,
+	2883: `range_adjacent(a: daterange, b: daterange) -> bool`,
+	2884: `range_adjacent(a: int4range, b: int4range) -> bool`,
+	2885: `range_adjacent(a: int8range, b: int8range) -> bool`,
+	2886: `range_adjacent(a: numrange, b: numrange) -> bool`,
+	2887: `range_adjacent(a: tsrange, b: tsrange) -> bool`,
+	2888: `range_adjacent(a: tstzrange, b: tstzrange) -> bool`,
+	2889: `range_merge(a: daterange, b: daterange) -> daterange`,
+	2890: `range_merge(a: int4range, b: int4range) -> int4range`,
+	2891: `range_merge(a: int8range, b: int8range) -> int8range`,
+	2892: `range_merge(a: numrange, b: numrange) -> numrange`,
+	2893: `range_merge(a: tsrange, b: tsrange) -> tsrange`,
+	2894: `range_merge(a: tstzrange, b: tstzrange) -> tstzrange`,
+	2895: `range_merge(val: datemultirange) -> daterange`,
+	2896: `range_merge(val: int4multirange) -> int4range`,
+	2897: `range_merge(val: int8multirange) -> int8range`,
+	2898: `range_merge(val: nummultirange) -> numrange`,
+	2899: `range_merge(val: tsmultirange) -> tsrange`,
+	2900: `range_merge(val: tstzmultirange) -> tstzrange`,
+	2901: `tsmultirange(tsrange...) -> tsmultirange`,
+	2902: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2903: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2904: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2905: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2906: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2907: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2908: `tsrangein(input: anyelement) -> tsrange`,
+	2909: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2910: `tsrangerecv(input: anyelement) -> tsrange`,
+	2911: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2912: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2913: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2914: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2915: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2916: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2917: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2918: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2919: `tstzrangein(input: anyelement) -> tstzrange`,
+	2920: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2921: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2922: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2923: `upper(val: datemultirange) -> date`,
+	2924: `upper(val: daterange) -> date`,
+	2925: `upper(val: int4multirange) -> int4`,
+	2926: `upper(val: int4range) -> int4`,
+	2927: `upper(val: int8multirange) -> int`,
+	2928: `upper(val: int8range) -> int`,
+	2929: `upper(val: nummultirange) -> decimal`,
+	2930: `upper(val: numrange) -> decimal`,
+	2931: `upper(val: tsmultirange) -> timestamp`,
+	2932: `upper(val: tsrange) -> timestamp`,
+	2933: `upper(val: tstzmultirange) -> timestamptz`,
+	2934: `upper(val: tstzrange) -> timestamptz`,
+	2935: `upper_inc(val: datemultirange) -> bool`,
+	2936: `upper_inc(val: daterange) -> bool`,
+	2937: `upper_inc(val: int4multirange) -> bool`,
+	2938: `upper_inc(val: int4range) -> bool`,
+	2939: `upper_inc(val: int8multirange) -> bool`,
+	2940: `upper_inc(val: int8range) -> bool`,
+	2941: `upper_inc(val: nummultirange) -> bool`,
+	2942: `upper_inc(val: numrange) -> bool`,
+	2943: `upper_inc(val: tsmultirange) -> bool`,
+	2944: `upper_inc(val: tsrange) -> bool`,
+	2945: `upper_inc(val: tstzmultirange) -> bool`,
+	2946: `upper_inc(val: tstzrange) -> bool`,
+	2947: `upper_inf(val: datemultirange) -> bool`,
+	2948: `upper_inf(val: daterange) -> bool`,
+	2949: `upper_inf(val: int4multirange) -> bool`,
+	2950: `upper_inf(val: int4range) -> bool`,
+	2951: `upper_inf(val: int8multirange) -> bool`,
+	2952: `upper_inf(val: int8range) -> bool`,
+	2953: `upper_inf(val: nummultirange) -> bool`,
+	2954: `upper_inf(val: numrange) -> bool`,
+	2955: `upper_inf(val: tsmultirange) -> bool`,
+	2956: `upper_inf(val: tsrange) -> bool`,
+	2957: `upper_inf(val: tstzmultirange) -> bool`,
+	2958: `upper_inf(val: tstzrange) -> bool`,
+	2959: `pg_advisory_lock(key: int) -> void`,
+	2960: `pg_advisory_lock(key1: int4, key2: int4) -> void`,
+	2961: `pg_advisory_lock_shared(key: int) -> void`,
+	2962: `pg_advisory_lock_shared(key1: int4, key2: int4) -> void`,
+	2963: `pg_advisory_xact_lock(key: int) -> void`,
+	2964: `pg_advisory_xact_lock(key1: int4, key2: int4) -> void`,
+	2965: `pg_advisory_xact_lock_shared(key: int) -> void`,
+	2966: `pg_advisory_xact_lock_shared(key1: int4, key2: int4) -> void`,
+	2967: `pg_try_advisory_lock(key1: int4, key2: int4) -> bool`,
+	2968: `pg_try_advisory_lock_shared(key: int) -> bool`,
+	2969: `pg_try_advisory_lock_shared(key1: int4, key2: int4) -> bool`,
+	2970: `pg_try_advisory_xact_lock(key: int) -> bool`,
+	2971: `pg_try_advisory_xact_lock(key1: int4, key2: int4) -> bool`,
+	2972: `pg_try_advisory_xact_lock_shared(key: int) -> bool`,
+	2973: `pg_try_advisory_xact_lock_shared(key1: int4, key2: int4) -> bool`,
+	2974: `triggerin(input: anyelement) -> trigger`,
+	2975: `triggerout(trigger: trigger) -> bytes`,
+	2976: `triggerrecv(input: anyelement) -> trigger`,
+	2977: `triggersend(trigger: trigger) -> bytes`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -0,0 +1,412 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+func init() {
+	for k, v := range geometricBuiltins {
+		v.props.Category = builtinconstants.CategoryGeometric
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// geometricDistancePairs are the pairs of geometric types for which the <->
+// operator is defined.
+var geometricDistancePairs = [][2]*types.T{
+	{types.Point, types.Point},
+	{types.Point, types.Line},
+	{types.Point, types.LSeg},
+	{types.Point, types.Box},
+	{types.Point, types.Path},
+	{types.Point, types.Polygon},
+	{types.Point, types.Circle},
+	{types.Line, types.Point},
+	{types.Line, types.LSeg},
+	{types.Line, types.Line},
+	{types.LSeg, types.Point},
+	{types.LSeg, types.Line},
+	{types.LSeg, types.LSeg},
+	{types.LSeg, types.Box},
+	{types.Box, types.Point},
+	{types.Box, types.LSeg},
+	{types.Box, types.Box},
+	{types.Path, types.Point},
+	{types.Path, types.Path},
+	{types.Polygon, types.Point},
+	{types.Polygon, types.Polygon},
+	{types.Polygon, types.Circle},
+	{types.Circle, types.Point},
+	{types.Circle, types.Polygon},
+	{types.Circle, types.Circle},
+}
+
+// geometricIntersectsPairs are the pairs of geometric types for which the ?#
+// operator is defined.
+var geometricIntersectsPairs = [][2]*types.T{
+	{types.LSeg, types.LSeg},
+	{types.LSeg, types.Line},
+	{types.LSeg, types.Box},
+	{types.Line, types.Line},
+	{types.Line, types.Box},
+	{types.Box, types.Box},
+	{types.Path, types.Path},
+}
+
+var geometricBuiltins = map[string]builtinDefinition{
+	"geometric_distance": makeBuiltin(defProps(),
+		makeGeometricBinaryOverloads(
+			geometricDistancePairs,
+			types.Float,
+			func(a, b pggeom.Shape) (tree.Datum, error) {
+				d, err := pggeom.Distance(a, b)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDFloat(tree.DFloat(d)), nil
+			},
+			"Returns the distance between `a` and `b`. This function is used to "+
+				"implement the `<->` operator.",
+		)...,
+	),
+
+	"geometric_intersects": makeBuiltin(defProps(),
+		makeGeometricBinaryOverloads(
+			geometricIntersectsPairs,
+			types.Bool,
+			func(a, b pggeom.Shape) (tree.Datum, error) {
+				res, err := pggeom.Intersects(a, b)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(res)), nil
+			},
+			"Returns whether `a` and `b` intersect. This function is used to "+
+				"implement the `?#` operator.",
+		)...,
+	),
+
+	"area": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Box, types.Path, types.Polygon, types.Circle},
+			types.Float,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				a, ok, err := pggeom.Area(s)
+				if err != nil || !ok {
+					return tree.DNull, err
+				}
+				return tree.NewDFloat(tree.DFloat(a)), nil
+			},
+			"Returns the area of `val`. Returns NULL for open paths.",
+		)...,
+	),
+
+	"center": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Box, types.Circle},
+			types.Point,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				c, err := pggeom.Center(s)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometric(c), nil
+			},
+			"Returns the center point of `val`.",
+		)...,
+	),
+
+	"diameter": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Circle},
+			types.Float,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(2 * s.(pggeom.Circle).Radius)), nil
+			},
+			"Returns the diameter of `val`.",
+		)...,
+	),
+
+	"radius": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Circle},
+			types.Float,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(pggeom.Circle).Radius)), nil
+			},
+			"Returns the radius of `val`.",
+		)...,
+	),
+
+	"height": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Box},
+			types.Float,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				b := s.(pggeom.Box)
+				return tree.NewDFloat(tree.DFloat(b.High.Y - b.Low.Y)), nil
+			},
+			"Returns the vertical size of `val`.",
+		)...,
+	),
+
+	"width": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Box},
+			types.Float,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				b := s.(pggeom.Box)
+				return tree.NewDFloat(tree.DFloat(b.High.X - b.Low.X)), nil
+			},
+			"Returns the horizontal size of `val`.",
+		)...,
+	),
+
+	"npoints": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Path, types.Polygon},
+			types.Int,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				if p, ok := s.(pggeom.Path); ok {
+					return tree.NewDInt(tree.DInt(len(p.Points))), nil
+				}
+				return tree.NewDInt(tree.DInt(len(s.(pggeom.Polygon).Points))), nil
+			},
+			"Returns the number of points in `val`.",
+		)...,
+	),
+
+	"isclosed": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Path},
+			types.Bool,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(s.(pggeom.Path).Closed)), nil
+			},
+			"Returns whether `val` is a closed path.",
+		)...,
+	),
+
+	"isopen": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Path},
+			types.Bool,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(!s.(pggeom.Path).Closed)), nil
+			},
+			"Returns whether `val` is an open path.",
+		)...,
+	),
+
+	"pclose": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Path},
+			types.Path,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				p := s.(pggeom.Path)
+				p.Closed = true
+				return tree.NewDGeometric(p), nil
+			},
+			"Converts `val` to a closed path.",
+		)...,
+	),
+
+	"popen": makeBuiltin(defProps(),
+		makeGeometricUnaryOverloads(
+			[]*types.T{types.Path},
+			types.Path,
+			func(s pggeom.Shape) (tree.Datum, error) {
+				p := s.(pggeom.Path)
+				p.Closed = false
+				return tree.NewDGeometric(p), nil
+			},
+			"Converts `val` to an open path.",
+		)...,
+	),
+}
+
+// geometricConstructors are the overloads of the functions that construct
+// geometric values from their components. The functions share their names with
+// the cast builtins of the geometric types, so they are registered together
+// with them.
+var geometricConstructors = map[string][]tree.Overload{
+	"point": {
+		{
+			Types:      tree.ParamTypes{{Name: "x", Typ: types.Float}, {Name: "y", Typ: types.Float}},
+			ReturnType: tree.FixedReturnType(types.Point),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDGeometric(pggeom.Point{
+					X: float64(tree.MustBeDFloat(args[0])),
+					Y: float64(tree.MustBeDFloat(args[1])),
+				}), nil
+			},
+			Info: "Returns the point with the given coordinates." +
+				"\n\nFor example, `point(1, 2)` returns `'(1,2)'`",
+			Volatility: volatility.Immutable,
+		},
+	},
+	"lseg": {
+		{
+			Types:      tree.ParamTypes{{Name: "p1", Typ: types.Point}, {Name: "p2", Typ: types.Point}},
+			ReturnType: tree.FixedReturnType(types.LSeg),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				p1, p2 := geometricPointArgs(args)
+				return tree.NewDGeometric(pggeom.LSeg{P: [2]pggeom.Point{p1, p2}}), nil
+			},
+			Info: "Returns the line segment between the two points." +
+				"\n\nFor example, `lseg(point(0, 0), point(1, 1))` returns `'[(0,0),(1,1)]'`",
+			Volatility: volatility.Immutable,
+		},
+	},
+	"box": {
+		{
+			Types:      tree.ParamTypes{{Name: "p1", Typ: types.Point}, {Name: "p2", Typ: types.Point}},
+			ReturnType: tree.FixedReturnType(types.Box),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				p1, p2 := geometricPointArgs(args)
+				return tree.NewDGeometric(pggeom.MakeBox(p1, p2)), nil
+			},
+			Info: "Returns the box with the two points as opposite corners." +
+				"\n\nFor example, `box(point(0, 1), point(1, 0))` returns `'(1,1),(0,0)'`",
+			Volatility: volatility.Immutable,
+		},
+	},
+	"line": {
+		{
+			Types:      tree.ParamTypes{{Name: "p1", Typ: types.Point}, {Name: "p2", Typ: types.Point}},
+			ReturnType: tree.FixedReturnType(types.Line),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				p1, p2 := geometricPointArgs(args)
+				l, err := pggeom.MakeLine(p1, p2)
+				if err != nil {
+					return nil, pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+				}
+				return tree.NewDGeometric(l), nil
+			},
+			Info: "Returns the line through the two points." +
+				"\n\nFor example, `line(point(0, 0), point(1, 1))` returns `'{1,-1,0}'`",
+			Volatility: volatility.Immutable,
+		},
+	},
+	"circle": {
+		{
+			Types:      tree.ParamTypes{{Name: "center", Typ: types.Point}, {Name: "radius", Typ: types.Float}},
+			ReturnType: tree.FixedReturnType(types.Circle),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				r := float64(tree.MustBeDFloat(args[1]))
+				if r < 0 {
+					return nil, pgerror.New(pgcode.InvalidParameterValue, "circle radius cannot be negative")
+				}
+				return tree.NewDGeometric(pggeom.Circle{
+					Center: tree.MustBeDGeometric(args[0]).Shape.(pggeom.Point),
+					Radius: r,
+				}), nil
+			},
+			Info: "Returns the circle with the given center and radius." +
+				"\n\nFor example, `circle(point(0, 0), 2)` returns `'<(0,0),2>'`",
+			Volatility: volatility.Immutable,
+		},
+	},
+	"polygon": {
+		{
+			Types:      tree.ParamTypes{{Name: "npts", Typ: types.Int}, {Name: "circle", Typ: types.Circle}},
+			ReturnType: tree.FixedReturnType(types.Polygon),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				c := tree.MustBeDGeometric(args[1]).Shape.(pggeom.Circle)
+				p, err := pggeom.MakePolygonFromCircle(c, int(tree.MustBeDInt(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDGeometric(p), nil
+			},
+			Info: "Returns the polygon with `npts` vertices that approximates the circle." +
+				"\n\nFor example, `polygon(4, circle(point(0, 0), 1))` returns " +
+				"`'((-1,0),(0,1),(1,0),(0,-1))'`",
+			Volatility: volatility.Immutable,
+		},
+	},
+}
+
+// geometricLengthOverloads are the overloads of the length builtin for the
+// geometric types.
+var geometricLengthOverloads = makeGeometricUnaryOverloads(
+	[]*types.T{types.LSeg, types.Path},
+	types.Float,
+	func(s pggeom.Shape) (tree.Datum, error) {
+		l, err := pggeom.Length(s)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDFloat(tree.DFloat(l)), nil
+	},
+	"Calculates the total length of the segments of `val`.",
+)
+
+func geometricPointArgs(args tree.Datums) (pggeom.Point, pggeom.Point) {
+	return tree.MustBeDGeometric(args[0]).Shape.(pggeom.Point),
+		tree.MustBeDGeometric(args[1]).Shape.(pggeom.Point)
+}
+
+// makeGeometricUnaryOverloads returns an overload of a function that takes a
+// single geometric argument for each of the given types.
+func makeGeometricUnaryOverloads(
+	typs []*types.T, returnType *types.T, f func(pggeom.Shape) (tree.Datum, error), info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, len(typs))
+	for i, typ := range typs {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: typ}},
+			ReturnType: tree.FixedReturnType(returnType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDGeometric(args[0]).Shape)
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// makeGeometricBinaryOverloads returns an overload of a function that takes
+// two geometric arguments for each of the given pairs of types.
+func makeGeometricBinaryOverloads(
+	pairs [][2]*types.T,
+	returnType *types.T,
+	f func(a, b pggeom.Shape) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, len(pairs))
+	for i, p := range pairs {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "a", Typ: p[0]}, {Name: "b", Typ: p[1]}},
+			ReturnType: tree.FixedReturnType(returnType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDGeometric(args[0]).Shape, tree.MustBeDGeometric(args[1]).Shape)
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		v.props.Category = builtinconstants.CategoryJSON
+		v.props.AvailableOnPublicSchema = true
+		// Most builtins in this file are of the Normal class, but there is one
+		// of the Generator class.
+		const enforceClass = false
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// jsonpathArgs are the arguments shared by all jsonpath builtins.
+type jsonpathArgs struct {
+	target json.JSON
+	path   *jsonpath.Path
+	vars   json.JSON
+	silent bool
+}
+
+func makeJSONPathArgs(args tree.Datums) jsonpathArgs {
+	a := jsonpathArgs{
+		target: tree.MustBeDJSON(args[0]).JSON,
+		path:   tree.MustBeDJsonpath(args[1]).Path,
+	}
+	if len(args) > 2 {
+		a.vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		a.silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return a
+}
+
+// jsonpathParamTypes returns the parameter types of the overloads of the
+// jsonpath builtins, which accept optional vars and silent arguments.
+func jsonpathParamTypes() []tree.ParamTypes {
+	target := tree.ParamType{Name: "target", Typ: types.Jsonb}
+	path := tree.ParamType{Name: "path", Typ: types.Jsonpath}
+	vars := tree.ParamType{Name: "vars", Typ: types.Jsonb}
+	silent := tree.ParamType{Name: "silent", Typ: types.Bool}
+	return []tree.ParamTypes{
+		{target, path},
+		{target, path, vars},
+		{target, path, vars, silent},
+	}
+}
+
+// makeJSONPathBuiltin returns a builtin with an overload for each of the
+// parameter lists returned by jsonpathParamTypes.
+func makeJSONPathBuiltin(
+	retType *types.T,
+	fn func(a jsonpathArgs) (tree.Datum, error),
+	info string,
+) builtinDefinition {
+	var overloads []tree.Overload
+	for _, params := range jsonpathParamTypes() {
+		overloads = append(overloads, tree.Overload{
+			Types:      params,
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(makeJSONPathArgs(args))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return makeBuiltin(tree.FunctionProperties{}, overloads...)
+}
+
+const jsonpathArgsInfo = " If the vars argument is specified, it must be an " +
+	"object whose fields provide the values of the variables referenced in the " +
+	"path. If the silent argument is true, errors that are suppressible according " +
+	"to the SQL/JSON standard, like missing object fields or array elements, are " +
+	"suppressed."
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeJSONPathBuiltin(
+		types.Bool,
+		func(a jsonpathArgs) (tree.Datum, error) {
+			exists, ok, err := jsonpath.Exists(a.path, a.target, a.vars, a.silent)
+			if err != nil || !ok {
+				return tree.DNull, err
+			}
+			return tree.MakeDBool(tree.DBool(exists)), nil
+		},
+		"Returns whether the JSON path returns any item for the specified JSON value."+
+			jsonpathArgsInfo,
+	),
+
+	"jsonb_path_match": makeJSONPathBuiltin(
+		types.Bool,
+		func(a jsonpathArgs) (tree.Datum, error) {
+			match, ok, err := jsonpath.Match(a.path, a.target, a.vars, a.silent)
+			if err != nil || !ok {
+				return tree.DNull, err
+			}
+			return tree.MakeDBool(tree.DBool(match)), nil
+		},
+		"Returns the result of a JSON path predicate check for the specified JSON "+
+			"value. Only the first item of the result is taken into account. If the "+
+			"result is not Boolean, then NULL is returned."+jsonpathArgsInfo,
+	),
+
+	"jsonb_path_exists_opr": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      jsonpathParamTypes()[0],
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.JSONPathExists(tree.MustBeDJSON(args[0]), tree.MustBeDJsonpath(args[1]))
+			},
+			Info:       "Implementation of the @? operator.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"jsonb_path_match_opr": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      jsonpathParamTypes()[0],
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.JSONPathMatch(tree.MustBeDJSON(args[0]), tree.MustBeDJsonpath(args[1]))
+			},
+			Info:       "Implementation of the @@ operator when used with jsonb and jsonpath operands.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"jsonb_path_query_array": makeJSONPathBuiltin(
+		types.Jsonb,
+		func(a jsonpathArgs) (tree.Datum, error) {
+			res, err := jsonpath.Query(a.path, a.target, a.vars, a.silent)
+			if err != nil {
+				return nil, err
+			}
+			b := json.NewArrayBuilder(len(res))
+			for _, j := range res {
+				b.Add(j)
+			}
+			return tree.NewDJSON(b.Build()), nil
+		},
+		"Returns all JSON items returned by the JSON path for the specified JSON "+
+			"value, as a JSON array."+jsonpathArgsInfo,
+	),
+
+	"jsonb_path_query_first": makeJSONPathBuiltin(
+		types.Jsonb,
+		func(a jsonpathArgs) (tree.Datum, error) {
+			res, err := jsonpath.Query(a.path, a.target, a.vars, a.silent)
+			if err != nil || len(res) == 0 {
+				return tree.DNull, err
+			}
+			return tree.NewDJSON(res[0]), nil
+		},
+		"Returns the first JSON item returned by the JSON path for the specified "+
+			"JSON value."+jsonpathArgsInfo,
+	),
+
+	"jsonb_path_query": func() builtinDefinition {
+		var overloads []tree.Overload
+		for _, params := range jsonpathParamTypes() {
+			overloads = append(overloads, makeGeneratorOverload(
+				params,
+				types.Jsonb,
+				makeJSONPathQueryGenerator,
+				"Returns all JSON items returned by the JSON path for the specified "+
+					"JSON value."+jsonpathArgsInfo,
+				volatility.Immutable,
+			))
+		}
+		return makeBuiltin(genProps(), overloads...)
+	}(),
+}
+
+// jsonPathQueryGenerator supports jsonb_path_query.
+type jsonPathQueryGenerator struct {
+	args    jsonpathArgs
+	results []json.JSON
+	next    int
+}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &jsonPathQueryGenerator{args: makeJSONPathArgs(args)}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return types.Jsonb
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) (err error) {
+	g.results, err = jsonpath.Query(g.args.path, g.args.target, g.args.vars, g.args.silent)
+	g.next = -1
+	return err
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	g.next++
+	return g.next < len(g.results), nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return tree.Datums{tree.NewDJSON(g.results[g.next])}, nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
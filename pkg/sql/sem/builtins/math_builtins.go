@@ -560,6 +560,24 @@ var mathBuiltins = map[string]builtinDefinition{
 			Info:       "Truncate `val` to `scale` decimal places",
 			Volatility: volatility.Immutable,
 		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MacAddr}},
+			ReturnType: tree.FixedReturnType(types.MacAddr),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMacAddr(tree.MustBeDMacAddr(args[0]).Trunc()), nil
+			},
+			Info:       "Sets the last 3 bytes of the MAC address to zero.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MacAddr8}},
+			ReturnType: tree.FixedReturnType(types.MacAddr8),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDMacAddr8(tree.MustBeDMacAddr8(args[0]).Trunc()), nil
+			},
+			Info:       "Sets the last 5 bytes of the MAC address to zero.",
+			Volatility: volatility.Immutable,
+		},
 	),
 
 	"width_bucket": makeBuiltin(defProps(),
@@ -63,6 +63,74 @@ func makeNotUsableFalseBuiltin() builtinDefinition {
 	)
 }
 
+// makeAdvisoryLockBuiltin creates a builtin that acquires an advisory lock,
+// with an overload for each of the two forms of advisory lock keys. Builtins
+// that try to acquire the lock return whether they succeeded.
+func makeAdvisoryLockBuiltin(shared, xact, try bool, info string) builtinDefinition {
+	retType := types.Void
+	if try {
+		retType = types.Bool
+	}
+	return makeAdvisoryLockOverloads(retType, info,
+		func(ctx context.Context, evalCtx *eval.Context, key eval.AdvisoryLockKey) (tree.Datum, error) {
+			ok, err := evalCtx.Planner.AcquireAdvisoryLock(ctx, key, shared, xact, try)
+			if err != nil {
+				return nil, err
+			}
+			if !try {
+				return tree.DVoidDatum, nil
+			}
+			return tree.MakeDBool(tree.DBool(ok)), nil
+		},
+	)
+}
+
+// makeAdvisoryUnlockBuiltin creates a builtin that releases a session-level
+// advisory lock, with an overload for each of the two forms of advisory lock
+// keys.
+func makeAdvisoryUnlockBuiltin(shared bool, info string) builtinDefinition {
+	return makeAdvisoryLockOverloads(types.Bool, info,
+		func(ctx context.Context, evalCtx *eval.Context, key eval.AdvisoryLockKey) (tree.Datum, error) {
+			ok, err := evalCtx.Planner.ReleaseAdvisoryLock(ctx, key, shared)
+			if err != nil {
+				return nil, err
+			}
+			return tree.MakeDBool(tree.DBool(ok)), nil
+		},
+	)
+}
+
+func makeAdvisoryLockOverloads(
+	retType *types.T,
+	info string,
+	fn func(context.Context, *eval.Context, eval.AdvisoryLockKey) (tree.Datum, error),
+) builtinDefinition {
+	return makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				key := eval.MakeAdvisoryLockKey(int64(tree.MustBeDInt(args[0])))
+				return fn(ctx, evalCtx, key)
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				key := eval.MakeAdvisoryLockKeyPair(
+					int32(tree.MustBeDInt(args[0])), int32(tree.MustBeDInt(args[1])),
+				)
+				return fn(ctx, evalCtx, key)
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		},
+	)
+}
+
 // typeBuiltinsHaveUnderscore is a map to keep track of which types have i/o
 // builtins with underscores in between their type name and the i/o builtin
 // name, like date_in vs int8in. There seems to be no other way to
@@ -204,6 +272,13 @@ func init() {
 	for toOID, def := range castBuiltins {
 		n := cast.CastTypeName(types.OidToType[toOID])
 		CastBuiltinNames[n] = struct{}{}
+		// The geometric types have constructor functions that share their names
+		// with the cast builtins, e.g. point(x, y).
+		if overloads, ok := geometricConstructors[n]; ok {
+			def.overloads = append(def.overloads, overloads...)
+			def.props.Category = builtinconstants.CategoryGeometric
+			def.props.Undocumented = false
+		}
 		registerBuiltin(n, *def, tree.NormalClass, enforceClass)
 	}
 
@@ -1159,69 +1234,104 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
-	"pg_try_advisory_lock": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "int", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
+	// See https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADVISORY-LOCKS.
+	"pg_advisory_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, false /* xact */, false, /* try */
+		"Obtains an exclusive session-level advisory lock, waiting if necessary.",
 	),
 
-	"pg_advisory_unlock": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
+	"pg_advisory_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, false /* xact */, false, /* try */
+		"Obtains a shared session-level advisory lock, waiting if necessary.",
 	),
 
-	"pg_advisory_unlock_shared": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
+	"pg_advisory_xact_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, true /* xact */, false, /* try */
+		"Obtains an exclusive transaction-level advisory lock, waiting if necessary. "+
+			"The lock is released at the end of the current transaction.",
+	),
+
+	"pg_advisory_xact_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, true /* xact */, false, /* try */
+		"Obtains a shared transaction-level advisory lock, waiting if necessary. "+
+			"The lock is released at the end of the current transaction.",
+	),
+
+	"pg_try_advisory_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, false /* xact */, true, /* try */
+		"Obtains an exclusive session-level advisory lock if available. Returns true "+
+			"if the lock was obtained, and false without waiting otherwise.",
+	),
+
+	"pg_try_advisory_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, false /* xact */, true, /* try */
+		"Obtains a shared session-level advisory lock if available. Returns true "+
+			"if the lock was obtained, and false without waiting otherwise.",
+	),
+
+	"pg_try_advisory_xact_lock": makeAdvisoryLockBuiltin(
+		false /* shared */, true /* xact */, true, /* try */
+		"Obtains an exclusive transaction-level advisory lock if available. Returns "+
+			"true if the lock was obtained, and false without waiting otherwise.",
+	),
+
+	"pg_try_advisory_xact_lock_shared": makeAdvisoryLockBuiltin(
+		true /* shared */, true /* xact */, true, /* try */
+		"Obtains a shared transaction-level advisory lock if available. Returns "+
+			"true if the lock was obtained, and false without waiting otherwise.",
+	),
+
+	"pg_advisory_unlock": makeAdvisoryUnlockBuiltin(
+		false, /* shared */
+		"Releases a previously obtained exclusive session-level advisory lock. Returns "+
+			"true if the lock was released, and false with a warning if it was not held.",
+	),
+
+	"pg_advisory_unlock_shared": makeAdvisoryUnlockBuiltin(
+		true, /* shared */
+		"Releases a previously obtained shared session-level advisory lock. Returns "+
+			"true if the lock was released, and false with a warning if it was not held.",
+	),
+
+	"pg_advisory_unlock_all": makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true},
 		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				if err := evalCtx.Planner.ReleaseAllAdvisoryLocks(ctx); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
 			},
-			Info:       notUsableInfo,
+			Info:       "Releases all the session-level advisory locks held by the current session.",
 			Volatility: volatility.Volatile,
 		},
 	),
 
-	"pg_advisory_unlock_all": makeBuiltin(defProps(),
+	// See https://www.postgresql.org/docs/current/functions-info.html.
+	"pg_notify": makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true},
 		tree.Overload{
-			Types:      tree.ParamTypes{},
+			Types:      tree.ParamTypes{{Name: "channel", Typ: types.String}, {Name: "payload", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.Void),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// Following Postgres, NULL arguments are treated as empty strings.
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.Planner.NotifyChannel(ctx, channel, payload); err != nil {
+					return nil, err
+				}
 				return tree.DVoidDatum, nil
 			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
+			Info: "Sends a notification with the given payload to the sessions " +
+				"listening on the given channel. Like NOTIFY, the notification is " +
+				"only sent if the current transaction commits.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
 		},
 	),
 
@@ -129,6 +129,11 @@ func TestFormat(t *testing.T) {
 				}
 			})
 			t.Run("%L creates a literal logically equivalent to the value", func(t *testing.T) {
+				if typ.Family() == types.JsonpathFamily ||
+					(typ.Family() == types.ArrayFamily && typ.ArrayContents().Family() == types.JsonpathFamily) {
+					// JSONPath has no equality operator, as in Postgres.
+					skip.IgnoreLint(t, "jsonpath values cannot be compared for equality")
+				}
 				if typ.Family() == types.ArrayFamily {
 					switch typ.InternalType.ArrayContents {
 					case types.Geometry, types.Geography, types.Box2D:
@@ -0,0 +1,353 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+	for i, rt := range types.Ranges {
+		mt := types.MultiRanges[i]
+		registerBuiltin(rt.PGName(), makeRangeConstructor(rt), tree.NormalClass, true /* enforceClass */)
+		registerBuiltin(mt.PGName(), makeMultiRangeConstructor(mt), tree.NormalClass, true /* enforceClass */)
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeBuiltin(defProps(),
+		append(
+			makeRangeUnaryOverloads(
+				types.Ranges,
+				func(*tree.DRange) *types.T { return types.Bool },
+				func(r *tree.DRange) (tree.Datum, error) {
+					return tree.MakeDBool(tree.DBool(r.Empty)), nil
+				},
+				"Returns whether `val` is empty.",
+			),
+			makeMultiRangeUnaryOverloads(
+				func(*tree.DMultiRange) *types.T { return types.Bool },
+				func(m *tree.DMultiRange) (tree.Datum, error) {
+					return tree.MakeDBool(len(m.Ranges) == 0), nil
+				},
+				"Returns whether `val` is empty.",
+			)...,
+		)...,
+	),
+
+	"lower_inc": makeRangeBoundPredicateBuiltin(
+		func(r *tree.DRange) bool { return r.Lower.Inclusive },
+		"Returns whether the lower bound of `val` is inclusive.",
+	),
+
+	"upper_inc": makeRangeBoundPredicateBuiltin(
+		func(r *tree.DRange) bool { return r.Upper.Inclusive },
+		"Returns whether the upper bound of `val` is inclusive.",
+	),
+
+	"lower_inf": makeRangeBoundPredicateBuiltin(
+		func(r *tree.DRange) bool { return r.Lower.Infinite() },
+		"Returns whether the lower bound of `val` is infinite.",
+	),
+
+	"upper_inf": makeRangeBoundPredicateBuiltin(
+		func(r *tree.DRange) bool { return r.Upper.Infinite() },
+		"Returns whether the upper bound of `val` is infinite.",
+	),
+
+	"range_merge": makeBuiltin(defProps(),
+		append(
+			makeRangeBinaryOverloads(
+				types.Ranges,
+				func(a *tree.DRange) *types.T { return a.Typ },
+				func(a, b *tree.DRange) (tree.Datum, error) {
+					return a.Merge(b)
+				},
+				"Returns the smallest range which includes both `a` and `b`.",
+			),
+			makeMultiRangeUnaryOverloads(
+				func(m *tree.DMultiRange) *types.T { return m.Typ.MultiRangeContents() },
+				func(m *tree.DMultiRange) (tree.Datum, error) {
+					return m.Span(), nil
+				},
+				"Returns the smallest range which includes the entire multirange `val`.",
+			)...,
+		)...,
+	),
+
+	"range_adjacent": makeBuiltin(defProps(),
+		makeRangeBinaryOverloads(
+			types.Ranges,
+			func(*tree.DRange) *types.T { return types.Bool },
+			func(a, b *tree.DRange) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(a.Adjacent(b))), nil
+			},
+			"Returns whether `a` and `b` are adjacent. This function is used to "+
+				"implement the `-|-` operator.",
+		)...,
+	),
+}
+
+// rangeLowerOverloads are the overloads of the lower builtin for the range and
+// multirange types.
+var rangeLowerOverloads = append(
+	makeRangeUnaryOverloads(
+		types.Ranges,
+		func(r *tree.DRange) *types.T { return r.Typ.RangeContents() },
+		func(r *tree.DRange) (tree.Datum, error) {
+			if r.Empty || r.Lower.Infinite() {
+				return tree.DNull, nil
+			}
+			return r.Lower.Val, nil
+		},
+		"Returns the lower bound of `val`. Returns NULL if `val` is empty or "+
+			"its lower bound is infinite.",
+	),
+	makeMultiRangeUnaryOverloads(
+		func(m *tree.DMultiRange) *types.T { return m.Typ.MultiRangeContents().RangeContents() },
+		func(m *tree.DMultiRange) (tree.Datum, error) {
+			if len(m.Ranges) == 0 || m.Ranges[0].Lower.Infinite() {
+				return tree.DNull, nil
+			}
+			return m.Ranges[0].Lower.Val, nil
+		},
+		"Returns the lower bound of `val`. Returns NULL if `val` is empty or "+
+			"its lower bound is infinite.",
+	)...,
+)
+
+// rangeUpperOverloads are the overloads of the upper builtin for the range and
+// multirange types.
+var rangeUpperOverloads = append(
+	makeRangeUnaryOverloads(
+		types.Ranges,
+		func(r *tree.DRange) *types.T { return r.Typ.RangeContents() },
+		func(r *tree.DRange) (tree.Datum, error) {
+			if r.Empty || r.Upper.Infinite() {
+				return tree.DNull, nil
+			}
+			return r.Upper.Val, nil
+		},
+		"Returns the upper bound of `val`. Returns NULL if `val` is empty or "+
+			"its upper bound is infinite.",
+	),
+	makeMultiRangeUnaryOverloads(
+		func(m *tree.DMultiRange) *types.T { return m.Typ.MultiRangeContents().RangeContents() },
+		func(m *tree.DMultiRange) (tree.Datum, error) {
+			if len(m.Ranges) == 0 || m.Ranges[len(m.Ranges)-1].Upper.Infinite() {
+				return tree.DNull, nil
+			}
+			return m.Ranges[len(m.Ranges)-1].Upper.Val, nil
+		},
+		"Returns the upper bound of `val`. Returns NULL if `val` is empty or "+
+			"its upper bound is infinite.",
+	)...,
+)
+
+// makeRangeUnaryOverloads returns an overload of a function that takes a single
+// range argument for each of the given range types. The return type of each
+// overload is determined by calling retType with an empty range of the
+// overload's type.
+func makeRangeUnaryOverloads(
+	typs []*types.T,
+	retType func(*tree.DRange) *types.T,
+	f func(*tree.DRange) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, len(typs))
+	for i, typ := range typs {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: typ}},
+			ReturnType: tree.FixedReturnType(retType(tree.NewDEmptyRange(typ))),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDRange(args[0]))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// makeMultiRangeUnaryOverloads returns an overload of a function that takes a
+// single multirange argument for each of the built-in multirange types. The
+// return type of each overload is determined by calling retType with an empty
+// multirange of the overload's type.
+func makeMultiRangeUnaryOverloads(
+	retType func(*tree.DMultiRange) *types.T,
+	f func(*tree.DMultiRange) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, len(types.MultiRanges))
+	for i, typ := range types.MultiRanges {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: typ}},
+			ReturnType: tree.FixedReturnType(retType(&tree.DMultiRange{Typ: typ})),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDMultiRange(args[0]))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// makeRangeBinaryOverloads returns an overload of a function that takes two
+// range arguments of the same type for each of the given range types.
+func makeRangeBinaryOverloads(
+	typs []*types.T,
+	retType func(*tree.DRange) *types.T,
+	f func(a, b *tree.DRange) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, len(typs))
+	for i, typ := range typs {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "a", Typ: typ}, {Name: "b", Typ: typ}},
+			ReturnType: tree.FixedReturnType(retType(tree.NewDEmptyRange(typ))),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDRange(args[0]), tree.MustBeDRange(args[1]))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// makeRangeBoundPredicateBuiltin returns the definition of a function that
+// reports a property of the bounds of a range or multirange. For multiranges,
+// the lower bound of the first range and the upper bound of the last range are
+// used. Empty ranges and multiranges always return false.
+func makeRangeBoundPredicateBuiltin(f func(*tree.DRange) bool, info string) builtinDefinition {
+	return makeBuiltin(defProps(),
+		append(
+			makeRangeUnaryOverloads(
+				types.Ranges,
+				func(*tree.DRange) *types.T { return types.Bool },
+				func(r *tree.DRange) (tree.Datum, error) {
+					return tree.MakeDBool(tree.DBool(!r.Empty && f(r))), nil
+				},
+				info,
+			),
+			makeMultiRangeUnaryOverloads(
+				func(*tree.DMultiRange) *types.T { return types.Bool },
+				func(m *tree.DMultiRange) (tree.Datum, error) {
+					if len(m.Ranges) == 0 {
+						return tree.DBoolFalse, nil
+					}
+					return tree.MakeDBool(tree.DBool(f(m.Span()))), nil
+				},
+				info,
+			)...,
+		)...,
+	)
+}
+
+// makeRangeConstructor returns the definition of the constructor function of
+// the given range type, such as int4range(lower, upper [, bounds]). A NULL
+// bound is infinite.
+func makeRangeConstructor(typ *types.T) builtinDefinition {
+	sub := typ.RangeContents()
+	construct := func(args tree.Datums, bounds string) (tree.Datum, error) {
+		if len(bounds) != 2 ||
+			(bounds[0] != '[' && bounds[0] != '(') ||
+			(bounds[1] != ']' && bounds[1] != ')') {
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		lower := tree.RangeBound{Inclusive: bounds[0] == '['}
+		if args[0] != tree.DNull {
+			lower.Val = args[0]
+		}
+		upper := tree.RangeBound{Inclusive: bounds[1] == ']'}
+		if args[1] != tree.DNull {
+			upper.Val = args[1]
+		}
+		return tree.NewDRange(typ, lower, upper)
+	}
+	props := defProps()
+	props.Category = builtinconstants.CategoryRange
+	props.AvailableOnPublicSchema = true
+	return makeBuiltin(props,
+		tree.Overload{
+			Types:             tree.ParamTypes{{Name: "lower", Typ: sub}, {Name: "upper", Typ: sub}},
+			ReturnType:        tree.FixedReturnType(typ),
+			CalledOnNullInput: true,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(args, "[)")
+			},
+			Info: fmt.Sprintf("Returns the %s with the given bounds. The lower bound is "+
+				"inclusive and the upper bound is exclusive. A NULL bound is infinite.", typ.PGName()),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: sub}, {Name: "upper", Typ: sub}, {Name: "bounds", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(typ),
+			CalledOnNullInput: true,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.DataException,
+						"range constructor flags argument must not be null")
+				}
+				return construct(args, string(tree.MustBeDString(args[2])))
+			},
+			Info: fmt.Sprintf("Returns the %s with the given bounds. `bounds` is one of "+
+				"`'[]'`, `'[)'`, `'(]'` or `'()'` and determines whether each bound is "+
+				"inclusive. A NULL bound is infinite.", typ.PGName()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeMultiRangeConstructor returns the definition of the constructor function
+// of the given multirange type, such as int4multirange(VARIADIC ranges).
+func makeMultiRangeConstructor(typ *types.T) builtinDefinition {
+	props := defProps()
+	props.Category = builtinconstants.CategoryRange
+	props.AvailableOnPublicSchema = true
+	return makeBuiltin(props,
+		tree.Overload{
+			Types:      tree.VariadicType{VarType: typ.MultiRangeContents()},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ranges := make([]*tree.DRange, len(args))
+				for i, arg := range args {
+					ranges[i] = tree.MustBeDRange(arg)
+				}
+				return tree.NewDMultiRange(typ, ranges)
+			},
+			Info:       fmt.Sprintf("Returns the %s which contains the given ranges.", typ.PGName()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
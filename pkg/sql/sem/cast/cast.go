@@ -212,6 +212,24 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs. A domain can be cast to and from anything its
+	// base type can. A domain and its base type are interchangeable, except that
+	// casting a value to the domain checks the constraints of the domain.
+	if src.IsDomain() || tgt.IsDomain() {
+		srcBase, tgtBase := src.DomainBaseType(), tgt.DomainBaseType()
+		if srcBase.Oid() == tgtBase.Oid() {
+			maxContext := ContextImplicit
+			if tgt.IsDomain() {
+				maxContext = ContextAssignment
+			}
+			return Cast{
+				MaxContext: maxContext,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		return LookupCast(srcBase, tgtBase)
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
@@ -282,6 +300,31 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Range and multirange types are not in castMap. Like arrays, they can be
+	// cast to and from string types, and a range can be cast to the multirange
+	// type of its range type.
+	if srcFamily == types.StringFamily &&
+		(tgtFamily == types.RangeFamily || tgtFamily == types.MultiRangeFamily) {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if (srcFamily == types.RangeFamily || srcFamily == types.MultiRangeFamily) &&
+		tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if srcFamily == types.RangeFamily && tgtFamily == types.MultiRangeFamily &&
+		tgt.MultiRangeContents().Oid() == src.Oid() {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Immutable,
+		}, true
+	}
+
 	// Casts from int types to bit and varbit types are allowed only if the the
 	// length of the bit or varbit is defined
 	if srcFamily == types.IntFamily &&
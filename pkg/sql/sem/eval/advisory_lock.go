@@ -0,0 +1,43 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+// AdvisoryLockKey identifies an advisory lock within a database. Following
+// Postgres, a lock is identified either by a single 64-bit key or by a pair of
+// 32-bit keys, and the two forms never conflict with each other. The fields
+// match the classid, objid and objsubid columns of pg_locks.
+type AdvisoryLockKey struct {
+	// ClassID and ObjID are the high and low halves of a 64-bit key, or the
+	// first and second key of a pair.
+	ClassID, ObjID uint32
+	// ObjSubID is 1 for a 64-bit key and 2 for a pair of keys.
+	ObjSubID uint16
+}
+
+// MakeAdvisoryLockKey returns the key of the advisory lock identified by a
+// single 64-bit key.
+func MakeAdvisoryLockKey(key int64) AdvisoryLockKey {
+	return AdvisoryLockKey{
+		ClassID:  uint32(uint64(key) >> 32),
+		ObjID:    uint32(key),
+		ObjSubID: 1,
+	}
+}
+
+// MakeAdvisoryLockKeyPair returns the key of the advisory lock identified by
+// a pair of 32-bit keys.
+func MakeAdvisoryLockKeyPair(key1, key2 int32) AdvisoryLockKey {
+	return AdvisoryLockKey{
+		ClassID:  uint32(key1),
+		ObjID:    uint32(key2),
+		ObjSubID: 2,
+	}
+}
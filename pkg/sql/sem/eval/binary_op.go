@@ -65,6 +65,18 @@ func (e *evaluator) EvalBitAndINetOp(
 	}), err
 }
 
+func (e *evaluator) EvalBitAndMacAddrOp(
+	ctx context.Context, _ *tree.BitAndMacAddrOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMacAddr(tree.MustBeDMacAddr(a).And(tree.MustBeDMacAddr(b).MacAddr)), nil
+}
+
+func (e *evaluator) EvalBitAndMacAddr8Op(
+	ctx context.Context, _ *tree.BitAndMacAddr8Op, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMacAddr8(tree.MustBeDMacAddr8(a).And(tree.MustBeDMacAddr8(b).MacAddr8)), nil
+}
+
 func (e *evaluator) EvalBitAndIntOp(
 	ctx context.Context, _ *tree.BitAndIntOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -95,6 +107,18 @@ func (e *evaluator) EvalBitOrINetOp(
 	}), err
 }
 
+func (e *evaluator) EvalBitOrMacAddrOp(
+	ctx context.Context, _ *tree.BitOrMacAddrOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMacAddr(tree.MustBeDMacAddr(a).Or(tree.MustBeDMacAddr(b).MacAddr)), nil
+}
+
+func (e *evaluator) EvalBitOrMacAddr8Op(
+	ctx context.Context, _ *tree.BitOrMacAddr8Op, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMacAddr8(tree.MustBeDMacAddr8(a).Or(tree.MustBeDMacAddr8(b).MacAddr8)), nil
+}
+
 func (e *evaluator) EvalBitOrIntOp(
 	ctx context.Context, _ *tree.BitOrIntOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -142,6 +166,22 @@ func (e *evaluator) EvalCompareBox2DOp(
 	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
 }
 
+func (e *evaluator) EvalCompareGeometricOp(
+	ctx context.Context, op *tree.CompareGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	res, err := op.Op(tree.MustBeDGeometric(left).Shape, tree.MustBeDGeometric(right).Shape)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+func (e *evaluator) EvalCompareRangeOp(
+	ctx context.Context, op *tree.CompareRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
+}
+
 func (e *evaluator) EvalCompareScalarOp(
 	ctx context.Context, op *tree.CompareScalarOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -696,6 +736,18 @@ func (e *evaluator) EvalJSONSomeExistsOp(
 	return tree.JSONExistsAny(tree.MustBeDJSON(a), tree.MustBeDArray(b))
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.JSONPathExists(tree.MustBeDJSON(a), tree.MustBeDJsonpath(b))
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return tree.JSONPathMatch(tree.MustBeDJSON(a), tree.MustBeDJsonpath(b))
+}
+
 func (e *evaluator) EvalLShiftINetOp(
 	ctx context.Context, _ *tree.LShiftINetOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -704,6 +756,14 @@ func (e *evaluator) EvalLShiftINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalLShiftCIDROp(
+	ctx context.Context, _ *tree.LShiftCIDROp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ipAddr := tree.MustBeDCIDR(left).IPAddr
+	other := tree.MustBeDCIDR(right).IPAddr
+	return tree.MakeDBool(tree.DBool(ipAddr.ContainedBy(&other))), nil
+}
+
 func (e *evaluator) EvalLShiftIntOp(
 	ctx context.Context, _ *tree.LShiftIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1211,6 +1271,14 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalOverlapsCIDROp(
+	ctx context.Context, _ *tree.OverlapsCIDROp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ipAddr := tree.MustBeDCIDR(left).IPAddr
+	other := tree.MustBeDCIDR(right).IPAddr
+	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1522,6 +1590,14 @@ func (e *evaluator) EvalRShiftINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.Contains(&other))), nil
 }
 
+func (e *evaluator) EvalRShiftCIDROp(
+	ctx context.Context, _ *tree.RShiftCIDROp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ipAddr := tree.MustBeDCIDR(left).IPAddr
+	other := tree.MustBeDCIDR(right).IPAddr
+	return tree.MakeDBool(tree.DBool(ipAddr.Contains(&other))), nil
+}
+
 func (e *evaluator) EvalRShiftIntOp(
 	ctx context.Context, _ *tree.RShiftIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -1543,6 +1619,12 @@ func (e *evaluator) EvalRShiftVarBitIntOp(
 	}, nil
 }
 
+func (e *evaluator) EvalRangeBinaryOp(
+	ctx context.Context, op *tree.RangeBinaryOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return op.Op(left, right)
+}
+
 func (e *evaluator) EvalSimilarToOp(
 	ctx context.Context, op *tree.SimilarToOp, left, right tree.Datum,
 ) (tree.Datum, error) {
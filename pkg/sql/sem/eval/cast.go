@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -92,6 +93,9 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	if t.TypeMeta.DomainData != nil {
+		return performDomainCast(ctx, evalCtx, d, t, truncateWidth)
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -432,7 +436,8 @@ func performCastWithoutPrecisionTruncation(
 			}
 		case *tree.DBool, *tree.DDecimal:
 			s = d.String()
-		case *tree.DTimestamp, *tree.DDate, *tree.DTime, *tree.DTimeTZ, *tree.DGeography, *tree.DGeometry, *tree.DBox2D, *tree.DPGLSN:
+		case *tree.DTimestamp, *tree.DDate, *tree.DTime, *tree.DTimeTZ, *tree.DGeography, *tree.DGeometry, *tree.DBox2D, *tree.DPGLSN,
+			*tree.DCIDR, *tree.DMacAddr, *tree.DMacAddr8, *tree.DGeometric:
 			s = tree.AsStringWithFlags(d, tree.FmtBareStrings)
 		case *tree.DTimestampTZ:
 			// Convert to context timezone for correct display.
@@ -451,7 +456,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange, *tree.DMultiRange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -489,6 +494,8 @@ func performCastWithoutPrecisionTruncation(
 			s = t.String()
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DJsonpath:
+			s = t.Path.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
 		case *tree.DTSVector:
@@ -564,6 +571,66 @@ func performCastWithoutPrecisionTruncation(
 			return tree.ParseDIPAddrFromINetString(t.Contents)
 		case *tree.DIPAddr:
 			return d, nil
+		case *tree.DCIDR:
+			return tree.NewDIPAddr(tree.DIPAddr{IPAddr: t.IPAddr}), nil
+		}
+
+	case types.CIDRFamily:
+		switch t := d.(type) {
+		case *tree.DString:
+			return tree.ParseDCIDR(string(*t))
+		case *tree.DCollatedString:
+			return tree.ParseDCIDR(t.Contents)
+		case *tree.DIPAddr:
+			// Casting an inet to cidr zeroes out the bits to the right of the
+			// netmask.
+			return tree.NewDCIDR(tree.DCIDR{IPAddr: t.IPAddr.Network()}), nil
+		case *tree.DCIDR:
+			return d, nil
+		}
+
+	case types.MacAddrFamily:
+		switch t := d.(type) {
+		case *tree.DString:
+			return tree.ParseDMacAddr(string(*t))
+		case *tree.DCollatedString:
+			return tree.ParseDMacAddr(t.Contents)
+		case *tree.DMacAddr8:
+			m, err := t.ToMacAddr()
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDMacAddr(m), nil
+		case *tree.DMacAddr:
+			return d, nil
+		}
+
+	case types.MacAddr8Family:
+		switch t := d.(type) {
+		case *tree.DString:
+			return tree.ParseDMacAddr8(string(*t))
+		case *tree.DCollatedString:
+			return tree.ParseDMacAddr8(t.Contents)
+		case *tree.DMacAddr:
+			return tree.NewDMacAddr8(t.ToMacAddr8()), nil
+		case *tree.DMacAddr8:
+			return d, nil
+		}
+
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDGeometric(t, string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDGeometric(t, d.Contents)
+		case *tree.DGeometric:
+			k, _ := tree.GeometricKind(t)
+			shape, err := pggeom.Convert(d.Shape, k)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(shape), nil
 		}
 
 	case types.Box2DFamily:
@@ -876,6 +943,15 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return tree.ParseDJSON(string(j))
 		}
+	case types.JsonpathFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		case *tree.DCollatedString:
+			return tree.ParseDJsonpath(v.Contents)
+		case *tree.DJsonpath:
+			return v, nil
+		}
 	case types.TSQueryFamily:
 		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
 			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
@@ -904,6 +980,32 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return &tree.DTSVector{TSVector: vec}, nil
 		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, v.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return d, nil
+		}
+
+	case types.MultiRangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultiRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDMultiRangeFromString(evalCtx, v.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return tree.NewDMultiRange(t, []*tree.DRange{v})
+		case *tree.DMultiRange:
+			return d, nil
+		}
+
 	case types.ArrayFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -233,6 +233,29 @@ type Planner interface {
 		ctx context.Context, expr *tree.RoutineExpr, args tree.Datums,
 	) ValueGenerator
 
+	// EvalTxnControlExpr records a COMMIT or ROLLBACK statement executed by a
+	// stored procedure, along with the continuation of the procedure given the
+	// argument datums. The transaction is ended once the statement that called
+	// the procedure finishes executing.
+	EvalTxnControlExpr(
+		ctx context.Context, expr *tree.TxnControlExpr, args tree.Datums,
+	) (tree.Datum, error)
+
+	// PLpgSQLCloseCursor closes the cursor with the given name, returning an
+	// error if the cursor doesn't exist. It is used to implement the PL/pgSQL
+	// CLOSE statement.
+	PLpgSQLCloseCursor(name tree.Name) error
+
+	// PLpgSQLFetchCursor returns the last row fetched from the cursor with the
+	// given name, or nil if no row was fetched. It is used to implement the
+	// PL/pgSQL FETCH and MOVE statements.
+	PLpgSQLFetchCursor(ctx context.Context, cursor *tree.CursorStmt) (tree.Datums, error)
+
+	// GenUniqueCursorName returns a name that is not used by any open cursor or
+	// portal in the session. It is used to name PL/pgSQL cursors that are
+	// opened without a name.
+	GenUniqueCursorName() tree.Name
+
 	// GenerateTestObjects is used to generate a large number of
 	// objets quickly.
 	// Note: we pass parameters as a string to avoid a package
@@ -348,6 +371,30 @@ type Planner interface {
 	// it is invalid.
 	RepairTTLScheduledJobForTable(ctx context.Context, tableID int64) error
 
+	// NotifyChannel queues a notification with the given payload on the given
+	// channel. It is delivered to the listening sessions when the current
+	// transaction commits.
+	NotifyChannel(ctx context.Context, channel, payload string) error
+
+	// AcquireAdvisoryLock acquires the advisory lock with the given key in the
+	// current database, in shared or exclusive mode. The lock is held until
+	// the end of the current transaction if xact is true, and until it is
+	// explicitly released or the session ends otherwise. If try is true and
+	// the lock is held by another session in a conflicting mode, false is
+	// returned rather than waiting for the lock.
+	AcquireAdvisoryLock(
+		ctx context.Context, key AdvisoryLockKey, shared, xact, try bool,
+	) (bool, error)
+
+	// ReleaseAdvisoryLock releases one session-level hold of the advisory lock
+	// with the given key in the current database. It returns false if the
+	// session does not hold the lock in the given mode.
+	ReleaseAdvisoryLock(ctx context.Context, key AdvisoryLockKey, shared bool) (bool, error)
+
+	// ReleaseAllAdvisoryLocks releases all the session-level advisory locks
+	// held by the session.
+	ReleaseAllAdvisoryLocks(ctx context.Context) error
+
 	// QueryRowEx executes the supplied SQL statement and returns a single row, or
 	// nil if no row is found, or an error if more that one row is returned.
 	//
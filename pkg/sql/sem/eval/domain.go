@@ -0,0 +1,93 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// DomainValueName is the name used to refer to the value being checked in the
+// CHECK constraints of a domain.
+const DomainValueName = "value"
+
+// ReplaceDomainValue returns a copy of the given domain CHECK constraint
+// expression with all references to VALUE replaced with repl.
+func ReplaceDomainValue(expr tree.Expr, repl tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == DomainValueName {
+			return false, repl, nil
+		}
+		return true, e, nil
+	})
+}
+
+// performDomainCast casts the datum to the base type of the domain t, and then
+// checks that the result satisfies the constraints of the domain.
+func performDomainCast(
+	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
+) (tree.Datum, error) {
+	d, err := performCast(ctx, evalCtx, d, t.TypeMeta.DomainData.BaseType, truncateWidth)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckDomainConstraints(ctx, evalCtx, d, t); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// CheckDomainConstraints returns an error if the datum, which must be of the
+// base type of the domain t, violates the NOT NULL or CHECK constraints of the
+// domain. It is a no-op if t is not a domain.
+func CheckDomainConstraints(ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T) error {
+	dd := t.TypeMeta.DomainData
+	if dd == nil {
+		return nil
+	}
+	if d == tree.DNull && dd.NotNull {
+		return pgerror.Newf(pgcode.NotNullViolation,
+			"domain %s does not allow null values", t.Name())
+	}
+	for _, check := range dd.Checks {
+		expr, err := parser.ParseExpr(check.Expr)
+		if err != nil {
+			return errors.NewAssertionErrorWithWrappedErrf(err,
+				"failed to parse check constraint %q of domain %s", check.Name, t.Name())
+		}
+		expr, err = ReplaceDomainValue(expr, d)
+		if err != nil {
+			return err
+		}
+		typedExpr, err := tree.TypeCheck(ctx, expr, nil /* semaCtx */, types.Bool)
+		if err != nil {
+			return err
+		}
+		res, err := Expr(ctx, evalCtx, typedExpr)
+		if err != nil {
+			return err
+		}
+		// A NULL result satisfies the constraint, as for table CHECK
+		// constraints.
+		if res != tree.DNull && !bool(tree.MustBeDBool(res)) {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"value for domain %s violates check constraint %q",
+				t.Name(), check.Name)
+		}
+	}
+	return nil
+}
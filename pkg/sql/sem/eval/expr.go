@@ -182,8 +182,12 @@ func (e *evaluator) EvalCastExpr(ctx context.Context, expr *tree.CastExpr) (tree
 		return nil, err
 	}
 
-	// NULL cast to anything is NULL.
+	// NULL cast to anything is NULL, unless the target is a domain that does
+	// not allow null values.
 	if d == tree.DNull {
+		if typ, ok := expr.Type.(*types.T); ok {
+			return d, CheckDomainConstraints(ctx, e.ctx(), d, typ)
+		}
 		return d, nil
 	}
 	d = UnwrapDatum(ctx, e.ctx(), d)
@@ -647,6 +651,23 @@ func (e *evaluator) evalRoutineArgs(
 	return args, nil
 }
 
+func (e *evaluator) EvalTxnControlExpr(
+	ctx context.Context, expr *tree.TxnControlExpr,
+) (tree.Datum, error) {
+	var err error
+	var args tree.Datums
+	if len(expr.Args) > 0 {
+		args = make(tree.Datums, len(expr.Args))
+		for i := range expr.Args {
+			args[i], err = expr.Args[i].Eval(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return e.Planner.EvalTxnControlExpr(ctx, expr, args)
+}
+
 func (e *evaluator) EvalTuple(ctx context.Context, t *tree.Tuple) (tree.Datum, error) {
 	tuple := tree.NewDTupleWithLen(t.ResolvedType(), len(t.Exprs))
 	for i, expr := range t.Exprs {
@@ -700,4 +721,10 @@ func (e *evaluator) EvalUnqualifiedStar(
 	return nil, errors.AssertionFailedf("unhandled type %T", star)
 }
 
+func (e *evaluator) EvalUserDefinedAggregate(
+	ctx context.Context, agg *tree.UserDefinedAggregate,
+) (tree.Datum, error) {
+	return nil, errors.AssertionFailedf("user-defined aggregate must be evaluated by an aggregator")
+}
+
 var _ tree.ExprEvaluator = (*evaluator)(nil)
@@ -45,6 +45,18 @@ func (e *evaluator) EvalComplementINetOp(
 	return tree.NewDIPAddr(tree.DIPAddr{IPAddr: ipAddr.Complement()}), nil
 }
 
+func (e *evaluator) EvalComplementMacAddrOp(
+	ctx context.Context, _ *tree.ComplementMacAddrOp, d tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMacAddr(tree.MustBeDMacAddr(d).Not()), nil
+}
+
+func (e *evaluator) EvalComplementMacAddr8Op(
+	ctx context.Context, _ *tree.ComplementMacAddr8Op, d tree.Datum,
+) (tree.Datum, error) {
+	return tree.NewDMacAddr8(tree.MustBeDMacAddr8(d).Not()), nil
+}
+
 func (e *evaluator) EvalComplementIntOp(
 	ctx context.Context, _ *tree.ComplementIntOp, d tree.Datum,
 ) (tree.Datum, error) {
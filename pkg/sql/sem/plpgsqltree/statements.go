@@ -56,8 +56,10 @@ func (s *StatementImpl) plpgsqlStmt() {}
 // pl_block
 type Block struct {
 	StatementImpl
-	Label      string
-	Decls      []Declaration
+	Label string
+	// Decls contains the variable and cursor declarations of the block. Each
+	// element is either a *Declaration or a *CursorDeclaration.
+	Decls      []Statement
 	Body       []Statement
 	Exceptions []Exception
 }
@@ -134,10 +136,43 @@ func (s *Declaration) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
 }
 
+// decl_cursor_stmt
+type CursorDeclaration struct {
+	StatementImpl
+	Name   Variable
+	Scroll tree.CursorScrollOption
+	Query  tree.Statement
+}
+
+func (s *CursorDeclaration) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Name)
+	switch s.Scroll {
+	case tree.Scroll:
+		ctx.WriteString(" SCROLL")
+	case tree.NoScroll:
+		ctx.WriteString(" NO SCROLL")
+	}
+	ctx.WriteString(" CURSOR FOR ")
+	s.Query.Format(ctx)
+	ctx.WriteString(";\n")
+}
+
+func (s *CursorDeclaration) PlpgSQLStatementTag() string {
+	return "decl_cursor_stmt"
+}
+
+func (s *CursorDeclaration) WalkStmt(visitor StatementVisitor) {
+	visitor.Visit(s)
+}
+
 // stmt_assign
 type Assignment struct {
 	Statement
-	Var   Variable
+	Var Variable
+	// Field is the name of the record field that is assigned to if the target
+	// of the assignment is of the form var.field, e.g. NEW.x := 1 in a trigger
+	// function. It is empty if the whole variable is assigned to.
+	Field tree.Name
 	Value Expr
 }
 
@@ -146,6 +181,10 @@ func (s *Assignment) PlpgSQLStatementTag() string {
 }
 
 func (s *Assignment) Format(ctx *tree.FmtCtx) {
+	if s.Field != "" {
+		ctx.WriteString(fmt.Sprintf("%s.%s := %s;\n", s.Var, s.Field, s.Value))
+		return
+	}
 	ctx.WriteString(fmt.Sprintf("%s := %s;\n", s.Var, s.Value))
 }
 
@@ -384,11 +423,26 @@ type ForInt struct {
 	Lower   Expr
 	Upper   Expr
 	Step    Expr
-	Reverse int
+	Reverse bool
 	Body    []Statement
 }
 
 func (s *ForInt) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(s.Lower)
+	ctx.WriteString("..")
+	ctx.FormatNode(s.Upper)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(s.Step)
+	}
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForInt) PlpgSQLStatementTag() string {
@@ -402,33 +456,43 @@ func (s *ForInt) WalkStmt(visitor StatementVisitor) {
 	}
 }
 
+// ForQuery contains the fields shared by the FOR loops that iterate over the
+// rows of a query.
 type ForQuery struct {
 	StatementImpl
-	Label string
-	Var   Variable
-	Body  []Statement
-}
-
-func (s *ForQuery) Format(ctx *tree.FmtCtx) {
+	Label  string
+	Target []Variable
+	Body   []Statement
 }
 
-func (s *ForQuery) PlpgSQLStatementTag() string {
-	return "stmt_for_query_loop"
+func (s *ForQuery) formatTarget(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	ctx.WriteString(" IN ")
 }
 
 func (s *ForQuery) WalkStmt(visitor StatementVisitor) {
-	visitor.Visit(s)
 	for _, stmt := range s.Body {
 		stmt.WalkStmt(visitor)
 	}
 }
 
+// stmt_for with a query
 type ForSelect struct {
 	ForQuery
-	Query Expr
+	Query tree.Statement
 }
 
 func (s *ForSelect) Format(ctx *tree.FmtCtx) {
+	s.formatTarget(ctx)
+	s.Query.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForSelect) PlpgSQLStatementTag() string {
@@ -440,13 +504,17 @@ func (s *ForSelect) WalkStmt(visitor StatementVisitor) {
 	s.ForQuery.WalkStmt(visitor)
 }
 
+// stmt_for with a bound cursor
 type ForCursor struct {
 	ForQuery
-	CurVar   int // TODO(drewk): is this CursorVariable?
-	ArgQuery Expr
+	CurVar Variable
 }
 
 func (s *ForCursor) Format(ctx *tree.FmtCtx) {
+	s.formatTarget(ctx)
+	ctx.FormatNode(&s.CurVar)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForCursor) PlpgSQLStatementTag() string {
@@ -458,35 +526,26 @@ func (s *ForCursor) WalkStmt(visitor StatementVisitor) {
 	s.ForQuery.WalkStmt(visitor)
 }
 
-type ForDynamic struct {
-	ForQuery
-	Query  Expr
-	Params []Expr
-}
-
-func (s *ForDynamic) Format(ctx *tree.FmtCtx) {
-}
-
-func (s *ForDynamic) PlpgSQLStatementTag() string {
-	return "stmt_for_dyn_loop"
-}
-
-func (s *ForDynamic) WalkStmt(visitor StatementVisitor) {
-	visitor.Visit(s)
-	s.ForQuery.WalkStmt(visitor)
-}
-
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
 	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Var   Variable
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOREACH ")
+	ctx.FormatNode(&s.Var)
+	if s.Slice != 0 {
+		ctx.WriteString(fmt.Sprintf(" SLICE %d", s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -501,6 +560,19 @@ func (s *ForEachArray) WalkStmt(visitor StatementVisitor) {
 	}
 }
 
+// formatLoopBody formats the body of a loop statement, followed by the END
+// LOOP keywords and the optional label.
+func formatLoopBody(ctx *tree.FmtCtx, label string, body []Statement) {
+	for _, stmt := range body {
+		stmt.Format(ctx)
+	}
+	ctx.WriteString("END LOOP")
+	if label != "" {
+		ctx.WriteString(fmt.Sprintf(" %s", label))
+	}
+	ctx.WriteString(";\n")
+}
+
 // stmt_exit
 type Exit struct {
 	StatementImpl
@@ -564,10 +636,14 @@ type Return struct {
 }
 
 func (s *Return) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString("RETURN ")
+	ctx.WriteString("RETURN")
 	if s.Expr == nil {
-		s.RetVar.Format(ctx)
+		if s.RetVar != "" {
+			ctx.WriteString(" ")
+			s.RetVar.Format(ctx)
+		}
 	} else {
+		ctx.WriteString(" ")
 		s.Expr.Format(ctx)
 	}
 	ctx.WriteString(";\n")
@@ -581,13 +657,19 @@ func (s *Return) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
 }
 
+// stmt_return_next
 type ReturnNext struct {
 	StatementImpl
-	Expr   Expr
-	RetVar Variable
+	Expr Expr
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteString(" ")
+		s.Expr.Format(ctx)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -598,14 +680,16 @@ func (s *ReturnNext) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
 }
 
+// stmt_return_query
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
-	DynamicQuery Expr
-	Params       []Expr
+	Query tree.Statement
 }
 
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	s.Query.Format(ctx)
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -765,10 +849,21 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// Query is the SELECT statement that results from replacing the PERFORM
+	// keyword with SELECT.
+	Query tree.Statement
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// Format the SELECT statement, and then replace the SELECT keyword with
+	// PERFORM.
+	start := ctx.Len()
+	s.Query.Format(ctx)
+	query := strings.TrimPrefix(ctx.String()[start:], "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(query)
+	ctx.WriteString(";\n")
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -850,50 +945,27 @@ func (s *GetDiagnostics) WalkStmt(visitor StatementVisitor) {
 // stmt_open
 type Open struct {
 	StatementImpl
-	CurVar        int // TODO(drewk): this could just a Variable
-	CursorOptions uint32
-	// TODO(jane): This is temporary and we should remove it and use CurVar.
-	CursorName       string
-	WithExplicitExpr bool
-	// TODO(jane): Should be Expr
-	ArgQuery string
-	// TODO(jane): Should be Expr
-	Query string
-	// TODO(jane): Should be Expr
-	DynamicQuery string
-	// TODO(jane): Should be []Expr
-	Params []string
+	CurVar Variable
+	Scroll tree.CursorScrollOption
+	// Query is the query the cursor is opened for. It is nil when opening a
+	// bound cursor, in which case the query from the cursor declaration is used.
+	Query tree.Statement
 }
 
 func (s *Open) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString(
-		fmt.Sprintf(
-			"OPEN %s ",
-			s.CursorName,
-		))
-
-	opts := OptListFromBitField(s.CursorOptions)
-	for _, opt := range opts {
-		if opt.String() != "" {
-			ctx.WriteString(fmt.Sprintf("%s ", opt.String()))
-		}
-	}
-	if !s.WithExplicitExpr {
-		ctx.WriteString("FOR ")
-		if s.DynamicQuery != "" {
-			// TODO(drewk): Make sure placeholders are properly printed
-			ctx.WriteString(fmt.Sprintf("EXECUTE %s ", s.DynamicQuery))
-			if len(s.Params) != 0 {
-				// TODO(drewk): Dont print instances of multiple params with brackets `[...]`
-				ctx.WriteString(fmt.Sprintf("USING %s", s.Params))
-			}
-		} else {
-			ctx.WriteString(s.Query)
+	ctx.WriteString("OPEN ")
+	ctx.FormatNode(&s.CurVar)
+	if s.Query != nil {
+		switch s.Scroll {
+		case tree.Scroll:
+			ctx.WriteString(" SCROLL")
+		case tree.NoScroll:
+			ctx.WriteString(" NO SCROLL")
 		}
-	} else {
-		ctx.WriteString(s.ArgQuery)
+		ctx.WriteString(" FOR ")
+		s.Query.Format(ctx)
 	}
-	ctx.WriteString("\n")
+	ctx.WriteString(";\n")
 }
 
 func (s *Open) PlpgSQLStatementTag() string {
@@ -908,16 +980,30 @@ func (s *Open) WalkStmt(visitor StatementVisitor) {
 // stmt_move (where IsMove = true)
 type Fetch struct {
 	StatementImpl
-	Target           Variable
-	CurVar           int // TODO(drewk): this could just a Variable
-	Direction        FetchDirection
-	HowMany          int64
-	Expr             Expr
-	IsMove           bool
-	ReturnsMultiRows bool
+	// Cursor contains the name of the cursor variable and the direction of the
+	// FETCH or MOVE.
+	Cursor tree.CursorStmt
+	Target []Variable
+	IsMove bool
 }
 
 func (s *Fetch) Format(ctx *tree.FmtCtx) {
+	if s.IsMove {
+		ctx.WriteString("MOVE ")
+	} else {
+		ctx.WriteString("FETCH ")
+	}
+	s.Cursor.Format(ctx)
+	if s.Target != nil {
+		ctx.WriteString(" INTO ")
+		for i := range s.Target {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			s.Target[i].Format(ctx)
+		}
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *Fetch) PlpgSQLStatementTag() string {
@@ -934,13 +1020,13 @@ func (s *Fetch) WalkStmt(visitor StatementVisitor) {
 // stmt_close
 type Close struct {
 	StatementImpl
-	CurVar int // TODO(drewk): this could just a Variable
+	CurVar Variable
 }
 
 func (s *Close) Format(ctx *tree.FmtCtx) {
-	// TODO(drewk): Pretty- Print the cursor identifier
-	ctx.WriteString("CLOSE a cursor\n")
-
+	ctx.WriteString("CLOSE ")
+	ctx.FormatNode(&s.CurVar)
+	ctx.WriteString(";\n")
 }
 
 func (s *Close) PlpgSQLStatementTag() string {
@@ -958,6 +1044,11 @@ type Commit struct {
 }
 
 func (s *Commit) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("COMMIT")
+	if s.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *Commit) PlpgSQLStatementTag() string {
@@ -975,6 +1066,11 @@ type Rollback struct {
 }
 
 func (s *Rollback) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("ROLLBACK")
+	if s.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *Rollback) PlpgSQLStatementTag() string {
@@ -991,7 +1087,7 @@ type Null struct {
 }
 
 func (s *Null) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString("NULL\n")
+	ctx.WriteString("NULL;\n")
 }
 
 func (s *Null) PlpgSQLStatementTag() string {
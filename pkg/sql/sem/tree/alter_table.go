@@ -14,6 +14,8 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
 // AlterTable represents an ALTER TABLE statement.
@@ -58,6 +60,7 @@ type AlterTableCmd interface {
 
 func (*AlterTableAddColumn) alterTableCmd()          {}
 func (*AlterTableAddConstraint) alterTableCmd()      {}
+func (*AlterTableAlterConstraint) alterTableCmd()    {}
 func (*AlterTableAlterColumnType) alterTableCmd()    {}
 func (*AlterTableAlterPrimaryKey) alterTableCmd()    {}
 func (*AlterTableDropColumn) alterTableCmd()         {}
@@ -76,9 +79,11 @@ func (*AlterTablePartitionByTable) alterTableCmd()   {}
 func (*AlterTableInjectStats) alterTableCmd()        {}
 func (*AlterTableSetStorageParams) alterTableCmd()   {}
 func (*AlterTableResetStorageParams) alterTableCmd() {}
+func (*AlterTableRowLevelSecurity) alterTableCmd()   {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
+var _ AlterTableCmd = &AlterTableAlterConstraint{}
 var _ AlterTableCmd = &AlterTableAlterColumnType{}
 var _ AlterTableCmd = &AlterTableDropColumn{}
 var _ AlterTableCmd = &AlterTableDropConstraint{}
@@ -96,6 +101,7 @@ var _ AlterTableCmd = &AlterTablePartitionByTable{}
 var _ AlterTableCmd = &AlterTableInjectStats{}
 var _ AlterTableCmd = &AlterTableSetStorageParams{}
 var _ AlterTableCmd = &AlterTableResetStorageParams{}
+var _ AlterTableCmd = &AlterTableRowLevelSecurity{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -345,6 +351,81 @@ func (node *AlterTableValidateConstraint) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Constraint)
 }
 
+// AlterTableAlterConstraint represents an ALTER CONSTRAINT command.
+type AlterTableAlterConstraint struct {
+	Constraint Name
+	// Actions contains the new ON DELETE and ON UPDATE actions of a foreign key
+	// constraint. Each action is only changed if the corresponding SetDeleteAction
+	// or SetUpdateAction field is set.
+	Actions         ReferenceActions
+	SetDeleteAction bool
+	SetUpdateAction bool
+	// Validation is the new validation state of the constraint. It is only
+	// changed if SetValidation is set.
+	Validation    ValidationBehavior
+	SetValidation bool
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableAlterConstraint) TelemetryName() string {
+	return "alter_constraint"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableAlterConstraint) Format(ctx *FmtCtx) {
+	ctx.WriteString(" ALTER CONSTRAINT ")
+	ctx.FormatNode(&node.Constraint)
+	formatAction := func(a ReferenceAction) {
+		if a == NoAction {
+			ctx.WriteString("NO ACTION")
+		} else {
+			ctx.WriteString(a.String())
+		}
+	}
+	if node.SetDeleteAction {
+		ctx.WriteString(" ON DELETE ")
+		formatAction(node.Actions.Delete)
+	}
+	if node.SetUpdateAction {
+		ctx.WriteString(" ON UPDATE ")
+		formatAction(node.Actions.Update)
+	}
+	if node.SetValidation {
+		if node.Validation == ValidationSkip {
+			ctx.WriteString(" NOT VALID")
+		} else {
+			ctx.WriteString(" VALIDATE")
+		}
+	}
+}
+
+// CombineWith merges the options of other into node. It returns an error if
+// the same option is specified in both.
+func (node *AlterTableAlterConstraint) CombineWith(other *AlterTableAlterConstraint) error {
+	if other.SetDeleteAction {
+		if node.SetDeleteAction {
+			return pgerror.Newf(pgcode.Syntax, "ON DELETE specified multiple times")
+		}
+		node.Actions.Delete = other.Actions.Delete
+		node.SetDeleteAction = true
+	}
+	if other.SetUpdateAction {
+		if node.SetUpdateAction {
+			return pgerror.Newf(pgcode.Syntax, "ON UPDATE specified multiple times")
+		}
+		node.Actions.Update = other.Actions.Update
+		node.SetUpdateAction = true
+	}
+	if other.SetValidation {
+		if node.SetValidation {
+			return pgerror.Newf(pgcode.Syntax, "validation state specified multiple times")
+		}
+		node.Validation = other.Validation
+		node.SetValidation = true
+	}
+	return nil
+}
+
 // AlterTableRenameColumn represents an ALTER TABLE RENAME [COLUMN] command.
 type AlterTableRenameColumn struct {
 	Column  Name
@@ -644,6 +725,54 @@ func (node *AlterTableResetStorageParams) Format(ctx *FmtCtx) {
 	ctx.WriteString(")")
 }
 
+// RowLevelSecurityMode is the mode set by an ALTER TABLE ... ROW LEVEL
+// SECURITY command.
+type RowLevelSecurityMode uint8
+
+const (
+	// RowLevelSecurityEnable enables the row-level security policies of the
+	// table.
+	RowLevelSecurityEnable RowLevelSecurityMode = iota
+	// RowLevelSecurityDisable disables the row-level security policies of the
+	// table. The policies are kept, but they are not applied.
+	RowLevelSecurityDisable
+	// RowLevelSecurityForce applies the row-level security policies of the
+	// table to its owner as well.
+	RowLevelSecurityForce
+	// RowLevelSecurityNoForce exempts the owner of the table from its
+	// row-level security policies.
+	RowLevelSecurityNoForce
+)
+
+var rowLevelSecurityModeName = [...]string{
+	RowLevelSecurityEnable:  "ENABLE",
+	RowLevelSecurityDisable: "DISABLE",
+	RowLevelSecurityForce:   "FORCE",
+	RowLevelSecurityNoForce: "NO FORCE",
+}
+
+func (m RowLevelSecurityMode) String() string {
+	return rowLevelSecurityModeName[m]
+}
+
+// AlterTableRowLevelSecurity represents an ALTER TABLE { ENABLE | DISABLE |
+// FORCE | NO FORCE } ROW LEVEL SECURITY command.
+type AlterTableRowLevelSecurity struct {
+	Mode RowLevelSecurityMode
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableRowLevelSecurity) TelemetryName() string {
+	return strings.ReplaceAll(strings.ToLower(node.Mode.String()), " ", "_") + "_row_level_security"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableRowLevelSecurity) Format(ctx *FmtCtx) {
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Mode.String())
+	ctx.WriteString(" ROW LEVEL SECURITY")
+}
+
 // AlterTableLocality represents an ALTER TABLE LOCALITY command.
 type AlterTableLocality struct {
 	Name     *UnresolvedObjectName
@@ -0,0 +1,130 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// Call represents a CALL statement, which invokes a stored procedure.
+type Call struct {
+	// Proc is the procedure being called, along with its arguments.
+	Proc *FuncExpr
+}
+
+var _ Statement = &Call{}
+
+// Format implements the NodeFormatter interface.
+func (node *Call) Format(ctx *FmtCtx) {
+	ctx.WriteString("CALL ")
+	ctx.FormatNode(node.Proc)
+}
+
+// TxnControlOp is a transaction control operation that can be executed from
+// within the body of a stored procedure.
+type TxnControlOp uint8
+
+const (
+	// StoredProcTxnNoOp indicates that no transaction control operation was
+	// requested.
+	StoredProcTxnNoOp TxnControlOp = iota
+	// StoredProcTxnCommit commits the current transaction.
+	StoredProcTxnCommit
+	// StoredProcTxnRollback rolls back the current transaction.
+	StoredProcTxnRollback
+)
+
+// String implements the fmt.Stringer interface.
+func (op TxnControlOp) String() string {
+	switch op {
+	case StoredProcTxnCommit:
+		return "COMMIT"
+	case StoredProcTxnRollback:
+		return "ROLLBACK"
+	default:
+		return "NO-OP"
+	}
+}
+
+// StoredProcContinuation is the remainder of a stored procedure that is
+// executed in a new transaction after the procedure executes a COMMIT or
+// ROLLBACK statement. It currently maps to *memo.Memo. We use the empty
+// interface here rather than *memo.Memo to avoid import cycles.
+type StoredProcContinuation interface{}
+
+// StoredProcContinuationGenerator builds the continuation of a stored
+// procedure, given the values of the procedure's variables at the point where
+// the transaction control statement was executed.
+type StoredProcContinuationGenerator func(
+	ctx context.Context, args Datums,
+) (StoredProcContinuation, error)
+
+// TxnControlExpr represents a COMMIT or ROLLBACK statement within the body of
+// a stored procedure. Evaluating it does not end the transaction directly;
+// instead, it records the operation and the remainder of the procedure, which
+// are processed once the statement that called the procedure finishes. It is
+// only created by execbuilder - it is never constructed during parsing.
+type TxnControlExpr struct {
+	// Op is the transaction control operation.
+	Op TxnControlOp
+
+	// Args contains the values of the procedure's variables, which are passed
+	// to the continuation.
+	Args TypedExprs
+
+	// Gen builds the continuation of the procedure.
+	Gen StoredProcContinuationGenerator
+
+	// Typ is the type of the expression. It matches the return type of the
+	// procedure; the expression always evaluates to NULL.
+	Typ *types.T
+}
+
+var _ TypedExpr = &TxnControlExpr{}
+
+// NewTxnControlExpr returns a new TxnControlExpr that is well-typed.
+func NewTxnControlExpr(
+	op TxnControlOp, args TypedExprs, gen StoredProcContinuationGenerator, typ *types.T,
+) *TxnControlExpr {
+	return &TxnControlExpr{
+		Op:   op,
+		Args: args,
+		Gen:  gen,
+		Typ:  typ,
+	}
+}
+
+// TypeCheck is part of the Expr interface.
+func (node *TxnControlExpr) TypeCheck(
+	ctx context.Context, semaCtx *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return node, nil
+}
+
+// ResolvedType is part of the TypedExpr interface.
+func (node *TxnControlExpr) ResolvedType() *types.T {
+	return node.Typ
+}
+
+// Format is part of the Expr interface.
+func (node *TxnControlExpr) Format(ctx *FmtCtx) {
+	ctx.Printf("%s(", node.Op)
+	ctx.FormatNode(&node.Args)
+	ctx.WriteByte(')')
+}
+
+// Walk is part of the Expr interface.
+func (node *TxnControlExpr) Walk(v Visitor) Expr {
+	// Cannot walk into a transaction control expression, so this is a no-op.
+	return node
+}
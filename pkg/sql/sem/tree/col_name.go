@@ -118,6 +118,10 @@ func ComputeColNameInternal(
 	case *CollateExpr:
 		return ComputeColNameInternal(ctx, sp, e.Expr, funcResolver)
 
+	case *GroupingFuncExpr:
+		// Make GROUPING() act like a regular function.
+		return 2, "grouping", nil
+
 	case *ArrayFlatten:
 		return 2, "array", nil
 
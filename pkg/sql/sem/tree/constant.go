@@ -541,15 +541,41 @@ var (
 		types.IntervalArray,
 		types.UUIDArray,
 		types.INet,
+		types.CIDR,
+		types.MacAddr,
+		types.MacAddr8,
+		types.Point,
+		types.LSeg,
+		types.Box,
+		types.Path,
+		types.Polygon,
+		types.Line,
+		types.Circle,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.DateRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.Int4MultiRange,
+		types.Int8MultiRange,
+		types.NumMultiRange,
+		types.DateMultiRange,
+		types.TSMultiRange,
+		types.TSTZMultiRange,
 		types.Jsonb,
 		types.PGLSN,
 		types.PGLSNArray,
 		types.TSQuery,
 		types.TSVector,
+		types.Jsonpath,
 		types.VarBit,
 		types.AnyEnum,
 		types.AnyEnumArray,
 		types.INetArray,
+		types.CIDRArray,
+		types.MacAddrArray,
+		types.MacAddr8Array,
 		types.VarBitArray,
 		types.AnyTuple,
 		types.AnyTupleArray,
@@ -353,6 +353,36 @@ func mustParseDINet(t *testing.T, s string) tree.Datum {
 	}
 	return d
 }
+func mustParseDCIDR(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+func mustParseDMacAddr(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDMacAddr(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+func mustParseDMacAddr8(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDMacAddr8(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+func mustParseDGeometricOfType(typ *types.T) func(t *testing.T, s string) tree.Datum {
+	return func(t *testing.T, s string) tree.Datum {
+		d, err := tree.ParseDGeometric(typ, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+}
 func mustParseDVarBit(t *testing.T, s string) tree.Datum {
 	d, err := tree.ParseDBitArray(s)
 	if err != nil {
@@ -367,6 +397,14 @@ func mustParseDTSVector(t *testing.T, s string) tree.Datum {
 	}
 	return d
 }
+func mustParseDJsonpath(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDJsonpath(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
 func mustParseDTSQuery(t *testing.T, s string) tree.Datum {
 	d, err := tree.ParseDTSQuery(s)
 	if err != nil {
@@ -384,6 +422,26 @@ func mustParseDArrayOfType(typ *types.T) func(t *testing.T, s string) tree.Datum
 		return d
 	}
 }
+func mustParseDRangeOfType(typ *types.T) func(t *testing.T, s string) tree.Datum {
+	return func(t *testing.T, s string) tree.Datum {
+		evalContext := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		d, _, err := tree.ParseDRangeFromString(&evalContext, s, typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+}
+func mustParseDMultiRangeOfType(typ *types.T) func(t *testing.T, s string) tree.Datum {
+	return func(t *testing.T, s string) tree.Datum {
+		evalContext := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		d, _, err := tree.ParseDMultiRangeFromString(&evalContext, s, typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+}
 
 var parseFuncs = map[*types.T]func(*testing.T, string) tree.Datum{
 	types.String:           func(t *testing.T, s string) tree.Datum { return tree.NewDString(s) },
@@ -404,10 +462,33 @@ var parseFuncs = map[*types.T]func(*testing.T, string) tree.Datum{
 	types.Geography:        mustParseDGeography,
 	types.Geometry:         mustParseDGeometry,
 	types.INet:             mustParseDINet,
+	types.CIDR:             mustParseDCIDR,
+	types.MacAddr:          mustParseDMacAddr,
+	types.MacAddr8:         mustParseDMacAddr8,
+	types.Point:            mustParseDGeometricOfType(types.Point),
+	types.LSeg:             mustParseDGeometricOfType(types.LSeg),
+	types.Box:              mustParseDGeometricOfType(types.Box),
+	types.Path:             mustParseDGeometricOfType(types.Path),
+	types.Polygon:          mustParseDGeometricOfType(types.Polygon),
+	types.Line:             mustParseDGeometricOfType(types.Line),
+	types.Circle:           mustParseDGeometricOfType(types.Circle),
+	types.Int4Range:        mustParseDRangeOfType(types.Int4Range),
+	types.Int8Range:        mustParseDRangeOfType(types.Int8Range),
+	types.NumRange:         mustParseDRangeOfType(types.NumRange),
+	types.DateRange:        mustParseDRangeOfType(types.DateRange),
+	types.TSRange:          mustParseDRangeOfType(types.TSRange),
+	types.TSTZRange:        mustParseDRangeOfType(types.TSTZRange),
+	types.Int4MultiRange:   mustParseDMultiRangeOfType(types.Int4MultiRange),
+	types.Int8MultiRange:   mustParseDMultiRangeOfType(types.Int8MultiRange),
+	types.NumMultiRange:    mustParseDMultiRangeOfType(types.NumMultiRange),
+	types.DateMultiRange:   mustParseDMultiRangeOfType(types.DateMultiRange),
+	types.TSMultiRange:     mustParseDMultiRangeOfType(types.TSMultiRange),
+	types.TSTZMultiRange:   mustParseDMultiRangeOfType(types.TSTZMultiRange),
 	types.VarBit:           mustParseDVarBit,
 	types.PGLSN:            mustParseDPGLSN,
 	types.TSQuery:          mustParseDTSQuery,
 	types.TSVector:         mustParseDTSVector,
+	types.Jsonpath:         mustParseDJsonpath,
 	types.BytesArray:       mustParseDArrayOfType(types.Bytes),
 	types.DecimalArray:     mustParseDArrayOfType(types.Decimal),
 	types.FloatArray:       mustParseDArrayOfType(types.Float),
@@ -423,6 +504,9 @@ var parseFuncs = map[*types.T]func(*testing.T, string) tree.Datum{
 	types.TimestampTZArray: mustParseDArrayOfType(types.TimestampTZ),
 	types.IntervalArray:    mustParseDArrayOfType(types.Interval),
 	types.INetArray:        mustParseDArrayOfType(types.INet),
+	types.CIDRArray:        mustParseDArrayOfType(types.CIDR),
+	types.MacAddrArray:     mustParseDArrayOfType(types.MacAddr),
+	types.MacAddr8Array:    mustParseDArrayOfType(types.MacAddr8),
 	types.VarBitArray:      mustParseDArrayOfType(types.VarBit),
 	types.PGLSNArray:       mustParseDArrayOfType(types.PGLSN),
 }
@@ -453,11 +537,11 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 		},
 		{
 			c:            tree.NewStrVal("true"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Bool, types.Jsonb, types.TSVector, types.TSQuery),
+			parseOptions: typeSet(types.String, types.Bytes, types.Bool, types.Jsonb, types.TSVector, types.TSQuery, types.Jsonpath),
 		},
 		{
 			c:            tree.NewStrVal("2010-09-28"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Date, types.Timestamp, types.TimestampTZ, types.TSVector, types.TSQuery),
+			parseOptions: typeSet(types.String, types.Bytes, types.Date, types.Timestamp, types.TimestampTZ, types.TSVector, types.TSQuery, types.Jsonpath),
 		},
 		{
 			c:            tree.NewStrVal("2010-09-28 12:00:00.1"),
@@ -501,7 +585,11 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 		},
 		{
 			c:            tree.NewStrVal("192.168.100.128/25"),
-			parseOptions: typeSet(types.String, types.Bytes, types.INet, types.TSVector, types.TSQuery),
+			parseOptions: typeSet(types.String, types.Bytes, types.INet, types.CIDR, types.TSVector, types.TSQuery),
+		},
+		{
+			c:            tree.NewStrVal("08:00:2b:01:02:03"),
+			parseOptions: typeSet(types.String, types.Bytes, types.MacAddr, types.MacAddr8),
 		},
 		{
 			c: tree.NewStrVal("111000110101"),
@@ -516,6 +604,9 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 				types.Jsonb,
 				types.TSVector,
 				types.TSQuery,
+				types.Jsonpath,
+				types.MacAddr,
+				types.MacAddr8,
 			),
 		},
 		{
@@ -537,6 +628,7 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 				types.FloatArray,
 				types.DecimalArray,
 				types.IntervalArray,
+				types.CIDRArray,
 				types.TSVector,
 				types.TSQuery,
 			),
@@ -551,6 +643,7 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 				types.FloatArray,
 				types.DecimalArray,
 				types.IntervalArray,
+				types.CIDRArray,
 				types.TSVector,
 				types.TSQuery,
 			),
@@ -617,7 +710,32 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 		},
 		{
 			c:            tree.NewStrVal("{192.168.100.128, ::ffff:10.4.3.2}"),
-			parseOptions: typeSet(types.String, types.Bytes, types.BytesArray, types.StringArray, types.INetArray),
+			parseOptions: typeSet(types.String, types.Bytes, types.BytesArray, types.StringArray, types.INetArray, types.CIDRArray),
+		},
+		{
+			c: tree.NewStrVal("[1,10)"),
+			parseOptions: typeSet(
+				types.String,
+				types.Bytes,
+				types.Path,
+				types.TSVector,
+				types.Int4Range,
+				types.Int8Range,
+				types.NumRange,
+			),
+		},
+		{
+			c: tree.NewStrVal("{[1,3), (5,7]}"),
+			parseOptions: typeSet(
+				types.String,
+				types.Bytes,
+				types.BytesArray,
+				types.StringArray,
+				types.TSVector,
+				types.Int4MultiRange,
+				types.Int8MultiRange,
+				types.NumMultiRange,
+			),
 		},
 		{
 			c: tree.NewStrVal("{0101, 11}"),
@@ -630,6 +748,7 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 				types.FloatArray,
 				types.DecimalArray,
 				types.IntervalArray,
+				types.CIDRArray,
 				types.VarBitArray,
 				types.TSVector,
 			),
@@ -13,7 +13,10 @@ package tree
 import (
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/errors"
 )
 
 // ReferenceAction is the method used to maintain referential integrity through
@@ -48,6 +51,38 @@ func (node *ReferenceActions) Format(ctx *FmtCtx) {
 	}
 }
 
+// ConstraintDeferrability describes whether the checks of a FOREIGN KEY or
+// UNIQUE constraint can be deferred until the end of the transaction.
+type ConstraintDeferrability struct {
+	// Deferrable is true if the checks can be deferred with SET CONSTRAINTS.
+	Deferrable bool
+	// InitiallyDeferred is true if the checks are deferred unless SET
+	// CONSTRAINTS says otherwise. It implies Deferrable.
+	InitiallyDeferred bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if node.Deferrable {
+		ctx.WriteString(" DEFERRABLE")
+		if node.InitiallyDeferred {
+			ctx.WriteString(" INITIALLY DEFERRED")
+		}
+	}
+}
+
+// NewDeferrableUniqueIndexError creates an error for a DEFERRABLE primary key
+// or unique constraint that would be enforced by a unique index. The index
+// rejects duplicate values as soon as they are written, so its checks cannot
+// be deferred until the end of the transaction.
+func NewDeferrableUniqueIndexError() error {
+	return errors.WithHint(
+		pgerror.New(pgcode.FeatureNotSupported,
+			"DEFERRABLE unique constraints backed by a unique index are not supported"),
+		"Use a UNIQUE WITHOUT INDEX constraint, which can be deferred.",
+	)
+}
+
 // ForeignKeyReferenceActionType allows the conversion between a
 // tree.ReferenceAction and a ForeignKeyReference_Action.
 var ForeignKeyReferenceActionType = [...]ReferenceAction{
@@ -94,7 +129,7 @@ type CompositeKeyMatchMethod semenumpb.Match
 const (
 	MatchSimple CompositeKeyMatchMethod = iota
 	MatchFull
-	MatchPartial // Note: PARTIAL not actually supported at this point.
+	MatchPartial
 )
 
 // CompositeKeyMatchMethodType allows the conversion from a
@@ -0,0 +1,125 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	Options AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString("(")
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteString(")")
+}
+
+// AggregateOptions represent a list of aggregate options.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOptions) Format(ctx *FmtCtx) {
+	for i, option := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(option)
+	}
+}
+
+// AggregateOption is an interface representing the options of a CREATE
+// AGGREGATE statement.
+type AggregateOption interface {
+	NodeFormatter
+	aggregateOption()
+}
+
+func (AggregateStateFunc) aggregateOption()   {}
+func (AggregateStateType) aggregateOption()   {}
+func (AggregateFinalFunc) aggregateOption()   {}
+func (AggregateCombineFunc) aggregateOption() {}
+func (AggregateInitCond) aggregateOption()    {}
+
+// AggregateStateFunc represents the SFUNC option of an aggregate, which names
+// the state transition function.
+type AggregateStateFunc struct {
+	Name RoutineName
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateStateFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("SFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateStateType represents the STYPE option of an aggregate, which is
+// the type of the aggregate's state value.
+type AggregateStateType struct {
+	Type ResolvableTypeReference
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateStateType) Format(ctx *FmtCtx) {
+	ctx.WriteString("STYPE = ")
+	ctx.FormatTypeReference(node.Type)
+}
+
+// AggregateFinalFunc represents the FINALFUNC option of an aggregate, which
+// names the function that computes the aggregate's result from its final
+// state.
+type AggregateFinalFunc struct {
+	Name RoutineName
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateFinalFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("FINALFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateCombineFunc represents the COMBINEFUNC option of an aggregate,
+// which names the function that merges two partial states.
+type AggregateCombineFunc struct {
+	Name RoutineName
+}
+
+// Format implements the NodeFormatter interface.
+func (node AggregateCombineFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("COMBINEFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateInitCond represents the INITCOND option of an aggregate, which is
+// the text representation of the aggregate's initial state.
+type AggregateInitCond string
+
+// Format implements the NodeFormatter interface.
+func (node AggregateInitCond) Format(ctx *FmtCtx) {
+	ctx.WriteString("INITCOND = ")
+	if ctx.flags.HasFlags(FmtAnonymize) || ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, string(node), ctx.flags.EncodeFlags())
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	PolicyName Name
+	TableName  *UnresolvedObjectName
+	Type       PolicyType
+	Cmd        PolicyCommand
+	// Roles are the roles to which the policy applies. The policy applies to
+	// all the roles if the list is empty.
+	Roles RoleSpecList
+	// Using is the optional USING expression of the policy. It is nil if it
+	// was not specified.
+	Using Expr
+	// WithCheck is the optional WITH CHECK expression of the policy. It is nil
+	// if it was not specified.
+	WithCheck Expr
+}
+
+var _ Statement = &CreatePolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.Type != PolicyTypeDefault {
+		ctx.WriteString(" AS ")
+		ctx.WriteString(node.Type.String())
+	}
+	if node.Cmd != PolicyCommandDefault {
+		ctx.WriteString(" FOR ")
+		ctx.WriteString(node.Cmd.String())
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteByte(')')
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteByte(')')
+	}
+}
+
+// PolicyType describes how a row-level security policy is combined with the
+// other policies of the table.
+type PolicyType uint8
+
+const (
+	// PolicyTypeDefault is used when the type of the policy is not specified.
+	// It is equivalent to PolicyTypePermissive.
+	PolicyTypeDefault PolicyType = iota
+	// PolicyTypePermissive indicates that the policy is combined with the other
+	// permissive policies using OR.
+	PolicyTypePermissive
+	// PolicyTypeRestrictive indicates that the policy is combined with the
+	// other policies using AND.
+	PolicyTypeRestrictive
+)
+
+var policyTypeName = [...]string{
+	PolicyTypeDefault:     "DEFAULT",
+	PolicyTypePermissive:  "PERMISSIVE",
+	PolicyTypeRestrictive: "RESTRICTIVE",
+}
+
+func (p PolicyType) String() string {
+	return policyTypeName[p]
+}
+
+// PolicyCommand describes the commands to which a row-level security policy
+// applies.
+type PolicyCommand uint8
+
+const (
+	// PolicyCommandDefault is used when the command of the policy is not
+	// specified. It is equivalent to PolicyCommandAll.
+	PolicyCommandDefault PolicyCommand = iota
+	// PolicyCommandAll indicates that the policy applies to all the commands.
+	PolicyCommandAll
+	// PolicyCommandSelect indicates that the policy applies to SELECT.
+	PolicyCommandSelect
+	// PolicyCommandInsert indicates that the policy applies to INSERT.
+	PolicyCommandInsert
+	// PolicyCommandUpdate indicates that the policy applies to UPDATE.
+	PolicyCommandUpdate
+	// PolicyCommandDelete indicates that the policy applies to DELETE.
+	PolicyCommandDelete
+)
+
+var policyCommandName = [...]string{
+	PolicyCommandDefault: "DEFAULT",
+	PolicyCommandAll:     "ALL",
+	PolicyCommandSelect:  "SELECT",
+	PolicyCommandInsert:  "INSERT",
+	PolicyCommandUpdate:  "UPDATE",
+	PolicyCommandDelete:  "DELETE",
+}
+
+func (p PolicyCommand) String() string {
+	return policyCommandName[p]
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	IfExists     bool
+	PolicyName   Name
+	TableName    *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.PolicyName)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
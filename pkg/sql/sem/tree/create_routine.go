@@ -12,6 +12,9 @@ package tree
 
 import (
 	"context"
+	"go/constant"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -191,6 +194,12 @@ func (RoutineVolatility) routineOption()        {}
 func (RoutineLeakproof) routineOption()         {}
 func (RoutineBodyStr) routineOption()           {}
 func (RoutineLanguage) routineOption()          {}
+func (RoutineSecurity) routineOption()          {}
+func (RoutineSetVar) routineOption()            {}
+func (RoutineCost) routineOption()              {}
+func (RoutineRows) routineOption()              {}
+func (RoutineSupport) routineOption()           {}
+func (RoutineParallel) routineOption()          {}
 
 // RoutineNullInputBehavior represent the UDF property on null parameters.
 type RoutineNullInputBehavior int
@@ -321,6 +330,143 @@ func (node RoutineBodyStr) Format(ctx *FmtCtx) {
 	}
 }
 
+// RoutineSecurity indicates the privileges with which a routine is executed.
+// The default is SECURITY INVOKER if no security option is provided.
+type RoutineSecurity int
+
+const (
+	// RoutineInvoker indicates that the routine is executed with the
+	// privileges of the user that calls it.
+	RoutineInvoker RoutineSecurity = iota
+	// RoutineDefiner indicates that the routine is executed with the
+	// privileges of the user that owns it.
+	RoutineDefiner
+)
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSecurity) Format(ctx *FmtCtx) {
+	switch node {
+	case RoutineInvoker:
+		ctx.WriteString("SECURITY INVOKER")
+	case RoutineDefiner:
+		ctx.WriteString("SECURITY DEFINER")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
+	}
+}
+
+// RoutineSetVar represents a SET clause of a routine definition, which sets a
+// session variable for the duration of each invocation of the routine. In
+// ALTER FUNCTION, it can also represent a RESET clause, which removes such a
+// setting from the routine.
+type RoutineSetVar struct {
+	*SetVar
+}
+
+// IsReset returns true if the clause is a RESET clause, or a SET clause with
+// the DEFAULT value, which is equivalent.
+func (node RoutineSetVar) IsReset() bool {
+	if node.ResetAll || node.Reset {
+		return true
+	}
+	if len(node.Values) == 1 {
+		_, ok := node.Values[0].(DefaultVal)
+		return ok
+	}
+	return false
+}
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSetVar) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.SetVar)
+}
+
+// RoutineCost is the estimated execution cost of a routine, in units of
+// cpu_operator_cost. It is currently only informational.
+type RoutineCost float64
+
+// Format implements the NodeFormatter interface.
+func (node RoutineCost) Format(ctx *FmtCtx) {
+	ctx.WriteString("COST ")
+	ctx.WriteString(strconv.FormatFloat(float64(node), 'g', -1, 64))
+}
+
+// RoutineRows is the estimated number of rows returned by a set-returning
+// routine. It is currently only informational.
+type RoutineRows float64
+
+// Format implements the NodeFormatter interface.
+func (node RoutineRows) Format(ctx *FmtCtx) {
+	ctx.WriteString("ROWS ")
+	ctx.WriteString(strconv.FormatFloat(float64(node), 'g', -1, 64))
+}
+
+// RoutineSupport is the name of the planner support function of a routine.
+type RoutineSupport Name
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSupport) Format(ctx *FmtCtx) {
+	ctx.WriteString("SUPPORT ")
+	ctx.FormatName(string(node))
+}
+
+// RoutineParallel indicates whether a routine is safe to execute in parallel
+// mode. The default is PARALLEL UNSAFE if no parallel option is provided. It
+// is currently only informational.
+type RoutineParallel int
+
+const (
+	// RoutineParallelUnsafe indicates that the routine cannot be executed in
+	// parallel mode.
+	RoutineParallelUnsafe RoutineParallel = iota
+	// RoutineParallelRestricted indicates that the routine can be executed in
+	// parallel mode, but only by the leader.
+	RoutineParallelRestricted
+	// RoutineParallelSafe indicates that the routine is safe to execute in
+	// parallel mode without restriction.
+	RoutineParallelSafe
+)
+
+// Format implements the NodeFormatter interface.
+func (node RoutineParallel) Format(ctx *FmtCtx) {
+	switch node {
+	case RoutineParallelUnsafe:
+		ctx.WriteString("PARALLEL UNSAFE")
+	case RoutineParallelRestricted:
+		ctx.WriteString("PARALLEL RESTRICTED")
+	case RoutineParallelSafe:
+		ctx.WriteString("PARALLEL SAFE")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
+	}
+}
+
+// AsRoutineParallel converts a string to a RoutineParallel. An error is
+// returned if the string is not a valid parallel mode.
+func AsRoutineParallel(mode string) (RoutineParallel, error) {
+	switch strings.ToLower(mode) {
+	case "unsafe":
+		return RoutineParallelUnsafe, nil
+	case "restricted":
+		return RoutineParallelRestricted, nil
+	case "safe":
+		return RoutineParallelSafe, nil
+	}
+	return 0, pgerror.New(
+		pgcode.InvalidParameterValue, `parameter "parallel" must be SAFE, RESTRICTED, or UNSAFE`,
+	)
+}
+
+// AsRoutineEstimate converts the numeric argument of a COST or ROWS clause to
+// a float.
+func AsRoutineEstimate(n *NumVal) (float64, error) {
+	f, _ := constant.Float64Val(constant.ToFloat(n.AsConstantValue()))
+	if math.IsInf(f, 0) {
+		return 0, pgerror.Newf(pgcode.NumericValueOutOfRange, "%s is out of range", n)
+	}
+	return f, nil
+}
+
 // RoutineParams represents a list of RoutineParam.
 type RoutineParams []RoutineParam
 
@@ -388,8 +534,10 @@ type RoutineReturnType struct {
 	IsSet bool
 }
 
-// DropFunction represents a DROP FUNCTION statement.
+// DropFunction represents a DROP FUNCTION or DROP PROCEDURE statement.
 type DropFunction struct {
+	IsProcedure  bool
+	IsAggregate  bool
 	IfExists     bool
 	Functions    FuncObjs
 	DropBehavior DropBehavior
@@ -397,7 +545,13 @@ type DropFunction struct {
 
 // Format implements the NodeFormatter interface.
 func (node *DropFunction) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.IsAggregate {
+		ctx.WriteString("DROP AGGREGATE ")
+	} else {
+		ctx.WriteString("DROP FUNCTION ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -472,48 +626,62 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterFunctionRename represents a ALTER FUNCTION...RENAME statement.
+// AlterFunctionRename represents a ALTER FUNCTION...RENAME or ALTER
+// AGGREGATE...RENAME statement.
 type AlterFunctionRename struct {
-	Function FuncObj
-	NewName  Name
+	Function    FuncObj
+	NewName     Name
+	IsAggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionRename) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" RENAME TO ")
 	ctx.WriteString(string(node.NewName))
 }
 
-// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA statement.
+// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA or ALTER
+// AGGREGATE...SET SCHEMA statement.
 type AlterFunctionSetSchema struct {
 	Function      FuncObj
 	NewSchemaName Name
+	IsAggregate   bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetSchema) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" SET SCHEMA ")
 	ctx.WriteString(string(node.NewSchemaName))
 }
 
-// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO statement.
+// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO or ALTER
+// AGGREGATE...OWNER TO statement.
 type AlterFunctionSetOwner struct {
-	Function FuncObj
-	NewOwner RoleSpec
+	Function    FuncObj
+	NewOwner    RoleSpec
+	IsAggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetOwner) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.NewOwner)
 }
 
+func formatAlterRoutineKind(ctx *FmtCtx, isAggregate bool) {
+	if isAggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
+	} else {
+		ctx.WriteString("ALTER FUNCTION ")
+	}
+}
+
 // AlterFunctionDepExtension represents the ALTER FUNCTION...DEPENDS ON statement.
 type AlterFunctionDepExtension struct {
 	Function  FuncObj
@@ -574,6 +742,11 @@ const (
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	DomainDefaultExpr               SchemaExprContext = "DEFAULT (in DOMAIN)"
+	DomainCheckConstraintExpr       SchemaExprContext = "CHECK (in DOMAIN)"
+	PolicyUsingExpr                 SchemaExprContext = "POLICY USING"
+	PolicyWithCheckExpr             SchemaExprContext = "POLICY WITH CHECK"
+	TriggerWhenExpr                 SchemaExprContext = "TRIGGER WHEN"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -587,6 +760,7 @@ func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
 // routine options in the given slice.
 func ValidateRoutineOptions(options RoutineOptions) error {
 	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior bool
+	var hasSecurity, hasCost, hasRows, hasSupport, hasParallel bool
 	conflictingErr := func(opt RoutineOption) error {
 		return errors.Wrapf(ErrConflictingRoutineOption, "%s", AsString(opt))
 	}
@@ -617,6 +791,34 @@ func ValidateRoutineOptions(options RoutineOptions) error {
 				return conflictingErr(option)
 			}
 			hasNullInputBehavior = true
+		case RoutineSecurity:
+			if hasSecurity {
+				return conflictingErr(option)
+			}
+			hasSecurity = true
+		case RoutineCost:
+			if hasCost {
+				return conflictingErr(option)
+			}
+			hasCost = true
+		case RoutineRows:
+			if hasRows {
+				return conflictingErr(option)
+			}
+			hasRows = true
+		case RoutineSupport:
+			if hasSupport {
+				return conflictingErr(option)
+			}
+			hasSupport = true
+		case RoutineParallel:
+			if hasParallel {
+				return conflictingErr(option)
+			}
+			hasParallel = true
+		case RoutineSetVar:
+			// A routine may set any number of session variables. If the same
+			// variable is set more than once, the last setting wins.
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown function option: ", AsString(option))
 		}
@@ -77,6 +77,35 @@ func TestConflictingFunctionOptions(t *testing.T) {
 			},
 			expectedErr: "AS $$others$$: conflicting or redundant options",
 		},
+		{
+			testName: "security conflict",
+			options: tree.RoutineOptions{
+				tree.RoutineDefiner, tree.RoutineInvoker,
+			},
+			expectedErr: "SECURITY INVOKER: conflicting or redundant options",
+		},
+		{
+			testName: "parallel conflict",
+			options: tree.RoutineOptions{
+				tree.RoutineParallelSafe, tree.RoutineParallelSafe,
+			},
+			expectedErr: "PARALLEL SAFE: conflicting or redundant options",
+		},
+		{
+			testName: "cost conflict",
+			options: tree.RoutineOptions{
+				tree.RoutineCost(1), tree.RoutineCost(2.5),
+			},
+			expectedErr: "COST 2.5: conflicting or redundant options",
+		},
+		{
+			testName: "multiple set clauses",
+			options: tree.RoutineOptions{
+				tree.RoutineSetVar{SetVar: &tree.SetVar{Name: "a", Values: tree.Exprs{tree.NewStrVal("b")}}},
+				tree.RoutineSetVar{SetVar: &tree.SetVar{Name: "a", Values: tree.Exprs{tree.NewStrVal("c")}}},
+			},
+			expectedErr: "",
+		},
 	}
 
 	for _, tc := range testCases {
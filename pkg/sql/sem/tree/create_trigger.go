@@ -0,0 +1,237 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace     bool
+	Name        Name
+	ActionTime  TriggerActionTime
+	Events      TriggerEvents
+	TableName   *UnresolvedObjectName
+	Transitions TriggerTransitions
+	ForEach     TriggerForEach
+	// When is the optional WHEN condition of the trigger. It is nil if the
+	// trigger is unconditional.
+	When     Expr
+	FuncName *UnresolvedName
+	// FuncArgs are the arguments passed to the trigger function. They are
+	// always stored as strings, following Postgres.
+	FuncArgs []string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if len(node.Transitions) > 0 {
+		ctx.WriteString(" REFERENCING ")
+		ctx.FormatNode(&node.Transitions)
+	}
+	ctx.WriteString(" FOR EACH ")
+	ctx.WriteString(node.ForEach.String())
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	// The function name is formatted directly rather than through FormatNode,
+	// since it is not an expression and must not be parenthesized.
+	node.FuncName.Format(ctx)
+	ctx.WriteByte('(')
+	for i, arg := range node.FuncArgs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, arg, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// TriggerActionTime describes when a trigger fires relative to the
+// operation that caused it.
+type TriggerActionTime uint8
+
+const (
+	// TriggerActionTimeUnknown is the zero value of TriggerActionTime.
+	TriggerActionTimeUnknown TriggerActionTime = iota
+	// TriggerActionTimeBefore indicates that the trigger fires before the
+	// operation.
+	TriggerActionTimeBefore
+	// TriggerActionTimeAfter indicates that the trigger fires after the
+	// operation.
+	TriggerActionTimeAfter
+	// TriggerActionTimeInsteadOf indicates that the trigger fires in place of
+	// the operation. It is only valid for views.
+	TriggerActionTimeInsteadOf
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeUnknown:   "UNKNOWN",
+	TriggerActionTimeBefore:    "BEFORE",
+	TriggerActionTimeAfter:     "AFTER",
+	TriggerActionTimeInsteadOf: "INSTEAD OF",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEventType describes the type of operation that causes a trigger to
+// fire.
+type TriggerEventType uint8
+
+const (
+	// TriggerEventInsert indicates that the trigger fires on INSERT.
+	TriggerEventInsert TriggerEventType = iota
+	// TriggerEventUpdate indicates that the trigger fires on UPDATE.
+	TriggerEventUpdate
+	// TriggerEventDelete indicates that the trigger fires on DELETE.
+	TriggerEventDelete
+	// TriggerEventTruncate indicates that the trigger fires on TRUNCATE.
+	TriggerEventTruncate
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventInsert:   "INSERT",
+	TriggerEventUpdate:   "UPDATE",
+	TriggerEventDelete:   "DELETE",
+	TriggerEventTruncate: "TRUNCATE",
+}
+
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvent represents one of the events that cause a trigger to fire.
+type TriggerEvent struct {
+	EventType TriggerEventType
+	// Columns is only set for UPDATE events that were specified as
+	// UPDATE OF <columns>.
+	Columns NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvent) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.EventType.String())
+	if len(node.Columns) > 0 {
+		ctx.WriteString(" OF ")
+		ctx.FormatNode(&node.Columns)
+	}
+}
+
+// TriggerEvents is a list of trigger events.
+type TriggerEvents []*TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, event := range *node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(event)
+	}
+}
+
+// TriggerTransition represents a transition relation declared in the
+// REFERENCING clause of a trigger.
+type TriggerTransition struct {
+	Name  Name
+	IsNew bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerTransition) Format(ctx *FmtCtx) {
+	if node.IsNew {
+		ctx.WriteString("NEW")
+	} else {
+		ctx.WriteString("OLD")
+	}
+	ctx.WriteString(" TABLE AS ")
+	ctx.FormatNode(&node.Name)
+}
+
+// TriggerTransitions is a list of trigger transition relations.
+type TriggerTransitions []*TriggerTransition
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerTransitions) Format(ctx *FmtCtx) {
+	for i, transition := range *node {
+		if i > 0 {
+			ctx.WriteByte(' ')
+		}
+		ctx.FormatNode(transition)
+	}
+}
+
+// TriggerForEach describes whether a trigger fires once per affected row or
+// once per statement.
+type TriggerForEach uint8
+
+const (
+	// TriggerForEachStatement indicates that the trigger fires once per
+	// statement. This is the default.
+	TriggerForEachStatement TriggerForEach = iota
+	// TriggerForEachRow indicates that the trigger fires once for each row
+	// affected by the statement.
+	TriggerForEachRow
+)
+
+func (t TriggerForEach) String() string {
+	if t == TriggerForEachRow {
+		return "ROW"
+	}
+	return "STATEMENT"
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	IfExists     bool
+	Trigger      Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Trigger)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -38,6 +39,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+	"github.com/cockroachdb/cockroach/pkg/util/macaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -2054,6 +2057,393 @@ func (d *DIPAddr) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// DCIDR is the cidr Datum.
+type DCIDR struct {
+	ipaddr.IPAddr
+}
+
+// NewDCIDR is a helper routine to create a *DCIDR initialized from its
+// argument.
+func NewDCIDR(d DCIDR) *DCIDR {
+	return &d
+}
+
+// ParseDCIDR parses and returns the *DCIDR Datum value represented by the
+// provided string, or an error if parsing is unsuccessful.
+func ParseDCIDR(s string) (*DCIDR, error) {
+	var d DCIDR
+	if err := ipaddr.ParseCIDR(s, &d.IPAddr); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// AsDCIDR attempts to retrieve a DCIDR from an Expr, returning a DCIDR and a
+// flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DCIDR wrapped by a
+// *DOidWrapper is possible.
+func AsDCIDR(e Expr) (DCIDR, bool) {
+	switch t := e.(type) {
+	case *DCIDR:
+		return *t, true
+	case *DOidWrapper:
+		return AsDCIDR(t.Wrapped)
+	}
+	return DCIDR{}, false
+}
+
+// MustBeDCIDR attempts to retrieve a DCIDR from an Expr, panicking if the
+// assertion fails.
+func MustBeDCIDR(e Expr) DCIDR {
+	i, ok := AsDCIDR(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DCIDR, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DCIDR) ResolvedType() *types.T {
+	return types.CIDR
+}
+
+// Compare implements the Datum interface.
+func (d *DCIDR) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DCIDR) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DCIDR)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.IPAddr.Compare(&v.IPAddr), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DCIDR) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DCIDR) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// DMinCIDR is the min DCIDR.
+var DMinCIDR = NewDCIDR(DCIDR{ipaddr.IPAddr{Family: ipaddr.IPv4family, Addr: dIPv4min, Mask: 0}})
+
+// DMaxCIDR is the max DCIDR.
+var DMaxCIDR = NewDCIDR(DCIDR{ipaddr.IPAddr{Family: ipaddr.IPv6family, Addr: dIPv6max, Mask: 128}})
+
+// IsMax implements the Datum interface.
+func (d *DCIDR) IsMax(ctx CompareContext) bool {
+	return d.IPAddr.Equal(&DMaxCIDR.IPAddr)
+}
+
+// IsMin implements the Datum interface.
+func (d *DCIDR) IsMin(ctx CompareContext) bool {
+	return d.IPAddr.Equal(&DMinCIDR.IPAddr)
+}
+
+// Min implements the Datum interface.
+func (*DCIDR) Min(ctx CompareContext) (Datum, bool) {
+	return DMinCIDR, true
+}
+
+// Max implements the Datum interface.
+func (*DCIDR) Max(ctx CompareContext) (Datum, bool) {
+	return DMaxCIDR, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DCIDR) AmbiguousFormat() bool {
+	return true
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DCIDR) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.IPAddr.CIDRString())
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DCIDR) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DMacAddr is the macaddr Datum.
+type DMacAddr struct {
+	macaddr.MacAddr
+}
+
+// NewDMacAddr returns a new macaddr Datum.
+func NewDMacAddr(m macaddr.MacAddr) *DMacAddr {
+	return &DMacAddr{MacAddr: m}
+}
+
+// ParseDMacAddr parses and returns the *DMacAddr Datum value represented by
+// the provided string, or an error if parsing is unsuccessful.
+func ParseDMacAddr(s string) (*DMacAddr, error) {
+	m, err := macaddr.ParseMacAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMacAddr(m), nil
+}
+
+// AsDMacAddr attempts to retrieve a *DMacAddr from an Expr, returning a
+// *DMacAddr and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMacAddr wrapped by a *DOidWrapper is possible.
+func AsDMacAddr(e Expr) (*DMacAddr, bool) {
+	switch t := e.(type) {
+	case *DMacAddr:
+		return t, true
+	case *DOidWrapper:
+		return AsDMacAddr(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMacAddr attempts to retrieve a *DMacAddr from an Expr, panicking if
+// the assertion fails.
+func MustBeDMacAddr(e Expr) *DMacAddr {
+	i, ok := AsDMacAddr(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMacAddr, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DMacAddr) ResolvedType() *types.T {
+	return types.MacAddr
+}
+
+// Compare implements the Datum interface.
+func (d *DMacAddr) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMacAddr) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMacAddr)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.MacAddr.Compare(v.MacAddr), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMacAddr) Prev(ctx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx) {
+		return nil, false
+	}
+	return NewDMacAddr(d.MacAddr - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DMacAddr) Next(ctx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx) {
+		return nil, false
+	}
+	return NewDMacAddr(d.MacAddr + 1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DMacAddr) IsMax(ctx CompareContext) bool {
+	return d.MacAddr == macaddr.MaxMacAddr
+}
+
+// IsMin implements the Datum interface.
+func (d *DMacAddr) IsMin(ctx CompareContext) bool {
+	return d.MacAddr == 0
+}
+
+// Max implements the Datum interface.
+func (d *DMacAddr) Max(ctx CompareContext) (Datum, bool) {
+	return NewDMacAddr(macaddr.MaxMacAddr), true
+}
+
+// Min implements the Datum interface.
+func (d *DMacAddr) Min(ctx CompareContext) (Datum, bool) {
+	return NewDMacAddr(0), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMacAddr) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMacAddr) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.MacAddr.String())
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DMacAddr) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DMacAddr8 is the macaddr8 Datum.
+type DMacAddr8 struct {
+	macaddr.MacAddr8
+}
+
+// NewDMacAddr8 returns a new macaddr8 Datum.
+func NewDMacAddr8(m macaddr.MacAddr8) *DMacAddr8 {
+	return &DMacAddr8{MacAddr8: m}
+}
+
+// ParseDMacAddr8 parses and returns the *DMacAddr8 Datum value represented by
+// the provided string, or an error if parsing is unsuccessful.
+func ParseDMacAddr8(s string) (*DMacAddr8, error) {
+	m, err := macaddr.ParseMacAddr8(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDMacAddr8(m), nil
+}
+
+// AsDMacAddr8 attempts to retrieve a *DMacAddr8 from an Expr, returning a
+// *DMacAddr8 and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMacAddr8 wrapped by a *DOidWrapper is possible.
+func AsDMacAddr8(e Expr) (*DMacAddr8, bool) {
+	switch t := e.(type) {
+	case *DMacAddr8:
+		return t, true
+	case *DOidWrapper:
+		return AsDMacAddr8(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMacAddr8 attempts to retrieve a *DMacAddr8 from an Expr, panicking if
+// the assertion fails.
+func MustBeDMacAddr8(e Expr) *DMacAddr8 {
+	i, ok := AsDMacAddr8(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMacAddr8, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DMacAddr8) ResolvedType() *types.T {
+	return types.MacAddr8
+}
+
+// Compare implements the Datum interface.
+func (d *DMacAddr8) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMacAddr8) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMacAddr8)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.MacAddr8.Compare(v.MacAddr8), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMacAddr8) Prev(ctx CompareContext) (Datum, bool) {
+	if d.IsMin(ctx) {
+		return nil, false
+	}
+	return NewDMacAddr8(d.MacAddr8 - 1), true
+}
+
+// Next implements the Datum interface.
+func (d *DMacAddr8) Next(ctx CompareContext) (Datum, bool) {
+	if d.IsMax(ctx) {
+		return nil, false
+	}
+	return NewDMacAddr8(d.MacAddr8 + 1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DMacAddr8) IsMax(ctx CompareContext) bool {
+	return d.MacAddr8 == macaddr.MaxMacAddr8
+}
+
+// IsMin implements the Datum interface.
+func (d *DMacAddr8) IsMin(ctx CompareContext) bool {
+	return d.MacAddr8 == 0
+}
+
+// Max implements the Datum interface.
+func (d *DMacAddr8) Max(ctx CompareContext) (Datum, bool) {
+	return NewDMacAddr8(macaddr.MaxMacAddr8), true
+}
+
+// Min implements the Datum interface.
+func (d *DMacAddr8) Min(ctx CompareContext) (Datum, bool) {
+	return NewDMacAddr8(0), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMacAddr8) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMacAddr8) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.MacAddr8.String())
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DMacAddr8) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
 // DDate is the date Datum represented as the number of days after
 // the Unix epoch.
 type DDate struct {
@@ -3836,6 +4226,167 @@ func (d *DBox2D) Size() uintptr {
 	return unsafe.Sizeof(*d) + unsafe.Sizeof(d.CartesianBoundingBox)
 }
 
+// DGeometric is the Datum of the native postgres geometric types: POINT,
+// LSEG, BOX, PATH, POLYGON, LINE and CIRCLE. The type of the datum is
+// determined by the kind of the wrapped shape.
+type DGeometric struct {
+	pggeom.Shape
+}
+
+// NewDGeometric returns a new geometric Datum.
+func NewDGeometric(s pggeom.Shape) *DGeometric {
+	return &DGeometric{Shape: s}
+}
+
+// ParseDGeometric parses and returns the *DGeometric Datum of the given type
+// represented by the provided string, or an error if parsing is unsuccessful.
+func ParseDGeometric(t *types.T, s string) (*DGeometric, error) {
+	k, ok := GeometricKind(t)
+	if !ok {
+		return nil, errors.AssertionFailedf("unexpected geometric type %s", t)
+	}
+	shape, err := pggeom.Parse(k, s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDGeometric(shape), nil
+}
+
+// GeometricKind returns the kind of the shapes of a geometric type, and false
+// if the type is not a geometric type.
+func GeometricKind(t *types.T) (pggeom.Kind, bool) {
+	switch t.Family() {
+	case types.PointFamily:
+		return pggeom.PointKind, true
+	case types.LSegFamily:
+		return pggeom.LSegKind, true
+	case types.BoxFamily:
+		return pggeom.BoxKind, true
+	case types.PathFamily:
+		return pggeom.PathKind, true
+	case types.PolygonFamily:
+		return pggeom.PolygonKind, true
+	case types.LineFamily:
+		return pggeom.LineKind, true
+	case types.CircleFamily:
+		return pggeom.CircleKind, true
+	}
+	return 0, false
+}
+
+// geometricTypes maps each pggeom.Kind to its type.
+var geometricTypes = [...]*types.T{
+	pggeom.PointKind:   types.Point,
+	pggeom.LSegKind:    types.LSeg,
+	pggeom.BoxKind:     types.Box,
+	pggeom.PathKind:    types.Path,
+	pggeom.PolygonKind: types.Polygon,
+	pggeom.LineKind:    types.Line,
+	pggeom.CircleKind:  types.Circle,
+}
+
+// AsDGeometric attempts to retrieve a *DGeometric from an Expr, returning a
+// *DGeometric and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DGeometric wrapped by a *DOidWrapper is possible.
+func AsDGeometric(e Expr) (*DGeometric, bool) {
+	switch t := e.(type) {
+	case *DGeometric:
+		return t, true
+	case *DOidWrapper:
+		return AsDGeometric(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDGeometric attempts to retrieve a *DGeometric from an Expr, panicking
+// if the assertion fails.
+func MustBeDGeometric(e Expr) *DGeometric {
+	i, ok := AsDGeometric(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DGeometric, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DGeometric) ResolvedType() *types.T {
+	return geometricTypes[d.Shape.Kind()]
+}
+
+// Compare implements the Datum interface.
+func (d *DGeometric) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DGeometric) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DGeometric)
+	if !ok || v.Shape.Kind() != d.Shape.Kind() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return pggeom.Compare(d.Shape, v.Shape), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DGeometric) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DGeometric) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DGeometric) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DGeometric) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DGeometric) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DGeometric) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DGeometric) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DGeometric) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.Shape.String())
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DGeometric) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.Shape.Size()
+}
+
 // DJSON is the JSON Datum.
 type DJSON struct{ json.JSON }
 
@@ -3997,7 +4548,8 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN:
+		*DTSVector, *DTSQuery, *DPGLSN, *DJsonpath, *DCIDR, *DMacAddr, *DMacAddr8, *DGeometric,
+		*DRange, *DMultiRange:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4110,6 +4662,137 @@ func (d *DJSON) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.JSON.Size()
 }
 
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	*jsonpath.Path
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Path.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DJsonpath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	l, r := d.String(), v.String()
+	if l < r {
+		return -1, nil
+	} else if l > r {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Path.String()))
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(p *jsonpath.Path) *DJsonpath {
+	return &DJsonpath{Path: p}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (Datum, error) {
+	p, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.Syntax, "could not parse jsonpath")
+	}
+	return NewDJsonpath(p), nil
+}
+
 // DTSQuery is the tsquery Datum.
 type DTSQuery struct {
 	tsearch.TSQuery
@@ -5952,7 +6635,13 @@ func NewDefaultDatum(collationEnv *CollationEnvironment, t *types.T) (d Datum, e
 		return dNullJSON, nil
 	case types.TimeTZFamily:
 		return dZeroTimeTZ, nil
-	case types.GeometryFamily, types.GeographyFamily, types.Box2DFamily:
+	case types.RangeFamily:
+		return NewDEmptyRange(t), nil
+	case types.MultiRangeFamily:
+		return &DMultiRange{Typ: t}, nil
+	case types.GeometryFamily, types.GeographyFamily, types.Box2DFamily,
+		types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
 		// TODO(otan): force Geometry/Geography to not allow `NOT NULL` columns to
 		// make this impossible.
 		return nil, pgerror.Newf(
@@ -6008,6 +6697,9 @@ func PGWireTypeSize(t *types.T) int {
 	if tOid == oid.T_date {
 		return 4
 	}
+	if tOid == oid.T_macaddr {
+		return 6
+	}
 	if sz, variable := DatumTypeSize(t); !variable {
 		return int(sz)
 	}
@@ -6089,12 +6781,27 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
+	types.CIDRFamily:           {unsafe.Sizeof(DCIDR{}), fixedSize},
+	types.MacAddrFamily:        {unsafe.Sizeof(DMacAddr{}), fixedSize},
+	types.MacAddr8Family:       {unsafe.Sizeof(DMacAddr8{}), fixedSize},
+	types.PointFamily:          {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.LSegFamily:           {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.BoxFamily:            {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.PathFamily:           {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.PolygonFamily:        {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.LineFamily:           {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.CircleFamily:         {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultiRangeFamily:     {unsafe.Sizeof(DMultiRange{}), variableSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
 
 	types.VoidFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
+	// There are no datums of the trigger pseudo type, so it is sized like void.
+	types.TriggerFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
 	// TODO(jordan,justin): This seems suspicious.
 	types.ArrayFamily: {unsafe.Sizeof(DString("")), variableSize},
 
@@ -0,0 +1,868 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"math"
+	"sort"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// RangeBound is one of the bounds of a DRange.
+type RangeBound struct {
+	// Val is the value of the bound, or nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val is contained in the range. It is always false
+	// for infinite bounds.
+	Inclusive bool
+}
+
+// Infinite returns true if the bound is unbounded.
+func (b RangeBound) Infinite() bool {
+	return b.Val == nil
+}
+
+// DRange is the Datum of the built-in range types. A range is either empty, or
+// the set of values of the range's subtype between its lower and upper bounds.
+//
+// Ranges are always kept in a canonical form: ranges over discrete subtypes
+// (INT4, INT8 and DATE) use an inclusive lower bound and an exclusive upper
+// bound, infinite bounds are never inclusive, and an empty range has no
+// bounds.
+type DRange struct {
+	Typ   *types.T
+	Lower RangeBound
+	Upper RangeBound
+	Empty bool
+}
+
+// DMultiRange is the Datum of the built-in multirange types. A multirange is
+// an ordered list of non-empty ranges, none of which overlap or are adjacent
+// to one another.
+type DMultiRange struct {
+	Typ    *types.T
+	Ranges []*DRange
+}
+
+var (
+	errRangeBoundOrder = pgerror.New(pgcode.DataException,
+		"range lower bound must be less than or equal to range upper bound")
+	errRangeUnionNotContiguous = pgerror.New(pgcode.DataException,
+		"result of range union would not be contiguous")
+	errRangeDifferenceNotContiguous = pgerror.New(pgcode.DataException,
+		"result of range difference would not be contiguous")
+)
+
+// rangeCompareContext is the CompareContext used to compare the bounds of
+// ranges. The bounds of a range always have the same type and are never
+// wrapped, so comparing them does not depend on the evaluation context.
+type rangeCompareContext struct{}
+
+var _ CompareContext = rangeCompareContext{}
+
+// UnwrapDatum implements the CompareContext interface.
+func (rangeCompareContext) UnwrapDatum(d Datum) Datum { return UnwrapDOidWrapper(d) }
+
+// GetLocation implements the CompareContext interface.
+func (rangeCompareContext) GetLocation() *time.Location { return time.UTC }
+
+// GetRelativeParseTime implements the CompareContext interface.
+func (rangeCompareContext) GetRelativeParseTime() time.Time { return time.Time{} }
+
+// MustGetPlaceholderValue implements the CompareContext interface.
+func (rangeCompareContext) MustGetPlaceholderValue(p *Placeholder) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder %s in range", p))
+}
+
+// NewDEmptyRange returns the empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{Typ: typ, Empty: true}
+}
+
+// NewDRange returns the range of the given type with the given bounds, in
+// canonical form. An error is returned if the lower bound is greater than the
+// upper bound.
+func NewDRange(typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	if !lower.Infinite() {
+		lower.Val = UnwrapDOidWrapper(lower.Val)
+	} else {
+		lower.Inclusive = false
+	}
+	if !upper.Infinite() {
+		upper.Val = UnwrapDOidWrapper(upper.Val)
+	} else {
+		upper.Inclusive = false
+	}
+	for _, b := range [2]RangeBound{lower, upper} {
+		if err := checkRangeBoundWidth(typ.RangeContents(), b); err != nil {
+			return nil, err
+		}
+	}
+	if !lower.Infinite() && !upper.Infinite() {
+		if c := lower.Val.Compare(rangeCmpCtx, upper.Val); c > 0 {
+			return nil, errRangeBoundOrder
+		} else if c == 0 && !(lower.Inclusive && upper.Inclusive) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	if sub := typ.RangeContents(); rangeSubtypeIsDiscrete(sub) {
+		var err error
+		if !lower.Infinite() && !lower.Inclusive && rangeDiscreteIsFinite(lower.Val) {
+			if lower.Val, err = rangeDiscreteNext(sub, lower.Val); err != nil {
+				return nil, err
+			}
+			lower.Inclusive = true
+		}
+		if !upper.Infinite() && upper.Inclusive && rangeDiscreteIsFinite(upper.Val) {
+			if upper.Val, err = rangeDiscreteNext(sub, upper.Val); err != nil {
+				return nil, err
+			}
+			upper.Inclusive = false
+		}
+		if !lower.Infinite() && !upper.Infinite() &&
+			lower.Val.Compare(rangeCmpCtx, upper.Val) >= 0 {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	return &DRange{Typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+var rangeCmpCtx CompareContext = rangeCompareContext{}
+
+// checkRangeBoundWidth returns an error if the value of the given bound does
+// not fit in the width of an INT4 subtype.
+func checkRangeBoundWidth(sub *types.T, b RangeBound) error {
+	if i, ok := b.Val.(*DInt); ok && sub.Width() == 32 && (*i < math.MinInt32 || *i > math.MaxInt32) {
+		return ErrInt4OutOfRange
+	}
+	return nil
+}
+
+// rangeSubtypeIsDiscrete returns true if ranges of the given subtype are
+// canonicalized to the [) form.
+func rangeSubtypeIsDiscrete(sub *types.T) bool {
+	switch sub.Family() {
+	case types.IntFamily, types.DateFamily:
+		return true
+	}
+	return false
+}
+
+// rangeDiscreteIsFinite returns false for the infinite values of a discrete
+// subtype, which are left as is when a range is canonicalized.
+func rangeDiscreteIsFinite(d Datum) bool {
+	if t, ok := d.(*DDate); ok {
+		return t.IsFinite()
+	}
+	return true
+}
+
+// rangeDiscreteNext returns the value following d in a discrete subtype.
+func rangeDiscreteNext(sub *types.T, d Datum) (Datum, error) {
+	switch t := d.(type) {
+	case *DInt:
+		if *t == math.MaxInt64 || (sub.Width() == 32 && *t >= math.MaxInt32) {
+			return nil, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		n, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(n), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected discrete range bound %T", d)
+}
+
+// compareRangeBounds compares two range bounds, each of which is either a
+// lower or an upper bound, following the semantics of range_cmp_bounds in
+// Postgres. An exclusive lower bound sorts just after its value, and an
+// exclusive upper bound sorts just before it.
+func compareRangeBounds(b1 RangeBound, lower1 bool, b2 RangeBound, lower2 bool) int {
+	switch {
+	case b1.Infinite() && b2.Infinite():
+		if lower1 == lower2 {
+			return 0
+		}
+		if lower1 {
+			return -1
+		}
+		return 1
+	case b1.Infinite():
+		if lower1 {
+			return -1
+		}
+		return 1
+	case b2.Infinite():
+		if lower2 {
+			return 1
+		}
+		return -1
+	}
+	c := b1.Val.Compare(rangeCmpCtx, b2.Val)
+	if c != 0 {
+		return c
+	}
+	switch {
+	case !b1.Inclusive && !b2.Inclusive:
+		if lower1 == lower2 {
+			return 0
+		}
+		if lower1 {
+			return 1
+		}
+		return -1
+	case !b1.Inclusive:
+		if lower1 {
+			return 1
+		}
+		return -1
+	case !b2.Inclusive:
+		if lower2 {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Empty ranges sort before all
+// other ranges, and non-empty ranges are ordered by their lower bounds and
+// then by their upper bounds.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok || v.Typ.Oid() != d.Typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.compare(v), nil
+}
+
+func (d *DRange) compare(v *DRange) int {
+	switch {
+	case d.Empty && v.Empty:
+		return 0
+	case d.Empty:
+		return -1
+	case v.Empty:
+		return 1
+	}
+	if c := compareRangeBounds(d.Lower, true, v.Lower, true); c != 0 {
+		return c
+	}
+	return compareRangeBounds(d.Upper, false, v.Upper, false)
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	d.formatText(ctx)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// formatText writes the Postgres text representation of the range.
+func (d *DRange) formatText(ctx *FmtCtx) {
+	if d.Empty {
+		ctx.WriteString("empty")
+		return
+	}
+	if d.Lower.Inclusive {
+		ctx.WriteByte('[')
+	} else {
+		ctx.WriteByte('(')
+	}
+	formatRangeBound(ctx, d.Lower)
+	ctx.WriteByte(',')
+	formatRangeBound(ctx, d.Upper)
+	if d.Upper.Inclusive {
+		ctx.WriteByte(']')
+	} else {
+		ctx.WriteByte(')')
+	}
+}
+
+// formatRangeBound writes the text representation of a range bound, quoting
+// it if it contains any characters that are special in range literals.
+func formatRangeBound(ctx *FmtCtx, b RangeBound) {
+	if b.Infinite() {
+		return
+	}
+	s := AsStringWithFlags(
+		b.Val, FmtPgwireText, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location),
+	)
+	quote := s == ""
+	for i := 0; i < len(s) && !quote; i++ {
+		switch s[i] {
+		case '"', '\\', '(', ')', '[', ']', ',', ' ', '\t', '\n', '\r', '\v', '\f':
+			quote = true
+		}
+	}
+	if !quote {
+		ctx.WriteString(s)
+		return
+	}
+	ctx.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			ctx.WriteByte(s[i])
+		}
+		ctx.WriteByte(s[i])
+	}
+	ctx.WriteByte('"')
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if !d.Lower.Infinite() {
+		sz += d.Lower.Val.Size()
+	}
+	if !d.Upper.Infinite() {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range [2]RangeBound{d.Lower, d.Upper} {
+		if cdatum, ok := b.Val.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsRange returns true if every value in other is also in d.
+func (d *DRange) ContainsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Lower, true) <= 0 &&
+		compareRangeBounds(d.Upper, false, other.Upper, false) >= 0
+}
+
+// ContainsElem returns true if the given value is in d.
+func (d *DRange) ContainsElem(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if !d.Lower.Infinite() {
+		c := d.Lower.Val.Compare(rangeCmpCtx, v)
+		if c > 0 || (c == 0 && !d.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !d.Upper.Infinite() {
+		c := d.Upper.Val.Compare(rangeCmpCtx, v)
+		if c < 0 || (c == 0 && !d.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns true if d and other have any value in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	if compareRangeBounds(d.Lower, true, other.Lower, true) >= 0 &&
+		compareRangeBounds(d.Lower, true, other.Upper, false) <= 0 {
+		return true
+	}
+	return compareRangeBounds(other.Lower, true, d.Lower, true) >= 0 &&
+		compareRangeBounds(other.Lower, true, d.Upper, false) <= 0
+}
+
+// StrictlyLeftOf returns true if every value in d is less than every value in
+// other.
+func (d *DRange) StrictlyLeftOf(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Upper, false, other.Lower, true) < 0
+}
+
+// StrictlyRightOf returns true if every value in d is greater than every
+// value in other.
+func (d *DRange) StrictlyRightOf(other *DRange) bool {
+	return other.StrictlyLeftOf(d)
+}
+
+// Adjacent returns true if d and other do not overlap, but there is no value
+// between them.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.Upper, other.Lower) || rangeBoundsAdjacent(other.Upper, d.Lower)
+}
+
+// rangeBoundsAdjacent returns true if the given upper and lower bounds have
+// the same value and exactly one of them includes it.
+func rangeBoundsAdjacent(upper, lower RangeBound) bool {
+	if upper.Infinite() || lower.Infinite() {
+		return false
+	}
+	return upper.Val.Compare(rangeCmpCtx, lower.Val) == 0 && upper.Inclusive != lower.Inclusive
+}
+
+// Merge returns the smallest range that contains both d and other.
+func (d *DRange) Merge(other *DRange) (*DRange, error) {
+	if d.Empty {
+		return other, nil
+	}
+	if other.Empty {
+		return d, nil
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(other.Lower, true, lower, true) < 0 {
+		lower = other.Lower
+	}
+	if compareRangeBounds(other.Upper, false, upper, false) > 0 {
+		upper = other.Upper
+	}
+	return NewDRange(d.Typ, lower, upper)
+}
+
+// Union returns the union of d and other, which must overlap or be adjacent.
+func (d *DRange) Union(other *DRange) (*DRange, error) {
+	if !d.Empty && !other.Empty && !d.Overlaps(other) && !d.Adjacent(other) {
+		return nil, errRangeUnionNotContiguous
+	}
+	return d.Merge(other)
+}
+
+// Intersect returns the intersection of d and other.
+func (d *DRange) Intersect(other *DRange) (*DRange, error) {
+	if !d.Overlaps(other) {
+		return NewDEmptyRange(d.Typ), nil
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(other.Lower, true, lower, true) > 0 {
+		lower = other.Lower
+	}
+	if compareRangeBounds(other.Upper, false, upper, false) < 0 {
+		upper = other.Upper
+	}
+	return NewDRange(d.Typ, lower, upper)
+}
+
+// Difference returns the values of d that are not in other. It is an error
+// for the result not to be a single range.
+func (d *DRange) Difference(other *DRange) (*DRange, error) {
+	res, err := d.split(other)
+	if err != nil {
+		return nil, err
+	}
+	switch len(res) {
+	case 0:
+		return NewDEmptyRange(d.Typ), nil
+	case 1:
+		return res[0], nil
+	}
+	return nil, errRangeDifferenceNotContiguous
+}
+
+// split returns the non-empty ranges that make up the values of d that are
+// not in other, in order. There are at most two of them.
+func (d *DRange) split(other *DRange) ([]*DRange, error) {
+	if d.Empty {
+		return nil, nil
+	}
+	if other.Empty || !d.Overlaps(other) {
+		return []*DRange{d}, nil
+	}
+	res := make([]*DRange, 0, 2)
+	if compareRangeBounds(d.Lower, true, other.Lower, true) < 0 {
+		r, err := NewDRange(d.Typ, d.Lower, RangeBound{Val: other.Lower.Val, Inclusive: !other.Lower.Inclusive})
+		if err != nil {
+			return nil, err
+		}
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	if compareRangeBounds(d.Upper, false, other.Upper, false) > 0 {
+		r, err := NewDRange(d.Typ, RangeBound{Val: other.Upper.Val, Inclusive: !other.Upper.Inclusive}, d.Upper)
+		if err != nil {
+			return nil, err
+		}
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// NewDMultiRange returns the multirange of the given type made up of the
+// given ranges. Empty ranges are dropped, and overlapping or adjacent ranges
+// are merged.
+func NewDMultiRange(typ *types.T, ranges []*DRange) (*DMultiRange, error) {
+	sorted := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].compare(sorted[j]) < 0
+	})
+	res := &DMultiRange{Typ: typ, Ranges: make([]*DRange, 0, len(sorted))}
+	for _, r := range sorted {
+		if n := len(res.Ranges); n > 0 {
+			if last := res.Ranges[n-1]; last.Overlaps(r) || last.Adjacent(r) {
+				merged, err := last.Merge(r)
+				if err != nil {
+					return nil, err
+				}
+				res.Ranges[n-1] = merged
+				continue
+			}
+		}
+		res.Ranges = append(res.Ranges, r)
+	}
+	return res, nil
+}
+
+// AsDMultiRange attempts to retrieve a *DMultiRange from an Expr, returning a
+// *DMultiRange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultiRange wrapped by a *DOidWrapper is possible.
+func AsDMultiRange(e Expr) (*DMultiRange, bool) {
+	switch t := e.(type) {
+	case *DMultiRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultiRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultiRange attempts to retrieve a *DMultiRange from an Expr,
+// panicking if the assertion fails.
+func MustBeDMultiRange(e Expr) *DMultiRange {
+	r, ok := AsDMultiRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultiRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultiRange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// Compare implements the Datum interface.
+func (d *DMultiRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Multiranges are compared range
+// by range, and a multirange sorts before any multirange it is a prefix of.
+func (d *DMultiRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultiRange)
+	if !ok || v.Typ.Oid() != d.Typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		if c := d.Ranges[i].compare(v.Ranges[i]); c != 0 {
+			return c, nil
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultiRange) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultiRange) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultiRange) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultiRange) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DMultiRange) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultiRange) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMultiRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DMultiRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			ctx.WriteByte(',')
+		}
+		r.formatText(ctx)
+	}
+	ctx.WriteByte('}')
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DMultiRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultiRange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Span returns the smallest range that contains every range in d.
+func (d *DMultiRange) Span() *DRange {
+	if len(d.Ranges) == 0 {
+		return NewDEmptyRange(d.Typ.MultiRangeContents())
+	}
+	return &DRange{
+		Typ:   d.Typ.MultiRangeContents(),
+		Lower: d.Ranges[0].Lower,
+		Upper: d.Ranges[len(d.Ranges)-1].Upper,
+	}
+}
+
+// ContainsRange returns true if every value in r is also in d.
+func (d *DMultiRange) ContainsRange(r *DRange) bool {
+	if r.Empty {
+		return true
+	}
+	for _, dr := range d.Ranges {
+		if dr.ContainsRange(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsMultiRange returns true if every value in other is also in d.
+func (d *DMultiRange) ContainsMultiRange(other *DMultiRange) bool {
+	for _, r := range other.Ranges {
+		if !d.ContainsRange(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsElem returns true if the given value is in d.
+func (d *DMultiRange) ContainsElem(v Datum) bool {
+	for _, r := range d.Ranges {
+		if r.ContainsElem(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverlapsRange returns true if d and r have any value in common.
+func (d *DMultiRange) OverlapsRange(r *DRange) bool {
+	for _, dr := range d.Ranges {
+		if dr.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverlapsMultiRange returns true if d and other have any value in common.
+func (d *DMultiRange) OverlapsMultiRange(other *DMultiRange) bool {
+	for _, r := range other.Ranges {
+		if d.OverlapsRange(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the multirange of the values in either d or other.
+func (d *DMultiRange) Union(other *DMultiRange) (*DMultiRange, error) {
+	ranges := make([]*DRange, 0, len(d.Ranges)+len(other.Ranges))
+	ranges = append(ranges, d.Ranges...)
+	ranges = append(ranges, other.Ranges...)
+	return NewDMultiRange(d.Typ, ranges)
+}
+
+// Intersect returns the multirange of the values in both d and other.
+func (d *DMultiRange) Intersect(other *DMultiRange) (*DMultiRange, error) {
+	var ranges []*DRange
+	for _, r1 := range d.Ranges {
+		for _, r2 := range other.Ranges {
+			r, err := r1.Intersect(r2)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	return NewDMultiRange(d.Typ, ranges)
+}
+
+// Difference returns the multirange of the values in d that are not in other.
+func (d *DMultiRange) Difference(other *DMultiRange) (*DMultiRange, error) {
+	var ranges []*DRange
+	for _, r := range d.Ranges {
+		pieces := []*DRange{r}
+		for _, o := range other.Ranges {
+			var next []*DRange
+			for _, p := range pieces {
+				res, err := p.split(o)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, res...)
+			}
+			pieces = next
+		}
+		ranges = append(ranges, pieces...)
+	}
+	return NewDMultiRange(d.Typ, ranges)
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
@@ -23,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -194,6 +196,18 @@ var UnaryOps = unaryOpFixups(map[UnaryOperatorSymbol]*UnaryOpOverloads{
 			EvalOp:     &ComplementINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			Typ:        types.MacAddr,
+			ReturnType: types.MacAddr,
+			EvalOp:     &ComplementMacAddrOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			Typ:        types.MacAddr8,
+			ReturnType: types.MacAddr8,
+			EvalOp:     &ComplementMacAddr8Op{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	UnarySqrt: {overloads: []*UnaryOp{
@@ -407,6 +421,26 @@ func JSONExistsAny(json DJSON, dArray *DArray) (*DBool, error) {
 	return DBoolFalse, nil
 }
 
+// JSONPathExists returns whether the jsonpath returns any item for the json
+// value. Suppressible errors are ignored, as for the @? operator.
+func JSONPathExists(j DJSON, p *DJsonpath) (Datum, error) {
+	exists, ok, err := jsonpath.Exists(p.Path, j.JSON, nil /* vars */, true /* silent */)
+	if err != nil || !ok {
+		return DNull, err
+	}
+	return MakeDBool(DBool(exists)), nil
+}
+
+// JSONPathMatch returns the result of the jsonpath predicate check for the json
+// value. Suppressible errors are ignored, as for the @@ operator.
+func JSONPathMatch(j DJSON, p *DJsonpath) (Datum, error) {
+	match, ok, err := jsonpath.Match(p.Path, j.JSON, nil /* vars */, true /* silent */)
+	if err != nil || !ok {
+		return DNull, err
+	}
+	return MakeDBool(DBool(match)), nil
+}
+
 func initArrayToArrayConcatenation() {
 	for _, t := range types.Scalar {
 		typ := t
@@ -459,6 +493,7 @@ func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
 }
 
 func init() {
@@ -553,6 +588,20 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &BitAndINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.MacAddr,
+			RightType:  types.MacAddr,
+			ReturnType: types.MacAddr,
+			EvalOp:     &BitAndMacAddrOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.MacAddr8,
+			RightType:  types.MacAddr8,
+			ReturnType: types.MacAddr8,
+			EvalOp:     &BitAndMacAddr8Op{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Bitor: {overloads: []*BinOp{
@@ -577,6 +626,20 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &BitOrINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.MacAddr,
+			RightType:  types.MacAddr,
+			ReturnType: types.MacAddr,
+			EvalOp:     &BitOrMacAddrOp{},
+			Volatility: volatility.Immutable,
+		},
+		{
+			LeftType:   types.MacAddr8,
+			RightType:  types.MacAddr8,
+			ReturnType: types.MacAddr8,
+			EvalOp:     &BitOrMacAddr8Op{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Bitxor: {overloads: []*BinOp{
@@ -1241,6 +1304,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &LShiftINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.CIDR,
+			RightType:  types.CIDR,
+			ReturnType: types.Bool,
+			EvalOp:     &LShiftCIDROp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.RShift: {overloads: []*BinOp{
@@ -1265,6 +1335,13 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &RShiftINetOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.CIDR,
+			RightType:  types.CIDR,
+			ReturnType: types.Bool,
+			EvalOp:     &RShiftCIDROp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 
 	treebin.Pow: {overloads: []*BinOp{
@@ -1413,16 +1490,27 @@ func cmpOpFixups(
 		panic(errors.AssertionFailedf("could not find cmp op %s(%s,%s)", op, t, t))
 	}
 
-	// Array equality comparisons.
-	for _, t := range append(types.Scalar, types.AnyEnum) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
-			}
-			s.overloads = append(s.overloads, cmpOp)
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
 		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
+	// Range and multirange comparisons.
+	for i, rt := range types.Ranges {
+		appendRangeCmpOps(appendCmpOp, rt, types.MultiRanges[i])
+	}
+
+	// Array equality comparisons.
+	arrayElemTypes := make([]*types.T, 0, len(types.Scalar)+1+len(types.Ranges)+len(types.MultiRanges))
+	arrayElemTypes = append(arrayElemTypes, types.Scalar...)
+	arrayElemTypes = append(arrayElemTypes, types.AnyEnum)
+	arrayElemTypes = append(arrayElemTypes, types.Ranges...)
+	arrayElemTypes = append(arrayElemTypes, types.MultiRanges...)
+	for _, t := range arrayElemTypes {
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1537,6 +1625,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeEqFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeEqFn(types.INet, types.INet, volatility.Leakproof),
+		makeEqFn(types.CIDR, types.CIDR, volatility.Leakproof),
+		makeEqFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeEqFn(types.MacAddr8, types.MacAddr8, volatility.Leakproof),
 		makeEqFn(types.Int, types.Int, volatility.Leakproof),
 		makeEqFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeEqFn(types.Jsonb, types.Jsonb, volatility.Immutable),
@@ -1597,6 +1688,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeLtFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeLtFn(types.INet, types.INet, volatility.Leakproof),
+		makeLtFn(types.CIDR, types.CIDR, volatility.Leakproof),
+		makeLtFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeLtFn(types.MacAddr8, types.MacAddr8, volatility.Leakproof),
 		makeLtFn(types.Int, types.Int, volatility.Leakproof),
 		makeLtFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeLtFn(types.Oid, types.Oid, volatility.Leakproof),
@@ -1655,6 +1749,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeLeFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeLeFn(types.INet, types.INet, volatility.Leakproof),
+		makeLeFn(types.CIDR, types.CIDR, volatility.Leakproof),
+		makeLeFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeLeFn(types.MacAddr8, types.MacAddr8, volatility.Leakproof),
 		makeLeFn(types.Int, types.Int, volatility.Leakproof),
 		makeLeFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeLeFn(types.Oid, types.Oid, volatility.Leakproof),
@@ -1733,9 +1830,13 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeIsFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeIsFn(types.INet, types.INet, volatility.Leakproof),
+		makeIsFn(types.CIDR, types.CIDR, volatility.Leakproof),
+		makeIsFn(types.MacAddr, types.MacAddr, volatility.Leakproof),
+		makeIsFn(types.MacAddr8, types.MacAddr8, volatility.Leakproof),
 		makeIsFn(types.Int, types.Int, volatility.Leakproof),
 		makeIsFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeIsFn(types.Jsonb, types.Jsonb, volatility.Immutable),
+		makeIsFn(types.Jsonpath, types.Jsonpath, volatility.Immutable),
 		makeIsFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeIsFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
 		makeIsFn(types.String, types.String, volatility.Leakproof),
@@ -1800,6 +1901,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.Geography, volatility.Leakproof),
 		makeEvalTupleIn(types.Geometry, volatility.Leakproof),
 		makeEvalTupleIn(types.INet, volatility.Leakproof),
+		makeEvalTupleIn(types.CIDR, volatility.Leakproof),
+		makeEvalTupleIn(types.MacAddr, volatility.Leakproof),
+		makeEvalTupleIn(types.MacAddr8, volatility.Leakproof),
 		makeEvalTupleIn(types.Int, volatility.Leakproof),
 		makeEvalTupleIn(types.Interval, volatility.Leakproof),
 		makeEvalTupleIn(types.Jsonb, volatility.Leakproof),
@@ -1892,7 +1996,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1905,9 +2009,21 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeGeometricComparisonOperators(
+		pggeom.Contains,
+		[][2]*types.T{
+			{types.Box, types.Point},
+			{types.Box, types.Box},
+			{types.Path, types.Point},
+			{types.Polygon, types.Point},
+			{types.Polygon, types.Polygon},
+			{types.Circle, types.Point},
+			{types.Circle, types.Circle},
+		},
+	)...),
+	},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1920,7 +2036,25 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeGeometricComparisonOperators(
+		func(left, right pggeom.Shape) (bool, error) {
+			return pggeom.Contains(right, left)
+		},
+		[][2]*types.T{
+			{types.Point, types.Box},
+			{types.Point, types.LSeg},
+			{types.Point, types.Line},
+			{types.Point, types.Path},
+			{types.Point, types.Polygon},
+			{types.Point, types.Circle},
+			{types.LSeg, types.Box},
+			{types.LSeg, types.Line},
+			{types.Box, types.Box},
+			{types.Polygon, types.Polygon},
+			{types.Circle, types.Circle},
+		},
+	)...),
+	},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1934,11 +2068,24 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
+		{
+			LeftType:   types.CIDR,
+			RightType:  types.CIDR,
+			EvalOp:     &OverlapsCIDROp{},
+			Volatility: volatility.Immutable,
+		},
+	}, append(makeBox2DComparisonOperators(
 		func(lhs, rhs *geo.CartesianBoundingBox) bool {
 			return lhs.Intersects(rhs)
 		},
-	)...),
+	), makeGeometricComparisonOperators(
+		pggeom.Overlaps,
+		[][2]*types.T{
+			{types.Box, types.Box},
+			{types.Polygon, types.Polygon},
+			{types.Circle, types.Circle},
+		},
+	)...)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -1953,6 +2100,21 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -2005,6 +2167,146 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// makeGeometricComparisonOperators returns the overloads of a comparison
+// operator between the given pairs of geometric types.
+func makeGeometricComparisonOperators(
+	op func(left, right pggeom.Shape) (bool, error), pairs [][2]*types.T,
+) []*CmpOp {
+	ops := make([]*CmpOp, len(pairs))
+	for i, p := range pairs {
+		ops[i] = &CmpOp{
+			LeftType:   p[0],
+			RightType:  p[1],
+			EvalOp:     &CompareGeometricOp{Op: op},
+			Volatility: volatility.Immutable,
+		}
+	}
+	return ops
+}
+
+// appendRangeCmpOps adds the comparison operators of the given range type and
+// of the corresponding multirange type.
+func appendRangeCmpOps(
+	appendCmpOp func(treecmp.ComparisonOperatorSymbol, *CmpOp), rt, mt *types.T,
+) {
+	for _, t := range []*types.T{rt, mt} {
+		for _, sym := range []treecmp.ComparisonOperatorSymbol{treecmp.EQ, treecmp.LT, treecmp.LE} {
+			appendCmpOp(sym, &CmpOp{
+				LeftType:   t,
+				RightType:  t,
+				EvalOp:     &CompareScalarOp{treecmp.MakeComparisonOperator(sym)},
+				Volatility: volatility.Immutable,
+			})
+		}
+		appendCmpOp(treecmp.IsNotDistinctFrom, &CmpOp{
+			LeftType:          t,
+			RightType:         t,
+			EvalOp:            &CompareScalarOp{treecmp.MakeComparisonOperator(treecmp.IsNotDistinctFrom)},
+			CalledOnNullInput: true,
+			Volatility:        volatility.Immutable,
+		})
+	}
+	add := func(sym treecmp.ComparisonOperatorSymbol, left, right *types.T, op func(left, right Datum) bool) {
+		appendCmpOp(sym, &CmpOp{
+			LeftType:   left,
+			RightType:  right,
+			EvalOp:     &CompareRangeOp{Op: op},
+			Volatility: volatility.Immutable,
+		})
+	}
+	elem := rt.RangeContents()
+	add(treecmp.Contains, rt, rt, func(left, right Datum) bool {
+		return MustBeDRange(left).ContainsRange(MustBeDRange(right))
+	})
+	add(treecmp.Contains, rt, elem, func(left, right Datum) bool {
+		return MustBeDRange(left).ContainsElem(right)
+	})
+	add(treecmp.Contains, rt, mt, func(left, right Datum) bool {
+		return MustBeDRange(left).ContainsRange(MustBeDMultiRange(right).Span())
+	})
+	add(treecmp.Contains, mt, mt, func(left, right Datum) bool {
+		return MustBeDMultiRange(left).ContainsMultiRange(MustBeDMultiRange(right))
+	})
+	add(treecmp.Contains, mt, rt, func(left, right Datum) bool {
+		return MustBeDMultiRange(left).ContainsRange(MustBeDRange(right))
+	})
+	add(treecmp.Contains, mt, elem, func(left, right Datum) bool {
+		return MustBeDMultiRange(left).ContainsElem(right)
+	})
+	add(treecmp.ContainedBy, rt, rt, func(left, right Datum) bool {
+		return MustBeDRange(right).ContainsRange(MustBeDRange(left))
+	})
+	add(treecmp.ContainedBy, elem, rt, func(left, right Datum) bool {
+		return MustBeDRange(right).ContainsElem(left)
+	})
+	add(treecmp.ContainedBy, mt, rt, func(left, right Datum) bool {
+		return MustBeDRange(right).ContainsRange(MustBeDMultiRange(left).Span())
+	})
+	add(treecmp.ContainedBy, mt, mt, func(left, right Datum) bool {
+		return MustBeDMultiRange(right).ContainsMultiRange(MustBeDMultiRange(left))
+	})
+	add(treecmp.ContainedBy, rt, mt, func(left, right Datum) bool {
+		return MustBeDMultiRange(right).ContainsRange(MustBeDRange(left))
+	})
+	add(treecmp.ContainedBy, elem, mt, func(left, right Datum) bool {
+		return MustBeDMultiRange(right).ContainsElem(left)
+	})
+	add(treecmp.Overlaps, rt, rt, func(left, right Datum) bool {
+		return MustBeDRange(left).Overlaps(MustBeDRange(right))
+	})
+	add(treecmp.Overlaps, mt, mt, func(left, right Datum) bool {
+		return MustBeDMultiRange(left).OverlapsMultiRange(MustBeDMultiRange(right))
+	})
+	add(treecmp.Overlaps, mt, rt, func(left, right Datum) bool {
+		return MustBeDMultiRange(left).OverlapsRange(MustBeDRange(right))
+	})
+	add(treecmp.Overlaps, rt, mt, func(left, right Datum) bool {
+		return MustBeDMultiRange(right).OverlapsRange(MustBeDRange(left))
+	})
+}
+
+// initRangeOperators initializes the binary operators of the range and
+// multirange types: union (+), intersection (*) and difference (-), as well
+// as strictly left of (<<) and strictly right of (>>) for ranges.
+func initRangeOperators() {
+	add := func(sym treebin.BinaryOperatorSymbol, left, right, ret *types.T, op func(left, right Datum) (Datum, error)) {
+		addBinOp(sym, &BinOp{
+			LeftType:   left,
+			RightType:  right,
+			ReturnType: ret,
+			EvalOp:     &RangeBinaryOp{Op: op},
+			Volatility: volatility.Immutable,
+		})
+	}
+	for i, rt := range types.Ranges {
+		mt := types.MultiRanges[i]
+		add(treebin.Plus, rt, rt, rt, func(left, right Datum) (Datum, error) {
+			return MustBeDRange(left).Union(MustBeDRange(right))
+		})
+		add(treebin.Mult, rt, rt, rt, func(left, right Datum) (Datum, error) {
+			return MustBeDRange(left).Intersect(MustBeDRange(right))
+		})
+		add(treebin.Minus, rt, rt, rt, func(left, right Datum) (Datum, error) {
+			return MustBeDRange(left).Difference(MustBeDRange(right))
+		})
+		add(treebin.LShift, rt, rt, types.Bool, func(left, right Datum) (Datum, error) {
+			return MakeDBool(DBool(MustBeDRange(left).StrictlyLeftOf(MustBeDRange(right)))), nil
+		})
+		add(treebin.RShift, rt, rt, types.Bool, func(left, right Datum) (Datum, error) {
+			return MakeDBool(DBool(MustBeDRange(left).StrictlyRightOf(MustBeDRange(right)))), nil
+		})
+		add(treebin.Plus, mt, mt, mt, func(left, right Datum) (Datum, error) {
+			return MustBeDMultiRange(left).Union(MustBeDMultiRange(right))
+		})
+		add(treebin.Mult, mt, mt, mt, func(left, right Datum) (Datum, error) {
+			return MustBeDMultiRange(left).Intersect(MustBeDMultiRange(right))
+		})
+		add(treebin.Minus, mt, mt, mt, func(left, right Datum) (Datum, error) {
+			return MustBeDMultiRange(left).Difference(MustBeDMultiRange(right))
+		})
+	}
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -2052,7 +2354,9 @@ func (e *MultipleResultsError) Error() string {
 func (expr *FuncExpr) MaybeWrapError(err error) error {
 	// If we are facing an explicit error, propagate it unchanged.
 	fName := expr.Func.String()
-	if fName == `crdb_internal.force_error` || fName == `crdb_internal.plpgsql_raise` {
+	switch fName {
+	case `crdb_internal.force_error`, `crdb_internal.plpgsql_raise`,
+		`crdb_internal.plpgsql_close`, `crdb_internal.plpgsql_fetch`:
 		return err
 	}
 	// Otherwise, wrap it with context.
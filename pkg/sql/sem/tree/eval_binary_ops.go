@@ -13,6 +13,7 @@ package tree
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/geo/pggeom"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
@@ -32,6 +33,16 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareGeometricOp is a BinaryEvalOp.
+type CompareGeometricOp struct {
+	Op func(left, right pggeom.Shape) (bool, error)
+}
+
+// CompareRangeOp is a BinaryEvalOp.
+type CompareRangeOp struct {
+	Op func(left, right Datum) bool
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -75,6 +86,9 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsCIDROp is a BinaryEvalOp.
+type OverlapsCIDROp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -109,6 +123,10 @@ type (
 	BitAndVarBitOp struct{}
 	// BitAndINetOp is a BinaryEvalOp.
 	BitAndINetOp struct{}
+	// BitAndMacAddrOp is a BinaryEvalOp.
+	BitAndMacAddrOp struct{}
+	// BitAndMacAddr8Op is a BinaryEvalOp.
+	BitAndMacAddr8Op struct{}
 )
 
 type (
@@ -118,6 +136,10 @@ type (
 	BitOrVarBitOp struct{}
 	// BitOrINetOp is a BinaryEvalOp.
 	BitOrINetOp struct{}
+	// BitOrMacAddrOp is a BinaryEvalOp.
+	BitOrMacAddrOp struct{}
+	// BitOrMacAddr8Op is a BinaryEvalOp.
+	BitOrMacAddr8Op struct{}
 )
 
 type (
@@ -320,6 +342,8 @@ type (
 type (
 	// LShiftINetOp is a BinaryEvalOp.
 	LShiftINetOp struct{}
+	// LShiftCIDROp is a BinaryEvalOp.
+	LShiftCIDROp struct{}
 	// LShiftIntOp is a BinaryEvalOp.
 	LShiftIntOp struct{}
 	// LShiftVarBitIntOp is a BinaryEvalOp.
@@ -329,12 +353,20 @@ type (
 type (
 	// RShiftINetOp is a BinaryEvalOp.
 	RShiftINetOp struct{}
+	// RShiftCIDROp is a BinaryEvalOp.
+	RShiftCIDROp struct{}
 	// RShiftIntOp is a BinaryEvalOp.
 	RShiftIntOp struct{}
 	// RShiftVarBitIntOp is a BinaryEvalOp.
 	RShiftVarBitIntOp struct{}
 )
 
+// RangeBinaryOp is a BinaryEvalOp for the operators on range and multirange
+// types.
+type RangeBinaryOp struct {
+	Op func(left, right Datum) (Datum, error)
+}
+
 type (
 	// PowDecimalIntOp is a BinaryEvalOp.
 	PowDecimalIntOp struct{}
@@ -370,6 +402,12 @@ type JSONSomeExistsOp struct{}
 // JSONAllExistsOp is a BinaryEvalOp.
 type JSONAllExistsOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONFetchValPathOp is a BinaryEvalOp.
 type JSONFetchValPathOp struct{}
 
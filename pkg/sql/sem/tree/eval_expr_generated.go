@@ -54,10 +54,12 @@ type ExprEvaluator interface {
 	EvalSubquery(context.Context, *Subquery) (Datum, error)
 	EvalTuple(context.Context, *Tuple) (Datum, error)
 	EvalTupleStar(context.Context, *TupleStar) (Datum, error)
+	EvalTxnControlExpr(context.Context, *TxnControlExpr) (Datum, error)
 	EvalTypedDummy(context.Context, *TypedDummy) (Datum, error)
 	EvalUnaryExpr(context.Context, *UnaryExpr) (Datum, error)
 	EvalUnqualifiedStar(context.Context, UnqualifiedStar) (Datum, error)
 	EvalUnresolvedName(context.Context, *UnresolvedName) (Datum, error)
+	EvalUserDefinedAggregate(context.Context, *UserDefinedAggregate) (Datum, error)
 }
 
 
@@ -146,6 +148,11 @@ func (node *DBytes) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DCIDR) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DCollatedString) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -181,6 +188,11 @@ func (node *DGeography) Eval(ctx context.Context, v ExprEvaluator) (Datum, error
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DGeometric) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DGeometry) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -206,11 +218,31 @@ func (node *DJSON) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DJsonpath) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DMacAddr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DMacAddr8) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DOid) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DMultiRange) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DOidWrapper) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -221,6 +253,11 @@ func (node *DPGLSN) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DRange) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DString) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -366,6 +403,11 @@ func (node *TupleStar) Eval(ctx context.Context, v ExprEvaluator) (Datum, error)
 	return v.EvalTupleStar(ctx, node)
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *TxnControlExpr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return v.EvalTxnControlExpr(ctx, node)
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *TypedDummy) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return v.EvalTypedDummy(ctx, node)
@@ -386,6 +428,11 @@ func (node *UnresolvedName) Eval(ctx context.Context, v ExprEvaluator) (Datum, e
 	return v.EvalUnresolvedName(ctx, node)
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *UserDefinedAggregate) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return v.EvalUserDefinedAggregate(ctx, node)
+}
+
 // Eval is part of the TypedExpr interface.
 func (node dNull) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -44,6 +44,8 @@ type UnaryOpEvaluator interface {
 	EvalCbrtFloatOp(context.Context, *CbrtFloatOp, Datum) (Datum, error)
 	EvalComplementINetOp(context.Context, *ComplementINetOp, Datum) (Datum, error)
 	EvalComplementIntOp(context.Context, *ComplementIntOp, Datum) (Datum, error)
+	EvalComplementMacAddr8Op(context.Context, *ComplementMacAddr8Op, Datum) (Datum, error)
+	EvalComplementMacAddrOp(context.Context, *ComplementMacAddrOp, Datum) (Datum, error)
 	EvalComplementVarBitOp(context.Context, *ComplementVarBitOp, Datum) (Datum, error)
 	EvalSqrtDecimalOp(context.Context, *SqrtDecimalOp, Datum) (Datum, error)
 	EvalSqrtFloatOp(context.Context, *SqrtFloatOp, Datum) (Datum, error)
@@ -58,13 +60,19 @@ type BinaryOpEvaluator interface {
 	EvalAppendToMaybeNullArrayOp(context.Context, *AppendToMaybeNullArrayOp, Datum, Datum) (Datum, error)
 	EvalBitAndINetOp(context.Context, *BitAndINetOp, Datum, Datum) (Datum, error)
 	EvalBitAndIntOp(context.Context, *BitAndIntOp, Datum, Datum) (Datum, error)
+	EvalBitAndMacAddr8Op(context.Context, *BitAndMacAddr8Op, Datum, Datum) (Datum, error)
+	EvalBitAndMacAddrOp(context.Context, *BitAndMacAddrOp, Datum, Datum) (Datum, error)
 	EvalBitAndVarBitOp(context.Context, *BitAndVarBitOp, Datum, Datum) (Datum, error)
 	EvalBitOrINetOp(context.Context, *BitOrINetOp, Datum, Datum) (Datum, error)
 	EvalBitOrIntOp(context.Context, *BitOrIntOp, Datum, Datum) (Datum, error)
+	EvalBitOrMacAddr8Op(context.Context, *BitOrMacAddr8Op, Datum, Datum) (Datum, error)
+	EvalBitOrMacAddrOp(context.Context, *BitOrMacAddrOp, Datum, Datum) (Datum, error)
 	EvalBitOrVarBitOp(context.Context, *BitOrVarBitOp, Datum, Datum) (Datum, error)
 	EvalBitXorIntOp(context.Context, *BitXorIntOp, Datum, Datum) (Datum, error)
 	EvalBitXorVarBitOp(context.Context, *BitXorVarBitOp, Datum, Datum) (Datum, error)
 	EvalCompareBox2DOp(context.Context, *CompareBox2DOp, Datum, Datum) (Datum, error)
+	EvalCompareGeometricOp(context.Context, *CompareGeometricOp, Datum, Datum) (Datum, error)
+	EvalCompareRangeOp(context.Context, *CompareRangeOp, Datum, Datum) (Datum, error)
 	EvalCompareScalarOp(context.Context, *CompareScalarOp, Datum, Datum) (Datum, error)
 	EvalCompareTupleOp(context.Context, *CompareTupleOp, Datum, Datum) (Datum, error)
 	EvalConcatArraysOp(context.Context, *ConcatArraysOp, Datum, Datum) (Datum, error)
@@ -98,7 +106,10 @@ type BinaryOpEvaluator interface {
 	EvalJSONFetchValIntOp(context.Context, *JSONFetchValIntOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValPathOp(context.Context, *JSONFetchValPathOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValStringOp(context.Context, *JSONFetchValStringOp, Datum, Datum) (Datum, error)
+	EvalJSONPathExistsOp(context.Context, *JSONPathExistsOp, Datum, Datum) (Datum, error)
+	EvalJSONPathMatchOp(context.Context, *JSONPathMatchOp, Datum, Datum) (Datum, error)
 	EvalJSONSomeExistsOp(context.Context, *JSONSomeExistsOp, Datum, Datum) (Datum, error)
+	EvalLShiftCIDROp(context.Context, *LShiftCIDROp, Datum, Datum) (Datum, error)
 	EvalLShiftINetOp(context.Context, *LShiftINetOp, Datum, Datum) (Datum, error)
 	EvalLShiftIntOp(context.Context, *LShiftIntOp, Datum, Datum) (Datum, error)
 	EvalLShiftVarBitIntOp(context.Context, *LShiftVarBitIntOp, Datum, Datum) (Datum, error)
@@ -148,6 +159,7 @@ type BinaryOpEvaluator interface {
 	EvalMultIntervalFloatOp(context.Context, *MultIntervalFloatOp, Datum, Datum) (Datum, error)
 	EvalMultIntervalIntOp(context.Context, *MultIntervalIntOp, Datum, Datum) (Datum, error)
 	EvalOverlapsArrayOp(context.Context, *OverlapsArrayOp, Datum, Datum) (Datum, error)
+	EvalOverlapsCIDROp(context.Context, *OverlapsCIDROp, Datum, Datum) (Datum, error)
 	EvalOverlapsINetOp(context.Context, *OverlapsINetOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntOp(context.Context, *PlusDateIntOp, Datum, Datum) (Datum, error)
 	EvalPlusDateIntervalOp(context.Context, *PlusDateIntervalOp, Datum, Datum) (Datum, error)
@@ -181,9 +193,11 @@ type BinaryOpEvaluator interface {
 	EvalPowIntDecimalOp(context.Context, *PowIntDecimalOp, Datum, Datum) (Datum, error)
 	EvalPowIntOp(context.Context, *PowIntOp, Datum, Datum) (Datum, error)
 	EvalPrependToMaybeNullArrayOp(context.Context, *PrependToMaybeNullArrayOp, Datum, Datum) (Datum, error)
+	EvalRShiftCIDROp(context.Context, *RShiftCIDROp, Datum, Datum) (Datum, error)
 	EvalRShiftINetOp(context.Context, *RShiftINetOp, Datum, Datum) (Datum, error)
 	EvalRShiftIntOp(context.Context, *RShiftIntOp, Datum, Datum) (Datum, error)
 	EvalRShiftVarBitIntOp(context.Context, *RShiftVarBitIntOp, Datum, Datum) (Datum, error)
+	EvalRangeBinaryOp(context.Context, *RangeBinaryOp, Datum, Datum) (Datum, error)
 	EvalSimilarToOp(context.Context, *SimilarToOp, Datum, Datum) (Datum, error)
 	EvalTSMatchesQueryVectorOp(context.Context, *TSMatchesQueryVectorOp, Datum, Datum) (Datum, error)
 	EvalTSMatchesVectorQueryOp(context.Context, *TSMatchesVectorQueryOp, Datum, Datum) (Datum, error)
@@ -210,6 +224,16 @@ func (op *ComplementIntOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Da
 	return e.EvalComplementIntOp(ctx, op, v)
 }
 
+// Eval is part of the UnaryEvalOp interface.
+func (op *ComplementMacAddr8Op) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
+	return e.EvalComplementMacAddr8Op(ctx, op, v)
+}
+
+// Eval is part of the UnaryEvalOp interface.
+func (op *ComplementMacAddrOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
+	return e.EvalComplementMacAddrOp(ctx, op, v)
+}
+
 // Eval is part of the UnaryEvalOp interface.
 func (op *ComplementVarBitOp) Eval(ctx context.Context, e OpEvaluator, v Datum) (Datum, error) {
 	return e.EvalComplementVarBitOp(ctx, op, v)
@@ -260,6 +284,16 @@ func (op *BitAndIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Dat
 	return e.EvalBitAndIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitAndMacAddr8Op) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitAndMacAddr8Op(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitAndMacAddrOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitAndMacAddrOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *BitAndVarBitOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalBitAndVarBitOp(ctx, op, a, b)
@@ -275,6 +309,16 @@ func (op *BitOrIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datu
 	return e.EvalBitOrIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitOrMacAddr8Op) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitOrMacAddr8Op(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *BitOrMacAddrOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalBitOrMacAddrOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *BitOrVarBitOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalBitOrVarBitOp(ctx, op, a, b)
@@ -295,6 +339,16 @@ func (op *CompareBox2DOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalCompareBox2DOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *CompareGeometricOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalCompareGeometricOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *CompareRangeOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalCompareRangeOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *CompareScalarOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalCompareScalarOp(ctx, op, a, b)
@@ -460,11 +514,26 @@ func (op *JSONFetchValStringOp) Eval(ctx context.Context, e OpEvaluator, a, b Da
 	return e.EvalJSONFetchValStringOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathExistsOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathMatchOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathMatchOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *JSONSomeExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalJSONSomeExistsOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *LShiftCIDROp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalLShiftCIDROp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *LShiftINetOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalLShiftINetOp(ctx, op, a, b)
@@ -710,6 +779,11 @@ func (op *OverlapsArrayOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum)
 	return e.EvalOverlapsArrayOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *OverlapsCIDROp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalOverlapsCIDROp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *OverlapsINetOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalOverlapsINetOp(ctx, op, a, b)
@@ -875,6 +949,11 @@ func (op *PrependToMaybeNullArrayOp) Eval(ctx context.Context, e OpEvaluator, a,
 	return e.EvalPrependToMaybeNullArrayOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *RShiftCIDROp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalRShiftCIDROp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *RShiftINetOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalRShiftINetOp(ctx, op, a, b)
@@ -890,6 +969,11 @@ func (op *RShiftVarBitIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum
 	return e.EvalRShiftVarBitIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *RangeBinaryOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalRangeBinaryOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *SimilarToOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalSimilarToOp(ctx, op, a, b)
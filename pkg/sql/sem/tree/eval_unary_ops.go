@@ -37,6 +37,10 @@ type (
 	ComplementVarBitOp struct{}
 	// ComplementINetOp is a UnaryEvalOp.
 	ComplementINetOp struct{}
+	// ComplementMacAddrOp is a UnaryEvalOp.
+	ComplementMacAddrOp struct{}
+	// ComplementMacAddr8Op is a UnaryEvalOp.
+	ComplementMacAddr8Op struct{}
 )
 type (
 	// SqrtFloatOp is a UnaryEvalOp.
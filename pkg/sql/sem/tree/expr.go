@@ -747,6 +747,20 @@ func (node *CoalesceExpr) GetWhenCondition(i int) (whenCond Expr) {
 	return whenCond
 }
 
+// GroupingFuncExpr represents a GROUPING(...) expression. Its value is a bit
+// mask with one bit for each of its arguments, set if the argument is not
+// part of the grouping set that produced the current row.
+type GroupingFuncExpr struct {
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingFuncExpr) Format(ctx *FmtCtx) {
+	ctx.WriteString("GROUPING(")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DefaultVal represents the DEFAULT expression.
 type DefaultVal struct{}
 
@@ -1275,6 +1289,10 @@ type FuncExpr struct {
 	// is used for any type of aggregation.
 	OrderBy OrderBy
 
+	// InCall is true when the FuncExpr is the procedure invoked by a CALL
+	// statement.
+	InCall bool
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1742,6 +1760,12 @@ func (node *DInterval) String() string        { return AsString(node) }
 func (node *DJSON) String() string            { return AsString(node) }
 func (node *DUuid) String() string            { return AsString(node) }
 func (node *DIPAddr) String() string          { return AsString(node) }
+func (node *DCIDR) String() string            { return AsString(node) }
+func (node *DMacAddr) String() string         { return AsString(node) }
+func (node *DMacAddr8) String() string        { return AsString(node) }
+func (node *DGeometric) String() string       { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
+func (node *DMultiRange) String() string      { return AsString(node) }
 func (node *DString) String() string          { return AsString(node) }
 func (node *DCollatedString) String() string  { return AsString(node) }
 func (node *DTimestamp) String() string       { return AsString(node) }
@@ -1754,6 +1778,8 @@ func (node *DVoid) String() string            { return AsString(node) }
 func (node *Exprs) String() string            { return AsString(node) }
 func (node *ArrayFlatten) String() string     { return AsString(node) }
 func (node *FuncExpr) String() string         { return AsString(node) }
+func (node *GroupingFuncExpr) String() string { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node *IfExpr) String() string           { return AsString(node) }
 func (node *IfErrExpr) String() string        { return AsString(node) }
 func (node *IndexedVar) String() string       { return AsString(node) }
@@ -1772,6 +1798,7 @@ func (node *RangeCond) String() string        { return AsString(node) }
 func (node *StrVal) String() string           { return AsString(node) }
 func (node *Subquery) String() string         { return AsString(node) }
 func (node *RoutineExpr) String() string      { return AsString(node) }
+func (node *TxnControlExpr) String() string   { return AsString(node) }
 func (node *Tuple) String() string            { return AsString(node) }
 func (node *TupleStar) String() string        { return AsString(node) }
 func (node *AnnotateTypeExpr) String() string { return AsString(node) }
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
@@ -239,12 +240,52 @@ type Overload struct {
 	// ReturnSet is set to true when a user-defined function is defined to return
 	// a set of values.
 	ReturnSet bool
+	// IsProcedure is set to true when this is a stored procedure overload built
+	// using CREATE PROCEDURE. Procedures can only be invoked with CALL.
+	IsProcedure bool
+	// OutParamOrdinals contains the ordinals of the OUT and INOUT parameters of
+	// a stored procedure. The values of these parameters make up the result of
+	// the procedure.
+	OutParamOrdinals []int
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the function overload. Only used for UDFs.
 	Version uint64
 	// Language is the function language that was used to define the UDF.
 	// This is currently either SQL or PL/pgSQL.
 	Language RoutineLanguage
+	// UserDefinedAggregate is set for overloads of aggregates created with
+	// CREATE AGGREGATE. It identifies the support functions that define the
+	// aggregate. Unset if UDFContainsOnlySignature is true.
+	UserDefinedAggregate *UserDefinedAggregateInfo
+	// SecurityDefiner is true if the UDF was created with SECURITY DEFINER, in
+	// which case it executes with the privileges of its owner rather than
+	// those of the invoking user.
+	SecurityDefiner bool
+	// Owner is the owner of the UDF. It is only set for UDFs.
+	Owner username.SQLUsername
+	// SessionConfig contains the session variables that are set for the
+	// duration of the UDF's execution, each in the form "name=value".
+	SessionConfig []string
+}
+
+// UserDefinedAggregateInfo describes a user-defined aggregate in terms of its
+// support functions.
+type UserDefinedAggregateInfo struct {
+	// StateFunc is the OID of the state transition function, which is called
+	// with the current state and the aggregate's arguments for each input row.
+	StateFunc oid.Oid
+	// FinalFunc is the OID of the final function, which computes the result of
+	// the aggregate from the final state. It is zero if the aggregate has no
+	// final function.
+	FinalFunc oid.Oid
+	// CombineFunc is the OID of the function that merges two partial states. It
+	// is zero if the aggregate has no combine function.
+	CombineFunc oid.Oid
+	// StateType is the type of the aggregate's state.
+	StateType *types.T
+	// InitCond is the text representation of the initial state, or nil if the
+	// initial state is NULL.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
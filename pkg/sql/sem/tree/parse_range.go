@@ -0,0 +1,233 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var malformedRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal")
+var malformedMultiRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal")
+
+func makeMalformedRangeError(detail string) error {
+	return errors.WithDetail(malformedRangeError, detail)
+}
+
+func makeMalformedMultiRangeError(detail string) error {
+	return errors.WithDetail(malformedMultiRangeError, detail)
+}
+
+// ParseDRangeFromString parses the string-form of constructing ranges,
+// handling cases such as `'[1,10)'::int4range` and `'empty'::int4range`.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx}
+	r, err := p.parseRange(t)
+	if err == nil {
+		p.eatWhitespace()
+		if !p.done() {
+			err = makeMalformedRangeError("Junk after right parenthesis or bracket.")
+		}
+	}
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return r, p.dependsOnContext, nil
+}
+
+// ParseDMultiRangeFromString parses the string-form of constructing
+// multiranges, such as `'{[1,3), [5,7)}'::int4multirange`.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultiRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultiRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx}
+	m, err := p.parseMultiRange(t)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return m, p.dependsOnContext, nil
+}
+
+// rangeParseState holds the state of the parsing of a range or multirange
+// literal. It follows range_parse and multirange_in in Postgres.
+type rangeParseState struct {
+	s                string
+	pos              int
+	ctx              ParseContext
+	dependsOnContext bool
+}
+
+func (p *rangeParseState) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *rangeParseState) peek() byte {
+	return p.s[p.pos]
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	for !p.done() && isRangeSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func isRangeSpace(c byte) bool {
+	return asciiSpace[c] == 1
+}
+
+// parseRange parses a single range, which is either `empty` or a pair of
+// bounds enclosed in brackets or parentheses.
+func (p *rangeParseState) parseRange(t *types.T) (*DRange, error) {
+	p.eatWhitespace()
+	if rest := p.s[p.pos:]; len(rest) >= len("empty") && strings.EqualFold(rest[:len("empty")], "empty") {
+		p.pos += len("empty")
+		return NewDEmptyRange(t), nil
+	}
+	var lower, upper RangeBound
+	switch {
+	case p.done():
+		return nil, makeMalformedRangeError("Missing left parenthesis or bracket.")
+	case p.peek() == '[':
+		lower.Inclusive = true
+	case p.peek() == '(':
+	default:
+		return nil, makeMalformedRangeError("Missing left parenthesis or bracket.")
+	}
+	p.pos++
+	var err error
+	if lower.Val, err = p.parseBound(t.RangeContents()); err != nil {
+		return nil, err
+	}
+	if p.done() || p.peek() != ',' {
+		return nil, makeMalformedRangeError("Missing comma after lower bound.")
+	}
+	p.pos++
+	if upper.Val, err = p.parseBound(t.RangeContents()); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.done():
+		return nil, makeMalformedRangeError("Missing right parenthesis or bracket.")
+	case p.peek() == ']':
+		upper.Inclusive = true
+	case p.peek() == ')':
+	case p.peek() == ',':
+		return nil, makeMalformedRangeError("Too many commas.")
+	default:
+		return nil, makeMalformedRangeError("Missing right parenthesis or bracket.")
+	}
+	p.pos++
+	return NewDRange(t, lower, upper)
+}
+
+// parseBound parses a range bound up to the next unquoted comma or closing
+// bracket or parenthesis. An empty, unquoted bound is infinite, and nil is
+// returned for it.
+func (p *rangeParseState) parseBound(sub *types.T) (Datum, error) {
+	if !p.done() {
+		switch p.peek() {
+		case ',', ')', ']':
+			return nil, nil
+		}
+	}
+	var sb strings.Builder
+	inQuote := false
+	for {
+		if p.done() {
+			return nil, makeMalformedRangeError("Unexpected end of input.")
+		}
+		c := p.peek()
+		if !inQuote && (c == ',' || c == ')' || c == ']') {
+			break
+		}
+		p.pos++
+		switch {
+		case c == '\\':
+			if p.done() {
+				return nil, makeMalformedRangeError("Unexpected end of input.")
+			}
+			sb.WriteByte(p.peek())
+			p.pos++
+		case c == '"':
+			if !inQuote {
+				inQuote = true
+			} else if !p.done() && p.peek() == '"' {
+				// Doubled quote within quote sequence.
+				sb.WriteByte('"')
+				p.pos++
+			} else {
+				inQuote = false
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	d, dependsOnContext, err := ParseAndRequireString(sub, sb.String(), p.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dependsOnContext {
+		p.dependsOnContext = true
+	}
+	return d, nil
+}
+
+// parseMultiRange parses a multirange, which is a list of ranges enclosed in
+// braces.
+func (p *rangeParseState) parseMultiRange(t *types.T) (*DMultiRange, error) {
+	p.eatWhitespace()
+	if p.done() || p.peek() != '{' {
+		return nil, makeMalformedMultiRangeError("Missing left brace.")
+	}
+	p.pos++
+	var ranges []*DRange
+	p.eatWhitespace()
+	if !p.done() && p.peek() == '}' {
+		p.pos++
+	} else {
+		for {
+			r, err := p.parseRange(t.MultiRangeContents())
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+			p.eatWhitespace()
+			if p.done() {
+				return nil, makeMalformedMultiRangeError("Unexpected end of input.")
+			}
+			c := p.peek()
+			p.pos++
+			if c == '}' {
+				break
+			}
+			if c != ',' {
+				return nil, makeMalformedMultiRangeError("Expected comma or end of multirange.")
+			}
+		}
+	}
+	p.eatWhitespace()
+	if !p.done() {
+		return nil, makeMalformedMultiRangeError("Junk after closing right brace.")
+	}
+	return NewDMultiRange(t, ranges)
+}
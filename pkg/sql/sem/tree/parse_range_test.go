@@ -0,0 +1,132 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestParseRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`empty`, types.Int4Range, `empty`},
+		{` EMPTY `, types.Int4Range, `empty`},
+		{`[1,10)`, types.Int4Range, `[1,10)`},
+		{`[1,10]`, types.Int4Range, `[1,11)`},
+		{`(1,10]`, types.Int4Range, `[2,11)`},
+		{`(1,2)`, types.Int4Range, `empty`},
+		{`[1,1)`, types.Int4Range, `empty`},
+		{`[1,1]`, types.Int4Range, `[1,2)`},
+		{`[,10)`, types.Int8Range, `(,10)`},
+		{`[1,]`, types.Int8Range, `[1,)`},
+		{`(,)`, types.Int8Range, `(,)`},
+		{`[ 1 , 10 )`, types.Int8Range, `[1,10)`},
+		{`["1","10")`, types.Int8Range, `[1,10)`},
+		{`(1.5,2.50]`, types.NumRange, `(1.5,2.50]`},
+		{`[1.5,1.5]`, types.NumRange, `[1.5,1.5]`},
+		{`(1.5,1.5]`, types.NumRange, `empty`},
+		{`[2020-01-01,2020-01-31]`, types.DateRange, `[2020-01-01,2020-02-01)`},
+		{`[-infinity,infinity]`, types.DateRange, `[-infinity,infinity]`},
+		{`["2020-01-01 10:00","2020-01-01 11:00")`, types.TSRange,
+			`["2020-01-01 10:00:00","2020-01-01 11:00:00")`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			r, _, err := ParseDRangeFromString(nil /* ctx */, td.str, td.typ)
+			if err != nil {
+				t.Fatalf("range %s: got error %s, expected %s", td.str, err.Error(), td.expected)
+			}
+			if actual := AsStringWithFlags(r, FmtPgwireText); actual != td.expected {
+				t.Fatalf("range %s: got %s, expected %s", td.str, actual, td.expected)
+			}
+		})
+	}
+}
+
+func TestParseMultiRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`{}`, types.Int4MultiRange, `{}`},
+		{` { } `, types.Int4MultiRange, `{}`},
+		{`{empty}`, types.Int4MultiRange, `{}`},
+		{`{[1,3)}`, types.Int4MultiRange, `{[1,3)}`},
+		{`{[5,7), [1,3)}`, types.Int4MultiRange, `{[1,3),[5,7)}`},
+		{`{[1,3), [3,7)}`, types.Int4MultiRange, `{[1,7)}`},
+		{`{[1,5), [3,7), empty}`, types.Int4MultiRange, `{[1,7)}`},
+		{`{(,3), [10,)}`, types.Int8MultiRange, `{(,3),[10,)}`},
+		{`{[1.5,2), (2,3]}`, types.NumMultiRange, `{[1.5,2),(2,3]}`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			m, _, err := ParseDMultiRangeFromString(nil /* ctx */, td.str, td.typ)
+			if err != nil {
+				t.Fatalf("multirange %s: got error %s, expected %s", td.str, err.Error(), td.expected)
+			}
+			if actual := AsStringWithFlags(m, FmtPgwireText); actual != td.expected {
+				t.Fatalf("multirange %s: got %s, expected %s", td.str, actual, td.expected)
+			}
+		})
+	}
+}
+
+func TestParseRangeError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	testData := []struct {
+		str           string
+		typ           *types.T
+		expectedError string
+	}{
+		{``, types.Int4Range, `could not parse "" as type int4range: malformed range literal`},
+		{`1,2`, types.Int4Range, `could not parse "1,2" as type int4range: malformed range literal`},
+		{`[1 2)`, types.Int4Range, `could not parse "[1 2)" as type int4range: could not parse "1 2" as type int: strconv.ParseInt: parsing "1 2": invalid syntax`},
+		{`[1,2`, types.Int4Range, `could not parse "[1,2" as type int4range: malformed range literal`},
+		{`[1,2,3)`, types.Int4Range, `could not parse "[1,2,3)" as type int4range: malformed range literal`},
+		{`[1,2) x`, types.Int4Range, `could not parse "[1,2) x" as type int4range: malformed range literal`},
+		{`[2,1)`, types.Int4Range, `could not parse "[2,1)" as type int4range: range lower bound must be less than or equal to range upper bound`},
+		{`[1,2147483647]`, types.Int4Range, `could not parse "[1,2147483647]" as type int4range: integer out of range`},
+		{`[1,2147483648)`, types.Int4Range, `could not parse "[1,2147483648)" as type int4range: integer out of range for type int4`},
+		{`[1,3)`, types.Int4MultiRange, `could not parse "[1,3)" as type int4multirange: malformed multirange literal`},
+		{`{[1,3)`, types.Int4MultiRange, `could not parse "{[1,3)" as type int4multirange: malformed multirange literal`},
+		{`{[1,3) [5,7)}`, types.Int4MultiRange, `could not parse "{[1,3) [5,7)}" as type int4multirange: malformed multirange literal`},
+		{`{[1,3)} x`, types.Int4MultiRange, `could not parse "{[1,3)} x" as type int4multirange: malformed multirange literal`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			var err error
+			if td.typ.Family() == types.RangeFamily {
+				_, _, err = ParseDRangeFromString(nil /* ctx */, td.str, td.typ)
+			} else {
+				_, _, err = ParseDMultiRangeFromString(nil /* ctx */, td.str, td.typ)
+			}
+			if err == nil {
+				t.Fatalf("expected %#v to error with message %#v", td.str, td.expectedError)
+			}
+			if err.Error() != td.expectedError {
+				t.Fatalf("%s: got error %s, expected %s", td.str, err.Error(), td.expectedError)
+			}
+		})
+	}
+}
@@ -59,6 +59,12 @@ func ParseAndRequireString(
 		d, err = ParseDFloat(strings.TrimSpace(s))
 	case types.INetFamily:
 		d, err = ParseDIPAddrFromINetString(s)
+	case types.CIDRFamily:
+		d, err = ParseDCIDR(s)
+	case types.MacAddrFamily:
+		d, err = ParseDMacAddr(s)
+	case types.MacAddr8Family:
+		d, err = ParseDMacAddr8(s)
 	case types.IntFamily:
 		d, err = ParseDInt(strings.TrimSpace(s))
 	case types.IntervalFamily:
@@ -77,6 +83,15 @@ func ParseAndRequireString(
 		d, err = ParseDGeometry(s)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
+	case types.PointFamily, types.LSegFamily, types.BoxFamily, types.PathFamily,
+		types.PolygonFamily, types.LineFamily, types.CircleFamily:
+		d, err = ParseDGeometric(t, s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultiRangeFamily:
+		d, dependsOnContext, err = ParseDMultiRangeFromString(ctx, s, t)
 	case types.OidFamily:
 		if t.Oid() != oid.T_oid && s == ZeroOidValue {
 			d = WrapAsZeroOid(t)
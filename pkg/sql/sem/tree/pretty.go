@@ -721,6 +721,9 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.TableSample != nil {
+		d = p.nestUnder(d, p.Doc(node.TableSample))
+	}
 	return d
 }
 
@@ -1628,7 +1631,7 @@ func (node *ShardedIndexDef) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	// CREATE [UNIQUE] [INVERTED] INDEX [name]
-	//    ON tbl (cols...)
+	//    ON tbl [USING brin] (cols...)
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
@@ -1656,10 +1659,12 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	}
 
 	clauses := make([]pretty.Doc, 0, 7)
-	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace,
-		pretty.Keyword("ON"),
-		p.Doc(&node.Table),
-		p.bracket("(", p.Doc(&node.Columns), ")")))
+	on := []pretty.Doc{pretty.Keyword("ON"), p.Doc(&node.Table)}
+	if node.BRIN {
+		on = append(on, pretty.Keyword("USING"), pretty.Text("brin"))
+	}
+	on = append(on, p.bracket("(", p.Doc(&node.Columns), ")"))
+	clauses = append(clauses, pretty.Fold(pretty.ConcatSpace, on...))
 
 	if node.Sharded != nil {
 		clauses = append(clauses, p.Doc(node.Sharded))
@@ -1821,6 +1826,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if d := p.Doc(&node.Deferrability); d != pretty.Nil {
+		clauses = append(clauses, d)
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1884,6 +1892,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if d := p.Doc(&node.Deferrability); d != pretty.Nil {
+		clauses = append(clauses, d)
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2070,6 +2082,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if d := p.Doc(&node.References.Deferrability); d != pretty.Nil {
+			fkDetails = append(fkDetails, d)
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -2147,6 +2162,16 @@ func (node *ReferenceActions) doc(p *PrettyCfg) pretty.Doc {
 	return pretty.Fold(pretty.ConcatSpace, docs...)
 }
 
+func (node *ConstraintDeferrability) doc(p *PrettyCfg) pretty.Doc {
+	if !node.Deferrable {
+		return pretty.Nil
+	}
+	if node.InitiallyDeferred {
+		return pretty.Keyword("DEFERRABLE INITIALLY DEFERRED")
+	}
+	return pretty.Keyword("DEFERRABLE")
+}
+
 func (node *Backup) doc(p *PrettyCfg) pretty.Doc {
 	items := make([]pretty.TableRow, 0, 7)
 
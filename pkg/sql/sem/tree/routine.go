@@ -13,6 +13,7 @@ package tree
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
@@ -123,6 +124,26 @@ type RoutineExpr struct {
 	// ExceptionHandler holds the information needed to handle errors if an
 	// exception block was defined.
 	ExceptionHandler *RoutineExceptionHandler
+
+	// CursorDeclaration contains the information needed to open a SQL cursor
+	// with the result of the *first* body statement. It may be unset.
+	CursorDeclaration *RoutineOpenCursor
+
+	// ResultBufferID, if non-zero, indicates that the routine is a
+	// set-returning PL/pgSQL function. Its result rows are collected by RETURN
+	// NEXT and RETURN QUERY statements in a buffer with this ID, rather than
+	// produced by the last body statement.
+	ResultBufferID RoutineResultBufferID
+
+	// ReturnRows, if non-zero, indicates that the rows produced by the *first*
+	// body statement are added to the result buffer with this ID. It is used to
+	// implement the RETURN NEXT and RETURN QUERY statements of set-returning
+	// PL/pgSQL functions.
+	ReturnRows RoutineResultBufferID
+
+	// SessionOverrides, if non-nil, contains changes to the session that are
+	// applied for the duration of the routine's execution.
+	SessionOverrides *RoutineSessionOverrides
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -137,6 +158,10 @@ func NewTypedRoutineExpr(
 	generator bool,
 	tailCall bool,
 	exceptionHandler *RoutineExceptionHandler,
+	cursorDeclaration *RoutineOpenCursor,
+	resultBufferID RoutineResultBufferID,
+	returnRows RoutineResultBufferID,
+	sessionOverrides *RoutineSessionOverrides,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -149,6 +174,10 @@ func NewTypedRoutineExpr(
 		Generator:         generator,
 		TailCall:          tailCall,
 		ExceptionHandler:  exceptionHandler,
+		CursorDeclaration: cursorDeclaration,
+		ResultBufferID:    resultBufferID,
+		ReturnRows:        returnRows,
+		SessionOverrides:  sessionOverrides,
 	}
 }
 
@@ -186,3 +215,121 @@ type RoutineExceptionHandler struct {
 	// Actions contains a routine to handle each error code.
 	Actions []*RoutineExpr
 }
+
+// RoutineOpenCursor stores the information needed to correctly open a cursor
+// with the output of a routine.
+type RoutineOpenCursor struct {
+	// NameArgIdx is the index of the routine argument that contains the name of
+	// the cursor that will be created.
+	NameArgIdx int
+
+	// Scroll is the scroll option for the cursor, if one was specified. The other
+	// cursor options are not valid in PLpgSQL.
+	Scroll CursorScrollOption
+
+	// CursorSQL is a formatted string used to associate the original SQL
+	// statement with the cursor.
+	CursorSQL string
+}
+
+// RoutineSessionOverrides contains changes to the session that are applied
+// for the duration of a routine's execution, as specified by the SECURITY and
+// SET clauses of the routine's definition.
+type RoutineSessionOverrides struct {
+	// User, if set, is the user with whose privileges the routine executes. It
+	// is the owner of a SECURITY DEFINER routine.
+	User username.SQLUsername
+
+	// Settings contains the session variables that are set for the duration of
+	// the routine's execution, each in the form "name=value".
+	Settings []string
+}
+
+// RoutineResultBufferID identifies the buffer that collects the result rows of
+// a set-returning PL/pgSQL routine.
+type RoutineResultBufferID uint64
+
+// UserDefinedAggregate is a TypedExpr that describes an invocation of a
+// user-defined aggregate function. It is never evaluated directly; instead, it
+// is passed to an aggregator which evaluates the support routines as rows are
+// accumulated.
+type UserDefinedAggregate struct {
+	// Name is the name of the aggregate function.
+	Name string
+
+	// StateFunc is the routine that computes the next state of the aggregate
+	// given the current state and the arguments of an input row. It is built
+	// without arguments; they are supplied when it is evaluated.
+	StateFunc *RoutineExpr
+
+	// FinalFunc, if non-nil, is the routine that computes the result of the
+	// aggregate from its final state. If it is nil, the final state is the
+	// result.
+	FinalFunc *RoutineExpr
+
+	// CombineFunc, if non-nil, is the routine that combines two partial states
+	// of the aggregate. It allows the aggregation to be split into local and
+	// final stages.
+	CombineFunc *RoutineExpr
+
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+
+	// Typ is the result type of the aggregate.
+	Typ *types.T
+
+	// InitCond is the initial state of the aggregate. It is DNull if no
+	// initial condition was specified.
+	InitCond Datum
+
+	// NumArgs is the number of arguments of the aggregate. If it is greater
+	// than one, the arguments are packed into a single tuple.
+	NumArgs int
+}
+
+// LocalStage returns an aggregate that computes the partial state of this
+// aggregate, for use in the local stage of a multi-stage aggregation.
+func (node *UserDefinedAggregate) LocalStage() *UserDefinedAggregate {
+	local := *node
+	local.FinalFunc = nil
+	local.Typ = node.StateType
+	return &local
+}
+
+// FinalStage returns an aggregate that combines the partial states produced
+// by LocalStage and computes the result of this aggregate, for use in the
+// final stage of a multi-stage aggregation. It must only be called if
+// CombineFunc is non-nil.
+func (node *UserDefinedAggregate) FinalStage() *UserDefinedAggregate {
+	final := *node
+	final.StateFunc = node.CombineFunc
+	final.CombineFunc = nil
+	final.InitCond = DNull
+	final.NumArgs = 1
+	return &final
+}
+
+// TypeCheck is part of the Expr interface.
+func (node *UserDefinedAggregate) TypeCheck(
+	ctx context.Context, semaCtx *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return node, nil
+}
+
+// ResolvedType is part of the TypedExpr interface.
+func (node *UserDefinedAggregate) ResolvedType() *types.T {
+	return node.Typ
+}
+
+// Format is part of the Expr interface.
+func (node *UserDefinedAggregate) Format(ctx *FmtCtx) {
+	ctx.Printf("%s(...)", node.Name)
+}
+
+func (node *UserDefinedAggregate) String() string { return AsString(node) }
+
+// Walk is part of the Expr interface.
+func (node *UserDefinedAggregate) Walk(v Visitor) Expr {
+	// Cannot walk into the support routines, so this is a no-op.
+	return node
+}
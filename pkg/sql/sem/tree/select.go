@@ -568,11 +568,12 @@ func (ih *IndexFlags) zigzagForced() bool {
 // AliasedTableExpr represents a table expression coupled with an optional
 // alias.
 type AliasedTableExpr struct {
-	Expr       TableExpr
-	IndexFlags *IndexFlags
-	Ordinality bool
-	Lateral    bool
-	As         AliasClause
+	Expr        TableExpr
+	IndexFlags  *IndexFlags
+	Ordinality  bool
+	Lateral     bool
+	As          AliasClause
+	TableSample *TableSample
 }
 
 // Format implements the NodeFormatter interface.
@@ -591,6 +592,65 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(&node.As)
 	}
+	if node.TableSample != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.TableSample)
+	}
+}
+
+// TableSampleMethod is the sampling method of a TABLESAMPLE clause.
+type TableSampleMethod uint8
+
+const (
+	// TableSampleBernoulli selects each row of the table independently.
+	TableSampleBernoulli TableSampleMethod = iota + 1
+	// TableSampleSystem selects whole blocks of rows of the table.
+	TableSampleSystem
+)
+
+var tableSampleMethodName = [...]string{
+	TableSampleBernoulli: "BERNOULLI",
+	TableSampleSystem:    "SYSTEM",
+}
+
+func (m TableSampleMethod) String() string {
+	return tableSampleMethodName[m]
+}
+
+// TableSampleMethodFromName returns the TableSampleMethod with the given
+// (normalized) name.
+func TableSampleMethodFromName(name string) (TableSampleMethod, bool) {
+	switch name {
+	case "bernoulli":
+		return TableSampleBernoulli, true
+	case "system":
+		return TableSampleSystem, true
+	}
+	return 0, false
+}
+
+// TableSample represents a TABLESAMPLE clause, as in
+// "t TABLESAMPLE BERNOULLI (10) REPEATABLE (42)".
+type TableSample struct {
+	Method TableSampleMethod
+	// Percent is the percentage of the table to sample.
+	Percent Expr
+	// Seed is the argument of the REPEATABLE clause, or nil if there is none.
+	Seed Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TableSample) Format(ctx *FmtCtx) {
+	ctx.WriteString("TABLESAMPLE ")
+	ctx.WriteString(node.Method.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Percent)
+	ctx.WriteByte(')')
+	if node.Seed != nil {
+		ctx.WriteString(" REPEATABLE (")
+		ctx.FormatNode(node.Seed)
+		ctx.WriteByte(')')
+	}
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -758,6 +818,42 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType is the type of a GroupingSet.
+type GroupingSetType uint8
+
+const (
+	// RollupGroupingSet groups by each prefix of its elements, from all of
+	// them down to none of them.
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet groups by each subset of its elements.
+	CubeGroupingSet
+	// ExplicitGroupingSets groups by each of its elements.
+	ExplicitGroupingSets
+)
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each element is an expression or a parenthesized list of
+// expressions that are grouped on together. The elements of GROUPING SETS
+// can also be nested GroupingSets.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	switch node.Type {
+	case RollupGroupingSet:
+		ctx.WriteString("ROLLUP(")
+	case CubeGroupingSet:
+		ctx.WriteString("CUBE(")
+	case ExplicitGroupingSets:
+		ctx.WriteString("GROUPING SETS (")
+	}
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
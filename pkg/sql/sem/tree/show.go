@@ -1224,6 +1224,11 @@ const (
 	// ScheduledChangefeedExecutor is an executor responsible for
 	// the execution of the scheduled changefeeds.
 	ScheduledChangefeedExecutor
+
+	// ScheduledRollingPartitionExecutor is an executor responsible for
+	// maintaining the window of range partitions on tables with rolling
+	// partitioning.
+	ScheduledRollingPartitionExecutor
 )
 
 var scheduleExecutorInternalNames = map[ScheduledJobExecutorType]string{
@@ -1233,6 +1238,7 @@ var scheduleExecutorInternalNames = map[ScheduledJobExecutorType]string{
 	ScheduledRowLevelTTLExecutor:        "scheduled-row-level-ttl-executor",
 	ScheduledSchemaTelemetryExecutor:    "scheduled-schema-telemetry-executor",
 	ScheduledChangefeedExecutor:         "scheduled-changefeed-executor",
+	ScheduledRollingPartitionExecutor:   "scheduled-rolling-partition-executor",
 }
 
 // InternalName returns an internal executor name.
@@ -1254,6 +1260,8 @@ func (t ScheduledJobExecutorType) UserName() string {
 		return "SCHEMA TELEMETRY"
 	case ScheduledChangefeedExecutor:
 		return "CHANGEFEED"
+	case ScheduledRollingPartitionExecutor:
+		return "ROLLING PARTITION"
 	}
 	return "unsupported-executor"
 }
@@ -76,9 +76,40 @@ func SampleDatum(t *types.T) Datum {
 	case types.INetFamily:
 		i, _ := ParseDIPAddrFromINetString("127.0.0.1")
 		return i
+	case types.CIDRFamily:
+		c, _ := ParseDCIDR("192.168.0.0/16")
+		return c
+	case types.MacAddrFamily:
+		m, _ := ParseDMacAddr("08:00:2b:01:02:03")
+		return m
+	case types.MacAddr8Family:
+		m, _ := ParseDMacAddr8("08:00:2b:01:02:03:04:05")
+		return m
 	case types.JsonFamily:
 		j, _ := ParseDJSON(`{"a": "b"}`)
 		return j
+	case types.JsonpathFamily:
+		p, _ := ParseDJsonpath(`$.a`)
+		return p
+	case types.PointFamily:
+		g, _ := ParseDGeometric(t, "(1,2)")
+		return g
+	case types.LSegFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily:
+		g, _ := ParseDGeometric(t, "(1,2),(3,4)")
+		return g
+	case types.LineFamily:
+		g, _ := ParseDGeometric(t, "{1,-1,0}")
+		return g
+	case types.CircleFamily:
+		g, _ := ParseDGeometric(t, "<(1,2),3>")
+		return g
+	case types.RangeFamily:
+		r, _ := NewDRange(t, RangeBound{Val: SampleDatum(t.RangeContents()), Inclusive: true}, RangeBound{})
+		return r
+	case types.MultiRangeFamily:
+		r := SampleDatum(t.MultiRangeContents()).(*DRange)
+		m, _ := NewDMultiRange(t, []*DRange{r})
+		return m
 	case types.OidFamily:
 		return NewDOid(1009)
 	case types.PGLSNFamily:
@@ -953,12 +953,21 @@ var (
 	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
 )
 
+var errInvalidGroupingSetUsage = pgerror.New(pgcode.Syntax,
+	"ROLLUP, CUBE and GROUPING SETS can only appear in a GROUP BY clause")
+
 // NewAggInAggError creates an error for the case when an aggregate function is
 // contained within another aggregate function.
 func NewAggInAggError() error {
 	return pgerror.Newf(pgcode.Grouping, "aggregate function calls cannot be nested")
 }
 
+// NewInvalidGroupingOperationError creates a rejection for a GROUPING
+// expression.
+func NewInvalidGroupingOperationError(context string) error {
+	return pgerror.Newf(pgcode.Grouping, "grouping operations are not allowed in %s", context)
+}
+
 // NewInvalidNestedSRFError creates a rejection for a nested SRF.
 func NewInvalidNestedSRFError(context string) error {
 	return pgerror.Newf(pgcode.FeatureNotSupported,
@@ -1240,6 +1249,20 @@ func (expr *FuncExpr) TypeCheck(
 			return nil, err
 		}
 	}
+	if overloadImpl.IsProcedure && !expr.InCall {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is a procedure",
+				getFuncSig(expr, s.typedExprs, types.Any)),
+			"To call a procedure, use CALL.",
+		)
+	}
+	if !overloadImpl.IsProcedure && expr.InCall {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is not a procedure",
+				getFuncSig(expr, s.typedExprs, types.Any)),
+			"To call a function, use SELECT.",
+		)
+	}
 
 	if expr.IsWindowFunctionApplication() {
 		// Make sure the window function application is of either a built-in window
@@ -1626,6 +1649,26 @@ func (expr *UnaryExpr) TypeCheck(
 	return expr, nil
 }
 
+// TypeCheck implements the Expr interface. GROUPING is only valid in the
+// SELECT list, HAVING and ORDER BY of a query with a GROUP BY clause, where it
+// is replaced when the query is planned.
+func (expr *GroupingFuncExpr) TypeCheck(
+	_ context.Context, semaCtx *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	context := "this context"
+	if semaCtx != nil && semaCtx.Properties.required.context != "" {
+		context = semaCtx.Properties.required.context
+	}
+	return nil, NewInvalidGroupingOperationError(context)
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingSetUsage
+}
+
 // TypeCheck implements the Expr interface.
 func (expr DefaultVal) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -1885,6 +1928,42 @@ func (d *DIPAddr) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Type
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DCIDR) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMacAddr) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMacAddr8) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DGeometric) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultiRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DDate) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -1951,6 +2030,12 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJsonpath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
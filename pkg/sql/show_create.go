@@ -144,9 +144,17 @@ func ShowCreateTable(
 			f.WriteString(fkCtx.String())
 		}
 	}
+	var brinIndexes []catalog.Index
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
 
+		// BRIN indexes can't be defined inside CREATE TABLE, so they are shown
+		// as separate CREATE INDEX statements below.
+		if idx.GetType() == descpb.IndexDescriptor_BRIN {
+			brinIndexes = append(brinIndexes, idx)
+			continue
+		}
+
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
 		if err := ShowCreatePartitioning(
@@ -197,6 +205,25 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	for _, idx := range brinIndexes {
+		idxStr, err := catformat.IndexForDisplay(
+			ctx,
+			desc,
+			tn,
+			idx,
+			"", /* partition */
+			fmtFlags,
+			p.RunParams(ctx).p.SemaCtx(),
+			p.RunParams(ctx).p.SessionData(),
+			catformat.IndexDisplayShowCreate,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(";\n")
+		f.WriteString(idxStr)
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
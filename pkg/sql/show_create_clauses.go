@@ -492,6 +492,12 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+		if fk.InitiallyDeferred {
+			buf.WriteString(" INITIALLY DEFERRED")
+		}
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -753,6 +759,12 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
+		if c.IsExclusion() {
+			if err := showExclusionConstraint(ctx, f, desc, c, semaCtx, sessionData, exprFmtFlags); err != nil {
+				return err
+			}
+			continue
+		}
 		f.WriteString("UNIQUE WITHOUT INDEX (")
 		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
 		if err != nil {
@@ -760,6 +772,10 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		f.FormatNode(&tree.ConstraintDeferrability{
+			Deferrable:        c.UniqueWithoutIndexDesc().Deferrable,
+			InitiallyDeferred: c.UniqueWithoutIndexDesc().InitiallyDeferred,
+		})
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -777,3 +793,51 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// showExclusionConstraint adds the EXCLUDE clause of an exclusion constraint,
+// without its name, to f.
+func showExclusionConstraint(
+	ctx context.Context,
+	f *tree.FmtCtx,
+	desc catalog.TableDescriptor,
+	c catalog.UniqueWithoutIndexConstraint,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	exprFmtFlags tree.FmtFlags,
+) error {
+	f.WriteString("EXCLUDE ")
+	if m := c.UniqueWithoutIndexDesc().ExclusionAccessMethod; m != "" {
+		f.WriteString("USING ")
+		f.WriteString(m)
+		f.WriteString(" ")
+	}
+	f.WriteString("(")
+	colNames, err := catalog.ColumnNamesForIDs(desc, c.UniqueWithoutIndexDesc().ColumnIDs)
+	if err != nil {
+		return err
+	}
+	ops := c.ExclusionOperators()
+	for i := range colNames {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		f.WriteString(colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(ops[i])
+	}
+	f.WriteString(")")
+	if c.IsPartial() {
+		f.WriteString(" WHERE ")
+		pred, err := schemaexpr.FormatExprForDisplay(
+			ctx, desc, c.GetPredicate(), semaCtx, sessionData, exprFmtFlags,
+		)
+		if err != nil {
+			return err
+		}
+		f.WriteString(pred)
+	}
+	if !c.IsConstraintValidated() {
+		f.WriteString(" NOT VALID")
+	}
+	return nil
+}
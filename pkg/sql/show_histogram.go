@@ -74,6 +74,10 @@ func (p *planner) ShowHistogram(ctx context.Context, n *tree.ShowHistogram) (pla
 			if err := protoutil.Unmarshal([]byte(histData), histogram); err != nil {
 				return nil, err
 			}
+			if len(histogram.Dependencies) > 0 {
+				// The statistic has functional dependencies instead of a histogram.
+				return nil, fmt.Errorf("histogram %d not found", n.HistogramID)
+			}
 
 			v := p.newContainerValuesNode(showHistogramColumns, 0)
 			resolver := descs.NewDistSQLTypeResolver(p.descCollection, p.InternalSQLTxn().KV())
@@ -208,7 +208,8 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 						return nil, err
 					}
 					obs := &stats.TableStatistic{TableStatisticProto: *stat}
-					if obs.HistogramData != nil && !obs.HistogramData.ColumnType.UserDefined() {
+					if obs.HistogramData != nil && obs.HistogramData.ColumnType != nil &&
+						!obs.HistogramData.ColumnType.UserDefined() {
 						if err := stats.DecodeHistogramBuckets(obs); err != nil {
 							return nil, err
 						}
@@ -12,18 +12,25 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -34,17 +41,13 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	if s.Binary {
 		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
 	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
-	}
 
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
-			if p.extendedEvalCtx.TxnImplicit {
-				if s.Hold {
-					return nil, unimplemented.NewWithIssue(77101, "DECLARE CURSOR WITH HOLD can only be used in transaction blocks")
-				}
+			// Cursors WITH HOLD are materialized when the implicit transaction
+			// commits, so only they may be declared outside a transaction block.
+			if p.extendedEvalCtx.TxnImplicit && !s.Hold {
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
@@ -109,6 +112,27 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				statement:  statement,
 				created:    timeutil.Now(),
 				withHold:   s.Hold,
+				scroll:     s.Scroll == tree.Scroll,
+			}
+			if cursor.scroll || cursor.withHold {
+				// Scrollable cursors buffer the rows they read so that they can
+				// move backward, and cursors WITH HOLD buffer their remaining rows
+				// when the transaction commits. The buffer outlives the monitor of
+				// the transaction, so use the session's monitor if possible.
+				resultCols := pt.main.planColumns()
+				colTypes := make([]*types.T, len(resultCols))
+				for i := range resultCols {
+					colTypes[i] = resultCols[i].Typ
+				}
+				parentMon := p.Mon()
+				if p.sessionMon != nil {
+					parentMon = p.sessionMon
+				}
+				cursor.buffer = &cursorBuffer{}
+				cursor.buffer.init(ctx, colTypes, parentMon, p.ExtendedEvalContext())
+				if cursor.scroll {
+					cursor.bufferStart = 1
+				}
 			}
 			if err := p.sqlCursors.addCursor(s.Name, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
@@ -122,7 +146,10 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	}, nil
 }
 
-var errBackwardScan = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward")
+var errBackwardScan = errors.WithHint(
+	pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward"),
+	"Declare it with SCROLL option to enable backward scan.",
+)
 
 // FetchCursor implements the FETCH and MOVE statements.
 // See https://www.postgresql.org/docs/current/sql-fetch.html for details.
@@ -135,7 +162,7 @@ func (p *planner) FetchCursor(
 			pgcode.InvalidCursorName, "cursor %q does not exist", s.Name,
 		)
 	}
-	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
+	if !cursor.scroll && (s.Count < 0 || s.FetchType == tree.FetchBackwardAll || s.FetchType == tree.FetchLast) {
 		return nil, errBackwardScan
 	}
 	node := &fetchNode{
@@ -153,9 +180,10 @@ func (p *planner) FetchCursor(
 
 type fetchNode struct {
 	cursor *sqlCursor
-	// n is the number of rows requested.
+	// n is the number of rows requested. It is negative if the rows are
+	// fetched backward.
 	n int64
-	// offset is the number of rows to read first, when in relative or absolute
+	// offset is the position to move to first, when in relative or absolute
 	// mode.
 	offset    int64
 	fetchType tree.FetchType
@@ -172,6 +200,10 @@ type fetchNode struct {
 }
 
 func (f *fetchNode) startExec(params runParams) error {
+	if f.cursor.txn == nil {
+		// The cursor was materialized when its transaction committed.
+		return nil
+	}
 	// We need to make sure that we're reading at the same read sequence number
 	// that we had when we created the cursor, to preserve the "sensitivity"
 	// semantics of cursors, which demand that data written after the cursor
@@ -181,52 +213,41 @@ func (f *fetchNode) startExec(params runParams) error {
 }
 
 func (f *fetchNode) Next(params runParams) (bool, error) {
-	if f.fetchType == tree.FetchAll {
-		return f.cursor.Next(params.ctx)
-	}
-
+	ctx := params.ctx
 	if !f.seeked {
-		// FIRST, LAST, ABSOLUTE, and RELATIVE require seeking before returning
-		// values. Do that first.
+		// FIRST, LAST, ABSOLUTE, and RELATIVE move the cursor to a single row
+		// and return it.
 		f.seeked = true
 		switch f.fetchType {
 		case tree.FetchFirst:
-			switch f.cursor.curRow {
-			case 0:
-				_, err := f.cursor.Next(params.ctx)
-				return true, err
-			case 1:
-				return true, nil
-			}
-			return false, errBackwardScan
+			return f.cursor.seek(ctx, 1)
 		case tree.FetchLast:
-			return false, errBackwardScan
+			return f.cursor.seekFromEnd(ctx, 1)
 		case tree.FetchAbsolute:
-			if f.cursor.curRow > f.offset {
-				return false, errBackwardScan
+			if f.offset < 0 {
+				return f.cursor.seekFromEnd(ctx, -f.offset)
 			}
-			for f.cursor.curRow < f.offset {
-				more, err := f.cursor.Next(params.ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
+			return f.cursor.seek(ctx, f.offset)
 		case tree.FetchRelative:
-			for i := int64(0); i < f.offset; i++ {
-				more, err := f.cursor.Next(params.ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
+			return f.cursor.seek(ctx, f.cursor.curRow+f.offset)
 		}
 	}
-	if f.n <= 0 {
-		return false, nil
+	switch f.fetchType {
+	case tree.FetchAll:
+		return f.cursor.seek(ctx, f.cursor.curRow+1)
+	case tree.FetchBackwardAll:
+		return f.cursor.seek(ctx, f.cursor.curRow-1)
+	case tree.FetchNormal:
+		switch {
+		case f.n > 0:
+			f.n--
+			return f.cursor.seek(ctx, f.cursor.curRow+1)
+		case f.n < 0:
+			f.n++
+			return f.cursor.seek(ctx, f.cursor.curRow-1)
+		}
 	}
-	f.n--
-	return f.cursor.Next(params.ctx)
+	return false, nil
 }
 
 func (f fetchNode) Values() tree.Datums {
@@ -237,6 +258,9 @@ func (f fetchNode) Close(ctx context.Context) {
 	// We explicitly do not pass through the Close to our Rows, because
 	// running FETCH on a CURSOR does not close it.
 
+	if f.cursor.txn == nil {
+		return
+	}
 	// Reset the transaction's read sequence number to what it was before the
 	// fetch began, so that subsequent reads in the transaction can still see
 	// writes from that transaction.
@@ -252,42 +276,310 @@ func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNod
 		name: n.String(),
 		constructor: func(ctx context.Context, p *planner) (planNode, error) {
 			if n.All {
-				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(false /* errorOnWithHold */)
+				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(true /* closeHeld */)
 			}
 			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(n.Name)
 		},
 	}, nil
 }
 
+// PLpgSQLCloseCursor is part of the eval.Planner interface.
+func (p *planner) PLpgSQLCloseCursor(name tree.Name) error {
+	return p.sqlCursors.closeCursor(name)
+}
+
+// PLpgSQLFetchCursor is part of the eval.Planner interface.
+func (p *planner) PLpgSQLFetchCursor(
+	ctx context.Context, cursorStmt *tree.CursorStmt,
+) (res tree.Datums, err error) {
+	// Only a single row can be assigned to the FETCH target, so only the last
+	// row is kept. MOVE is handled identically, except that the row is unused.
+	n, err := p.FetchCursor(ctx, cursorStmt, false /* isMove */)
+	if err != nil {
+		return nil, err
+	}
+	fetch := n.(*fetchNode)
+	if err = fetch.startExec(p.RunParams(ctx)); err != nil {
+		return nil, err
+	}
+	defer fetch.Close(ctx)
+	for {
+		more, err := fetch.Next(p.RunParams(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			break
+		}
+		res = append(res[:0], fetch.Values()...)
+	}
+	return res, nil
+}
+
+// GenUniqueCursorName is part of the eval.Planner interface.
+func (p *planner) GenUniqueCursorName() tree.Name {
+	// Postgres uses the same format for the names of unnamed portals that are
+	// opened by PL/pgSQL.
+	for i := 1; ; i++ {
+		name := tree.Name(fmt.Sprintf("<unnamed portal %d>", i))
+		if p.sqlCursors.getCursor(name) != nil {
+			continue
+		}
+		if p.extendedEvalCtx.PreparedStatementState.HasPortal(string(name)) {
+			continue
+		}
+		return name
+	}
+}
+
 type sqlCursor struct {
 	isql.Rows
 	// txn is the transaction object that the internal executor for this cursor
-	// is running with.
+	// is running with. It is nil once a cursor WITH HOLD has been materialized
+	// because its transaction committed.
 	txn *kv.Txn
 	// readSeqNum is the sequence number of the transaction that the cursor was
 	// initialized with.
 	readSeqNum enginepb.TxnSeq
 	statement  string
 	created    time.Time
-	curRow     int64
-	withHold   bool
+	// curRow is the position of the cursor. It is 0 before the first row, i
+	// when the cursor is on the i-th row, and one past the number of rows once
+	// the cursor has moved past the last row.
+	curRow   int64
+	withHold bool
+	// holdPending is true once a cursor WITH HOLD has been materialized and
+	// until the transaction that created it commits. The cursor is closed if
+	// the commit fails or the transaction is restarted instead.
+	holdPending bool
+	// scroll is true if the cursor was declared SCROLL, in which case it can
+	// also move backward.
+	scroll bool
+
+	// readRows is the number of rows that were read from Rows, and lastRead is
+	// the last of them.
+	readRows int64
+	lastRead tree.Datums
+	// exhausted is true once all rows have been read from Rows.
+	exhausted bool
+	// cur is the row the cursor is on.
+	cur tree.Datums
+
+	// buffer contains the rows that were read by a scrollable cursor, and the
+	// rows of a cursor WITH HOLD that were materialized when its transaction
+	// committed. It is nil for other cursors.
+	buffer *cursorBuffer
+	// bufferStart is the position of the first row in buffer, or zero if rows
+	// are not buffered (yet).
+	bufferStart int64
+	// resultCols are the columns of the rows of a cursor WITH HOLD, which are
+	// retained once Rows is closed.
+	resultCols colinfo.ResultColumns
 }
 
 // Next implements the Rows interface.
 func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
+	return s.seek(ctx, s.curRow+1)
+}
+
+// Cur implements the Rows interface.
+func (s *sqlCursor) Cur() tree.Datums {
+	return s.cur
+}
+
+// Types implements the Rows interface.
+func (s *sqlCursor) Types() colinfo.ResultColumns {
+	if s.Rows == nil {
+		return s.resultCols
+	}
+	return s.Rows.Types()
+}
+
+// Close implements the Rows interface.
+func (s *sqlCursor) Close() error {
+	var err error
+	if s.Rows != nil {
+		err = s.Rows.Close()
+		s.Rows = nil
+	}
+	if s.buffer != nil {
+		// The cursor outlives the context of the statement that declared it.
+		s.buffer.close(context.Background())
+		s.buffer = nil
+	}
+	return err
+}
+
+// held returns whether the cursor was created WITH HOLD and has outlived the
+// transaction that created it.
+func (s *sqlCursor) held() bool {
+	return s.txn == nil
+}
+
+// seek moves the cursor to the given position, and returns whether there is a
+// row at that position. Moving before the first row or past the last row
+// leaves the cursor just before the first or just after the last row.
+func (s *sqlCursor) seek(ctx context.Context, pos int64) (bool, error) {
+	if pos < s.curRow && !s.scroll {
+		return false, errBackwardScan
+	}
+	s.cur = nil
+	if pos <= 0 {
+		s.curRow = 0
+		return false, nil
+	}
+	for s.readRows < pos && !s.exhausted {
+		if err := s.readRow(ctx); err != nil {
+			return false, err
+		}
+	}
+	if pos > s.readRows {
+		s.curRow = s.readRows + 1
+		return false, nil
+	}
+	s.curRow = pos
+	if pos == s.readRows && s.lastRead != nil {
+		s.cur = s.lastRead
+		return true, nil
+	}
+	if s.bufferStart == 0 || pos < s.bufferStart {
+		return false, errors.AssertionFailedf("row %d of cursor is not buffered", pos)
+	}
+	row, err := s.buffer.getRow(ctx, int(pos-s.bufferStart))
+	if err != nil {
+		return false, err
+	}
+	s.cur = row
+	return true, nil
+}
+
+// seekFromEnd moves the cursor to the n-th row from the end, where the last
+// row is 1, and returns whether there is a row at that position.
+func (s *sqlCursor) seekFromEnd(ctx context.Context, n int64) (bool, error) {
+	for !s.exhausted {
+		if err := s.readRow(ctx); err != nil {
+			return false, err
+		}
+	}
+	pos := s.readRows + 1 - n
+	if pos < 0 {
+		pos = 0
+	}
+	return s.seek(ctx, pos)
+}
+
+// readRow reads the next row from Rows, buffering it if necessary.
+func (s *sqlCursor) readRow(ctx context.Context) error {
 	more, err := s.Rows.Next(ctx)
-	if err == nil {
-		s.curRow++
+	if err != nil {
+		return err
+	}
+	if !more {
+		s.exhausted = true
+		s.lastRead = nil
+		return nil
+	}
+	s.readRows++
+	s.lastRead = s.Rows.Cur()
+	if s.bufferStart != 0 {
+		return s.buffer.addRow(ctx, s.lastRead)
+	}
+	return nil
+}
+
+// materialize reads the remaining rows of a cursor WITH HOLD into its buffer
+// and closes its query, so that the cursor can outlive its transaction.
+func (s *sqlCursor) materialize(ctx context.Context) (retErr error) {
+	txn := s.txn
+	origTxnSeqNum := txn.GetReadSeqNum()
+	if err := txn.SetReadSeqNum(s.readSeqNum); err != nil {
+		return err
+	}
+	defer func() {
+		if err := txn.SetReadSeqNum(origTxnSeqNum); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	if s.bufferStart == 0 {
+		// Rows before the current one can't be fetched by a cursor that can
+		// only scan forward, so only buffer the current row and the rows
+		// after it.
+		s.bufferStart = s.readRows + 1
+		if s.lastRead != nil && s.curRow == s.readRows {
+			s.bufferStart = s.curRow
+			if err := s.buffer.addRow(ctx, s.lastRead); err != nil {
+				return err
+			}
+		}
+	}
+	for !s.exhausted {
+		if err := s.readRow(ctx); err != nil {
+			return err
+		}
+	}
+	s.resultCols = s.Rows.Types()
+	if err := s.Rows.Close(); err != nil {
+		return err
+	}
+	s.Rows = nil
+	s.txn = nil
+	return nil
+}
+
+// cursorBuffer is a disk-backed container of the rows of a cursor, which
+// allows them to be fetched by their position.
+type cursorBuffer struct {
+	memMonitor  *mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+	rows        *rowcontainer.DiskBackedIndexedRowContainer
+	scratch     rowenc.EncDatumRow
+}
+
+func (b *cursorBuffer) init(
+	ctx context.Context,
+	typs []*types.T,
+	parent *mon.BytesMonitor,
+	evalContext *extendedEvalContext,
+) {
+	distSQLCfg := &evalContext.DistSQLPlanner.distSQLSrv.ServerConfig
+	b.memMonitor = execinfra.NewLimitedMonitorNoFlowCtx(
+		ctx, parent, distSQLCfg, evalContext.SessionData(), "sql-cursor-limited",
+	)
+	b.diskMonitor = execinfra.NewMonitor(ctx, distSQLCfg.ParentDiskMonitor, "sql-cursor-disk")
+	b.rows = rowcontainer.NewDiskBackedIndexedRowContainer(
+		colinfo.NoOrdering, typs, &evalContext.Context,
+		distSQLCfg.TempStorage, b.memMonitor, b.diskMonitor,
+	)
+	b.scratch = make(rowenc.EncDatumRow, len(typs))
+}
+
+func (b *cursorBuffer) addRow(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		b.scratch[i].Datum = row[i]
+	}
+	return b.rows.AddRow(ctx, b.scratch)
+}
+
+func (b *cursorBuffer) getRow(ctx context.Context, idx int) (tree.Datums, error) {
+	row, err := b.rows.GetRow(ctx, idx)
+	if err != nil {
+		return nil, err
 	}
-	return more, err
+	return row.GetDatums(0, len(b.scratch))
+}
+
+func (b *cursorBuffer) close(ctx context.Context) {
+	b.rows.Close(ctx)
+	b.memMonitor.Stop(ctx)
+	b.diskMonitor.Stop(ctx)
 }
 
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
-	// closeAll closes all cursors in the set. If any of the cursors were
-	// created WITH HOLD, and the errorOnWithHold flag is true, an error is
-	// returned.
-	closeAll(errorOnWithHold bool) error
+	// closeAll closes all cursors in the set. Cursors that were created WITH
+	// HOLD and have outlived their transaction are only closed if closeHeld is
+	// true.
+	closeAll(closeHeld bool) error
 	// closeCursor closes the named cursor, returning an error if that cursor
 	// didn't exist in the set.
 	closeCursor(tree.Name) error
@@ -332,16 +624,60 @@ type cursorMap struct {
 	cursors map[tree.Name]*sqlCursor
 }
 
-func (c *cursorMap) closeAll(errorOnWithHold bool) error {
-	for n, c := range c.cursors {
-		if c.withHold && errorOnWithHold {
-			return unimplemented.NewWithIssuef(77101, "cursor %s WITH HOLD must be closed before committing", n)
+func (c *cursorMap) closeAll(closeHeld bool) error {
+	for n, cursor := range c.cursors {
+		if cursor.held() && !closeHeld {
+			continue
 		}
-		if err := c.Close(); err != nil {
+		delete(c.cursors, n)
+		if err := cursor.Close(); err != nil {
 			return err
 		}
 	}
-	c.cursors = nil
+	if len(c.cursors) == 0 {
+		c.cursors = nil
+	}
+	return nil
+}
+
+// materializeHeld materializes all cursors in the set that were created WITH
+// HOLD in the current transaction, so that they outlive it. It must be called
+// before the transaction commits, and finishHeld must be called once it
+// finished.
+func (c *cursorMap) materializeHeld(ctx context.Context) error {
+	for _, cursor := range c.cursors {
+		if !cursor.withHold || cursor.held() {
+			continue
+		}
+		if err := cursor.materialize(ctx); err != nil {
+			return err
+		}
+		cursor.holdPending = true
+	}
+	return nil
+}
+
+// finishHeld is called when the transaction that materialized cursors WITH
+// HOLD finishes. The cursors are kept if the transaction committed, and are
+// closed otherwise, since their rows were read by a transaction whose effects
+// were discarded.
+func (c *cursorMap) finishHeld(committed bool) error {
+	for n, cursor := range c.cursors {
+		if !cursor.holdPending {
+			continue
+		}
+		if committed {
+			cursor.holdPending = false
+			continue
+		}
+		delete(c.cursors, n)
+		if err := cursor.Close(); err != nil {
+			return err
+		}
+	}
+	if len(c.cursors) == 0 {
+		c.cursors = nil
+	}
 	return nil
 }
 
@@ -380,8 +716,8 @@ type connExCursorAccessor struct {
 	ex *connExecutor
 }
 
-func (c connExCursorAccessor) closeAll(errorOnWithHold bool) error {
-	return c.ex.extraTxnState.sqlCursors.closeAll(errorOnWithHold)
+func (c connExCursorAccessor) closeAll(closeHeld bool) error {
+	return c.ex.extraTxnState.sqlCursors.closeAll(closeHeld)
 }
 
 func (c connExCursorAccessor) closeCursor(s tree.Name) error {
@@ -409,7 +745,12 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 	// We could improve this by matching the memo metadata's list of dependent
 	// schema objects in each open cursor with the objects being changed in the
 	// schema change.
-	if len(p.sqlCursors.list()) > 0 {
+	for _, cursor := range p.sqlCursors.list() {
+		// Cursors WITH HOLD that outlived their transaction no longer read
+		// any schema objects.
+		if cursor.held() {
+			continue
+		}
 		return unimplemented.NewWithIssue(74608, "cannot run schema change "+
 			"in a transaction with open DECLARE cursors")
 	}
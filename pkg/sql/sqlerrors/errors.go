@@ -69,6 +69,13 @@ func NewNonNullViolationError(columnName string) error {
 	return pgerror.Newf(pgcode.NotNullViolation, "null value in column %q violates not-null constraint", columnName)
 }
 
+// NewRowLevelSecurityViolationError creates an error for a row that does not
+// satisfy the row-level security policies of a table.
+func NewRowLevelSecurityViolationError(tableName string) error {
+	return pgerror.Newf(pgcode.InsufficientPrivilege,
+		"new row violates row-level security policy for table %q", tableName)
+}
+
 // NewInvalidAssignmentCastError creates an error that is used when a mutation
 // cannot be performed because there is not a valid assignment cast from a
 // value's type to the type of the target column.
@@ -49,6 +49,10 @@ var (
 	// sharded index is created.
 	HashShardedIndexCounter = telemetry.GetCounterOnce("sql.schema.hash_sharded_index")
 
+	// BRINIndexCounter is to be incremented every time a BRIN index is
+	// created.
+	BRINIndexCounter = telemetry.GetCounterOnce("sql.schema.brin_index")
+
 	// InvertedIndexCounter is to be incremented every time an inverted index is
 	// created. This includes single-column inverted indexes, geometry/geography
 	// inverted indexes, multi-column inverted indexes, and partial inverted
@@ -0,0 +1,630 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// replicationKeepaliveInterval is the interval at which keepalive messages
+// are sent to the client of a replication stream.
+const replicationKeepaliveInterval = 10 * time.Second
+
+// execStartReplication runs a START_REPLICATION ... LOGICAL command, which
+// streams the changes to the tables of the database of a replication slot to
+// the client in the pgoutput format until the client ends the stream. The
+// changes are read from a rangefeed over the tables, and a change is only
+// sent once the rangefeed's frontier passed it, so that all the changes
+// committed at the same timestamp are sent together as one transaction.
+//
+// The position of a change in the stream (its LSN) is derived from its
+// commit timestamp; see lsnutil.HLCToLSN. Once the client confirms that it
+// flushed the changes up to a position, the position is saved in the slot,
+// and a new stream of the slot resumes from there.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) (retEv fsm.Event, retPayload fsm.EventPayload) {
+	// When we're done, stop the network routine from forwarding messages.
+	defer close(cmd.Done)
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return ex.makeErrEvent(
+			pgerror.Newf(pgcode.ActiveSQLTransaction,
+				"%s cannot be executed inside a transaction block", cmd.Stmt.StatementTag()),
+			cmd.Stmt,
+		)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+		if !payloadHasError(retPayload) {
+			ex.incrementExecutedStmtCounter(cmd.Stmt)
+		}
+		if p, ok := retPayload.(payloadWithError); ok {
+			log.SqlExec.Errorf(ctx, "error executing %s: %+v", cmd, p.errorCause())
+		}
+	}()
+
+	s, err := newReplicationStream(ctx, ex.server.cfg, ex.sessionData(), cmd, res)
+	if err == nil {
+		err = s.run(ctx)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			err = cancelchecker.QueryCanceledError
+		}
+		return ex.makeErrEvent(err, cmd.Stmt)
+	}
+	return nil, nil
+}
+
+// replicationStream streams the changes of a replication slot to a client.
+type replicationStream struct {
+	cfg  *ExecutorConfig
+	slot replicationSlot
+	cmd  StartReplication
+	res  StartReplicationResult
+
+	// startLSN is the position after which changes are sent.
+	startLSN lsn.LSN
+	// tableSpans are the primary index spans of the tables of the slot's
+	// database.
+	tableSpans []roachpb.Span
+
+	// events receives the rangefeed values and frontier advances.
+	events chan replicationEvent
+	// pending are the rangefeed values that were not sent yet because the
+	// frontier did not pass them.
+	pending []*kvpb.RangeFeedValue
+	// frontier is the timestamp up to which all the changes were sent.
+	frontier hlc.Timestamp
+
+	// relations are the descriptor versions of the tables for which a
+	// Relation message was sent.
+	relations map[descpb.ID]descpb.DescriptorVersion
+	// decoders are the row decoders of the tables, by descriptor version.
+	decoders map[replicationDecoderKey]*replicationDecoder
+	// xid is the transaction ID of the last transaction sent.
+	xid uint32
+
+	msgBuf  []byte
+	dataBuf []byte
+}
+
+// replicationEvent is either a rangefeed value or a frontier advance.
+type replicationEvent struct {
+	value    *kvpb.RangeFeedValue
+	frontier hlc.Timestamp
+}
+
+type replicationDecoderKey struct {
+	id      descpb.ID
+	version descpb.DescriptorVersion
+}
+
+// replicationDecoder decodes the rows of a version of a table.
+type replicationDecoder struct {
+	desc     catalog.TableDescriptor
+	relation pgoutput.Relation
+	fetcher  row.Fetcher
+	// isKey is set for the columns that are part of the replica identity.
+	isKey []bool
+}
+
+func newReplicationStream(
+	ctx context.Context,
+	cfg *ExecutorConfig,
+	sd *sessiondata.SessionData,
+	cmd StartReplication,
+	res StartReplicationResult,
+) (*replicationStream, error) {
+	if err := checkLogicalReplicationConnection(sd); err != nil {
+		return nil, err
+	}
+	if err := checkReplicationOptions(cmd.Stmt.Options); err != nil {
+		return nil, err
+	}
+	if setting, ok, _ := settings.LookupForLocalAccess(
+		"kv.rangefeed.enabled", cfg.Codec.ForSystemTenant(),
+	); ok && setting.String(&cfg.Settings.SV) != "true" {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires the kv.rangefeed.enabled setting")
+	}
+	s := &replicationStream{
+		cfg:       cfg,
+		cmd:       cmd,
+		res:       res,
+		events:    make(chan replicationEvent),
+		relations: make(map[descpb.ID]descpb.DescriptorVersion),
+		decoders:  make(map[replicationDecoderKey]*replicationDecoder),
+	}
+	if err := cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		s.slot, err = getReplicationSlot(ctx, txn, txn.KV(), string(cmd.Stmt.Slot))
+		if err != nil {
+			return err
+		}
+		db, err := txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Database(ctx, sd.Database)
+		if err != nil {
+			return err
+		}
+		if db.GetID() != s.slot.databaseID {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", s.slot.name)
+		}
+		tables, err := txn.Descriptors().GetAllTablesInDatabase(ctx, txn.KV(), db)
+		if err != nil {
+			return err
+		}
+		return tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			tbl, ok := desc.(catalog.TableDescriptor)
+			if !ok || !tbl.IsTable() || tbl.IsVirtualTable() || !tbl.Public() {
+				return nil
+			}
+			s.tableSpans = append(s.tableSpans, tbl.PrimaryIndexSpan(cfg.Codec))
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	s.startLSN = cmd.Stmt.LSN
+	if s.startLSN < s.slot.confirmedFlushLSN {
+		s.startLSN = s.slot.confirmedFlushLSN
+	}
+	s.frontier = lsnutil.LSNToHLC(s.startLSN)
+	return s, nil
+}
+
+// checkReplicationOptions checks the options passed to the pgoutput plugin.
+// Publications are not supported, so the changes to all the tables of the
+// database are streamed regardless of the publication_names option.
+func checkReplicationOptions(opts pgrepltree.Options) error {
+	for _, opt := range opts {
+		var val string
+		if s, ok := opt.Value.(*tree.StrVal); ok {
+			val = s.RawString()
+		} else if opt.Value != nil {
+			val = tree.AsStringWithFlags(opt.Value, tree.FmtBareStrings)
+		}
+		switch opt.Key {
+		case "proto_version":
+			if val != fmt.Sprint(pgoutput.ProtocolVersion) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but server only supports protocol %d",
+					val, pgoutput.ProtocolVersion)
+			}
+		case "publication_names":
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", opt.Key)
+		}
+	}
+	return nil
+}
+
+// run streams the changes until the client ends the stream.
+func (s *replicationStream) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if len(s.tableSpans) > 0 {
+		rf, err := s.cfg.RangeFeedFactory.RangeFeed(
+			ctx, fmt.Sprintf("replication-slot-%s", s.slot.name), s.tableSpans, s.frontier,
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				select {
+				case s.events <- replicationEvent{value: value}:
+				case <-ctx.Done():
+				}
+			},
+			rangefeed.WithDiff(true),
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, frontier hlc.Timestamp) {
+				select {
+				case s.events <- replicationEvent{frontier: frontier}:
+				case <-ctx.Done():
+				}
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		defer rf.Close()
+	}
+
+	if err := s.res.SendCopyBoth(ctx); err != nil {
+		return err
+	}
+	keepalive := timeutil.NewTimer()
+	defer keepalive.Stop()
+	keepalive.Reset(replicationKeepaliveInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev := <-s.events:
+			if ev.value != nil {
+				if ev.value.Value.Timestamp.After(s.frontier) {
+					s.pending = append(s.pending, ev.value)
+				}
+				continue
+			}
+			if err := s.sendPending(ctx, ev.frontier); err != nil {
+				return err
+			}
+		case msg := <-s.cmd.ClientMessages:
+			done, err := s.handleClientMessage(ctx, msg)
+			if err != nil || done {
+				return err
+			}
+		case <-keepalive.C:
+			keepalive.Read = true
+			if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+				return err
+			}
+			keepalive.Reset(replicationKeepaliveInterval)
+		}
+	}
+}
+
+// handleClientMessage handles a message sent by the client during the
+// stream. It returns true if the client ended the stream.
+func (s *replicationStream) handleClientMessage(
+	ctx context.Context, msg ReplicationClientMessage,
+) (done bool, _ error) {
+	switch msg.Typ {
+	case pgwirebase.ClientMsgCopyDone:
+		return true, s.res.SendCopyDone(ctx)
+	case pgwirebase.ClientMsgCopyFail:
+		return false, pgerror.Newf(pgcode.QueryCanceled,
+			"replication stream failed: %s", bytes.TrimRight(msg.Data, "\x00"))
+	}
+	if len(msg.Data) == 0 || msg.Data[0] != pgoutput.MessageStandbyStatusUpdate {
+		// Other messages, like hot standby feedback, do not apply to logical
+		// replication and are ignored.
+		return false, nil
+	}
+	update, err := pgoutput.DecodeStandbyStatusUpdate(msg.Data)
+	if err != nil {
+		return false, err
+	}
+	if update.FlushPosition > s.slot.confirmedFlushLSN {
+		if err := s.cfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			return advanceReplicationSlot(ctx, txn, s.slot.name, update.FlushPosition)
+		}); err != nil {
+			return false, err
+		}
+		s.slot.confirmedFlushLSN = update.FlushPosition
+	}
+	if update.ReplyRequested {
+		return false, s.sendKeepalive(ctx, false /* replyRequested */)
+	}
+	return false, nil
+}
+
+// sendKeepalive sends a keepalive message with the current end of the
+// stream.
+func (s *replicationStream) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	s.dataBuf = pgoutput.AppendPrimaryKeepalive(
+		s.dataBuf[:0], s.endLSN(), timeutil.Now(), replyRequested,
+	)
+	return s.res.SendCopyData(ctx, s.dataBuf, false /* isHeader */)
+}
+
+// endLSN returns the position up to which all changes were sent.
+func (s *replicationStream) endLSN() lsn.LSN {
+	if end := lsnutil.HLCToLSN(s.frontier); end > s.startLSN {
+		return end
+	}
+	return s.startLSN
+}
+
+// sendPending sends the pending changes up to the given frontier. The
+// changes committed at the same timestamp are sent as one transaction.
+func (s *replicationStream) sendPending(ctx context.Context, frontier hlc.Timestamp) error {
+	if !s.frontier.Less(frontier) {
+		return nil
+	}
+	sort.Slice(s.pending, func(i, j int) bool {
+		if ts := s.pending[i].Value.Timestamp; ts != s.pending[j].Value.Timestamp {
+			return ts.Less(s.pending[j].Value.Timestamp)
+		}
+		return s.pending[i].Key.Compare(s.pending[j].Key) < 0
+	})
+	n := sort.Search(len(s.pending), func(i int) bool {
+		return frontier.Less(s.pending[i].Value.Timestamp)
+	})
+	ready := s.pending[:n]
+	for len(ready) > 0 {
+		ts := ready[0].Value.Timestamp
+		end := 1
+		for end < len(ready) && ready[end].Value.Timestamp == ts {
+			end++
+		}
+		if err := s.sendTxn(ctx, ts, ready[:end]); err != nil {
+			return err
+		}
+		ready = ready[end:]
+	}
+	s.pending = append(s.pending[:0], s.pending[n:]...)
+	s.frontier = frontier
+	return nil
+}
+
+// sendTxn sends the given changes committed at the given timestamp as one
+// transaction.
+func (s *replicationStream) sendTxn(
+	ctx context.Context, ts hlc.Timestamp, values []*kvpb.RangeFeedValue,
+) error {
+	txnLSN := lsnutil.HLCToLSN(ts)
+	if txnLSN <= s.startLSN {
+		// The client already received the changes at this position.
+		return nil
+	}
+	commitTime := ts.GoTime()
+	s.xid++
+	if err := s.send(ctx, txnLSN, pgoutput.AppendBegin(s.msgBuf[:0], txnLSN, commitTime, s.xid)); err != nil {
+		return err
+	}
+	var lastKey roachpb.Key
+	for _, v := range values {
+		if v.Key.Equal(lastKey) {
+			// The rangefeed may deliver the same change more than once.
+			continue
+		}
+		lastKey = v.Key
+		if err := s.sendChange(ctx, txnLSN, v); err != nil {
+			return err
+		}
+	}
+	return s.send(ctx, txnLSN, pgoutput.AppendCommit(s.msgBuf[:0], txnLSN, txnLSN, commitTime))
+}
+
+// sendChange sends the Insert, Update or Delete message for the given
+// change, preceded by a Relation message if the client does not know the
+// table's current definition yet.
+func (s *replicationStream) sendChange(
+	ctx context.Context, txnLSN lsn.LSN, v *kvpb.RangeFeedValue,
+) error {
+	isDelete := !v.Value.IsPresent()
+	if isDelete && !v.PrevValue.IsPresent() {
+		// The row did not exist.
+		return nil
+	}
+	d, err := s.decoder(ctx, v)
+	if err != nil || d == nil {
+		return err
+	}
+	if version, ok := s.relations[d.desc.GetID()]; !ok || version != d.desc.GetVersion() {
+		if err := s.send(ctx, txnLSN, pgoutput.AppendRelation(s.msgBuf[:0], &d.relation)); err != nil {
+			return err
+		}
+		s.relations[d.desc.GetID()] = d.desc.GetVersion()
+	}
+	keyOnly := d.relation.ReplicaIdentity == pgoutput.ReplicaIdentityDefault
+	var msg []byte
+	switch {
+	case isDelete:
+		oldRow, err := d.decode(ctx, v.Key, v.PrevValue, v.Value.Timestamp, keyOnly)
+		if err != nil {
+			return err
+		}
+		msg = pgoutput.AppendDelete(s.msgBuf[:0], d.relation.OID, oldRow, keyOnly)
+	case v.PrevValue.IsPresent():
+		newRow, err := d.decode(ctx, v.Key, v.Value, v.Value.Timestamp, false /* keyOnly */)
+		if err != nil {
+			return err
+		}
+		// The old row is only sent with the full replica identity, since a
+		// change to the key of a row is a delete followed by an insert.
+		var oldRow tree.Datums
+		if !keyOnly {
+			oldRow, err = d.decode(ctx, v.Key, v.PrevValue, v.Value.Timestamp, false /* keyOnly */)
+			if err != nil {
+				return err
+			}
+		}
+		msg = pgoutput.AppendUpdate(s.msgBuf[:0], d.relation.OID, oldRow, false /* keyOnly */, newRow)
+	default:
+		newRow, err := d.decode(ctx, v.Key, v.Value, v.Value.Timestamp, false /* keyOnly */)
+		if err != nil {
+			return err
+		}
+		msg = pgoutput.AppendInsert(s.msgBuf[:0], d.relation.OID, newRow)
+	}
+	return s.send(ctx, txnLSN, msg)
+}
+
+// send sends the given pgoutput message wrapped in an XLogData message.
+func (s *replicationStream) send(ctx context.Context, msgLSN lsn.LSN, msg []byte) error {
+	s.msgBuf = msg
+	s.dataBuf = pgoutput.AppendXLogData(s.dataBuf[:0], msgLSN, msgLSN, timeutil.Now(), msg)
+	return s.res.SendCopyData(ctx, s.dataBuf, false /* isHeader */)
+}
+
+// decoder returns the decoder for the version of the table of the given
+// change, or nil if the table was dropped.
+func (s *replicationStream) decoder(
+	ctx context.Context, v *kvpb.RangeFeedValue,
+) (*replicationDecoder, error) {
+	key, err := s.cfg.Codec.StripTenantPrefix(v.Key)
+	if err != nil {
+		return nil, err
+	}
+	_, tableID, _, err := rowenc.DecodePartialTableIDIndexID(key)
+	if err != nil {
+		return nil, err
+	}
+	var tbl catalog.TableDescriptor
+	var schemaName string
+	coll := s.cfg.CollectionFactory.NewCollection(ctx)
+	defer coll.ReleaseAll(ctx)
+	if err := s.cfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		if err := txn.SetFixedTimestamp(ctx, v.Value.Timestamp); err != nil {
+			return err
+		}
+		var err error
+		tbl, err = coll.ByIDWithLeased(txn).WithoutNonPublic().Get().Table(ctx, tableID)
+		if err != nil {
+			return err
+		}
+		sc, err := coll.ByIDWithLeased(txn).WithoutNonPublic().Get().Schema(ctx, tbl.GetParentSchemaID())
+		if err != nil {
+			return err
+		}
+		schemaName = sc.GetName()
+		return nil
+	}); err != nil {
+		if errors.Is(err, catalog.ErrDescriptorDropped) || sqlerrors.IsUndefinedRelationError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	k := replicationDecoderKey{id: tbl.GetID(), version: tbl.GetVersion()}
+	if d, ok := s.decoders[k]; ok {
+		return d, nil
+	}
+	d, err := newReplicationDecoder(ctx, s.cfg, tbl, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	s.decoders[k] = d
+	return d, nil
+}
+
+func newReplicationDecoder(
+	ctx context.Context, cfg *ExecutorConfig, tbl catalog.TableDescriptor, schemaName string,
+) (*replicationDecoder, error) {
+	if tbl.NumFamilies() > 1 {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"logical replication of table %q with multiple column families is not supported",
+			tbl.GetName())
+	}
+	d := &replicationDecoder{
+		desc: tbl,
+		relation: pgoutput.Relation{
+			OID:             oid.Oid(tbl.GetID()),
+			Namespace:       schemaName,
+			Name:            tbl.GetName(),
+			ReplicaIdentity: pgoutput.ReplicaIdentityDefault,
+		},
+	}
+	// The hidden primary key column of a table without an explicit primary
+	// key is not sent, so the rows of such a table are identified by all
+	// their columns.
+	pkCols := tbl.GetPrimaryIndex().CollectKeyColumnIDs()
+	cols := tbl.VisibleColumns()
+	var visiblePKCols int
+	for _, col := range cols {
+		if pkCols.Contains(col.GetID()) {
+			visiblePKCols++
+		}
+	}
+	if visiblePKCols != pkCols.Len() {
+		d.relation.ReplicaIdentity = pgoutput.ReplicaIdentityFull
+	}
+	colIDs := make([]descpb.ColumnID, len(cols))
+	d.isKey = make([]bool, len(cols))
+	for i, col := range cols {
+		colIDs[i] = col.GetID()
+		d.isKey[i] = d.relation.ReplicaIdentity == pgoutput.ReplicaIdentityFull ||
+			pkCols.Contains(col.GetID())
+		d.relation.Columns = append(d.relation.Columns, pgoutput.RelationColumn{
+			Name:    col.GetName(),
+			TypeOID: col.GetType().Oid(),
+			TypeMod: col.GetType().TypeModifier(),
+			IsKey:   d.isKey[i],
+		})
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, cfg.Codec, tbl, tbl.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return nil, err
+	}
+	if err := d.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// decode decodes the row with the given key and value. If keyOnly is set,
+// the columns that are not part of the replica identity are NULL.
+func (d *replicationDecoder) decode(
+	ctx context.Context, key roachpb.Key, value roachpb.Value, ts hlc.Timestamp, keyOnly bool,
+) (tree.Datums, error) {
+	value.Timestamp = ts
+	if err := d.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{
+		KVs: []roachpb.KeyValue{{Key: key, Value: value}},
+	}); err != nil {
+		return nil, err
+	}
+	datums, err := d.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if datums == nil {
+		return nil, errors.AssertionFailedf("no row decoded from key %s", key)
+	}
+	row := make(tree.Datums, len(datums))
+	for i := range datums {
+		if keyOnly && !d.isKey[i] {
+			row[i] = tree.DNull
+		} else {
+			row[i] = datums[i]
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stats
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// MaxDependenciesColumns is the maximum number of columns in a statistic
+// created with the "dependencies" statistics kind. The number of dependencies
+// computed grows quadratically with the number of columns.
+const MaxDependenciesColumns = 8
+
+// FunctionalDependencies estimates the degree of the soft functional
+// dependency between every ordered pair of the given columns from a set of
+// sampled rows. The columns are identified by their index in the sampled rows,
+// and the From and To fields of the returned dependencies are indexes into
+// colIdxs.
+//
+// The degree of the dependency a => b is the fraction of rows which belong to
+// a group of rows that all share the same value of a and the same value of b.
+// A degree of 1 means that a determines b for all the sampled rows. NULL is
+// treated like any other value. Dependencies with a degree of 0 are omitted.
+func FunctionalDependencies(
+	compareCtx tree.CompareContext, samples []SampledRow, colIdxs []int,
+) ([]HistogramData_Dependency, error) {
+	if len(samples) == 0 {
+		return nil, nil
+	}
+	for _, sample := range samples {
+		for _, colIdx := range colIdxs {
+			if sample.Row[colIdx].Datum == nil {
+				return nil, errors.AssertionFailedf("value in column %d not decoded", colIdx)
+			}
+		}
+	}
+
+	var err error
+	compare := func(a, b tree.Datum) int {
+		c, cmpErr := a.CompareError(compareCtx, b)
+		if cmpErr != nil && err == nil {
+			err = cmpErr
+		}
+		return c
+	}
+
+	order := make([]int, len(samples))
+	datum := func(i, colIdx int) tree.Datum {
+		return samples[order[i]].Row[colIdx].Datum
+	}
+	var deps []HistogramData_Dependency
+	for from, fromIdx := range colIdxs {
+		for to, toIdx := range colIdxs {
+			if from == to {
+				continue
+			}
+			for i := range order {
+				order[i] = i
+			}
+			sort.Slice(order, func(i, j int) bool {
+				if c := compare(datum(i, fromIdx), datum(j, fromIdx)); c != 0 {
+					return c < 0
+				}
+				return compare(datum(i, toIdx), datum(j, toIdx)) < 0
+			})
+
+			var supporting int
+			for start := 0; start < len(order); {
+				end := start + 1
+				for end < len(order) && compare(datum(start, fromIdx), datum(end, fromIdx)) == 0 {
+					end++
+				}
+				// The rows of the group are sorted on the dependent column, so all of
+				// them share the same dependent value iff the first and last do.
+				if compare(datum(start, toIdx), datum(end-1, toIdx)) == 0 {
+					supporting += end - start
+				}
+				start = end
+			}
+			if err != nil {
+				return nil, err
+			}
+			if supporting > 0 {
+				deps = append(deps, HistogramData_Dependency{
+					From:   uint32(from),
+					To:     uint32(to),
+					Degree: float64(supporting) / float64(len(order)),
+				})
+			}
+		}
+	}
+	return deps, nil
+}
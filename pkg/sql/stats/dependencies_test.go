@@ -0,0 +1,91 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+func TestFunctionalDependencies(t *testing.T) {
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+
+	// Each row is (city, zip, x). Every zip code belongs to a single city, but
+	// some cities have several zip codes.
+	rows := []struct {
+		city string
+		zip  tree.Datum
+		x    int
+	}{
+		{"nyc", tree.NewDInt(10001), 1},
+		{"nyc", tree.NewDInt(10001), 2},
+		{"nyc", tree.NewDInt(10002), 3},
+		{"sf", tree.NewDInt(94103), 1},
+		{"sf", tree.NewDInt(94103), 2},
+		{"la", tree.DNull, 1},
+	}
+	samples := make([]SampledRow, len(rows))
+	for i, r := range rows {
+		samples[i].Row = rowenc.EncDatumRow{
+			rowenc.DatumToEncDatum(types.String, tree.NewDString(r.city)),
+			rowenc.DatumToEncDatum(types.Int, r.zip),
+			rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(r.x))),
+		}
+	}
+
+	testCases := []struct {
+		colIdxs  []int
+		expected []HistogramData_Dependency
+	}{
+		{
+			colIdxs: []int{0, 1},
+			expected: []HistogramData_Dependency{
+				{From: 0, To: 1, Degree: 3.0 / 6},
+				{From: 1, To: 0, Degree: 1},
+			},
+		},
+		{
+			colIdxs: []int{1, 2},
+			expected: []HistogramData_Dependency{
+				{From: 0, To: 1, Degree: 2.0 / 6},
+				{From: 1, To: 0, Degree: 1.0 / 6},
+			},
+		},
+		{
+			colIdxs: []int{2, 0},
+			expected: []HistogramData_Dependency{
+				{From: 0, To: 1, Degree: 1.0 / 6},
+				{From: 1, To: 0, Degree: 1.0 / 6},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		deps, err := FunctionalDependencies(evalCtx, samples, tc.colIdxs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(deps, tc.expected) {
+			t.Errorf("columns %v: expected %v, got %v", tc.colIdxs, tc.expected, deps)
+		}
+	}
+
+	if deps, err := FunctionalDependencies(evalCtx, nil /* samples */, []int{0, 1}); err != nil {
+		t.Fatal(err)
+	} else if deps != nil {
+		t.Errorf("expected no dependencies for an empty sample, got %v", deps)
+	}
+}
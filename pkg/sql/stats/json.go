@@ -45,6 +45,9 @@ type JSONStatistic struct {
 	HistogramVersion    HistogramVersion  `json:"histo_version,omitempty"`
 	PartialPredicate    string            `json:"partial_predicate,omitempty"`
 	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
+	// Dependencies are the functional dependencies between the columns of a
+	// multi-column statistic (or unset if there are none).
+	Dependencies []JSONDependency `json:"dependencies,omitempty"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -60,8 +63,24 @@ type JSONHistoBucket struct {
 	UpperBound string `json:"upper_bound"`
 }
 
-// SetHistogram fills in the HistogramColumnType and HistogramBuckets fields.
+// JSONDependency is a struct used for JSON marshaling and unmarshaling of
+// functional dependencies.
+//
+// See HistogramData_Dependency for a description of the fields.
+type JSONDependency struct {
+	// From and To are column names; they must be part of the statistic's
+	// columns.
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Degree float64 `json:"degree"`
+}
+
+// SetHistogram fills in the HistogramColumnType and HistogramBuckets fields,
+// or the Dependencies field if h contains functional dependencies.
 func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
+	if len(h.Dependencies) > 0 {
+		return js.setDependencies(h.Dependencies)
+	}
 	typ := h.ColumnType
 	if typ == nil {
 		return fmt.Errorf("histogram type is unset")
@@ -94,6 +113,24 @@ func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
 	return nil
 }
 
+// setDependencies fills in the Dependencies field, naming the columns of each
+// dependency.
+func (js *JSONStatistic) setDependencies(deps []HistogramData_Dependency) error {
+	js.Dependencies = make([]JSONDependency, len(deps))
+	for i := range deps {
+		d := &deps[i]
+		if int(d.From) >= len(js.Columns) || int(d.To) >= len(js.Columns) {
+			return fmt.Errorf("dependency refers to a column outside of the statistic")
+		}
+		js.Dependencies[i] = JSONDependency{
+			From:   js.Columns[d.From],
+			To:     js.Columns[d.To],
+			Degree: d.Degree,
+		}
+	}
+	return nil
+}
+
 // DecodeAndSetHistogram decodes a histogram marshaled as a Bytes datum and
 // fills in the JSONStatistic histogram fields.
 func (js *JSONStatistic) DecodeAndSetHistogram(
@@ -115,7 +152,7 @@ func (js *JSONStatistic) DecodeAndSetHistogram(
 	}
 	// If the serialized column type is user defined, then it needs to be
 	// hydrated before use.
-	if h.ColumnType.UserDefined() {
+	if h.ColumnType != nil && h.ColumnType.UserDefined() {
 		resolver := semaCtx.GetTypeResolver()
 		if resolver == nil {
 			return errors.AssertionFailedf("attempt to resolve user defined type with nil TypeResolver")
@@ -129,10 +166,17 @@ func (js *JSONStatistic) DecodeAndSetHistogram(
 	return js.SetHistogram(h)
 }
 
-// GetHistogram converts the json histogram into HistogramData.
+// GetHistogram converts the json histogram (or functional dependencies) into
+// HistogramData.
 func (js *JSONStatistic) GetHistogram(
 	ctx context.Context, semaCtx *tree.SemaContext, evalCtx *eval.Context,
 ) (*HistogramData, error) {
+	if len(js.Dependencies) > 0 {
+		if js.HistogramColumnType != "" {
+			return nil, errors.New("a statistic cannot have both a histogram and dependencies")
+		}
+		return js.getDependencies()
+	}
 	if js.HistogramColumnType == "" {
 		return nil, nil
 	}
@@ -165,6 +209,38 @@ func (js *JSONStatistic) GetHistogram(
 	return h, nil
 }
 
+// getDependencies converts the json dependencies into HistogramData.
+func (js *JSONStatistic) getDependencies() (*HistogramData, error) {
+	colOrdinal := func(name string) (uint32, error) {
+		for i := range js.Columns {
+			if js.Columns[i] == name {
+				return uint32(i), nil
+			}
+		}
+		return 0, errors.Newf("dependency column %q is not part of the statistic", name)
+	}
+	h := &HistogramData{Dependencies: make([]HistogramData_Dependency, len(js.Dependencies))}
+	for i := range js.Dependencies {
+		d := &js.Dependencies[i]
+		from, err := colOrdinal(d.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := colOrdinal(d.To)
+		if err != nil {
+			return nil, err
+		}
+		if from == to {
+			return nil, errors.Newf("column %q cannot depend on itself", d.From)
+		}
+		if d.Degree < 0 || d.Degree > 1 {
+			return nil, errors.Newf("dependency degree %g is not between 0 and 1", d.Degree)
+		}
+		h.Dependencies[i] = HistogramData_Dependency{From: from, To: to, Degree: d.Degree}
+	}
+	return h, nil
+}
+
 // IsPartial returns true if this statistic was collected with a where clause.
 func (js *JSONStatistic) IsPartial() bool {
 	return js.PartialPredicate != ""
@@ -624,7 +624,9 @@ func (sc *TableStatisticsCache) parseStats(
 		return nil, err
 	}
 	res := &TableStatistic{TableStatisticProto: *tsp}
-	if res.HistogramData != nil {
+	// Functional dependencies of multi-column statistics are stored in place of
+	// a histogram, and need no decoding.
+	if res.HistogramData != nil && len(res.HistogramData.Dependencies) == 0 {
 		// hydrate the type in case any user defined types are present.
 		// There are cases where typ is nil, so don't do anything if so.
 		if typ := res.HistogramData.ColumnType; typ != nil && typ.UserDefined() {
@@ -107,6 +107,27 @@ func (po *Setter) applyGeometryIndexSetting(
 	return nil
 }
 
+func (po *Setter) applyPagesPerRange(
+	ctx context.Context, evalCtx *eval.Context, key string, expr tree.Datum,
+) error {
+	if po.IndexDesc.Type != descpb.IndexDescriptor_BRIN {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "%q can only be applied to BRIN indexes", key)
+	}
+	val, err := paramparse.DatumAsInt(ctx, evalCtx, key, expr)
+	if err != nil {
+		return errors.Wrapf(err, "error decoding %q", key)
+	}
+	// This is the same range as in Postgres.
+	if val < 1 || val > 131072 {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue, "%q value must be between 1 and 131072 inclusive", key,
+		)
+	}
+	pagesPerRange := uint32(val)
+	po.IndexDesc.PagesPerRange = &pagesPerRange
+	return nil
+}
+
 // Set implements the Setter interface.
 func (po *Setter) Set(
 	ctx context.Context,
@@ -126,6 +147,8 @@ func (po *Setter) Set(
 		return po.applyS2ConfigSetting(ctx, evalCtx, key, expr, 1, 32)
 	case `geometry_min_x`, `geometry_max_x`, `geometry_min_y`, `geometry_max_y`:
 		return po.applyGeometryIndexSetting(ctx, evalCtx, key, expr)
+	case `pages_per_range`:
+		return po.applyPagesPerRange(ctx, evalCtx, key, expr)
 	// `bucket_count` is handled in schema changer when creating hash sharded
 	// indexes.
 	case `bucket_count`:
@@ -134,7 +157,6 @@ func (po *Setter) Set(
 		`buffering`,
 		`fastupdate`,
 		`gin_pending_list_limit`,
-		`pages_per_range`,
 		`autosummarize`:
 		return unimplemented.NewWithIssuef(43299, "storage parameter %q", key)
 	}
@@ -41,6 +41,10 @@ type Setter struct {
 	// UpdatedRowLevelTTL is kept separate from the RowLevelTTL in TableDesc
 	// in case changes need to be made in schema changer.
 	UpdatedRowLevelTTL *catpb.RowLevelTTL
+
+	// UpdatedRollingPartitioning is kept separate from the RollingPartitioning
+	// in TableDesc so that the schedule can be updated based on the changes.
+	UpdatedRollingPartitioning *catpb.RollingPartitioning
 }
 
 var _ storageparam.Setter = (*Setter)(nil)
@@ -51,9 +55,14 @@ func NewSetter(tableDesc *tabledesc.Mutable) *Setter {
 	if tableDesc.HasRowLevelTTL() {
 		updatedRowLevelTTL = protoutil.Clone(tableDesc.GetRowLevelTTL()).(*catpb.RowLevelTTL)
 	}
+	var updatedRollingPartitioning *catpb.RollingPartitioning
+	if tableDesc.HasRollingPartitioning() {
+		updatedRollingPartitioning = protoutil.Clone(tableDesc.GetRollingPartitioning()).(*catpb.RollingPartitioning)
+	}
 	return &Setter{
-		TableDesc:          tableDesc,
-		UpdatedRowLevelTTL: updatedRowLevelTTL,
+		TableDesc:                  tableDesc,
+		UpdatedRowLevelTTL:         updatedRowLevelTTL,
+		UpdatedRollingPartitioning: updatedRollingPartitioning,
 	}
 }
 
@@ -62,6 +71,9 @@ func (po *Setter) RunPostChecks() error {
 	if err := tabledesc.ValidateRowLevelTTL(po.UpdatedRowLevelTTL); err != nil {
 		return err
 	}
+	if err := tabledesc.ValidateRollingPartitioning(po.UpdatedRollingPartitioning); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -110,6 +122,59 @@ func floatFromDatum(
 	return s, nil
 }
 
+// intervalFromDatum returns the interval held by the datum, which may
+// be either an INTERVAL or a string that can be parsed as one. It returns an
+// error if the interval is not positive, or if allowZero is set, negative.
+func intervalFromDatum(
+	ctx context.Context, evalCtx *eval.Context, key string, datum tree.Datum, allowZero bool,
+) (*tree.DInterval, error) {
+	var d *tree.DInterval
+	if stringVal, err := paramparse.DatumAsString(ctx, evalCtx, key, datum); err == nil {
+		d, err = tree.ParseDInterval(evalCtx.SessionData().GetIntervalStyle(), stringVal)
+		if err != nil {
+			return nil, pgerror.Wrapf(
+				err,
+				pgcode.InvalidParameterValue,
+				`value of %q must be an interval`,
+				key,
+			)
+		}
+		if d == nil {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				`value of %q must be an interval`,
+				key,
+			)
+		}
+	} else {
+		var ok bool
+		d, ok = datum.(*tree.DInterval)
+		if !ok || d == nil {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				`value of %q must be an interval`,
+				key,
+			)
+		}
+	}
+
+	if cmp := d.Duration.Compare(duration.MakeDuration(0, 0, 0)); cmp < 0 || (cmp == 0 && !allowZero) {
+		if allowZero {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				`value of %q must be at least zero`,
+				key,
+			)
+		}
+		return nil, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`value of %q must be greater than zero`,
+			key,
+		)
+	}
+	return d, nil
+}
+
 func (po *Setter) hasRowLevelTTL() bool {
 	return po.UpdatedRowLevelTTL != nil
 }
@@ -123,6 +188,19 @@ func (po *Setter) getOrCreateRowLevelTTL() *catpb.RowLevelTTL {
 	return rowLevelTTL
 }
 
+func (po *Setter) hasRollingPartitioning() bool {
+	return po.UpdatedRollingPartitioning != nil
+}
+
+func (po *Setter) getOrCreateRollingPartitioning() *catpb.RollingPartitioning {
+	rp := po.UpdatedRollingPartitioning
+	if rp == nil {
+		rp = &catpb.RollingPartitioning{}
+		po.UpdatedRollingPartitioning = rp
+	}
+	return rp
+}
+
 type tableParam struct {
 	onSet   func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error
 	onReset func(ctx context.Context, po *Setter, evalCtx *eval.Context, key string) error
@@ -210,42 +288,9 @@ var tableParams = map[string]tableParam{
 	},
 	`ttl_expire_after`: {
 		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
-			var d *tree.DInterval
-			if stringVal, err := paramparse.DatumAsString(ctx, evalCtx, key, datum); err == nil {
-				d, err = tree.ParseDInterval(evalCtx.SessionData().GetIntervalStyle(), stringVal)
-				if err != nil {
-					return pgerror.Wrapf(
-						err,
-						pgcode.InvalidParameterValue,
-						`value of %q must be an interval`,
-						key,
-					)
-				}
-				if d == nil {
-					return pgerror.Newf(
-						pgcode.InvalidParameterValue,
-						`value of %q must be an interval`,
-						key,
-					)
-				}
-			} else {
-				var ok bool
-				d, ok = datum.(*tree.DInterval)
-				if !ok || d == nil {
-					return pgerror.Newf(
-						pgcode.InvalidParameterValue,
-						`value of %q must be an interval`,
-						key,
-					)
-				}
-			}
-
-			if d.Duration.Compare(duration.MakeDuration(0, 0, 0)) < 0 {
-				return pgerror.Newf(
-					pgcode.InvalidParameterValue,
-					`value of %q must be at least zero`,
-					key,
-				)
+			d, err := intervalFromDatum(ctx, evalCtx, key, datum, true /* allowZero */)
+			if err != nil {
+				return err
 			}
 			rowLevelTTL := po.getOrCreateRowLevelTTL()
 			rowLevelTTL.DurationExpr = catpb.Expression(tree.Serialize(d))
@@ -419,6 +464,80 @@ var tableParams = map[string]tableParam{
 			return nil
 		},
 	},
+	`rolling_partition_interval`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			d, err := intervalFromDatum(ctx, evalCtx, key, datum, false /* allowZero */)
+			if err != nil {
+				return err
+			}
+			rp := po.getOrCreateRollingPartitioning()
+			rp.IntervalExpr = catpb.Expression(tree.Serialize(d))
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			// Removing the partition interval removes rolling partitioning from
+			// the table. Existing partitions are left in place.
+			po.UpdatedRollingPartitioning = nil
+			return nil
+		},
+	},
+	`rolling_partition_lookahead`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			val, err := intFromDatum(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			if err := tabledesc.ValidateRollingPartitionLookahead(key, val); err != nil {
+				return err
+			}
+			rp := po.getOrCreateRollingPartitioning()
+			rp.Lookahead = val
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if po.hasRollingPartitioning() {
+				po.UpdatedRollingPartitioning.Lookahead = 0
+			}
+			return nil
+		},
+	},
+	`rolling_partition_retention`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			d, err := intervalFromDatum(ctx, evalCtx, key, datum, false /* allowZero */)
+			if err != nil {
+				return err
+			}
+			rp := po.getOrCreateRollingPartitioning()
+			rp.RetentionExpr = catpb.Expression(tree.Serialize(d))
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if po.hasRollingPartitioning() {
+				po.UpdatedRollingPartitioning.RetentionExpr = ""
+			}
+			return nil
+		},
+	},
+	`rolling_partition_job_cron`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			str, err := paramparse.DatumAsString(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			if err := tabledesc.ValidateTTLCronExpr(key, str); err != nil {
+				return err
+			}
+			rp := po.getOrCreateRollingPartitioning()
+			rp.JobCron = str
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if po.hasRollingPartitioning() {
+				po.UpdatedRollingPartitioning.JobCron = ""
+			}
+			return nil
+		},
+	},
 	`exclude_data_from_backup`: {
 		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext,
 			evalCtx *eval.Context, key string, datum tree.Datum) error {
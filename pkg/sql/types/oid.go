@@ -64,14 +64,20 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_bool:       Bool,
 	oid.T_bpchar:     typeBpChar,
 	oid.T_bytea:      Bytes,
+	oid.T_box:        Box,
 	oid.T_char:       QChar,
+	oid.T_cidr:       CIDR,
+	oid.T_circle:     Circle,
 	oid.T_date:       Date,
+	oid.T_daterange:  DateRange,
 	oid.T_float4:     Float4,
 	oid.T_float8:     Float,
 	oid.T_int2:       Int2,
 	oid.T_int2vector: Int2Vector,
 	oid.T_int4:       Int4,
+	oid.T_int4range:  Int4Range,
 	oid.T_int8:       Int,
+	oid.T_int8range:  Int8Range,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
@@ -79,10 +85,17 @@ var OidToType = map[oid.Oid]*T{
 	// existing tables.
 	// oid.T_json:      Json,
 	oid.T_jsonb:        Jsonb,
+	oid.T_line:         Line,
+	oid.T_lseg:         LSeg,
+	oid.T_macaddr:      MacAddr,
 	oid.T_name:         Name,
 	oid.T_numeric:      Decimal,
+	oid.T_numrange:     NumRange,
 	oid.T_oid:          Oid,
 	oid.T_oidvector:    OidVector,
+	oid.T_path:         Path,
+	oid.T_point:        Point,
+	oid.T_polygon:      Polygon,
 	oid.T_pg_lsn:       PGLSN,
 	oid.T_record:       AnyTuple,
 	oid.T_regclass:     RegClass,
@@ -96,6 +109,9 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timetz:       TimeTZ,
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
+	oid.T_tsrange:      TSRange,
+	oid.T_tstzrange:    TSTZRange,
+	oid.T_trigger:      Trigger,
 	oid.T_tsquery:      TSQuery,
 	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
@@ -107,6 +123,15 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
+	oidext.T_jsonpath:  Jsonpath,
+	oidext.T_macaddr8:  MacAddr8,
+
+	oidext.T_int4multirange: Int4MultiRange,
+	oidext.T_int8multirange: Int8MultiRange,
+	oidext.T_nummultirange:  NumMultiRange,
+	oidext.T_datemultirange: DateMultiRange,
+	oidext.T_tsmultirange:   TSMultiRange,
+	oidext.T_tstzmultirange: TSTZMultiRange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -116,21 +141,34 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_bool:         oid.T__bool,
 	oid.T_bpchar:       oid.T__bpchar,
 	oid.T_bytea:        oid.T__bytea,
+	oid.T_box:          oid.T__box,
 	oid.T_char:         oid.T__char,
+	oid.T_cidr:         oid.T__cidr,
+	oid.T_circle:       oid.T__circle,
 	oid.T_date:         oid.T__date,
+	oid.T_daterange:    oid.T__daterange,
 	oid.T_float4:       oid.T__float4,
 	oid.T_float8:       oid.T__float8,
 	oid.T_inet:         oid.T__inet,
 	oid.T_int2:         oid.T__int2,
 	oid.T_int2vector:   oid.T__int2vector,
 	oid.T_int4:         oid.T__int4,
+	oid.T_int4range:    oid.T__int4range,
 	oid.T_int8:         oid.T__int8,
+	oid.T_int8range:    oid.T__int8range,
 	oid.T_interval:     oid.T__interval,
 	oid.T_jsonb:        oid.T__jsonb,
+	oid.T_line:         oid.T__line,
+	oid.T_lseg:         oid.T__lseg,
+	oid.T_macaddr:      oid.T__macaddr,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
+	oid.T_numrange:     oid.T__numrange,
 	oid.T_oid:          oid.T__oid,
 	oid.T_oidvector:    oid.T__oidvector,
+	oid.T_path:         oid.T__path,
+	oid.T_point:        oid.T__point,
+	oid.T_polygon:      oid.T__polygon,
 	oid.T_pg_lsn:       oid.T__pg_lsn,
 	oid.T_record:       oid.T__record,
 	oid.T_regclass:     oid.T__regclass,
@@ -144,6 +182,8 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_timetz:       oid.T__timetz,
 	oid.T_timestamp:    oid.T__timestamp,
 	oid.T_timestamptz:  oid.T__timestamptz,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
 	oid.T_tsquery:      oid.T__tsquery,
 	oid.T_tsvector:     oid.T__tsvector,
 	oid.T_uuid:         oid.T__uuid,
@@ -153,6 +193,15 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geometry:  oidext.T__geometry,
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
+	oidext.T_jsonpath:  oidext.T__jsonpath,
+	oidext.T_macaddr8:  oidext.T__macaddr8,
+
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -176,6 +225,16 @@ var familyToOid = map[Family]oid.Oid{
 	UuidFamily:           oid.T_uuid,
 	ArrayFamily:          oid.T_anyarray,
 	INetFamily:           oid.T_inet,
+	CIDRFamily:           oid.T_cidr,
+	MacAddrFamily:        oid.T_macaddr,
+	PointFamily:          oid.T_point,
+	LSegFamily:           oid.T_lseg,
+	BoxFamily:            oid.T_box,
+	PathFamily:           oid.T_path,
+	PolygonFamily:        oid.T_polygon,
+	LineFamily:           oid.T_line,
+	CircleFamily:         oid.T_circle,
+	RangeFamily:          oid.T_int8range,
 	TimeFamily:           oid.T_time,
 	TimeTZFamily:         oid.T_timetz,
 	JsonFamily:           oid.T_jsonb,
@@ -185,9 +244,12 @@ var familyToOid = map[Family]oid.Oid{
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
 
-	GeometryFamily:  oidext.T_geometry,
-	GeographyFamily: oidext.T_geography,
-	Box2DFamily:     oidext.T_box2d,
+	GeometryFamily:   oidext.T_geometry,
+	GeographyFamily:  oidext.T_geography,
+	Box2DFamily:      oidext.T_box2d,
+	JsonpathFamily:   oidext.T_jsonpath,
+	MacAddr8Family:   oidext.T_macaddr8,
+	MultiRangeFamily: oidext.T_int8multirange,
 }
 
 // ArrayOids is a set of all oids which correspond to an array type.
@@ -203,6 +265,9 @@ func init() {
 // CalcArrayOid returns the OID of the array type having elements of the given
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
+	if elemTyp.IsDomain() {
+		return elemTyp.UserDefinedArrayOID()
+	}
 	o := elemTyp.Oid()
 	switch elemTyp.Family() {
 	case ArrayFamily:
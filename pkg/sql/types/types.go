@@ -195,6 +195,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -221,6 +224,27 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed for evaluation.
+type DomainMetadata struct {
+	// BaseType is the type that the domain is defined over.
+	BaseType *T
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, if any.
+	DefaultExpr *string
+	// Checks are the CHECK constraints of the domain. Values of the domain are
+	// referenced with the VALUE keyword in their expressions.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint of a DOMAIN.
+type DomainCheck struct {
+	// Name is the name of the constraint.
+	Name string
+	// Expr is the serialized check expression.
+	Expr string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -429,6 +453,85 @@ var (
 	INet = &T{InternalType: InternalType{
 		Family: INetFamily, Oid: oid.T_inet, Locale: &emptyLocale}}
 
+	// CIDR is the type of an IPv4 or IPv6 network specification. Unlike INet
+	// values, CIDR values cannot have bits set to the right of the mask. For
+	// example:
+	//
+	//   192.168.100.128/25
+	//   2001:4f8:3:ba::/64
+	//
+	CIDR = &T{InternalType: InternalType{
+		Family: CIDRFamily, Oid: oid.T_cidr, Locale: &emptyLocale}}
+
+	// MacAddr is the type of a 6 byte MAC address. For example:
+	//
+	//   08:00:2b:01:02:03
+	//
+	MacAddr = &T{InternalType: InternalType{
+		Family: MacAddrFamily, Oid: oid.T_macaddr, Locale: &emptyLocale}}
+
+	// MacAddr8 is the type of an 8 byte MAC address in EUI-64 format. For
+	// example:
+	//
+	//   08:00:2b:01:02:03:04:05
+	//
+	MacAddr8 = &T{InternalType: InternalType{
+		Family: MacAddr8Family, Oid: oidext.T_macaddr8, Locale: &emptyLocale}}
+
+	// Point is the type of a point on a plane. For example:
+	//
+	//   (1,2)
+	//
+	Point = &T{InternalType: InternalType{
+		Family: PointFamily, Oid: oid.T_point, Locale: &emptyLocale}}
+
+	// LSeg is the type of a finite line segment. For example:
+	//
+	//   [(1,2),(3,4)]
+	//
+	LSeg = &T{InternalType: InternalType{
+		Family: LSegFamily, Oid: oid.T_lseg, Locale: &emptyLocale}}
+
+	// Box is the type of a rectangular box, which is represented by its upper right and
+	// lower left corners. For example:
+	//
+	//   (3,4),(1,2)
+	//
+	Box = &T{InternalType: InternalType{
+		Family: BoxFamily, Oid: oid.T_box, Locale: &emptyLocale}}
+
+	// Path is the type of a list of connected points, which is either open or closed.
+	// For example:
+	//
+	//   [(1,2),(3,4),(5,6)]
+	//   ((1,2),(3,4),(5,6))
+	//
+	Path = &T{InternalType: InternalType{
+		Family: PathFamily, Oid: oid.T_path, Locale: &emptyLocale}}
+
+	// Polygon is the type of a polygon, which is a closed path that includes its
+	// interior. For example:
+	//
+	//   ((0,0),(0,1),(1,0))
+	//
+	Polygon = &T{InternalType: InternalType{
+		Family: PolygonFamily, Oid: oid.T_polygon, Locale: &emptyLocale}}
+
+	// Line is the type of an infinite line, represented by the equation Ax + By + C = 0.
+	// For example:
+	//
+	//   {1,-1,0}
+	//
+	Line = &T{InternalType: InternalType{
+		Family: LineFamily, Oid: oid.T_line, Locale: &emptyLocale}}
+
+	// Circle is the type of a circle, represented by its center and radius. For example:
+	//
+	//   <(1,2),3>
+	//
+	Circle = &T{InternalType: InternalType{
+		Family: CircleFamily, Oid: oid.T_circle, Locale: &emptyLocale}}
+
 	// Geometry is the type of a geospatial Geometry object.
 	Geometry = &T{
 		InternalType: InternalType{
@@ -476,6 +579,15 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo type returned by trigger functions.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -507,6 +619,91 @@ var (
 		},
 	}
 
+	// Jsonpath is the type of a SQL/JSON path expression.
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Int4Range is the type of a range of Int4 values. For example:
+	//
+	//   [1,10)
+	//
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of Int values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of Decimal values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of Date values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of Timestamp values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TimestampTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// Int4MultiRange is the type of an ordered set of non-overlapping Int4Range
+	// values. For example:
+	//
+	//   {[1,3),[5,10)}
+	//
+	Int4MultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8MultiRange is the type of a multirange of Int8Range values.
+	Int8MultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultiRange is the type of a multirange of NumRange values.
+	NumMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// DateMultiRange is the type of a multirange of DateRange values.
+	DateMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// TSMultiRange is the type of a multirange of TSRange values.
+	TSMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultiRange is the type of a multirange of TSTZRange values.
+	TSTZMultiRange = &T{InternalType: InternalType{
+		Family: MultiRangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// Ranges contains all of the built-in range types.
+	Ranges = []*T{
+		Int4Range,
+		Int8Range,
+		NumRange,
+		DateRange,
+		TSRange,
+		TSTZRange,
+	}
+
+	// MultiRanges contains all of the built-in multirange types, in the same
+	// order as the corresponding range types in Ranges.
+	MultiRanges = []*T{
+		Int4MultiRange,
+		Int8MultiRange,
+		NumMultiRange,
+		DateMultiRange,
+		TSMultiRange,
+		TSTZMultiRange,
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -530,6 +727,9 @@ var (
 		Oid,
 		Uuid,
 		INet,
+		CIDR,
+		MacAddr,
+		MacAddr8,
 		PGLSN,
 		Time,
 		TimeTZ,
@@ -640,6 +840,47 @@ var (
 	INetArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: INet, Oid: oid.T__inet, Locale: &emptyLocale}}
 
+	// CIDRArray is the type of an array value having CIDR-typed elements.
+	CIDRArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: CIDR, Oid: oid.T__cidr, Locale: &emptyLocale}}
+
+	// MacAddrArray is the type of an array value having MacAddr-typed elements.
+	MacAddrArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: MacAddr, Oid: oid.T__macaddr, Locale: &emptyLocale}}
+
+	// MacAddr8Array is the type of an array value having MacAddr8-typed
+	// elements.
+	MacAddr8Array = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: MacAddr8, Oid: oidext.T__macaddr8, Locale: &emptyLocale}}
+
+	// PointArray is the type of an array value having Point-typed elements.
+	PointArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Point, Oid: oid.T__point, Locale: &emptyLocale}}
+
+	// LSegArray is the type of an array value having LSeg-typed elements.
+	LSegArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: LSeg, Oid: oid.T__lseg, Locale: &emptyLocale}}
+
+	// BoxArray is the type of an array value having Box-typed elements.
+	BoxArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Box, Oid: oid.T__box, Locale: &emptyLocale}}
+
+	// PathArray is the type of an array value having Path-typed elements.
+	PathArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Path, Oid: oid.T__path, Locale: &emptyLocale}}
+
+	// PolygonArray is the type of an array value having Polygon-typed elements.
+	PolygonArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Polygon, Oid: oid.T__polygon, Locale: &emptyLocale}}
+
+	// LineArray is the type of an array value having Line-typed elements.
+	LineArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Line, Oid: oid.T__line, Locale: &emptyLocale}}
+
+	// CircleArray is the type of an array value having Circle-typed elements.
+	CircleArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Circle, Oid: oid.T__circle, Locale: &emptyLocale}}
+
 	// VarBitArray is the type of an array value having VarBit-typed elements.
 	VarBitArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: VarBit, Oid: oid.T__varbit, Locale: &emptyLocale}}
@@ -1202,6 +1443,18 @@ func NewCompositeType(typeOID, arrayTypeOID oid.Oid, contents []*T, labels []str
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type over the given base
+// type. The domain type has the attributes of the base type, but the OID of the
+// domain.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, baseType *T) *T {
+	t := &T{InternalType: baseType.InternalType}
+	t.InternalType.Oid = typeOID
+	t.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+	}
+	return t
+}
+
 // Family specifies a group of types that are compatible with one another. Types
 // in the same family can be compared, assigned, etc., but may differ from one
 // another in width, precision, locale, and other attributes. For example, it is
@@ -1331,6 +1584,10 @@ func (t *T) TypeModifier() int32 {
 // precision. If the given type already has no type modifiers, it is returned
 // unchanged and the function does not allocate a new type.
 func (t *T) WithoutTypeModifiers() *T {
+	if t.IsDomain() {
+		// The type modifiers of a domain are part of its definition.
+		return t
+	}
 	switch t.Family() {
 	case ArrayFamily:
 		// Remove type modifiers of the array content type.
@@ -1403,6 +1660,60 @@ func (t *T) TupleContents() []*T {
 	return t.InternalType.TupleContents
 }
 
+// RangeContents returns the type of the bounds of a range. This is nil for
+// types that are not in the RangeFamily.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	return rangeSubtypes[t.Oid()]
+}
+
+// MultiRangeContents returns the range type of the ranges in a multirange.
+// This is nil for types that are not in the MultiRangeFamily.
+func (t *T) MultiRangeContents() *T {
+	if t.Family() != MultiRangeFamily {
+		return nil
+	}
+	return multiRangeRanges[t.Oid()]
+}
+
+// MultiRangeOf returns the multirange type whose ranges have the given range
+// type. This is nil if rangeTyp is not a range type.
+func MultiRangeOf(rangeTyp *T) *T {
+	if rangeTyp.Family() != RangeFamily {
+		return nil
+	}
+	for i, r := range Ranges {
+		if r.Oid() == rangeTyp.Oid() {
+			return MultiRanges[i]
+		}
+	}
+	return nil
+}
+
+// rangeSubtypes maps the Oid of each built-in range type to the type of its
+// bounds.
+var rangeSubtypes = map[oid.Oid]*T{
+	oid.T_int4range: Int4,
+	oid.T_int8range: Int,
+	oid.T_numrange:  Decimal,
+	oid.T_daterange: Date,
+	oid.T_tsrange:   Timestamp,
+	oid.T_tstzrange: TimestampTZ,
+}
+
+// multiRangeRanges maps the Oid of each built-in multirange type to the type
+// of its ranges.
+var multiRangeRanges = map[oid.Oid]*T{
+	oidext.T_int4multirange: Int4Range,
+	oidext.T_int8multirange: Int8Range,
+	oidext.T_nummultirange:  NumRange,
+	oidext.T_datemultirange: DateRange,
+	oidext.T_tsmultirange:   TSRange,
+	oidext.T_tstzmultirange: TSTZRange,
+}
+
 // TupleLabels returns a slice containing the labels of each tuple field. This
 // is nil for types not in the TupleFamily, or if the tuple type does not
 // specify labels.
@@ -1440,6 +1751,29 @@ func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
 }
 
+// IsDomain returns whether or not t is a user defined DOMAIN type. Domain types
+// share the family, width and other attributes of their base type, but keep
+// the OID of the domain.
+func (t *T) IsDomain() bool {
+	if !t.UserDefined() {
+		return false
+	}
+	switch t.Family() {
+	case EnumFamily, TupleFamily, ArrayFamily:
+		return false
+	}
+	return true
+}
+
+// DomainBaseType returns the base type of a hydrated DOMAIN type. If t is not
+// a domain type, t is returned.
+func (t *T) DomainBaseType() *T {
+	if t.TypeMeta.DomainData == nil {
+		return t
+	}
+	return t.TypeMeta.DomainData.BaseType
+}
+
 // IsOIDUserDefinedType returns whether or not o corresponds to a user
 // defined type.
 func IsOIDUserDefinedType(o oid.Oid) bool {
@@ -1461,9 +1795,22 @@ var familyNames = map[Family]string{
 	GeographyFamily:      "geography",
 	GeometryFamily:       "geometry",
 	INetFamily:           "inet",
+	CIDRFamily:           "cidr",
+	MacAddrFamily:        "macaddr",
+	MacAddr8Family:       "macaddr8",
+	PointFamily:          "point",
+	LSegFamily:           "lseg",
+	BoxFamily:            "box",
+	PathFamily:           "path",
+	PolygonFamily:        "polygon",
+	LineFamily:           "line",
+	CircleFamily:         "circle",
+	RangeFamily:          "range",
+	MultiRangeFamily:     "multirange",
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
 	StringFamily:         "string",
@@ -1477,6 +1824,7 @@ var familyNames = map[Family]string{
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
 	VoidFamily:           "void",
+	TriggerFamily:        "trigger",
 	EncodedKeyFamily:     "encodedkey",
 }
 
@@ -1499,6 +1847,13 @@ func (f Family) Name() string {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return "unknown_domain"
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1572,6 +1927,9 @@ func (t *T) Name() string {
 		}
 		return t.TypeMeta.Name.Basename()
 
+	case RangeFamily, MultiRangeFamily:
+		return t.PGName()
+
 	default:
 		return fam.Name()
 	}
@@ -1636,6 +1994,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1694,6 +2055,28 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return t.Name() + t.InternalType.GeoMetadata.SQLString()
 	case INetFamily:
 		return "inet"
+	case CIDRFamily:
+		return "cidr"
+	case MacAddrFamily:
+		return "macaddr"
+	case MacAddr8Family:
+		return "macaddr8"
+	case PointFamily:
+		return "point"
+	case LSegFamily:
+		return "lseg"
+	case BoxFamily:
+		return "box"
+	case PathFamily:
+		return "path"
+	case PolygonFamily:
+		return "polygon"
+	case LineFamily:
+		return "line"
+	case CircleFamily:
+		return "circle"
+	case RangeFamily, MultiRangeFamily:
+		return t.PGName()
 	case IntFamily:
 		switch t.Width() {
 		case 16:
@@ -1735,6 +2118,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		}
 	case PGLSNFamily:
 		return "pg_lsn"
+	case JsonpathFamily:
+		return "jsonpath"
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1808,6 +2193,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "uuid"
 	case VoidFamily:
 		return "void"
+	case TriggerFamily:
+		return "trigger"
 	case EnumFamily:
 		return t.TypeMeta.Name.Basename()
 	default:
@@ -1825,6 +2212,10 @@ func (t *T) InformationSchemaName() string {
 	if t.Family() == ArrayFamily {
 		return "ARRAY"
 	}
+	// Domains are described by their base type.
+	if dd := t.TypeMeta.DomainData; dd != nil {
+		return dd.BaseType.InformationSchemaName()
+	}
 	// TypeMeta attributes are populated only when it is user defined type.
 	if t.TypeMeta.Name != nil {
 		return "USER-DEFINED"
@@ -1836,6 +2227,12 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1948,12 +2345,14 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		// Show the redacted SQLString output with an un-redacted prefix to indicate
 		// that the type is user defined (and possibly enum or record).
 		prefix := "TYPE"
-		switch t.Family() {
-		case EnumFamily:
+		switch {
+		case t.IsDomain():
+			prefix = "DOMAIN"
+		case t.Family() == EnumFamily:
 			prefix = "ENUM"
-		case TupleFamily:
+		case t.Family() == TupleFamily:
 			prefix = "RECORD"
-		case ArrayFamily:
+		case t.Family() == ArrayFamily:
 			prefix = "ARRAY"
 		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
@@ -1968,7 +2367,9 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, JsonpathFamily, CIDRFamily, MacAddrFamily,
+		MacAddr8Family, PointFamily, LSegFamily, BoxFamily, PathFamily, PolygonFamily, LineFamily,
+		CircleFamily, RangeFamily, MultiRangeFamily, TriggerFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2043,6 +2444,12 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily, MultiRangeFamily:
+		// Ranges over different element types are not comparable.
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2224,6 +2631,11 @@ func (t *T) Unmarshal(data []byte) error {
 // setting required values. This is necessary to preserve backwards-
 // compatibility with older formats (e.g. restoring database from old backup).
 func (t *T) upgradeType() error {
+	// Domain types keep the OID of the domain rather than the OID of their base
+	// type, so it must not be replaced below.
+	if t.IsDomain() {
+		defer func(o oid.Oid) { t.InternalType.Oid = o }(t.Oid())
+	}
 	switch t.Family() {
 	case IntFamily:
 		// Check VisibleType field that was populated in previous versions.
@@ -2461,6 +2873,14 @@ func (t *T) MarshalTo(data []byte) (int, error) {
 // CRDB. This is necessary to preserve backwards-compatibility in mixed-version
 // scenarios, such as during upgrade.
 func (t *T) downgradeType() error {
+	// Domain types were introduced long after 19.1, and their OID does not
+	// determine a VisibleType.
+	if t.IsDomain() {
+		if t.InternalType.Locale != nil && len(*t.InternalType.Locale) == 0 {
+			t.InternalType.Locale = nil
+		}
+		return nil
+	}
 	// Set Family and VisibleType for 19.1 backwards-compatibility.
 	switch t.Family() {
 	case BitFamily:
@@ -2531,6 +2951,9 @@ func (t *T) downgradeType() error {
 // TODO(andyk): It'd be nice to have this return SqlString() method output,
 // since that is more descriptive.
 func (t *T) String() string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	switch t.Family() {
 	case CollatedStringFamily:
 		if t.Locale() == "" {
@@ -2924,16 +3347,7 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
-	"cidr":          18846,
-	"circle":        21286,
-	"jsonpath":      22513,
-	"line":          21286,
-	"lseg":          21286,
-	"macaddr":       45813,
-	"macaddr8":      45813,
 	"money":         41578,
-	"path":          21286,
 	"pg_lsn":        -1,
 	"txid_snapshot": -1,
 	"xml":           43355,
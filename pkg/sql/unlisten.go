@@ -13,17 +13,23 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html for details.
 func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
-	p.BufferClientNotice(ctx,
-		pgerror.WithSeverity(
-			unimplemented.NewWithIssuef(41522, "CRDB does not support LISTEN, making UNLISTEN a no-op"),
-			"NOTICE",
-		),
-	)
+	var channel string
+	if !n.Star {
+		if n.ChannelName.NumParts > 1 {
+			return nil, pgerror.Newf(pgcode.Syntax, "invalid channel name: %s", n.ChannelName)
+		}
+		channel = n.ChannelName.Object()
+	}
+	if err := p.notifications.unlisten(channel); err != nil {
+		return nil, err
+	}
 	return newZeroNode(nil /* columns */), nil
 }
@@ -878,7 +878,8 @@ CREATE TABLE information_schema.usage_privileges (
 	is_grantable STRING
 )`
 
-// InformationSchemaDomains is an empty table in the information_schema that is not implemented yet
+// InformationSchemaDomains describes the schema of the
+// information_schema.domains table.
 const InformationSchemaDomains = `
 CREATE TABLE information_schema.domains (
 	domain_catalog STRING,
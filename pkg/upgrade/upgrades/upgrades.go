@@ -324,6 +324,18 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.NoPrecondition,
 		createRegionLivenessTables,
 	),
+	upgrade.NewTenantUpgrade(
+		"create system.replication_slots table",
+		toCV(clusterversion.V23_2_ReplicationSlotsTable),
+		upgrade.NoPrecondition,
+		systemReplicationSlotsTableMigration,
+	),
+	upgrade.NewTenantUpgrade(
+		"create system.notifications table",
+		toCV(clusterversion.V23_2_NotificationsTable),
+		upgrade.NoPrecondition,
+		systemNotificationsTableMigration,
+	),
 }
 
 var (
@@ -250,6 +250,98 @@ func ParseINet(s string, dest *IPAddr) error {
 	return nil
 }
 
+// ParseCIDR parses postgres style CIDR types. Unlike INET values, CIDR values
+// must not have any bits set to the right of the mask. If no mask is provided
+// for an IPv4 address, it is inferred from the class of the network and the
+// number of provided octets, as postgres does. See TestIPAddrParseCIDR for
+// examples.
+func ParseCIDR(s string, dest *IPAddr) error {
+	addr, maskStr, hasMask := strings.Cut(s, "/")
+	if getFamily(s) == IPv4family {
+		// Trims IPv4 suffix "." to match postgres compitibility.
+		addr = strings.TrimRight(addr, ".")
+		octetCount := strings.Count(addr, ".") + 1
+		if !hasMask {
+			firstOctet, err := strconv.Atoi(strings.SplitN(addr, ".", 2)[0])
+			if err != nil {
+				return makeCIDRSyntaxError(s)
+			}
+			maskStr = strconv.Itoa(classfulMaskSize(firstOctet, octetCount))
+		}
+		// Unlike INET values, the mask of a CIDR value may cover more octets than
+		// were provided, so we append "0" octets before parsing.
+		for i := octetCount; i < 4; i++ {
+			addr += ".0"
+		}
+	} else if !hasMask {
+		maskStr = "128"
+	}
+	if err := ParseINet(addr+"/"+maskStr, dest); err != nil {
+		return makeCIDRSyntaxError(s)
+	}
+	if network := dest.Network(); !network.Equal(dest) {
+		return pgerror.WithCandidateCode(
+			errors.Errorf("could not parse %q as cidr. value has bits set to right of mask", s),
+			pgcode.InvalidTextRepresentation)
+	}
+	return nil
+}
+
+func makeCIDRSyntaxError(s string) error {
+	return pgerror.WithCandidateCode(
+		errors.Errorf("could not parse %q as cidr", s),
+		pgcode.InvalidTextRepresentation)
+}
+
+// classfulMaskSize returns the mask size of an IPv4 CIDR value without an
+// explicit mask, which is derived from the class of the network and widened to
+// cover all of the provided octets.
+func classfulMaskSize(firstOctet int, octetCount int) int {
+	var maskSize int
+	switch {
+	case firstOctet >= 240:
+		// Class E.
+		maskSize = 32
+	case firstOctet >= 224:
+		// Class D.
+		maskSize = 8
+	case firstOctet >= 192:
+		// Class C.
+		maskSize = 24
+	case firstOctet >= 128:
+		// Class B.
+		maskSize = 16
+	default:
+		// Class A.
+		maskSize = 8
+	}
+	if maskSize < octetCount*8 {
+		maskSize = octetCount * 8
+	}
+	// Class D addresses without additional octets only use the first 4 bits.
+	if maskSize == 8 && firstOctet == 224 {
+		maskSize = 4
+	}
+	return maskSize
+}
+
+// CIDRString formats the IPAddr as a CIDR value, which always includes the
+// mask.
+func (ipAddr IPAddr) CIDRString() string {
+	s := ipAddr.String()
+	if strings.IndexByte(s, '/') == -1 {
+		s += "/" + strconv.Itoa(int(ipAddr.Mask))
+	}
+	return s
+}
+
+// Network returns a new IPAddr with the same mask, where all bits of the IP
+// address to the right of the mask are set to zero.
+func (ipAddr *IPAddr) Network() IPAddr {
+	netmask := ipAddr.Netmask()
+	return IPAddr{Family: ipAddr.Family, Mask: ipAddr.Mask, Addr: ipAddr.Addr.and(netmask.Addr)}
+}
+
 // RandIPAddr generates a random IPAddr. This includes random mask size and IP
 // family.
 func RandIPAddr(rng *rand.Rand) IPAddr {
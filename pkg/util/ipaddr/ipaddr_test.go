@@ -101,6 +101,84 @@ func TestIPAddrParseInet(t *testing.T) {
 	}
 }
 
+func TestIPAddrParseCIDR(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+		err string
+	}{
+		{"192.168.100.128/25", "192.168.100.128/25", ""},
+		{"192.168/24", "192.168.0.0/24", ""},
+		{"192.168/25", "192.168.0.0/25", ""},
+		{"192.168.1", "192.168.1.0/24", ""},
+		{"192.168", "192.168.0.0/24", ""},
+		{"128.1", "128.1.0.0/16", ""},
+		{"128", "128.0.0.0/16", ""},
+		{"128.1.2", "128.1.2.0/24", ""},
+		{"10.1.2", "10.1.2.0/24", ""},
+		{"10.1", "10.1.0.0/16", ""},
+		{"10", "10.0.0.0/8", ""},
+		{"224", "224.0.0.0/4", ""},
+		{"10.1.2.3/32", "10.1.2.3/32", ""},
+		{"10.1.2.3", "10.1.2.3/32", ""},
+		{"2001:4f8:3:ba::/64", "2001:4f8:3:ba::/64", ""},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/128", "2001:4f8:3:ba:2e0:81ff:fe22:d1f1/128", ""},
+		{"::ffff:1.2.3.0/120", "::ffff:1.2.3.0/120", ""},
+		{"::ffff:1.2.3.0", "::ffff:1.2.3.0/128", ""},
+
+		{"192.168.1.2/24", "", "value has bits set to right of mask"},
+		{"10.1/8", "", "value has bits set to right of mask"},
+		{"2001:4f8:3:ba::1/64", "", "value has bits set to right of mask"},
+		{"abc", "", "as cidr"},
+		{"192.168.1.2/33", "", "as cidr"},
+		{"", "", "as cidr"},
+	}
+	for i, testCase := range testCases {
+		var actual IPAddr
+		err := ParseCIDR(testCase.s, &actual)
+		if testCase.err != "" {
+			if err == nil {
+				t.Errorf("%d: ParseCIDR(%s) expected error %s", i, testCase.s, testCase.err)
+			} else if !strings.Contains(err.Error(), testCase.err) {
+				t.Errorf("%d: ParseCIDR(%s) caused an incorrect error actual:%s, expected:%s", i, testCase.s,
+					err, testCase.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: ParseCIDR(%s) caused an unexpected error:%s", i, testCase.s, err)
+		} else if s := actual.CIDRString(); s != testCase.exp {
+			t.Errorf("%d: ParseCIDR(%s) actual:%s does not match expected:%s", i, testCase.s, s,
+				testCase.exp)
+		}
+	}
+}
+
+func TestIPAddrNetwork(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+	}{
+		{"192.168.1.2/24", "192.168.1.0/24"},
+		{"192.168.1.2", "192.168.1.2"},
+		{"192.168.1.2/0", "0.0.0.0/0"},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/64", "2001:4f8:3:ba::/64"},
+		{"2001:4f8:3:ba:2e0:81ff:fe22:d1f1/100", "2001:4f8:3:ba:2e0:81ff:f000:0/100"},
+		{"::ffff:1.2.3.4/120", "::ffff:1.2.3.0/120"},
+	}
+	for i, testCase := range testCases {
+		var ip IPAddr
+		if err := ParseINet(testCase.s, &ip); err != nil {
+			t.Fatalf("%d: Bad test input s:%s", i, testCase.s)
+		}
+		network := ip.Network()
+		if actual := network.String(); actual != testCase.exp {
+			t.Errorf("%d: Network(%q) actual:%v does not match expected:%v", i, testCase.s, actual,
+				testCase.exp)
+		}
+	}
+}
+
 func TestIPAddrBinaryMarshalling(t *testing.T) {
 	testCases := []struct {
 		input *IPAddr
@@ -1118,6 +1118,57 @@ func EncodeExistsInvertedIndexSpans(
 	), nil
 }
 
+// EncodeKeyPathExistsInvertedIndexSpans takes in a key prefix and returns the
+// spans that must be scanned in the inverted index to find the objects in the
+// index that contain the given path of object keys, e.g. the path a, b for
+// '{"a": {"b": 1}}'. If unwrapArrays is true, the spans also cover paths where
+// any of the objects along the path is an element of an array, e.g.
+// '{"a": [{"b": 1}]}', as in the lax mode of jsonpath member accessors.
+//
+// The returned inverted expression is never tight.
+//
+// The input inKey is prefixed to the keys in all returned spans.
+func EncodeKeyPathExistsInvertedIndexSpans(
+	b []byte, keys []string, unwrapArrays bool,
+) (invertedExpr inverted.Expression, err error) {
+	if len(keys) == 0 {
+		return nil, errors.AssertionFailedf("expected at least one key")
+	}
+	prefixes := [][]byte{encoding.EncodeJSONAscending(b)}
+	for i, k := range keys {
+		if unwrapArrays {
+			n := len(prefixes)
+			for _, p := range prefixes[:n] {
+				prefixes = append(prefixes, encoding.EncodeArrayAscending(p[:len(p):len(p)]))
+			}
+		}
+		if i == len(keys)-1 {
+			break
+		}
+		for j, p := range prefixes {
+			prefixes[j] = encoding.EncodeJSONKeyStringAscending(p[:len(p):len(p)], k, false /* end */)
+		}
+	}
+	// The last key is encoded as in EncodeExistsInvertedIndexSpans, so that the
+	// spans cover both keys that point to scalars and keys that point to
+	// non-scalars.
+	last := keys[len(keys)-1]
+	for _, p := range prefixes {
+		objectKey := encoding.EncodeJSONKeyStringAscending(p[:len(p):len(p)], last, true /* end */)
+		span := inverted.Span{
+			Start: objectKey,
+			End:   keysbase.PrefixEnd(encoding.AddJSONPathSeparator(objectKey)),
+		}
+		expr := inverted.ExprForSpan(span, false /* tight */)
+		if invertedExpr == nil {
+			invertedExpr = expr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, expr)
+		}
+	}
+	return invertedExpr, nil
+}
+
 func (j jsonNull) encodeInvertedIndexKeys(b []byte) ([][]byte, error) {
 	b = encoding.AddJSONPathTerminator(b)
 	return [][]byte{encoding.EncodeNullAscending(b)}, nil
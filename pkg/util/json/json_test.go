@@ -2038,6 +2038,68 @@ func TestEncodeExistsJSONInvertedIndexSpans(t *testing.T) {
 	}
 }
 
+func TestEncodeKeyPathExistsJSONInvertedIndexSpans(t *testing.T) {
+	testCases := []struct {
+		indexedValue string
+		keys         []string
+		unwrapArrays bool
+		expected     bool
+	}{
+		// This test uses EncodeInvertedIndexKeys and
+		// EncodeKeyPathExistsInvertedIndexSpans to determine if the spans
+		// produced from the path of keys will include or exclude the keys
+		// produced by the JSON value.
+		{`{"a": 1}`, []string{"a"}, false, true},
+		{`{"a": {}}`, []string{"a"}, false, true},
+		{`{"a": {"b": 1}}`, []string{"a"}, false, true},
+		{`{"a": {"b": 1}}`, []string{"a", "b"}, false, true},
+		{`{"a": {"b": []}}`, []string{"a", "b"}, false, true},
+		{`{"a": {"b": [1, 2]}}`, []string{"a", "b"}, false, true},
+		{`{"a": [{"b": 1}]}`, []string{"a", "b"}, true, true},
+		{`[{"a": {"b": 1}}]`, []string{"a", "b"}, true, true},
+		{`[{"a": [{"b": 1}]}]`, []string{"a", "b"}, true, true},
+
+		// Test negative cases.
+		{`{"ab": 1}`, []string{"a"}, false, false},
+		{`{"b": {"a": 1}}`, []string{"a"}, false, false},
+		{`{"a": {"bc": 1}}`, []string{"a", "b"}, false, false},
+		{`{"a": {}}`, []string{"a", "b"}, false, false},
+		{`{"a": [{"b": 1}]}`, []string{"a", "b"}, false, false},
+		{`[{"a": {"b": 1}}]`, []string{"a", "b"}, false, false},
+		{`{"a": [[{"b": 1}]]}`, []string{"a", "b"}, true, false},
+		{`["a"]`, []string{"a"}, true, false},
+	}
+
+	for _, c := range testCases {
+		indexedValue := parseJSON(t, c.indexedValue)
+		keys, err := EncodeInvertedIndexKeys(nil, indexedValue)
+		require.NoError(t, err)
+
+		invertedExpr, err := EncodeKeyPathExistsInvertedIndexSpans(nil, c.keys, c.unwrapArrays)
+		require.NoError(t, err)
+
+		spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+		if !ok {
+			t.Fatalf("invertedExpr %v is not a SpanExpression", invertedExpr)
+		}
+
+		// Spans should never be tight for key paths.
+		if spanExpr.Tight {
+			t.Errorf("For %v, expected tight=false, but got true", c.keys)
+		}
+
+		containsKeys, err := spanExpr.ContainsKeys(keys)
+		require.NoError(t, err)
+		if containsKeys != c.expected {
+			if c.expected {
+				t.Errorf("expected spans of %v to include %s but they did not", c.keys, c.indexedValue)
+			} else {
+				t.Errorf("expected spans of %v not to include %s but they did", c.keys, c.indexedValue)
+			}
+		}
+	}
+}
+
 func TestNumInvertedIndexEntries(t *testing.T) {
 	testCases := []struct {
 		value    string
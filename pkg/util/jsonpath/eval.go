@@ -0,0 +1,1120 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// errSuppressible marks errors that are suppressed when a path is evaluated
+// in silent mode, and that evaluate to unknown within predicates. Other
+// errors, like references to undefined variables, are always returned.
+var errSuppressible = errors.New("suppressible jsonpath error")
+
+func suppressible(err error) error {
+	return errors.Mark(err, errSuppressible)
+}
+
+func isSuppressible(err error) bool {
+	return errors.Is(err, errSuppressible)
+}
+
+var (
+	// decimalCtx is used for division and modulo, which require a bounded
+	// precision. It matches the precision of the SQL DECIMAL type.
+	decimalCtx = &apd.Context{
+		Precision:   20,
+		Rounding:    apd.RoundHalfUp,
+		MaxExponent: 2000,
+		MinExponent: -2000,
+		Traps:       apd.DefaultTraps,
+	}
+	// exactCtx is used for the other arithmetic operations.
+	exactCtx = decimalCtx.WithPrecision(0)
+)
+
+// boolResult is the result of evaluating a predicate, which uses three-valued
+// logic.
+type boolResult int
+
+const (
+	boolFalse boolResult = iota
+	boolTrue
+	boolUnknown
+)
+
+func makeBoolResult(b bool) boolResult {
+	if b {
+		return boolTrue
+	}
+	return boolFalse
+}
+
+// evaluator holds the state of the evaluation of a path.
+type evaluator struct {
+	strict bool
+	root   json.JSON
+	vars   json.JSON
+	// current is the item that @ refers to.
+	current json.JSON
+	// ignoreStructuralErrors is true if errors due to the structure of the
+	// document, like missing keys, should produce empty results instead. It is
+	// true in lax mode, and within .** accessors.
+	ignoreStructuralErrors bool
+	// innermostArraySize is the size of the array that last refers to, or -1
+	// outside of array subscripts.
+	innermostArraySize int
+	// keyValueID is used to assign ids to the objects produced by the
+	// .keyvalue() method.
+	keyValueID int
+}
+
+// Query evaluates the path against target and returns the sequence of items it
+// produces. vars is an object which contains the values of the variables
+// referenced by the path; it may be nil. If silent is true, errors that are
+// suppressible according to the SQL/JSON standard produce an empty result.
+func Query(p *Path, target, vars json.JSON, silent bool) ([]json.JSON, error) {
+	res, _, err := execute(p, target, vars, true /* needResult */)
+	if err != nil {
+		if silent && isSuppressible(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// Exists returns whether the path produces any items when evaluated against
+// target. ok is false if the evaluation failed with an error that was
+// suppressed because silent is true, in which case the result is unknown.
+func Exists(p *Path, target, vars json.JSON, silent bool) (exists bool, ok bool, err error) {
+	_, exists, err = execute(p, target, vars, false /* needResult */)
+	if err != nil {
+		if silent && isSuppressible(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return exists, true, nil
+}
+
+// Match returns the result of a path that is a predicate, evaluated against
+// target. ok is false if the result is null or unknown, or if the evaluation
+// failed with an error that was suppressed because silent is true.
+func Match(p *Path, target, vars json.JSON, silent bool) (match bool, ok bool, err error) {
+	res, _, err := execute(p, target, vars, true /* needResult */)
+	if err != nil {
+		if silent && isSuppressible(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	if len(res) == 1 {
+		switch res[0].Type() {
+		case json.TrueJSONType:
+			return true, true, nil
+		case json.FalseJSONType:
+			return false, true, nil
+		case json.NullJSONType:
+			return false, false, nil
+		}
+	}
+	if silent {
+		return false, false, nil
+	}
+	return false, false, pgerror.New(pgcode.SingletonSQLJSONItemRequired,
+		"single boolean result is expected")
+}
+
+// execute evaluates the path against target, and returns the resulting items
+// and whether there are any. If needResult is false, the evaluation may stop
+// at the first item that is produced, and the items are not returned.
+func execute(
+	p *Path, target, vars json.JSON, needResult bool,
+) (_ []json.JSON, any bool, _ error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, false, errors.WithDetail(
+			pgerror.New(pgcode.InvalidParameterValue, `"vars" argument is not an object`),
+			`Jsonpath parameters should be encoded as key-value pairs of "vars" object.`,
+		)
+	}
+	e := evaluator{
+		strict:                 p.strict,
+		root:                   target,
+		vars:                   vars,
+		current:                target,
+		ignoreStructuralErrors: !p.strict,
+		innermostArraySize:     -1,
+	}
+	var found []json.JSON
+	// In strict mode, the complete result has to be computed to make sure that
+	// the evaluation does not produce any errors.
+	collect := needResult || p.strict
+	any, err := e.eval(p.root, target, &found, collect, e.autoUnwrap())
+	if err != nil {
+		return nil, false, err
+	}
+	return found, any, nil
+}
+
+// autoUnwrap returns whether arrays are automatically unwrapped by accessors,
+// which is the case in lax mode.
+func (e *evaluator) autoUnwrap() bool {
+	return !e.strict
+}
+
+// autoWrap returns whether non-array items are automatically wrapped into
+// arrays by array accessors, which is the case in lax mode.
+func (e *evaluator) autoWrap() bool {
+	return !e.strict
+}
+
+// eval evaluates the item against j and appends the resulting items to found.
+// If collect is false, the evaluation stops at the first item that is
+// produced, which is then not appended to found. The returned bool is true if
+// any item was produced. If unwrap is true, arrays are unwrapped before
+// accessors that require objects are applied.
+func (e *evaluator) eval(
+	it *item, j json.JSON, found *[]json.JSON, collect bool, unwrap bool,
+) (bool, error) {
+	switch it.typ {
+	case nullItem:
+		return e.evalNext(it, json.NullJSONValue, found, collect)
+	case boolItem:
+		return e.evalNext(it, json.FromBool(it.b), found, collect)
+	case numericItem:
+		return e.evalNext(it, json.FromDecimal(it.num), found, collect)
+	case stringItem:
+		return e.evalNext(it, json.FromString(it.str), found, collect)
+	case rootItem:
+		return e.evalNext(it, e.root, found, collect)
+	case currentItem:
+		return e.evalNext(it, e.current, found, collect)
+	case variableItem:
+		v, err := e.variable(it.str)
+		if err != nil {
+			return false, err
+		}
+		return e.evalNext(it, v, found, collect)
+	case lastItem:
+		if e.innermostArraySize < 0 {
+			return false, errors.AssertionFailedf("evaluating jsonpath LAST outside of array subscript")
+		}
+		return e.evalNext(it, json.FromInt(e.innermostArraySize-1), found, collect)
+
+	case keyItem:
+		switch j.Type() {
+		case json.ObjectJSONType:
+			v, err := j.FetchValKey(it.str)
+			if err != nil {
+				return false, err
+			}
+			if v != nil {
+				return e.evalNext(it, v, found, collect)
+			}
+			if !e.ignoreStructuralErrors {
+				return false, suppressible(pgerror.Newf(pgcode.SQLJSONMemberNotFound,
+					"JSON object does not contain key %s", formatKey(it.str)))
+			}
+			return false, nil
+		case json.ArrayJSONType:
+			if unwrap {
+				return e.evalUnwrapped(it, j, found, collect)
+			}
+		}
+		if !e.ignoreStructuralErrors {
+			return false, suppressible(pgerror.New(pgcode.SQLJSONMemberNotFound,
+				"jsonpath member accessor can only be applied to an object"))
+		}
+		return false, nil
+
+	case anyKeyItem:
+		switch j.Type() {
+		case json.ObjectJSONType:
+			return e.evalAny(it.next, j, found, collect, 1 /* level */, 1 /* first */, 1, /* last */
+				false /* ignoreStructuralErrors */, e.autoUnwrap())
+		case json.ArrayJSONType:
+			if unwrap {
+				return e.evalUnwrapped(it, j, found, collect)
+			}
+		}
+		if !e.ignoreStructuralErrors {
+			return false, suppressible(pgerror.New(pgcode.SQLJSONObjectNotFound,
+				"jsonpath wildcard member accessor can only be applied to an object"))
+		}
+		return false, nil
+
+	case anyArrayItem:
+		if j.Type() == json.ArrayJSONType {
+			var any bool
+			for i, n := 0, j.Len(); i < n; i++ {
+				elem, err := j.FetchValIdx(i)
+				if err != nil {
+					return false, err
+				}
+				ok, err := e.evalNext(it, elem, found, collect)
+				if err != nil {
+					return false, err
+				}
+				if ok {
+					any = true
+					if !collect {
+						return true, nil
+					}
+				}
+			}
+			return any, nil
+		}
+		if e.autoWrap() {
+			return e.evalNext(it, j, found, collect)
+		}
+		if !e.ignoreStructuralErrors {
+			return false, suppressible(pgerror.New(pgcode.SQLJSONArrayNotFound,
+				"jsonpath wildcard array accessor can only be applied to an array"))
+		}
+		return false, nil
+
+	case indexArrayItem:
+		return e.evalIndexArray(it, j, found, collect)
+
+	case anyItem:
+		var any bool
+		// Level 0 is the item itself.
+		if it.first == 0 {
+			saved := e.ignoreStructuralErrors
+			e.ignoreStructuralErrors = true
+			ok, err := e.evalNext(it, j, found, collect)
+			e.ignoreStructuralErrors = saved
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				any = true
+				if !collect {
+					return true, nil
+				}
+			}
+		}
+		if isContainer(j) {
+			ok, err := e.evalAny(it.next, j, found, collect, 1 /* level */, it.first, it.last,
+				true /* ignoreStructuralErrors */, e.autoUnwrap())
+			if err != nil {
+				return false, err
+			}
+			any = any || ok
+		}
+		return any, nil
+
+	case filterItem:
+		if unwrap && j.Type() == json.ArrayJSONType {
+			return e.evalUnwrapped(it, j, found, collect)
+		}
+		res, err := e.evalNestedPredicate(it.left, j)
+		if err != nil {
+			return false, err
+		}
+		if res != boolTrue {
+			return false, nil
+		}
+		return e.evalNext(it, j, found, collect)
+
+	case typeMethod:
+		return e.evalNext(it, json.FromString(typeName(j)), found, collect)
+
+	case sizeMethod:
+		size := 1
+		if j.Type() == json.ArrayJSONType {
+			size = j.Len()
+		} else if !e.autoWrap() {
+			if !e.ignoreStructuralErrors {
+				return false, suppressible(pgerror.Newf(pgcode.SQLJSONArrayNotFound,
+					"jsonpath item method .%s() can only be applied to an array", it.typ.operatorName()))
+			}
+			return false, nil
+		}
+		return e.evalNext(it, json.FromInt(size), found, collect)
+
+	case absMethod, floorMethod, ceilingMethod:
+		if unwrap && j.Type() == json.ArrayJSONType {
+			return e.evalUnwrapped(it, j, found, collect)
+		}
+		d, ok := j.AsDecimal()
+		if !ok {
+			return false, suppressible(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", it.typ.operatorName()))
+		}
+		var res apd.Decimal
+		var err error
+		switch it.typ {
+		case absMethod:
+			res.Abs(d)
+		case floorMethod:
+			_, err = exactCtx.Floor(&res, d)
+		case ceilingMethod:
+			_, err = exactCtx.Ceil(&res, d)
+		}
+		if err != nil {
+			return false, err
+		}
+		return e.evalNext(it, json.FromDecimal(res), found, collect)
+
+	case doubleMethod:
+		if unwrap && j.Type() == json.ArrayJSONType {
+			return e.evalUnwrapped(it, j, found, collect)
+		}
+		res, err := e.evalDouble(it, j)
+		if err != nil {
+			return false, err
+		}
+		return e.evalNext(it, res, found, collect)
+
+	case keyValueMethod:
+		if unwrap && j.Type() == json.ArrayJSONType {
+			return e.evalUnwrapped(it, j, found, collect)
+		}
+		return e.evalKeyValue(it, j, found, collect)
+
+	case datetimeMethod:
+		return false, unimplemented.NewWithIssue(22513, "jsonpath item method .datetime() is not supported")
+
+	case addItem, subItem, mulItem, divItem, modItem:
+		return e.evalBinaryArithmetic(it, j, found, collect)
+
+	case plusItem, minusItem:
+		return e.evalUnaryArithmetic(it, j, found, collect)
+
+	default:
+		if !it.typ.isPredicate() {
+			return false, errors.AssertionFailedf("unexpected jsonpath item type %d", it.typ)
+		}
+		res, err := e.evalPredicate(it, j)
+		if err != nil {
+			return false, err
+		}
+		var v json.JSON
+		switch res {
+		case boolTrue:
+			v = json.TrueJSONValue
+		case boolFalse:
+			v = json.FalseJSONValue
+		default:
+			v = json.NullJSONValue
+		}
+		return e.evalNext(it, v, found, collect)
+	}
+}
+
+// evalNext evaluates the items chained after it against j, or appends j to
+// found if it is the last item.
+func (e *evaluator) evalNext(
+	it *item, j json.JSON, found *[]json.JSON, collect bool,
+) (bool, error) {
+	if it.next != nil {
+		return e.eval(it.next, j, found, collect, e.autoUnwrap())
+	}
+	if collect {
+		*found = append(*found, j)
+	}
+	return true, nil
+}
+
+// evalUnwrapped evaluates the item against each element of the array j,
+// without unwrapping nested arrays.
+func (e *evaluator) evalUnwrapped(
+	it *item, j json.JSON, found *[]json.JSON, collect bool,
+) (bool, error) {
+	var any bool
+	for i, n := 0, j.Len(); i < n; i++ {
+		elem, err := j.FetchValIdx(i)
+		if err != nil {
+			return false, err
+		}
+		ok, err := e.eval(it, elem, found, collect, false /* unwrap */)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			any = true
+			if !collect {
+				return true, nil
+			}
+		}
+	}
+	return any, nil
+}
+
+// evalAny implements the .** and .* accessors, by evaluating next against the
+// values of the container j and, recursively, the values nested within it,
+// for the levels between first and last.
+func (e *evaluator) evalAny(
+	next *item,
+	j json.JSON,
+	found *[]json.JSON,
+	collect bool,
+	level, first, last int,
+	ignoreStructuralErrors bool,
+	unwrap bool,
+) (bool, error) {
+	if last != maxLevel && level > last {
+		return false, nil
+	}
+	values, err := containerValues(j)
+	if err != nil {
+		return false, err
+	}
+	var any bool
+	for _, v := range values {
+		// The last level is made up of the leaves of the document.
+		if (first != maxLevel && level >= first) || (first == maxLevel && last == maxLevel && !isContainer(v)) {
+			if next != nil {
+				saved := e.ignoreStructuralErrors
+				if ignoreStructuralErrors {
+					e.ignoreStructuralErrors = true
+				}
+				ok, err := e.eval(next, v, found, collect, unwrap)
+				e.ignoreStructuralErrors = saved
+				if err != nil {
+					return false, err
+				}
+				if ok {
+					any = true
+					if !collect {
+						return true, nil
+					}
+				}
+			} else {
+				any = true
+				if !collect {
+					return true, nil
+				}
+				*found = append(*found, v)
+			}
+		}
+		if (last == maxLevel || level < last) && isContainer(v) {
+			ok, err := e.evalAny(next, v, found, collect, level+1, first, last, ignoreStructuralErrors, unwrap)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				any = true
+				if !collect {
+					return true, nil
+				}
+			}
+		}
+	}
+	return any, nil
+}
+
+func (e *evaluator) evalIndexArray(
+	it *item, j json.JSON, found *[]json.JSON, collect bool,
+) (bool, error) {
+	isArray := j.Type() == json.ArrayJSONType
+	if !isArray && !e.autoWrap() {
+		if !e.ignoreStructuralErrors {
+			return false, suppressible(pgerror.New(pgcode.SQLJSONArrayNotFound,
+				"jsonpath array accessor can only be applied to an array"))
+		}
+		return false, nil
+	}
+	size := 1
+	if isArray {
+		size = j.Len()
+	}
+	savedSize := e.innermostArraySize
+	e.innermostArraySize = size
+	defer func() { e.innermostArraySize = savedSize }()
+
+	var any bool
+	for _, s := range it.subscripts {
+		from, err := e.arrayIndex(s.from, j)
+		if err != nil {
+			return false, err
+		}
+		to := from
+		if s.to != nil {
+			if to, err = e.arrayIndex(s.to, j); err != nil {
+				return false, err
+			}
+		}
+		if !e.ignoreStructuralErrors && (from < 0 || from > to || to >= size) {
+			return false, suppressible(pgerror.New(pgcode.InvalidSQLJSONSubscript,
+				"jsonpath array subscript is out of bounds"))
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to >= size {
+			to = size - 1
+		}
+		for idx := from; idx <= to; idx++ {
+			elem := j
+			if isArray {
+				if elem, err = j.FetchValIdx(idx); err != nil {
+					return false, err
+				}
+			}
+			ok, err := e.evalNext(it, elem, found, collect)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				any = true
+				if !collect {
+					return true, nil
+				}
+			}
+		}
+	}
+	return any, nil
+}
+
+// arrayIndex evaluates an array subscript, which has to produce a single
+// numeric value. The value is truncated to an integer.
+func (e *evaluator) arrayIndex(it *item, j json.JSON) (int, error) {
+	var found []json.JSON
+	if _, err := e.eval(it, j, &found, true /* collect */, e.autoUnwrap()); err != nil {
+		return 0, err
+	}
+	var d *apd.Decimal
+	if len(found) == 1 {
+		d, _ = found[0].AsDecimal()
+	}
+	if d == nil {
+		return 0, suppressible(pgerror.New(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value"))
+	}
+	// Subscripts are truncated towards zero.
+	var truncated apd.Decimal
+	if _, err := truncCtx.RoundToIntegralValue(&truncated, d); err != nil {
+		return 0, err
+	}
+	idx, err := truncated.Int64()
+	if err != nil || idx > math.MaxInt32 || idx < math.MinInt32 {
+		return 0, suppressible(pgerror.New(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is out of integer range"))
+	}
+	return int(idx), nil
+}
+
+// truncCtx rounds towards zero.
+var truncCtx = func() *apd.Context {
+	c := exactCtx.WithPrecision(0)
+	c.Rounding = apd.RoundDown
+	return c
+}()
+
+// evalDouble implements the .double() item method.
+func (e *evaluator) evalDouble(it *item, j json.JSON) (json.JSON, error) {
+	switch j.Type() {
+	case json.NumberJSONType:
+		d, _ := j.AsDecimal()
+		f, err := d.Float64()
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, suppressible(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"numeric argument of jsonpath item method .%s() is out of range for type double precision",
+				it.typ.operatorName()))
+		}
+		return j, nil
+	case json.StringJSONType:
+		s, err := j.AsText()
+		if err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(*s), 64)
+		if err != nil {
+			if !errors.Is(err, strconv.ErrRange) {
+				return nil, suppressible(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number",
+					it.typ.operatorName()))
+			}
+		}
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, suppressible(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"string argument of jsonpath item method .%s() is not a valid representation of a double precision number",
+				it.typ.operatorName()))
+		}
+		return json.FromFloat64(f)
+	}
+	return nil, suppressible(pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+		"jsonpath item method .%s() can only be applied to a string or numeric value",
+		it.typ.operatorName()))
+}
+
+// evalKeyValue implements the .keyvalue() item method, which produces an
+// object with the key, value and an identifier of the containing object for
+// each member of the object j.
+func (e *evaluator) evalKeyValue(
+	it *item, j json.JSON, found *[]json.JSON, collect bool,
+) (bool, error) {
+	if j.Type() != json.ObjectJSONType {
+		return false, suppressible(pgerror.Newf(pgcode.SQLJSONObjectNotFound,
+			"jsonpath item method .%s() can only be applied to an object", it.typ.operatorName()))
+	}
+	iter, err := j.ObjectIter()
+	if err != nil {
+		return false, err
+	}
+	id := e.keyValueID
+	e.keyValueID++
+	var any bool
+	for iter.Next() {
+		b := json.NewObjectBuilder(3)
+		b.Add("id", json.FromInt(id))
+		b.Add("key", json.FromString(iter.Key()))
+		b.Add("value", iter.Value())
+		ok, err := e.evalNext(it, b.Build(), found, collect)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			any = true
+			if !collect {
+				return true, nil
+			}
+		}
+	}
+	return any, nil
+}
+
+// evalUnwrappedResult evaluates the item against j and, in lax mode, unwraps
+// the arrays in the result.
+func (e *evaluator) evalUnwrappedResult(it *item, j json.JSON, unwrap bool) ([]json.JSON, error) {
+	var found []json.JSON
+	if _, err := e.eval(it, j, &found, true /* collect */, e.autoUnwrap()); err != nil {
+		return nil, err
+	}
+	if !unwrap || !e.autoUnwrap() {
+		return found, nil
+	}
+	var res []json.JSON
+	for _, v := range found {
+		if v.Type() != json.ArrayJSONType {
+			res = append(res, v)
+			continue
+		}
+		elems, err := containerValues(v)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, elems...)
+	}
+	return res, nil
+}
+
+// singleNumeric returns the numeric value of the only item in vals, or nil if
+// vals does not consist of a single numeric item.
+func singleNumeric(vals []json.JSON) *apd.Decimal {
+	if len(vals) != 1 {
+		return nil
+	}
+	d, ok := vals[0].AsDecimal()
+	if !ok {
+		return nil
+	}
+	return d
+}
+
+func (e *evaluator) evalBinaryArithmetic(
+	it *item, j json.JSON, found *[]json.JSON, collect bool,
+) (bool, error) {
+	lvals, err := e.evalUnwrappedResult(it.left, j, true /* unwrap */)
+	if err != nil {
+		return false, err
+	}
+	rvals, err := e.evalUnwrappedResult(it.right, j, true /* unwrap */)
+	if err != nil {
+		return false, err
+	}
+	l := singleNumeric(lvals)
+	if l == nil {
+		return false, suppressible(pgerror.Newf(pgcode.SingletonSQLJSONItemRequired,
+			"left operand of jsonpath operator %s is not a single numeric value", it.typ.operatorName()))
+	}
+	r := singleNumeric(rvals)
+	if r == nil {
+		return false, suppressible(pgerror.Newf(pgcode.SingletonSQLJSONItemRequired,
+			"right operand of jsonpath operator %s is not a single numeric value", it.typ.operatorName()))
+	}
+	var res apd.Decimal
+	switch it.typ {
+	case addItem:
+		_, err = exactCtx.Add(&res, l, r)
+	case subItem:
+		_, err = exactCtx.Sub(&res, l, r)
+	case mulItem:
+		_, err = exactCtx.Mul(&res, l, r)
+	case divItem, modItem:
+		if r.IsZero() {
+			return false, suppressible(pgerror.New(pgcode.DivisionByZero, "division by zero"))
+		}
+		if it.typ == divItem {
+			_, err = decimalCtx.Quo(&res, l, r)
+		} else {
+			_, err = decimalCtx.Rem(&res, l, r)
+		}
+	}
+	if err != nil {
+		return false, suppressible(pgerror.WithCandidateCode(err, pgcode.NumericValueOutOfRange))
+	}
+	return e.evalNext(it, json.FromDecimal(res), found, collect)
+}
+
+func (e *evaluator) evalUnaryArithmetic(
+	it *item, j json.JSON, found *[]json.JSON, collect bool,
+) (bool, error) {
+	vals, err := e.evalUnwrappedResult(it.left, j, true /* unwrap */)
+	if err != nil {
+		return false, err
+	}
+	var any bool
+	for _, v := range vals {
+		d, ok := v.AsDecimal()
+		if !ok {
+			if !collect && it.next == nil {
+				continue
+			}
+			return false, suppressible(pgerror.Newf(pgcode.SQLJSONNumberNotFound,
+				"operand of unary jsonpath operator %s is not a numeric value", it.typ.operatorName()))
+		}
+		if !collect && it.next == nil {
+			return true, nil
+		}
+		if it.typ == minusItem {
+			var neg apd.Decimal
+			neg.Neg(d)
+			v = json.FromDecimal(neg)
+		}
+		ok, err := e.evalNext(it, v, found, collect)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			any = true
+			if !collect {
+				return true, nil
+			}
+		}
+	}
+	return any, nil
+}
+
+// evalNestedPredicate evaluates a predicate within a filter, for which @ refers
+// to j.
+func (e *evaluator) evalNestedPredicate(it *item, j json.JSON) (boolResult, error) {
+	saved := e.current
+	e.current = j
+	defer func() { e.current = saved }()
+	return e.evalPredicate(it, j)
+}
+
+// evalPredicate evaluates a predicate against j. Suppressible errors that occur
+// while evaluating the operands of the predicate produce an unknown result.
+func (e *evaluator) evalPredicate(it *item, j json.JSON) (boolResult, error) {
+	switch it.typ {
+	case andItem:
+		l, err := e.evalPredicate(it.left, j)
+		if err != nil || l == boolFalse {
+			return l, err
+		}
+		r, err := e.evalPredicate(it.right, j)
+		if err != nil {
+			return boolUnknown, err
+		}
+		if l == boolUnknown && r != boolFalse {
+			return boolUnknown, nil
+		}
+		return r, nil
+
+	case orItem:
+		l, err := e.evalPredicate(it.left, j)
+		if err != nil || l == boolTrue {
+			return l, err
+		}
+		r, err := e.evalPredicate(it.right, j)
+		if err != nil {
+			return boolUnknown, err
+		}
+		if l == boolUnknown && r != boolTrue {
+			return boolUnknown, nil
+		}
+		return r, nil
+
+	case notItem:
+		res, err := e.evalPredicate(it.left, j)
+		if err != nil {
+			return boolUnknown, err
+		}
+		switch res {
+		case boolTrue:
+			return boolFalse, nil
+		case boolFalse:
+			return boolTrue, nil
+		}
+		return boolUnknown, nil
+
+	case isUnknownItem:
+		res, err := e.evalPredicate(it.left, j)
+		if err != nil {
+			return boolUnknown, err
+		}
+		return makeBoolResult(res == boolUnknown), nil
+
+	case existsItem:
+		var found []json.JSON
+		ok, err := e.eval(it.left, j, &found, e.strict /* collect */, e.autoUnwrap())
+		if err != nil {
+			if isSuppressible(err) {
+				return boolUnknown, nil
+			}
+			return boolUnknown, err
+		}
+		return makeBoolResult(ok), nil
+
+	case equalItem, notEqualItem, lessItem, lessOrEqualItem, greaterItem, greaterOrEqualItem:
+		return e.evalComparison(it, j, true /* unwrapRight */, func(l, r json.JSON) (boolResult, error) {
+			return compareItems(it.typ, l, r)
+		})
+
+	case startsWithItem:
+		return e.evalComparison(it, j, false /* unwrapRight */, func(l, r json.JSON) (boolResult, error) {
+			if l.Type() != json.StringJSONType || r.Type() != json.StringJSONType {
+				return boolUnknown, nil
+			}
+			s, err := l.AsText()
+			if err != nil {
+				return boolUnknown, err
+			}
+			prefix, err := r.AsText()
+			if err != nil {
+				return boolUnknown, err
+			}
+			return makeBoolResult(strings.HasPrefix(*s, *prefix)), nil
+		})
+
+	case likeRegexItem:
+		return e.evalComparison(it, j, false /* unwrapRight */, func(l, _ json.JSON) (boolResult, error) {
+			if l.Type() != json.StringJSONType {
+				return boolUnknown, nil
+			}
+			s, err := l.AsText()
+			if err != nil {
+				return boolUnknown, err
+			}
+			return makeBoolResult(it.re.MatchString(*s)), nil
+		})
+	}
+	return boolUnknown, errors.AssertionFailedf("unexpected jsonpath predicate type %d", it.typ)
+}
+
+// evalComparison evaluates a predicate with one or two operands. The predicate
+// is true if cmp is true for any pair of items produced by the operands. In
+// strict mode, it is unknown if cmp is unknown for any pair. In lax mode, it is
+// only unknown if cmp is not true for any pair and unknown for some pair.
+func (e *evaluator) evalComparison(
+	it *item, j json.JSON, unwrapRight bool, cmp func(l, r json.JSON) (boolResult, error),
+) (boolResult, error) {
+	lvals, err := e.evalUnwrappedResult(it.left, j, true /* unwrap */)
+	if err != nil {
+		if isSuppressible(err) {
+			return boolUnknown, nil
+		}
+		return boolUnknown, err
+	}
+	rvals := []json.JSON{nil}
+	if it.right != nil {
+		rvals, err = e.evalUnwrappedResult(it.right, j, unwrapRight)
+		if err != nil {
+			if isSuppressible(err) {
+				return boolUnknown, nil
+			}
+			return boolUnknown, err
+		}
+	}
+	var found, unknown bool
+	for _, l := range lvals {
+		for _, r := range rvals {
+			res, err := cmp(l, r)
+			if err != nil {
+				return boolUnknown, err
+			}
+			switch res {
+			case boolUnknown:
+				if e.strict {
+					return boolUnknown, nil
+				}
+				unknown = true
+			case boolTrue:
+				if !e.strict {
+					return boolTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	if found {
+		return boolTrue, nil
+	}
+	if unknown {
+		return boolUnknown, nil
+	}
+	return boolFalse, nil
+}
+
+// compareItems compares two items with the given comparison operator. Only
+// scalars of the same type can be compared; comparing null to any other item
+// is false, except for inequality.
+func compareItems(op itemType, l, r json.JSON) (boolResult, error) {
+	lt, rt := normalizedType(l), normalizedType(r)
+	if lt != rt {
+		if lt == json.NullJSONType || rt == json.NullJSONType {
+			return makeBoolResult(op == notEqualItem), nil
+		}
+		return boolUnknown, nil
+	}
+	var c int
+	switch lt {
+	case json.NullJSONType:
+		c = 0
+	case json.TrueJSONType:
+		lb, _ := l.AsBool()
+		rb, _ := r.AsBool()
+		switch {
+		case lb == rb:
+			c = 0
+		case lb:
+			c = 1
+		default:
+			c = -1
+		}
+	case json.NumberJSONType:
+		ld, _ := l.AsDecimal()
+		rd, _ := r.AsDecimal()
+		c = ld.Cmp(rd)
+	case json.StringJSONType:
+		ls, err := l.AsText()
+		if err != nil {
+			return boolUnknown, err
+		}
+		rs, err := r.AsText()
+		if err != nil {
+			return boolUnknown, err
+		}
+		c = strings.Compare(*ls, *rs)
+	default:
+		// Arrays and objects are not comparable.
+		return boolUnknown, nil
+	}
+	switch op {
+	case equalItem:
+		return makeBoolResult(c == 0), nil
+	case notEqualItem:
+		return makeBoolResult(c != 0), nil
+	case lessItem:
+		return makeBoolResult(c < 0), nil
+	case lessOrEqualItem:
+		return makeBoolResult(c <= 0), nil
+	case greaterItem:
+		return makeBoolResult(c > 0), nil
+	case greaterOrEqualItem:
+		return makeBoolResult(c >= 0), nil
+	}
+	return boolUnknown, errors.AssertionFailedf("unexpected jsonpath comparison type %d", op)
+}
+
+// normalizedType returns the type of j, where both booleans have the same
+// type.
+func normalizedType(j json.JSON) json.Type {
+	if t := j.Type(); t != json.FalseJSONType {
+		return t
+	}
+	return json.TrueJSONType
+}
+
+// variable returns the value of the variable with the given name.
+func (e *evaluator) variable(name string) (json.JSON, error) {
+	if e.vars != nil {
+		v, err := e.vars.FetchValKey(name)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			return v, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject, "could not find jsonpath variable %q", name)
+}
+
+func isContainer(j json.JSON) bool {
+	t := j.Type()
+	return t == json.ArrayJSONType || t == json.ObjectJSONType
+}
+
+// containerValues returns the elements of an array, or the values of an
+// object.
+func containerValues(j json.JSON) ([]json.JSON, error) {
+	switch j.Type() {
+	case json.ArrayJSONType:
+		vals := make([]json.JSON, j.Len())
+		for i := range vals {
+			v, err := j.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = v
+		}
+		return vals, nil
+	case json.ObjectJSONType:
+		iter, err := j.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]json.JSON, 0, j.Len())
+		for iter.Next() {
+			vals = append(vals, iter.Value())
+		}
+		return vals, nil
+	}
+	return nil, nil
+}
+
+// typeName returns the name of the type of j, as returned by the .type()
+// item method.
+func typeName(j json.JSON) string {
+	switch j.Type() {
+	case json.NullJSONType:
+		return "null"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	case json.NumberJSONType:
+		return "number"
+	case json.StringJSONType:
+		return "string"
+	case json.ArrayJSONType:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// formatKey formats an object key for use in error messages.
+func formatKey(key string) string {
+	return json.FromString(key).String()
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	for _, tc := range []struct {
+		target   string
+		path     string
+		vars     string
+		expected string
+	}{
+		{`{"a": 1}`, `$`, ``, `{"a": 1}`},
+		{`{"a": 1}`, `$.a`, ``, `1`},
+		{`{"a": 1}`, `$.b`, ``, ``},
+		{`{"a": [1, 2, 3]}`, `$.a[*]`, ``, `1 2 3`},
+		{`{"a": [1, 2, 3]}`, `$.a[1]`, ``, `2`},
+		{`{"a": [1, 2, 3]}`, `$.a[last]`, ``, `3`},
+		{`{"a": [1, 2, 3]}`, `$.a[0 to 1, last]`, ``, `1 2 3`},
+		{`{"a": [1, 2, 3]}`, `$.a[1.9]`, ``, `2`},
+		{`{"a": [1, 2, 3]}`, `$.a[5]`, ``, ``},
+		{`[{"a": 1}, {"a": 2}]`, `$.a`, ``, `1 2`},
+		{`[{"a": 1}, {"a": 2}]`, `strict $[*].a`, ``, `1 2`},
+		{`{"a": 1}`, `$[*]`, ``, `{"a": 1}`},
+		{`{"a": 1, "b": {"c": 2}}`, `$.*`, ``, `1 {"c": 2}`},
+		{`{"a": 1, "b": {"c": 2}}`, `$.**`, ``, `{"a": 1, "b": {"c": 2}} 1 {"c": 2} 2`},
+		{`{"a": 1, "b": {"c": 2}}`, `$.**{2}`, ``, `2`},
+		{`{"a": 1, "b": {"c": 2}}`, `$.**{last}`, ``, `1 2`},
+		{`{"a": 1, "b": {"c": 2}}`, `$.**.c`, ``, `2`},
+		{`[1, 2, 3, 4]`, `$[*] ? (@ > 2)`, ``, `3 4`},
+		{`[1, 2, 3, 4]`, `$[*] ? (@ > $min)`, `{"min": 3}`, `4`},
+		{`[1, "a", null]`, `$[*] ? (@ != 1)`, ``, `null`},
+		{`[1, "a", null]`, `$[*] ? (@ == null)`, ``, `null`},
+		{`["abc", "bcd"]`, `$[*] ? (@ starts with "b")`, ``, `"bcd"`},
+		{`["abc", "ABD"]`, `$[*] ? (@ like_regex "^ab" flag "i")`, ``, `"abc" "ABD"`},
+		{`[{"a": 1}, {"b": 2}]`, `$[*] ? (exists (@.a))`, ``, `{"a": 1}`},
+		{`[1, "a"]`, `$[*] ? ((@ > 0) is unknown)`, ``, `"a"`},
+		{`{"a": 2}`, `$.a + 3`, ``, `5`},
+		{`{"a": 2}`, `$.a * 3 - 1`, ``, `5`},
+		{`{"a": 2}`, `1 / 3`, ``, `0.33333333333333333333`},
+		{`{"a": 2}`, `7 % 3`, ``, `1`},
+		{`[1, -2]`, `-$[*]`, ``, `-1 2`},
+		{`[1, 2, 3]`, `$.size()`, ``, `3`},
+		{`{"a": 1}`, `$.size()`, ``, `1`},
+		{`[null, true, 1, "a", [], {}]`, `$[*].type()`, ``,
+			`"null" "boolean" "number" "string" "array" "object"`},
+		{`[1.5, -1.5]`, `$[*].floor()`, ``, `1 -2`},
+		{`[1.5, -1.5]`, `$[*].ceiling()`, ``, `2 -1`},
+		{`[1.5, -1.5]`, `$.abs()`, ``, `1.5 1.5`},
+		{`["1.5", 2]`, `$.double()`, ``, `1.5 2`},
+		{`{"a": 1, "b": [2]}`, `$.keyvalue()`, ``,
+			`{"id": 0, "key": "a", "value": 1} {"id": 0, "key": "b", "value": [2]}`},
+		{`[1, 2]`, `$[*] > 1`, ``, `true`},
+		{`[1, 2]`, `$[*] > 2`, ``, `false`},
+		{`[1, "a"]`, `strict $[*] > 0`, ``, `null`},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			var vars json.JSON
+			if tc.vars != "" {
+				vars, err = json.ParseJSON(tc.vars)
+				require.NoError(t, err)
+			}
+			res, err := Query(MustParse(tc.path), target, vars, false /* silent */)
+			require.NoError(t, err)
+			strs := make([]string, len(res))
+			for i := range res {
+				strs[i] = res[i].String()
+			}
+			assert.Equal(t, tc.expected, strings.Join(strs, " "))
+
+			// Exists has to agree with the result of Query.
+			exists, ok, err := Exists(MustParse(tc.path), target, vars, false /* silent */)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, len(res) > 0, exists)
+		})
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	for _, tc := range []struct {
+		target     string
+		path       string
+		expected   string
+		suppressed bool
+	}{
+		{`{"a": 1}`, `strict $.b`, `JSON object does not contain key "b"`, true},
+		{`1`, `strict $.a`, `jsonpath member accessor can only be applied to an object`, true},
+		{`1`, `strict $[*]`, `jsonpath wildcard array accessor can only be applied to an array`, true},
+		{`[1]`, `strict $[1]`, `jsonpath array subscript is out of bounds`, true},
+		{`1`, `strict $.*`, `jsonpath wildcard member accessor can only be applied to an object`, true},
+		{`1`, `strict $.size()`, `jsonpath item method .size() can only be applied to an array`, true},
+		{`"a"`, `$.floor()`, `jsonpath item method .floor() can only be applied to a numeric value`, true},
+		{`[1, 2]`, `$[*] + 1`, `left operand of jsonpath operator + is not a single numeric value`, true},
+		{`1`, `$ / 0`, `division by zero`, true},
+		{`"a"`, `-$`, `operand of unary jsonpath operator - is not a numeric value`, true},
+		{`1`, `$x`, `could not find jsonpath variable "x"`, false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			_, err = Query(MustParse(tc.path), target, nil /* vars */, false /* silent */)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+
+			res, err := Query(MustParse(tc.path), target, nil /* vars */, true /* silent */)
+			if tc.suppressed {
+				require.NoError(t, err)
+				assert.Empty(t, res)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		target string
+		path   string
+		match  bool
+		ok     bool
+	}{
+		{`{"a": 1}`, `$.a == 1`, true, true},
+		{`{"a": 1}`, `$.a == 2`, false, true},
+		{`{"a": 1}`, `$.a == "a"`, false, false},
+		{`{"a": true}`, `$.a`, true, true},
+		{`{"a": null}`, `$.a`, false, false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			match, ok, err := Match(MustParse(tc.path), target, nil /* vars */, false /* silent */)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.match, match)
+		})
+	}
+
+	target, err := json.ParseJSON(`{"a": 1}`)
+	require.NoError(t, err)
+	_, _, err = Match(MustParse(`$.a`), target, nil /* vars */, false /* silent */)
+	require.EqualError(t, err, "single boolean result is expected")
+	_, ok, err := Match(MustParse(`$.a`), target, nil /* vars */, true /* silent */)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,427 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, as supported by
+// the jsonpath type in Postgres. A Path is parsed from its textual form with
+// Parse, and can be evaluated against a JSON document with Query, Exists and
+// Match.
+//
+// See https://www.postgresql.org/docs/current/functions-json.html#FUNCTIONS-SQLJSON-PATH.
+package jsonpath
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// itemType is the type of a single item of a jsonpath expression.
+type itemType int
+
+const (
+	invalidItem itemType = iota
+
+	// Literals.
+	nullItem
+	boolItem
+	numericItem
+	stringItem
+
+	// Variables. rootItem is $, currentItem is @, variableItem is $name and
+	// lastItem is the last keyword within an array subscript.
+	rootItem
+	currentItem
+	variableItem
+	lastItem
+
+	// Accessors. keyItem is .key, anyKeyItem is .*, anyArrayItem is [*],
+	// indexArrayItem is [subscript, ...] and anyItem is .** with optional
+	// level bounds.
+	keyItem
+	anyKeyItem
+	anyArrayItem
+	indexArrayItem
+	anyItem
+
+	// filterItem is the ? (predicate) filter expression.
+	filterItem
+
+	// Item methods.
+	typeMethod
+	sizeMethod
+	doubleMethod
+	ceilingMethod
+	floorMethod
+	absMethod
+	keyValueMethod
+	datetimeMethod
+
+	// Arithmetic operators.
+	addItem
+	subItem
+	mulItem
+	divItem
+	modItem
+	plusItem
+	minusItem
+
+	// Predicates.
+	andItem
+	orItem
+	notItem
+	isUnknownItem
+	equalItem
+	notEqualItem
+	lessItem
+	lessOrEqualItem
+	greaterItem
+	greaterOrEqualItem
+	existsItem
+	startsWithItem
+	likeRegexItem
+)
+
+// maxLevel is used as the level bound of the .** accessor to denote the last
+// level, i.e. the leaves of the document.
+const maxLevel = -1
+
+// item is a single node within a parsed jsonpath expression. Accessors, item
+// methods and filters are chained together with next, so that $.a[*] is
+// represented as a rootItem followed by a keyItem and an anyArrayItem.
+type item struct {
+	typ itemType
+
+	// left and right are the operands of binary operators. Unary operators,
+	// filters and exists only use left.
+	left, right *item
+
+	// str is the key of a keyItem, the name of a variableItem, the value of a
+	// stringItem, the pattern of a likeRegexItem and the template of a
+	// datetimeMethod.
+	str string
+	// num is the value of a numericItem.
+	num apd.Decimal
+	// b is the value of a boolItem.
+	b bool
+
+	// subscripts are the subscripts of an indexArrayItem.
+	subscripts []subscript
+
+	// first and last are the level bounds of an anyItem. maxLevel denotes the
+	// last level.
+	first, last int
+
+	// flags are the flags of a likeRegexItem, and re is its compiled pattern.
+	flags string
+	re    *regexp.Regexp
+
+	next *item
+}
+
+// subscript is a single subscript of an array accessor. to is nil unless the
+// subscript is a range.
+type subscript struct {
+	from, to *item
+}
+
+// Path is a parsed jsonpath expression.
+type Path struct {
+	strict bool
+	root   *item
+}
+
+// Strict returns whether the path is evaluated in strict mode. Otherwise, it is
+// evaluated in lax mode.
+func (p *Path) Strict() bool {
+	return p.strict
+}
+
+// Keys returns the keys of the member accessors if the path is of the simple
+// form $.k1.k2...kn with n > 0, and ok=false otherwise.
+func (p *Path) Keys() (keys []string, ok bool) {
+	if p.root.typ != rootItem || p.root.next == nil {
+		return nil, false
+	}
+	for it := p.root.next; it != nil; it = it.next {
+		if it.typ != keyItem {
+			return nil, false
+		}
+		keys = append(keys, it.str)
+	}
+	return keys, true
+}
+
+// String returns the canonical textual representation of the path.
+func (p *Path) String() string {
+	var buf bytes.Buffer
+	if p.strict {
+		buf.WriteString("strict ")
+	}
+	p.root.format(&buf, false /* inKey */, true /* parens */)
+	return buf.String()
+}
+
+// priority returns the binding priority of operators, which is used to decide
+// whether an operand has to be wrapped in parentheses when it is formatted.
+func (t itemType) priority() int {
+	switch t {
+	case orItem:
+		return 0
+	case andItem:
+		return 1
+	case equalItem, notEqualItem, lessItem, lessOrEqualItem, greaterItem,
+		greaterOrEqualItem, startsWithItem:
+		return 2
+	case addItem, subItem:
+		return 3
+	case mulItem, divItem, modItem:
+		return 4
+	case plusItem, minusItem:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// operatorName returns the textual form of an operator.
+func (t itemType) operatorName() string {
+	switch t {
+	case andItem:
+		return "&&"
+	case orItem:
+		return "||"
+	case equalItem:
+		return "=="
+	case notEqualItem:
+		return "!="
+	case lessItem:
+		return "<"
+	case lessOrEqualItem:
+		return "<="
+	case greaterItem:
+		return ">"
+	case greaterOrEqualItem:
+		return ">="
+	case addItem, plusItem:
+		return "+"
+	case subItem, minusItem:
+		return "-"
+	case mulItem:
+		return "*"
+	case divItem:
+		return "/"
+	case modItem:
+		return "%"
+	case startsWithItem:
+		return "starts with"
+	case likeRegexItem:
+		return "like_regex"
+	case typeMethod:
+		return "type"
+	case sizeMethod:
+		return "size"
+	case doubleMethod:
+		return "double"
+	case ceilingMethod:
+		return "ceiling"
+	case floorMethod:
+		return "floor"
+	case absMethod:
+		return "abs"
+	case keyValueMethod:
+		return "keyvalue"
+	case datetimeMethod:
+		return "datetime"
+	}
+	panic(errors.AssertionFailedf("unexpected jsonpath item type %d", t))
+}
+
+// isBinaryOperator returns whether the item type is an operator with two
+// operands.
+func (t itemType) isBinaryOperator() bool {
+	switch t {
+	case andItem, orItem, equalItem, notEqualItem, lessItem, lessOrEqualItem,
+		greaterItem, greaterOrEqualItem, addItem, subItem, mulItem, divItem,
+		modItem, startsWithItem:
+		return true
+	}
+	return false
+}
+
+// isPredicate returns whether the item type evaluates to a boolean.
+func (t itemType) isPredicate() bool {
+	switch t {
+	case andItem, orItem, notItem, isUnknownItem, equalItem, notEqualItem,
+		lessItem, lessOrEqualItem, greaterItem, greaterOrEqualItem, existsItem,
+		startsWithItem, likeRegexItem:
+		return true
+	}
+	return false
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	json.FromString(s).Format(buf)
+}
+
+// format writes the textual representation of the item and the items chained
+// to it to buf. inKey is true if the item follows another item, in which case
+// key accessors are prefixed with a dot. parens is true if an operator has to
+// be wrapped in parentheses.
+func (it *item) format(buf *bytes.Buffer, inKey bool, parens bool) {
+	// Operators that are followed by accessors always need parentheses, since
+	// the accessors would otherwise bind to the right operand.
+	parens = parens || it.next != nil
+	switch it.typ {
+	case nullItem:
+		buf.WriteString("null")
+	case boolItem:
+		if it.b {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case numericItem:
+		json.FromDecimal(it.num).Format(buf)
+	case stringItem:
+		writeJSONString(buf, it.str)
+	case rootItem:
+		buf.WriteString("$")
+	case currentItem:
+		buf.WriteString("@")
+	case variableItem:
+		buf.WriteString("$")
+		writeJSONString(buf, it.str)
+	case lastItem:
+		buf.WriteString("last")
+	case keyItem:
+		if inKey {
+			buf.WriteString(".")
+		}
+		writeJSONString(buf, it.str)
+	case anyKeyItem:
+		if inKey {
+			buf.WriteString(".")
+		}
+		buf.WriteString("*")
+	case anyArrayItem:
+		buf.WriteString("[*]")
+	case indexArrayItem:
+		buf.WriteString("[")
+		for i, s := range it.subscripts {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			s.from.format(buf, false /* inKey */, false /* parens */)
+			if s.to != nil {
+				buf.WriteString(" to ")
+				s.to.format(buf, false /* inKey */, false /* parens */)
+			}
+		}
+		buf.WriteString("]")
+	case anyItem:
+		if inKey {
+			buf.WriteString(".")
+		}
+		buf.WriteString("**")
+		switch {
+		case it.first == it.last:
+			buf.WriteString("{")
+			formatLevel(buf, it.first)
+			buf.WriteString("}")
+		case it.first == 0 && it.last == maxLevel:
+		default:
+			buf.WriteString("{")
+			formatLevel(buf, it.first)
+			buf.WriteString(" to ")
+			formatLevel(buf, it.last)
+			buf.WriteString("}")
+		}
+	case filterItem:
+		buf.WriteString("?(")
+		it.left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteString(")")
+	case typeMethod, sizeMethod, doubleMethod, ceilingMethod, floorMethod,
+		absMethod, keyValueMethod:
+		buf.WriteString(".")
+		buf.WriteString(it.typ.operatorName())
+		buf.WriteString("()")
+	case datetimeMethod:
+		buf.WriteString(".datetime(")
+		if it.str != "" {
+			writeJSONString(buf, it.str)
+		}
+		buf.WriteString(")")
+	case plusItem, minusItem:
+		if parens {
+			buf.WriteString("(")
+		}
+		buf.WriteString(it.typ.operatorName())
+		it.left.format(buf, false /* inKey */, it.left.typ.priority() <= it.typ.priority())
+		if parens {
+			buf.WriteString(")")
+		}
+	case notItem:
+		buf.WriteString("!(")
+		it.left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteString(")")
+	case isUnknownItem:
+		buf.WriteString("(")
+		it.left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteString(") is unknown")
+	case existsItem:
+		buf.WriteString("exists (")
+		it.left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteString(")")
+	case likeRegexItem:
+		if parens {
+			buf.WriteString("(")
+		}
+		it.left.format(buf, false /* inKey */, it.left.typ.priority() <= it.typ.priority())
+		buf.WriteString(" like_regex ")
+		writeJSONString(buf, it.str)
+		if it.flags != "" {
+			buf.WriteString(" flag ")
+			writeJSONString(buf, it.flags)
+		}
+		if parens {
+			buf.WriteString(")")
+		}
+	default:
+		if !it.typ.isBinaryOperator() {
+			panic(errors.AssertionFailedf("unexpected jsonpath item type %d", it.typ))
+		}
+		if parens {
+			buf.WriteString("(")
+		}
+		it.left.format(buf, false /* inKey */, it.left.typ.priority() <= it.typ.priority())
+		buf.WriteString(" ")
+		buf.WriteString(it.typ.operatorName())
+		buf.WriteString(" ")
+		it.right.format(buf, false /* inKey */, it.right.typ.priority() <= it.typ.priority())
+		if parens {
+			buf.WriteString(")")
+		}
+	}
+	if it.next != nil {
+		it.next.format(buf, true /* inKey */, true /* parens */)
+	}
+}
+
+func formatLevel(buf *bytes.Buffer, level int) {
+	if level == maxLevel {
+		buf.WriteString("last")
+	} else {
+		buf.WriteString(strconv.Itoa(level))
+	}
+}
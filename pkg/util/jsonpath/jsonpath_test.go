@@ -0,0 +1,128 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`strict $`, `strict $`},
+		{`lax $`, `$`},
+		{`$.a`, `$."a"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.b`, `$."a"."b"`},
+		{`$.*`, `$.*`},
+		{`$[*]`, `$[*]`},
+		{`$.a[*].b`, `$."a"[*]."b"`},
+		{`$[0]`, `$[0]`},
+		{`$[1, 2 to 4]`, `$[1,2 to 4]`},
+		{`$[last]`, `$[last]`},
+		{`$[last - 1]`, `$[last - 1]`},
+		{`$.**`, `$.**`},
+		{`$.**{2}`, `$.**{2}`},
+		{`$.**{1 to last}`, `$.**{1 to last}`},
+		{`$.**{last}`, `$.**{last}`},
+		{`$var`, `$"var"`},
+		{`$"var"`, `$"var"`},
+		{`1`, `1`},
+		{`-1`, `-1`},
+		{`1.5`, `1.5`},
+		{`1e2`, `1E+2`},
+		{`- $.a`, `(-$."a")`},
+		{`"a\"b"`, `"a\"b"`},
+		{`"A"`, `"A"`},
+		{`null`, `null`},
+		{`true`, `true`},
+		{`$.a + 1`, `($."a" + 1)`},
+		{`1 + 2 * 3`, `(1 + 2 * 3)`},
+		{`(1 + 2) * 3`, `((1 + 2) * 3)`},
+		{`($.a + 1).b`, `($."a" + 1)."b"`},
+		{`$ ? (@ > 1)`, `$?(@ > 1)`},
+		{`$.a ? (@.b == "x" && @.c < 2 || !(@.d == 1))`,
+			`$."a"?(@."b" == "x" && @."c" < 2 || !(@."d" == 1))`},
+		{`$ ? ((@ > 1) is unknown)`, `$?((@ > 1) is unknown)`},
+		{`$ ? (exists (@.a))`, `$?(exists (@."a"))`},
+		{`$ ? (@ starts with "a")`, `$?(@ starts with "a")`},
+		{`$ ? (@ like_regex "^a" flag "i")`, `$?(@ like_regex "^a" flag "i")`},
+		{`$ ? (@ <> 1)`, `$?(@ != 1)`},
+		{`$.a.size()`, `$."a".size()`},
+		{`$.type()`, `$.type()`},
+		{`$.double().floor().ceiling().abs()`, `$.double().floor().ceiling().abs()`},
+		{`$.keyvalue()`, `$.keyvalue()`},
+		{`$.datetime()`, `$.datetime()`},
+		{`$ == 1`, `($ == 1)`},
+		{`$.a == 1 && $.b == 2`, `($."a" == 1 && $."b" == 2)`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := Parse(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p.String())
+			// The canonical form has to parse to the same path.
+			p2, err := Parse(p.String())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p2.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{``, `invalid input syntax for type jsonpath: ""`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$ $`, `syntax error at or near "$" of jsonpath input`},
+		{`@`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$[`, `syntax error at end of jsonpath input`},
+		{`$ ? (@ like_regex "(")`, `invalid regular expression`},
+		{`$ ? (@ like_regex "a" flag "z")`, `unrecognized flag character "z" in LIKE_REGEX predicate`},
+		{`1a`, `trailing junk after numeric literal`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		keys  []string
+		ok    bool
+	}{
+		{`$.a`, []string{"a"}, true},
+		{`strict $.a."b c"`, []string{"a", "b c"}, true},
+		{`$`, nil, false},
+		{`$.a[*]`, nil, false},
+		{`$.a ? (@ > 1)`, nil, false},
+		{`$.a.size()`, nil, false},
+		{`$.a == 1`, nil, false},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			keys, ok := MustParse(tc.input).Keys()
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.keys, keys)
+		})
+	}
+}
@@ -0,0 +1,961 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// tokenType is the type of a lexed jsonpath token.
+type tokenType int
+
+const (
+	eofToken tokenType = iota
+	// identToken is an unquoted identifier, which includes keywords.
+	identToken
+	// stringToken is a double-quoted string.
+	stringToken
+	// numericToken is a numeric literal.
+	numericToken
+	// variableToken is a named variable, like $foo or $"foo".
+	variableToken
+	// punctToken is an operator or punctuation.
+	punctToken
+)
+
+type token struct {
+	typ tokenType
+	// val is the unescaped value of string and variable tokens, and the raw
+	// text of all other tokens.
+	val string
+	// raw is the text of the token in the input.
+	raw string
+}
+
+// punctuation lists the operators and punctuation of the jsonpath language,
+// longest first so that they can be matched greedily.
+var punctuation = []string{
+	"**", "==", "!=", "<>", "<=", ">=", "&&", "||",
+	"$", "@", ".", "[", "]", "(", ")", "{", "}", ",", "?", "!", "*", "+", "-",
+	"/", "%", "<", ">",
+}
+
+// isIdentRune returns whether r can be part of an unquoted identifier.
+func isIdentRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9') || r >= utf8.RuneSelf
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// lexer splits the textual form of a jsonpath expression into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) syntaxError(raw string) error {
+	if raw == "" {
+		return pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	}
+	return pgerror.Newf(pgcode.Syntax, "syntax error at or near %q of jsonpath input", raw)
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		switch l.input[l.pos] {
+		case ' ', '\t', '\n', '\r', '\f', '\v':
+			l.pos++
+			continue
+		}
+		break
+	}
+	if l.pos >= len(l.input) {
+		return token{typ: eofToken}, nil
+	}
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '"':
+		s, err := l.lexString()
+		if err != nil {
+			return token{}, err
+		}
+		return token{typ: stringToken, val: s, raw: l.input[start:l.pos]}, nil
+	case c == '$' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '"':
+		l.pos++
+		s, err := l.lexString()
+		if err != nil {
+			return token{}, err
+		}
+		return token{typ: variableToken, val: s, raw: l.input[start:l.pos]}, nil
+	case c == '$' && l.pos+1 < len(l.input) && isIdentRune(rune(l.input[l.pos+1])):
+		l.pos++
+		name := l.lexIdent()
+		return token{typ: variableToken, val: name, raw: l.input[start:l.pos]}, nil
+	case isDigit(c):
+		return l.lexNumber()
+	case isIdentRune(rune(c)):
+		ident := l.lexIdent()
+		return token{typ: identToken, val: ident, raw: ident}, nil
+	}
+	for _, p := range punctuation {
+		if strings.HasPrefix(l.input[l.pos:], p) {
+			l.pos += len(p)
+			return token{typ: punctToken, val: p, raw: p}, nil
+		}
+	}
+	_, size := utf8.DecodeRuneInString(l.input[l.pos:])
+	return token{}, l.syntaxError(l.input[l.pos : l.pos+size])
+}
+
+func (l *lexer) lexIdent() string {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !isIdentRune(r) {
+			break
+		}
+		l.pos += size
+	}
+	return l.input[start:l.pos]
+}
+
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	// A dot is only part of the number if it is followed by a digit, so that
+	// accessors can directly follow integer literals.
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' && isDigit(l.input[l.pos+1]) {
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos >= len(l.input) || !isDigit(l.input[l.pos]) {
+			return token{}, pgerror.Newf(pgcode.Syntax,
+				"invalid numeric literal at or near %q of jsonpath input", l.input[start:l.pos])
+		}
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) {
+		if r, _ := utf8.DecodeRuneInString(l.input[l.pos:]); isIdentRune(r) {
+			return token{}, pgerror.Newf(pgcode.Syntax,
+				"trailing junk after numeric literal at or near %q of jsonpath input", l.input[start:l.pos+1])
+		}
+	}
+	raw := l.input[start:l.pos]
+	return token{typ: numericToken, val: raw, raw: raw}, nil
+}
+
+// lexString lexes a double-quoted string, processing its escape sequences.
+func (l *lexer) lexString() (string, error) {
+	start := l.pos
+	// Skip the opening quote.
+	l.pos++
+	var buf strings.Builder
+	for {
+		if l.pos >= len(l.input) {
+			return "", pgerror.Newf(pgcode.Syntax,
+				"unterminated quoted string at or near %q of jsonpath input", l.input[start:])
+		}
+		c := l.input[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return buf.String(), nil
+		case '\\':
+			l.pos++
+			if l.pos >= len(l.input) {
+				continue
+			}
+			e := l.input[l.pos]
+			l.pos++
+			switch e {
+			case 'b':
+				buf.WriteByte('\b')
+			case 'f':
+				buf.WriteByte('\f')
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case 'v':
+				buf.WriteByte('\v')
+			case 'x':
+				r, err := l.lexHex(2, 2)
+				if err != nil {
+					return "", err
+				}
+				buf.WriteRune(r)
+			case 'u':
+				var r rune
+				var err error
+				if l.pos < len(l.input) && l.input[l.pos] == '{' {
+					l.pos++
+					r, err = l.lexHex(1, 6)
+					if err == nil {
+						if l.pos >= len(l.input) || l.input[l.pos] != '}' {
+							err = pgerror.New(pgcode.Syntax, "invalid Unicode escape sequence in jsonpath input")
+						} else {
+							l.pos++
+						}
+					}
+				} else {
+					r, err = l.lexHex(4, 4)
+				}
+				if err != nil {
+					return "", err
+				}
+				if r == 0 {
+					return "", pgerror.New(pgcode.UntranslatableCharacter,
+						"unsupported Unicode escape sequence in jsonpath input")
+				}
+				buf.WriteRune(r)
+			default:
+				// Any other escaped character, including \" and \\, stands for
+				// itself.
+				buf.WriteByte(e)
+			}
+		default:
+			buf.WriteByte(c)
+			l.pos++
+		}
+	}
+}
+
+// lexHex lexes between minLen and maxLen hexadecimal digits.
+func (l *lexer) lexHex(minLen, maxLen int) (rune, error) {
+	start := l.pos
+	for l.pos < len(l.input) && l.pos-start < maxLen && isHexDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos-start < minLen {
+		return 0, pgerror.New(pgcode.Syntax, "invalid hexadecimal character sequence in jsonpath input")
+	}
+	v, err := strconv.ParseUint(l.input[start:l.pos], 16, 32)
+	if err != nil || v > utf8.MaxRune {
+		return 0, pgerror.New(pgcode.Syntax, "invalid Unicode escape value in jsonpath input")
+	}
+	return rune(v), nil
+}
+
+func isHexDigit(b byte) bool {
+	return isDigit(b) || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+// parser is a recursive descent parser for the jsonpath language. The
+// precedence of operators, from least to most tightly binding, is:
+//
+//	||
+//	&&
+//	!
+//	== != <> < <= > >= starts with, like_regex
+//	+ - (binary)
+//	* / %
+//	+ - (unary)
+//	accessors, item methods and filters
+type parser struct {
+	lexer lexer
+	tok   token
+	// filterDepth is the number of filters that enclose the current position,
+	// which allows @ to be used.
+	filterDepth int
+	// subscriptDepth is the number of array subscripts that enclose the
+	// current position, which allows last to be used.
+	subscriptDepth int
+}
+
+// Parse parses the textual form of a jsonpath expression.
+func Parse(input string) (*Path, error) {
+	p := parser{lexer: lexer{input: input}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.typ == eofToken {
+		return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid input syntax for type jsonpath: %q", input)
+	}
+	path := &Path{}
+	if p.isKeyword("strict") || p.isKeyword("lax") {
+		path.strict = p.tok.val == "strict"
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.typ != eofToken {
+		return nil, p.syntaxError()
+	}
+	path.root = root
+	return path, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) syntaxError() error {
+	return p.lexer.syntaxError(p.tok.raw)
+}
+
+func (p *parser) isPunct(s string) bool {
+	return p.tok.typ == punctToken && p.tok.val == s
+}
+
+func (p *parser) isKeyword(s string) bool {
+	return p.tok.typ == identToken && p.tok.val == s
+}
+
+// expectPunct consumes the current token if it is the given punctuation, and
+// returns a syntax error otherwise.
+func (p *parser) expectPunct(s string) error {
+	if !p.isPunct(s) {
+		return p.syntaxError()
+	}
+	return p.advance()
+}
+
+// expectKeyword consumes the current token if it is the given keyword, and
+// returns a syntax error otherwise.
+func (p *parser) expectKeyword(s string) error {
+	if !p.isKeyword(s) {
+		return p.syntaxError()
+	}
+	return p.advance()
+}
+
+// isPredicate returns whether the given item is a predicate that is not
+// followed by any accessors, which means it evaluates to a boolean.
+func isPredicate(it *item) bool {
+	return it.typ.isPredicate() && it.next == nil
+}
+
+// expectPredicate returns a syntax error if the given item is not a
+// predicate.
+func (p *parser) expectPredicate(it *item) error {
+	if !isPredicate(it) {
+		return p.syntaxError()
+	}
+	return nil
+}
+
+// expectExpr returns a syntax error if the given item is a predicate.
+func (p *parser) expectExpr(it *item) error {
+	if isPredicate(it) {
+		return p.syntaxError()
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (*item, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("||") {
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right); err != nil {
+			return nil, err
+		}
+		left = &item{typ: orItem, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (*item, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("&&") {
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right); err != nil {
+			return nil, err
+		}
+		left = &item{typ: andItem, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (*item, error) {
+	if !p.isPunct("!") {
+		return p.parsePredicate()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	// The operand of ! must be a parenthesized predicate or exists.
+	if !p.isPunct("(") && !p.isKeyword("exists") {
+		return nil, p.syntaxError()
+	}
+	arg, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPredicate(arg); err != nil {
+		return nil, err
+	}
+	return &item{typ: notItem, left: arg}, nil
+}
+
+// comparisonOperators maps comparison operators to their item types.
+var comparisonOperators = map[string]itemType{
+	"==": equalItem,
+	"!=": notEqualItem,
+	"<>": notEqualItem,
+	"<":  lessItem,
+	"<=": lessOrEqualItem,
+	">":  greaterItem,
+	">=": greaterOrEqualItem,
+}
+
+// parsePredicate parses a comparison, starts with or like_regex predicate, or
+// an arithmetic expression.
+func (p *parser) parsePredicate() (*item, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.typ == punctToken {
+		if typ, ok := comparisonOperators[p.tok.val]; ok {
+			if err := p.expectExpr(left); err != nil {
+				return nil, err
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectExpr(right); err != nil {
+				return nil, err
+			}
+			return &item{typ: typ, left: left, right: right}, nil
+		}
+	}
+	switch {
+	case p.isKeyword("starts"):
+		if err := p.expectExpr(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("with"); err != nil {
+			return nil, err
+		}
+		var right *item
+		switch p.tok.typ {
+		case stringToken:
+			right = &item{typ: stringItem, str: p.tok.val}
+		case variableToken:
+			right = &item{typ: variableItem, str: p.tok.val}
+		default:
+			return nil, p.syntaxError()
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &item{typ: startsWithItem, left: left, right: right}, nil
+	case p.isKeyword("like_regex"):
+		if err := p.expectExpr(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.typ != stringToken {
+			return nil, p.syntaxError()
+		}
+		it := &item{typ: likeRegexItem, left: left, str: p.tok.val}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.isKeyword("flag") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.typ != stringToken {
+				return nil, p.syntaxError()
+			}
+			it.flags = p.tok.val
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		re, err := compileRegex(it.str, it.flags)
+		if err != nil {
+			return nil, err
+		}
+		it.re = re
+		return it, nil
+	}
+	return left, nil
+}
+
+// compileRegex compiles the pattern of a like_regex predicate with the given
+// flags.
+func compileRegex(pattern string, flags string) (*regexp.Regexp, error) {
+	var goFlags strings.Builder
+	literal := false
+	for _, f := range flags {
+		switch f {
+		case 'i', 's', 'm':
+			if !strings.ContainsRune(goFlags.String(), f) {
+				goFlags.WriteRune(f)
+			}
+		case 'q':
+			literal = true
+		case 'x':
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"invalid input syntax for type jsonpath: unrecognized flag character %q in LIKE_REGEX predicate",
+				string(f))
+		}
+	}
+	if literal {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if goFlags.Len() > 0 {
+		pattern = "(?" + goFlags.String() + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	return re, nil
+}
+
+// additiveOperators and multiplicativeOperators map arithmetic operators to
+// their item types.
+var additiveOperators = map[string]itemType{
+	"+": addItem,
+	"-": subItem,
+}
+
+var multiplicativeOperators = map[string]itemType{
+	"*": mulItem,
+	"/": divItem,
+	"%": modItem,
+}
+
+func (p *parser) parseAdditive() (*item, error) {
+	return p.parseBinary(additiveOperators, p.parseMultiplicative)
+}
+
+func (p *parser) parseMultiplicative() (*item, error) {
+	return p.parseBinary(multiplicativeOperators, p.parseUnary)
+}
+
+// parseBinary parses a left-associative sequence of the given arithmetic
+// operators, whose operands are parsed by parseOperand.
+func (p *parser) parseBinary(
+	operators map[string]itemType, parseOperand func() (*item, error),
+) (*item, error) {
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.typ == punctToken {
+		typ, ok := operators[p.tok.val]
+		if !ok {
+			break
+		}
+		if err := p.expectExpr(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectExpr(right); err != nil {
+			return nil, err
+		}
+		left = &item{typ: typ, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (*item, error) {
+	if p.isPunct("+") || p.isPunct("-") {
+		typ := plusItem
+		if p.tok.val == "-" {
+			typ = minusItem
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectExpr(arg); err != nil {
+			return nil, err
+		}
+		// Fold unary operators into numeric literals.
+		if arg.typ == numericItem && arg.next == nil {
+			if typ == minusItem {
+				arg.num.Neg(&arg.num)
+			}
+			return arg, nil
+		}
+		return &item{typ: typ, left: arg}, nil
+	}
+	return p.parseAccessorExpr()
+}
+
+// parseAccessorExpr parses a primary expression followed by any number of
+// accessors, item methods and filters.
+func (p *parser) parseAccessorExpr() (*item, error) {
+	first, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	last := first
+	for {
+		var accessor *item
+		switch {
+		case p.isPunct("."):
+			accessor, err = p.parseDotAccessor()
+		case p.isPunct("["):
+			accessor, err = p.parseArrayAccessor()
+		case p.isPunct("?"):
+			accessor, err = p.parseFilter()
+		default:
+			return first, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for last.next != nil {
+			last = last.next
+		}
+		last.next = accessor
+		last = accessor
+	}
+}
+
+func (p *parser) parsePrimary() (*item, error) {
+	var it *item
+	switch p.tok.typ {
+	case stringToken:
+		it = &item{typ: stringItem, str: p.tok.val}
+	case numericToken:
+		it = &item{typ: numericItem}
+		if _, _, err := it.num.SetString(p.tok.val); err != nil {
+			return nil, p.syntaxError()
+		}
+	case variableToken:
+		it = &item{typ: variableItem, str: p.tok.val}
+	case identToken:
+		switch p.tok.val {
+		case "null":
+			it = &item{typ: nullItem}
+		case "true", "false":
+			it = &item{typ: boolItem, b: p.tok.val == "true"}
+		case "last":
+			if p.subscriptDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			it = &item{typ: lastItem}
+		case "exists":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectExpr(arg); err != nil {
+				return nil, err
+			}
+			if !p.isPunct(")") {
+				return nil, p.syntaxError()
+			}
+			it = &item{typ: existsItem, left: arg}
+		default:
+			return nil, p.syntaxError()
+		}
+	case punctToken:
+		switch p.tok.val {
+		case "$":
+			it = &item{typ: rootItem}
+		case "@":
+			if p.filterDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			it = &item{typ: currentItem}
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isPunct(")") {
+				return nil, p.syntaxError()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if isPredicate(inner) && p.isKeyword("is") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if err := p.expectKeyword("unknown"); err != nil {
+					return nil, err
+				}
+				return &item{typ: isUnknownItem, left: inner}, nil
+			}
+			return inner, nil
+		default:
+			return nil, p.syntaxError()
+		}
+	default:
+		return nil, p.syntaxError()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// methods maps the names of item methods to their item types.
+var methods = map[string]itemType{
+	"type":     typeMethod,
+	"size":     sizeMethod,
+	"double":   doubleMethod,
+	"ceiling":  ceilingMethod,
+	"floor":    floorMethod,
+	"abs":      absMethod,
+	"keyvalue": keyValueMethod,
+	"datetime": datetimeMethod,
+}
+
+// parseDotAccessor parses an accessor that starts with a dot: a key accessor,
+// a wildcard member accessor, a recursive wildcard accessor or an item method.
+func (p *parser) parseDotAccessor() (*item, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	switch p.tok.typ {
+	case stringToken:
+		it := &item{typ: keyItem, str: p.tok.val}
+		return it, p.advance()
+	case identToken:
+		name := p.tok.val
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		typ, ok := methods[name]
+		if !ok || !p.isPunct("(") {
+			// Keywords and method names can be used as keys.
+			return &item{typ: keyItem, str: name}, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		it := &item{typ: typ}
+		if typ == datetimeMethod && p.tok.typ == stringToken {
+			it.str = p.tok.val
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		return it, p.expectPunct(")")
+	case punctToken:
+		switch p.tok.val {
+		case "*":
+			return &item{typ: anyKeyItem}, p.advance()
+		case "**":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return p.parseAnyLevels()
+		}
+	}
+	return nil, p.syntaxError()
+}
+
+// parseAnyLevels parses the optional level bounds of the .** accessor.
+func (p *parser) parseAnyLevels() (*item, error) {
+	it := &item{typ: anyItem, first: 0, last: maxLevel}
+	if !p.isPunct("{") {
+		return it, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	first, err := p.parseLevel()
+	if err != nil {
+		return nil, err
+	}
+	it.first, it.last = first, first
+	if p.isKeyword("to") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if it.last, err = p.parseLevel(); err != nil {
+			return nil, err
+		}
+	}
+	return it, p.expectPunct("}")
+}
+
+func (p *parser) parseLevel() (int, error) {
+	if p.isKeyword("last") {
+		return maxLevel, p.advance()
+	}
+	if p.tok.typ != numericToken {
+		return 0, p.syntaxError()
+	}
+	level, err := strconv.ParseInt(p.tok.val, 10, 32)
+	if err != nil || level > math.MaxInt32 {
+		return 0, p.syntaxError()
+	}
+	return int(level), p.advance()
+}
+
+// parseArrayAccessor parses a wildcard array accessor or a list of array
+// subscripts.
+func (p *parser) parseArrayAccessor() (*item, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("*") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &item{typ: anyArrayItem}, p.expectPunct("]")
+	}
+	p.subscriptDepth++
+	defer func() { p.subscriptDepth-- }()
+	it := &item{typ: indexArrayItem}
+	for {
+		from, err := p.parseSubscriptBound()
+		if err != nil {
+			return nil, err
+		}
+		s := subscript{from: from}
+		if p.isKeyword("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if s.to, err = p.parseSubscriptBound(); err != nil {
+				return nil, err
+			}
+		}
+		it.subscripts = append(it.subscripts, s)
+		if !p.isPunct(",") {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return it, p.expectPunct("]")
+}
+
+func (p *parser) parseSubscriptBound() (*item, error) {
+	bound, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return bound, p.expectExpr(bound)
+}
+
+// parseFilter parses a filter expression.
+func (p *parser) parseFilter() (*item, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	p.filterDepth++
+	defer func() { p.filterDepth-- }()
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPredicate(pred); err != nil {
+		return nil, err
+	}
+	return &item{typ: filterItem, left: pred}, p.expectPunct(")")
+}
+
+// MustParse parses the textual form of a jsonpath expression, panicking if it
+// is invalid. It is only intended for tests.
+func MustParse(input string) *Path {
+	p, err := Parse(input)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "invalid jsonpath %q", input))
+	}
+	return p
+}
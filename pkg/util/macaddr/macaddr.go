@@ -0,0 +1,254 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package macaddr contains logic for handling the macaddr and macaddr8 types.
+package macaddr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// MacAddr is a 6 byte MAC address. The address is stored in the 48 least
+// significant bits, so that the numeric order of MacAddrs matches the order of
+// their bytes.
+type MacAddr uint64
+
+// MacAddr8 is an 8 byte MAC address in EUI-64 format.
+type MacAddr8 uint64
+
+// mask48 selects the 48 bits of a MacAddr.
+const mask48 = MacAddr(1)<<48 - 1
+
+// MaxMacAddr is the largest MacAddr.
+const MaxMacAddr = mask48
+
+// MaxMacAddr8 is the largest MacAddr8.
+const MaxMacAddr8 = ^MacAddr8(0)
+
+// macAddrFormat is a format accepted for macaddr input. groups holds the
+// number of hex digits of each group, where 0 means that the group contains
+// the one or two hex digits of a single byte, and sep is the separator between
+// the groups.
+type macAddrFormat struct {
+	groups []int
+	sep    string
+}
+
+// macAddrFormats are the formats accepted for macaddr input, which are the same
+// that postgres accepts. For example, 08:00:2b:01:02:03, 08-00-2b-01-02-03,
+// 08002b:010203, 08002b-010203, 0800.2b01.0203, 0800-2b01-0203 and
+// 08002b010203 all specify the same address.
+var macAddrFormats = []macAddrFormat{
+	{groups: []int{0, 0, 0, 0, 0, 0}, sep: ":"},
+	{groups: []int{0, 0, 0, 0, 0, 0}, sep: "-"},
+	{groups: []int{6, 6}, sep: ":"},
+	{groups: []int{6, 6}, sep: "-"},
+	{groups: []int{4, 4, 4}, sep: "."},
+	{groups: []int{4, 4, 4}, sep: "-"},
+	{groups: []int{12}},
+}
+
+// parse attempts to parse s according to the format.
+func (f macAddrFormat) parse(s string) (MacAddr, bool) {
+	parts := []string{s}
+	if f.sep != "" {
+		parts = strings.Split(s, f.sep)
+	}
+	if len(parts) != len(f.groups) {
+		return 0, false
+	}
+	var m MacAddr
+	for i, part := range parts {
+		digits := f.groups[i]
+		if digits == 0 {
+			if len(part) < 1 || len(part) > 2 {
+				return 0, false
+			}
+			digits = 2
+		} else if len(part) != digits {
+			return 0, false
+		}
+		v, err := strconv.ParseUint(part, 16, 64)
+		if err != nil {
+			return 0, false
+		}
+		m = m<<(4*digits) | MacAddr(v)
+	}
+	return m, true
+}
+
+// ParseMacAddr parses a macaddr in any of the formats accepted by postgres.
+func ParseMacAddr(s string) (MacAddr, error) {
+	trimmed := strings.TrimSpace(s)
+	for _, f := range macAddrFormats {
+		if m, ok := f.parse(trimmed); ok {
+			return m, nil
+		}
+	}
+	return 0, pgerror.WithCandidateCode(
+		errors.Errorf("could not parse %q as macaddr", s),
+		pgcode.InvalidTextRepresentation)
+}
+
+// ParseMacAddr8 parses a macaddr8. The input is a sequence of 6 or 8 bytes,
+// each of which is written as two hex digits, optionally separated by a ':',
+// '-' or '.' separator that must be the same throughout the input. 6 byte
+// addresses are converted to EUI-64 format the same way as ToMacAddr8 does.
+func ParseMacAddr8(s string) (MacAddr8, error) {
+	syntaxErr := pgerror.WithCandidateCode(
+		errors.Errorf("could not parse %q as macaddr8", s),
+		pgcode.InvalidTextRepresentation)
+	trimmed := strings.TrimSpace(s)
+	var m MacAddr8
+	var sep byte
+	count := 0
+	for i := 0; i < len(trimmed); {
+		if i+2 > len(trimmed) || count == 8 {
+			return 0, syntaxErr
+		}
+		v, err := strconv.ParseUint(trimmed[i:i+2], 16, 8)
+		if err != nil {
+			return 0, syntaxErr
+		}
+		m = m<<8 | MacAddr8(v)
+		count++
+		i += 2
+		if i < len(trimmed) {
+			switch c := trimmed[i]; c {
+			case ':', '-', '.':
+				if sep == 0 {
+					sep = c
+				} else if sep != c {
+					return 0, syntaxErr
+				}
+				i++
+			}
+		}
+	}
+	switch count {
+	case 6:
+		return MacAddr(m).ToMacAddr8(), nil
+	case 8:
+		return m, nil
+	}
+	return 0, syntaxErr
+}
+
+// String formats the MacAddr as six colon separated bytes.
+func (m MacAddr) String() string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x",
+		byte(m>>40), byte(m>>32), byte(m>>24), byte(m>>16), byte(m>>8), byte(m))
+}
+
+// Compare returns -1, 0 or 1 depending on whether m is less than, equal to or
+// greater than other.
+func (m MacAddr) Compare(other MacAddr) int {
+	if m < other {
+		return -1
+	}
+	if m > other {
+		return 1
+	}
+	return 0
+}
+
+// Not returns the bitwise NOT of the MacAddr.
+func (m MacAddr) Not() MacAddr {
+	return ^m & mask48
+}
+
+// And returns the bitwise AND of two MacAddrs.
+func (m MacAddr) And(other MacAddr) MacAddr {
+	return m & other
+}
+
+// Or returns the bitwise OR of two MacAddrs.
+func (m MacAddr) Or(other MacAddr) MacAddr {
+	return m | other
+}
+
+// Trunc sets the last 3 bytes of the MacAddr to zero, which leaves only the
+// manufacturer prefix.
+func (m MacAddr) Trunc() MacAddr {
+	return m &^ (1<<24 - 1)
+}
+
+// ToMacAddr8 converts the MacAddr to EUI-64 format by inserting FF and FE as
+// the 4th and 5th bytes.
+func (m MacAddr) ToMacAddr8() MacAddr8 {
+	return MacAddr8(m>>24)<<40 | 0xFFFE<<24 | MacAddr8(m&(1<<24-1))
+}
+
+// String formats the MacAddr8 as eight colon separated bytes.
+func (m MacAddr8) String() string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x:%02x:%02x",
+		byte(m>>56), byte(m>>48), byte(m>>40), byte(m>>32),
+		byte(m>>24), byte(m>>16), byte(m>>8), byte(m))
+}
+
+// Compare returns -1, 0 or 1 depending on whether m is less than, equal to or
+// greater than other.
+func (m MacAddr8) Compare(other MacAddr8) int {
+	if m < other {
+		return -1
+	}
+	if m > other {
+		return 1
+	}
+	return 0
+}
+
+// Not returns the bitwise NOT of the MacAddr8.
+func (m MacAddr8) Not() MacAddr8 {
+	return ^m
+}
+
+// And returns the bitwise AND of two MacAddr8s.
+func (m MacAddr8) And(other MacAddr8) MacAddr8 {
+	return m & other
+}
+
+// Or returns the bitwise OR of two MacAddr8s.
+func (m MacAddr8) Or(other MacAddr8) MacAddr8 {
+	return m | other
+}
+
+// Trunc sets the last 5 bytes of the MacAddr8 to zero, which leaves only the
+// manufacturer prefix.
+func (m MacAddr8) Trunc() MacAddr8 {
+	return m &^ (1<<40 - 1)
+}
+
+// Set7Bit sets the 7th bit of the MacAddr8 to one, which creates what is known
+// as a modified EUI-64, for inclusion in an IPv6 address.
+func (m MacAddr8) Set7Bit() MacAddr8 {
+	return m | 0x02<<56
+}
+
+// ToMacAddr converts the MacAddr8 to a 6 byte MacAddr, which is only possible
+// if its 4th and 5th bytes are FF and FE.
+func (m MacAddr8) ToMacAddr() (MacAddr, error) {
+	if (m>>24)&0xFFFF != 0xFFFE {
+		return 0, errors.WithHint(
+			pgerror.WithCandidateCode(
+				errors.New("macaddr8 data out of range to convert to macaddr"),
+				pgcode.NumericValueOutOfRange),
+			"Only addresses that have FF and FE as values in the 4th and 5th bytes "+
+				"from the left, for example xx:xx:xx:ff:fe:xx:xx:xx, are eligible to be "+
+				"converted from macaddr8 to macaddr.")
+	}
+	return MacAddr(m>>40)<<24 | MacAddr(m&(1<<24-1)), nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package macaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMacAddr(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+		err bool
+	}{
+		{s: "08:00:2b:01:02:03", exp: "08:00:2b:01:02:03"},
+		{s: "08-00-2b-01-02-03", exp: "08:00:2b:01:02:03"},
+		{s: "08002b:010203", exp: "08:00:2b:01:02:03"},
+		{s: "08002b-010203", exp: "08:00:2b:01:02:03"},
+		{s: "0800.2b01.0203", exp: "08:00:2b:01:02:03"},
+		{s: "0800-2b01-0203", exp: "08:00:2b:01:02:03"},
+		{s: "08002b010203", exp: "08:00:2b:01:02:03"},
+		{s: "  08:00:2B:01:02:03  ", exp: "08:00:2b:01:02:03"},
+		{s: "8:0:2b:1:2:3", exp: "08:00:2b:01:02:03"},
+		{s: "ff:ff:ff:ff:ff:ff", exp: "ff:ff:ff:ff:ff:ff"},
+
+		{s: "", err: true},
+		{s: "08:00:2b:01:02", err: true},
+		{s: "08:00:2b:01:02:03:04", err: true},
+		{s: "08:00-2b:01:02:03", err: true},
+		{s: "08:00:2b:01:02:0g", err: true},
+		{s: "08:00:2b:01:02:100", err: true},
+		{s: "0800.2b01-0203", err: true},
+		{s: "08002b01020", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			m, err := ParseMacAddr(tc.s)
+			if tc.err {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "as macaddr")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, m.String())
+		})
+	}
+}
+
+func TestParseMacAddr8(t *testing.T) {
+	testCases := []struct {
+		s   string
+		exp string
+		err bool
+	}{
+		{s: "08:00:2b:01:02:03:04:05", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "08-00-2b-01-02-03-04-05", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "08002b:0102030405", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "08002b-0102030405", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "0800.2b01.0203.0405", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "0800-2b01-0203-0405", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "08002b01:02030405", exp: "08:00:2b:01:02:03:04:05"},
+		{s: "08002b0102030405", exp: "08:00:2b:01:02:03:04:05"},
+		{s: " 08:00:2B:01:02:03:04:05 ", exp: "08:00:2b:01:02:03:04:05"},
+		// 6 byte addresses are converted to EUI-64.
+		{s: "08:00:2b:01:02:03", exp: "08:00:2b:ff:fe:01:02:03"},
+		{s: "08002b010203", exp: "08:00:2b:ff:fe:01:02:03"},
+
+		{s: "", err: true},
+		{s: "08:00:2b:01:02:03:04", err: true},
+		{s: "08:00:2b:01:02:03:04:05:06", err: true},
+		{s: "08:00-2b:01:02:03:04:05", err: true},
+		{s: "8:00:2b:01:02:03:04:05", err: true},
+		{s: "08:00:2b:01:02:03:04:0g", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			m, err := ParseMacAddr8(tc.s)
+			if tc.err {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "as macaddr8")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, m.String())
+		})
+	}
+}
+
+func TestMacAddrOperations(t *testing.T) {
+	m, err := ParseMacAddr("12:34:56:78:90:ab")
+	require.NoError(t, err)
+	other, err := ParseMacAddr("ff:00:ff:00:ff:00")
+	require.NoError(t, err)
+
+	require.Equal(t, "12:34:56:00:00:00", m.Trunc().String())
+	require.Equal(t, "ed:cb:a9:87:6f:54", m.Not().String())
+	require.Equal(t, "12:00:56:00:90:00", m.And(other).String())
+	require.Equal(t, "ff:34:ff:78:ff:ab", m.Or(other).String())
+	require.Equal(t, -1, m.Compare(other))
+	require.Equal(t, 0, m.Compare(m))
+	require.Equal(t, 1, other.Compare(m))
+	require.Equal(t, "12:34:56:ff:fe:78:90:ab", m.ToMacAddr8().String())
+}
+
+func TestMacAddr8Operations(t *testing.T) {
+	m, err := ParseMacAddr8("12:34:56:78:90:ab:cd:ef")
+	require.NoError(t, err)
+	other, err := ParseMacAddr8("ff:00:ff:00:ff:00:ff:00")
+	require.NoError(t, err)
+
+	require.Equal(t, "12:34:56:00:00:00:00:00", m.Trunc().String())
+	require.Equal(t, "ed:cb:a9:87:6f:54:32:10", m.Not().String())
+	require.Equal(t, "12:00:56:00:90:00:cd:00", m.And(other).String())
+	require.Equal(t, "ff:34:ff:78:ff:ab:ff:ef", m.Or(other).String())
+	require.Equal(t, "12:34:56:78:90:ab:cd:ef", m.Set7Bit().String())
+	require.Equal(t, "ff:00:ff:00:ff:00:ff:00", other.Set7Bit().String())
+	require.Equal(t, "0a:00:2b:01:02:03:04:05", MacAddr8(0x08002b0102030405).Set7Bit().String())
+	require.Equal(t, -1, m.Compare(other))
+	require.Equal(t, 1, other.Compare(m))
+
+	_, err = m.ToMacAddr()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "macaddr8 data out of range to convert to macaddr")
+
+	eui, err := ParseMacAddr8("08:00:2b:ff:fe:01:02:03")
+	require.NoError(t, err)
+	m6, err := eui.ToMacAddr()
+	require.NoError(t, err)
+	require.Equal(t, "08:00:2b:01:02:03", m6.String())
+}
@@ -128,6 +128,24 @@ func (iNetDecoder) decode(v parquet.ByteArray) (tree.Datum, error) {
 	return tree.ParseDIPAddrFromINetString(string(v))
 }
 
+type cIDRDecoder struct{}
+
+func (cIDRDecoder) decode(v parquet.ByteArray) (tree.Datum, error) {
+	return tree.ParseDCIDR(string(v))
+}
+
+type macAddrDecoder struct{}
+
+func (macAddrDecoder) decode(v parquet.ByteArray) (tree.Datum, error) {
+	return tree.ParseDMacAddr(string(v))
+}
+
+type macAddr8Decoder struct{}
+
+func (macAddr8Decoder) decode(v parquet.ByteArray) (tree.Datum, error) {
+	return tree.ParseDMacAddr8(string(v))
+}
+
 type jsonDecoder struct{}
 
 func (jsonDecoder) decode(v parquet.ByteArray) (tree.Datum, error) {
@@ -274,6 +292,12 @@ func decoderFromFamilyAndType(typOid oid.Oid, family types.Family) (decoder, err
 		return uUIDDecoder{}, nil
 	case types.INetFamily:
 		return iNetDecoder{}, nil
+	case types.CIDRFamily:
+		return cIDRDecoder{}, nil
+	case types.MacAddrFamily:
+		return macAddrDecoder{}, nil
+	case types.MacAddr8Family:
+		return macAddr8Decoder{}, nil
 	case types.JsonFamily:
 		return jsonDecoder{}, nil
 	case types.BitFamily:
@@ -328,6 +352,9 @@ func init() {
 	var _, _ = timestampTZDecoder{}.decode(parquet.ByteArray{})
 	var _, _ = uUIDDecoder{}.decode(parquet.FixedLenByteArray{})
 	var _, _ = iNetDecoder{}.decode(parquet.ByteArray{})
+	var _, _ = cIDRDecoder{}.decode(parquet.ByteArray{})
+	var _, _ = macAddrDecoder{}.decode(parquet.ByteArray{})
+	var _, _ = macAddr8Decoder{}.decode(parquet.ByteArray{})
 	var _, _ = jsonDecoder{}.decode(parquet.ByteArray{})
 	var _, _ = bitDecoder{}.decode(parquet.ByteArray{})
 	var _, _ = bytesDecoder{}.decode(parquet.ByteArray{})
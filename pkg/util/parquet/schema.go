@@ -226,6 +226,33 @@ func makeColumn(colName string, typ *types.T, repetitions parquet.Repetition) (d
 		}
 		result.colWriter = scalarWriter(writeINet)
 		return result, nil
+	case types.CIDRFamily:
+		result.node, err = schema.NewPrimitiveNodeLogical(colName,
+			repetitions, schema.StringLogicalType{}, parquet.Types.ByteArray,
+			defaultTypeLength, defaultSchemaFieldID)
+		if err != nil {
+			return datumColumn{}, err
+		}
+		result.colWriter = scalarWriter(writeCIDR)
+		return result, nil
+	case types.MacAddrFamily:
+		result.node, err = schema.NewPrimitiveNodeLogical(colName,
+			repetitions, schema.StringLogicalType{}, parquet.Types.ByteArray,
+			defaultTypeLength, defaultSchemaFieldID)
+		if err != nil {
+			return datumColumn{}, err
+		}
+		result.colWriter = scalarWriter(writeMacAddr)
+		return result, nil
+	case types.MacAddr8Family:
+		result.node, err = schema.NewPrimitiveNodeLogical(colName,
+			repetitions, schema.StringLogicalType{}, parquet.Types.ByteArray,
+			defaultTypeLength, defaultSchemaFieldID)
+		if err != nil {
+			return datumColumn{}, err
+		}
+		result.colWriter = scalarWriter(writeMacAddr8)
+		return result, nil
 	case types.JsonFamily:
 		result.node, err = schema.NewPrimitiveNodeLogical(colName,
 			repetitions, schema.JSONLogicalType{}, parquet.Types.ByteArray,
@@ -457,6 +457,57 @@ func writeINet(
 	return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
 }
 
+func writeCIDR(
+	d tree.Datum, w file.ColumnChunkWriter, a *batchAlloc, defLevels, repLevels []int16,
+) error {
+	if d == tree.DNull {
+		return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
+	}
+	_, ok := tree.AsDCIDR(d)
+	if !ok {
+		return pgerror.Newf(pgcode.DatatypeMismatch, "expected DCIDR, found %T", d)
+	}
+
+	if err := formatDatum(d, a); err != nil {
+		return err
+	}
+	return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
+}
+
+func writeMacAddr(
+	d tree.Datum, w file.ColumnChunkWriter, a *batchAlloc, defLevels, repLevels []int16,
+) error {
+	if d == tree.DNull {
+		return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
+	}
+	_, ok := tree.AsDMacAddr(d)
+	if !ok {
+		return pgerror.Newf(pgcode.DatatypeMismatch, "expected DMacAddr, found %T", d)
+	}
+
+	if err := formatDatum(d, a); err != nil {
+		return err
+	}
+	return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
+}
+
+func writeMacAddr8(
+	d tree.Datum, w file.ColumnChunkWriter, a *batchAlloc, defLevels, repLevels []int16,
+) error {
+	if d == tree.DNull {
+		return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
+	}
+	_, ok := tree.AsDMacAddr8(d)
+	if !ok {
+		return pgerror.Newf(pgcode.DatatypeMismatch, "expected DMacAddr8, found %T", d)
+	}
+
+	if err := formatDatum(d, a); err != nil {
+		return err
+	}
+	return writeBatch[parquet.ByteArray](w, a.byteArrayBatch[:], defLevels, repLevels)
+}
+
 func writeJSON(
 	d tree.Datum, w file.ColumnChunkWriter, a *batchAlloc, defLevels, repLevels []int16,
 ) error {
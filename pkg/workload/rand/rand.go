@@ -419,6 +419,16 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return d.UUID, nil
 	case *tree.DIPAddr:
 		return d.IPAddr.String(), nil
+	case *tree.DCIDR:
+		return d.IPAddr.CIDRString(), nil
+	case *tree.DMacAddr:
+		return d.MacAddr.String(), nil
+	case *tree.DMacAddr8:
+		return d.MacAddr8.String(), nil
+	case *tree.DGeometric:
+		return d.Shape.String(), nil
+	case *tree.DRange, *tree.DMultiRange:
+		return tree.AsStringWithFlags(d, tree.FmtPgwireText), nil
 	case *tree.DJSON:
 		return d.JSON.String(), nil
 	case *tree.DTimeTZ: